@@ -25,6 +25,12 @@ const (
 	// ApplicationV1_4_2 is the capabilties string for standard new non-backwards compatible fabric v1.4.2 application capabilities.
 	ApplicationV1_4_2 = "V1_4_2"
 
+	// ApplicationV1_4_3 is the capabilties string for standard new non-backwards compatible fabric v1.4.3 application capabilities.
+	ApplicationV1_4_3 = "V1_4_3"
+
+	// ApplicationV2_0 is the capabilties string for standard new non-backwards compatible fabric v2.0 application capabilities.
+	ApplicationV2_0 = "V2_0"
+
 	// ApplicationPvtDataExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationPvtDataExperimental = "V1_1_PVTDATA_EXPERIMENTAL"
 
@@ -39,6 +45,8 @@ type ApplicationProvider struct {
 	v12                    bool
 	v13                    bool
 	v142                   bool
+	v143                   bool
+	v20                    bool
 	v11PvtDataExperimental bool
 }
 
@@ -50,6 +58,8 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v12 = capabilities[ApplicationV1_2]
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v142 = capabilities[ApplicationV1_4_2]
+	_, ap.v143 = capabilities[ApplicationV1_4_3]
+	_, ap.v20 = capabilities[ApplicationV2_0]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
 	return ap
 }
@@ -61,44 +71,44 @@ func (ap *ApplicationProvider) Type() string {
 
 // ACLs returns whether ACLs may be specified in the channel application config
 func (ap *ApplicationProvider) ACLs() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // ForbidDuplicateTXIdInBlock specifies whether two transactions with the same TXId are permitted
 // in the same block or whether we mark the second one as TxValidationCode_DUPLICATE_TXID
 func (ap *ApplicationProvider) ForbidDuplicateTXIdInBlock() bool {
-	return ap.v11 || ap.v12 || ap.v13 || ap.v142
+	return ap.v11 || ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // PrivateChannelData returns true if support for private channel data (a.k.a. collections) is enabled.
 // In v1.1, the private channel data is experimental and has to be enabled explicitly.
 // In v1.2, the private channel data is enabled by default.
 func (ap *ApplicationProvider) PrivateChannelData() bool {
-	return ap.v11PvtDataExperimental || ap.v12 || ap.v13 || ap.v142
+	return ap.v11PvtDataExperimental || ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // CollectionUpgrade returns true if this channel is configured to allow updates to
 // existing collection or add new collections through chaincode upgrade (as introduced in v1.2)
 func (ap ApplicationProvider) CollectionUpgrade() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // V1_1Validation returns true is this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.1).
 func (ap *ApplicationProvider) V1_1Validation() bool {
-	return ap.v11 || ap.v12 || ap.v13 || ap.v142
+	return ap.v11 || ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // V1_2Validation returns true if this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.2).
 func (ap *ApplicationProvider) V1_2Validation() bool {
-	return ap.v12 || ap.v13 || ap.v142
+	return ap.v12 || ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // V1_3Validation returns true if this channel is configured to perform stricter validation
 // of transactions (as introduced in v1.3).
 func (ap *ApplicationProvider) V1_3Validation() bool {
-	return ap.v13 || ap.v142
+	return ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // MetadataLifecycle indicates whether the peer should use the deprecated and problematic
@@ -111,7 +121,7 @@ func (ap *ApplicationProvider) MetadataLifecycle() bool {
 // KeyLevelEndorsement returns true if this channel supports endorsement
 // policies expressible at a ledger key granularity, as described in FAB-8812
 func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
-	return ap.v13 || ap.v142
+	return ap.v13 || ap.v142 || ap.v143 || ap.v20
 }
 
 // There is no fabtoken support in v1.4, so always return false
@@ -122,7 +132,28 @@ func (ap *ApplicationProvider) FabToken() bool {
 // StorePvtDataOfInvalidTx returns true if the peer needs to store
 // the pvtData of invalid transactions.
 func (ap *ApplicationProvider) StorePvtDataOfInvalidTx() bool {
-	return ap.v142
+	return ap.v142 || ap.v143 || ap.v20
+}
+
+// WasmChaincode returns true if chaincode compiled to WebAssembly may be
+// instantiated on this channel with the legacy lifecycle. Peers which can't
+// execute it would otherwise invalidate its deployment.
+func (ap *ApplicationProvider) WasmChaincode() bool {
+	return ap.v143 || ap.v20
+}
+
+// ErasureCodedCollections returns true if the erasure coding configuration of
+// collections is validated when chaincodes are instantiated or upgraded. Peers
+// which don't know the configuration would otherwise accept what others reject.
+func (ap *ApplicationProvider) ErasureCodedCollections() bool {
+	return ap.v143 || ap.v20
+}
+
+// LifecycleV20 returns true if chaincodes may be defined on this channel
+// through the lifecycle SCC, whose definitions then take precedence over
+// the ones of lscc when validating and committing transactions.
+func (ap *ApplicationProvider) LifecycleV20() bool {
+	return ap.v20
 }
 
 // HasCapability returns true if the capability is supported by this binary.
@@ -137,6 +168,10 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationV1_4_2:
 		return true
+	case ApplicationV1_4_3:
+		return true
+	case ApplicationV2_0:
+		return true
 	case ApplicationPvtDataExperimental:
 		return true
 	case ApplicationResourcesTreeExperimental:
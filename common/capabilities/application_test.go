@@ -69,6 +69,46 @@ func TestApplicationV142(t *testing.T) {
 	assert.True(t, ap.ACLs())
 	assert.True(t, ap.CollectionUpgrade())
 	assert.True(t, ap.PrivateChannelData())
+	assert.False(t, ap.WasmChaincode())
+	assert.False(t, ap.ErasureCodedCollections())
+}
+
+func TestApplicationV143(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_4_3: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.StorePvtDataOfInvalidTx())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.True(t, ap.V1_1Validation())
+	assert.True(t, ap.V1_2Validation())
+	assert.True(t, ap.V1_3Validation())
+	assert.True(t, ap.KeyLevelEndorsement())
+	assert.True(t, ap.ACLs())
+	assert.True(t, ap.CollectionUpgrade())
+	assert.True(t, ap.PrivateChannelData())
+	assert.True(t, ap.WasmChaincode())
+	assert.True(t, ap.ErasureCodedCollections())
+	assert.False(t, ap.LifecycleV20())
+}
+
+func TestApplicationV20(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV2_0: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.StorePvtDataOfInvalidTx())
+	assert.True(t, ap.ForbidDuplicateTXIdInBlock())
+	assert.True(t, ap.V1_1Validation())
+	assert.True(t, ap.V1_2Validation())
+	assert.True(t, ap.V1_3Validation())
+	assert.True(t, ap.KeyLevelEndorsement())
+	assert.True(t, ap.ACLs())
+	assert.True(t, ap.CollectionUpgrade())
+	assert.True(t, ap.PrivateChannelData())
+	assert.True(t, ap.WasmChaincode())
+	assert.True(t, ap.ErasureCodedCollections())
+	assert.True(t, ap.LifecycleV20())
 }
 
 func TestApplicationPvtDataExperimental(t *testing.T) {
@@ -173,6 +173,18 @@ type ApplicationCapabilities interface {
 	// invalid transactions.
 	StorePvtDataOfInvalidTx() bool
 
+	// WasmChaincode returns true if chaincode compiled to WebAssembly may be
+	// instantiated with the legacy lifecycle.
+	WasmChaincode() bool
+
+	// ErasureCodedCollections returns true if the erasure coding configuration
+	// of collections is validated.
+	ErasureCodedCollections() bool
+
+	// LifecycleV20 returns true if chaincodes may be defined through the
+	// lifecycle SCC, whose definitions take precedence over the ones of lscc.
+	LifecycleV20() bool
+
 	// MetadataLifecycle indicates whether the peer should use the deprecated and problematic
 	// v1.0/v1.1 lifecycle, or whether it should use the newer per channel peer local chaincode
 	// metadata package approach planned for release with Fabric v1.2
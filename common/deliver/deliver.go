@@ -116,6 +116,13 @@ type Filtered interface {
 	IsFiltered() bool
 }
 
+// EventFilteredResponseSender is implemented by response senders which are
+// able to restrict the blocks they send to the chaincode events selected by
+// an EventFilter.
+type EventFilteredResponseSender interface {
+	SendEventFilteredBlockResponse(block *cb.Block, filter *EventFilter) error
+}
+
 // Server is a polymorphic structure to support generalization of this handler
 // to be able to deliver different type of responses.
 type Server struct {
@@ -240,6 +247,24 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_BAD_REQUEST, nil
 	}
 
+	eventFilter, err := NewEventFilter(seekInfo.ChaincodeEventFilters)
+	if err != nil {
+		logger.Warningf("[channel: %s] Received a deliver request from %s with invalid chaincode event filters: %s", chdr.ChannelId, addr, err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	var eventSender EventFilteredResponseSender
+	if eventFilter != nil {
+		var ok bool
+		if eventSender, ok = srv.ResponseSender.(EventFilteredResponseSender); !ok {
+			logger.Warningf("[channel: %s] Received a deliver request from %s with chaincode event filters, which are not supported", chdr.ChannelId, addr)
+			return cb.Status_BAD_REQUEST, nil
+		}
+		if seekInfo.ContentType == ab.SeekInfo_HEADER_WITH_SIG {
+			logger.Warningf("[channel: %s] Received a deliver request from %s with chaincode event filters for block headers only", chdr.ChannelId, addr)
+			return cb.Status_BAD_REQUEST, nil
+		}
+	}
+
 	erroredChan := chain.Errored()
 	if seekInfo.ErrorResponse == ab.SeekInfo_BEST_EFFORT {
 		// In a 'best effort' delivery of blocks, we should ignore consenter errors
@@ -329,9 +354,23 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			return cb.Status_FORBIDDEN, nil
 		}
 
-		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
+		logger.Debugf("[channel: %s] Delivering block [%d] for (%p) for %s", chdr.ChannelId, block.Header.Number, seekInfo, addr)
+
+		block2send := block
+		if seekInfo.ContentType == ab.SeekInfo_HEADER_WITH_SIG && !utils.IsConfigBlock(block) {
+			// The block may be shared with the ledger, hence it must not be modified
+			block2send = &cb.Block{
+				Header:   block.Header,
+				Metadata: block.Metadata,
+			}
+		}
 
-		if err := srv.SendBlockResponse(block); err != nil {
+		if eventFilter != nil {
+			err = eventSender.SendEventFilteredBlockResponse(block2send, eventFilter)
+		} else {
+			err = srv.SendBlockResponse(block2send)
+		}
+		if err != nil {
 			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
 			return cb.Status_INTERNAL_SERVER_ERROR, err
 		}
@@ -20,6 +20,12 @@ type filteredResponseSender interface {
 	deliver.Filtered
 }
 
+//go:generate counterfeiter -o mock/event_filtered_response_sender.go -fake-name EventFilteredResponseSender . eventFilteredResponseSender
+type eventFilteredResponseSender interface {
+	deliver.ResponseSender
+	deliver.EventFilteredResponseSender
+}
+
 func TestDeliver(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Deliver Suite")
@@ -469,6 +469,59 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when only the block headers are requested", func() {
+			var configBlock *cb.Block
+
+			BeforeEach(func() {
+				seekInfo.Stop = &ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{
+						Specified: &ab.SeekSpecified{Number: 101},
+					},
+				}
+				seekInfo.ContentType = ab.SeekInfo_HEADER_WITH_SIG
+
+				configEnvelope := &cb.Envelope{
+					Payload: utils.MarshalOrPanic(&cb.Payload{
+						Header: &cb.Header{
+							ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+								Type: int32(cb.HeaderType_CONFIG),
+							}),
+						},
+					}),
+				}
+				configBlock = &cb.Block{
+					Header:   &cb.BlockHeader{Number: 101},
+					Data:     &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(configEnvelope)}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}
+				fakeBlockIterator.NextReturnsOnCall(0, &cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Data:     &cb.BlockData{Data: [][]byte{[]byte("transaction")}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}, cb.Status_SUCCESS)
+				fakeBlockIterator.NextReturnsOnCall(1, configBlock, cb.Status_SUCCESS)
+			})
+
+			It("strips the data of the blocks but keeps their header and metadata", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+				Expect(fakeResponseSender.SendBlockResponseArgsForCall(0)).To(Equal(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}))
+			})
+
+			It("sends config blocks in full", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(2))
+				Expect(fakeResponseSender.SendBlockResponseArgsForCall(1)).To(Equal(configBlock))
+			})
+		})
+
 		Context("when filtered blocks are requested", func() {
 			var fakeResponseSender *mock.FilteredResponseSender
 
@@ -537,6 +590,90 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when chaincode event filters are requested", func() {
+			var fakeEventFilteredSender *mock.EventFilteredResponseSender
+
+			BeforeEach(func() {
+				seekInfo.ChaincodeEventFilters = []*ab.ChaincodeEventFilter{
+					{ChaincodeName: "mycc", EventNamePattern: "transfer-.*"},
+				}
+				fakeEventFilteredSender = &mock.EventFilteredResponseSender{}
+				server.ResponseSender = fakeEventFilteredSender
+			})
+
+			It("sends the blocks along with the event filter", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeEventFilteredSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeEventFilteredSender.SendEventFilteredBlockResponseCallCount()).To(Equal(1))
+				block, filter := fakeEventFilteredSender.SendEventFilteredBlockResponseArgsForCall(0)
+				Expect(block).To(Equal(&cb.Block{Header: &cb.BlockHeader{Number: 100}}))
+				Expect(filter.Match("mycc", "transfer-1")).To(BeTrue())
+				Expect(filter.Match("mycc", "mint")).To(BeFalse())
+				Expect(filter.Match("othercc", "transfer-1")).To(BeFalse())
+
+				Expect(fakeEventFilteredSender.SendStatusResponseCallCount()).To(Equal(1))
+				Expect(fakeEventFilteredSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+			})
+
+			Context("when the response sender does not support event filters", func() {
+				BeforeEach(func() {
+					server.ResponseSender = fakeResponseSender
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when an event filter is invalid", func() {
+				BeforeEach(func() {
+					seekInfo.ChaincodeEventFilters[0].EventNamePattern = "transfer-("
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeEventFilteredSender.SendEventFilteredBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeEventFilteredSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeEventFilteredSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when only the block headers are requested", func() {
+				BeforeEach(func() {
+					seekInfo.ContentType = ab.SeekInfo_HEADER_WITH_SIG
+				})
+
+				It("sends status bad request", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeEventFilteredSender.SendEventFilteredBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeEventFilteredSender.SendStatusResponseCallCount()).To(Equal(1))
+					Expect(fakeEventFilteredSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_BAD_REQUEST))
+				})
+			})
+
+			Context("when sending the block fails", func() {
+				BeforeEach(func() {
+					fakeEventFilteredSender.SendEventFilteredBlockResponseReturns(errors.New("send-fails"))
+				})
+
+				It("returns the error", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).To(MatchError("send-fails"))
+				})
+			})
+		})
+
 		Context("when sending the block fails", func() {
 			BeforeEach(func() {
 				fakeResponseSender.SendBlockResponseReturns(errors.New("send-fails"))
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"regexp"
+
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+)
+
+// EventFilter selects chaincode events by chaincode name and event name, as
+// requested by the chaincode event filters of a deliver request.
+type EventFilter struct {
+	selectors []eventSelector
+}
+
+type eventSelector struct {
+	chaincodeName string
+	eventName     *regexp.Regexp
+}
+
+// NewEventFilter creates the EventFilter of the chaincode event filters of
+// a deliver request. It returns nil when the request has no such filters.
+func NewEventFilter(filters []*ab.ChaincodeEventFilter) (*EventFilter, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+
+	eventFilter := &EventFilter{}
+	for i, filter := range filters {
+		if filter.ChaincodeName == "" {
+			return nil, errors.Errorf("chaincode event filter %d has no chaincode name", i)
+		}
+		selector := eventSelector{chaincodeName: filter.ChaincodeName}
+		if filter.EventNamePattern != "" {
+			eventName, err := regexp.Compile("^(?:" + filter.EventNamePattern + ")$")
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid event name pattern of chaincode %s", filter.ChaincodeName)
+			}
+			selector.eventName = eventName
+		}
+		eventFilter.selectors = append(eventFilter.selectors, selector)
+	}
+	return eventFilter, nil
+}
+
+// Match returns whether the event of the chaincode is selected by one of the
+// filters. A nil EventFilter selects all events.
+func (f *EventFilter) Match(chaincodeName, eventName string) bool {
+	if f == nil {
+		return true
+	}
+	for _, selector := range f.selectors {
+		if selector.chaincodeName != chaincodeName {
+			continue
+		}
+		if selector.eventName == nil || selector.eventName.MatchString(eventName) {
+			return true
+		}
+	}
+	return false
+}
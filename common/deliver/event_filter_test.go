@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver_test
+
+import (
+	"github.com/hyperledger/fabric/common/deliver"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventFilter", func() {
+	It("returns nil when there are no filters", func() {
+		filter, err := deliver.NewEventFilter(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filter).To(BeNil())
+		Expect(filter.Match("mycc", "event")).To(BeTrue())
+	})
+
+	It("selects the events matching one of the filters", func() {
+		filter, err := deliver.NewEventFilter([]*ab.ChaincodeEventFilter{
+			{ChaincodeName: "mycc", EventNamePattern: "transfer|mint"},
+			{ChaincodeName: "othercc"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(filter.Match("mycc", "transfer")).To(BeTrue())
+		Expect(filter.Match("mycc", "mint")).To(BeTrue())
+		Expect(filter.Match("mycc", "transfer-all")).To(BeFalse())
+		Expect(filter.Match("mycc", "burn")).To(BeFalse())
+		Expect(filter.Match("othercc", "anything")).To(BeTrue())
+		Expect(filter.Match("thirdcc", "transfer")).To(BeFalse())
+	})
+
+	It("rejects filters without a chaincode name", func() {
+		_, err := deliver.NewEventFilter([]*ab.ChaincodeEventFilter{
+			{ChaincodeName: "mycc"},
+			{EventNamePattern: "event"},
+		})
+		Expect(err).To(MatchError("chaincode event filter 1 has no chaincode name"))
+	})
+
+	It("rejects invalid event name patterns", func() {
+		_, err := deliver.NewEventFilter([]*ab.ChaincodeEventFilter{
+			{ChaincodeName: "mycc", EventNamePattern: "event("},
+		})
+		Expect(err).To(MatchError(ContainSubstring("invalid event name pattern of chaincode mycc")))
+	})
+})
@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package erasure implements a systematic Reed-Solomon erasure code over GF(2^8).
+// Data is split into data shards and complemented with parity shards,
+// such that any data shards of all the shards suffice to reconstruct it.
+package erasure
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MaxShards is the maximum total number of data and parity shards
+const MaxShards = 256
+
+var (
+	expTable [2 * 255]byte
+	logTable [256]byte
+)
+
+func init() {
+	// The field is generated by the primitive polynomial x^8 + x^4 + x^3 + x^2 + 1
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := 255; i < len(expTable); i++ {
+		expTable[i] = expTable[i-255]
+	}
+}
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func inv(a byte) byte {
+	return expTable[255-int(logTable[a])]
+}
+
+// Validate checks that data can be erasure-coded
+// with the given number of data and parity shards
+func Validate(dataShards, parityShards int) error {
+	if dataShards < 1 {
+		return errors.Errorf("number of data shards must be positive, got %d", dataShards)
+	}
+	if parityShards < 0 {
+		return errors.Errorf("number of parity shards cannot be negative, got %d", parityShards)
+	}
+	if dataShards+parityShards > MaxShards {
+		return errors.Errorf("total number of shards (%d) exceeds the maximum of %d", dataShards+parityShards, MaxShards)
+	}
+	return nil
+}
+
+// Encode splits the data into dataShards equally sized data shards,
+// padded with zeros if needed, and appends parityShards parity shards.
+func Encode(data []byte, dataShards, parityShards int) ([][]byte, error) {
+	if err := Validate(dataShards, parityShards); err != nil {
+		return nil, err
+	}
+	shardSize := (len(data) + dataShards - 1) / dataShards
+	padded := make([]byte, shardSize*dataShards)
+	copy(padded, data)
+
+	shards := make([][]byte, dataShards+parityShards)
+	for i := 0; i < dataShards; i++ {
+		shards[i] = padded[i*shardSize : (i+1)*shardSize]
+	}
+	for i := 0; i < parityShards; i++ {
+		parity := make([]byte, shardSize)
+		for j := 0; j < dataShards; j++ {
+			coefficient := encodingCoefficient(dataShards, dataShards+i, j)
+			for p, b := range shards[j] {
+				parity[p] ^= mul(coefficient, b)
+			}
+		}
+		shards[dataShards+i] = parity
+	}
+	return shards, nil
+}
+
+// Decode reconstructs data of the given size out of its shards, ordered by
+// their index. Missing shards are nil, and at least dataShards of the shards
+// must be present.
+func Decode(shards [][]byte, dataShards, parityShards int, size int) ([]byte, error) {
+	if err := Validate(dataShards, parityShards); err != nil {
+		return nil, err
+	}
+	if len(shards) != dataShards+parityShards {
+		return nil, errors.Errorf("expected %d shards, got %d", dataShards+parityShards, len(shards))
+	}
+
+	var indices []int
+	shardSize := -1
+	for i, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		if shardSize == -1 {
+			shardSize = len(shard)
+		}
+		if len(shard) != shardSize {
+			return nil, errors.Errorf("shard %d is of size %d, expected %d", i, len(shard), shardSize)
+		}
+		if len(indices) < dataShards {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) < dataShards {
+		return nil, errors.Errorf("%d shards are needed for reconstruction, only %d are available", dataShards, len(indices))
+	}
+	if size < 0 || size > shardSize*dataShards {
+		return nil, errors.Errorf("size %d exceeds the capacity of the shards (%d)", size, shardSize*dataShards)
+	}
+
+	// The rows of the encoding matrix that produced the available shards
+	matrix := make([][]byte, dataShards)
+	for r, index := range indices {
+		matrix[r] = make([]byte, dataShards)
+		for c := 0; c < dataShards; c++ {
+			matrix[r][c] = encodingCoefficient(dataShards, index, c)
+		}
+	}
+	decoding, err := invert(matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, shardSize*dataShards)
+	for j := 0; j < dataShards; j++ {
+		out := data[j*shardSize : (j+1)*shardSize]
+		for r, index := range indices {
+			coefficient := decoding[j][r]
+			if coefficient == 0 {
+				continue
+			}
+			for p, b := range shards[index] {
+				out[p] ^= mul(coefficient, b)
+			}
+		}
+	}
+	return data[:size], nil
+}
+
+// encodingCoefficient returns the coefficient of the given data shard in the
+// given row of the encoding matrix. The top of the matrix is the identity
+// matrix, and the bottom is a Cauchy matrix, hence any dataShards of its rows
+// form an invertible matrix.
+func encodingCoefficient(dataShards, row, column int) byte {
+	if row < dataShards {
+		if row == column {
+			return 1
+		}
+		return 0
+	}
+	return inv(byte(row) ^ byte(column))
+}
+
+// invert returns the inverse of the given square matrix,
+// using Gauss-Jordan elimination
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	work := make([][]byte, n)
+	for i := range matrix {
+		work[i] = make([]byte, 2*n)
+		copy(work[i], matrix[i])
+		work[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for r := col; r < n; r++ {
+			if work[r][col] != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot == -1 {
+			return nil, errors.New("matrix is singular")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		scale := inv(work[col][col])
+		for c := range work[col] {
+			work[col][c] = mul(work[col][c], scale)
+		}
+		for r := 0; r < n; r++ {
+			if r == col || work[r][col] == 0 {
+				continue
+			}
+			factor := work[r][col]
+			for c := range work[r] {
+				work[r][c] ^= mul(factor, work[col][c])
+			}
+		}
+	}
+
+	inverse := make([][]byte, n)
+	for i := range work {
+		inverse[i] = work[i][n:]
+	}
+	return inverse, nil
+}
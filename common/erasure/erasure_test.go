@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package erasure
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(1, 0))
+	assert.NoError(t, Validate(200, 56))
+	assert.EqualError(t, Validate(0, 2), "number of data shards must be positive, got 0")
+	assert.EqualError(t, Validate(2, -1), "number of parity shards cannot be negative, got -1")
+	assert.EqualError(t, Validate(200, 57), "total number of shards (257) exceeds the maximum of 256")
+}
+
+func TestEncodeDecodeAnySubset(t *testing.T) {
+	data := make([]byte, 1001)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	dataShards, parityShards := 3, 3
+	shards, err := Encode(data, dataShards, parityShards)
+	require.NoError(t, err)
+	require.Len(t, shards, dataShards+parityShards)
+	for _, shard := range shards {
+		assert.Len(t, shard, 334)
+	}
+
+	// Reconstruct out of every subset of the shards
+	// which has exactly dataShards shards
+	total := dataShards + parityShards
+	for mask := 0; mask < 1<<uint(total); mask++ {
+		available := make([][]byte, total)
+		count := 0
+		for i := 0; i < total; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				available[i] = shards[i]
+				count++
+			}
+		}
+		if count != dataShards {
+			continue
+		}
+		decoded, err := Decode(available, dataShards, parityShards, len(data))
+		require.NoError(t, err)
+		assert.Equal(t, data, decoded, "failed reconstructing out of shards %b", mask)
+	}
+}
+
+func TestEncodeDecodeEdgeCases(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		size         int
+		dataShards   int
+		parityShards int
+	}{
+		{name: "empty data", size: 0, dataShards: 2, parityShards: 1},
+		{name: "no parity", size: 10, dataShards: 4, parityShards: 0},
+		{name: "single data shard", size: 10, dataShards: 1, parityShards: 3},
+		{name: "maximum shards", size: 1000, dataShards: 200, parityShards: 56},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			data := make([]byte, test.size)
+			rand.Read(data)
+			shards, err := Encode(data, test.dataShards, test.parityShards)
+			require.NoError(t, err)
+
+			// Drop as many shards as possible, starting from the data shards
+			for i := 0; i < test.parityShards; i++ {
+				shards[i] = nil
+			}
+			decoded, err := Decode(shards, test.dataShards, test.parityShards, test.size)
+			require.NoError(t, err)
+			assert.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	shards, err := Encode([]byte("private data"), 2, 2)
+	require.NoError(t, err)
+
+	_, err = Decode(shards[:3], 2, 2, 12)
+	assert.EqualError(t, err, "expected 4 shards, got 3")
+
+	_, err = Decode([][]byte{nil, nil, nil, shards[3]}, 2, 2, 12)
+	assert.EqualError(t, err, "2 shards are needed for reconstruction, only 1 are available")
+
+	_, err = Decode([][]byte{shards[0], shards[1][:2], nil, nil}, 2, 2, 12)
+	assert.EqualError(t, err, "shard 1 is of size 2, expected 6")
+
+	_, err = Decode(shards, 2, 2, 13)
+	assert.EqualError(t, err, "size 13 exceeds the capacity of the shards (12)")
+
+	_, err = Decode(shards, 0, 2, 12)
+	assert.EqualError(t, err, "number of data shards must be positive, got 0")
+
+	_, err = Encode([]byte("private data"), 2, 255)
+	assert.EqualError(t, err, "total number of shards (257) exceeds the maximum of 256")
+}
@@ -38,6 +38,9 @@ var (
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrPruned is used to indicate that a block has been pruned from the store
+	ErrPruned = errors.New("block has been pruned")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
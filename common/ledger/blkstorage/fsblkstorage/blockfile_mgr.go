@@ -44,6 +44,8 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	pruneInfo         atomic.Value
+	pruneLock         sync.Mutex
 }
 
 /*
@@ -144,6 +146,12 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// or announcing the occurrence of an event.
 	mgr.cpInfoCond = sync.NewCond(&sync.Mutex{})
 
+	pInfo, err := mgr.loadPruneInfo()
+	if err != nil {
+		panic(fmt.Sprintf("Could not load prune info from db: %s", err))
+	}
+	mgr.pruneInfo.Store(pInfo)
+
 	// init BlockchainInfo for external API's
 	bcInfo := &common.BlockchainInfo{
 		Height:            0,
@@ -466,6 +474,10 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
 
+	if blockNum < mgr.getPruneInfo().firstBlock {
+		return mgr.retrieveRetainedBlock(blockNum)
+	}
+
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -491,6 +503,13 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
+	if blockNum < mgr.getPruneInfo().firstBlock {
+		block, err := mgr.retrieveRetainedBlock(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		return block.Header, nil
+	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -507,6 +526,9 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if startNum < mgr.getPruneInfo().firstBlock {
+		return nil, blkstorage.ErrPruned
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -552,9 +574,12 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.checkNotPruned(lp); err != nil {
+		return nil, err
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
-		return nil, err
+		return nil, mgr.prunedOr(lp, err)
 	}
 	defer stream.close()
 	b, err := stream.nextBlockBytes()
@@ -565,10 +590,13 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.checkNotPruned(lp); err != nil {
+		return nil, err
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
-		return nil, err
+		return nil, mgr.prunedOr(lp, err)
 	}
 	defer reader.close()
 	b, err := reader.read(lp.offset, lp.bytesLength)
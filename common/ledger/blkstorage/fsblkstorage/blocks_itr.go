@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 )
 
 // blocksItr - an iterator for iterating over a sequence of blocks
@@ -56,9 +57,12 @@ func (itr *blocksItr) initStream() error {
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
-	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
+	if err = itr.mgr.checkNotPruned(lp); err != nil {
 		return err
 	}
+	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
+		return itr.mgr.prunedOr(lp, err)
+	}
 	return nil
 }
 
@@ -86,6 +90,10 @@ func (itr *blocksItr) Next() (ledger.QueryResult, error) {
 	}
 	nextBlockBytes, err := itr.stream.nextBlockBytes()
 	if err != nil {
+		// the stream fails moving to the next block file if it was pruned meanwhile
+		if itr.blockNumToRetrieve < itr.mgr.firstBlockNumber() {
+			return nil, blkstorage.ErrPruned
+		}
 		return nil, err
 	}
 	itr.blockNumToRetrieve++
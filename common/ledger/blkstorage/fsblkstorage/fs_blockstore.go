@@ -102,6 +102,17 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// Prune deletes the oldest blocks which are not needed to satisfy the given
+// retention policy. The listed blocks remain retrievable by number once pruned.
+func (store *fsBlockStore) Prune(policy RetentionPolicy, retain []uint64) error {
+	return store.fileMgr.prune(policy, retain)
+}
+
+// FirstBlockNumber returns the number of the oldest block which has not been pruned
+func (store *fsBlockStore) FirstBlockNumber() uint64 {
+	return store.fileMgr.firstBlockNumber()
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
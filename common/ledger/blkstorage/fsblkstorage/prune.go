@@ -0,0 +1,275 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const retainedBlockKeyPrefix = 'r'
+
+var pruneInfoKey = []byte("pruneInfo")
+
+// RetentionPolicy bounds the blocks kept by a block store. A zero bound is
+// not enforced. Blocks are pruned a whole block file at a time, and only while
+// every enforced bound is still met by the remaining blocks, hence a store
+// keeps at least the configured number of blocks and bytes. The block file
+// currently being written to is never pruned.
+type RetentionPolicy struct {
+	// Blocks is the minimum number of most recent blocks to keep
+	Blocks uint64
+	// Bytes is the minimum number of bytes of most recent blocks to keep
+	Bytes uint64
+}
+
+// IsZero returns whether no bound is enforced by the policy
+func (p RetentionPolicy) IsZero() bool {
+	return p.Blocks == 0 && p.Bytes == 0
+}
+
+// pruneInfo tracks the block files which have been pruned
+type pruneInfo struct {
+	firstFileNum int
+	firstBlock   uint64
+}
+
+func (i *pruneInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstFileNum [%d]", i.firstFileNum)
+	}
+	if err := buffer.EncodeVarint(i.firstBlock); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the firstBlock [%d]", i.firstBlock)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *pruneInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	val, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileNum = int(val)
+	if i.firstBlock, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *pruneInfo) String() string {
+	return fmt.Sprintf("firstFileNum=[%d], firstBlock=[%d]", i.firstFileNum, i.firstBlock)
+}
+
+func constructRetainedBlockKey(blockNum uint64) []byte {
+	return append([]byte{retainedBlockKeyPrefix}, util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
+
+func (mgr *blockfileMgr) getPruneInfo() *pruneInfo {
+	return mgr.pruneInfo.Load().(*pruneInfo)
+}
+
+func (mgr *blockfileMgr) loadPruneInfo() (*pruneInfo, error) {
+	b, err := mgr.db.Get(pruneInfoKey)
+	if err != nil {
+		return nil, err
+	}
+	i := &pruneInfo{}
+	if b == nil {
+		return i, nil
+	}
+	if err = i.unmarshal(b); err != nil {
+		return nil, err
+	}
+	logger.Debugf("loaded pruneInfo:%s", i)
+	return i, nil
+}
+
+// firstBlockNumber returns the number of the oldest block which has not been
+// pruned. Retained blocks below it may still be retrieved by number.
+func (mgr *blockfileMgr) firstBlockNumber() uint64 {
+	return mgr.getPruneInfo().firstBlock
+}
+
+// retrieveRetainedBlock returns a block which was retained when the file
+// holding it was pruned.
+func (mgr *blockfileMgr) retrieveRetainedBlock(blockNum uint64) (*common.Block, error) {
+	b, err := mgr.db.Get(constructRetainedBlockKey(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, blkstorage.ErrPruned
+	}
+	return deserializeBlock(b)
+}
+
+// checkNotPruned returns ErrPruned if the given location lies in a block file
+// which has been pruned. The block indexes are not purged when pruning, hence
+// the locations they return are checked before the block files are read.
+func (mgr *blockfileMgr) checkNotPruned(lp *fileLocPointer) error {
+	if lp.fileSuffixNum < mgr.getPruneInfo().firstFileNum {
+		return blkstorage.ErrPruned
+	}
+	return nil
+}
+
+// prunedOr returns ErrPruned if the block file of the given location was
+// pruned after being checked, and err otherwise.
+func (mgr *blockfileMgr) prunedOr(lp *fileLocPointer, err error) error {
+	if mgr.checkNotPruned(lp) != nil {
+		return blkstorage.ErrPruned
+	}
+	return err
+}
+
+// fileFirstBlockNumber returns the number of the first block stored in the
+// given block file.
+func (mgr *blockfileMgr) fileFirstBlockNumber(fileNum int) (uint64, error) {
+	stream, err := newBlockfileStream(mgr.rootDir, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return 0, err
+	}
+	if blockBytes == nil {
+		return 0, errors.Errorf("block file [%d] is empty", fileNum)
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, err
+	}
+	return info.blockHeader.Number, nil
+}
+
+// prune deletes the oldest block files which are not needed to satisfy the
+// retention policy. The blocks listed in retain are copied out of the pruned
+// files, so that they remain retrievable by number, while the previously
+// retained blocks which are no longer listed are dropped. prune may be called
+// concurrently with addBlock, as the block file being written to is never pruned.
+func (mgr *blockfileMgr) prune(policy RetentionPolicy, retain []uint64) error {
+	if policy.IsZero() {
+		return nil
+	}
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	mgr.cpInfoCond.L.Lock()
+	current := mgr.cpInfo
+	mgr.cpInfoCond.L.Unlock()
+	if current.isChainEmpty {
+		return nil
+	}
+	height := current.lastBlockNumber + 1
+	pInfo := mgr.getPruneInfo()
+
+	sizes := make(map[int]uint64)
+	var totalBytes uint64
+	for fileNum := pInfo.firstFileNum; fileNum < current.latestFileChunkSuffixNum; fileNum++ {
+		exists, size, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errors.Errorf("block file [%d] does not exist", fileNum)
+		}
+		sizes[fileNum] = uint64(size)
+		totalBytes += uint64(size)
+	}
+	totalBytes += uint64(current.latestFileChunksize)
+
+	next := &pruneInfo{firstFileNum: pInfo.firstFileNum, firstBlock: pInfo.firstBlock}
+	for next.firstFileNum < current.latestFileChunkSuffixNum {
+		nextFirstBlock := height
+		if next.firstFileNum+1 < current.latestFileChunkSuffixNum || current.latestFileChunksize > 0 {
+			var err error
+			if nextFirstBlock, err = mgr.fileFirstBlockNumber(next.firstFileNum + 1); err != nil {
+				return err
+			}
+		}
+		if policy.Blocks > 0 && height-nextFirstBlock < policy.Blocks {
+			break
+		}
+		remainingBytes := totalBytes - sizes[next.firstFileNum]
+		if policy.Bytes > 0 && remainingBytes < policy.Bytes {
+			break
+		}
+		totalBytes = remainingBytes
+		next.firstFileNum++
+		next.firstBlock = nextFirstBlock
+	}
+	if next.firstFileNum == pInfo.firstFileNum {
+		return nil
+	}
+
+	logger.Infof("Pruning block files [%d] to [%d], holding blocks [%d] to [%d]",
+		pInfo.firstFileNum, next.firstFileNum-1, pInfo.firstBlock, next.firstBlock-1)
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, blockNum := range retain {
+		if blockNum < pInfo.firstBlock || blockNum >= next.firstBlock {
+			continue
+		}
+		block, err := mgr.retrieveBlockByNumber(blockNum)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error retrieving block [%d] to retain", blockNum))
+		}
+		blockBytes, _, err := serializeBlock(block)
+		if err != nil {
+			return err
+		}
+		batch.Put(constructRetainedBlockKey(blockNum), blockBytes)
+	}
+	if err := mgr.dropRetainedBlocks(batch, retain); err != nil {
+		return err
+	}
+	b, err := next.marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(pruneInfoKey, b)
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	mgr.pruneInfo.Store(next)
+
+	for fileNum := pInfo.firstFileNum; fileNum < next.firstFileNum; fileNum++ {
+		if err := os.Remove(deriveBlockfilePath(mgr.rootDir, fileNum)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing block file [%d]", fileNum)
+		}
+	}
+	return nil
+}
+
+// dropRetainedBlocks adds to the batch the deletion of the retained blocks
+// which are not listed in retain.
+func (mgr *blockfileMgr) dropRetainedBlocks(batch *leveldbhelper.UpdateBatch, retain []uint64) error {
+	keep := make(map[string]struct{})
+	for _, blockNum := range retain {
+		keep[string(constructRetainedBlockKey(blockNum))] = struct{}{}
+	}
+	itr := mgr.db.GetIterator([]byte{retainedBlockKeyPrefix}, []byte{retainedBlockKeyPrefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		key := append([]byte{}, itr.Key()...)
+		if _, ok := keep[string(key)]; !ok {
+			batch.Delete(key)
+		}
+	}
+	return itr.Error()
+}
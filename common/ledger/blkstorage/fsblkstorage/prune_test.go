@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneByBlocks(t *testing.T) {
+	// every block goes to its own file, block n being in file n+1
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 11)
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+
+	require.NoError(t, mgr.prune(RetentionPolicy{}, nil))
+	assert.Equal(t, uint64(0), mgr.firstBlockNumber())
+
+	require.NoError(t, mgr.prune(RetentionPolicy{Blocks: 3}, []uint64{2}))
+	assert.Equal(t, uint64(7), mgr.firstBlockNumber())
+	for fileNum := 0; fileNum <= 10; fileNum++ {
+		exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		require.NoError(t, err)
+		assert.Equal(t, fileNum >= 8, exists, "block file %d", fileNum)
+	}
+
+	block, err := mgr.retrieveBlockByNumber(2)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[2], block)
+	_, err = mgr.retrieveBlockByNumber(3)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveBlocks(6)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+
+	itr, err := mgr.retrieveBlocks(7)
+	require.NoError(t, err)
+	defer itr.Close()
+	for _, expected := range blocks[7:10] {
+		block, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, block)
+	}
+
+	// the retained block is dropped once no longer listed
+	blkfileMgrWrapper.addBlocks(blocks[10:])
+	require.NoError(t, mgr.prune(RetentionPolicy{Blocks: 3}, []uint64{10}))
+	assert.Equal(t, uint64(8), mgr.firstBlockNumber())
+	_, err = mgr.retrieveBlockByNumber(2)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+}
+
+func TestPruneByBytes(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+
+	var lastFilesBytes uint64
+	for fileNum := 9; fileNum <= 10; fileNum++ {
+		_, size, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		require.NoError(t, err)
+		lastFilesBytes += uint64(size)
+	}
+
+	require.NoError(t, mgr.prune(RetentionPolicy{Bytes: lastFilesBytes}, nil))
+	assert.Equal(t, uint64(8), mgr.firstBlockNumber())
+
+	// both bounds must be met
+	require.NoError(t, mgr.prune(RetentionPolicy{Blocks: 1, Bytes: lastFilesBytes}, nil))
+	assert.Equal(t, uint64(8), mgr.firstBlockNumber())
+}
+
+func TestPruneInfoPersisted(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	require.NoError(t, blkfileMgrWrapper.blockfileMgr.prune(RetentionPolicy{Blocks: 5}, []uint64{0}))
+	blkfileMgrWrapper.close()
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.Equal(t, uint64(5), mgr.firstBlockNumber())
+	assert.Equal(t, uint64(10), mgr.getBlockchainInfo().Height)
+	block, err := mgr.retrieveBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[0], block)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[5:], 5, nil)
+}
+
+func TestPrunedBlocksNotRetrievableThroughIndexes(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 1))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blkfileMgrWrapper.addBlocks(blocks)
+	itr, err := mgr.retrieveBlocks(2)
+	require.NoError(t, err)
+	defer itr.Close()
+	require.NoError(t, mgr.prune(RetentionPolicy{Blocks: 5}, []uint64{1}))
+	require.Equal(t, uint64(5), mgr.firstBlockNumber())
+
+	txID, err := putil.GetOrComputeTxIDFromEnvelope(blocks[3].Data.Data[0])
+	require.NoError(t, err)
+	_, err = mgr.retrieveBlockByHash(blocks[3].Header.Hash())
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveBlockByTxID(txID)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveTransactionByID(txID)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveTransactionByBlockNumTranNum(3, 0)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = mgr.retrieveBlockHeaderByNumber(3)
+	assert.Equal(t, blkstorage.ErrPruned, err)
+	_, err = itr.Next()
+	assert.Equal(t, blkstorage.ErrPruned, err)
+
+	header, err := mgr.retrieveBlockHeaderByNumber(1)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].Header, header)
+	block, err := mgr.retrieveBlockByHash(blocks[5].Header.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[5], block)
+}
@@ -25,9 +25,30 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 )
 
+// RetentionPolicies holds the retention policies of the ledgers created by
+// a factory. The policy of a channel defaults to the Default policy. The
+// ledger of the system channel is never pruned, as orderers joining the
+// ordering service replicate it from its genesis block.
+type RetentionPolicies struct {
+	Default       fsblkstorage.RetentionPolicy
+	Channels      map[string]fsblkstorage.RetentionPolicy
+	SystemChannel string
+}
+
+func (rp RetentionPolicies) forChannel(chainID string) fsblkstorage.RetentionPolicy {
+	if rp.SystemChannel != "" && chainID == rp.SystemChannel {
+		return fsblkstorage.RetentionPolicy{}
+	}
+	if policy, ok := rp.Channels[chainID]; ok {
+		return policy
+	}
+	return rp.Default
+}
+
 type fileLedgerFactory struct {
 	blkstorageProvider blkstorage.BlockStoreProvider
 	ledgers            map[string]blockledger.ReadWriter
+	retention          RetentionPolicies
 	mutex              sync.Mutex
 }
 
@@ -47,7 +68,7 @@ func (flf *fileLedgerFactory) GetOrCreate(chainID string) (blockledger.ReadWrite
 	if err != nil {
 		return nil, err
 	}
-	ledger = NewFileLedger(blockStore)
+	ledger = NewPrunedFileLedger(blockStore, flf.retention.forChannel(chainID))
 	flf.ledgers[key] = ledger
 	return ledger, nil
 }
@@ -63,11 +84,24 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
+	flf.mutex.Lock()
+	for _, ledger := range flf.ledgers {
+		if fl, ok := ledger.(*FileLedger); ok {
+			fl.stopPruning()
+		}
+	}
+	flf.mutex.Unlock()
 	flf.blkstorageProvider.Close()
 }
 
 // New creates a new ledger factory
 func New(directory string, metricsProvider metrics.Provider) blockledger.Factory {
+	return NewWithRetention(directory, metricsProvider, RetentionPolicies{})
+}
+
+// NewWithRetention creates a new ledger factory whose ledgers prune their
+// oldest blocks according to the given retention policies
+func NewWithRetention(directory string, metricsProvider metrics.Provider, retention RetentionPolicies) blockledger.Factory {
 	return &fileLedgerFactory{
 		blkstorageProvider: fsblkstorage.NewProvider(
 			fsblkstorage.NewConf(directory, -1),
@@ -75,6 +109,7 @@ func New(directory string, metricsProvider metrics.Provider) blockledger.Factory
 				AttrsToIndex: []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}},
 			metricsProvider,
 		),
-		ledgers: make(map[string]blockledger.ReadWriter),
+		ledgers:   make(map[string]blockledger.ReadWriter),
+		retention: retention,
 	}
 }
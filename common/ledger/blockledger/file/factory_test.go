@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -93,3 +94,14 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRetentionPoliciesForChannel(t *testing.T) {
+	policies := RetentionPolicies{
+		Default:       fsblkstorage.RetentionPolicy{Blocks: 10},
+		Channels:      map[string]fsblkstorage.RetentionPolicy{"foo": {Bytes: 1000}},
+		SystemChannel: "system",
+	}
+	assert.Equal(t, fsblkstorage.RetentionPolicy{Blocks: 10}, policies.forChannel("bar"))
+	assert.Equal(t, fsblkstorage.RetentionPolicy{Bytes: 1000}, policies.forChannel("foo"))
+	assert.True(t, policies.forChannel("system").IsZero(), "Expected the system channel never to be pruned")
+}
@@ -7,19 +7,30 @@ SPDX-License-Identifier: Apache-2.0
 package fileledger
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("common.ledger.blockledger.file")
 
 // FileLedger is a struct used to interact with a node's ledger
 type FileLedger struct {
-	blockStore FileLedgerBlockStore
-	signal     chan struct{}
+	blockStore  FileLedgerBlockStore
+	signal      chan struct{}
+	retention   fsblkstorage.RetentionPolicy
+	pruneReq    chan uint64
+	pruneDone   chan struct{}
+	pruneExited chan struct{}
+	pruneStop   sync.Once
 }
 
 // FileLedgerBlockStore defines the interface to interact with deliver when using a
@@ -30,22 +41,58 @@ type FileLedgerBlockStore interface {
 	RetrieveBlocks(startBlockNumber uint64) (ledger.ResultsIterator, error)
 }
 
+// prunableBlockStore is implemented by the block stores which support
+// pruning their oldest blocks
+type prunableBlockStore interface {
+	Prune(policy fsblkstorage.RetentionPolicy, retain []uint64) error
+	FirstBlockNumber() uint64
+	RetrieveBlockByNumber(blockNum uint64) (*cb.Block, error)
+}
+
 // NewFileLedger creates a new FileLedger for interaction with the ledger
 func NewFileLedger(blockStore FileLedgerBlockStore) *FileLedger {
 	return &FileLedger{blockStore: blockStore, signal: make(chan struct{})}
 }
 
+// NewPrunedFileLedger creates a new FileLedger which prunes the oldest blocks
+// of the block store according to the retention policy in the background,
+// whenever blocks are appended. The last config block is always kept.
+func NewPrunedFileLedger(blockStore FileLedgerBlockStore, retention fsblkstorage.RetentionPolicy) *FileLedger {
+	fl := NewFileLedger(blockStore)
+	prunable, ok := blockStore.(prunableBlockStore)
+	if retention.IsZero() || !ok {
+		return fl
+	}
+	fl.retention = retention
+	fl.pruneReq = make(chan uint64, 1)
+	fl.pruneDone = make(chan struct{})
+	fl.pruneExited = make(chan struct{})
+	go fl.pruneLoop(prunable)
+	return fl
+}
+
 type fileLedgerIterator struct {
 	ledger         *FileLedger
 	blockNumber    uint64
+	mutex          sync.Mutex
+	closed         bool
 	commonIterator ledger.ResultsIterator
 }
 
 // Next blocks until there is a new block available, or until Close is called.
-// It returns an error if the next block is no longer retrievable.
+// It returns an error if the next block is no longer retrievable. Blocks below
+// the first block which was not pruned are served while they are retained.
 func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
-	result, err := i.commonIterator.Next()
+	commonIterator, block, status := i.advance()
+	if commonIterator == nil {
+		return block, status
+	}
+	result, err := commonIterator.Next()
 	if err != nil {
+		if errors.Cause(err) == blkstorage.ErrPruned {
+			logger.Debugf("Block %d has been pruned", i.blockNumber)
+			return nil, cb.Status_GONE
+		}
 		logger.Error(err)
 		return nil, cb.Status_SERVICE_UNAVAILABLE
 	}
@@ -53,21 +100,70 @@ func (i *fileLedgerIterator) Next() (*cb.Block, cb.Status) {
 	if result == nil {
 		return nil, cb.Status_SERVICE_UNAVAILABLE
 	}
+	i.mutex.Lock()
+	i.blockNumber++
+	i.mutex.Unlock()
 	return result.(*cb.Block), cb.Status_SUCCESS
 }
 
+// advance returns the next block if it is a retained one, or otherwise the
+// iterator of the block store to retrieve it from, which is opened once the
+// iterator reaches the first block which was not pruned.
+func (i *fileLedgerIterator) advance() (ledger.ResultsIterator, *cb.Block, cb.Status) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.closed {
+		return nil, nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+	if i.commonIterator != nil {
+		return i.commonIterator, nil, cb.Status_SUCCESS
+	}
+	prunable := i.ledger.blockStore.(prunableBlockStore)
+	if i.blockNumber < prunable.FirstBlockNumber() {
+		block, err := prunable.RetrieveBlockByNumber(i.blockNumber)
+		if err != nil {
+			logger.Debugf("Block %d has been pruned: %s", i.blockNumber, err)
+			return nil, nil, cb.Status_GONE
+		}
+		i.blockNumber++
+		return nil, block, cb.Status_SUCCESS
+	}
+	commonIterator, err := i.ledger.blockStore.RetrieveBlocks(i.blockNumber)
+	if err != nil {
+		if errors.Cause(err) == blkstorage.ErrPruned {
+			logger.Debugf("Block %d has been pruned", i.blockNumber)
+			return nil, nil, cb.Status_GONE
+		}
+		logger.Error(err)
+		return nil, nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+	i.commonIterator = commonIterator
+	return commonIterator, nil, cb.Status_SUCCESS
+}
+
 // Close releases resources acquired by the Iterator
 func (i *fileLedgerIterator) Close() {
-	i.commonIterator.Close()
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.closed = true
+	if i.commonIterator != nil {
+		i.commonIterator.Close()
+	}
 }
 
 // Iterator returns an Iterator, as specified by an ab.SeekInfo message, and its
 // starting block number
 func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iterator, uint64) {
 	var startingBlockNumber uint64
+	var firstBlockNumber uint64
+	prunable, isPrunable := fl.blockStore.(prunableBlockStore)
+	if isPrunable {
+		firstBlockNumber = prunable.FirstBlockNumber()
+	}
+
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		startingBlockNumber = 0
+		startingBlockNumber = firstBlockNumber
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
@@ -81,12 +177,20 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 		if startingBlockNumber > height {
 			return &blockledger.NotFoundErrorIterator{}, 0
 		}
+		if startingBlockNumber < firstBlockNumber {
+			// the block store is iterated once the retained blocks are served
+			return &fileLedgerIterator{ledger: fl, blockNumber: startingBlockNumber}, startingBlockNumber
+		}
 	default:
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
 
 	iterator, err := fl.blockStore.RetrieveBlocks(startingBlockNumber)
 	if err != nil {
+		if isPrunable && errors.Cause(err) == blkstorage.ErrPruned {
+			// the starting block was pruned meanwhile
+			return &fileLedgerIterator{ledger: fl, blockNumber: startingBlockNumber}, startingBlockNumber
+		}
 		return &blockledger.NotFoundErrorIterator{}, 0
 	}
 
@@ -108,6 +212,58 @@ func (fl *FileLedger) Append(block *cb.Block) error {
 	if err == nil {
 		close(fl.signal)
 		fl.signal = make(chan struct{})
+		fl.requestPrune(block)
 	}
 	return err
 }
+
+// requestPrune asks the pruning goroutine to prune the block store, keeping
+// the last config block referenced by the given block. A pending request is
+// superseded, as pruning is done according to the latest block.
+func (fl *FileLedger) requestPrune(block *cb.Block) {
+	if fl.pruneReq == nil {
+		return
+	}
+	lastConfig, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		logger.Warningf("Not pruning the ledger, failed to retrieve the last config index of block %d: %s", block.Header.Number, err)
+		return
+	}
+	select {
+	case fl.pruneReq <- lastConfig:
+	default:
+		// Append is the only sender, hence once the pending request is
+		// discarded the following send does not block.
+		select {
+		case <-fl.pruneReq:
+		default:
+		}
+		fl.pruneReq <- lastConfig
+	}
+}
+
+// pruneLoop prunes the block store upon requests, off the commit path of
+// the blocks, until the ledger is closed.
+func (fl *FileLedger) pruneLoop(prunable prunableBlockStore) {
+	defer close(fl.pruneExited)
+	for {
+		select {
+		case lastConfig := <-fl.pruneReq:
+			if err := prunable.Prune(fl.retention, []uint64{lastConfig}); err != nil {
+				logger.Errorf("Failed pruning the ledger: %s", err)
+			}
+		case <-fl.pruneDone:
+			return
+		}
+	}
+}
+
+// stopPruning stops the pruning goroutine of the ledger, if any, and waits
+// for the ongoing pruning to complete
+func (fl *FileLedger) stopPruning() {
+	if fl.pruneDone == nil {
+		return
+	}
+	fl.pruneStop.Do(func() { close(fl.pruneDone) })
+	<-fl.pruneExited
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -306,6 +308,120 @@ func TestBlockstoreError(t *testing.T) {
 	}
 }
 
+type mockPrunableBlockStore struct {
+	*mockBlockStore
+	firstBlockNumber uint64
+	retained         map[uint64]*cb.Block
+	pruneCalls       chan []uint64
+	pruneRelease     chan struct{}
+	pruneError       error
+}
+
+func (mpbs *mockPrunableBlockStore) Prune(policy fsblkstorage.RetentionPolicy, retain []uint64) error {
+	if mpbs.pruneRelease != nil {
+		<-mpbs.pruneRelease
+	}
+	mpbs.pruneCalls <- retain
+	return mpbs.pruneError
+}
+
+func (mpbs *mockPrunableBlockStore) FirstBlockNumber() uint64 {
+	return mpbs.firstBlockNumber
+}
+
+func (mpbs *mockPrunableBlockStore) RetrieveBlockByNumber(blockNum uint64) (*cb.Block, error) {
+	block, ok := mpbs.retained[blockNum]
+	if !ok {
+		return nil, blkstorage.ErrPruned
+	}
+	return block, nil
+}
+
+func TestPrunedRetrieval(t *testing.T) {
+	resultsIterator := &mockBlockStoreIterator{}
+	resultsIterator.On("Next").Return(cb.NewBlock(4, nil), nil)
+	resultsIterator.On("Close").Return()
+	blockStore := &mockPrunableBlockStore{
+		mockBlockStore: &mockBlockStore{
+			blockchainInfo:  &cb.BlockchainInfo{Height: uint64(10)},
+			resultsIterator: resultsIterator,
+		},
+		firstBlockNumber: 4,
+		retained:         map[uint64]*cb.Block{1: cb.NewBlock(1, nil), 2: cb.NewBlock(2, nil), 3: cb.NewBlock(3, nil)},
+	}
+	fl := NewFileLedger(blockStore)
+
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	it.Close()
+	assert.Equal(t, uint64(4), num, "Expected the oldest block to be the first block which was not pruned")
+
+	it, num = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 2}}})
+	assert.Equal(t, uint64(2), num)
+	for _, expected := range []uint64{2, 3, 4} {
+		block, status := it.Next()
+		assert.Equal(t, cb.Status_SUCCESS, status, "Expected to retrieve block %d", expected)
+		assert.Equal(t, expected, block.Header.Number)
+	}
+	it.Close()
+	resultsIterator.AssertCalled(t, "Close")
+
+	delete(blockStore.retained, 2)
+	it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 1}}})
+	defer it.Close()
+	block, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status, "Expected to retrieve the retained block")
+	assert.Equal(t, uint64(1), block.Header.Number)
+	_, status = it.Next()
+	assert.Equal(t, cb.Status_GONE, status, "Expected the block following the retained block to be pruned")
+
+	prunedIterator := &mockBlockStoreIterator{}
+	prunedIterator.On("Next").Return(nil, blkstorage.ErrPruned)
+	prunedIterator.On("Close").Return()
+	blockStore.resultsIterator = prunedIterator
+	it, _ = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	_, status = it.Next()
+	assert.Equal(t, cb.Status_GONE, status, "Expected a block pruned while iterating to be reported as such")
+}
+
+func TestAppendPrunes(t *testing.T) {
+	blockStore := &mockPrunableBlockStore{
+		mockBlockStore: &mockBlockStore{},
+		pruneCalls:     make(chan []uint64, 10),
+		pruneRelease:   make(chan struct{}),
+		pruneError:     errors.New("disk failure"),
+	}
+
+	fl := NewFileLedger(blockStore)
+	assert.NoError(t, fl.Append(cb.NewBlock(0, nil)))
+	assert.Nil(t, fl.pruneReq, "Expected no pruning without a retention policy")
+
+	fl = NewPrunedFileLedger(blockStore, fsblkstorage.RetentionPolicy{Blocks: 5})
+	defer fl.stopPruning()
+	badBlock := cb.NewBlock(7, nil)
+	badBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = []byte("garbage")
+	assert.NoError(t, fl.Append(badBlock))
+
+	for i, lastConfig := range []uint64{3, 4, 5, 6} {
+		block := cb.NewBlock(uint64(8+i), nil)
+		block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+			Value: utils.MarshalOrPanic(&cb.LastConfig{Index: lastConfig}),
+		})
+		assert.NoError(t, fl.Append(block), "Expected pruning not to block appending")
+	}
+
+	// The first request may have been picked up before the others were
+	// superseded by the latest one.
+	blockStore.pruneRelease <- struct{}{}
+	retain := <-blockStore.pruneCalls
+	if !assert.ObjectsAreEqual([]uint64{6}, retain) {
+		assert.Equal(t, []uint64{3}, retain)
+		blockStore.pruneRelease <- struct{}{}
+		assert.Equal(t, []uint64{6}, <-blockStore.pruneCalls, "Expected the last config block to be retained")
+	}
+	assert.Empty(t, blockStore.pruneCalls, "Expected no pruning without a last config index")
+}
+
 func getSampleEnvelopeWithSignatureHeader() *cb.Envelope {
 	nonce := utils.CreateNonceOrPanic()
 	sighdr := &cb.SignatureHeader{Nonce: nonce}
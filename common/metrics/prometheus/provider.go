@@ -16,31 +16,31 @@ import (
 type Provider struct{}
 
 func (p *Provider) NewCounter(o metrics.CounterOpts) metrics.Counter {
-	return &Counter{
-		Counter: prometheus.NewCounterFrom(
-			prom.CounterOpts{
-				Namespace: o.Namespace,
-				Subsystem: o.Subsystem,
-				Name:      o.Name,
-				Help:      o.Help,
-			},
-			o.LabelNames,
-		),
-	}
+	cv := prom.NewCounterVec(
+		prom.CounterOpts{
+			Namespace: o.Namespace,
+			Subsystem: o.Subsystem,
+			Name:      o.Name,
+			Help:      o.Help,
+		},
+		o.LabelNames,
+	)
+	prom.MustRegister(cv)
+	return &Counter{Counter: prometheus.NewCounter(cv), cv: cv}
 }
 
 func (p *Provider) NewGauge(o metrics.GaugeOpts) metrics.Gauge {
-	return &Gauge{
-		Gauge: prometheus.NewGaugeFrom(
-			prom.GaugeOpts{
-				Namespace: o.Namespace,
-				Subsystem: o.Subsystem,
-				Name:      o.Name,
-				Help:      o.Help,
-			},
-			o.LabelNames,
-		),
-	}
+	gv := prom.NewGaugeVec(
+		prom.GaugeOpts{
+			Namespace: o.Namespace,
+			Subsystem: o.Subsystem,
+			Name:      o.Name,
+			Help:      o.Help,
+		},
+		o.LabelNames,
+	)
+	prom.MustRegister(gv)
+	return &Gauge{Gauge: prometheus.NewGauge(gv), gv: gv}
 }
 
 func (p *Provider) NewHistogram(o metrics.HistogramOpts) metrics.Histogram {
@@ -58,16 +58,30 @@ func (p *Provider) NewHistogram(o metrics.HistogramOpts) metrics.Histogram {
 	}
 }
 
-type Counter struct{ kitmetrics.Counter }
+type Counter struct {
+	kitmetrics.Counter
+	cv *prom.CounterVec
+}
 
 func (c *Counter) With(labelValues ...string) metrics.Counter {
-	return &Counter{Counter: c.Counter.With(labelValues...)}
+	return &Counter{Counter: c.Counter.With(labelValues...), cv: c.cv}
+}
+
+func (c *Counter) DeleteLabelValues(labelValues ...string) {
+	c.cv.Delete(makeLabels(labelValues...))
 }
 
-type Gauge struct{ kitmetrics.Gauge }
+type Gauge struct {
+	kitmetrics.Gauge
+	gv *prom.GaugeVec
+}
 
 func (g *Gauge) With(labelValues ...string) metrics.Gauge {
-	return &Gauge{Gauge: g.Gauge.With(labelValues...)}
+	return &Gauge{Gauge: g.Gauge.With(labelValues...), gv: g.gv}
+}
+
+func (g *Gauge) DeleteLabelValues(labelValues ...string) {
+	g.gv.Delete(makeLabels(labelValues...))
 }
 
 type Histogram struct{ kitmetrics.Histogram }
@@ -75,3 +89,13 @@ type Histogram struct{ kitmetrics.Histogram }
 func (h *Histogram) With(labelValues ...string) metrics.Histogram {
 	return &Histogram{Histogram: h.Histogram.With(labelValues...)}
 }
+
+// makeLabels converts label values, given as alternating label names and
+// values as passed to With, into prometheus labels
+func makeLabels(labelValues ...string) prom.Labels {
+	labels := prom.Labels{}
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		labels[labelValues[i]] = labelValues[i+1]
+	}
+	return labels
+}
@@ -79,6 +79,23 @@ var _ = Describe("Provider", func() {
 			Expect(string(bytes)).To(ContainSubstring(`peer_playground_counter_name{alpha="aardvark",beta="b"} 2`))
 		})
 
+		It("deletes the series of label values", func() {
+			counter := p.NewCounter(counterOpts)
+			counter.With("alpha", "a", "beta", "b").Add(1)
+			counter.With("alpha", "aardvark", "beta", "b").Add(2)
+			Expect(counter).To(BeAssignableToTypeOf(&prometheus.Counter{}))
+			counter.(commonmetrics.LabelDeleter).DeleteLabelValues("alpha", "a", "beta", "b")
+
+			resp, err := client.Get(fmt.Sprintf("http://%s/metrics", server.Listener.Addr().String()))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			bytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bytes)).NotTo(ContainSubstring(`peer_playground_counter_name{alpha="a",beta="b"}`))
+			Expect(string(bytes)).To(ContainSubstring(`peer_playground_counter_name{alpha="aardvark",beta="b"} 2`))
+		})
+
 		Context("when the counter is defined without labels", func() {
 			BeforeEach(func() {
 				counterOpts.LabelNames = nil
@@ -131,6 +148,23 @@ var _ = Describe("Provider", func() {
 			Expect(string(bytes)).To(ContainSubstring(`peer_playground_gauge_name{alpha="aardvark",beta="b"} 1`))
 			Expect(string(bytes)).To(ContainSubstring(`peer_playground_gauge_name{alpha="aardvark",beta="bob"} 99`))
 		})
+
+		It("deletes the series of label values", func() {
+			gauge := p.NewGauge(gaugeOpts)
+			gauge.With("alpha", "a", "beta", "b").Set(1)
+			gauge.With("alpha", "aardvark", "beta", "b").Set(2)
+			Expect(gauge).To(BeAssignableToTypeOf(&prometheus.Gauge{}))
+			gauge.(commonmetrics.LabelDeleter).DeleteLabelValues("alpha", "a", "beta", "b")
+
+			resp, err := client.Get(fmt.Sprintf("http://%s/metrics", server.Listener.Addr().String()))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+
+			bytes, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(bytes)).NotTo(ContainSubstring(`peer_playground_gauge_name{alpha="a",beta="b"}`))
+			Expect(string(bytes)).To(ContainSubstring(`peer_playground_gauge_name{alpha="aardvark",beta="b"} 2`))
+		})
 	})
 
 	Describe("NewHistogram", func() {
@@ -108,6 +108,16 @@ type GaugeOpts struct {
 	StatsdFormat string
 }
 
+// A LabelDeleter is implemented by meters of providers which keep a series
+// for every combination of label values. It allows deleting the series of
+// label values which are no longer in use, such as the address of a peer
+// which disconnected.
+type LabelDeleter interface {
+	// DeleteLabelValues deletes the series recorded with the given label
+	// values. The label values are provided in the same way as to With.
+	DeleteLabelValues(labelValues ...string)
+}
+
 // A Histogram is a meter that records an observed value into quantized
 // buckets.
 type Histogram interface {
@@ -48,6 +48,9 @@ type MockApplicationCapabilities struct {
 	V1_3ValidationRv             bool
 	FabTokenRv                   bool
 	StorePvtDataOfInvalidTxRv    bool
+	WasmChaincodeRv              bool
+	LifecycleV20Rv               bool
+	ErasureCodedCollectionsRv    bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -97,3 +100,15 @@ func (mac *MockApplicationCapabilities) FabToken() bool {
 func (mac *MockApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	return mac.StorePvtDataOfInvalidTxRv
 }
+
+func (mac *MockApplicationCapabilities) WasmChaincode() bool {
+	return mac.WasmChaincodeRv
+}
+
+func (mac *MockApplicationCapabilities) ErasureCodedCollections() bool {
+	return mac.ErasureCodedCollectionsRv
+}
+
+func (mac *MockApplicationCapabilities) LifecycleV20() bool {
+	return mac.LifecycleV20Rv
+}
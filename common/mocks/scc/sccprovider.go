@@ -59,7 +59,7 @@ func (c *MocksccProviderImpl) IsSysCC(name string) bool {
 		return c.SysCCMap[name]
 	}
 
-	return (name == "lscc") || (name == "escc") || (name == "vscc") || (name == "notext")
+	return (name == "lscc") || (name == "+lifecycle") || (name == "escc") || (name == "vscc") || (name == "notext")
 }
 
 func (c *MocksccProviderImpl) IsSysCCAndNotInvokableCC2CC(name string) bool {
@@ -8,6 +8,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -15,16 +16,20 @@ import (
 	"reflect"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/migration"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/common/tools/protolator"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	_ "github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common" // Import these to register the proto types
 	_ "github.com/hyperledger/fabric/protos/msp"
 	_ "github.com/hyperledger/fabric/protos/orderer"
-	_ "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	_ "github.com/hyperledger/fabric/protos/peer"
 
 	"github.com/gorilla/handlers"
@@ -57,6 +62,17 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	migrationStatus       = app.Command("migration_status", "Reports the stage of channels in their migration from kafka to etcdraft, given their latest config blocks.")
+	migrationStatusBlocks = migrationStatus.Flag("config_block", "The latest config block of a channel (may be repeated).").Required().ExistingFiles()
+
+	migrationUpdate         = app.Command("migration_update", "Computes the config update envelope which moves a channel to the next stage of its migration from kafka to etcdraft.")
+	migrationUpdateBlock    = migrationUpdate.Flag("config_block", "The latest config block of the channel.").Required().File()
+	migrationUpdateMetadata = migrationUpdate.Flag("raft_metadata", "A marshaled etcdraft.ConfigMetadata message, required to change the consensus type.").File()
+	migrationUpdateRollback = migrationUpdate.Flag("rollback", "Compute the config update which moves the channel one stage back towards kafka instead.").Bool()
+	migrationUpdateMSPDir   = migrationUpdate.Flag("msp_dir", "The MSP directory of an orderer admin to sign the config update with. The envelope is left unsigned if not set.").ExistingDir()
+	migrationUpdateMSPID    = migrationUpdate.Flag("msp_id", "The MSP ID of the orderer admin to sign the config update with.").String()
+	migrationUpdateDest     = migrationUpdate.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -91,6 +107,22 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case migrationStatus.FullCommand():
+		err := printMigrationStatus(*migrationStatusBlocks, os.Stdout)
+		if err != nil {
+			app.Fatalf("Error computing migration status: %s", err)
+		}
+	case migrationUpdate.FullCommand():
+		defer (*migrationUpdateBlock).Close()
+		defer (*migrationUpdateDest).Close()
+		signer, err := migrationSigner(*migrationUpdateMSPDir, *migrationUpdateMSPID)
+		if err != nil {
+			app.Fatalf("Error loading signing identity: %s", err)
+		}
+		err = computeMigrationUpdate(*migrationUpdateBlock, *migrationUpdateMetadata, *migrationUpdateRollback, signer, *migrationUpdateDest)
+		if err != nil {
+			app.Fatalf("Error computing migration update: %s", err)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -218,3 +250,118 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func readConfigBlock(input io.Reader) (*cb.Block, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(in, block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling config block")
+	}
+
+	return block, nil
+}
+
+func printMigrationStatus(configBlocks []string, output io.Writer) error {
+	var statuses []*migration.ChannelStatus
+	for _, path := range configBlocks {
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "error opening config block %s", path)
+		}
+		block, err := readConfigBlock(f)
+		f.Close()
+		if err != nil {
+			return errors.WithMessage(err, path)
+		}
+
+		status, err := migration.Status(block)
+		if err != nil {
+			return errors.WithMessage(err, path)
+		}
+		statuses = append(statuses, status)
+
+		fmt.Fprintf(output, "%s: %s", status.ChannelID, status.Stage)
+		if status.RaftVerified {
+			fmt.Fprint(output, " (ordered by etcdraft)")
+		} else if status.Stage == migration.StageRaftMaintenance {
+			fmt.Fprint(output, " (not yet ordered by etcdraft)")
+		}
+		fmt.Fprintln(output)
+	}
+
+	ready, err := migration.Plan(statuses)
+	if err != nil {
+		return err
+	}
+	for _, channelID := range ready {
+		fmt.Fprintf(output, "ready to move to the next stage: %s\n", channelID)
+	}
+
+	return nil
+}
+
+// migrationSigner loads the local MSP out of the given directory, and returns a
+// signer for its identity, or nil if no directory is given.
+func migrationSigner(mspDir, mspID string) (crypto.LocalSigner, error) {
+	if mspDir == "" {
+		return nil, nil
+	}
+	if mspID == "" {
+		return nil, errors.New("the MSP ID is required along with the MSP directory")
+	}
+	if err := mspmgmt.LoadLocalMsp(mspDir, nil, mspID); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error loading MSP %s from %s", mspID, mspDir))
+	}
+	return localmsp.NewSigner(), nil
+}
+
+func computeMigrationUpdate(configBlock, raftMetadata *os.File, rollback bool, signer crypto.LocalSigner, output *os.File) error {
+	block, err := readConfigBlock(configBlock)
+	if err != nil {
+		return err
+	}
+
+	var cu *cb.ConfigUpdate
+	if rollback {
+		cu, err = migration.RollbackUpdate(block)
+	} else {
+		var metadata *etcdraft.ConfigMetadata
+		if raftMetadata != nil {
+			defer raftMetadata.Close()
+			in, err := ioutil.ReadAll(raftMetadata)
+			if err != nil {
+				return errors.Wrapf(err, "error reading etcdraft metadata")
+			}
+			metadata = &etcdraft.ConfigMetadata{}
+			if err := proto.Unmarshal(in, metadata); err != nil {
+				return errors.Wrapf(err, "error unmarshaling etcdraft metadata")
+			}
+		}
+		cu, err = migration.NextUpdate(block, metadata)
+	}
+	if err != nil {
+		return err
+	}
+
+	env, err := migration.UpdateEnvelope(cu, signer)
+	if err != nil {
+		return errors.WithMessage(err, "error creating config update envelope")
+	}
+
+	outBytes, err := proto.Marshal(env)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling config update envelope")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update envelope to output")
+	}
+
+	return nil
+}
@@ -0,0 +1,407 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package migration drives the migration of channels from the kafka to the
+// etcdraft consensus type through the sequence of config updates enforced by
+// the orderer maintenance filter:
+//
+//  1. ConsensusType.State is set to STATE_MAINTENANCE on every channel.
+//  2. ConsensusType.Type is set to etcdraft, along with the etcdraft
+//     metadata, on every channel.
+//  3. All orderers are restarted, and start etcdraft chains. A config update
+//     which leaves the config unchanged is submitted on every channel, to
+//     verify that its etcdraft chain orders config blocks.
+//  4. ConsensusType.State is set back to STATE_NORMAL on every channel.
+//
+// The stage a channel is in is derived from its latest config block, hence
+// the progress of a migration is tracked by the channels themselves.
+package migration
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	kafkaType    = "kafka"
+	etcdraftType = "etcdraft"
+)
+
+// Stage is the stage of a channel in the migration from kafka to etcdraft.
+type Stage int
+
+const (
+	// StageKafka is the stage of a channel ordered by kafka.
+	StageKafka Stage = iota
+	// StageKafkaMaintenance is the stage of a kafka channel in maintenance mode.
+	StageKafkaMaintenance
+	// StageRaftMaintenance is the stage of a channel in maintenance mode whose
+	// consensus type is etcdraft. The orderers must be restarted in this stage.
+	StageRaftMaintenance
+	// StageRaft is the stage of a channel ordered by etcdraft.
+	StageRaft
+)
+
+func (s Stage) String() string {
+	switch s {
+	case StageKafka:
+		return "kafka"
+	case StageKafkaMaintenance:
+		return "kafka-maintenance"
+	case StageRaftMaintenance:
+		return "etcdraft-maintenance"
+	case StageRaft:
+		return "etcdraft"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// ChannelStatus is the migration status of a channel.
+type ChannelStatus struct {
+	ChannelID string
+	Stage     Stage
+	// RaftVerified is true if the consensus type of the channel is etcdraft, and
+	// the config block was ordered by an etcdraft chain, which shows that the
+	// chain has started after the orderers were restarted.
+	RaftVerified bool
+}
+
+// channel holds the parsed latest config of a channel.
+type channel struct {
+	id      string
+	config  *cb.Config
+	orderer channelconfig.Orderer
+	block   *cb.Block
+}
+
+func parseConfigBlock(configBlock *cb.Block) (*channel, error) {
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to extract config envelope from block")
+	}
+	configEnv := &cb.ConfigEnvelope{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG, configEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "block is not a config block")
+	}
+	bundle, err := channelconfig.NewBundle(chdr.ChannelId, configEnv.Config)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to parse config of channel %s", chdr.ChannelId))
+	}
+	ordererConfig, ok := bundle.OrdererConfig()
+	if !ok {
+		return nil, errors.Errorf("config of channel %s has no orderer group", chdr.ChannelId)
+	}
+	return &channel{
+		id:      chdr.ChannelId,
+		config:  configEnv.Config,
+		orderer: ordererConfig,
+		block:   configBlock,
+	}, nil
+}
+
+func (ch *channel) stage() (Stage, error) {
+	maintenance := ch.orderer.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE
+	switch ch.orderer.ConsensusType() {
+	case kafkaType:
+		if maintenance {
+			return StageKafkaMaintenance, nil
+		}
+		return StageKafka, nil
+	case etcdraftType:
+		if maintenance {
+			return StageRaftMaintenance, nil
+		}
+		return StageRaft, nil
+	default:
+		return 0, errors.Errorf("channel %s has unsupported consensus type %s", ch.id, ch.orderer.ConsensusType())
+	}
+}
+
+// orderedByRaft returns whether the orderer metadata of the config block was
+// written by an etcdraft chain whose cluster matches the consenters of the
+// channel config.
+func (ch *channel) orderedByRaft() bool {
+	md, err := utils.GetMetadataFromBlock(ch.block, cb.BlockMetadataIndex_ORDERER)
+	if err != nil || len(md.Value) == 0 {
+		return false
+	}
+	blockMetadata := &etcdraft.BlockMetadata{}
+	if err := proto.Unmarshal(md.Value, blockMetadata); err != nil {
+		return false
+	}
+	configMetadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(ch.orderer.ConsensusMetadata(), configMetadata); err != nil {
+		return false
+	}
+	if blockMetadata.RaftIndex == 0 || len(blockMetadata.ConsenterIds) != len(configMetadata.Consenters) {
+		return false
+	}
+	for _, id := range blockMetadata.ConsenterIds {
+		if id == 0 || id >= blockMetadata.NextConsenterId {
+			return false
+		}
+	}
+	return true
+}
+
+// Status returns the migration status of the channel whose latest config
+// block is given.
+func Status(configBlock *cb.Block) (*ChannelStatus, error) {
+	ch, err := parseConfigBlock(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	stage, err := ch.stage()
+	if err != nil {
+		return nil, err
+	}
+	return &ChannelStatus{
+		ChannelID:    ch.id,
+		Stage:        stage,
+		RaftVerified: stage >= StageRaftMaintenance && ch.orderedByRaft(),
+	}, nil
+}
+
+// Plan checks that the channels are migrated in lockstep, and returns the IDs
+// of the channels which may move on to their next stage. A channel may only
+// move on once all channels have reached its stage, as the consensus type may
+// only change once every channel is in maintenance mode, and the orderers may
+// only be restarted once every channel has changed its consensus type.
+// Likewise, channels may only leave maintenance mode once the etcdraft chains
+// of all of them were verified, as the orderers can no longer be rolled back
+// to kafka afterwards. Until then, only the channels whose etcdraft chain is
+// yet to be verified are returned.
+func Plan(statuses []*ChannelStatus) ([]string, error) {
+	if len(statuses) == 0 {
+		return nil, errors.New("no channels provided")
+	}
+
+	min, max := statuses[0].Stage, statuses[0].Stage
+	for _, s := range statuses[1:] {
+		if s.Stage < min {
+			min = s.Stage
+		}
+		if s.Stage > max {
+			max = s.Stage
+		}
+	}
+	if max-min > 1 {
+		return nil, errors.Errorf("channels are more than one stage apart, from %s to %s", min, max)
+	}
+
+	var ready, unverified []string
+	for _, s := range statuses {
+		if s.Stage != min || s.Stage == StageRaft {
+			continue
+		}
+		ready = append(ready, s.ChannelID)
+		if s.Stage == StageRaftMaintenance && !s.RaftVerified {
+			unverified = append(unverified, s.ChannelID)
+		}
+	}
+	if len(unverified) != 0 {
+		return unverified, nil
+	}
+	return ready, nil
+}
+
+// NextUpdate returns the config update which moves the channel whose latest
+// config block is given to its next stage. The etcdraft metadata is only
+// required to move from StageKafkaMaintenance to StageRaftMaintenance.
+// In StageRaftMaintenance, the channel only leaves maintenance mode once its
+// latest config block was ordered by etcdraft. Until then, the config update
+// leaves the config unchanged, so that the etcdraft chain is verified by
+// ordering it.
+func NextUpdate(configBlock *cb.Block, raftMetadata *etcdraft.ConfigMetadata) (*cb.ConfigUpdate, error) {
+	ch, err := parseConfigBlock(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.orderer.Capabilities().ConsensusTypeMigration() {
+		return nil, errors.Errorf("channel %s does not have the orderer capability required for consensus-type migration", ch.id)
+	}
+	stage, err := ch.stage()
+	if err != nil {
+		return nil, err
+	}
+
+	next := &ab.ConsensusType{
+		Type:     ch.orderer.ConsensusType(),
+		Metadata: ch.orderer.ConsensusMetadata(),
+		State:    ch.orderer.ConsensusState(),
+	}
+	switch stage {
+	case StageKafka:
+		next.State = ab.ConsensusType_STATE_MAINTENANCE
+	case StageKafkaMaintenance:
+		if err := validateRaftMetadata(raftMetadata); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid etcdraft metadata for channel %s", ch.id))
+		}
+		next.Type = etcdraftType
+		next.Metadata = utils.MarshalOrPanic(raftMetadata)
+	case StageRaftMaintenance:
+		if !ch.orderedByRaft() {
+			return ch.verificationUpdate()
+		}
+		next.State = ab.ConsensusType_STATE_NORMAL
+	case StageRaft:
+		return nil, errors.Errorf("channel %s has already been migrated to etcdraft", ch.id)
+	}
+
+	return ch.consensusTypeUpdate(next)
+}
+
+// RollbackUpdate returns the config update which moves the channel whose
+// latest config block is given one stage back towards kafka. A channel can
+// no longer be rolled back once it has left maintenance mode as an etcdraft
+// channel.
+func RollbackUpdate(configBlock *cb.Block) (*cb.ConfigUpdate, error) {
+	ch, err := parseConfigBlock(configBlock)
+	if err != nil {
+		return nil, err
+	}
+	stage, err := ch.stage()
+	if err != nil {
+		return nil, err
+	}
+
+	next := &ab.ConsensusType{
+		Type:     ch.orderer.ConsensusType(),
+		Metadata: ch.orderer.ConsensusMetadata(),
+		State:    ch.orderer.ConsensusState(),
+	}
+	switch stage {
+	case StageKafka:
+		return nil, errors.Errorf("channel %s has not started the migration", ch.id)
+	case StageKafkaMaintenance:
+		next.State = ab.ConsensusType_STATE_NORMAL
+	case StageRaftMaintenance:
+		next.Type = kafkaType
+		next.Metadata = nil
+	case StageRaft:
+		return nil, errors.Errorf("channel %s has completed the migration and cannot be rolled back", ch.id)
+	}
+
+	return ch.consensusTypeUpdate(next)
+}
+
+// consensusTypeUpdate computes the config update which sets the consensus type
+// of the channel.
+func (ch *channel) consensusTypeUpdate(consensusType *ab.ConsensusType) (*cb.ConfigUpdate, error) {
+	updated := proto.Clone(ch.config).(*cb.Config)
+	ordererGroup, ok := updated.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.Errorf("config of channel %s has no orderer group", ch.id)
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, errors.Errorf("config of channel %s has no %s value", ch.id, channelconfig.ConsensusTypeKey)
+	}
+	value.Value = utils.MarshalOrPanic(consensusType)
+
+	configUpdate, err := update.Compute(ch.config, updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to compute config update for channel %s", ch.id))
+	}
+	configUpdate.ChannelId = ch.id
+	return configUpdate, nil
+}
+
+// verificationUpdate computes the config update which sets the consensus type
+// of the channel to its current value. The config update only increments the
+// version of the value, hence it is accepted in maintenance mode, and the
+// config block it results in shows which consensus type ordered it.
+func (ch *channel) verificationUpdate() (*cb.ConfigUpdate, error) {
+	current := ch.config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey].GetValues()[channelconfig.ConsensusTypeKey]
+	if current == nil {
+		return nil, errors.Errorf("config of channel %s has no %s value", ch.id, channelconfig.ConsensusTypeKey)
+	}
+	// Compute the update against a placeholder value, as an update which
+	// changes nothing would be empty, and then restore the current value
+	configUpdate, err := ch.consensusTypeUpdate(&ab.ConsensusType{Type: "placeholder"})
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value = current.Value
+	return configUpdate, nil
+}
+
+// UpdateEnvelope wraps the config update in a CONFIG_UPDATE envelope, and signs
+// both with the given signer, which would be an orderer admin of the channel
+// for the config updates of the migration. If the signer is nil, the envelope
+// is left unsigned.
+func UpdateEnvelope(configUpdate *cb.ConfigUpdate, signer crypto.LocalSigner) (*cb.Envelope, error) {
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}
+
+	if signer != nil {
+		sigHeader, err := signer.NewSignatureHeader()
+		if err != nil {
+			return nil, errors.Wrap(err, "creating signature header failed")
+		}
+		configSig := &cb.ConfigSignature{
+			SignatureHeader: utils.MarshalOrPanic(sigHeader),
+		}
+		configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+		if err != nil {
+			return nil, errors.Wrap(err, "signature failure over config update")
+		}
+		configUpdateEnv.Signatures = []*cb.ConfigSignature{configSig}
+	}
+
+	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, configUpdate.ChannelId, signer, configUpdateEnv, 0, 0)
+}
+
+func validateRaftMetadata(metadata *etcdraft.ConfigMetadata) error {
+	if metadata == nil {
+		return errors.New("etcdraft metadata is required to change the consensus type")
+	}
+	if len(metadata.Consenters) == 0 {
+		return errors.New("no consenters provided")
+	}
+	endpoints := make(map[string]struct{})
+	for _, c := range metadata.Consenters {
+		if c.Host == "" || c.Port == 0 {
+			return errors.Errorf("consenter %s:%d has an invalid endpoint", c.Host, c.Port)
+		}
+		endpoint := fmt.Sprintf("%s:%d", c.Host, c.Port)
+		if _, exists := endpoints[endpoint]; exists {
+			return errors.Errorf("consenter %s is duplicated", endpoint)
+		}
+		endpoints[endpoint] = struct{}{}
+		if err := validateCert(c.ClientTlsCert); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid client TLS certificate of consenter %s", endpoint))
+		}
+		if err := validateCert(c.ServerTlsCert); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("invalid server TLS certificate of consenter %s", endpoint))
+		}
+	}
+	return nil
+}
+
+func validateCert(pemBytes []byte) error {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return errors.New("certificate is not PEM encoded")
+	}
+	_, err := x509.ParseCertificate(bl.Bytes)
+	return err
+}
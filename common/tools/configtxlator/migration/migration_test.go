@@ -0,0 +1,351 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	mockcrypto "github.com/hyperledger/fabric/common/mocks/crypto"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	factory.InitFactories(nil)
+}
+
+type filterSupport struct {
+	channelID string
+	orderer   channelconfig.Orderer
+}
+
+func (fs *filterSupport) OrdererConfig() (channelconfig.Orderer, bool) {
+	return fs.orderer, true
+}
+
+func (fs *filterSupport) ChainID() string {
+	return fs.channelID
+}
+
+func kafkaConfigBlock(channelID string) *cb.Block {
+	return encoder.New(configtxgentest.Load(genesisconfig.SampleDevModeKafkaProfile)).GenesisBlockForChannel(channelID)
+}
+
+func raftMetadata(t *testing.T) *etcdraft.ConfigMetadata {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverCert, err := ca.NewServerCertKeyPair("orderer0")
+	require.NoError(t, err)
+	clientCert, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	return &etcdraft.ConfigMetadata{
+		Consenters: []*etcdraft.Consenter{
+			{Host: "orderer0", Port: 7050, ServerTlsCert: serverCert.Cert, ClientTlsCert: clientCert.Cert},
+		},
+		Options: &etcdraft.Options{TickInterval: "500ms", ElectionTick: 10, HeartbeatTick: 1, MaxInflightBlocks: 5},
+	}
+}
+
+// applyUpdate checks the config update against the maintenance filter of the
+// orderer, and returns the config block the orderer would produce out of it.
+func applyUpdate(t *testing.T, configBlock *cb.Block, configUpdate *cb.ConfigUpdate) *cb.Block {
+	ch, err := parseConfigBlock(configBlock)
+	require.NoError(t, err)
+
+	ordererGroup := configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey]
+	require.NotNil(t, ordererGroup)
+	consensusType := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	require.NotNil(t, consensusType)
+
+	nextConfig := proto.Clone(ch.config).(*cb.Config)
+	nextConfig.Sequence++
+	nextConfig.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value = consensusType.Value
+
+	lastUpdate := &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_CONFIG_UPDATE), ChannelId: ch.id}),
+			},
+			Data: utils.MarshalOrPanic(&cb.ConfigUpdateEnvelope{ConfigUpdate: utils.MarshalOrPanic(configUpdate)}),
+		}),
+	}
+	configEnv, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, ch.id, nil, &cb.ConfigEnvelope{
+		Config:     nextConfig,
+		LastUpdate: lastUpdate,
+	}, 0, 0)
+	require.NoError(t, err)
+
+	filter := msgprocessor.NewMaintenanceFilter(&filterSupport{channelID: ch.id, orderer: ch.orderer})
+	require.NoError(t, filter.Apply(configEnv))
+
+	block := cb.NewBlock(configBlock.Header.Number+1, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(configEnv)}
+	return block
+}
+
+// orderByRaft sets the orderer metadata an etcdraft chain writes to the block.
+func orderByRaft(block *cb.Block) {
+	block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&etcdraft.BlockMetadata{ConsenterIds: []uint64{1}, NextConsenterId: 2, RaftIndex: 5}),
+	})
+}
+
+func TestMigration(t *testing.T) {
+	block := kafkaConfigBlock("mychannel")
+	metadata := raftMetadata(t)
+
+	for _, expected := range []Stage{StageKafka, StageKafkaMaintenance} {
+		status, err := Status(block)
+		require.NoError(t, err)
+		assert.Equal(t, &ChannelStatus{ChannelID: "mychannel", Stage: expected}, status)
+
+		configUpdate, err := NextUpdate(block, metadata)
+		require.NoError(t, err)
+		assert.Equal(t, "mychannel", configUpdate.ChannelId)
+		block = applyUpdate(t, block, configUpdate)
+	}
+
+	// The config block which changed the consensus type was ordered by kafka,
+	// hence the channel doesn't leave maintenance mode but is verified first
+	status, err := Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, &ChannelStatus{ChannelID: "mychannel", Stage: StageRaftMaintenance}, status)
+	ch, err := parseConfigBlock(block)
+	require.NoError(t, err)
+	current := ch.config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey]
+
+	configUpdate, err := NextUpdate(block, nil)
+	require.NoError(t, err)
+	written := configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey]
+	assert.Equal(t, current.Value, written.Value)
+	assert.Equal(t, current.Version+1, written.Version)
+	block = applyUpdate(t, block, configUpdate)
+
+	// The verification update is computed as long as the orderers haven't restarted on etcdraft
+	status, err = Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, &ChannelStatus{ChannelID: "mychannel", Stage: StageRaftMaintenance}, status)
+	configUpdate, err = NextUpdate(block, nil)
+	require.NoError(t, err)
+	assert.Equal(t, current.Value, configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value)
+
+	// Once the verification update is ordered by etcdraft, the channel leaves maintenance mode
+	orderByRaft(block)
+	status, err = Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, &ChannelStatus{ChannelID: "mychannel", Stage: StageRaftMaintenance, RaftVerified: true}, status)
+	configUpdate, err = NextUpdate(block, nil)
+	require.NoError(t, err)
+	block = applyUpdate(t, block, configUpdate)
+
+	status, err = Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, StageRaft, status.Stage)
+	assert.False(t, status.RaftVerified)
+
+	ch, err = parseConfigBlock(block)
+	require.NoError(t, err)
+	assert.Equal(t, "etcdraft", ch.orderer.ConsensusType())
+	assert.Equal(t, ab.ConsensusType_STATE_NORMAL, ch.orderer.ConsensusState())
+	assert.Equal(t, utils.MarshalOrPanic(metadata), ch.orderer.ConsensusMetadata())
+
+	_, err = NextUpdate(block, metadata)
+	assert.EqualError(t, err, "channel mychannel has already been migrated to etcdraft")
+	_, err = RollbackUpdate(block)
+	assert.EqualError(t, err, "channel mychannel has completed the migration and cannot be rolled back")
+
+	t.Run("ordered by etcdraft", func(t *testing.T) {
+		orderByRaft(block)
+		status, err := Status(block)
+		require.NoError(t, err)
+		assert.True(t, status.RaftVerified)
+	})
+
+	t.Run("ordered by kafka", func(t *testing.T) {
+		block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{
+			Value: utils.MarshalOrPanic(&ab.KafkaMetadata{LastOffsetPersisted: 12, LastOriginalOffsetProcessed: 3}),
+		})
+		status, err := Status(block)
+		require.NoError(t, err)
+		assert.False(t, status.RaftVerified)
+	})
+}
+
+func TestRollback(t *testing.T) {
+	start := kafkaConfigBlock("mychannel")
+
+	_, err := RollbackUpdate(start)
+	assert.EqualError(t, err, "channel mychannel has not started the migration")
+
+	configUpdate, err := NextUpdate(start, nil)
+	require.NoError(t, err)
+	maintenance := applyUpdate(t, start, configUpdate)
+	configUpdate, err = NextUpdate(maintenance, raftMetadata(t))
+	require.NoError(t, err)
+	raftMaintenance := applyUpdate(t, maintenance, configUpdate)
+
+	configUpdate, err = RollbackUpdate(raftMaintenance)
+	require.NoError(t, err)
+	block := applyUpdate(t, raftMaintenance, configUpdate)
+	status, err := Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, StageKafkaMaintenance, status.Stage)
+
+	configUpdate, err = RollbackUpdate(block)
+	require.NoError(t, err)
+	block = applyUpdate(t, block, configUpdate)
+	status, err = Status(block)
+	require.NoError(t, err)
+	assert.Equal(t, StageKafka, status.Stage)
+}
+
+func TestNextUpdateInvalidMetadata(t *testing.T) {
+	block := kafkaConfigBlock("mychannel")
+	configUpdate, err := NextUpdate(block, nil)
+	require.NoError(t, err)
+	block = applyUpdate(t, block, configUpdate)
+
+	_, err = NextUpdate(block, nil)
+	assert.EqualError(t, err, "invalid etcdraft metadata for channel mychannel: etcdraft metadata is required to change the consensus type")
+
+	_, err = NextUpdate(block, &etcdraft.ConfigMetadata{})
+	assert.EqualError(t, err, "invalid etcdraft metadata for channel mychannel: no consenters provided")
+
+	metadata := raftMetadata(t)
+	metadata.Consenters = append(metadata.Consenters, metadata.Consenters[0])
+	_, err = NextUpdate(block, metadata)
+	assert.EqualError(t, err, "invalid etcdraft metadata for channel mychannel: consenter orderer0:7050 is duplicated")
+
+	metadata = raftMetadata(t)
+	metadata.Consenters[0].Port = 0
+	_, err = NextUpdate(block, metadata)
+	assert.EqualError(t, err, "invalid etcdraft metadata for channel mychannel: consenter orderer0:0 has an invalid endpoint")
+
+	metadata = raftMetadata(t)
+	metadata.Consenters[0].ClientTlsCert = []byte("not a certificate")
+	_, err = NextUpdate(block, metadata)
+	assert.EqualError(t, err, "invalid etcdraft metadata for channel mychannel: invalid client TLS certificate of consenter orderer0:7050: certificate is not PEM encoded")
+}
+
+func TestNextUpdateWithoutCapability(t *testing.T) {
+	profile := configtxgentest.Load(genesisconfig.SampleDevModeKafkaProfile)
+	profile.Orderer.Capabilities = map[string]bool{"V1_1": true}
+	block := encoder.New(profile).GenesisBlockForChannel("mychannel")
+
+	_, err := NextUpdate(block, nil)
+	assert.EqualError(t, err, "channel mychannel does not have the orderer capability required for consensus-type migration")
+}
+
+func TestStatusNotConfigBlock(t *testing.T) {
+	block := cb.NewBlock(1, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_ENDORSER_TRANSACTION)}),
+			},
+		}),
+	})}
+	_, err := Status(block)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "block is not a config block")
+}
+
+func TestUpdateEnvelope(t *testing.T) {
+	configUpdate, err := NextUpdate(kafkaConfigBlock("mychannel"), nil)
+	require.NoError(t, err)
+
+	for _, signer := range []crypto.LocalSigner{nil, mockcrypto.FakeLocalSigner} {
+		env, err := UpdateEnvelope(configUpdate, signer)
+		require.NoError(t, err)
+
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		require.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		require.NoError(t, err)
+		assert.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+		assert.Equal(t, "mychannel", chdr.ChannelId)
+
+		configUpdateEnv, err := utils.EnvelopeToConfigUpdate(env)
+		require.NoError(t, err)
+		assert.Equal(t, utils.MarshalOrPanic(configUpdate), configUpdateEnv.ConfigUpdate)
+
+		if signer == nil {
+			assert.Empty(t, env.Signature)
+			assert.Empty(t, configUpdateEnv.Signatures)
+			continue
+		}
+		// The mock signer returns the signed message as the signature
+		assert.Equal(t, env.Payload, env.Signature)
+		require.Len(t, configUpdateEnv.Signatures, 1)
+		sigHeader, err := utils.GetSignatureHeader(configUpdateEnv.Signatures[0].SignatureHeader)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("IdentityBytes"), sigHeader.Creator)
+		assert.Equal(t, append(configUpdateEnv.Signatures[0].SignatureHeader, configUpdateEnv.ConfigUpdate...), configUpdateEnv.Signatures[0].Signature)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	_, err := Plan(nil)
+	assert.EqualError(t, err, "no channels provided")
+
+	ready, err := Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageKafkaMaintenance},
+		{ChannelID: "app1", Stage: StageKafka},
+		{ChannelID: "app2", Stage: StageKafkaMaintenance},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app1"}, ready)
+
+	// Channels don't leave maintenance mode until the etcdraft chains of all of them are verified
+	ready, err = Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageRaftMaintenance},
+		{ChannelID: "app1", Stage: StageRaftMaintenance},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"system", "app1"}, ready)
+
+	ready, err = Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageRaftMaintenance, RaftVerified: true},
+		{ChannelID: "app1", Stage: StageRaftMaintenance},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app1"}, ready)
+
+	ready, err = Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageRaftMaintenance, RaftVerified: true},
+		{ChannelID: "app1", Stage: StageRaftMaintenance, RaftVerified: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"system", "app1"}, ready)
+
+	ready, err = Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageRaft},
+		{ChannelID: "app1", Stage: StageRaft},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, ready)
+
+	_, err = Plan([]*ChannelStatus{
+		{ChannelID: "system", Stage: StageRaftMaintenance},
+		{ChannelID: "app1", Stage: StageKafka},
+	})
+	assert.EqualError(t, err, "channels are more than one stage apart, from kafka to etcdraft-maintenance")
+}
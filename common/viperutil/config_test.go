@@ -207,6 +207,31 @@ func TestByteSizeOverflow(t *testing.T) {
 	}
 }
 
+type testByteSize64 struct {
+	Inner struct {
+		ByteSize uint64
+	}
+}
+
+func TestByteSize64(t *testing.T) {
+	config := viper.New()
+	config.SetConfigType("yaml")
+
+	data := "---\nInner:\n    ByteSize: 10 GB"
+	err := config.ReadConfig(bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatalf("Error reading config: %s", err)
+	}
+	var uconf testByteSize64
+	err = EnhancedExactUnmarshal(config, &uconf)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal with: %s", err)
+	}
+	if uconf.Inner.ByteSize != 10*1024*1024*1024 {
+		t.Fatalf("Did not get back the right byte size, expected: %v got %v", 10*1024*1024*1024, uconf.Inner.ByteSize)
+	}
+}
+
 type stringFromFileConfig struct {
 	Inner struct {
 		Single   string
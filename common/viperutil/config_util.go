@@ -138,7 +138,7 @@ func customDecodeHook() mapstructure.DecodeHookFunc {
 
 func byteSizeDecodeHook() mapstructure.DecodeHookFunc {
 	return func(f reflect.Kind, t reflect.Kind, data interface{}) (interface{}, error) {
-		if f != reflect.String || t != reflect.Uint32 {
+		if f != reflect.String || (t != reflect.Uint32 && t != reflect.Uint64) {
 			return data, nil
 		}
 		raw := data.(string)
@@ -162,7 +162,7 @@ func byteSizeDecodeHook() mapstructure.DecodeHookFunc {
 			case "k":
 				size = size << 10
 			}
-			if size > math.MaxUint32 {
+			if t == reflect.Uint32 && size > math.MaxUint32 {
 				return size, fmt.Errorf("value '%s' overflows uint32", raw)
 			}
 			return size, nil
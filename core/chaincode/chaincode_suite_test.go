@@ -48,6 +48,11 @@ type certGenerator interface {
 	chaincode.CertGenerator
 }
 
+//go:generate counterfeiter -o mock/external_builder.go --fake-name ExternalBuilder . externalBuilder
+type externalBuilder interface {
+	chaincode.ExternalBuilder
+}
+
 //go:generate counterfeiter -o mock/processor.go --fake-name Processor . processor
 type processor interface {
 	chaincode.Processor
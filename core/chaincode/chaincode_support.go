@@ -13,10 +13,13 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/wasmcc"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -58,6 +61,12 @@ type ChaincodeSupport struct {
 	appConfig        ApplicationConfigRetriever
 	HandlerMetrics   *HandlerMetrics
 	LaunchMetrics    *LaunchMetrics
+	RuntimeParams    *pb.ChaincodeAdditionalParams
+	Limits           ChaincodeLimits
+
+	// RecordCrossChannelReads enables recording the reads of chaincodes
+	// invoked on other channels in the transaction
+	RecordCrossChannelReads bool
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -87,6 +96,14 @@ func NewChaincodeSupport(
 		appConfig:        appConfig,
 		HandlerMetrics:   NewHandlerMetrics(metricsProvider),
 		LaunchMetrics:    NewLaunchMetrics(metricsProvider),
+		RuntimeParams: &pb.ChaincodeAdditionalParams{
+			UseWriteBatch:          config.UseWriteBatch,
+			MaxSizeWriteBatch:      config.MaxSizeWriteBatch,
+			UseGetMultipleKeys:     config.UseGetMultipleKeys,
+			MaxSizeGetMultipleKeys: config.MaxSizeGetMultipleKeys,
+		},
+		Limits:                  config.Limits,
+		RecordCrossChannelReads: config.CrossChannelReadProofs,
 	}
 
 	// Keep TestQueries working
@@ -94,18 +111,32 @@ func NewChaincodeSupport(
 		certGenerator = nil
 	}
 
-	cs.Runtime = &ContainerRuntime{
+	containerRuntime := &ContainerRuntime{
 		CertGenerator:    certGenerator,
 		Processor:        processor,
 		CACert:           caCert,
 		PeerAddress:      peerAddress,
 		PlatformRegistry: platformRegistry,
+		ExternalRuntime:  &extcc.ExternalChaincodeRuntime{StreamHandler: cs},
+		Limits:           config.Limits,
 		CommonEnv: []string{
 			"CORE_CHAINCODE_LOGGING_LEVEL=" + config.LogLevel,
 			"CORE_CHAINCODE_LOGGING_SHIM=" + config.ShimLogLevel,
 			"CORE_CHAINCODE_LOGGING_FORMAT=" + config.LogFormat,
 		},
+		WasmRuntime: &wasmcc.WasmChaincodeRuntime{
+			StreamHandler:  cs,
+			MemoryLimit:    config.WasmMemoryLimit,
+			ExecuteTimeout: cs.executeTimeout,
+		},
+	}
+
+	if len(config.ExternalBuilders) > 0 {
+		containerRuntime.ExternalBuilder = &externalbuilders.Detector{
+			Builders: externalbuilders.CreateBuilders(config.ExternalBuilders),
+		}
 	}
+	cs.Runtime = containerRuntime
 
 	cs.Launcher = &RuntimeLauncher{
 		Runtime:         cs.Runtime,
@@ -186,6 +217,9 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		LedgerGetter:               peer.Default,
 		AppConfig:                  cs.appConfig,
 		Metrics:                    cs.HandlerMetrics,
+		RuntimeParams:              cs.RuntimeParams,
+		Limits:                     cs.Limits,
+		RecordCrossChannelReads:    cs.RecordCrossChannelReads,
 	}
 
 	return handler.ProcessStream(stream)
@@ -237,7 +271,11 @@ func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionPa
 // Execute invokes chaincode and returns the original response.
 func (cs *ChaincodeSupport) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
 	resp, err := cs.Invoke(txParams, cccid, input)
-	return processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	res, event, err := processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	if err == nil {
+		txParams.ChaincodeEvents = resp.ChaincodeEvents
+	}
+	return res, event, err
 }
 
 func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMessage, err error) (*pb.Response, *pb.ChaincodeEvent, error) {
@@ -252,6 +290,10 @@ func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMess
 		resp.ChaincodeEvent.ChaincodeId = ccName
 		resp.ChaincodeEvent.TxId = txid
 	}
+	for _, event := range resp.ChaincodeEvents {
+		event.ChaincodeId = ccName
+		event.TxId = txid
+	}
 
 	switch resp.Type {
 	case pb.ChaincodeMessage_COMPLETED:
@@ -302,7 +344,8 @@ func (cs *ChaincodeSupport) Invoke(txParams *ccprovider.TransactionParams, cccid
 	return cs.execute(cctype, txParams, cccid, input, h)
 }
 
-// execute executes a transaction and waits for it to complete until a timeout value.
+// execute executes a transaction and waits for it to complete until a timeout
+// value, which is the execute timeout of the chaincode when it has one.
 func (cs *ChaincodeSupport) execute(cctyp pb.ChaincodeMessage_Type, txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput, h *Handler) (*pb.ChaincodeMessage, error) {
 	input.Decorations = txParams.ProposalDecorations
 	ccMsg, err := createCCMessage(cctyp, txParams.ChannelID, txParams.TxID, input)
@@ -310,10 +353,19 @@ func (cs *ChaincodeSupport) execute(cctyp pb.ChaincodeMessage_Type, txParams *cc
 		return nil, errors.WithMessage(err, "failed to create chaincode message")
 	}
 
-	ccresp, err := h.Execute(txParams, cccid, ccMsg, cs.ExecuteTimeout)
+	ccresp, err := h.Execute(txParams, cccid, ccMsg, cs.executeTimeout(cccid.Name))
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error sending"))
 	}
 
 	return ccresp, nil
 }
+
+// executeTimeout returns the execute timeout of the chaincode, which is the
+// execute timeout of the peer unless the limits of the chaincode override it.
+func (cs *ChaincodeSupport) executeTimeout(ccName string) time.Duration {
+	if limits := cs.Limits.For(ccName); limits.ExecuteTimeout > 0 {
+		return limits.ExecuteTimeout
+	}
+	return cs.ExecuteTimeout
+}
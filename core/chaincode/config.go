@@ -12,13 +12,17 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
 	logging "github.com/op/go-logging"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
 const (
 	defaultExecutionTimeout = 30 * time.Second
 	minimumStartupTimeout   = 5 * time.Second
+	defaultMaxSizeBatch     = 1000
 )
 
 type Config struct {
@@ -29,6 +33,58 @@ type Config struct {
 	LogFormat      string
 	LogLevel       string
 	ShimLogLevel   string
+
+	ExternalBuilders []externalbuilders.Config
+
+	// WasmMemoryLimit is the maximum size in bytes of the memory of an
+	// instance of a WASM chaincode, unlimited when zero
+	WasmMemoryLimit int64
+
+	UseWriteBatch          bool
+	MaxSizeWriteBatch      uint32
+	UseGetMultipleKeys     bool
+	MaxSizeGetMultipleKeys uint32
+
+	Limits ChaincodeLimits
+
+	CrossChannelReadProofs bool
+}
+
+// Limits holds the execution limits of a chaincode. A zero value means the
+// corresponding limit isn't set.
+type Limits struct {
+	// ExecuteTimeout overrides the execute timeout of the peer
+	ExecuteTimeout time.Duration
+	// MaxConcurrency is the maximum number of transactions in flight on
+	// the handler of the chaincode
+	MaxConcurrency int
+	// MaxResponseSize is the maximum size in bytes of a chaincode response
+	MaxResponseSize int
+	// MaxEventSize is the maximum size in bytes of a chaincode event
+	MaxEventSize int
+	// Resources holds the resource limits of the chaincode container
+	Resources ccintf.ResourceLimits
+}
+
+// ChaincodeLimits maps chaincode names to their execution limits.
+type ChaincodeLimits map[string]Limits
+
+// For returns the execution limits of the named chaincode. Names are
+// matched regardless of case as viper lowercases configuration keys.
+func (cl ChaincodeLimits) For(ccName string) Limits {
+	return cl[strings.ToLower(ccName)]
+}
+
+// limitsConfig is the peer-local configuration of the limits of a chaincode
+type limitsConfig struct {
+	ExecuteTimeout  string
+	MaxConcurrency  int
+	MaxResponseSize int
+	MaxEventSize    int
+	Memory          int64
+	CPUShares       int64
+	CPUQuota        int64
+	CPUPeriod       int64
 }
 
 func GlobalConfig() *Config {
@@ -58,6 +114,58 @@ func (c *Config) load() {
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
+
+	c.UseWriteBatch = viper.GetBool("chaincode.runtimeParams.useWriteBatch")
+	c.MaxSizeWriteBatch = toMaxSize(viper.GetInt("chaincode.runtimeParams.maxSizeWriteBatch"))
+	c.UseGetMultipleKeys = viper.GetBool("chaincode.runtimeParams.useGetMultipleKeys")
+	c.MaxSizeGetMultipleKeys = toMaxSize(viper.GetInt("chaincode.runtimeParams.maxSizeGetMultipleKeys"))
+
+	if err := viper.UnmarshalKey("chaincode.externalBuilders", &c.ExternalBuilders); err != nil {
+		chaincodeLogger.Panicf("invalid external builders configuration: %s", err)
+	}
+
+	c.WasmMemoryLimit = int64(viper.GetInt("chaincode.wasm.memoryLimit"))
+
+	c.CrossChannelReadProofs = viper.GetBool("chaincode.crossChannelReadProofs")
+
+	limits, err := loadLimits("chaincode.limits")
+	if err != nil {
+		chaincodeLogger.Panicf("invalid chaincode limits configuration: %s", err)
+	}
+	c.Limits = limits
+}
+
+// loadLimits reads the per-chaincode execution limits under the key
+func loadLimits(key string) (ChaincodeLimits, error) {
+	var configs map[string]limitsConfig
+	if err := viper.UnmarshalKey(key, &configs); err != nil {
+		return nil, err
+	}
+
+	limits := ChaincodeLimits{}
+	for name, lc := range configs {
+		var executeTimeout time.Duration
+		if lc.ExecuteTimeout != "" {
+			var err error
+			executeTimeout, err = time.ParseDuration(lc.ExecuteTimeout)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid execute timeout for chaincode %s", name)
+			}
+		}
+		limits[strings.ToLower(name)] = Limits{
+			ExecuteTimeout:  executeTimeout,
+			MaxConcurrency:  lc.MaxConcurrency,
+			MaxResponseSize: lc.MaxResponseSize,
+			MaxEventSize:    lc.MaxEventSize,
+			Resources: ccintf.ResourceLimits{
+				Memory:    lc.Memory,
+				CPUShares: lc.CPUShares,
+				CPUQuota:  lc.CPUQuota,
+				CPUPeriod: lc.CPUPeriod,
+			},
+		}
+	}
+	return limits, nil
 }
 
 func toSeconds(s string, def int) time.Duration {
@@ -69,6 +177,15 @@ func toSeconds(s string, def int) time.Duration {
 	return time.Duration(seconds) * time.Second
 }
 
+// toMaxSize returns the size as a batch size limit, falling back to the
+// default limit when the size isn't positive
+func toMaxSize(size int) uint32 {
+	if size <= 0 {
+		return defaultMaxSizeBatch
+	}
+	return uint32(size)
+}
+
 // getLogLevelFromViper gets the chaincode container log levels from viper
 func getLogLevelFromViper(key string) string {
 	levelString := viper.GetString(key)
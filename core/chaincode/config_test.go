@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/spf13/viper"
@@ -35,6 +37,7 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "WARNING")
 			viper.Set("chaincode.logging.shim", "WARNING")
+			viper.Set("chaincode.crossChannelReadProofs", "true")
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
@@ -44,6 +47,91 @@ var _ = Describe("Config", func() {
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("WARNING"))
 			Expect(config.ShimLogLevel).To(Equal("WARNING"))
+			Expect(config.CrossChannelReadProofs).To(BeTrue())
+		})
+
+		Context("when external builders are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.externalBuilders", []map[string]interface{}{
+					{"name": "builder", "path": "/builders/builder", "environmentWhitelist": []string{"GOPROXY"}},
+				})
+			})
+
+			AfterEach(func() {
+				viper.Set("chaincode.externalBuilders", nil)
+			})
+
+			It("captures the external builders", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ExternalBuilders).To(Equal([]externalbuilders.Config{
+					{Name: "builder", Path: "/builders/builder", EnvironmentWhitelist: []string{"GOPROXY"}},
+				}))
+			})
+		})
+
+		Context("when runtime parameters are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.runtimeParams.useWriteBatch", "true")
+				viper.Set("chaincode.runtimeParams.maxSizeWriteBatch", "100")
+				viper.Set("chaincode.runtimeParams.useGetMultipleKeys", "true")
+				viper.Set("chaincode.runtimeParams.maxSizeGetMultipleKeys", "0")
+			})
+
+			It("captures the runtime parameters", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.UseWriteBatch).To(BeTrue())
+				Expect(config.MaxSizeWriteBatch).To(Equal(uint32(100)))
+				Expect(config.UseGetMultipleKeys).To(BeTrue())
+				Expect(config.MaxSizeGetMultipleKeys).To(Equal(uint32(1000)))
+			})
+		})
+
+		Context("when chaincode limits are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.limits", map[string]interface{}{
+					"MyCC": map[string]interface{}{
+						"executeTimeout":  "2m",
+						"maxConcurrency":  4,
+						"maxResponseSize": 1024,
+						"maxEventSize":    512,
+						"memory":          268435456,
+						"cpuShares":       512,
+					},
+				})
+			})
+
+			AfterEach(func() {
+				viper.Set("chaincode.limits", nil)
+			})
+
+			It("captures the limits of each chaincode", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Limits).To(HaveLen(1))
+				Expect(config.Limits.For("mycc")).To(Equal(chaincode.Limits{
+					ExecuteTimeout:  2 * time.Minute,
+					MaxConcurrency:  4,
+					MaxResponseSize: 1024,
+					MaxEventSize:    512,
+					Resources: ccintf.ResourceLimits{
+						Memory:    268435456,
+						CPUShares: 512,
+					},
+				}))
+				Expect(config.Limits.For("MYCC")).To(Equal(config.Limits.For("mycc")))
+				Expect(config.Limits.For("othercc")).To(Equal(chaincode.Limits{}))
+			})
+
+			Context("when the execute timeout is invalid", func() {
+				BeforeEach(func() {
+					viper.Set("chaincode.limits", map[string]interface{}{
+						"mycc": map[string]interface{}{"executeTimeout": "forever"},
+					})
+				})
+
+				It("panics", func() {
+					Expect(func() { chaincode.GlobalConfig() }).To(Panic())
+				})
+			})
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -119,6 +207,13 @@ func capture() (restore func()) {
 		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
 		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
 		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+
+		"chaincode.crossChannelReadProofs": viper.GetString("chaincode.crossChannelReadProofs"),
+
+		"chaincode.runtimeParams.useWriteBatch":          viper.GetString("chaincode.runtimeParams.useWriteBatch"),
+		"chaincode.runtimeParams.maxSizeWriteBatch":      viper.GetString("chaincode.runtimeParams.maxSizeWriteBatch"),
+		"chaincode.runtimeParams.useGetMultipleKeys":     viper.GetString("chaincode.runtimeParams.useGetMultipleKeys"),
+		"chaincode.runtimeParams.maxSizeGetMultipleKeys": viper.GetString("chaincode.runtimeParams.maxSizeGetMultipleKeys"),
 	}
 
 	return func() {
@@ -13,10 +13,14 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/wasmcc"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
+	"github.com/hyperledger/fabric/core/container/inproccontroller"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -33,6 +37,22 @@ type CertGenerator interface {
 	Generate(ccName string) (*accesscontrol.CertAndPrivKeyPair, error)
 }
 
+// ExternalBuilder builds and runs chaincode through external builders
+// instead of Docker.
+type ExternalBuilder interface {
+	// Build builds the code package with the first external builder which
+	// detects it, and returns false when none of them does.
+	Build(ccid string, buildInfo *externalbuilders.BuildInfo, codePackage []byte) (bool, error)
+	// Run starts the chaincode built by an external builder.
+	Run(ccid string, peerConnection *ccintf.PeerConnection) error
+	// Owns returns whether the chaincode was built by an external builder.
+	Owns(ccid string) bool
+	// Stop terminates the chaincode built by an external builder.
+	Stop(ccid string) error
+	// Wait waits for the chaincode built by an external builder to exit.
+	Wait(ccid string) (int, error)
+}
+
 // ContainerRuntime is responsible for managing containerized chaincode.
 type ContainerRuntime struct {
 	CertGenerator    CertGenerator
@@ -41,12 +61,44 @@ type ContainerRuntime struct {
 	CommonEnv        []string
 	PeerAddress      string
 	PlatformRegistry *platforms.Registry
+
+	// ExternalRuntime manages the chaincodes running as external servers
+	ExternalRuntime Runtime
+
+	// WasmRuntime manages the chaincodes compiled to WebAssembly, which are
+	// executed in-process
+	WasmRuntime Runtime
+
+	// ExternalBuilder, when set, is given the chance to build and run
+	// chaincode before falling back to the container processor
+	ExternalBuilder ExternalBuilder
+
+	// Limits holds the resource limits applied to chaincode containers
+	Limits ChaincodeLimits
 }
 
 // Start launches chaincode in a runtime environment.
 func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	if ccci.ContainerType == extcc.ContainerType {
+		return c.ExternalRuntime.Start(ccci, codePackage)
+	}
+	if ccci.ContainerType == wasmcc.ContainerType {
+		return c.WasmRuntime.Start(ccci, codePackage)
+	}
+
 	cname := ccci.Name + ":" + ccci.Version
 
+	if c.ExternalBuilder != nil && ccci.ContainerType != inproccontroller.ContainerType {
+		buildInfo := &externalbuilders.BuildInfo{Type: ccci.Type, Path: ccci.Path}
+		built, err := c.ExternalBuilder.Build(cname, buildInfo, codePackage)
+		if err != nil {
+			return errors.WithMessage(err, "error building chaincode")
+		}
+		if built {
+			return c.runExternal(cname)
+		}
+	}
+
 	lc, err := c.LaunchConfig(cname, ccci.Type)
 	if err != nil {
 		return err
@@ -73,6 +125,9 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 			Version: ccci.Version,
 		},
 	}
+	if resources := c.Limits.For(ccci.Name).Resources; resources != (ccintf.ResourceLimits{}) {
+		scr.Resources = &resources
+	}
 
 	if err := c.Processor.Process(ccci.ContainerType, scr); err != nil {
 		return errors.WithMessage(err, "error starting container")
@@ -83,6 +138,18 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 
 // Stop terminates chaincode and its container runtime environment.
 func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
+	if ccci.ContainerType == extcc.ContainerType {
+		return c.ExternalRuntime.Stop(ccci)
+	}
+	if ccci.ContainerType == wasmcc.ContainerType {
+		return c.WasmRuntime.Stop(ccci)
+	}
+
+	cname := ccci.Name + ":" + ccci.Version
+	if c.ExternalBuilder != nil && c.ExternalBuilder.Owns(cname) {
+		return c.ExternalBuilder.Stop(cname)
+	}
+
 	scr := container.StopContainerReq{
 		CCID: ccintf.CCID{
 			Name:    ccci.Name,
@@ -101,6 +168,18 @@ func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
 
 // Wait waits for the container runtime to terminate.
 func (c *ContainerRuntime) Wait(ccci *ccprovider.ChaincodeContainerInfo) (int, error) {
+	if ccci.ContainerType == extcc.ContainerType {
+		return c.ExternalRuntime.Wait(ccci)
+	}
+	if ccci.ContainerType == wasmcc.ContainerType {
+		return c.WasmRuntime.Wait(ccci)
+	}
+
+	cname := ccci.Name + ":" + ccci.Version
+	if c.ExternalBuilder != nil && c.ExternalBuilder.Owns(cname) {
+		return c.ExternalBuilder.Wait(cname)
+	}
+
 	type result struct {
 		exitCode int
 		err      error
@@ -126,6 +205,30 @@ func (c *ContainerRuntime) Wait(ccci *ccprovider.ChaincodeContainerInfo) (int, e
 	return r.exitCode, r.err
 }
 
+// runExternal runs the chaincode built by an external builder as a local
+// process, handing it the peer connection information.
+func (c *ContainerRuntime) runExternal(cname string) error {
+	peerConnection := &ccintf.PeerConnection{Address: c.PeerAddress}
+	if c.CertGenerator != nil {
+		certKeyPair, err := c.CertGenerator.Generate(cname)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to generate TLS certificates for %s", cname))
+		}
+		peerConnection.TLSConfig = &ccintf.TLSConfig{
+			ClientCert: []byte(certKeyPair.Cert),
+			ClientKey:  []byte(certKeyPair.Key),
+			RootCert:   c.CACert,
+		}
+	}
+
+	chaincodeLogger.Debugf("run external chaincode: %s", cname)
+	if err := c.ExternalBuilder.Run(cname, peerConnection); err != nil {
+		return errors.WithMessage(err, "error running chaincode")
+	}
+
+	return nil
+}
+
 const (
 	// Mutual TLS auth client key and cert paths in the chaincode container
 	TLSClientKeyPath      string = "/etc/hyperledger/fabric/client.key"
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -187,6 +188,34 @@ func TestContainerRuntimeStart(t *testing.T) {
 		Name:    "chaincode-name",
 		Version: "chaincode-version",
 	})
+	assert.Nil(t, startReq.Resources)
+}
+
+func TestContainerRuntimeStartResourceLimits(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		PeerAddress: "peer.example.com",
+		Limits: chaincode.ChaincodeLimits{
+			"chaincode-name": {Resources: ccintf.ResourceLimits{Memory: 1024, CPUShares: 512}},
+		},
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Name:          "Chaincode-Name",
+		Version:       "chaincode-version",
+		ContainerType: "container-type",
+	}
+
+	err := cr.Start(ccci, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	_, req := fakeProcessor.ProcessArgsForCall(0)
+	startReq, ok := req.(container.StartContainerReq)
+	assert.True(t, ok)
+	assert.Equal(t, &ccintf.ResourceLimits{Memory: 1024, CPUShares: 512}, startReq.Resources)
 }
 
 func TestContainerRuntimeStartErrors(t *testing.T) {
@@ -314,3 +343,166 @@ func TestContainerRuntimeWait(t *testing.T) {
 	_, err = cr.Wait(ccci)
 	assert.EqualError(t, err, "moles-and-trolls")
 }
+
+func TestContainerRuntimeExternal(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	fakeExternalRuntime := &mock.Runtime{}
+	fakeExternalRuntime.WaitReturns(0, errors.New("stream-closed"))
+	cr := &chaincode.ContainerRuntime{
+		Processor:       fakeProcessor,
+		ExternalRuntime: fakeExternalRuntime,
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          "EXTERNAL",
+		Name:          "chaincode-id-name",
+		Version:       "chaincode-version",
+		ContainerType: "EXTERNAL",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalRuntime.StartCallCount())
+	startCCCI, codePackage := fakeExternalRuntime.StartArgsForCall(0)
+	assert.Equal(t, ccci, startCCCI)
+	assert.Equal(t, []byte("code-package"), codePackage)
+
+	_, err = cr.Wait(ccci)
+	assert.EqualError(t, err, "stream-closed")
+	assert.Equal(t, 1, fakeExternalRuntime.WaitCallCount())
+
+	err = cr.Stop(ccci)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalRuntime.StopCallCount())
+
+	assert.Equal(t, 0, fakeProcessor.ProcessCallCount())
+}
+
+func TestContainerRuntimeWasm(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	fakeWasmRuntime := &mock.Runtime{}
+	fakeWasmRuntime.WaitReturns(0, errors.New("chaincode-stopped"))
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		WasmRuntime: fakeWasmRuntime,
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          "WASM",
+		Name:          "chaincode-id-name",
+		Version:       "chaincode-version",
+		ContainerType: "WASM",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeWasmRuntime.StartCallCount())
+	startCCCI, codePackage := fakeWasmRuntime.StartArgsForCall(0)
+	assert.Equal(t, ccci, startCCCI)
+	assert.Equal(t, []byte("code-package"), codePackage)
+
+	_, err = cr.Wait(ccci)
+	assert.EqualError(t, err, "chaincode-stopped")
+	assert.Equal(t, 1, fakeWasmRuntime.WaitCallCount())
+
+	err = cr.Stop(ccci)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeWasmRuntime.StopCallCount())
+
+	assert.Equal(t, 0, fakeProcessor.ProcessCallCount())
+}
+
+func TestContainerRuntimeExternalBuilder(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	fakeCertGenerator := &mock.CertGenerator{}
+	fakeCertGenerator.GenerateReturns(&accesscontrol.CertAndPrivKeyPair{Cert: "certificate", Key: "key"}, nil)
+	fakeExternalBuilder := &mock.ExternalBuilder{}
+	fakeExternalBuilder.BuildReturns(true, nil)
+	fakeExternalBuilder.OwnsReturns(true)
+	fakeExternalBuilder.WaitReturns(2, nil)
+	cr := &chaincode.ContainerRuntime{
+		Processor:       fakeProcessor,
+		CertGenerator:   fakeCertGenerator,
+		CACert:          []byte("ca-certificate"),
+		PeerAddress:     "peer-address",
+		ExternalBuilder: fakeExternalBuilder,
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Path:          "chaincode-path",
+		Name:          "chaincode-id-name",
+		Version:       "chaincode-version",
+		ContainerType: "DOCKER",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalBuilder.BuildCallCount())
+	ccid, buildInfo, codePackage := fakeExternalBuilder.BuildArgsForCall(0)
+	assert.Equal(t, "chaincode-id-name:chaincode-version", ccid)
+	assert.Equal(t, &externalbuilders.BuildInfo{Type: "GOLANG", Path: "chaincode-path"}, buildInfo)
+	assert.Equal(t, []byte("code-package"), codePackage)
+
+	assert.Equal(t, 1, fakeExternalBuilder.RunCallCount())
+	ccid, peerConnection := fakeExternalBuilder.RunArgsForCall(0)
+	assert.Equal(t, "chaincode-id-name:chaincode-version", ccid)
+	assert.Equal(t, &ccintf.PeerConnection{
+		Address: "peer-address",
+		TLSConfig: &ccintf.TLSConfig{
+			ClientCert: []byte("certificate"),
+			ClientKey:  []byte("key"),
+			RootCert:   []byte("ca-certificate"),
+		},
+	}, peerConnection)
+
+	exitCode, err := cr.Wait(ccci)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, exitCode)
+
+	err = cr.Stop(ccci)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalBuilder.StopCallCount())
+	assert.Equal(t, 0, fakeProcessor.ProcessCallCount())
+
+	fakeExternalBuilder.RunReturns(errors.New("no-exec"))
+	err = cr.Start(ccci, []byte("code-package"))
+	assert.EqualError(t, err, "error running chaincode: no-exec")
+
+	fakeExternalBuilder.BuildReturns(false, errors.New("no-build"))
+	err = cr.Start(ccci, []byte("code-package"))
+	assert.EqualError(t, err, "error building chaincode: no-build")
+}
+
+func TestContainerRuntimeExternalBuilderNotDetected(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	fakeExternalBuilder := &mock.ExternalBuilder{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:       fakeProcessor,
+		PeerAddress:     "peer-address",
+		ExternalBuilder: fakeExternalBuilder,
+	}
+
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Type:          pb.ChaincodeSpec_GOLANG.String(),
+		Name:          "chaincode-id-name",
+		Version:       "chaincode-version",
+		ContainerType: "DOCKER",
+	}
+
+	err := cr.Start(ccci, []byte("code-package"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalBuilder.BuildCallCount())
+	assert.Equal(t, 0, fakeExternalBuilder.RunCallCount())
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+
+	err = cr.Stop(ccci)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fakeExternalBuilder.StopCallCount())
+	assert.Equal(t, 2, fakeProcessor.ProcessCallCount())
+
+	ccci.ContainerType = "SYSTEM"
+	err = cr.Start(ccci, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeExternalBuilder.BuildCallCount())
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// CrossChannelSimulator is the transaction simulator of a chaincode invoked
+// read-only on another channel than the one of the transaction. The
+// simulation results of the other channel never make it to the transaction,
+// so writes fail instead of being silently discarded, and the first rejected
+// write is kept so that the invocation fails even when the chaincode ignores
+// the error.
+type CrossChannelSimulator struct {
+	ledger.TxSimulator
+	ChannelID string
+
+	mutex    sync.Mutex
+	writeErr error
+}
+
+// WriteErr returns the error of the first write attempted through the
+// simulator, if any.
+func (c *CrossChannelSimulator) WriteErr() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.writeErr
+}
+
+func (c *CrossChannelSimulator) rejectWrite(namespace string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	err := errors.Errorf("chaincode %s cannot write to channel %s: cross-channel invocations are read-only", namespace, c.ChannelID)
+	if c.writeErr == nil {
+		c.writeErr = err
+	}
+	return err
+}
+
+func (c *CrossChannelSimulator) SetState(namespace, key string, value []byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) DeleteState(namespace, key string) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) DeleteStateMetadata(namespace, key string) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) ExecuteUpdate(query string) error {
+	return c.rejectWrite("")
+}
+
+func (c *CrossChannelSimulator) SetPrivateData(namespace, collection, key string, value []byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) DeletePrivateData(namespace, collection, key string) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
+	return c.rejectWrite(namespace)
+}
+
+func (c *CrossChannelSimulator) DeletePrivateDataMetadata(namespace, collection, key string) error {
+	return c.rejectWrite(namespace)
+}
+
+// CrossChannelRead returns the proof of what the chaincode read on the
+// channel.
+func (c *CrossChannelSimulator) CrossChannelRead(chaincodeName string) (*pb.CrossChannelRead, error) {
+	simRes, err := c.GetTxSimulationResults()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get the simulation results")
+	}
+	results, err := simRes.GetPubSimulationBytes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal the simulation results")
+	}
+	return &pb.CrossChannelRead{
+		ChannelId:     c.ChannelID,
+		ChaincodeName: chaincodeName,
+		Results:       results,
+	}, nil
+}
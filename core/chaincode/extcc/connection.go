@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ChaincodeType is the type, as found in the metadata of a chaincode
+	// package, of chaincodes running as external servers
+	ChaincodeType = "EXTERNAL"
+
+	// ContainerType is the container type of chaincodes running as
+	// external servers
+	ContainerType = "EXTERNAL"
+
+	// ConnectionFile is the name of the file of the code package which
+	// holds the connection information of the chaincode server
+	ConnectionFile = "connection.json"
+
+	// DefaultDialTimeout is the dial timeout used when the connection
+	// information doesn't specify one
+	DefaultDialTimeout = 3 * time.Second
+)
+
+// ConnectionInfo is the connection information of a chaincode server,
+// as found in the code package of an external chaincode
+type ConnectionInfo struct {
+	Address            string `json:"address"`
+	DialTimeout        string `json:"dial_timeout"`
+	TLSRequired        bool   `json:"tls_required"`
+	ClientAuthRequired bool   `json:"client_auth_required"`
+	ClientKey          string `json:"client_key"`
+	ClientCert         string `json:"client_cert"`
+	RootCert           string `json:"root_cert"`
+}
+
+// ChaincodeServerInfo holds what is needed to dial a chaincode server
+type ChaincodeServerInfo struct {
+	Address      string
+	ClientConfig comm.ClientConfig
+}
+
+// ParseConnectionInfo extracts the connection information from the
+// gzipped tar code package of an external chaincode
+func ParseConnectionInfo(codePackage []byte) (*ConnectionInfo, error) {
+	gzReader, err := gzip.NewReader(bytes.NewBuffer(codePackage))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading as gzip stream")
+	}
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error inspecting next tar header")
+		}
+
+		if header.Name != ConnectionFile {
+			continue
+		}
+
+		fileBytes, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s from tar", header.Name)
+		}
+
+		connInfo := &ConnectionInfo{}
+		if err := json.Unmarshal(fileBytes, connInfo); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s as json", ConnectionFile)
+		}
+		return connInfo, nil
+	}
+
+	return nil, errors.Errorf("did not find %s inside the code package", ConnectionFile)
+}
+
+// ChaincodeServerInfo validates the connection information and returns
+// the information needed to dial the chaincode server
+func (c *ConnectionInfo) ChaincodeServerInfo() (*ChaincodeServerInfo, error) {
+	if c.Address == "" {
+		return nil, errors.New("chaincode address is not provided")
+	}
+
+	timeout := DefaultDialTimeout
+	if c.DialTimeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(c.DialTimeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid dial timeout '%s'", c.DialTimeout)
+		}
+	}
+
+	clientConfig := comm.ClientConfig{
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: timeout,
+		SecOpts: &comm.SecureOptions{},
+	}
+
+	if !c.TLSRequired {
+		return &ChaincodeServerInfo{Address: c.Address, ClientConfig: clientConfig}, nil
+	}
+
+	if c.RootCert == "" {
+		return nil, errors.New("chaincode root cert is required when TLS is enabled")
+	}
+	clientConfig.SecOpts.UseTLS = true
+	clientConfig.SecOpts.ServerRootCAs = [][]byte{[]byte(c.RootCert)}
+
+	if c.ClientAuthRequired {
+		if c.ClientKey == "" || c.ClientCert == "" {
+			return nil, errors.New("chaincode client key and cert are required when client authentication is enabled")
+		}
+		clientConfig.SecOpts.RequireClientCert = true
+		clientConfig.SecOpts.Key = []byte(c.ClientKey)
+		clientConfig.SecOpts.Certificate = []byte(c.ClientCert)
+	}
+
+	return &ChaincodeServerInfo{Address: c.Address, ClientConfig: clientConfig}, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var logger = flogging.MustGetLogger("chaincode.extcc")
+
+// StreamHandler handles the chaincode stream established with a
+// chaincode server
+type StreamHandler interface {
+	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
+}
+
+// instance is a connection to a running chaincode server
+type instance struct {
+	conn   *grpc.ClientConn
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// ExternalChaincodeRuntime is a chaincode runtime which, instead of
+// launching the chaincode, connects to chaincode running as a server
+// and processes the chaincode stream over that connection.
+type ExternalChaincodeRuntime struct {
+	StreamHandler StreamHandler
+
+	mutex     sync.Mutex
+	instances map[string]*instance
+}
+
+// Start connects to the chaincode server described by the connection
+// information of the code package and starts handling the stream.
+func (r *ExternalChaincodeRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	cname := ccci.Name + ":" + ccci.Version
+
+	connInfo, err := ParseConnectionInfo(codePackage)
+	if err != nil {
+		return errors.WithMessage(err, "could not parse connection information of chaincode "+cname)
+	}
+	serverInfo, err := connInfo.ChaincodeServerInfo()
+	if err != nil {
+		return errors.WithMessage(err, "invalid connection information of chaincode "+cname)
+	}
+
+	client, err := comm.NewGRPCClient(serverInfo.ClientConfig)
+	if err != nil {
+		return errors.WithMessage(err, "error creating grpc client to chaincode "+cname)
+	}
+	conn, err := client.NewConnection(serverInfo.Address, "")
+	if err != nil {
+		return errors.WithMessage(err, "error connecting to chaincode "+cname)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := pb.NewChaincodeClient(conn).Connect(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return errors.WithMessage(errors.WithStack(err), "error creating stream to chaincode "+cname)
+	}
+
+	inst := &instance{
+		conn:   conn,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	r.mutex.Lock()
+	if r.instances == nil {
+		r.instances = map[string]*instance{}
+	}
+	previous := r.instances[cname]
+	r.instances[cname] = inst
+	r.mutex.Unlock()
+
+	if previous != nil {
+		previous.cancel()
+		previous.conn.Close()
+	}
+
+	logger.Debugf("connected to chaincode %s at %s", cname, serverInfo.Address)
+
+	go func() {
+		inst.err = r.StreamHandler.HandleChaincodeStream(stream)
+		close(inst.done)
+	}()
+
+	return nil
+}
+
+// Stop closes the connection to the chaincode server.
+func (r *ExternalChaincodeRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
+	cname := ccci.Name + ":" + ccci.Version
+
+	r.mutex.Lock()
+	inst, ok := r.instances[cname]
+	delete(r.instances, cname)
+	r.mutex.Unlock()
+
+	if !ok {
+		return errors.Errorf("chaincode %s is not connected", cname)
+	}
+
+	inst.cancel()
+	return inst.conn.Close()
+}
+
+// Wait waits for the stream with the chaincode server to terminate.
+func (r *ExternalChaincodeRuntime) Wait(ccci *ccprovider.ChaincodeContainerInfo) (int, error) {
+	cname := ccci.Name + ":" + ccci.Version
+
+	r.mutex.Lock()
+	inst, ok := r.instances[cname]
+	r.mutex.Unlock()
+
+	if !ok {
+		return -1, errors.Errorf("chaincode %s is not connected", cname)
+	}
+
+	<-inst.done
+	return 0, inst.err
+}
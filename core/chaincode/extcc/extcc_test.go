@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0600, Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func connectionPackage(t *testing.T, connInfo *extcc.ConnectionInfo) []byte {
+	connBytes, err := json.Marshal(connInfo)
+	require.NoError(t, err)
+	return codePackage(t, map[string][]byte{"metadata.json": []byte("{}"), extcc.ConnectionFile: connBytes})
+}
+
+func TestParseConnectionInfo(t *testing.T) {
+	connInfo, err := extcc.ParseConnectionInfo(connectionPackage(t, &extcc.ConnectionInfo{
+		Address:     "127.0.0.1:9999",
+		DialTimeout: "10s",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, &extcc.ConnectionInfo{Address: "127.0.0.1:9999", DialTimeout: "10s"}, connInfo)
+
+	_, err = extcc.ParseConnectionInfo([]byte("garbage"))
+	assert.Contains(t, err.Error(), "error reading as gzip stream")
+
+	_, err = extcc.ParseConnectionInfo(codePackage(t, map[string][]byte{"main.go": []byte("package main")}))
+	assert.EqualError(t, err, "did not find connection.json inside the code package")
+
+	_, err = extcc.ParseConnectionInfo(codePackage(t, map[string][]byte{extcc.ConnectionFile: []byte("{")}))
+	assert.Contains(t, err.Error(), "could not unmarshal connection.json as json")
+}
+
+func TestChaincodeServerInfo(t *testing.T) {
+	serverInfo, err := (&extcc.ConnectionInfo{Address: "127.0.0.1:9999"}).ChaincodeServerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9999", serverInfo.Address)
+	assert.Equal(t, extcc.DefaultDialTimeout, serverInfo.ClientConfig.Timeout)
+	assert.False(t, serverInfo.ClientConfig.SecOpts.UseTLS)
+
+	serverInfo, err = (&extcc.ConnectionInfo{
+		Address:            "127.0.0.1:9999",
+		TLSRequired:        true,
+		ClientAuthRequired: true,
+		RootCert:           "root",
+		ClientKey:          "key",
+		ClientCert:         "cert",
+	}).ChaincodeServerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, &comm.SecureOptions{
+		UseTLS:            true,
+		RequireClientCert: true,
+		ServerRootCAs:     [][]byte{[]byte("root")},
+		Key:               []byte("key"),
+		Certificate:       []byte("cert"),
+	}, serverInfo.ClientConfig.SecOpts)
+
+	tests := []struct {
+		connInfo *extcc.ConnectionInfo
+		err      string
+	}{
+		{&extcc.ConnectionInfo{}, "chaincode address is not provided"},
+		{&extcc.ConnectionInfo{Address: "a", DialTimeout: "forever"}, "invalid dial timeout 'forever'"},
+		{&extcc.ConnectionInfo{Address: "a", TLSRequired: true}, "chaincode root cert is required when TLS is enabled"},
+		{&extcc.ConnectionInfo{Address: "a", TLSRequired: true, RootCert: "root", ClientAuthRequired: true}, "chaincode client key and cert are required when client authentication is enabled"},
+	}
+	for _, tc := range tests {
+		_, err := tc.connInfo.ChaincodeServerInfo()
+		assert.Contains(t, err.Error(), tc.err)
+	}
+}
+
+// echoServer is a chaincode server echoing back the messages it receives
+type echoServer struct{}
+
+func (echoServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+type streamHandler struct {
+	received chan *pb.ChaincodeMessage
+}
+
+func (s *streamHandler) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	if err := stream.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}); err != nil {
+		return err
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.received <- msg
+	_, err = stream.Recv()
+	return err
+}
+
+func TestExternalChaincodeRuntime(t *testing.T) {
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{SecOpts: &comm.SecureOptions{}})
+	require.NoError(t, err)
+	pb.RegisterChaincodeServer(server.Server(), echoServer{})
+	go server.Start()
+	defer server.Stop()
+
+	handler := &streamHandler{received: make(chan *pb.ChaincodeMessage, 1)}
+	r := &extcc.ExternalChaincodeRuntime{StreamHandler: handler}
+	ccci := &ccprovider.ChaincodeContainerInfo{Name: "cc", Version: "1.0", ContainerType: extcc.ContainerType}
+
+	_, err = r.Wait(ccci)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not connected")
+	err = r.Stop(ccci)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not connected")
+
+	err = r.Start(ccci, connectionPackage(t, &extcc.ConnectionInfo{Address: server.Address()}))
+	require.NoError(t, err)
+
+	msg := <-handler.received
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+
+	err = r.Stop(ccci)
+	assert.NoError(t, err)
+
+	exitCode, err := r.Wait(ccci)
+	assert.Equal(t, -1, exitCode)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not connected")
+}
+
+func TestExternalChaincodeRuntimeStartErrors(t *testing.T) {
+	r := &extcc.ExternalChaincodeRuntime{}
+	ccci := &ccprovider.ChaincodeContainerInfo{Name: "cc", Version: "1.0", ContainerType: extcc.ContainerType}
+
+	err := r.Start(ccci, []byte("garbage"))
+	assert.Contains(t, err.Error(), "could not parse connection information of chaincode cc:1.0")
+
+	err = r.Start(ccci, connectionPackage(t, &extcc.ConnectionInfo{}))
+	assert.EqualError(t, err, "invalid connection information of chaincode cc:1.0: chaincode address is not provided")
+
+	err = r.Start(ccci, connectionPackage(t, &extcc.ConnectionInfo{Address: "127.0.0.1:1", DialTimeout: "100ms"}))
+	assert.Contains(t, err.Error(), "error connecting to chaincode cc:1.0")
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/semaphore"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
@@ -149,6 +151,14 @@ type Handler struct {
 	UUIDGenerator UUIDGenerator
 	// AppConfig is used to retrieve the application config for a channel
 	AppConfig ApplicationConfigRetriever
+	// RuntimeParams holds the optional protocol capabilities advertised to
+	// the chaincode on registration
+	RuntimeParams *pb.ChaincodeAdditionalParams
+	// Limits holds the execution limits of chaincodes
+	Limits ChaincodeLimits
+	// RecordCrossChannelReads enables recording the reads of chaincodes
+	// invoked on other channels in the transaction
+	RecordCrossChannelReads bool
 
 	// state holds the current handler state. It will be created, established, or
 	// ready.
@@ -168,6 +178,11 @@ type Handler struct {
 	errChan chan error
 	// Metrics holds chaincode handler metrics
 	Metrics *HandlerMetrics
+
+	// concurrency bounds the number of transactions in flight on the
+	// handler when the chaincode has a concurrency limit.
+	concurrency     semaphore.Semaphore
+	concurrencyOnce sync.Once
 }
 
 // handleMessage is called by ProcessStream to dispatch messages.
@@ -227,6 +242,10 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandlePutStateMetadata)
+	case pb.ChaincodeMessage_GET_STATE_MULTIPLE:
+		go h.HandleTransaction(msg, h.HandleGetStateMultiple)
+	case pb.ChaincodeMessage_PUT_STATE_MULTIPLE:
+		go h.HandleTransaction(msg, h.HandlePutStateMultiple)
 	default:
 		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, msg.Type)
 	}
@@ -511,8 +530,19 @@ func (h *Handler) HandleRegister(msg *pb.ChaincodeMessage) {
 	// name in keys
 	h.ccInstance = ParseName(h.chaincodeID.Name)
 
+	// advertise the optional capabilities of the peer, which older
+	// chaincodes ignore
+	var payload []byte
+	if h.RuntimeParams != nil {
+		payload, err = proto.Marshal(h.RuntimeParams)
+		if err != nil {
+			h.notifyRegistry(errors.Wrap(err, "failed to marshal runtime parameters"))
+			return
+		}
+	}
+
 	chaincodeLogger.Debugf("Got %s for chaincodeID = %s, sending back %s", pb.ChaincodeMessage_REGISTER, chaincodeID, pb.ChaincodeMessage_REGISTERED)
-	if err := h.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED}); err != nil {
+	if err := h.serialSend(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: payload}); err != nil {
 		chaincodeLogger.Errorf("error sending %s: %s", pb.ChaincodeMessage_REGISTERED, err)
 		h.notifyRegistry(err)
 		return
@@ -647,6 +677,46 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to ledger to get the state of multiple keys at once
+func (h *Handler) HandleGetStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getStateMultiple := &pb.GetStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, getStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	if maxSize := h.RuntimeParams.GetMaxSizeGetMultipleKeys(); maxSize > 0 && uint32(len(getStateMultiple.Keys)) > maxSize {
+		return nil, errors.Errorf("number of keys %d exceeds the maximum of %d", len(getStateMultiple.Keys), maxSize)
+	}
+
+	var values [][]byte
+	chaincodeName := h.ChaincodeName()
+	collection := getStateMultiple.Collection
+	chaincodeLogger.Debugf("[%s] getting state of %d keys for chaincode %s, channel %s", shorttxid(msg.Txid), len(getStateMultiple.Keys), chaincodeName, txContext.ChainID)
+
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if err := errorIfCreatorHasNoReadAccess(chaincodeName, collection, txContext); err != nil {
+			return nil, err
+		}
+		values, err = txContext.TXSimulator.GetPrivateDataMultipleKeys(chaincodeName, collection, getStateMultiple.Keys)
+	} else {
+		values, err = txContext.TXSimulator.GetStateMultipleKeys(chaincodeName, getStateMultiple.Keys)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	payload, err := proto.Marshal(&pb.GetStateMultipleResult{Values: values})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payload, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	getState := &pb.GetState{}
 	err := proto.Unmarshal(msg.Payload, getState)
@@ -1057,6 +1127,45 @@ func (h *Handler) HandlePutState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles the writes buffered by the chaincode, applying them in order
+func (h *Handler) HandlePutStateMultiple(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	putStateMultiple := &pb.PutStateMultiple{}
+	err := proto.Unmarshal(msg.Payload, putStateMultiple)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	if maxSize := h.RuntimeParams.GetMaxSizeWriteBatch(); maxSize > 0 && uint32(len(putStateMultiple.Records)) > maxSize {
+		return nil, errors.Errorf("number of writes %d exceeds the maximum of %d", len(putStateMultiple.Records), maxSize)
+	}
+
+	chaincodeName := h.ChaincodeName()
+	for _, record := range putStateMultiple.Records {
+		collection := record.Collection
+		if isCollectionSet(collection) {
+			if txContext.IsInitTransaction {
+				return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+			}
+			if record.IsDelete {
+				err = txContext.TXSimulator.DeletePrivateData(chaincodeName, collection, record.Key)
+			} else {
+				err = txContext.TXSimulator.SetPrivateData(chaincodeName, collection, record.Key, record.Value)
+			}
+		} else {
+			if record.IsDelete {
+				err = txContext.TXSimulator.DeleteState(chaincodeName, record.Key)
+			} else {
+				err = txContext.TXSimulator.SetState(chaincodeName, record.Key, record.Value)
+			}
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	err := h.checkMetadataCap(msg)
 	if err != nil {
@@ -1157,8 +1266,14 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		Proposal:             txContext.Proposal,
 		TXSimulator:          txContext.TXSimulator,
 		HistoryQueryExecutor: txContext.HistoryQueryExecutor,
+		CrossChannelReads:    txContext.CrossChannelReads,
 	}
 
+	// A chaincode invoked on another channel runs with a simulator of that
+	// channel whose writes are discarded, or rejected when the calling
+	// chaincode asks for a read-only invocation
+	var crossChannelSim *CrossChannelSimulator
+	var blockHeight uint64
 	if targetInstance.ChainID != txContext.ChainID {
 		lgr := h.LedgerGetter.GetLedger(targetInstance.ChainID)
 		if lgr == nil {
@@ -1178,6 +1293,19 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 
 		txParams.TXSimulator = sim
 		txParams.HistoryQueryExecutor = hqe
+
+		if msg.ReadOnly {
+			if h.RecordCrossChannelReads && txContext.CrossChannelReads != nil {
+				info, err := lgr.GetBlockchainInfo()
+				if err != nil {
+					return nil, errors.WithMessage(err, fmt.Sprintf("failed to get the height of channel %s", targetInstance.ChainID))
+				}
+				blockHeight = info.Height
+			}
+
+			crossChannelSim = &CrossChannelSimulator{TxSimulator: sim, ChannelID: targetInstance.ChainID}
+			txParams.TXSimulator = crossChannelSim
+		}
 	}
 
 	chaincodeLogger.Debugf("[%s] getting chaincode data for %s on channel %s", shorttxid(msg.Txid), targetInstance.ChaincodeName, targetInstance.ChainID)
@@ -1192,9 +1320,12 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 
 		version = cd.CCVersion()
 
-		err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, cd.(*ccprovider.ChaincodeData))
-		if err != nil {
-			return nil, errors.WithStack(err)
+		// chaincodes defined through the lifecycle SCC have no instantiation policy
+		if cdLedger, isLegacy := cd.(*ccprovider.ChaincodeData); isLegacy {
+			err = h.InstantiationPolicyChecker.CheckInstantiationPolicy(targetInstance.ChaincodeName, version, cdLedger)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
 		}
 	}
 
@@ -1212,6 +1343,19 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.Wrap(err, "execute failed")
 	}
 
+	if crossChannelSim != nil {
+		if err := crossChannelSim.WriteErr(); err != nil {
+			return nil, err
+		}
+		if h.RecordCrossChannelReads && txContext.CrossChannelReads != nil {
+			read, err := crossChannelSim.CrossChannelRead(targetInstance.ChaincodeName)
+			if err != nil {
+				return nil, err
+			}
+			txContext.CrossChannelReads.Add(read, blockHeight)
+		}
+	}
+
 	// payload is marshalled and sent to the calling chaincode's shim which unmarshals and
 	// sends it to chaincode
 	res, err := proto.Marshal(responseMessage)
@@ -1226,6 +1370,24 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	chaincodeLogger.Debugf("Entry")
 	defer chaincodeLogger.Debugf("Exit")
 
+	ccName := cccid.Name + ":" + cccid.Version
+	limits := h.Limits.For(cccid.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if sem := h.concurrencyLimit(limits); sem != nil {
+		if err := sem.Acquire(ctx); err != nil {
+			h.Metrics.ExecuteRejections.With("chaincode", ccName, "reason", "concurrency").Add(1)
+			return nil, errors.Errorf("timeout expired waiting for one of the %d execution slots of chaincode %s", limits.MaxConcurrency, ccName)
+		}
+		defer sem.Release()
+	}
+
+	inFlight := h.Metrics.ExecutionsInFlight.With("chaincode", ccName)
+	inFlight.Add(1)
+	defer inFlight.Add(-1)
+
 	txParams.CollectionStore = h.getCollectionStore(msg.ChannelId)
 	txParams.IsInitTransaction = (msg.Type == pb.ChaincodeMessage_INIT)
 
@@ -1246,9 +1408,12 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	case ccresp = <-txctx.ResponseNotifier:
 		// response is sent to user or calling chaincode. ChaincodeMessage_ERROR
 		// are typically treated as error
-	case <-time.After(timeout):
+		if reason, err := checkResponseLimits(ccresp, limits); err != nil {
+			h.Metrics.ExecuteRejections.With("chaincode", ccName, "reason", reason).Add(1)
+			return nil, err
+		}
+	case <-ctx.Done():
 		err = errors.New("timeout expired while executing transaction")
-		ccName := cccid.Name + ":" + cccid.Version
 		h.Metrics.ExecuteTimeouts.With(
 			"chaincode", ccName,
 		).Add(1)
@@ -1257,6 +1422,37 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	return ccresp, err
 }
 
+// concurrencyLimit returns the semaphore bounding the number of transactions
+// in flight on the handler, or nil when the chaincode has no such limit.
+func (h *Handler) concurrencyLimit(limits Limits) semaphore.Semaphore {
+	h.concurrencyOnce.Do(func() {
+		if limits.MaxConcurrency > 0 {
+			h.concurrency = semaphore.New(limits.MaxConcurrency)
+		}
+	})
+	return h.concurrency
+}
+
+// checkResponseLimits returns an error, along with the reason reported in
+// metrics, when a completed chaincode response exceeds the size limits of
+// the chaincode.
+func checkResponseLimits(ccresp *pb.ChaincodeMessage, limits Limits) (string, error) {
+	if ccresp == nil || ccresp.Type != pb.ChaincodeMessage_COMPLETED {
+		return "", nil
+	}
+	if limits.MaxResponseSize > 0 && len(ccresp.Payload) > limits.MaxResponseSize {
+		return "response_size", errors.Errorf("chaincode response of %d bytes exceeds the limit of %d bytes", len(ccresp.Payload), limits.MaxResponseSize)
+	}
+	if limits.MaxEventSize > 0 {
+		for _, event := range append([]*pb.ChaincodeEvent{ccresp.ChaincodeEvent}, ccresp.ChaincodeEvents...) {
+			if size := proto.Size(event); size > limits.MaxEventSize {
+				return "event_size", errors.Errorf("chaincode event of %d bytes exceeds the limit of %d bytes", size, limits.MaxEventSize)
+			}
+		}
+	}
+	return "", nil
+}
+
 func (h *Handler) setChaincodeProposal(signedProp *pb.SignedProposal, prop *pb.Proposal, msg *pb.ChaincodeMessage) error {
 	if prop != nil && signedProp == nil {
 		return errors.New("failed getting proposal context. Signed proposal is nil")
@@ -20,6 +20,9 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -48,6 +51,8 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeExecuteRejections          *metricsfakes.Counter
+		fakeExecutionsInFlight         *metricsfakes.Gauge
 
 		responseNotifier chan *pb.ChaincodeMessage
 		txContext        *chaincode.TransactionContext
@@ -102,12 +107,18 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeExecuteRejections = &metricsfakes.Counter{}
+		fakeExecuteRejections.WithReturns(fakeExecuteRejections)
+		fakeExecutionsInFlight = &metricsfakes.Gauge{}
+		fakeExecutionsInFlight.WithReturns(fakeExecutionsInFlight)
 
 		chaincodeMetrics := &chaincode.HandlerMetrics{
 			ShimRequestsReceived:  fakeShimRequestsReceived,
 			ShimRequestsCompleted: fakeShimRequestsCompleted,
 			ShimRequestDuration:   fakeShimRequestDuration,
 			ExecuteTimeouts:       fakeExecuteTimeouts,
+			ExecuteRejections:     fakeExecuteRejections,
+			ExecutionsInFlight:    fakeExecutionsInFlight,
 		}
 
 		handler = &chaincode.Handler{
@@ -758,6 +769,114 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePutStateMultiple", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *pb.PutStateMultiple
+		)
+
+		BeforeEach(func() {
+			request = &pb.PutStateMultiple{
+				Records: []*pb.WriteRecord{
+					{Key: "put-key", Value: []byte("put-value")},
+					{Key: "del-key", IsDelete: true},
+					{Collection: "collection-name", Key: "pvt-put-key", Value: []byte("pvt-put-value")},
+					{Collection: "collection-name", Key: "pvt-del-key", IsDelete: true},
+				},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_PUT_STATE_MULTIPLE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("applies the writes to the transaction simulator", func() {
+			resp, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+			ccname, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("put-key"))
+			Expect(value).To(Equal([]byte("put-value")))
+
+			Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(1))
+			ccname, key = fakeTxSimulator.DeleteStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("del-key"))
+
+			Expect(fakeTxSimulator.SetPrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key, value := fakeTxSimulator.SetPrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("pvt-put-key"))
+			Expect(value).To(Equal([]byte("pvt-put-value")))
+
+			Expect(fakeTxSimulator.DeletePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key = fakeTxSimulator.DeletePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("pvt-del-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the number of writes exceeds the maximum", func() {
+			BeforeEach(func() {
+				handler.RuntimeParams = &pb.ChaincodeAdditionalParams{MaxSizeWriteBatch: 3}
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("number of writes 4 exceeds the maximum of 3"))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a write fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.DeleteStateReturns(errors.New("papaya"))
+			})
+
+			It("returns the error and stops applying writes", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("papaya"))
+				Expect(fakeTxSimulator.SetPrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the transaction is an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("rejects private data writes", func() {
+				_, err := handler.HandlePutStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				Expect(fakeTxSimulator.SetPrivateDataCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("HandleDelState", func() {
 		var incomingMessage *pb.ChaincodeMessage
 		var request *pb.DelState
@@ -1043,6 +1162,136 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateMultiple", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			request         *pb.GetStateMultiple
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetStateMultiple{
+				Keys: []string{"key1", "key2"},
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_MULTIPLE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeTxSimulator.GetStateMultipleKeysReturns([][]byte{[]byte("value1"), nil}, nil)
+		})
+
+		It("calls GetStateMultipleKeys on the transaction simulator", func() {
+			_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(1))
+			ccname, keys := fakeTxSimulator.GetStateMultipleKeysArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(keys).To(Equal([]string{"key1", "key2"}))
+		})
+
+		It("returns the values in the response", func() {
+			resp, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Txid).To(Equal("tx-id"))
+			Expect(resp.ChannelId).To(Equal("channel-id"))
+
+			result := &pb.GetStateMultipleResult{}
+			err = proto.Unmarshal(resp.Payload, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Values).To(HaveLen(2))
+			Expect(result.Values[0]).To(Equal([]byte("value1")))
+			Expect(result.Values[1]).To(BeEmpty())
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the number of keys exceeds the maximum", func() {
+			BeforeEach(func() {
+				handler.RuntimeParams = &pb.ChaincodeAdditionalParams{MaxSizeGetMultipleKeys: 1}
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("number of keys 2 exceeds the maximum of 1"))
+				Expect(fakeTxSimulator.GetStateMultipleKeysCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when GetStateMultipleKeys fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateMultipleKeysReturns(nil, errors.New("mango"))
+			})
+
+			It("returns the error from GetStateMultipleKeys", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).To(MatchError("mango"))
+			})
+		})
+
+		Context("when collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeCollectionStore.HasReadAccessReturns(true, nil)
+				fakeTxSimulator.GetPrivateDataMultipleKeysReturns([][]byte{[]byte("pvt1"), []byte("pvt2")}, nil)
+			})
+
+			It("calls GetPrivateDataMultipleKeys on the transaction simulator", func() {
+				_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeTxSimulator.GetPrivateDataMultipleKeysCallCount()).To(Equal(1))
+				ccname, collection, keys := fakeTxSimulator.GetPrivateDataMultipleKeysArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(keys).To(Equal([]string{"key1", "key2"}))
+			})
+
+			Context("and the creator has no read access", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.HasReadAccessReturns(false, nil)
+				})
+
+				It("returns the error from errorIfCreatorHasNoReadAccess", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have read access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+				})
+			})
+
+			Context("and the transaction is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateMultiple(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+		})
+	})
+
 	Describe("HandleGetPrivateDataHash", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -2153,6 +2402,22 @@ var _ = Describe("Handler", func() {
 			Expect(proposal).To(Equal(expectedSignedProp))
 		})
 
+		Context("when the calling chaincode asks for a read-only invocation on the same channel", func() {
+			BeforeEach(func() {
+				incomingMessage.ReadOnly = true
+			})
+
+			It("invokes the target with the simulator of the transaction", func() {
+				_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLedgerGetter.GetLedgerCallCount()).To(Equal(0))
+				Expect(fakeInvoker.InvokeCallCount()).To(Equal(1))
+				txParams, _, _ := fakeInvoker.InvokeArgsForCall(0)
+				Expect(txParams.TXSimulator).To(BeIdenticalTo(fakeTxSimulator)) // same instance, not just equal
+			})
+		})
+
 		Context("when the target channel is different from the context", func() {
 			BeforeEach(func() {
 				request = &pb.ChaincodeSpec{
@@ -2231,6 +2496,160 @@ var _ = Describe("Handler", func() {
 				Expect(newTxSimulator.DoneCallCount()).To(Equal(1))
 			})
 
+			Context("when cross-channel reads are recorded", func() {
+				BeforeEach(func() {
+					txContext.CrossChannelReads = &ccprovider.CrossChannelReads{}
+					handler.RecordCrossChannelReads = true
+				})
+
+				It("records nothing as the invocation is not read-only", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(txContext.CrossChannelReads.Reads()).To(BeEmpty())
+					Expect(fakePeerLedger.GetBlockchainInfoCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the target chaincode writes", func() {
+				BeforeEach(func() {
+					fakeInvoker.InvokeStub = func(txParams *ccprovider.TransactionParams, _ *ccprovider.CCContext, _ *pb.ChaincodeInput) (*pb.ChaincodeMessage, error) {
+						err := txParams.TXSimulator.SetState("target-chaincode-name", "key", []byte("value"))
+						Expect(err).NotTo(HaveOccurred())
+						return responseMessage, nil
+					}
+				})
+
+				It("discards the writes without failing the invocation", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(newTxSimulator.SetStateCallCount()).To(Equal(1))
+					Expect(newTxSimulator.GetTxSimulationResultsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the calling chaincode asks for a read-only invocation", func() {
+				BeforeEach(func() {
+					incomingMessage.ReadOnly = true
+				})
+
+				It("provides a read-only view of the new simulator in the context used for execution", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeInvoker.InvokeCallCount()).To(Equal(1))
+					txParams, _, _ := fakeInvoker.InvokeArgsForCall(0)
+					Expect(txParams.TXSimulator).To(BeAssignableToTypeOf(&chaincode.CrossChannelSimulator{}))
+					sim := txParams.TXSimulator.(*chaincode.CrossChannelSimulator)
+					Expect(sim.TxSimulator).To(BeIdenticalTo(newTxSimulator)) // same instance, not just equal
+					Expect(sim.ChannelID).To(Equal("target-channel-id"))
+				})
+
+				Context("when the target chaincode writes", func() {
+					BeforeEach(func() {
+						fakeInvoker.InvokeStub = func(txParams *ccprovider.TransactionParams, _ *ccprovider.CCContext, _ *pb.ChaincodeInput) (*pb.ChaincodeMessage, error) {
+							err := txParams.TXSimulator.SetState("target-chaincode-name", "key", []byte("value"))
+							Expect(err).To(MatchError("chaincode target-chaincode-name cannot write to channel target-channel-id: cross-channel invocations are read-only"))
+							err = txParams.TXSimulator.DeletePrivateData("target-chaincode-name", "collection", "key")
+							Expect(err).To(HaveOccurred())
+							return responseMessage, nil
+						}
+					})
+
+					It("does not write to the ledger of the target channel", func() {
+						handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(newTxSimulator.SetStateCallCount()).To(Equal(0))
+						Expect(newTxSimulator.DeletePrivateDataCallCount()).To(Equal(0))
+					})
+
+					It("fails the invocation with the first rejected write", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).To(MatchError("chaincode target-chaincode-name cannot write to channel target-channel-id: cross-channel invocations are read-only"))
+					})
+				})
+
+				Context("when cross-channel reads are recorded", func() {
+					var (
+						crossChannelReads *ccprovider.CrossChannelReads
+						results           []byte
+					)
+
+					BeforeEach(func() {
+						crossChannelReads = &ccprovider.CrossChannelReads{}
+						txContext.CrossChannelReads = crossChannelReads
+						handler.RecordCrossChannelReads = true
+
+						fakePeerLedger.GetBlockchainInfoReturns(&common.BlockchainInfo{Height: 42}, nil)
+						pubSimResults := &rwset.TxReadWriteSet{
+							DataModel: rwset.TxReadWriteSet_KV,
+							NsRwset: []*rwset.NsReadWriteSet{
+								{Namespace: "target-chaincode-name", Rwset: []byte("reads")},
+							},
+						}
+						var err error
+						results, err = proto.Marshal(pubSimResults)
+						Expect(err).NotTo(HaveOccurred())
+						newTxSimulator.GetTxSimulationResultsReturns(&ledger.TxSimulationResults{PubSimulationResults: pubSimResults}, nil)
+					})
+
+					It("passes the collector to the target chaincode", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+
+						txParams, _, _ := fakeInvoker.InvokeArgsForCall(0)
+						Expect(txParams.CrossChannelReads).To(BeIdenticalTo(crossChannelReads))
+					})
+
+					It("records the reads of the target chaincode with the height of the channel", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(crossChannelReads.Reads()).To(Equal([]*pb.CrossChannelRead{{
+							ChannelId:     "target-channel-id",
+							ChaincodeName: "target-chaincode-name",
+							Results:       results,
+						}}))
+						Expect(crossChannelReads.Heights()).To(Equal([]uint64{42}))
+					})
+
+					Context("when the peer does not record cross-channel reads", func() {
+						BeforeEach(func() {
+							handler.RecordCrossChannelReads = false
+						})
+
+						It("records nothing", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(crossChannelReads.Reads()).To(BeEmpty())
+							Expect(fakePeerLedger.GetBlockchainInfoCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when getting the height of the target channel fails", func() {
+						BeforeEach(func() {
+							fakePeerLedger.GetBlockchainInfoReturns(nil, errors.New("no-height"))
+						})
+
+						It("returns an error", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).To(MatchError("failed to get the height of channel target-channel-id: no-height"))
+						})
+					})
+
+					Context("when getting the simulation results fails", func() {
+						BeforeEach(func() {
+							newTxSimulator.GetTxSimulationResultsReturns(nil, errors.New("no-results"))
+						})
+
+						It("returns an error", func() {
+							_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+							Expect(err).To(MatchError("failed to get the simulation results: no-results"))
+						})
+					})
+				})
+			})
+
 			Context("when getting the ledger for the target channel fails", func() {
 				BeforeEach(func() {
 					fakeLedgerGetter.GetLedgerReturns(nil)
@@ -2618,6 +3037,128 @@ var _ = Describe("Handler", func() {
 				Expect(txid).To(Equal("tx-id"))
 			})
 		})
+
+		It("records the executions in flight", func() {
+			close(responseNotifier)
+			handler.Execute(txParams, cccid, incomingMessage, time.Second)
+
+			Expect(fakeExecutionsInFlight.WithCallCount()).To(Equal(1))
+			Expect(fakeExecutionsInFlight.WithArgsForCall(0)).To(Equal([]string{
+				"chaincode", "chaincode-name:chaincode-version",
+			}))
+			Expect(fakeExecutionsInFlight.AddCallCount()).To(Equal(2))
+			Expect(fakeExecutionsInFlight.AddArgsForCall(0)).To(BeNumerically("~", 1.0))
+			Expect(fakeExecutionsInFlight.AddArgsForCall(1)).To(BeNumerically("~", -1.0))
+		})
+
+		Context("when the chaincode has a concurrency limit", func() {
+			BeforeEach(func() {
+				handler.Limits = chaincode.ChaincodeLimits{
+					"chaincode-name": {MaxConcurrency: 1},
+				}
+			})
+
+			It("waits for an execution slot until the timeout expires", func() {
+				doneCh := make(chan struct{})
+				go func() {
+					handler.Execute(txParams, cccid, incomingMessage, time.Second)
+					close(doneCh)
+				}()
+				Eventually(fakeChatStream.SendCallCount).Should(Equal(1))
+
+				_, err := handler.Execute(txParams, cccid, incomingMessage, 10*time.Millisecond)
+				Expect(err).To(MatchError("timeout expired waiting for one of the 1 execution slots of chaincode chaincode-name:chaincode-version"))
+				Expect(fakeChatStream.SendCallCount()).To(Equal(1))
+
+				Expect(fakeExecuteRejections.WithCallCount()).To(Equal(1))
+				Expect(fakeExecuteRejections.WithArgsForCall(0)).To(Equal([]string{
+					"chaincode", "chaincode-name:chaincode-version", "reason", "concurrency",
+				}))
+				Expect(fakeExecuteRejections.AddCallCount()).To(Equal(1))
+
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{}))
+				Eventually(doneCh).Should(BeClosed())
+
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{}))
+				_, err = handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the chaincode has size limits", func() {
+			BeforeEach(func() {
+				handler.Limits = chaincode.ChaincodeLimits{
+					"chaincode-name": {MaxResponseSize: 4, MaxEventSize: 16},
+				}
+			})
+
+			It("returns responses within the limits", func() {
+				resp := &pb.ChaincodeMessage{
+					Type:           pb.ChaincodeMessage_COMPLETED,
+					Payload:        []byte("1234"),
+					ChaincodeEvent: &pb.ChaincodeEvent{EventName: "event"},
+				}
+				Eventually(responseNotifier).Should(BeSent(resp))
+
+				ccresp, err := handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccresp).To(Equal(resp))
+				Expect(fakeExecuteRejections.WithCallCount()).To(Equal(0))
+			})
+
+			It("rejects responses exceeding the response size limit", func() {
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{
+					Type:    pb.ChaincodeMessage_COMPLETED,
+					Payload: []byte("12345"),
+				}))
+
+				_, err := handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).To(MatchError("chaincode response of 5 bytes exceeds the limit of 4 bytes"))
+				Expect(fakeExecuteRejections.WithCallCount()).To(Equal(1))
+				Expect(fakeExecuteRejections.WithArgsForCall(0)).To(Equal([]string{
+					"chaincode", "chaincode-name:chaincode-version", "reason", "response_size",
+				}))
+			})
+
+			It("rejects responses exceeding the event size limit", func() {
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{
+					Type:           pb.ChaincodeMessage_COMPLETED,
+					ChaincodeEvent: &pb.ChaincodeEvent{EventName: "event", Payload: []byte("event-payload")},
+				}))
+
+				_, err := handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).To(MatchError("chaincode event of 22 bytes exceeds the limit of 16 bytes"))
+				Expect(fakeExecuteRejections.WithCallCount()).To(Equal(1))
+				Expect(fakeExecuteRejections.WithArgsForCall(0)).To(Equal([]string{
+					"chaincode", "chaincode-name:chaincode-version", "reason", "event_size",
+				}))
+			})
+
+			It("applies the event size limit to each event of the transaction", func() {
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{
+					Type:           pb.ChaincodeMessage_COMPLETED,
+					ChaincodeEvent: &pb.ChaincodeEvent{EventName: "event"},
+					ChaincodeEvents: []*pb.ChaincodeEvent{
+						{EventName: "event", Payload: []byte("event-payload")},
+						{EventName: "event"},
+					},
+				}))
+
+				_, err := handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).To(MatchError("chaincode event of 22 bytes exceeds the limit of 16 bytes"))
+				Expect(fakeExecuteRejections.WithCallCount()).To(Equal(1))
+			})
+
+			It("does not limit error responses", func() {
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{
+					Type:    pb.ChaincodeMessage_ERROR,
+					Payload: []byte("a long error message"),
+				}))
+
+				_, err := handler.Execute(txParams, cccid, incomingMessage, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("HandleRegister", func() {
@@ -2686,6 +3227,30 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		Context("when runtime parameters are configured", func() {
+			BeforeEach(func() {
+				handler.RuntimeParams = &pb.ChaincodeAdditionalParams{
+					UseWriteBatch:          true,
+					MaxSizeWriteBatch:      100,
+					UseGetMultipleKeys:     true,
+					MaxSizeGetMultipleKeys: 200,
+				}
+			})
+
+			It("advertises them in the registered message", func() {
+				handler.HandleRegister(incomingMessage)
+
+				Eventually(fakeChatStream.SendCallCount).Should(Equal(2))
+				registeredMessage := fakeChatStream.SendArgsForCall(0)
+				Expect(registeredMessage.Type).To(Equal(pb.ChaincodeMessage_REGISTERED))
+
+				runtimeParams := &pb.ChaincodeAdditionalParams{}
+				err := proto.Unmarshal(registeredMessage.Payload, runtimeParams)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(runtimeParams, handler.RuntimeParams)).To(BeTrue())
+			})
+		})
+
 		Context("when sending the ready message fails", func() {
 			BeforeEach(func() {
 				fakeChatStream.SendReturnsOnCall(1, errors.New("carrot"))
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/protos/common"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LifecycleNamespace is the namespace in which the lifecycle SCC
+	// keeps the chaincode definitions and the approvals of the orgs
+	LifecycleNamespace = "+lifecycle"
+
+	// LifecycleEndorsementPolicyRef is the channel policy which the
+	// endorsements of the commit of a chaincode definition must satisfy
+	LifecycleEndorsementPolicyRef = "/Channel/Application/LifecycleEndorsement"
+
+	// approvalsPrefix prefixes the keys which hold the chaincode
+	// definitions approved by an org in its implicit collection
+	approvalsPrefix = "approvals/"
+)
+
+// The committed chaincode definitions follow the state layout of lscc:
+// the definition of a chaincode is stored at the chaincode name, and its
+// collections are stored at privdata.BuildCollectionKVSKey of the name.
+// The approvals of an org are kept in the org-scoped state, which is the
+// implicit collection of the org, so that other orgs only see their hashes.
+
+// ApprovalKey returns the key which holds, in the org-scoped state of
+// an org, the definition of the given chaincode approved by the org
+func ApprovalKey(name string) string {
+	return approvalsPrefix + name
+}
+
+// ChaincodeDefinition is a chaincode definition committed through the
+// lifecycle SCC, adapted to the ccprovider.ChaincodeDefinition interface
+type ChaincodeDefinition struct {
+	Name       string
+	Definition *lb.ChaincodeDefinition
+}
+
+// CCName returns the name of the chaincode
+func (cd *ChaincodeDefinition) CCName() string {
+	return cd.Name
+}
+
+// Hash returns nil, as definitions committed through the lifecycle SCC
+// don't pin the chaincode package; each org installs its own
+func (cd *ChaincodeDefinition) Hash() []byte {
+	return nil
+}
+
+// CCVersion returns the version of the chaincode
+func (cd *ChaincodeDefinition) CCVersion() string {
+	return cd.Definition.Version
+}
+
+// Validation returns the name of the validation plugin and its argument
+func (cd *ChaincodeDefinition) Validation() (string, []byte) {
+	return cd.Definition.ValidationPlugin, cd.Definition.ValidationParameter
+}
+
+// Endorsement returns the name of the endorsement plugin
+func (cd *ChaincodeDefinition) Endorsement() string {
+	return cd.Definition.EndorsementPlugin
+}
+
+// GetChaincodeDefinition returns the committed definition of the given
+// chaincode along with its collections, or nil if the chaincode wasn't
+// defined through the lifecycle SCC
+func GetChaincodeDefinition(name string, state privdata.State) (*ChaincodeDefinition, error) {
+	definitionBytes, err := state.GetState(LifecycleNamespace, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve definition of chaincode %s", name)
+	}
+	if definitionBytes == nil {
+		return nil, nil
+	}
+
+	definition := &lb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(definitionBytes, definition); err != nil {
+		return nil, errors.Wrapf(err, "chaincode %s has bad definition", name)
+	}
+
+	collectionsBytes, err := state.GetState(LifecycleNamespace, privdata.BuildCollectionKVSKey(name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve collections of chaincode %s", name)
+	}
+	if collectionsBytes != nil {
+		definition.Collections = &common.CollectionConfigPackage{}
+		if err := proto.Unmarshal(collectionsBytes, definition.Collections); err != nil {
+			return nil, errors.Wrapf(err, "chaincode %s has bad collections", name)
+		}
+	}
+
+	return &ChaincodeDefinition{
+		Name:       name,
+		Definition: definition,
+	}, nil
+}
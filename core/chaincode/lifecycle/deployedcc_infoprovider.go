@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+)
+
+// DeployedCCInfoProvider implements ledger.DeployedChaincodeInfoProvider
+// for the chaincodes defined through the lifecycle SCC, and falls back
+// to Legacy for the chaincodes which were instantiated through lscc
+type DeployedCCInfoProvider struct {
+	Legacy                    ledger.DeployedChaincodeInfoProvider
+	ChannelCapabilitiesSource ChannelCapabilitiesSource
+}
+
+// enabled returns whether chaincodes may be defined through the lifecycle SCC on
+// the channel. While the ledger of a channel is recovered, its capabilities are not
+// known yet and the state is trusted, as it can only be written with the capability.
+func (p *DeployedCCInfoProvider) enabled(channelName string) bool {
+	ac := p.ChannelCapabilitiesSource.GetApplicationCapabilities(channelName)
+	return ac == nil || ac.LifecycleV20()
+}
+
+// Namespaces implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) Namespaces() []string {
+	return append([]string{LifecycleNamespace}, p.Legacy.Namespaces()...)
+}
+
+// UpdatedChaincodes implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) UpdatedChaincodes(stateUpdates map[string][]*kvrwset.KVWrite) ([]*ledger.ChaincodeLifecycleInfo, error) {
+	lifecycleInfo, err := p.Legacy.UpdatedChaincodes(stateUpdates)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedCCNames := map[string]bool{}
+	for _, info := range lifecycleInfo {
+		updatedCCNames[info.Name] = true
+	}
+
+	for _, kvWrite := range stateUpdates[LifecycleNamespace] {
+		ccname := kvWrite.Key
+		if privdata.IsCollectionConfigKey(kvWrite.Key) {
+			ccname = privdata.GetCCNameFromCollectionConfigKey(kvWrite.Key)
+		}
+		if updatedCCNames[ccname] {
+			continue
+		}
+		updatedCCNames[ccname] = true
+		lifecycleInfo = append(lifecycleInfo, &ledger.ChaincodeLifecycleInfo{Name: ccname})
+	}
+
+	return lifecycleInfo, nil
+}
+
+// ChaincodeInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) ChaincodeInfo(channelName, chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error) {
+	if !p.enabled(channelName) {
+		return p.Legacy.ChaincodeInfo(channelName, chaincodeName, qe)
+	}
+
+	if chaincodeName == LifecycleNamespace {
+		// the implicit collections of the orgs are not part of any package,
+		// they are resolved individually through CollectionInfo
+		return &ledger.DeployedChaincodeInfo{
+			Name:                LifecycleNamespace,
+			CollectionConfigPkg: &common.CollectionConfigPackage{},
+		}, nil
+	}
+
+	cd, err := GetChaincodeDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return p.Legacy.ChaincodeInfo(channelName, chaincodeName, qe)
+	}
+
+	return &ledger.DeployedChaincodeInfo{
+		Name:                chaincodeName,
+		Version:             cd.Definition.Version,
+		CollectionConfigPkg: cd.Definition.Collections,
+	}, nil
+}
+
+// CollectionInfo implements function in interface ledger.DeployedChaincodeInfoProvider
+func (p *DeployedCCInfoProvider) CollectionInfo(channelName, chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+	if !p.enabled(channelName) {
+		return p.Legacy.CollectionInfo(channelName, chaincodeName, collectionName, qe)
+	}
+
+	if chaincodeName == LifecycleNamespace {
+		mspID, isImplicit := privdata.MSPIDIfImplicitCollection(collectionName)
+		if !isImplicit {
+			return nil, nil
+		}
+		return privdata.GenerateImplicitCollectionForOrg(mspID), nil
+	}
+
+	cd, err := GetChaincodeDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return p.Legacy.CollectionInfo(channelName, chaincodeName, collectionName, qe)
+	}
+
+	for _, conf := range cd.Definition.Collections.GetConfig() {
+		staticCollConfig := conf.GetStaticCollectionConfig()
+		if staticCollConfig != nil && staticCollConfig.Name == collectionName {
+			return staticCollConfig, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"github.com/golang/protobuf/proto"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockledger "github.com/hyperledger/fabric/common/mocks/ledger"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lifecyclemock "github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeployedCCInfoProvider", func() {
+	var (
+		p                *lifecycle.DeployedCCInfoProvider
+		fakeLegacy       *mock.DeployedChaincodeInfoProvider
+		fakeCapabilities *lifecyclemock.ChannelCapabilitiesSource
+		state            map[string][]byte
+		qe               *mockledger.MockQueryExecutor
+	)
+
+	BeforeEach(func() {
+		fakeLegacy = &mock.DeployedChaincodeInfoProvider{}
+		fakeCapabilities = &lifecyclemock.ChannelCapabilitiesSource{}
+		fakeCapabilities.GetApplicationCapabilitiesReturns(&mockconfig.MockApplicationCapabilities{LifecycleV20Rv: true})
+		p = &lifecycle.DeployedCCInfoProvider{
+			Legacy:                    fakeLegacy,
+			ChannelCapabilitiesSource: fakeCapabilities,
+		}
+
+		collections := &common.CollectionConfigPackage{
+			Config: []*common.CollectionConfig{{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{Name: "collection", BlockToLive: 10},
+				},
+			}},
+		}
+		state = map[string][]byte{
+			"cc":                                 utils.MarshalOrPanic(&lb.ChaincodeDefinition{Sequence: 1, Version: "1.0"}),
+			privdata.BuildCollectionKVSKey("cc"): utils.MarshalOrPanic(collections),
+		}
+		qe = &mockledger.MockQueryExecutor{
+			State: map[string]map[string][]byte{
+				lifecycle.LifecycleNamespace: state,
+			},
+		}
+	})
+
+	Describe("Namespaces", func() {
+		BeforeEach(func() {
+			fakeLegacy.NamespacesReturns([]string{"lscc"})
+		})
+
+		It("returns the lifecycle and legacy namespaces", func() {
+			Expect(p.Namespaces()).To(Equal([]string{"+lifecycle", "lscc"}))
+		})
+	})
+
+	Describe("UpdatedChaincodes", func() {
+		BeforeEach(func() {
+			fakeLegacy.UpdatedChaincodesReturns([]*ledger.ChaincodeLifecycleInfo{{Name: "legacy-cc"}}, nil)
+		})
+
+		It("returns the chaincodes whose definitions were committed", func() {
+			stateUpdates := map[string][]*kvrwset.KVWrite{
+				lifecycle.LifecycleNamespace: {
+					{Key: "cc1"},
+					{Key: privdata.BuildCollectionKVSKey("cc1")},
+					{Key: privdata.BuildCollectionKVSKey("cc2")},
+				},
+			}
+			updated, err := p.UpdatedChaincodes(stateUpdates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated).To(ConsistOf(
+				&ledger.ChaincodeLifecycleInfo{Name: "legacy-cc"},
+				&ledger.ChaincodeLifecycleInfo{Name: "cc1"},
+				&ledger.ChaincodeLifecycleInfo{Name: "cc2"},
+			))
+			Expect(fakeLegacy.UpdatedChaincodesArgsForCall(0)).To(Equal(stateUpdates))
+		})
+	})
+
+	Describe("ChaincodeInfo", func() {
+		It("returns the info of the committed definition", func() {
+			info, err := p.ChaincodeInfo("testchannel", "cc", qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name).To(Equal("cc"))
+			Expect(info.Version).To(Equal("1.0"))
+			Expect(info.CollectionConfigPkg.Config).To(HaveLen(1))
+			Expect(fakeLegacy.ChaincodeInfoCallCount()).To(Equal(0))
+		})
+
+		It("returns the lifecycle namespace with no collections in its package", func() {
+			info, err := p.ChaincodeInfo("testchannel", lifecycle.LifecycleNamespace, qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Name).To(Equal(lifecycle.LifecycleNamespace))
+			Expect(info.CollectionConfigPkg.Config).To(BeEmpty())
+			Expect(fakeLegacy.ChaincodeInfoCallCount()).To(Equal(0))
+		})
+
+		Context("when the chaincode was instantiated through lscc", func() {
+			BeforeEach(func() {
+				fakeLegacy.ChaincodeInfoReturns(&ledger.DeployedChaincodeInfo{Name: "legacy-cc"}, nil)
+			})
+
+			It("falls back to lscc", func() {
+				info, err := p.ChaincodeInfo("testchannel", "legacy-cc", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Name).To(Equal("legacy-cc"))
+			})
+		})
+
+		Context("when the channel does not have the V2_0 capability", func() {
+			BeforeEach(func() {
+				fakeCapabilities.GetApplicationCapabilitiesReturns(&mockconfig.MockApplicationCapabilities{})
+				fakeLegacy.ChaincodeInfoReturns(nil, nil)
+			})
+
+			It("ignores the committed definitions", func() {
+				info, err := p.ChaincodeInfo("testchannel", "cc", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info).To(BeNil())
+
+				info, err = p.ChaincodeInfo("testchannel", lifecycle.LifecycleNamespace, qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info).To(BeNil())
+
+				Expect(fakeLegacy.ChaincodeInfoCallCount()).To(Equal(2))
+				channelName, ccName, _ := fakeLegacy.ChaincodeInfoArgsForCall(0)
+				Expect(channelName).To(Equal("testchannel"))
+				Expect(ccName).To(Equal("cc"))
+				Expect(fakeCapabilities.GetApplicationCapabilitiesArgsForCall(0)).To(Equal("testchannel"))
+			})
+		})
+
+		Context("when the capabilities of the channel are not known yet", func() {
+			BeforeEach(func() {
+				fakeCapabilities.GetApplicationCapabilitiesReturns(nil)
+			})
+
+			It("returns the info of the committed definition", func() {
+				info, err := p.ChaincodeInfo("testchannel", "cc", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Version).To(Equal("1.0"))
+				Expect(fakeLegacy.ChaincodeInfoCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("CollectionInfo", func() {
+		It("returns the collection of the committed definition", func() {
+			collection, err := p.CollectionInfo("testchannel", "cc", "collection", qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collection.BlockToLive).To(Equal(uint64(10)))
+
+			collection, err = p.CollectionInfo("testchannel", "cc", "missing", qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collection).To(BeNil())
+		})
+
+		It("returns the implicit collections of the orgs in the lifecycle namespace", func() {
+			collection, err := p.CollectionInfo("testchannel", lifecycle.LifecycleNamespace, privdata.ImplicitCollectionNameForOrg("org1"), qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(collection, privdata.GenerateImplicitCollectionForOrg("org1"))).To(BeTrue())
+
+			collection, err = p.CollectionInfo("testchannel", lifecycle.LifecycleNamespace, "collection", qe)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collection).To(BeNil())
+			Expect(fakeLegacy.CollectionInfoCallCount()).To(Equal(0))
+		})
+
+		Context("when the definition is corrupt", func() {
+			BeforeEach(func() {
+				state["cc"] = []byte("garbage")
+			})
+
+			It("returns an error", func() {
+				_, err := p.CollectionInfo("testchannel", "cc", "collection", qe)
+				Expect(err).To(MatchError(ContainSubstring("chaincode cc has bad definition")))
+			})
+		})
+
+		Context("when the chaincode was instantiated through lscc", func() {
+			BeforeEach(func() {
+				fakeLegacy.CollectionInfoReturns(&common.StaticCollectionConfig{Name: "legacy-collection"}, nil)
+			})
+
+			It("falls back to lscc", func() {
+				collection, err := p.CollectionInfo("testchannel", "legacy-cc", "legacy-collection", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(collection, &common.StaticCollectionConfig{Name: "legacy-collection"})).To(BeTrue())
+			})
+		})
+
+		Context("when the channel does not have the V2_0 capability", func() {
+			BeforeEach(func() {
+				fakeCapabilities.GetApplicationCapabilitiesReturns(&mockconfig.MockApplicationCapabilities{})
+				fakeLegacy.CollectionInfoReturns(nil, nil)
+			})
+
+			It("neither resolves the committed nor the implicit collections", func() {
+				collection, err := p.CollectionInfo("testchannel", "cc", "collection", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection).To(BeNil())
+
+				collection, err = p.CollectionInfo("testchannel", lifecycle.LifecycleNamespace, privdata.ImplicitCollectionNameForOrg("org1"), qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(collection).To(BeNil())
+
+				Expect(fakeLegacy.CollectionInfoCallCount()).To(Equal(2))
+			})
+		})
+	})
+})
@@ -7,9 +7,22 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/chaincode/wasmcc"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
 )
 
@@ -17,17 +30,75 @@ import (
 type ChaincodeStore interface {
 	Save(name, version string, ccInstallPkg []byte) (hash []byte, err error)
 	RetrieveHash(name, version string) (hash []byte, err error)
+	Load(hash []byte) (ccInstallPkg []byte, name, version string, err error)
 }
 
 type PackageParser interface {
 	Parse(data []byte) (*persistence.ChaincodePackage, error)
 }
 
+// ChannelOrgSource provides the MSP IDs of the application orgs of a channel
+type ChannelOrgSource interface {
+	GetMSPIDs(channelID string) []string
+}
+
+// ChannelPolicySource provides the policy manager of a channel
+type ChannelPolicySource interface {
+	GetPolicyManager(channelID string) policies.Manager
+}
+
+// ChannelCapabilitiesSource provides the application capabilities of a channel
+type ChannelCapabilitiesSource interface {
+	// GetApplicationCapabilities returns nil if the capabilities of the channel are not known yet
+	GetApplicationCapabilities(channelID string) channelconfig.ApplicationCapabilities
+}
+
+// Legacy provides the chaincode definitions and packages of the
+// chaincodes which were instantiated through lscc
+type Legacy interface {
+	ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error)
+	ChaincodeContainerInfo(chaincodeName string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error)
+}
+
+// LegacyPackageSource provides the chaincode packages
+// which were installed through lscc
+type LegacyPackageSource interface {
+	GetChaincode(name, version string) (ccprovider.CCPackage, error)
+}
+
+// ReadableState is the state which the lifecycle reads definitions from
+type ReadableState interface {
+	GetState(key string) (value []byte, err error)
+}
+
+// ReadWritableState is the state which the lifecycle
+// reads definitions from and writes definitions to
+type ReadWritableState interface {
+	ReadableState
+	PutState(key string, value []byte) error
+	DelState(key string) error
+}
+
+// OpaqueState is the state of which only the hashes of the values can be
+// read, such as the org-scoped state of another org
+type OpaqueState interface {
+	GetStateHash(key string) (valueHash []byte, err error)
+}
+
+// OrgStates provides the org-scoped state of the orgs of a channel
+type OrgStates interface {
+	OrgState(orgMSPID string) OpaqueState
+}
+
 // Lifecycle implements the lifecycle operations which are invoked
 // by the SCC as well as internally
 type Lifecycle struct {
-	ChaincodeStore ChaincodeStore
-	PackageParser  PackageParser
+	ChaincodeStore      ChaincodeStore
+	PackageParser       PackageParser
+	ChannelOrgSource    ChannelOrgSource
+	ChannelPolicySource ChannelPolicySource
+	Legacy              Legacy
+	LegacyPackageSource LegacyPackageSource
 }
 
 // InstallChaincode installs a given chaincode to the peer's chaincode store.
@@ -56,3 +127,262 @@ func (l *Lifecycle) QueryInstalledChaincode(name, version string) ([]byte, error
 
 	return hash, nil
 }
+
+// ApproveChaincodeDefinitionForOrg records the approval of an org for the
+// next definition of a chaincode, in the org-scoped state of the org.
+func (l *Lifecycle) ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgState ReadWritableState) error {
+	if err := validateDefinition(name, cd); err != nil {
+		return err
+	}
+
+	if err := checkSequence(name, cd, publicState); err != nil {
+		return err
+	}
+
+	approvalBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal chaincode definition")
+	}
+
+	if err := orgState.PutState(ApprovalKey(name), approvalBytes); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("could not write approval for chaincode '%s'", name))
+	}
+
+	return nil
+}
+
+// CheckCommitReadiness returns whether each org of the channel approved
+// the given definition of a chaincode, by comparing the hash of the
+// definition with the hash of the approval in the org-scoped state.
+func (l *Lifecycle) CheckCommitReadiness(channelID, name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates OrgStates) (map[string]bool, error) {
+	if err := validateDefinition(name, cd); err != nil {
+		return nil, err
+	}
+
+	if err := checkSequence(name, cd, publicState); err != nil {
+		return nil, err
+	}
+
+	orgs := l.ChannelOrgSource.GetMSPIDs(channelID)
+	if len(orgs) == 0 {
+		return nil, errors.Errorf("no application orgs are defined on channel '%s'", channelID)
+	}
+
+	definitionBytes, err := proto.Marshal(cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	definitionHash := util.ComputeSHA256(definitionBytes)
+
+	approvals := make(map[string]bool, len(orgs))
+	for _, org := range orgs {
+		approvalHash, err := orgStates.OrgState(org).GetStateHash(ApprovalKey(name))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not read approval of org '%s' for chaincode '%s'", org, name))
+		}
+		approvals[org] = bytes.Equal(approvalHash, definitionHash)
+	}
+
+	return approvals, nil
+}
+
+// CommitChaincodeDefinition commits the given definition of a chaincode,
+// provided that the org of the peer approved it. Which orgs must approve
+// the definition is governed by the LifecycleEndorsement policy of the
+// channel, which the endorsements of the commit must satisfy. The committed
+// definition supersedes any definition of the chaincode in lscc.
+func (l *Lifecycle) CommitChaincodeDefinition(channelID, orgMSPID, name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates OrgStates) (map[string]bool, error) {
+	policyManager := l.ChannelPolicySource.GetPolicyManager(channelID)
+	if policyManager == nil {
+		return nil, errors.Errorf("could not get policy manager of channel '%s'", channelID)
+	}
+	if _, ok := policyManager.GetPolicy(LifecycleEndorsementPolicyRef); !ok {
+		return nil, errors.Errorf("channel '%s' does not define the policy '%s' which governs the commit of chaincode definitions", channelID, LifecycleEndorsementPolicyRef)
+	}
+
+	approvals, err := l.CheckCommitReadiness(channelID, name, cd, publicState, orgStates)
+	if err != nil {
+		return nil, err
+	}
+
+	if !approvals[orgMSPID] {
+		return nil, errors.Errorf("chaincode definition for '%s' is not approved by org '%s'", name, orgMSPID)
+	}
+
+	// The collections are stored separately, following the state layout of lscc
+	definition := proto.Clone(cd).(*lb.ChaincodeDefinition)
+	definition.Collections = nil
+	definitionBytes, err := proto.Marshal(definition)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal chaincode definition")
+	}
+	if err := publicState.PutState(name, definitionBytes); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not write definition of chaincode '%s'", name))
+	}
+
+	collectionsKey := privdata.BuildCollectionKVSKey(name)
+	if len(cd.Collections.GetConfig()) == 0 {
+		// Remove the collections of the previous definition, if any
+		if err := publicState.DelState(collectionsKey); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not remove collections of chaincode '%s'", name))
+		}
+		return approvals, nil
+	}
+
+	collectionsBytes, err := proto.Marshal(cd.Collections)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal collections")
+	}
+	if err := publicState.PutState(collectionsKey, collectionsBytes); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not write collections of chaincode '%s'", name))
+	}
+
+	return approvals, nil
+}
+
+// QueryChaincodeDefinition returns the committed definition of a chaincode.
+func (l *Lifecycle) QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	definition, err := committedDefinition(name, publicState)
+	if err != nil {
+		return nil, err
+	}
+	if definition == nil {
+		return nil, errors.Errorf("chaincode '%s' is not defined", name)
+	}
+
+	collectionsBytes, err := publicState.GetState(privdata.BuildCollectionKVSKey(name))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read collections of chaincode '%s'", name))
+	}
+	if collectionsBytes != nil {
+		definition.Collections = &common.CollectionConfigPackage{}
+		if err := proto.Unmarshal(collectionsBytes, definition.Collections); err != nil {
+			return nil, errors.Wrapf(err, "chaincode '%s' has bad collections", name)
+		}
+	}
+
+	return definition, nil
+}
+
+// ChaincodeDefinition returns the definition of a chaincode. Chaincodes which
+// were not defined through the lifecycle SCC are looked up in lscc, which lets
+// chaincodes instantiated through lscc migrate by committing a new definition.
+func (l *Lifecycle) ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error) {
+	cd, err := GetChaincodeDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return l.Legacy.ChaincodeDefinition(chaincodeName, qe)
+	}
+
+	return cd, nil
+}
+
+// ChaincodeContainerInfo returns the information necessary to launch a
+// chaincode. For chaincodes defined through the lifecycle SCC, the package
+// installed for the defined version is used, whether it was installed
+// through the lifecycle SCC or through lscc.
+func (l *Lifecycle) ChaincodeContainerInfo(chaincodeName string, qe ledger.QueryExecutor) (*ccprovider.ChaincodeContainerInfo, error) {
+	cd, err := GetChaincodeDefinition(chaincodeName, qe)
+	if err != nil {
+		return nil, err
+	}
+	if cd == nil {
+		return l.Legacy.ChaincodeContainerInfo(chaincodeName, qe)
+	}
+
+	version := cd.Definition.Version
+	hash, err := l.ChaincodeStore.RetrieveHash(chaincodeName, version)
+	if _, notFound := err.(*persistence.CodePackageNotFoundErr); notFound {
+		ccPackage, err := l.LegacyPackageSource.GetChaincode(chaincodeName, version)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("chaincode '%s:%s' is not installed", chaincodeName, version))
+		}
+		return ccprovider.DeploymentSpecToChaincodeContainerInfo(ccPackage.GetDepSpec()), nil
+	}
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve hash for chaincode '%s:%s'", chaincodeName, version))
+	}
+
+	ccInstallPkg, _, _, err := l.ChaincodeStore.Load(hash)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not load chaincode '%s:%s'", chaincodeName, version))
+	}
+
+	ccPackage, err := l.PackageParser.Parse(ccInstallPkg)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not parse chaincode '%s:%s'", chaincodeName, version))
+	}
+
+	ccType := strings.ToUpper(ccPackage.Metadata.Type)
+	containerType := "DOCKER"
+	switch ccType {
+	case extcc.ChaincodeType:
+		containerType = extcc.ContainerType
+	case wasmcc.ChaincodeType:
+		containerType = wasmcc.ContainerType
+	}
+
+	return &ccprovider.ChaincodeContainerInfo{
+		Name:          chaincodeName,
+		Version:       version,
+		Path:          ccPackage.Metadata.Path,
+		Type:          ccType,
+		ContainerType: containerType,
+	}, nil
+}
+
+func committedDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error) {
+	definitionBytes, err := publicState.GetState(name)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read definition of chaincode '%s'", name))
+	}
+	if definitionBytes == nil {
+		return nil, nil
+	}
+
+	definition := &lb.ChaincodeDefinition{}
+	if err := proto.Unmarshal(definitionBytes, definition); err != nil {
+		return nil, errors.Wrapf(err, "chaincode '%s' has bad definition", name)
+	}
+
+	return definition, nil
+}
+
+// checkSequence ensures that the given definition is the next one
+// after the committed definition of the chaincode, if any
+func checkSequence(name string, cd *lb.ChaincodeDefinition, publicState ReadableState) error {
+	current, err := committedDefinition(name, publicState)
+	if err != nil {
+		return err
+	}
+
+	expected := current.GetSequence() + 1
+	if cd.Sequence != expected {
+		return errors.Errorf("requested sequence is %d, but new definition of chaincode '%s' must be sequence %d", cd.Sequence, name, expected)
+	}
+
+	return nil
+}
+
+func validateDefinition(name string, cd *lb.ChaincodeDefinition) error {
+	switch {
+	case name == "":
+		return errors.New("chaincode name must be specified")
+	case strings.Contains(name, "/"):
+		return errors.Errorf("invalid chaincode name '%s'", name)
+	case cd == nil:
+		return errors.New("chaincode definition must be specified")
+	case cd.Version == "":
+		return errors.New("chaincode version must be specified")
+	case cd.EndorsementPlugin == "":
+		return errors.New("endorsement plugin must be specified")
+	case cd.ValidationPlugin == "":
+		return errors.New("validation plugin must be specified")
+	case len(cd.ValidationParameter) == 0:
+		return errors.New("validation parameter must be specified")
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ package lifecycle_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	. "github.com/onsi/ginkgo"
@@ -35,6 +36,65 @@ type sccFunctions interface {
 	lifecycle.SCCFunctions
 }
 
+//go:generate counterfeiter -o mock/channel_org_source.go --fake-name ChannelOrgSource . channelOrgSource
+type channelOrgSource interface {
+	lifecycle.ChannelOrgSource
+}
+
+//go:generate counterfeiter -o mock/channel_policy_source.go --fake-name ChannelPolicySource . channelPolicySource
+type channelPolicySource interface {
+	lifecycle.ChannelPolicySource
+}
+
+//go:generate counterfeiter -o mock/channel_capabilities_source.go --fake-name ChannelCapabilitiesSource . channelCapabilitiesSource
+type channelCapabilitiesSource interface {
+	lifecycle.ChannelCapabilitiesSource
+}
+
+//go:generate counterfeiter -o mock/legacy.go --fake-name Legacy . legacy
+type legacy interface {
+	lifecycle.Legacy
+}
+
+//go:generate counterfeiter -o mock/legacy_package_source.go --fake-name LegacyPackageSource . legacyPackageSource
+type legacyPackageSource interface {
+	lifecycle.LegacyPackageSource
+}
+
+// MapLedgerShim is a ReadWritableState backed by a map
+type MapLedgerShim map[string][]byte
+
+func (m MapLedgerShim) GetState(key string) ([]byte, error) {
+	return m[key], nil
+}
+
+func (m MapLedgerShim) PutState(key string, value []byte) error {
+	m[key] = value
+	return nil
+}
+
+func (m MapLedgerShim) DelState(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m MapLedgerShim) GetStateHash(key string) ([]byte, error) {
+	if m[key] == nil {
+		return nil, nil
+	}
+	return util.ComputeSHA256(m[key]), nil
+}
+
+// MapOrgStates provides the org-scoped states of the orgs backed by maps
+type MapOrgStates map[string]MapLedgerShim
+
+func (m MapOrgStates) OrgState(orgMSPID string) lifecycle.OpaqueState {
+	if m[orgMSPID] == nil {
+		m[orgMSPID] = MapLedgerShim{}
+	}
+	return m[orgMSPID]
+}
+
 func TestLifecycle(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Lifecycle Suite")
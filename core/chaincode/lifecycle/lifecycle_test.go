@@ -9,26 +9,57 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/golang/protobuf/proto"
+	mockledger "github.com/hyperledger/fabric/common/mocks/ledger"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 )
 
 var _ = Describe("Lifecycle", func() {
 	var (
-		l           *lifecycle.Lifecycle
-		fakeCCStore *mock.ChaincodeStore
-		fakeParser  *mock.PackageParser
+		l                       *lifecycle.Lifecycle
+		fakeCCStore             *mock.ChaincodeStore
+		fakeParser              *mock.PackageParser
+		fakeChannelOrgSource    *mock.ChannelOrgSource
+		fakePolicySource        *mock.ChannelPolicySource
+		fakePolicyManager       *mockpolicies.Manager
+		fakeLegacy              *mock.Legacy
+		fakeLegacyPackageSource *mock.LegacyPackageSource
 	)
 
 	BeforeEach(func() {
 		fakeCCStore = &mock.ChaincodeStore{}
 		fakeParser = &mock.PackageParser{}
+		fakeChannelOrgSource = &mock.ChannelOrgSource{}
+		fakePolicyManager = &mockpolicies.Manager{
+			PolicyMap: map[string]policies.Policy{
+				lifecycle.LifecycleEndorsementPolicyRef: &mockpolicies.Policy{},
+			},
+		}
+		fakePolicySource = &mock.ChannelPolicySource{}
+		fakePolicySource.GetPolicyManagerReturns(fakePolicyManager)
+		fakeLegacy = &mock.Legacy{}
+		fakeLegacyPackageSource = &mock.LegacyPackageSource{}
 
 		l = &lifecycle.Lifecycle{
-			PackageParser:  fakeParser,
-			ChaincodeStore: fakeCCStore,
+			PackageParser:       fakeParser,
+			ChaincodeStore:      fakeCCStore,
+			ChannelOrgSource:    fakeChannelOrgSource,
+			ChannelPolicySource: fakePolicySource,
+			Legacy:              fakeLegacy,
+			LegacyPackageSource: fakeLegacyPackageSource,
 		}
 	})
 
@@ -103,4 +134,399 @@ var _ = Describe("Lifecycle", func() {
 			})
 		})
 	})
+
+	Describe("Chaincode definitions", func() {
+		var (
+			state      MapLedgerShim
+			orgStates  MapOrgStates
+			definition *lb.ChaincodeDefinition
+		)
+
+		approve := func(org string, cd *lb.ChaincodeDefinition) {
+			orgStates.OrgState(org).(MapLedgerShim)[lifecycle.ApprovalKey("cc")] = utils.MarshalOrPanic(cd)
+		}
+
+		BeforeEach(func() {
+			state = MapLedgerShim{}
+			orgStates = MapOrgStates{}
+			definition = &lb.ChaincodeDefinition{
+				Sequence:            1,
+				Version:             "1.0",
+				EndorsementPlugin:   "escc",
+				ValidationPlugin:    "vscc",
+				ValidationParameter: []byte("policy"),
+				Collections: &common.CollectionConfigPackage{
+					Config: []*common.CollectionConfig{{
+						Payload: &common.CollectionConfig_StaticCollectionConfig{
+							StaticCollectionConfig: &common.StaticCollectionConfig{Name: "collection"},
+						},
+					}},
+				},
+			}
+			fakeChannelOrgSource.GetMSPIDsReturns([]string{"org1", "org2", "org3"})
+		})
+
+		Describe("ApproveChaincodeDefinitionForOrg", func() {
+			var orgState MapLedgerShim
+
+			BeforeEach(func() {
+				orgState = MapLedgerShim{}
+			})
+
+			It("records the approval in the org-scoped state", func() {
+				err := l.ApproveChaincodeDefinitionForOrg("cc", definition, state, orgState)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(state).To(BeEmpty())
+				Expect(orgState).To(HaveLen(1))
+
+				approved := &lb.ChaincodeDefinition{}
+				err = proto.Unmarshal(orgState["approvals/cc"], approved)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(approved, definition)).To(BeTrue())
+			})
+
+			Context("when the sequence doesn't follow the committed definition", func() {
+				BeforeEach(func() {
+					state["cc"] = utils.MarshalOrPanic(&lb.ChaincodeDefinition{Sequence: 1})
+				})
+
+				It("returns an error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("cc", definition, state, orgState)
+					Expect(err).To(MatchError("requested sequence is 1, but new definition of chaincode 'cc' must be sequence 2"))
+				})
+			})
+
+			Context("when the definition is incomplete", func() {
+				BeforeEach(func() {
+					definition.ValidationParameter = nil
+				})
+
+				It("returns an error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("cc", definition, state, orgState)
+					Expect(err).To(MatchError("validation parameter must be specified"))
+					Expect(orgState).To(BeEmpty())
+				})
+			})
+
+			Context("when the chaincode name is invalid", func() {
+				It("returns an error", func() {
+					err := l.ApproveChaincodeDefinitionForOrg("cc/1", definition, state, orgState)
+					Expect(err).To(MatchError("invalid chaincode name 'cc/1'"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadiness", func() {
+			It("returns which orgs approved the definition", func() {
+				approve("org1", definition)
+				otherVersion := proto.Clone(definition).(*lb.ChaincodeDefinition)
+				otherVersion.Version = "2.0"
+				approve("org2", otherVersion)
+
+				approvals, err := l.CheckCommitReadiness("channel", "cc", definition, state, orgStates)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{
+					"org1": true,
+					"org2": false,
+					"org3": false,
+				}))
+				Expect(fakeChannelOrgSource.GetMSPIDsArgsForCall(0)).To(Equal("channel"))
+			})
+
+			Context("when the channel has no application orgs", func() {
+				BeforeEach(func() {
+					fakeChannelOrgSource.GetMSPIDsReturns(nil)
+				})
+
+				It("returns an error", func() {
+					_, err := l.CheckCommitReadiness("channel", "cc", definition, state, orgStates)
+					Expect(err).To(MatchError("no application orgs are defined on channel 'channel'"))
+				})
+			})
+
+			Context("when the hash of an approval cannot be read", func() {
+				It("returns an error", func() {
+					_, err := l.CheckCommitReadiness("channel", "cc", definition, state, &failingOrgStates{})
+					Expect(err).To(MatchError("could not read approval of org 'org1' for chaincode 'cc': hash unavailable"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			BeforeEach(func() {
+				approve("org1", definition)
+				approve("org3", definition)
+			})
+
+			It("commits the definition following the state layout of lscc", func() {
+				approvals, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{
+					"org1": true,
+					"org2": false,
+					"org3": true,
+				}))
+				Expect(fakePolicySource.GetPolicyManagerArgsForCall(0)).To(Equal("channel"))
+
+				committed := &lb.ChaincodeDefinition{}
+				err = proto.Unmarshal(state["cc"], committed)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(committed.Collections).To(BeNil())
+				Expect(committed.Version).To(Equal("1.0"))
+
+				collections := &common.CollectionConfigPackage{}
+				err = proto.Unmarshal(state[privdata.BuildCollectionKVSKey("cc")], collections)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(collections, definition.Collections)).To(BeTrue())
+
+				queried, err := l.QueryChaincodeDefinition("cc", state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(queried, definition)).To(BeTrue())
+			})
+
+			Context("when the next definition has no collections", func() {
+				BeforeEach(func() {
+					_, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+					Expect(err).NotTo(HaveOccurred())
+
+					definition = proto.Clone(definition).(*lb.ChaincodeDefinition)
+					definition.Sequence = 2
+					definition.Collections = nil
+					approve("org1", definition)
+					approve("org2", definition)
+				})
+
+				It("removes the collections of the previous definition", func() {
+					_, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(state).NotTo(HaveKey(privdata.BuildCollectionKVSKey("cc")))
+
+					queried, err := l.QueryChaincodeDefinition("cc", state)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(queried.Sequence).To(Equal(int64(2)))
+					Expect(queried.Collections).To(BeNil())
+				})
+			})
+
+			Context("when the org of the peer didn't approve the definition", func() {
+				It("returns an error and doesn't commit the definition", func() {
+					_, err := l.CommitChaincodeDefinition("channel", "org2", "cc", definition, state, orgStates)
+					Expect(err).To(MatchError("chaincode definition for 'cc' is not approved by org 'org2'"))
+					Expect(state).NotTo(HaveKey("cc"))
+				})
+			})
+
+			Context("when the channel doesn't define the LifecycleEndorsement policy", func() {
+				BeforeEach(func() {
+					fakePolicyManager.PolicyMap = nil
+				})
+
+				It("returns an error and doesn't commit the definition", func() {
+					_, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+					Expect(err).To(MatchError("channel 'channel' does not define the policy '/Channel/Application/LifecycleEndorsement' which governs the commit of chaincode definitions"))
+					Expect(state).NotTo(HaveKey("cc"))
+				})
+			})
+
+			Context("when the channel doesn't exist", func() {
+				BeforeEach(func() {
+					fakePolicySource.GetPolicyManagerReturns(nil)
+				})
+
+				It("returns an error", func() {
+					_, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+					Expect(err).To(MatchError("could not get policy manager of channel 'channel'"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			Context("when the chaincode is not defined", func() {
+				It("returns an error", func() {
+					_, err := l.QueryChaincodeDefinition("cc", state)
+					Expect(err).To(MatchError("chaincode 'cc' is not defined"))
+				})
+			})
+		})
+
+		Describe("ChaincodeDefinition", func() {
+			var qe *mockledger.MockQueryExecutor
+
+			BeforeEach(func() {
+				qe = &mockledger.MockQueryExecutor{
+					State: map[string]map[string][]byte{
+						lifecycle.LifecycleNamespace: state,
+					},
+				}
+			})
+
+			It("returns the committed definition", func() {
+				approve("org1", definition)
+				approve("org2", definition)
+				_, err := l.CommitChaincodeDefinition("channel", "org1", "cc", definition, state, orgStates)
+				Expect(err).NotTo(HaveOccurred())
+
+				cd, err := l.ChaincodeDefinition("cc", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cd.CCName()).To(Equal("cc"))
+				Expect(cd.CCVersion()).To(Equal("1.0"))
+				Expect(cd.Endorsement()).To(Equal("escc"))
+				vscc, policy := cd.Validation()
+				Expect(vscc).To(Equal("vscc"))
+				Expect(policy).To(Equal([]byte("policy")))
+				Expect(proto.Equal(cd.(*lifecycle.ChaincodeDefinition).Definition, definition)).To(BeTrue())
+				Expect(fakeLegacy.ChaincodeDefinitionCallCount()).To(Equal(0))
+			})
+
+			Context("when the chaincode was instantiated through lscc", func() {
+				BeforeEach(func() {
+					fakeLegacy.ChaincodeDefinitionReturns(&ccprovider.ChaincodeData{Name: "cc", Version: "legacy"}, nil)
+				})
+
+				It("falls back to lscc", func() {
+					cd, err := l.ChaincodeDefinition("cc", qe)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cd.CCVersion()).To(Equal("legacy"))
+					name, legacyQE := fakeLegacy.ChaincodeDefinitionArgsForCall(0)
+					Expect(name).To(Equal("cc"))
+					Expect(legacyQE).To(Equal(qe))
+				})
+			})
+		})
+
+		Describe("ChaincodeContainerInfo", func() {
+			var qe *mockledger.MockQueryExecutor
+
+			BeforeEach(func() {
+				qe = &mockledger.MockQueryExecutor{
+					State: map[string]map[string][]byte{
+						lifecycle.LifecycleNamespace: state,
+					},
+				}
+				state["cc"] = utils.MarshalOrPanic(definition)
+
+				fakeCCStore.RetrieveHashReturns([]byte("hash"), nil)
+				fakeCCStore.LoadReturns([]byte("package"), "cc", "1.0", nil)
+				fakeParser.ParseReturns(&persistence.ChaincodePackage{
+					Metadata: &persistence.ChaincodePackageMetadata{
+						Type: "golang",
+						Path: "github.com/cc",
+					},
+				}, nil)
+			})
+
+			It("returns the information of the installed package", func() {
+				ccci, err := l.ChaincodeContainerInfo("cc", qe)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccci).To(Equal(&ccprovider.ChaincodeContainerInfo{
+					Name:          "cc",
+					Version:       "1.0",
+					Path:          "github.com/cc",
+					Type:          "GOLANG",
+					ContainerType: "DOCKER",
+				}))
+
+				name, version := fakeCCStore.RetrieveHashArgsForCall(0)
+				Expect(name).To(Equal("cc"))
+				Expect(version).To(Equal("1.0"))
+				Expect(fakeCCStore.LoadArgsForCall(0)).To(Equal([]byte("hash")))
+				Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("package")))
+			})
+
+			Context("when the package is for an external chaincode", func() {
+				BeforeEach(func() {
+					fakeParser.ParseReturns(&persistence.ChaincodePackage{
+						Metadata: &persistence.ChaincodePackageMetadata{
+							Type: "external",
+						},
+					}, nil)
+				})
+
+				It("returns the external container type", func() {
+					ccci, err := l.ChaincodeContainerInfo("cc", qe)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccci.Type).To(Equal("EXTERNAL"))
+					Expect(ccci.ContainerType).To(Equal("EXTERNAL"))
+				})
+			})
+
+			Context("when the package is for a WASM chaincode", func() {
+				BeforeEach(func() {
+					fakeParser.ParseReturns(&persistence.ChaincodePackage{
+						Metadata: &persistence.ChaincodePackageMetadata{
+							Type: "wasm",
+						},
+					}, nil)
+				})
+
+				It("returns the WASM container type", func() {
+					ccci, err := l.ChaincodeContainerInfo("cc", qe)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccci.Type).To(Equal("WASM"))
+					Expect(ccci.ContainerType).To(Equal("WASM"))
+				})
+			})
+
+			Context("when the package was installed through lscc", func() {
+				BeforeEach(func() {
+					fakeCCStore.RetrieveHashReturns(nil, &persistence.CodePackageNotFoundErr{Name: "cc", Version: "1.0"})
+
+					ccpack := &ccprovider.CDSPackage{}
+					_, err := ccpack.InitFromBuffer(utils.MarshalOrPanic(&pb.ChaincodeDeploymentSpec{
+						ChaincodeSpec: &pb.ChaincodeSpec{
+							Type:        pb.ChaincodeSpec_GOLANG,
+							ChaincodeId: &pb.ChaincodeID{Name: "cc", Version: "1.0", Path: "github.com/legacy"},
+						},
+					}))
+					Expect(err).NotTo(HaveOccurred())
+					fakeLegacyPackageSource.GetChaincodeReturns(ccpack, nil)
+				})
+
+				It("returns the information of the legacy package", func() {
+					ccci, err := l.ChaincodeContainerInfo("cc", qe)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccci.Path).To(Equal("github.com/legacy"))
+					Expect(ccci.Type).To(Equal("GOLANG"))
+					Expect(fakeCCStore.LoadCallCount()).To(Equal(0))
+				})
+
+				Context("when the package isn't installed through lscc either", func() {
+					BeforeEach(func() {
+						fakeLegacyPackageSource.GetChaincodeReturns(nil, fmt.Errorf("fake-error"))
+					})
+
+					It("returns an error", func() {
+						_, err := l.ChaincodeContainerInfo("cc", qe)
+						Expect(err).To(MatchError("chaincode 'cc:1.0' is not installed: fake-error"))
+					})
+				})
+			})
+
+			Context("when the chaincode was instantiated through lscc", func() {
+				BeforeEach(func() {
+					delete(state, "cc")
+					fakeLegacy.ChaincodeContainerInfoReturns(&ccprovider.ChaincodeContainerInfo{Name: "cc"}, nil)
+				})
+
+				It("falls back to lscc", func() {
+					ccci, err := l.ChaincodeContainerInfo("cc", qe)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccci).To(Equal(&ccprovider.ChaincodeContainerInfo{Name: "cc"}))
+					Expect(fakeCCStore.RetrieveHashCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
 })
+
+type failingOrgStates struct{}
+
+func (*failingOrgStates) OrgState(orgMSPID string) lifecycle.OpaqueState {
+	return &failingOrgState{}
+}
+
+type failingOrgState struct{}
+
+func (*failingOrgState) GetStateHash(key string) ([]byte, error) {
+	return nil, errors.New("hash unavailable")
+}
@@ -138,6 +138,33 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetMultiplePrivateDataStub        func(string, ...string) ([][]byte, error)
+	getMultiplePrivateDataMutex       sync.RWMutex
+	getMultiplePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getMultiplePrivateDataReturns struct {
+		result1 [][]byte
+		result2 error
+	}
+	getMultiplePrivateDataReturnsOnCall map[int]struct {
+		result1 [][]byte
+		result2 error
+	}
+	GetMultipleStatesStub        func(...string) ([][]byte, error)
+	getMultipleStatesMutex       sync.RWMutex
+	getMultipleStatesArgsForCall []struct {
+		arg1 []string
+	}
+	getMultipleStatesReturns struct {
+		result1 [][]byte
+		result2 error
+	}
+	getMultipleStatesReturnsOnCall map[int]struct {
+		result1 [][]byte
+		result2 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -413,6 +440,19 @@ type ChaincodeStub struct {
 	invokeChaincodeReturnsOnCall map[int]struct {
 		result1 peer.Response
 	}
+	InvokeChaincodeReadOnlyStub        func(string, [][]byte, string) peer.Response
+	invokeChaincodeReadOnlyMutex       sync.RWMutex
+	invokeChaincodeReadOnlyArgsForCall []struct {
+		arg1 string
+		arg2 [][]byte
+		arg3 string
+	}
+	invokeChaincodeReadOnlyReturns struct {
+		result1 peer.Response
+	}
+	invokeChaincodeReadOnlyReturnsOnCall map[int]struct {
+		result1 peer.Response
+	}
 	PutPrivateDataStub        func(string, string, []byte) error
 	putPrivateDataMutex       sync.RWMutex
 	putPrivateDataArgsForCall []struct {
@@ -1123,6 +1163,133 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetMultiplePrivateData(arg1 string, arg2 ...string) ([][]byte, error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	ret, specificReturn := fake.getMultiplePrivateDataReturnsOnCall[len(fake.getMultiplePrivateDataArgsForCall)]
+	fake.getMultiplePrivateDataArgsForCall = append(fake.getMultiplePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	fake.recordInvocation("GetMultiplePrivateData", []interface{}{arg1, arg2})
+	fake.getMultiplePrivateDataMutex.Unlock()
+	if fake.GetMultiplePrivateDataStub != nil {
+		return fake.GetMultiplePrivateDataStub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMultiplePrivateDataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataCallCount() int {
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	return len(fake.getMultiplePrivateDataArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataCalls(stub func(string, ...string) ([][]byte, error)) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = stub
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataArgsForCall(i int) (string, []string) {
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	argsForCall := fake.getMultiplePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataReturns(result1 [][]byte, result2 error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = nil
+	fake.getMultiplePrivateDataReturns = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultiplePrivateDataReturnsOnCall(i int, result1 [][]byte, result2 error) {
+	fake.getMultiplePrivateDataMutex.Lock()
+	defer fake.getMultiplePrivateDataMutex.Unlock()
+	fake.GetMultiplePrivateDataStub = nil
+	if fake.getMultiplePrivateDataReturnsOnCall == nil {
+		fake.getMultiplePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 [][]byte
+			result2 error
+		})
+	}
+	fake.getMultiplePrivateDataReturnsOnCall[i] = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultipleStates(arg1 ...string) ([][]byte, error) {
+	fake.getMultipleStatesMutex.Lock()
+	ret, specificReturn := fake.getMultipleStatesReturnsOnCall[len(fake.getMultipleStatesArgsForCall)]
+	fake.getMultipleStatesArgsForCall = append(fake.getMultipleStatesArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	fake.recordInvocation("GetMultipleStates", []interface{}{arg1})
+	fake.getMultipleStatesMutex.Unlock()
+	if fake.GetMultipleStatesStub != nil {
+		return fake.GetMultipleStatesStub(arg1...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getMultipleStatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesCallCount() int {
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
+	return len(fake.getMultipleStatesArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesCalls(stub func(...string) ([][]byte, error)) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = stub
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesArgsForCall(i int) []string {
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
+	argsForCall := fake.getMultipleStatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesReturns(result1 [][]byte, result2 error) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = nil
+	fake.getMultipleStatesReturns = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetMultipleStatesReturnsOnCall(i int, result1 [][]byte, result2 error) {
+	fake.getMultipleStatesMutex.Lock()
+	defer fake.getMultipleStatesMutex.Unlock()
+	fake.GetMultipleStatesStub = nil
+	if fake.getMultipleStatesReturnsOnCall == nil {
+		fake.getMultipleStatesReturnsOnCall = make(map[int]struct {
+			result1 [][]byte
+			result2 error
+		})
+	}
+	fake.getMultipleStatesReturnsOnCall[i] = struct {
+		result1 [][]byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -2383,6 +2550,73 @@ func (fake *ChaincodeStub) InvokeChaincodeReturnsOnCall(i int, result1 peer.Resp
 	}{result1}
 }
 
+func (fake *ChaincodeStub) InvokeChaincodeReadOnly(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
+	var arg2Copy [][]byte
+	if arg2 != nil {
+		arg2Copy = make([][]byte, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.invokeChaincodeReadOnlyMutex.Lock()
+	ret, specificReturn := fake.invokeChaincodeReadOnlyReturnsOnCall[len(fake.invokeChaincodeReadOnlyArgsForCall)]
+	fake.invokeChaincodeReadOnlyArgsForCall = append(fake.invokeChaincodeReadOnlyArgsForCall, struct {
+		arg1 string
+		arg2 [][]byte
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("InvokeChaincodeReadOnly", []interface{}{arg1, arg2Copy, arg3})
+	fake.invokeChaincodeReadOnlyMutex.Unlock()
+	if fake.InvokeChaincodeReadOnlyStub != nil {
+		return fake.InvokeChaincodeReadOnlyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.invokeChaincodeReadOnlyReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) InvokeChaincodeReadOnlyCallCount() int {
+	fake.invokeChaincodeReadOnlyMutex.RLock()
+	defer fake.invokeChaincodeReadOnlyMutex.RUnlock()
+	return len(fake.invokeChaincodeReadOnlyArgsForCall)
+}
+
+func (fake *ChaincodeStub) InvokeChaincodeReadOnlyCalls(stub func(string, [][]byte, string) peer.Response) {
+	fake.invokeChaincodeReadOnlyMutex.Lock()
+	defer fake.invokeChaincodeReadOnlyMutex.Unlock()
+	fake.InvokeChaincodeReadOnlyStub = stub
+}
+
+func (fake *ChaincodeStub) InvokeChaincodeReadOnlyArgsForCall(i int) (string, [][]byte, string) {
+	fake.invokeChaincodeReadOnlyMutex.RLock()
+	defer fake.invokeChaincodeReadOnlyMutex.RUnlock()
+	argsForCall := fake.invokeChaincodeReadOnlyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) InvokeChaincodeReadOnlyReturns(result1 peer.Response) {
+	fake.invokeChaincodeReadOnlyMutex.Lock()
+	defer fake.invokeChaincodeReadOnlyMutex.Unlock()
+	fake.InvokeChaincodeReadOnlyStub = nil
+	fake.invokeChaincodeReadOnlyReturns = struct {
+		result1 peer.Response
+	}{result1}
+}
+
+func (fake *ChaincodeStub) InvokeChaincodeReadOnlyReturnsOnCall(i int, result1 peer.Response) {
+	fake.invokeChaincodeReadOnlyMutex.Lock()
+	defer fake.invokeChaincodeReadOnlyMutex.Unlock()
+	fake.InvokeChaincodeReadOnlyStub = nil
+	if fake.invokeChaincodeReadOnlyReturnsOnCall == nil {
+		fake.invokeChaincodeReadOnlyReturnsOnCall = make(map[int]struct {
+			result1 peer.Response
+		})
+	}
+	fake.invokeChaincodeReadOnlyReturnsOnCall[i] = struct {
+		result1 peer.Response
+	}{result1}
+}
+
 func (fake *ChaincodeStub) PutPrivateData(arg1 string, arg2 string, arg3 []byte) error {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -2806,6 +3040,10 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getMultiplePrivateDataMutex.RLock()
+	defer fake.getMultiplePrivateDataMutex.RUnlock()
+	fake.getMultipleStatesMutex.RLock()
+	defer fake.getMultipleStatesMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
@@ -2846,6 +3084,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getTxTimestampMutex.RUnlock()
 	fake.invokeChaincodeMutex.RLock()
 	defer fake.invokeChaincodeMutex.RUnlock()
+	fake.invokeChaincodeReadOnlyMutex.RLock()
+	defer fake.invokeChaincodeReadOnlyMutex.RUnlock()
 	fake.putPrivateDataMutex.RLock()
 	defer fake.putPrivateDataMutex.RUnlock()
 	fake.putStateMutex.RLock()
@@ -10,6 +10,8 @@ import (
 	"fmt"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/pkg/errors"
@@ -21,6 +23,22 @@ const (
 
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
+
+	// ApproveChaincodeDefinitionForMyOrgFuncName is the chaincode function name used to
+	// approve a chaincode definition on behalf of the org of the peer
+	ApproveChaincodeDefinitionForMyOrgFuncName = "ApproveChaincodeDefinitionForMyOrg"
+
+	// CheckCommitReadinessFuncName is the chaincode function name used to check
+	// which orgs approved a chaincode definition
+	CheckCommitReadinessFuncName = "CheckCommitReadiness"
+
+	// CommitChaincodeDefinitionFuncName is the chaincode function name used to
+	// commit a chaincode definition to the channel
+	CommitChaincodeDefinitionFuncName = "CommitChaincodeDefinition"
+
+	// QueryChaincodeDefinitionFuncName is the chaincode function name used to
+	// query the committed definition of a chaincode
+	QueryChaincodeDefinitionFuncName = "QueryChaincodeDefinition"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -31,6 +49,18 @@ type SCCFunctions interface {
 
 	// QueryInstalledChaincode returns the hash for a given name and version of an installed chaincode
 	QueryInstalledChaincode(name, version string) (hash []byte, err error)
+
+	// ApproveChaincodeDefinitionForOrg records the approval of an org for a chaincode definition
+	ApproveChaincodeDefinitionForOrg(name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgState ReadWritableState) error
+
+	// CheckCommitReadiness returns whether each org of the channel approved a chaincode definition
+	CheckCommitReadiness(channelID, name string, cd *lb.ChaincodeDefinition, publicState ReadableState, orgStates OrgStates) (map[string]bool, error)
+
+	// CommitChaincodeDefinition commits a chaincode definition approved by the org of the peer
+	CommitChaincodeDefinition(channelID, orgMSPID, name string, cd *lb.ChaincodeDefinition, publicState ReadWritableState, orgStates OrgStates) (map[string]bool, error)
+
+	// QueryChaincodeDefinition returns the committed definition of a chaincode
+	QueryChaincodeDefinition(name string, publicState ReadableState) (*lb.ChaincodeDefinition, error)
 }
 
 // SCC implements the required methods to satisfy the chaincode interface.
@@ -38,6 +68,10 @@ type SCCFunctions interface {
 type SCC struct {
 	Protobuf  Protobuf
 	Functions SCCFunctions
+
+	// OrgMSPID is the MSP ID of the org of the peer, on
+	// behalf of which chaincode definitions are approved
+	OrgMSPID string
 }
 
 // Name returns "+lifecycle"
@@ -145,8 +179,167 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
+		return shim.Success(resultBytes)
+	case ApproveChaincodeDefinitionForMyOrgFuncName:
+		input := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to ApproveChaincodeDefinitionForMyOrg")
+			return shim.Error(err.Error())
+		}
+
+		// Only members of the org of the peer may approve on its behalf
+		err = scc.checkCreatorOrg(stub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		orgState := &ChaincodePrivateLedgerShim{
+			Stub:       stub,
+			Collection: privdata.ImplicitCollectionNameForOrg(scc.OrgMSPID),
+		}
+		err = scc.Functions.ApproveChaincodeDefinitionForOrg(input.Name, input.Definition, stub, orgState)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing ApproveChaincodeDefinitionForOrg")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.ApproveChaincodeDefinitionForMyOrgResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CheckCommitReadinessFuncName:
+		input := &lb.CheckCommitReadinessArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		approvals, err := scc.Functions.CheckCommitReadiness(stub.GetChannelID(), input.Name, input.Definition, stub, &ChaincodeOrgStates{Stub: stub})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CheckCommitReadiness")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CheckCommitReadinessResult{
+			Approvals: approvals,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case CommitChaincodeDefinitionFuncName:
+		input := &lb.CommitChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		_, err = scc.Functions.CommitChaincodeDefinition(stub.GetChannelID(), scc.OrgMSPID, input.Name, input.Definition, stub, &ChaincodeOrgStates{Stub: stub})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing CommitChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.CommitChaincodeDefinitionResult{})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(resultBytes)
+	case QueryChaincodeDefinitionFuncName:
+		input := &lb.QueryChaincodeDefinitionArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		definition, err := scc.Functions.QueryChaincodeDefinition(input.Name, stub)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryChaincodeDefinition")
+			return shim.Error(err.Error())
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(&lb.QueryChaincodeDefinitionResult{
+			Definition: definition,
+		})
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
 		return shim.Success(resultBytes)
 	default:
 		return shim.Error(fmt.Sprintf("unknown lifecycle function: %s", funcName))
 	}
 }
+
+// checkCreatorOrg ensures that the creator of the proposal belongs to the org of the peer
+func (scc *SCC) checkCreatorOrg(stub shim.ChaincodeStubInterface) error {
+	creatorBytes, err := stub.GetCreator()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get creator")
+	}
+
+	creator := &msp.SerializedIdentity{}
+	err = scc.Protobuf.Unmarshal(creatorBytes, creator)
+	if err != nil {
+		return errors.WithMessage(err, "failed to decode creator")
+	}
+
+	if creator.Mspid != scc.OrgMSPID {
+		return errors.Errorf("creator of org '%s' cannot approve on behalf of org '%s'", creator.Mspid, scc.OrgMSPID)
+	}
+
+	return nil
+}
+
+// ChaincodePrivateLedgerShim reads and writes the private data of a
+// collection through the stub, as the state of the lifecycle
+type ChaincodePrivateLedgerShim struct {
+	Stub       shim.ChaincodeStubInterface
+	Collection string
+}
+
+// GetState returns the value of the key in the collection
+func (cpls *ChaincodePrivateLedgerShim) GetState(key string) ([]byte, error) {
+	return cpls.Stub.GetPrivateData(cpls.Collection, key)
+}
+
+// GetStateHash returns the hash of the value of the key in the collection
+func (cpls *ChaincodePrivateLedgerShim) GetStateHash(key string) ([]byte, error) {
+	return cpls.Stub.GetPrivateDataHash(cpls.Collection, key)
+}
+
+// PutState writes the value of the key in the collection
+func (cpls *ChaincodePrivateLedgerShim) PutState(key string, value []byte) error {
+	return cpls.Stub.PutPrivateData(cpls.Collection, key, value)
+}
+
+// DelState deletes the key from the collection
+func (cpls *ChaincodePrivateLedgerShim) DelState(key string) error {
+	return cpls.Stub.DelPrivateData(cpls.Collection, key)
+}
+
+// ChaincodeOrgStates provides the org-scoped state of each org,
+// which is the implicit collection of the org
+type ChaincodeOrgStates struct {
+	Stub shim.ChaincodeStubInterface
+}
+
+// OrgState returns the org-scoped state of the given org
+func (cos *ChaincodeOrgStates) OrgState(orgMSPID string) OpaqueState {
+	return &ChaincodePrivateLedgerShim{
+		Stub:       cos.Stub,
+		Collection: privdata.ImplicitCollectionNameForOrg(orgMSPID),
+	}
+}
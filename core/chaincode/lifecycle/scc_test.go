@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
 	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -31,6 +32,7 @@ var _ = Describe("SCC", func() {
 		scc = &lifecycle.SCC{
 			Protobuf:  fakeProto,
 			Functions: fakeSCCFuncs,
+			OrgMSPID:  "org1",
 		}
 	})
 
@@ -268,5 +270,239 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("ApproveChaincodeDefinitionForMyOrg", func() {
+			var definition *lb.ChaincodeDefinition
+
+			BeforeEach(func() {
+				definition = &lb.ChaincodeDefinition{Sequence: 1, Version: "version"}
+				marshaledArg, err := proto.Marshal(&lb.ApproveChaincodeDefinitionForMyOrgArgs{
+					Name:       "name",
+					Definition: definition,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionForMyOrg"), marshaledArg})
+				fakeStub.GetCreatorReturns(proto.Marshal(&msp.SerializedIdentity{Mspid: "org1"}))
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("approves the definition on behalf of the org of the peer", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(1))
+				name, cd, publicState, orgState := fakeSCCFuncs.ApproveChaincodeDefinitionForOrgArgsForCall(0)
+				Expect(name).To(Equal("name"))
+				Expect(proto.Equal(cd, definition)).To(BeTrue())
+				Expect(publicState).To(Equal(fakeStub))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{
+					Stub:       fakeStub,
+					Collection: "_implicit_org_org1",
+				}))
+			})
+
+			Context("when the creator belongs to another org", func() {
+				BeforeEach(func() {
+					fakeStub.GetCreatorReturns(proto.Marshal(&msp.SerializedIdentity{Mspid: "org2"}))
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("creator of org 'org2' cannot approve on behalf of org 'org1'"))
+					Expect(fakeSCCFuncs.ApproveChaincodeDefinitionForOrgCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.ApproveChaincodeDefinitionForOrgReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing ApproveChaincodeDefinitionForOrg: underlying-error"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadiness", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.CheckCommitReadinessArgs{
+					Name:       "name",
+					Definition: &lb.ChaincodeDefinition{Sequence: 1},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CheckCommitReadiness"), marshaledArg})
+				fakeStub.GetChannelIDReturns("channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.CheckCommitReadinessReturns(map[string]bool{"org1": true, "org2": false}, nil)
+			})
+
+			It("returns the approvals of the orgs of the channel", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.CheckCommitReadinessResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Approvals).To(Equal(map[string]bool{"org1": true, "org2": false}))
+
+				channelID, name, cd, publicState, orgStates := fakeSCCFuncs.CheckCommitReadinessArgsForCall(0)
+				Expect(channelID).To(Equal("channel"))
+				Expect(name).To(Equal("name"))
+				Expect(cd.Sequence).To(Equal(int64(1)))
+				Expect(publicState).To(Equal(fakeStub))
+				Expect(orgStates).To(Equal(&lifecycle.ChaincodeOrgStates{Stub: fakeStub}))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CheckCommitReadinessReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CheckCommitReadiness: underlying-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinition", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.CommitChaincodeDefinitionArgs{
+					Name:       "name",
+					Definition: &lb.ChaincodeDefinition{Sequence: 1},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinition"), marshaledArg})
+				fakeStub.GetChannelIDReturns("channel")
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+			})
+
+			It("commits the definition to the channel", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeSCCFuncs.CommitChaincodeDefinitionCallCount()).To(Equal(1))
+				channelID, org, name, cd, publicState, orgStates := fakeSCCFuncs.CommitChaincodeDefinitionArgsForCall(0)
+				Expect(channelID).To(Equal("channel"))
+				Expect(org).To(Equal("org1"))
+				Expect(name).To(Equal("name"))
+				Expect(cd.Sequence).To(Equal(int64(1)))
+				Expect(publicState).To(Equal(fakeStub))
+				Expect(orgStates).To(Equal(&lifecycle.ChaincodeOrgStates{Stub: fakeStub}))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing CommitChaincodeDefinition: underlying-error"))
+				})
+			})
+		})
+
+		Describe("QueryChaincodeDefinition", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.QueryChaincodeDefinitionArgs{Name: "name"})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinition"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryChaincodeDefinitionReturns(&lb.ChaincodeDefinition{Sequence: 3, Version: "version"}, nil)
+			})
+
+			It("returns the committed definition", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryChaincodeDefinitionResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.Definition.Sequence).To(Equal(int64(3)))
+				Expect(payload.Definition.Version).To(Equal("version"))
+
+				name, _ := fakeSCCFuncs.QueryChaincodeDefinitionArgsForCall(0)
+				Expect(name).To(Equal("name"))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryChaincodeDefinition: underlying-error"))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("ChaincodeOrgStates", func() {
+	var (
+		fakeStub  *mock.ChaincodeStub
+		orgStates *lifecycle.ChaincodeOrgStates
+	)
+
+	BeforeEach(func() {
+		fakeStub = &mock.ChaincodeStub{}
+		orgStates = &lifecycle.ChaincodeOrgStates{Stub: fakeStub}
+	})
+
+	It("reads the hashes of the implicit collection of the org", func() {
+		fakeStub.GetPrivateDataHashReturns([]byte("hash"), nil)
+
+		hash, err := orgStates.OrgState("org2").GetStateHash("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).To(Equal([]byte("hash")))
+		collection, key := fakeStub.GetPrivateDataHashArgsForCall(0)
+		Expect(collection).To(Equal("_implicit_org_org2"))
+		Expect(key).To(Equal("key"))
+	})
+
+	It("reads and writes the private data of the implicit collection of the org", func() {
+		orgState := orgStates.OrgState("org1").(*lifecycle.ChaincodePrivateLedgerShim)
+
+		fakeStub.GetPrivateDataReturns([]byte("value"), nil)
+		value, err := orgState.GetState("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]byte("value")))
+		collection, key := fakeStub.GetPrivateDataArgsForCall(0)
+		Expect(collection).To(Equal("_implicit_org_org1"))
+		Expect(key).To(Equal("key"))
+
+		err = orgState.PutState("key", []byte("value"))
+		Expect(err).NotTo(HaveOccurred())
+		collection, key, value = fakeStub.PutPrivateDataArgsForCall(0)
+		Expect(collection).To(Equal("_implicit_org_org1"))
+		Expect(key).To(Equal("key"))
+		Expect(value).To(Equal([]byte("value")))
+
+		err = orgState.DelState("key")
+		Expect(err).NotTo(HaveOccurred())
+		collection, key = fakeStub.DelPrivateDataArgsForCall(0)
+		Expect(collection).To(Equal("_implicit_org_org1"))
+		Expect(key).To(Equal("key"))
 	})
 })
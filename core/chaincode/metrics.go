@@ -59,6 +59,20 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+	executeRejections = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "execute_rejections",
+		Help:         "The number of chaincode executions rejected for exceeding the limits of the chaincode.",
+		LabelNames:   []string{"chaincode", "reason"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{reason}",
+	}
+	executionsInFlight = metrics.GaugeOpts{
+		Namespace:    "chaincode",
+		Name:         "executions_in_flight",
+		Help:         "The number of chaincode executions (Init or Invoke) in flight.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
 )
 
 type HandlerMetrics struct {
@@ -66,6 +80,8 @@ type HandlerMetrics struct {
 	ShimRequestsCompleted metrics.Counter
 	ShimRequestDuration   metrics.Histogram
 	ExecuteTimeouts       metrics.Counter
+	ExecuteRejections     metrics.Counter
+	ExecutionsInFlight    metrics.Gauge
 }
 
 func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
@@ -74,6 +90,8 @@ func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
 		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
 		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		ExecuteRejections:     p.NewCounter(executeRejections),
+		ExecutionsInFlight:    p.NewGauge(executionsInFlight),
 	}
 }
 
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm/interpreter"
+	"github.com/pkg/errors"
+)
+
+// The ABI between the peer and WASM chaincode.
+//
+// A chaincode module exports its linear memory as "memory" and the
+// following functions:
+//
+//	alloc(size i32) i32  returns the offset of size bytes of memory the peer
+//	                     may write the results of host functions to
+//	invoke() i32         processes a transaction and returns its status
+//	init() i32           optional, processes the initialization of the
+//	                     chaincode and returns its status
+//
+// The status is a shim response status: 200 for success and 400 or more
+// for errors. The chaincode sets the payload of a successful response, or
+// the message of an error response, with set_response.
+//
+// The chaincode may only import the host functions of the "fabric" module
+// listed in HostFunctions. WASI and other host modules aren't available,
+// so that chaincode has no access to clocks, randomness or the file system.
+// Floating point values and instructions are rejected too, as the bit
+// patterns of the NaNs they produce differ between platforms, so that
+// chaincode executes deterministically. Byte strings are passed to host
+// functions as offset and length pairs. Host functions returning data
+// return an i64 holding the offset of the data in the upper 32 bits and
+// its length in the lower 32 bits, the data being written to memory
+// obtained from alloc; no data is returned as 0. Lists are encoded as the
+// concatenation of their entries, each entry being prefixed by its length
+// as a little endian u32, and key value pairs as a list alternating keys
+// and values. A host function which fails aborts the transaction with its
+// error.
+const (
+	// HostModule is the name of the module of the host functions
+	HostModule = "fabric"
+
+	// MemoryExport is the name of the exported memory of chaincode
+	MemoryExport = "memory"
+	// AllocExport is the name of the exported allocation function
+	AllocExport = "alloc"
+	// InitExport is the name of the optional exported init function
+	InitExport = "init"
+	// InvokeExport is the name of the exported invoke function
+	InvokeExport = "invoke"
+)
+
+var (
+	i32 = interpreter.I32
+	i64 = interpreter.I64
+)
+
+// HostFunctions holds the signatures of the host functions, by name.
+var HostFunctions = map[string]interpreter.FuncType{
+	// get_args() i64 returns the arguments of the transaction as a list
+	"get_args": {Results: []interpreter.ValueType{i64}},
+	// get_tx_id() i64 returns the ID of the transaction
+	"get_tx_id": {Results: []interpreter.ValueType{i64}},
+	// get_channel_id() i64 returns the ID of the channel
+	"get_channel_id": {Results: []interpreter.ValueType{i64}},
+	// get_state(key) i64 returns the value of the key
+	"get_state": {Params: []interpreter.ValueType{i32, i32}, Results: []interpreter.ValueType{i64}},
+	// put_state(key, value) writes the value of the key
+	"put_state": {Params: []interpreter.ValueType{i32, i32, i32, i32}},
+	// del_state(key) deletes the key
+	"del_state": {Params: []interpreter.ValueType{i32, i32}},
+	// get_state_by_range(startKey, endKey) i64 returns the key value pairs
+	// of the range
+	"get_state_by_range": {Params: []interpreter.ValueType{i32, i32, i32, i32}, Results: []interpreter.ValueType{i64}},
+	// get_private_data(collection, key) i64 returns the value of the key
+	"get_private_data": {Params: []interpreter.ValueType{i32, i32, i32, i32}, Results: []interpreter.ValueType{i64}},
+	// put_private_data(collection, key, value) writes the value of the key
+	"put_private_data": {Params: []interpreter.ValueType{i32, i32, i32, i32, i32, i32}},
+	// del_private_data(collection, key) deletes the key
+	"del_private_data": {Params: []interpreter.ValueType{i32, i32, i32, i32}},
+	// get_private_data_by_range(collection, startKey, endKey) i64 returns
+	// the key value pairs of the range
+	"get_private_data_by_range": {Params: []interpreter.ValueType{i32, i32, i32, i32, i32, i32}, Results: []interpreter.ValueType{i64}},
+	// set_event(name, payload) sets an event of the transaction
+	"set_event": {Params: []interpreter.ValueType{i32, i32, i32, i32}},
+	// set_response(data) sets the payload or the message of the response
+	"set_response": {Params: []interpreter.ValueType{i32, i32}},
+	// log(message) logs the message at debug level on the peer
+	"log": {Params: []interpreter.ValueType{i32, i32}},
+}
+
+// ValidateModule checks that the module is a valid WASM binary which
+// complies with the ABI between the peer and chaincode.
+func ValidateModule(module []byte) error {
+	compiled, err := interpreter.Compile(module)
+	if err != nil {
+		return errors.WithMessage(err, "invalid WASM module")
+	}
+
+	for _, imported := range compiled.Imports() {
+		if imported.Kind != interpreter.FunctionKind {
+			return errors.Errorf("chaincode must not import the %s %s", imported.Kind, imported.Name)
+		}
+		if imported.Module != HostModule {
+			return errors.Errorf("function %s imported from module %s, only the %s module can be imported", imported.Name, imported.Module, HostModule)
+		}
+		signature, ok := HostFunctions[imported.Name]
+		if !ok {
+			return errors.Errorf("unknown host function %s", imported.Name)
+		}
+		if !signature.Equal(imported.Type) {
+			return errors.Errorf("host function %s imported with a wrong signature", imported.Name)
+		}
+	}
+
+	if !compiled.ExportsMemory(MemoryExport) {
+		return errors.Errorf("chaincode must export its memory as %s", MemoryExport)
+	}
+	for _, name := range []string{AllocExport, InvokeExport, InitExport} {
+		signature := exportSignatures[name]
+		exported, ok := compiled.ExportedFunction(name)
+		if !ok {
+			if name == InitExport {
+				continue
+			}
+			return errors.Errorf("chaincode must export the %s function", name)
+		}
+		if !signature.Equal(exported) {
+			return errors.Errorf("function %s exported with a wrong signature", name)
+		}
+	}
+
+	return nil
+}
+
+// exportSignatures holds the signatures of the functions exported by
+// chaincode, by name
+var exportSignatures = map[string]interpreter.FuncType{
+	AllocExport:  {Params: []interpreter.ValueType{i32}, Results: []interpreter.ValueType{i32}},
+	InvokeExport: {Results: []interpreter.ValueType{i32}},
+	InitExport:   {Results: []interpreter.ValueType{i32}},
+}
@@ -0,0 +1,682 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// function is a validated function, compiled into a sequence of
+// instructions.
+type function struct {
+	typ       FuncType
+	numLocals int
+	// maxStack is the maximum height of the operand stack
+	maxStack int
+	code     []instruction
+	blocks   []block
+	brTables []uint32
+}
+
+// instruction is an instruction with its immediates decoded:
+//   - the value of constants
+//   - the index of locals, globals, functions and types
+//   - the offset of memory accesses
+//   - the depth of the label of branches
+//   - the index in brTables of the labels of br_table, a, followed by
+//     their number, b, and then the default label
+//   - the index in blocks of the block started by block, loop and if
+//     instructions
+type instruction struct {
+	opcode uint16
+	a      uint64
+	b      uint64
+}
+
+// block is a block, loop or if instruction with the indices of its else
+// and end instructions.
+type block struct {
+	params  int
+	results int
+	elseAt  int
+	endAt   int
+}
+
+// ctrlFrame is a frame of the control stack of the validation algorithm
+type ctrlFrame struct {
+	opcode      byte
+	params      []ValueType
+	results     []ValueType
+	height      int
+	unreachable bool
+	block       int
+}
+
+func (f *ctrlFrame) labelTypes() []ValueType {
+	if f.opcode == opLoop {
+		return f.params
+	}
+	return f.results
+}
+
+// compiler validates a function and compiles its code
+type compiler struct {
+	module *Module
+	fn     *function
+	locals []ValueType
+	vals   []ValueType
+	ctrls  []ctrlFrame
+}
+
+// compileFunction validates the body of a function and compiles its code.
+func compileFunction(m *Module, typ FuncType, body []byte) (*function, error) {
+	c := &compiler{
+		module: m,
+		fn:     &function{typ: typ},
+		locals: append([]ValueType(nil), typ.Params...),
+	}
+	r := &reader{data: body}
+
+	groups, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < groups; i++ {
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		t, err := r.valueType()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(c.fn.numLocals)+uint64(n) > maxLocals {
+			return nil, errors.New("too many locals")
+		}
+		c.fn.numLocals += int(n)
+		for j := uint32(0); j < n; j++ {
+			c.locals = append(c.locals, t)
+		}
+	}
+
+	c.pushCtrl(opBlock, nil, typ.Results, -1)
+	for len(c.ctrls) > 0 {
+		if r.done() {
+			return nil, errors.New("unexpected end of function body")
+		}
+		if err := c.instruction(r); err != nil {
+			return nil, errors.WithMessage(err, "offset "+strconv.Itoa(r.pos))
+		}
+	}
+	if !r.done() {
+		return nil, errors.New("operators after the end of the function body")
+	}
+	return c.fn, nil
+}
+
+func (c *compiler) emit(opcode uint16, a, b uint64) {
+	c.fn.code = append(c.fn.code, instruction{opcode: opcode, a: a, b: b})
+}
+
+func (c *compiler) push(t ValueType) {
+	c.vals = append(c.vals, t)
+	if len(c.vals) > c.fn.maxStack {
+		c.fn.maxStack = len(c.vals)
+	}
+}
+
+func (c *compiler) pushAll(types []ValueType) {
+	for _, t := range types {
+		c.push(t)
+	}
+}
+
+func (c *compiler) pop(expected ValueType) (ValueType, error) {
+	frame := &c.ctrls[len(c.ctrls)-1]
+	if len(c.vals) == frame.height {
+		if frame.unreachable {
+			return expected, nil
+		}
+		return 0, errors.New("type mismatch: stack underflow")
+	}
+	actual := c.vals[len(c.vals)-1]
+	c.vals = c.vals[:len(c.vals)-1]
+	if actual != expected && actual != unknown && expected != unknown {
+		return 0, errors.Errorf("type mismatch: expected %s, got %s", expected, actual)
+	}
+	if actual == unknown {
+		return expected, nil
+	}
+	return actual, nil
+}
+
+func (c *compiler) popAll(types []ValueType) error {
+	for i := len(types) - 1; i >= 0; i-- {
+		if _, err := c.pop(types[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiler) pushCtrl(opcode byte, params, results []ValueType, block int) {
+	c.ctrls = append(c.ctrls, ctrlFrame{
+		opcode:  opcode,
+		params:  params,
+		results: results,
+		height:  len(c.vals),
+		block:   block,
+	})
+	c.pushAll(params)
+}
+
+func (c *compiler) popCtrl() (ctrlFrame, error) {
+	frame := c.ctrls[len(c.ctrls)-1]
+	if err := c.popAll(frame.results); err != nil {
+		return frame, err
+	}
+	if len(c.vals) != frame.height {
+		return frame, errors.New("type mismatch: values remaining on the stack at the end of the block")
+	}
+	c.ctrls = c.ctrls[:len(c.ctrls)-1]
+	return frame, nil
+}
+
+func (c *compiler) setUnreachable() {
+	frame := &c.ctrls[len(c.ctrls)-1]
+	c.vals = c.vals[:frame.height]
+	frame.unreachable = true
+}
+
+func (c *compiler) label(depth uint32) (*ctrlFrame, error) {
+	if int(depth) >= len(c.ctrls) {
+		return nil, errors.Errorf("unknown label %d", depth)
+	}
+	return &c.ctrls[len(c.ctrls)-1-int(depth)], nil
+}
+
+func (c *compiler) blockType(r *reader) (FuncType, error) {
+	if r.done() {
+		return FuncType{}, errUnexpectedEnd
+	}
+	b := r.data[r.pos]
+	if b == 0x40 {
+		r.pos++
+		return FuncType{}, nil
+	}
+	if b&0xc0 == 0x40 {
+		// a single value type
+		r.pos++
+		t, err := toValueType(b)
+		if err != nil {
+			return FuncType{}, err
+		}
+		return FuncType{Results: []ValueType{t}}, nil
+	}
+	index, err := r.s33()
+	if err != nil {
+		return FuncType{}, err
+	}
+	if index < 0 || index >= int64(len(c.module.types)) {
+		return FuncType{}, errors.Errorf("unknown type %d", index)
+	}
+	return c.module.types[index], nil
+}
+
+func (c *compiler) checkMemory() error {
+	if c.module.memory == nil {
+		return errors.New("unknown memory 0")
+	}
+	return nil
+}
+
+// memarg decodes the immediates of a memory access of size bytes.
+func (c *compiler) memarg(r *reader, size uint32) (uint64, error) {
+	if err := c.checkMemory(); err != nil {
+		return 0, err
+	}
+	align, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	if align >= 32 || 1<<align > size {
+		return 0, errors.New("alignment must not be larger than natural")
+	}
+	offset, err := r.u32()
+	return uint64(offset), err
+}
+
+// loads and stores map the memory instructions to the type of their value
+// and the size of their access
+var (
+	loads = map[byte]struct {
+		t    ValueType
+		size uint32
+	}{
+		opI32Load: {I32, 4}, opI64Load: {I64, 8},
+		opI32Load8S: {I32, 1}, opI32Load8U: {I32, 1}, opI32Load16S: {I32, 2}, opI32Load16U: {I32, 2},
+		opI64Load8S: {I64, 1}, opI64Load8U: {I64, 1}, opI64Load16S: {I64, 2}, opI64Load16U: {I64, 2},
+		opI64Load32S: {I64, 4}, opI64Load32U: {I64, 4},
+	}
+	stores = map[byte]struct {
+		t    ValueType
+		size uint32
+	}{
+		opI32Store: {I32, 4}, opI64Store: {I64, 8},
+		opI32Store8: {I32, 1}, opI32Store16: {I32, 2},
+		opI64Store8: {I64, 1}, opI64Store16: {I64, 2}, opI64Store32: {I64, 4},
+	}
+)
+
+// numeric maps the numeric instructions to their type
+var numeric = map[byte]FuncType{}
+
+func init() {
+	i32, i64 := []ValueType{I32}, []ValueType{I64}
+	i32i32, i64i64 := []ValueType{I32, I32}, []ValueType{I64, I64}
+	for op := byte(opI32Eq); op <= opI32GeU; op++ {
+		numeric[op] = FuncType{i32i32, i32}
+	}
+	for op := byte(opI64Eq); op <= opI64GeU; op++ {
+		numeric[op] = FuncType{i64i64, i32}
+	}
+	for op := byte(opI32Clz); op <= opI32Popcnt; op++ {
+		numeric[op] = FuncType{i32, i32}
+	}
+	for op := byte(opI32Add); op <= opI32Rotr; op++ {
+		numeric[op] = FuncType{i32i32, i32}
+	}
+	for op := byte(opI64Clz); op <= opI64Popcnt; op++ {
+		numeric[op] = FuncType{i64, i64}
+	}
+	for op := byte(opI64Add); op <= opI64Rotr; op++ {
+		numeric[op] = FuncType{i64i64, i64}
+	}
+	numeric[opI32Eqz] = FuncType{i32, i32}
+	numeric[opI64Eqz] = FuncType{i64, i32}
+	numeric[opI32WrapI64] = FuncType{i64, i32}
+	numeric[opI64ExtendI32S] = FuncType{i32, i64}
+	numeric[opI64ExtendI32U] = FuncType{i32, i64}
+	numeric[opI32Extend8S] = FuncType{i32, i32}
+	numeric[opI32Extend16S] = FuncType{i32, i32}
+	numeric[opI64Extend8S] = FuncType{i64, i64}
+	numeric[opI64Extend16S] = FuncType{i64, i64}
+	numeric[opI64Extend32S] = FuncType{i64, i64}
+}
+
+// instruction validates and compiles the next instruction.
+func (c *compiler) instruction(r *reader) error {
+	op, err := r.byte()
+	if err != nil {
+		return err
+	}
+
+	if t, ok := numeric[op]; ok {
+		if err := c.popAll(t.Params); err != nil {
+			return err
+		}
+		c.pushAll(t.Results)
+		c.emit(uint16(op), 0, 0)
+		return nil
+	}
+	if l, ok := loads[op]; ok {
+		offset, err := c.memarg(r, l.size)
+		if err != nil {
+			return err
+		}
+		if _, err := c.pop(I32); err != nil {
+			return err
+		}
+		c.push(l.t)
+		c.emit(uint16(op), offset, 0)
+		return nil
+	}
+	if s, ok := stores[op]; ok {
+		offset, err := c.memarg(r, s.size)
+		if err != nil {
+			return err
+		}
+		if err := c.popAll([]ValueType{I32, s.t}); err != nil {
+			return err
+		}
+		c.emit(uint16(op), offset, 0)
+		return nil
+	}
+	if isFloatOpcode(op) {
+		return errors.Errorf("floating point instruction 0x%x is not supported", op)
+	}
+
+	switch op {
+	case opUnreachable:
+		c.emit(opUnreachable, 0, 0)
+		c.setUnreachable()
+
+	case opNop:
+
+	case opBlock, opLoop, opIf:
+		t, err := c.blockType(r)
+		if err != nil {
+			return err
+		}
+		if op == opIf {
+			if _, err := c.pop(I32); err != nil {
+				return err
+			}
+		}
+		if err := c.popAll(t.Params); err != nil {
+			return err
+		}
+		index := len(c.fn.blocks)
+		c.fn.blocks = append(c.fn.blocks, block{params: len(t.Params), results: len(t.Results), elseAt: -1})
+		c.emit(uint16(op), uint64(index), 0)
+		c.pushCtrl(op, t.Params, t.Results, index)
+
+	case opElse:
+		frame := c.ctrls[len(c.ctrls)-1]
+		if frame.opcode != opIf {
+			return errors.New("else without if")
+		}
+		if _, err := c.popCtrl(); err != nil {
+			return err
+		}
+		c.fn.blocks[frame.block].elseAt = len(c.fn.code)
+		c.emit(opElse, uint64(frame.block), 0)
+		c.pushCtrl(opElse, frame.params, frame.results, frame.block)
+
+	case opEnd:
+		frame, err := c.popCtrl()
+		if err != nil {
+			return err
+		}
+		if frame.opcode == opIf && !equalTypes(frame.params, frame.results) {
+			return errors.New("type mismatch: if without else must not change the stack")
+		}
+		if frame.block >= 0 {
+			c.fn.blocks[frame.block].endAt = len(c.fn.code)
+		}
+		c.emit(opEnd, 0, 0)
+		c.pushAll(frame.results)
+
+	case opBr:
+		depth, err := r.u32()
+		if err != nil {
+			return err
+		}
+		label, err := c.label(depth)
+		if err != nil {
+			return err
+		}
+		if err := c.popAll(label.labelTypes()); err != nil {
+			return err
+		}
+		c.emit(opBr, uint64(depth), 0)
+		c.setUnreachable()
+
+	case opBrIf:
+		depth, err := r.u32()
+		if err != nil {
+			return err
+		}
+		label, err := c.label(depth)
+		if err != nil {
+			return err
+		}
+		if _, err := c.pop(I32); err != nil {
+			return err
+		}
+		types := label.labelTypes()
+		if err := c.popAll(types); err != nil {
+			return err
+		}
+		c.pushAll(types)
+		c.emit(opBrIf, uint64(depth), 0)
+
+	case opBrTable:
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		start := len(c.fn.brTables)
+		for i := uint32(0); i <= n; i++ {
+			depth, err := r.u32()
+			if err != nil {
+				return err
+			}
+			c.fn.brTables = append(c.fn.brTables, depth)
+		}
+		if _, err := c.pop(I32); err != nil {
+			return err
+		}
+		defaultLabel, err := c.label(c.fn.brTables[len(c.fn.brTables)-1])
+		if err != nil {
+			return err
+		}
+		arity := len(defaultLabel.labelTypes())
+		for _, depth := range c.fn.brTables[start:] {
+			label, err := c.label(depth)
+			if err != nil {
+				return err
+			}
+			types := label.labelTypes()
+			if len(types) != arity {
+				return errors.New("type mismatch: br_table labels of different arities")
+			}
+			if err := c.popAll(types); err != nil {
+				return err
+			}
+			c.pushAll(types)
+		}
+		if err := c.popAll(defaultLabel.labelTypes()); err != nil {
+			return err
+		}
+		c.emit(opBrTable, uint64(start), uint64(n))
+		c.setUnreachable()
+
+	case opReturn:
+		if err := c.popAll(c.ctrls[0].results); err != nil {
+			return err
+		}
+		c.emit(opReturn, 0, 0)
+		c.setUnreachable()
+
+	case opCall:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(index) >= len(c.module.funcs) {
+			return errors.Errorf("unknown function %d", index)
+		}
+		t := c.module.types[c.module.funcs[index]]
+		if err := c.popAll(t.Params); err != nil {
+			return err
+		}
+		c.pushAll(t.Results)
+		c.emit(opCall, uint64(index), 0)
+
+	case opCallIndirect:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		table, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if table != 0 || c.module.table == nil {
+			return errors.Errorf("unknown table %d", table)
+		}
+		if int(index) >= len(c.module.types) {
+			return errors.Errorf("unknown type %d", index)
+		}
+		if _, err := c.pop(I32); err != nil {
+			return err
+		}
+		t := c.module.types[index]
+		if err := c.popAll(t.Params); err != nil {
+			return err
+		}
+		c.pushAll(t.Results)
+		c.emit(opCallIndirect, uint64(index), 0)
+
+	case opDrop:
+		if _, err := c.pop(unknown); err != nil {
+			return err
+		}
+		c.emit(opDrop, 0, 0)
+
+	case opSelect, opSelectTyped:
+		var expected ValueType
+		if op == opSelectTyped {
+			types, err := r.valueTypes()
+			if err != nil {
+				return err
+			}
+			if len(types) != 1 {
+				return errors.New("invalid result arity of select")
+			}
+			expected = types[0]
+		}
+		if _, err := c.pop(I32); err != nil {
+			return err
+		}
+		t1, err := c.pop(expected)
+		if err != nil {
+			return err
+		}
+		t2, err := c.pop(expected)
+		if err != nil {
+			return err
+		}
+		if t1 != t2 && t1 != unknown && t2 != unknown {
+			return errors.New("type mismatch: select operands of different types")
+		}
+		if t1 == unknown {
+			t1 = t2
+		}
+		c.push(t1)
+		c.emit(opSelect, 0, 0)
+
+	case opLocalGet, opLocalSet, opLocalTee:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(index) >= len(c.locals) {
+			return errors.Errorf("unknown local %d", index)
+		}
+		t := c.locals[index]
+		if op != opLocalGet {
+			if _, err := c.pop(t); err != nil {
+				return err
+			}
+		}
+		if op != opLocalSet {
+			c.push(t)
+		}
+		c.emit(uint16(op), uint64(index), 0)
+
+	case opGlobalGet, opGlobalSet:
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if int(index) >= len(c.module.globals) {
+			return errors.Errorf("unknown global %d", index)
+		}
+		g := c.module.globals[index]
+		if op == opGlobalGet {
+			c.push(g.valueType)
+		} else {
+			if !g.mutable {
+				return errors.Errorf("global %d is immutable", index)
+			}
+			if _, err := c.pop(g.valueType); err != nil {
+				return err
+			}
+		}
+		c.emit(uint16(op), uint64(index), 0)
+
+	case opMemorySize, opMemoryGrow:
+		if err := c.checkMemory(); err != nil {
+			return err
+		}
+		if b, err := r.byte(); err != nil || b != 0 {
+			return errors.New("invalid memory index")
+		}
+		if op == opMemoryGrow {
+			if _, err := c.pop(I32); err != nil {
+				return err
+			}
+		}
+		c.push(I32)
+		c.emit(uint16(op), 0, 0)
+
+	case opI32Const:
+		v, err := r.s32()
+		if err != nil {
+			return err
+		}
+		c.push(I32)
+		c.emit(opI32Const, uint64(uint32(v)), 0)
+
+	case opI64Const:
+		v, err := r.s64()
+		if err != nil {
+			return err
+		}
+		c.push(I64)
+		c.emit(opI64Const, uint64(v), 0)
+
+	case opPrefix:
+		return c.prefixedInstruction(r)
+
+	default:
+		return errors.Errorf("unsupported instruction 0x%x", op)
+	}
+	return nil
+}
+
+// prefixedInstruction validates and compiles an instruction prefixed with
+// 0xfc.
+func (c *compiler) prefixedInstruction(r *reader) error {
+	op, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if op <= maxTruncSat {
+		return errors.Errorf("floating point instruction 0xfc 0x%x is not supported", op)
+	}
+
+	switch prefixedOpcodes | op {
+	case opMemoryCopy:
+		if err := c.checkMemory(); err != nil {
+			return err
+		}
+		for i := 0; i < 2; i++ {
+			if b, err := r.byte(); err != nil || b != 0 {
+				return errors.New("invalid memory index")
+			}
+		}
+	case opMemoryFill:
+		if err := c.checkMemory(); err != nil {
+			return err
+		}
+		if b, err := r.byte(); err != nil || b != 0 {
+			return errors.New("invalid memory index")
+		}
+	default:
+		return errors.Errorf("unsupported instruction 0xfc 0x%x", op)
+	}
+	if err := c.popAll([]ValueType{I32, I32, I32}); err != nil {
+		return err
+	}
+	c.emit(uint16(prefixedOpcodes|op), 0, 0)
+	return nil
+}
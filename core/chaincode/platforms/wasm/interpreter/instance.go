@@ -0,0 +1,731 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxCallDepth is the maximum depth of nested calls
+	maxCallDepth = 10000
+	// maxStackSize is the maximum size in bytes of the locals and operand
+	// stacks of the frames of nested calls
+	maxStackSize = 64 << 20
+	// checkInterval is the number of branches and calls between checks of
+	// the cancellation of the execution
+	checkInterval = 1024
+)
+
+var (
+	errUnreachable       = errors.New("unreachable executed")
+	errOutOfBounds       = errors.New("out of bounds memory access")
+	errDivideByZero      = errors.New("integer divide by zero")
+	errIntegerOverflow   = errors.New("integer overflow")
+	errCallStackExceeded = errors.New("call stack exhausted")
+	errUndefinedElement  = errors.New("undefined element")
+	errNullElement       = errors.New("uninitialized element")
+	errIndirectCallType  = errors.New("indirect call type mismatch")
+)
+
+// HostFunction is a function implemented by the host which modules may
+// import.
+type HostFunction struct {
+	Type FuncType
+	// Func is called with the instance calling the function, and the
+	// context of the call. An error aborts the execution.
+	Func func(ctx context.Context, inst *Instance, params []uint64) ([]uint64, error)
+}
+
+// Imports holds the host functions modules may import, by module and name.
+type Imports map[string]map[string]*HostFunction
+
+// Instance is an instance of a module. An instance must not be used
+// concurrently.
+type Instance struct {
+	module    *Module
+	imports   []*HostFunction
+	memory    []byte
+	maxPages  uint32
+	globals   []uint64
+	table     []int64
+	callDepth int
+	// stackSize is the size in bytes of the frames of the calls in
+	// progress, which is limited to maxStackSize
+	stackSize    uint64
+	maxStackSize uint64
+}
+
+// trap aborts the execution of an instance
+type trap struct {
+	err error
+}
+
+// Instantiate instantiates the module, resolving its imports. The memory of
+// the instance is limited to memoryLimit pages, unless zero. The start
+// function of the module, if any, is executed with the context. The frames
+// of nested calls are limited to the same number of bytes as the memory,
+// up to maxStackSize.
+func Instantiate(ctx context.Context, m *Module, imports Imports, memoryLimit uint32) (*Instance, error) {
+	inst := &Instance{module: m, maxStackSize: maxStackSize}
+	if memoryLimit > 0 && uint64(memoryLimit)*pageSize < inst.maxStackSize {
+		inst.maxStackSize = uint64(memoryLimit) * pageSize
+	}
+	for _, imp := range m.imports {
+		if imp.Kind != FunctionKind {
+			return nil, errors.Errorf("%s %s imported from module %s is not supported", imp.Kind, imp.Name, imp.Module)
+		}
+		fn, ok := imports[imp.Module][imp.Name]
+		if !ok {
+			return nil, errors.Errorf("function %s imported from module %s is not defined", imp.Name, imp.Module)
+		}
+		if !fn.Type.Equal(imp.Type) {
+			return nil, errors.Errorf("function %s imported from module %s has a different type", imp.Name, imp.Module)
+		}
+		inst.imports = append(inst.imports, fn)
+	}
+
+	if m.memory != nil {
+		inst.maxPages = maxPages
+		if m.memory.hasMax {
+			inst.maxPages = m.memory.max
+		}
+		if memoryLimit > 0 && memoryLimit < inst.maxPages {
+			inst.maxPages = memoryLimit
+		}
+		if m.memory.min > inst.maxPages {
+			return nil, errors.Errorf("memory of %d pages exceeds the limit of %d pages", m.memory.min, inst.maxPages)
+		}
+		inst.memory = make([]byte, int(m.memory.min)*pageSize)
+	}
+
+	for _, g := range m.globals {
+		inst.globals = append(inst.globals, g.init)
+	}
+
+	if m.table != nil {
+		inst.table = make([]int64, m.table.min)
+		for i := range inst.table {
+			inst.table[i] = -1
+		}
+	}
+	for _, e := range m.elements {
+		if uint64(e.offset)+uint64(len(e.funcs)) > uint64(len(inst.table)) {
+			return nil, errors.New("element segment does not fit in the table")
+		}
+		for i, f := range e.funcs {
+			inst.table[int(e.offset)+i] = int64(f)
+		}
+	}
+	for _, d := range m.data {
+		if uint64(d.offset)+uint64(len(d.init)) > uint64(len(inst.memory)) {
+			return nil, errors.New("data segment does not fit in the memory")
+		}
+		copy(inst.memory[d.offset:], d.init)
+	}
+
+	if m.start >= 0 {
+		if _, err := inst.call(ctx, uint32(m.start), nil); err != nil {
+			return nil, errors.WithMessage(err, "error executing the start function")
+		}
+	}
+	return inst, nil
+}
+
+// Call calls the function exported under the name with the parameters, and
+// returns its results. The execution is aborted when the context is done.
+func (inst *Instance) Call(ctx context.Context, name string, params ...uint64) ([]uint64, error) {
+	e, ok := inst.module.exports[name]
+	if !ok || e.kind != FunctionKind {
+		return nil, errors.Errorf("function %s is not exported", name)
+	}
+	if t := inst.module.types[inst.module.funcs[e.index]]; len(params) != len(t.Params) {
+		return nil, errors.Errorf("function %s expects %d parameters, got %d", name, len(t.Params), len(params))
+	}
+	return inst.call(ctx, e.index, params)
+}
+
+func (inst *Instance) call(ctx context.Context, index uint32, params []uint64) (results []uint64, err error) {
+	m := &machine{ctx: ctx, inst: inst, stack: append(make([]uint64, 0, 1024), params...)}
+	defer func() {
+		if r := recover(); r != nil {
+			if t, ok := r.(trap); ok {
+				err = t.err
+			} else {
+				err = errors.Errorf("%v", r)
+			}
+		}
+	}()
+	m.call(index)
+	return m.stack, nil
+}
+
+// MemorySize returns the size in bytes of the memory of the instance.
+func (inst *Instance) MemorySize() uint32 {
+	return uint32(len(inst.memory))
+}
+
+// Read returns a copy of length bytes of memory at offset, or false if
+// they're out of bounds.
+func (inst *Instance) Read(offset, length uint32) ([]byte, bool) {
+	if uint64(offset)+uint64(length) > uint64(len(inst.memory)) {
+		return nil, false
+	}
+	return append([]byte(nil), inst.memory[offset:offset+length]...), true
+}
+
+// Write writes data to memory at offset, or returns false if it's out of
+// bounds.
+func (inst *Instance) Write(offset uint32, data []byte) bool {
+	if uint64(offset)+uint64(len(data)) > uint64(len(inst.memory)) {
+		return false
+	}
+	copy(inst.memory[offset:], data)
+	return true
+}
+
+// machine executes calls of an instance
+type machine struct {
+	ctx   context.Context
+	inst  *Instance
+	stack []uint64
+	steps uint32
+}
+
+// label is the target of branches
+type label struct {
+	cont   int
+	height int
+	arity  int
+	loop   bool
+}
+
+func (m *machine) push(v uint64) {
+	m.stack = append(m.stack, v)
+}
+
+func (m *machine) pop() uint64 {
+	v := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	return v
+}
+
+// step counts a branch or a call, and aborts the execution if the context
+// is done.
+func (m *machine) step() {
+	m.steps++
+	if m.steps%checkInterval == 0 {
+		if err := m.ctx.Err(); err != nil {
+			panic(trap{err})
+		}
+	}
+}
+
+// call calls the function, whose parameters are on the stack.
+func (m *machine) call(index uint32) {
+	m.step()
+	inst := m.inst
+	inst.callDepth++
+	defer func() { inst.callDepth-- }()
+	if inst.callDepth > maxCallDepth {
+		panic(trap{errCallStackExceeded})
+	}
+
+	if int(index) < len(inst.imports) {
+		fn := inst.imports[index]
+		n := len(fn.Type.Params)
+		params := append([]uint64(nil), m.stack[len(m.stack)-n:]...)
+		m.stack = m.stack[:len(m.stack)-n]
+		results, err := fn.Func(m.ctx, inst, params)
+		if err != nil {
+			panic(trap{err})
+		}
+		if len(results) != len(fn.Type.Results) {
+			panic(trap{errors.Errorf("host function returned %d results instead of %d", len(results), len(fn.Type.Results))})
+		}
+		m.stack = append(m.stack, results...)
+		return
+	}
+
+	m.execute(inst.module.code[int(index)-len(inst.imports)])
+}
+
+// address returns the effective address of a memory access of size bytes.
+func (m *machine) address(offset uint64, size uint64) uint64 {
+	ea := uint64(uint32(m.pop())) + offset
+	if ea+size > uint64(len(m.inst.memory)) {
+		panic(trap{errOutOfBounds})
+	}
+	return ea
+}
+
+// execute executes the code of a function, whose parameters are on the
+// stack.
+func (m *machine) execute(f *function) {
+	numParams := len(f.typ.Params)
+	// the locals and the operand stack of the frame are charged up front,
+	// before anything is allocated
+	inst := m.inst
+	frameSize := uint64(numParams+f.numLocals+f.maxStack) * 8
+	if inst.stackSize+frameSize > inst.maxStackSize {
+		panic(trap{errCallStackExceeded})
+	}
+	inst.stackSize += frameSize
+	defer func() { inst.stackSize -= frameSize }()
+
+	locals := make([]uint64, numParams+f.numLocals)
+	copy(locals, m.stack[len(m.stack)-numParams:])
+	m.stack = m.stack[:len(m.stack)-numParams]
+	base := len(m.stack)
+
+	labels := make([]label, 1, 16)
+	labels[0] = label{cont: len(f.code), height: base, arity: len(f.typ.Results)}
+
+	code := f.code
+	pc := 0
+	for pc < len(code) {
+		in := &code[pc]
+		pc++
+
+		switch in.opcode {
+		case opUnreachable:
+			panic(trap{errUnreachable})
+
+		case opBlock, opLoop:
+			b := &f.blocks[in.a]
+			l := label{height: len(m.stack) - b.params}
+			if in.opcode == opLoop {
+				l.cont, l.arity, l.loop = pc, b.params, true
+			} else {
+				l.cont, l.arity = b.endAt+1, b.results
+			}
+			labels = append(labels, l)
+
+		case opIf:
+			b := &f.blocks[in.a]
+			cond := uint32(m.pop())
+			labels = append(labels, label{cont: b.endAt + 1, height: len(m.stack) - b.params, arity: b.results})
+			if cond == 0 {
+				if b.elseAt >= 0 {
+					pc = b.elseAt + 1
+				} else {
+					pc = b.endAt + 1
+					labels = labels[:len(labels)-1]
+				}
+			}
+
+		case opElse:
+			// end of the then branch
+			pc = f.blocks[in.a].endAt + 1
+			labels = labels[:len(labels)-1]
+
+		case opEnd:
+			labels = labels[:len(labels)-1]
+
+		case opBr:
+			m.step()
+			pc = m.branch(&labels, int(in.a))
+
+		case opBrIf:
+			if uint32(m.pop()) != 0 {
+				m.step()
+				pc = m.branch(&labels, int(in.a))
+			}
+
+		case opBrTable:
+			m.step()
+			i := uint64(uint32(m.pop()))
+			if i > in.b {
+				i = in.b
+			}
+			pc = m.branch(&labels, int(f.brTables[in.a+i]))
+
+		case opReturn:
+			pc = m.branch(&labels, len(labels)-1)
+
+		case opCall:
+			m.call(uint32(in.a))
+
+		case opCallIndirect:
+			i := uint64(uint32(m.pop()))
+			if i >= uint64(len(m.inst.table)) {
+				panic(trap{errUndefinedElement})
+			}
+			index := m.inst.table[i]
+			if index < 0 {
+				panic(trap{errNullElement})
+			}
+			if !m.inst.module.types[m.inst.module.funcs[index]].Equal(m.inst.module.types[in.a]) {
+				panic(trap{errIndirectCallType})
+			}
+			m.call(uint32(index))
+
+		case opDrop:
+			m.pop()
+
+		case opSelect:
+			cond := uint32(m.pop())
+			v2 := m.pop()
+			v1 := m.pop()
+			if cond != 0 {
+				m.push(v1)
+			} else {
+				m.push(v2)
+			}
+
+		case opLocalGet:
+			m.push(locals[in.a])
+		case opLocalSet:
+			locals[in.a] = m.pop()
+		case opLocalTee:
+			locals[in.a] = m.stack[len(m.stack)-1]
+		case opGlobalGet:
+			m.push(m.inst.globals[in.a])
+		case opGlobalSet:
+			m.inst.globals[in.a] = m.pop()
+
+		case opI32Load:
+			ea := m.address(in.a, 4)
+			m.push(uint64(binary.LittleEndian.Uint32(m.inst.memory[ea:])))
+		case opI64Load:
+			ea := m.address(in.a, 8)
+			m.push(binary.LittleEndian.Uint64(m.inst.memory[ea:]))
+		case opI32Load8S:
+			ea := m.address(in.a, 1)
+			m.push(uint64(uint32(int32(int8(m.inst.memory[ea])))))
+		case opI32Load8U:
+			ea := m.address(in.a, 1)
+			m.push(uint64(m.inst.memory[ea]))
+		case opI32Load16S:
+			ea := m.address(in.a, 2)
+			m.push(uint64(uint32(int32(int16(binary.LittleEndian.Uint16(m.inst.memory[ea:]))))))
+		case opI32Load16U:
+			ea := m.address(in.a, 2)
+			m.push(uint64(binary.LittleEndian.Uint16(m.inst.memory[ea:])))
+		case opI64Load8S:
+			ea := m.address(in.a, 1)
+			m.push(uint64(int64(int8(m.inst.memory[ea]))))
+		case opI64Load8U:
+			ea := m.address(in.a, 1)
+			m.push(uint64(m.inst.memory[ea]))
+		case opI64Load16S:
+			ea := m.address(in.a, 2)
+			m.push(uint64(int64(int16(binary.LittleEndian.Uint16(m.inst.memory[ea:])))))
+		case opI64Load16U:
+			ea := m.address(in.a, 2)
+			m.push(uint64(binary.LittleEndian.Uint16(m.inst.memory[ea:])))
+		case opI64Load32S:
+			ea := m.address(in.a, 4)
+			m.push(uint64(int64(int32(binary.LittleEndian.Uint32(m.inst.memory[ea:])))))
+		case opI64Load32U:
+			ea := m.address(in.a, 4)
+			m.push(uint64(binary.LittleEndian.Uint32(m.inst.memory[ea:])))
+
+		case opI32Store, opI64Store32:
+			v := m.pop()
+			ea := m.address(in.a, 4)
+			binary.LittleEndian.PutUint32(m.inst.memory[ea:], uint32(v))
+		case opI64Store:
+			v := m.pop()
+			ea := m.address(in.a, 8)
+			binary.LittleEndian.PutUint64(m.inst.memory[ea:], v)
+		case opI32Store8, opI64Store8:
+			v := m.pop()
+			ea := m.address(in.a, 1)
+			m.inst.memory[ea] = byte(v)
+		case opI32Store16, opI64Store16:
+			v := m.pop()
+			ea := m.address(in.a, 2)
+			binary.LittleEndian.PutUint16(m.inst.memory[ea:], uint16(v))
+
+		case opMemorySize:
+			m.push(uint64(len(m.inst.memory) / pageSize))
+		case opMemoryGrow:
+			m.push(uint64(m.inst.grow(uint32(m.pop()))))
+
+		case opMemoryCopy:
+			n := uint64(uint32(m.pop()))
+			src := uint64(uint32(m.pop()))
+			dst := uint64(uint32(m.pop()))
+			size := uint64(len(m.inst.memory))
+			if src+n > size || dst+n > size {
+				panic(trap{errOutOfBounds})
+			}
+			copy(m.inst.memory[dst:dst+n], m.inst.memory[src:src+n])
+		case opMemoryFill:
+			n := uint64(uint32(m.pop()))
+			v := byte(m.pop())
+			dst := uint64(uint32(m.pop()))
+			if dst+n > uint64(len(m.inst.memory)) {
+				panic(trap{errOutOfBounds})
+			}
+			for i := dst; i < dst+n; i++ {
+				m.inst.memory[i] = v
+			}
+
+		case opI32Const, opI64Const:
+			m.push(in.a)
+
+		case opI32Eqz:
+			m.push(boolToI32(uint32(m.pop()) == 0))
+		case opI64Eqz:
+			m.push(boolToI32(m.pop() == 0))
+
+		case opI32Eq, opI32Ne, opI32LtS, opI32LtU, opI32GtS, opI32GtU, opI32LeS, opI32LeU, opI32GeS, opI32GeU:
+			b := uint32(m.pop())
+			a := uint32(m.pop())
+			m.push(boolToI32(i32Compare(in.opcode, a, b)))
+		case opI64Eq, opI64Ne, opI64LtS, opI64LtU, opI64GtS, opI64GtU, opI64LeS, opI64LeU, opI64GeS, opI64GeU:
+			b := m.pop()
+			a := m.pop()
+			m.push(boolToI32(i64Compare(in.opcode, a, b)))
+
+		case opI32Clz:
+			m.push(uint64(bits.LeadingZeros32(uint32(m.pop()))))
+		case opI32Ctz:
+			m.push(uint64(bits.TrailingZeros32(uint32(m.pop()))))
+		case opI32Popcnt:
+			m.push(uint64(bits.OnesCount32(uint32(m.pop()))))
+		case opI64Clz:
+			m.push(uint64(bits.LeadingZeros64(m.pop())))
+		case opI64Ctz:
+			m.push(uint64(bits.TrailingZeros64(m.pop())))
+		case opI64Popcnt:
+			m.push(uint64(bits.OnesCount64(m.pop())))
+
+		case opI32Add, opI32Sub, opI32Mul, opI32DivS, opI32DivU, opI32RemS, opI32RemU,
+			opI32And, opI32Or, opI32Xor, opI32Shl, opI32ShrS, opI32ShrU, opI32Rotl, opI32Rotr:
+			b := uint32(m.pop())
+			a := uint32(m.pop())
+			m.push(uint64(i32Binary(in.opcode, a, b)))
+		case opI64Add, opI64Sub, opI64Mul, opI64DivS, opI64DivU, opI64RemS, opI64RemU,
+			opI64And, opI64Or, opI64Xor, opI64Shl, opI64ShrS, opI64ShrU, opI64Rotl, opI64Rotr:
+			b := m.pop()
+			a := m.pop()
+			m.push(i64Binary(in.opcode, a, b))
+
+		case opI32WrapI64:
+			m.push(uint64(uint32(m.pop())))
+		case opI64ExtendI32S:
+			m.push(uint64(int64(int32(m.pop()))))
+		case opI64ExtendI32U:
+			m.push(uint64(uint32(m.pop())))
+		case opI32Extend8S:
+			m.push(uint64(uint32(int32(int8(m.pop())))))
+		case opI32Extend16S:
+			m.push(uint64(uint32(int32(int16(m.pop())))))
+		case opI64Extend8S:
+			m.push(uint64(int64(int8(m.pop()))))
+		case opI64Extend16S:
+			m.push(uint64(int64(int16(m.pop()))))
+		case opI64Extend32S:
+			m.push(uint64(int64(int32(m.pop()))))
+
+		default:
+			panic(fmt.Sprintf("unknown opcode 0x%x", in.opcode))
+		}
+	}
+
+	// the results are on top of the stack
+	results := len(f.typ.Results)
+	copy(m.stack[base:], m.stack[len(m.stack)-results:])
+	m.stack = m.stack[:base+results]
+}
+
+// branch branches to the label at depth, and returns the index of the
+// instruction to continue with.
+func (m *machine) branch(labels *[]label, depth int) int {
+	ls := *labels
+	l := ls[len(ls)-1-depth]
+	copy(m.stack[l.height:], m.stack[len(m.stack)-l.arity:])
+	m.stack = m.stack[:l.height+l.arity]
+	if l.loop {
+		*labels = ls[:len(ls)-depth]
+	} else {
+		*labels = ls[:len(ls)-1-depth]
+	}
+	return l.cont
+}
+
+// grow grows the memory by delta pages and returns its previous size in
+// pages, or -1 if it can't grow.
+func (inst *Instance) grow(delta uint32) uint32 {
+	current := uint32(len(inst.memory) / pageSize)
+	if inst.module.memory == nil || delta > inst.maxPages-current {
+		return 0xffffffff
+	}
+	inst.memory = append(inst.memory, make([]byte, int(delta)*pageSize)...)
+	return current
+}
+
+func boolToI32(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func i32Compare(op uint16, a, b uint32) bool {
+	switch op {
+	case opI32Eq:
+		return a == b
+	case opI32Ne:
+		return a != b
+	case opI32LtS:
+		return int32(a) < int32(b)
+	case opI32LtU:
+		return a < b
+	case opI32GtS:
+		return int32(a) > int32(b)
+	case opI32GtU:
+		return a > b
+	case opI32LeS:
+		return int32(a) <= int32(b)
+	case opI32LeU:
+		return a <= b
+	case opI32GeS:
+		return int32(a) >= int32(b)
+	default:
+		return a >= b
+	}
+}
+
+func i64Compare(op uint16, a, b uint64) bool {
+	switch op {
+	case opI64Eq:
+		return a == b
+	case opI64Ne:
+		return a != b
+	case opI64LtS:
+		return int64(a) < int64(b)
+	case opI64LtU:
+		return a < b
+	case opI64GtS:
+		return int64(a) > int64(b)
+	case opI64GtU:
+		return a > b
+	case opI64LeS:
+		return int64(a) <= int64(b)
+	case opI64LeU:
+		return a <= b
+	case opI64GeS:
+		return int64(a) >= int64(b)
+	default:
+		return a >= b
+	}
+}
+
+func i32Binary(op uint16, a, b uint32) uint32 {
+	switch op {
+	case opI32Add:
+		return a + b
+	case opI32Sub:
+		return a - b
+	case opI32Mul:
+		return a * b
+	case opI32DivS:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int32(a) == -1<<31 && int32(b) == -1 {
+			panic(trap{errIntegerOverflow})
+		}
+		return uint32(int32(a) / int32(b))
+	case opI32DivU:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a / b
+	case opI32RemS:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int32(b) == -1 {
+			return 0
+		}
+		return uint32(int32(a) % int32(b))
+	case opI32RemU:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a % b
+	case opI32And:
+		return a & b
+	case opI32Or:
+		return a | b
+	case opI32Xor:
+		return a ^ b
+	case opI32Shl:
+		return a << (b & 31)
+	case opI32ShrS:
+		return uint32(int32(a) >> (b & 31))
+	case opI32ShrU:
+		return a >> (b & 31)
+	case opI32Rotl:
+		return bits.RotateLeft32(a, int(b&31))
+	default:
+		return bits.RotateLeft32(a, -int(b&31))
+	}
+}
+
+func i64Binary(op uint16, a, b uint64) uint64 {
+	switch op {
+	case opI64Add:
+		return a + b
+	case opI64Sub:
+		return a - b
+	case opI64Mul:
+		return a * b
+	case opI64DivS:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int64(a) == -1<<63 && int64(b) == -1 {
+			panic(trap{errIntegerOverflow})
+		}
+		return uint64(int64(a) / int64(b))
+	case opI64DivU:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a / b
+	case opI64RemS:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		if int64(b) == -1 {
+			return 0
+		}
+		return uint64(int64(a) % int64(b))
+	case opI64RemU:
+		if b == 0 {
+			panic(trap{errDivideByZero})
+		}
+		return a % b
+	case opI64And:
+		return a & b
+	case opI64Or:
+		return a | b
+	case opI64Xor:
+		return a ^ b
+	case opI64Shl:
+		return a << (b & 63)
+	case opI64ShrS:
+		return uint64(int64(a) >> (b & 63))
+	case opI64ShrU:
+		return a >> (b & 63)
+	case opI64Rotl:
+		return bits.RotateLeft64(a, int(b&63))
+	default:
+		return bits.RotateLeft64(a, -int(b&63))
+	}
+}
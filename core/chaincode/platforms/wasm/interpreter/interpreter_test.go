@@ -0,0 +1,420 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm/interpreter"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var i32, i64 = interpreter.I32, interpreter.I64
+
+func compileTestModule(t *testing.T) *interpreter.Module {
+	binary, err := ioutil.ReadFile("testdata/test.wasm")
+	require.NoError(t, err)
+	m, err := interpreter.Compile(binary)
+	require.NoError(t, err)
+	return m
+}
+
+func testImports() interpreter.Imports {
+	return interpreter.Imports{
+		"env": {
+			"double": {
+				Type: interpreter.FuncType{Params: []interpreter.ValueType{i32}, Results: []interpreter.ValueType{i32}},
+				Func: func(ctx context.Context, inst *interpreter.Instance, params []uint64) ([]uint64, error) {
+					if params[0] == 0 {
+						return nil, errors.New("nothing to double")
+					}
+					return []uint64{uint64(uint32(params[0] * 2))}, nil
+				},
+			},
+			"call_back": {
+				Type: interpreter.FuncType{Params: []interpreter.ValueType{i32}, Results: []interpreter.ValueType{i32}},
+				Func: func(ctx context.Context, inst *interpreter.Instance, params []uint64) ([]uint64, error) {
+					return inst.Call(ctx, "sum", params[0])
+				},
+			},
+		},
+	}
+}
+
+func instantiate(t *testing.T, memoryLimit uint32) *interpreter.Instance {
+	inst, err := interpreter.Instantiate(context.Background(), compileTestModule(t), testImports(), memoryLimit)
+	require.NoError(t, err)
+	return inst
+}
+
+func i32Value(v int32) uint64 {
+	return uint64(uint32(v))
+}
+
+func i64Value(v int64) uint64 {
+	return uint64(v)
+}
+
+func TestNumericInstructions(t *testing.T) {
+	inst := instantiate(t, 0)
+
+	tests := []struct {
+		function string
+		params   []uint64
+		result   uint64
+		err      string
+	}{
+		{"i32.add", []uint64{i32Value(math.MaxInt32), 1}, i32Value(math.MinInt32), ""},
+		{"i32.sub", []uint64{0, 1}, i32Value(-1), ""},
+		{"i32.mul", []uint64{i32Value(-3), 7}, i32Value(-21), ""},
+		{"i32.div_s", []uint64{i32Value(-7), 2}, i32Value(-3), ""},
+		{"i32.div_s", []uint64{1, 0}, 0, "integer divide by zero"},
+		{"i32.div_s", []uint64{i32Value(math.MinInt32), i32Value(-1)}, 0, "integer overflow"},
+		{"i32.div_u", []uint64{i32Value(-7), 2}, 0x7ffffffc, ""},
+		{"i32.rem_s", []uint64{i32Value(-7), 2}, i32Value(-1), ""},
+		{"i32.rem_s", []uint64{i32Value(math.MinInt32), i32Value(-1)}, 0, ""},
+		{"i32.rem_u", []uint64{7, 0}, 0, "integer divide by zero"},
+		{"i32.and", []uint64{0xff00, 0x0ff0}, 0x0f00, ""},
+		{"i32.or", []uint64{0xff00, 0x0ff0}, 0xfff0, ""},
+		{"i32.xor", []uint64{0xff00, 0x0ff0}, 0xf0f0, ""},
+		{"i32.shl", []uint64{1, 33}, 2, ""},
+		{"i32.shr_s", []uint64{i32Value(-8), 1}, i32Value(-4), ""},
+		{"i32.shr_u", []uint64{i32Value(-8), 1}, 0x7ffffffc, ""},
+		{"i32.rotl", []uint64{0x80000001, 1}, 3, ""},
+		{"i32.rotr", []uint64{3, 1}, 0x80000001, ""},
+		{"i32.eq", []uint64{1, 1}, 1, ""},
+		{"i32.ne", []uint64{1, 1}, 0, ""},
+		{"i32.lt_s", []uint64{i32Value(-1), 0}, 1, ""},
+		{"i32.lt_u", []uint64{i32Value(-1), 0}, 0, ""},
+		{"i32.gt_s", []uint64{i32Value(-1), 0}, 0, ""},
+		{"i32.gt_u", []uint64{i32Value(-1), 0}, 1, ""},
+		{"i32.le_s", []uint64{0, 0}, 1, ""},
+		{"i32.le_u", []uint64{1, 0}, 0, ""},
+		{"i32.ge_s", []uint64{0, i32Value(-1)}, 1, ""},
+		{"i32.ge_u", []uint64{0, i32Value(-1)}, 0, ""},
+		{"i32.clz", []uint64{1}, 31, ""},
+		{"i32.ctz", []uint64{0}, 32, ""},
+		{"i32.popcnt", []uint64{0xff}, 8, ""},
+		{"i32.eqz", []uint64{0}, 1, ""},
+		{"i32.extend8_s", []uint64{0x80}, i32Value(-128), ""},
+		{"i32.extend16_s", []uint64{0x8000}, i32Value(-32768), ""},
+
+		{"i64.add", []uint64{i64Value(math.MaxInt64), 1}, i64Value(math.MinInt64), ""},
+		{"i64.sub", []uint64{0, 1}, i64Value(-1), ""},
+		{"i64.mul", []uint64{1 << 32, 1 << 32}, 0, ""},
+		{"i64.div_s", []uint64{i64Value(-7), 2}, i64Value(-3), ""},
+		{"i64.div_s", []uint64{i64Value(math.MinInt64), i64Value(-1)}, 0, "integer overflow"},
+		{"i64.div_u", []uint64{7, 0}, 0, "integer divide by zero"},
+		{"i64.rem_s", []uint64{i64Value(math.MinInt64), i64Value(-1)}, 0, ""},
+		{"i64.rem_u", []uint64{7, 4}, 3, ""},
+		{"i64.and", []uint64{0xff00, 0x0ff0}, 0x0f00, ""},
+		{"i64.or", []uint64{0xff00, 0x0ff0}, 0xfff0, ""},
+		{"i64.xor", []uint64{0xff00, 0x0ff0}, 0xf0f0, ""},
+		{"i64.shl", []uint64{1, 65}, 2, ""},
+		{"i64.shr_s", []uint64{i64Value(-8), 1}, i64Value(-4), ""},
+		{"i64.shr_u", []uint64{i64Value(-8), 1}, 0x7ffffffffffffffc, ""},
+		{"i64.rotl", []uint64{0x8000000000000001, 1}, 3, ""},
+		{"i64.rotr", []uint64{3, 1}, 0x8000000000000001, ""},
+		{"i64.eq", []uint64{1, 1}, 1, ""},
+		{"i64.ne", []uint64{1, 1}, 0, ""},
+		{"i64.lt_s", []uint64{i64Value(-1), 0}, 1, ""},
+		{"i64.lt_u", []uint64{i64Value(-1), 0}, 0, ""},
+		{"i64.gt_s", []uint64{i64Value(-1), 0}, 0, ""},
+		{"i64.gt_u", []uint64{i64Value(-1), 0}, 1, ""},
+		{"i64.le_s", []uint64{0, 0}, 1, ""},
+		{"i64.le_u", []uint64{1, 0}, 0, ""},
+		{"i64.ge_s", []uint64{0, i64Value(-1)}, 1, ""},
+		{"i64.ge_u", []uint64{0, i64Value(-1)}, 0, ""},
+		{"i64.clz", []uint64{1}, 63, ""},
+		{"i64.ctz", []uint64{0}, 64, ""},
+		{"i64.popcnt", []uint64{0xffff}, 16, ""},
+		{"i64.eqz", []uint64{1 << 40}, 0, ""},
+		{"i64.extend8_s", []uint64{0x80}, i64Value(-128), ""},
+		{"i64.extend16_s", []uint64{0x8000}, i64Value(-32768), ""},
+		{"i64.extend32_s", []uint64{0x80000000}, i64Value(math.MinInt32), ""},
+
+		{"i32.wrap_i64", []uint64{0x123456789}, 0x23456789, ""},
+		{"i64.extend_i32_s", []uint64{i32Value(-1)}, i64Value(-1), ""},
+		{"i64.extend_i32_u", []uint64{i32Value(-1)}, 0xffffffff, ""},
+	}
+	for _, tt := range tests {
+		results, err := inst.Call(context.Background(), tt.function, tt.params...)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err, tt.function)
+			continue
+		}
+		if assert.NoError(t, err, tt.function) {
+			assert.Equal(t, []uint64{tt.result}, results, tt.function)
+		}
+	}
+}
+
+func TestControlInstructions(t *testing.T) {
+	inst := instantiate(t, 0)
+	ctx := context.Background()
+
+	call := func(function string, params ...uint64) []uint64 {
+		results, err := inst.Call(ctx, function, params...)
+		require.NoError(t, err, function)
+		return results
+	}
+
+	assert.Equal(t, []uint64{1}, call("counter"), "the start function is executed at instantiation")
+	assert.Equal(t, []uint64{i64Value(-1)}, call("base"))
+
+	assert.Equal(t, []uint64{10}, call("switch", 0))
+	assert.Equal(t, []uint64{11}, call("switch", 1))
+	assert.Equal(t, []uint64{12}, call("switch", 2))
+	assert.Equal(t, []uint64{99}, call("switch", 3))
+	assert.Equal(t, []uint64{99}, call("switch", 100))
+
+	assert.Equal(t, []uint64{3628800}, call("factorial", 10))
+	assert.Equal(t, []uint64{5050}, call("sum", 100))
+	assert.Equal(t, []uint64{1}, call("select", 1))
+	assert.Equal(t, []uint64{2}, call("select", 0))
+	assert.Equal(t, []uint64{1}, call("swap_sub", 2, 3))
+
+	assert.Equal(t, []uint64{5}, call("indirect", 2, 3, 0))
+	assert.Equal(t, []uint64{i32Value(-1)}, call("indirect", 2, 3, 1))
+	_, err := inst.Call(ctx, "indirect", 2, 3, 2)
+	assert.EqualError(t, err, "uninitialized element")
+	_, err = inst.Call(ctx, "indirect", 2, 3, 3)
+	assert.EqualError(t, err, "undefined element")
+
+	_, err = inst.Call(ctx, "unreachable")
+	assert.EqualError(t, err, "unreachable executed")
+	_, err = inst.Call(ctx, "recurse")
+	assert.EqualError(t, err, "call stack exhausted")
+
+	_, err = inst.Call(ctx, "missing")
+	assert.EqualError(t, err, "function missing is not exported")
+	_, err = inst.Call(ctx, "sum")
+	assert.EqualError(t, err, "function sum expects 1 parameters, got 0")
+}
+
+func TestMemoryInstructions(t *testing.T) {
+	inst := instantiate(t, 0)
+	ctx := context.Background()
+
+	data, ok := inst.Read(16, 5)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hello"), data, "the data segments initialize the memory")
+
+	assert.True(t, inst.Write(0, []byte{0xfe, 0xff}))
+	results, err := inst.Call(ctx, "load8_s", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{i32Value(-2)}, results)
+	results, err = inst.Call(ctx, "load16_u", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0xfffe}, results)
+
+	_, err = inst.Call(ctx, "store", 32, i64Value(-5))
+	assert.NoError(t, err)
+	results, err = inst.Call(ctx, "load32_s", 36)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{i64Value(-1)}, results)
+	results, err = inst.Call(ctx, "load32_s", 32)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{i64Value(-5)}, results)
+
+	_, err = inst.Call(ctx, "copy", 100, 16, 5)
+	assert.NoError(t, err)
+	data, _ = inst.Read(100, 5)
+	assert.Equal(t, []byte("hello"), data)
+	_, err = inst.Call(ctx, "fill", 101, 'a', 3)
+	assert.NoError(t, err)
+	data, _ = inst.Read(100, 5)
+	assert.Equal(t, []byte("haaao"), data)
+
+	_, err = inst.Call(ctx, "load16_u", 65535)
+	assert.EqualError(t, err, "out of bounds memory access")
+	_, err = inst.Call(ctx, "store", i32Value(-1), 0)
+	assert.EqualError(t, err, "out of bounds memory access")
+	_, err = inst.Call(ctx, "copy", 0, 65530, 10)
+	assert.EqualError(t, err, "out of bounds memory access")
+	_, err = inst.Call(ctx, "fill", 65530, 0, 10)
+	assert.EqualError(t, err, "out of bounds memory access")
+	_, ok = inst.Read(65530, 10)
+	assert.False(t, ok)
+	assert.False(t, inst.Write(65530, make([]byte, 10)))
+
+	results, err = inst.Call(ctx, "grow", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, results)
+	results, err = inst.Call(ctx, "size")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3}, results)
+	assert.Equal(t, uint32(3*65536), inst.MemorySize())
+	results, err = inst.Call(ctx, "grow", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0xffffffff}, results, "the memory can't grow beyond its maximum")
+}
+
+func TestMemoryLimit(t *testing.T) {
+	inst := instantiate(t, 2)
+	results, err := inst.Call(context.Background(), "grow", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0xffffffff}, results)
+	results, err = inst.Call(context.Background(), "grow", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, results)
+
+	binary, err := ioutil.ReadFile("testdata/test.wasm")
+	require.NoError(t, err)
+	m, err := interpreter.Compile(binary)
+	require.NoError(t, err)
+	_, err = interpreter.Instantiate(context.Background(), m, testImports(), 0)
+	assert.NoError(t, err)
+}
+
+func TestStackLimit(t *testing.T) {
+	// a function with 50000 i64 locals, exported as run, which calls
+	// itself
+	binary := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		0x03, 0x02, 0x01, 0x00,
+		0x07, 0x07, 0x01, 0x03, 'r', 'u', 'n', 0x00, 0x00,
+		0x0a, 0x0a, 0x01, 0x08, 0x01, 0xd0, 0x86, 0x03, 0x7e, 0x10, 0x00, 0x0b,
+	}
+	require.Len(t, binary, 39)
+	m, err := interpreter.Compile(binary)
+	require.NoError(t, err)
+
+	for _, memoryLimit := range []uint32{0, 16} {
+		inst, err := interpreter.Instantiate(context.Background(), m, nil, memoryLimit)
+		require.NoError(t, err)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err = inst.Call(context.Background(), "run")
+		runtime.ReadMemStats(&after)
+		assert.EqualError(t, err, "call stack exhausted")
+		assert.True(t, after.TotalAlloc-before.TotalAlloc < 128<<20, "%d bytes were allocated", after.TotalAlloc-before.TotalAlloc)
+
+		// the frames are released once the execution is aborted
+		_, err = inst.Call(context.Background(), "run")
+		assert.EqualError(t, err, "call stack exhausted")
+	}
+}
+
+func TestHostFunctions(t *testing.T) {
+	inst := instantiate(t, 0)
+	ctx := context.Background()
+
+	results, err := inst.Call(ctx, "double", 21)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{42}, results)
+
+	_, err = inst.Call(ctx, "double", 0)
+	assert.EqualError(t, err, "nothing to double")
+
+	results, err = inst.Call(ctx, "call_back", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{55}, results)
+}
+
+func TestCancellation(t *testing.T) {
+	inst := instantiate(t, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := inst.Call(ctx, "loop")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	m := compileTestModule(t)
+
+	_, err := interpreter.Instantiate(context.Background(), m, interpreter.Imports{}, 0)
+	assert.EqualError(t, err, "function double imported from module env is not defined")
+
+	imports := testImports()
+	imports["env"]["double"] = &interpreter.HostFunction{Type: interpreter.FuncType{Params: []interpreter.ValueType{i64}}}
+	_, err = interpreter.Instantiate(context.Background(), m, imports, 0)
+	assert.EqualError(t, err, "function double imported from module env has a different type")
+}
+
+func TestModule(t *testing.T) {
+	m := compileTestModule(t)
+
+	assert.Equal(t, []interpreter.Import{
+		{Module: "env", Name: "double", Kind: interpreter.FunctionKind, Type: interpreter.FuncType{Params: []interpreter.ValueType{i32}, Results: []interpreter.ValueType{i32}}},
+		{Module: "env", Name: "call_back", Kind: interpreter.FunctionKind, Type: interpreter.FuncType{Params: []interpreter.ValueType{i32}, Results: []interpreter.ValueType{i32}}},
+	}, m.Imports())
+
+	typ, ok := m.ExportedFunction("i64.lt_s")
+	assert.True(t, ok)
+	assert.Equal(t, interpreter.FuncType{Params: []interpreter.ValueType{i64, i64}, Results: []interpreter.ValueType{i32}}, typ)
+	_, ok = m.ExportedFunction("memory")
+	assert.False(t, ok)
+
+	assert.True(t, m.ExportsMemory("memory"))
+	assert.False(t, m.ExportsMemory("sum"))
+}
+
+func TestCompileErrors(t *testing.T) {
+	header := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module := func(sections ...byte) []byte {
+		return append(append([]byte(nil), header...), sections...)
+	}
+	// type section with a single () -> () type, function and code
+	// sections with a single function of the body
+	function := func(body ...byte) []byte {
+		return module(append([]byte{
+			0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+			0x03, 0x02, 0x01, 0x00,
+			0x0a, byte(len(body) + 2), 0x01, byte(len(body)),
+		}, body...)...)
+	}
+
+	tests := []struct {
+		name   string
+		binary []byte
+		err    string
+	}{
+		{"empty", nil, "not a WASM binary of version 1"},
+		{"version", []byte{0x00, 0x61, 0x73, 0x6d, 0x02, 0x00, 0x00, 0x00}, "not a WASM binary of version 1"},
+		{"truncated", module(0x01, 0x05, 0x01), "unexpected end"},
+		{"unknown section", module(0x0d, 0x00), "unknown section: unknown section 13"},
+		{"order", module(0x03, 0x01, 0x00, 0x01, 0x01, 0x00), "type section out of order"},
+		{"float type", module(0x01, 0x05, 0x01, 0x60, 0x01, 0x7c, 0x00), "type section: floating point values are not supported"},
+		{"long integer", module(0x01, 0x06, 0x81, 0x80, 0x80, 0x80, 0x80, 0x00), "type section: integer representation too long"},
+		{"size mismatch", module(0x01, 0x02, 0x00, 0x00), "type section size mismatch"},
+		{"missing code", module(0x01, 0x04, 0x01, 0x60, 0x00, 0x00, 0x03, 0x02, 0x01, 0x00), "function and code section have inconsistent lengths"},
+		{"valid", function(0x00, 0x0b), ""},
+		{"float instruction", function(0x00, 0x43, 0x00, 0x00, 0x00, 0x00, 0x1a, 0x0b), "code section: function 0: offset 2: floating point instruction 0x43 is not supported"},
+		{"saturating truncation", function(0x00, 0xfc, 0x00, 0x0b), "code section: function 0: offset 3: floating point instruction 0xfc 0x0 is not supported"},
+		{"float local", function(0x01, 0x01, 0x7d, 0x0b), "code section: function 0: floating point values are not supported"},
+		{"type mismatch", function(0x00, 0x42, 0x00, 0x41, 0x00, 0x6a, 0x1a, 0x0b), "code section: function 0: offset 6: type mismatch: expected i32, got i64"},
+		{"underflow", function(0x00, 0x1a, 0x0b), "code section: function 0: offset 2: type mismatch: stack underflow"},
+		{"remaining", function(0x00, 0x41, 0x00, 0x0b), "code section: function 0: offset 4: type mismatch: values remaining on the stack at the end of the block"},
+		{"unknown function", function(0x00, 0x10, 0x05, 0x0b), "code section: function 0: offset 3: unknown function 5"},
+		{"unknown local", function(0x00, 0x20, 0x00, 0x1a, 0x0b), "code section: function 0: offset 3: unknown local 0"},
+		{"unknown label", function(0x00, 0x0c, 0x01, 0x0b), "code section: function 0: offset 3: unknown label 1"},
+		{"no memory", function(0x00, 0x41, 0x00, 0x28, 0x02, 0x00, 0x1a, 0x0b), "code section: function 0: offset 4: unknown memory 0"},
+		{"missing end", function(0x00, 0x01), "code section: function 0: unexpected end of function body"},
+		{"unreachable stack", function(0x00, 0x00, 0x6a, 0x1a, 0x0b), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interpreter.Compile(tt.binary)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
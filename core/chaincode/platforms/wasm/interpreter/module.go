@@ -0,0 +1,745 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// pageSize is the size in bytes of a page of memory
+	pageSize = 65536
+	// maxPages is the maximum number of pages of a memory
+	maxPages = 65536
+	// maxTableSize is the maximum number of elements of a table
+	maxTableSize = 1 << 20
+	// maxLocals is the maximum number of locals of a function
+	maxLocals = 50000
+)
+
+var magic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// Section IDs of the binary format
+const (
+	customSection    = 0
+	typeSection      = 1
+	importSection    = 2
+	functionSection  = 3
+	tableSection     = 4
+	memorySection    = 5
+	globalSection    = 6
+	exportSection    = 7
+	startSection     = 8
+	elementSection   = 9
+	codeSection      = 10
+	dataSection      = 11
+	dataCountSection = 12
+)
+
+// Module is a decoded and validated WASM module, which may be instantiated
+// any number of times.
+type Module struct {
+	types   []FuncType
+	imports []Import
+	// funcs holds the type indices of the functions, imported functions
+	// first
+	funcs           []uint32
+	numImportedFunc int
+	code            []*function
+	table           *limits
+	memory          *limits
+	globals         []global
+	exports         map[string]export
+	start           int64
+	elements        []element
+	data            []segment
+}
+
+// Compile decodes and validates a WASM module in the binary format.
+// Modules using floating point values or instructions are rejected, as
+// the bit patterns of NaNs they produce depend on the platform.
+func Compile(binary []byte) (*Module, error) {
+	if len(binary) < len(magic) || !bytes.Equal(binary[:len(magic)], magic) {
+		return nil, errors.New("not a WASM binary of version 1")
+	}
+
+	m := &Module{exports: map[string]export{}, start: -1}
+	r := &reader{data: binary, pos: len(magic)}
+	var lastOrder int
+	var funcTypes []uint32
+	dataCount := int64(-1)
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := r.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+		if id != customSection && id <= dataCountSection {
+			if sectionOrder(id) <= lastOrder {
+				return nil, errors.Errorf("%s section out of order", sectionName(id))
+			}
+			lastOrder = sectionOrder(id)
+		}
+
+		s := &reader{data: payload}
+		switch id {
+		case customSection:
+			_, err = s.name()
+			s.pos = len(s.data)
+		case typeSection:
+			err = m.decodeTypes(s)
+		case importSection:
+			err = m.decodeImports(s)
+		case functionSection:
+			funcTypes, err = m.decodeFunctions(s)
+		case tableSection:
+			err = m.decodeTables(s)
+		case memorySection:
+			err = m.decodeMemories(s)
+		case globalSection:
+			err = m.decodeGlobals(s)
+		case exportSection:
+			err = m.decodeExports(s)
+		case startSection:
+			err = m.decodeStart(s)
+		case elementSection:
+			err = m.decodeElements(s)
+		case codeSection:
+			err = m.decodeCode(s, funcTypes)
+		case dataSection:
+			err = m.decodeData(s)
+			if err == nil && dataCount >= 0 && int64(len(m.data)) != dataCount {
+				err = errors.New("data count and data section have inconsistent lengths")
+			}
+		case dataCountSection:
+			var count uint32
+			count, err = s.u32()
+			dataCount = int64(count)
+		default:
+			err = errors.Errorf("unknown section %d", id)
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, sectionName(id)+" section")
+		}
+		if !s.done() {
+			return nil, errors.Errorf("%s section size mismatch", sectionName(id))
+		}
+	}
+
+	if len(funcTypes) != len(m.code) {
+		return nil, errors.New("function and code section have inconsistent lengths")
+	}
+	return m, nil
+}
+
+// sectionOrder returns the position of a section. Sections other than
+// custom sections must appear in that order, the data count section
+// being between the element and code sections.
+func sectionOrder(id byte) int {
+	if id == dataCountSection {
+		return 2*elementSection + 1
+	}
+	return 2 * int(id)
+}
+
+func sectionName(id byte) string {
+	names := []string{"custom", "type", "import", "function", "table", "memory", "global", "export", "start", "element", "code", "data", "data count"}
+	if int(id) < len(names) {
+		return names[id]
+	}
+	return "unknown"
+}
+
+// Imports returns the imports of the module.
+func (m *Module) Imports() []Import {
+	return append([]Import(nil), m.imports...)
+}
+
+// ExportedFunction returns the type of the function exported under the
+// name.
+func (m *Module) ExportedFunction(name string) (FuncType, bool) {
+	e, ok := m.exports[name]
+	if !ok || e.kind != FunctionKind {
+		return FuncType{}, false
+	}
+	return m.types[m.funcs[e.index]], true
+}
+
+// ExportsMemory returns whether the memory of the module is exported under
+// the name.
+func (m *Module) ExportsMemory(name string) bool {
+	e, ok := m.exports[name]
+	return ok && e.kind == MemoryKind
+}
+
+func (m *Module) decodeTypes(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		form, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if form != 0x60 {
+			return errors.Errorf("invalid function type form 0x%x", form)
+		}
+		params, err := r.valueTypes()
+		if err != nil {
+			return err
+		}
+		results, err := r.valueTypes()
+		if err != nil {
+			return err
+		}
+		m.types = append(m.types, FuncType{Params: params, Results: results})
+	}
+	return nil
+}
+
+func (m *Module) decodeImports(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		module, err := r.name()
+		if err != nil {
+			return err
+		}
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		imp := Import{Module: module, Name: name, Kind: ExternKind(kind)}
+		switch imp.Kind {
+		case FunctionKind:
+			index, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if int(index) >= len(m.types) {
+				return errors.Errorf("unknown type %d", index)
+			}
+			imp.Type = m.types[index]
+			m.funcs = append(m.funcs, index)
+			m.numImportedFunc++
+		case TableKind:
+			if err := m.decodeTable(r); err != nil {
+				return err
+			}
+		case MemoryKind:
+			if err := m.decodeMemory(r); err != nil {
+				return err
+			}
+		case GlobalKind:
+			g, err := r.globalType()
+			if err != nil {
+				return err
+			}
+			m.globals = append(m.globals, g)
+		default:
+			return errors.Errorf("invalid import kind 0x%x", kind)
+		}
+		m.imports = append(m.imports, imp)
+	}
+	return nil
+}
+
+func (m *Module) decodeFunctions(r *reader) ([]uint32, error) {
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	var funcTypes []uint32
+	for i := uint32(0); i < count; i++ {
+		index, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		if int(index) >= len(m.types) {
+			return nil, errors.Errorf("unknown type %d", index)
+		}
+		funcTypes = append(funcTypes, index)
+		m.funcs = append(m.funcs, index)
+	}
+	return funcTypes, nil
+}
+
+func (m *Module) decodeTables(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if err := m.decodeTable(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeTable(r *reader) error {
+	elemType, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if elemType != 0x70 {
+		return errors.Errorf("unsupported table element type 0x%x", elemType)
+	}
+	l, err := r.limits(maxTableSize)
+	if err != nil {
+		return err
+	}
+	if m.table != nil {
+		return errors.New("multiple tables")
+	}
+	m.table = l
+	return nil
+}
+
+func (m *Module) decodeMemories(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		if err := m.decodeMemory(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Module) decodeMemory(r *reader) error {
+	l, err := r.limits(maxPages)
+	if err != nil {
+		return err
+	}
+	if m.memory != nil {
+		return errors.New("multiple memories")
+	}
+	m.memory = l
+	return nil
+}
+
+func (m *Module) decodeGlobals(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		g, err := r.globalType()
+		if err != nil {
+			return err
+		}
+		g.init, err = r.constExpr(g.valueType)
+		if err != nil {
+			return err
+		}
+		m.globals = append(m.globals, g)
+	}
+	return nil
+}
+
+func (m *Module) decodeExports(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		name, err := r.name()
+		if err != nil {
+			return err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return err
+		}
+		index, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch ExternKind(kind) {
+		case FunctionKind:
+			if int(index) >= len(m.funcs) {
+				return errors.Errorf("unknown function %d", index)
+			}
+		case TableKind:
+			if index != 0 || m.table == nil {
+				return errors.Errorf("unknown table %d", index)
+			}
+		case MemoryKind:
+			if index != 0 || m.memory == nil {
+				return errors.Errorf("unknown memory %d", index)
+			}
+		case GlobalKind:
+			if int(index) >= len(m.globals) {
+				return errors.Errorf("unknown global %d", index)
+			}
+		default:
+			return errors.Errorf("invalid export kind 0x%x", kind)
+		}
+		if _, ok := m.exports[name]; ok {
+			return errors.Errorf("duplicate export name %s", name)
+		}
+		m.exports[name] = export{kind: ExternKind(kind), index: index}
+	}
+	return nil
+}
+
+func (m *Module) decodeStart(r *reader) error {
+	index, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if int(index) >= len(m.funcs) {
+		return errors.Errorf("unknown function %d", index)
+	}
+	if t := m.types[m.funcs[index]]; len(t.Params) != 0 || len(t.Results) != 0 {
+		return errors.New("start function must not take parameters or return results")
+	}
+	m.start = int64(index)
+	return nil
+}
+
+func (m *Module) decodeElements(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		flags, err := r.u32()
+		if err != nil {
+			return err
+		}
+		if flags != 0 {
+			return errors.Errorf("unsupported element segment kind %d", flags)
+		}
+		if m.table == nil {
+			return errors.New("unknown table 0")
+		}
+		offset, err := r.constExpr(I32)
+		if err != nil {
+			return err
+		}
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		e := element{offset: uint32(offset)}
+		for j := uint32(0); j < n; j++ {
+			index, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if int(index) >= len(m.funcs) {
+				return errors.Errorf("unknown function %d", index)
+			}
+			e.funcs = append(e.funcs, index)
+		}
+		m.elements = append(m.elements, e)
+	}
+	return nil
+}
+
+func (m *Module) decodeCode(r *reader, funcTypes []uint32) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	if int(count) != len(funcTypes) {
+		return errors.New("function and code section have inconsistent lengths")
+	}
+	for i := uint32(0); i < count; i++ {
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		body, err := r.bytes(size)
+		if err != nil {
+			return err
+		}
+		index := m.numImportedFunc + int(i)
+		f, err := compileFunction(m, m.types[funcTypes[i]], body)
+		if err != nil {
+			return errors.WithMessage(err, "function "+strconv.Itoa(index))
+		}
+		m.code = append(m.code, f)
+	}
+	return nil
+}
+
+func (m *Module) decodeData(r *reader) error {
+	count, err := r.u32()
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < count; i++ {
+		flags, err := r.u32()
+		if err != nil {
+			return err
+		}
+		switch flags {
+		case 0:
+		case 2:
+			index, err := r.u32()
+			if err != nil {
+				return err
+			}
+			if index != 0 {
+				return errors.Errorf("unknown memory %d", index)
+			}
+		default:
+			return errors.Errorf("unsupported data segment kind %d", flags)
+		}
+		if m.memory == nil {
+			return errors.New("unknown memory 0")
+		}
+		offset, err := r.constExpr(I32)
+		if err != nil {
+			return err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return err
+		}
+		init, err := r.bytes(size)
+		if err != nil {
+			return err
+		}
+		m.data = append(m.data, segment{offset: uint32(offset), init: init})
+	}
+	return nil
+}
+
+// reader decodes values of the binary format
+type reader struct {
+	data []byte
+	pos  int
+}
+
+var errUnexpectedEnd = errors.New("unexpected end")
+
+func (r *reader) done() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *reader) bytes(n uint32) ([]byte, error) {
+	if uint64(len(r.data)-r.pos) < uint64(n) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// leb decodes an integer of size bits in the LEB128 encoding.
+func (r *reader) leb(size uint, signed bool) (uint64, error) {
+	maxBytes := (size + 6) / 7
+	var result uint64
+	for i := uint(0); ; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		if i == maxBytes-1 {
+			if b&0x80 != 0 {
+				return 0, errors.New("integer representation too long")
+			}
+			// the bits of the last byte beyond size must be zero, or a
+			// sign extension
+			rem := size - 7*i
+			if signed {
+				extension := (b & 0x7f) >> (rem - 1)
+				if extension != 0 && extension != 0x7f>>(rem-1) {
+					return 0, errors.New("integer too large")
+				}
+			} else if rem < 7 && b>>rem != 0 {
+				return 0, errors.New("integer too large")
+			}
+		}
+		result |= uint64(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			if signed && b&0x40 != 0 && 7*(i+1) < 64 {
+				result |= ^uint64(0) << (7 * (i + 1))
+			}
+			return result, nil
+		}
+	}
+}
+
+func (r *reader) u32() (uint32, error) {
+	v, err := r.leb(32, false)
+	return uint32(v), err
+}
+
+func (r *reader) s32() (int32, error) {
+	v, err := r.leb(32, true)
+	return int32(v), err
+}
+
+func (r *reader) s33() (int64, error) {
+	v, err := r.leb(33, true)
+	return int64(v), err
+}
+
+func (r *reader) s64() (int64, error) {
+	v, err := r.leb(64, true)
+	return int64(v), err
+}
+
+func (r *reader) name() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8 encoding")
+	}
+	return string(b), nil
+}
+
+func (r *reader) valueType() (ValueType, error) {
+	b, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	return toValueType(b)
+}
+
+func toValueType(b byte) (ValueType, error) {
+	switch ValueType(b) {
+	case I32, I64:
+		return ValueType(b), nil
+	case 0x7d, 0x7c:
+		return 0, errors.New("floating point values are not supported")
+	default:
+		return 0, errors.Errorf("unsupported value type 0x%x", b)
+	}
+}
+
+func (r *reader) valueTypes() ([]ValueType, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	var types []ValueType
+	for i := uint32(0); i < n; i++ {
+		t, err := r.valueType()
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+func (r *reader) limits(max uint32) (*limits, error) {
+	flags, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	if flags > 1 {
+		return nil, errors.Errorf("invalid limits flags 0x%x", flags)
+	}
+	l := &limits{}
+	if l.min, err = r.u32(); err != nil {
+		return nil, err
+	}
+	if flags == 1 {
+		if l.max, err = r.u32(); err != nil {
+			return nil, err
+		}
+		l.hasMax = true
+		if l.max < l.min {
+			return nil, errors.New("size minimum must not be greater than maximum")
+		}
+	}
+	if l.min > max || l.hasMax && l.max > max {
+		return nil, errors.Errorf("size must be at most %d", max)
+	}
+	return l, nil
+}
+
+func (r *reader) globalType() (global, error) {
+	t, err := r.valueType()
+	if err != nil {
+		return global{}, err
+	}
+	mutable, err := r.byte()
+	if err != nil {
+		return global{}, err
+	}
+	if mutable > 1 {
+		return global{}, errors.Errorf("invalid mutability 0x%x", mutable)
+	}
+	return global{valueType: t, mutable: mutable == 1}, nil
+}
+
+// constExpr decodes a constant expression, which must be a constant of the
+// type.
+func (r *reader) constExpr(t ValueType) (uint64, error) {
+	op, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	var value uint64
+	switch {
+	case op == opI32Const && t == I32:
+		v, err := r.s32()
+		if err != nil {
+			return 0, err
+		}
+		value = uint64(uint32(v))
+	case op == opI64Const && t == I64:
+		v, err := r.s64()
+		if err != nil {
+			return 0, err
+		}
+		value = uint64(v)
+	default:
+		return 0, errors.Errorf("unsupported constant expression of type %s", t)
+	}
+	end, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	if end != opEnd {
+		return 0, errors.New("constant expression must be a single constant")
+	}
+	return value, nil
+}
@@ -0,0 +1,393 @@
+;; Exercises the instructions supported by the interpreter.
+(module
+  (type $binary (func (param i32 i32) (result i32)))
+  (type $swap (func (param i32 i32) (result i32 i32)))
+  (import "env" "double" (func $double (param i32) (result i32)))
+  (import "env" "call_back" (func $call_back (param i32) (result i32)))
+  (memory (export "memory") 1 4)
+  (table 3 funcref)
+  (elem (i32.const 0) $add $sub)
+  (global $counter (mut i32) (i32.const 0))
+  (global $base i64 (i64.const -1))
+  (data (i32.const 16) "hello")
+  (start $init)
+
+  (func $init
+    global.get $counter
+    i32.const 1
+    i32.add
+    global.set $counter)
+
+  (func $add (export "i32.add") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.add)
+  (func $sub (export "i32.sub") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.sub)
+  (func (export "i32.mul") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.mul)
+  (func (export "i32.div_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.div_s)
+  (func (export "i32.div_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.div_u)
+  (func (export "i32.rem_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.rem_s)
+  (func (export "i32.rem_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.rem_u)
+  (func (export "i32.and") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.and)
+  (func (export "i32.or") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.or)
+  (func (export "i32.xor") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.xor)
+  (func (export "i32.shl") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.shl)
+  (func (export "i32.shr_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.shr_s)
+  (func (export "i32.shr_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.shr_u)
+  (func (export "i32.rotl") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.rotl)
+  (func (export "i32.rotr") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.rotr)
+  (func (export "i32.eq") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.eq)
+  (func (export "i32.ne") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.ne)
+  (func (export "i32.lt_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.lt_s)
+  (func (export "i32.lt_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.lt_u)
+  (func (export "i32.gt_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.gt_s)
+  (func (export "i32.gt_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.gt_u)
+  (func (export "i32.le_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.le_s)
+  (func (export "i32.le_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.le_u)
+  (func (export "i32.ge_s") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.ge_s)
+  (func (export "i32.ge_u") (param i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    i32.ge_u)
+  (func (export "i32.clz") (param i32) (result i32)
+    local.get 0
+    i32.clz)
+  (func (export "i32.ctz") (param i32) (result i32)
+    local.get 0
+    i32.ctz)
+  (func (export "i32.popcnt") (param i32) (result i32)
+    local.get 0
+    i32.popcnt)
+  (func (export "i32.eqz") (param i32) (result i32)
+    local.get 0
+    i32.eqz)
+  (func (export "i32.extend8_s") (param i32) (result i32)
+    local.get 0
+    i32.extend8_s)
+  (func (export "i32.extend16_s") (param i32) (result i32)
+    local.get 0
+    i32.extend16_s)
+  (func (export "i64.add") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.add)
+  (func (export "i64.sub") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.sub)
+  (func (export "i64.mul") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.mul)
+  (func (export "i64.div_s") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.div_s)
+  (func (export "i64.div_u") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.div_u)
+  (func (export "i64.rem_s") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.rem_s)
+  (func (export "i64.rem_u") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.rem_u)
+  (func (export "i64.and") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.and)
+  (func (export "i64.or") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.or)
+  (func (export "i64.xor") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.xor)
+  (func (export "i64.shl") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.shl)
+  (func (export "i64.shr_s") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.shr_s)
+  (func (export "i64.shr_u") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.shr_u)
+  (func (export "i64.rotl") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.rotl)
+  (func (export "i64.rotr") (param i64 i64) (result i64)
+    local.get 0
+    local.get 1
+    i64.rotr)
+  (func (export "i64.eq") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.eq)
+  (func (export "i64.ne") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.ne)
+  (func (export "i64.lt_s") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.lt_s)
+  (func (export "i64.lt_u") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.lt_u)
+  (func (export "i64.gt_s") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.gt_s)
+  (func (export "i64.gt_u") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.gt_u)
+  (func (export "i64.le_s") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.le_s)
+  (func (export "i64.le_u") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.le_u)
+  (func (export "i64.ge_s") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.ge_s)
+  (func (export "i64.ge_u") (param i64 i64) (result i32)
+    local.get 0
+    local.get 1
+    i64.ge_u)
+  (func (export "i64.clz") (param i64) (result i64)
+    local.get 0
+    i64.clz)
+  (func (export "i64.ctz") (param i64) (result i64)
+    local.get 0
+    i64.ctz)
+  (func (export "i64.popcnt") (param i64) (result i64)
+    local.get 0
+    i64.popcnt)
+  (func (export "i64.extend8_s") (param i64) (result i64)
+    local.get 0
+    i64.extend8_s)
+  (func (export "i64.extend16_s") (param i64) (result i64)
+    local.get 0
+    i64.extend16_s)
+  (func (export "i64.extend32_s") (param i64) (result i64)
+    local.get 0
+    i64.extend32_s)
+  (func (export "i64.eqz") (param i64) (result i32)
+    local.get 0
+    i64.eqz)
+  (func (export "i32.wrap_i64") (param i64) (result i32)
+    local.get 0
+    i32.wrap_i64)
+  (func (export "i64.extend_i32_s") (param i32) (result i64)
+    local.get 0
+    i64.extend_i32_s)
+  (func (export "i64.extend_i32_u") (param i32) (result i64)
+    local.get 0
+    i64.extend_i32_u)
+
+  (func (export "counter") (result i32)
+    global.get $counter)
+  (func (export "base") (result i64)
+    global.get $base)
+
+  (func (export "switch") (param i32) (result i32)
+    block $d
+      block $c
+        block $b
+          block $a
+            local.get 0
+            br_table $a $b $c $d
+          end
+          i32.const 10
+          return
+        end
+        i32.const 11
+        return
+      end
+      i32.const 12
+      return
+    end
+    i32.const 99)
+
+  (func $factorial (export "factorial") (param i64) (result i64)
+    local.get 0
+    i64.eqz
+    if (result i64)
+      i64.const 1
+    else
+      local.get 0
+      local.get 0
+      i64.const 1
+      i64.sub
+      call $factorial
+      i64.mul
+    end)
+
+  (func (export "sum") (param i32) (result i32) (local $acc i32)
+    block $done
+      loop $next
+        local.get 0
+        i32.eqz
+        br_if $done
+        local.get $acc
+        local.get 0
+        i32.add
+        local.set $acc
+        local.get 0
+        i32.const 1
+        i32.sub
+        local.set 0
+        br $next
+      end
+    end
+    local.get $acc)
+
+  (func (export "select") (param i32) (result i32)
+    i32.const 1
+    i32.const 2
+    local.get 0
+    select)
+
+  (func (export "indirect") (param i32 i32 i32) (result i32)
+    local.get 0
+    local.get 1
+    local.get 2
+    call_indirect (type $binary))
+
+  (func (export "swap_sub") (param i32 i32) (result i32) (local $x i32) (local $y i32)
+    local.get 0
+    local.get 1
+    block (type $swap)
+      local.set $x
+      local.set $y
+      local.get $x
+      local.get $y
+    end
+    i32.sub)
+
+  (func (export "load8_s") (param i32) (result i32)
+    local.get 0
+    i32.load8_s)
+  (func (export "load16_u") (param i32) (result i32)
+    local.get 0
+    i32.load16_u)
+  (func (export "load32_s") (param i32) (result i64)
+    local.get 0
+    i64.load32_s offset=8)
+  (func (export "store") (param i32 i64)
+    local.get 0
+    local.get 1
+    i64.store offset=8)
+  (func (export "size") (result i32)
+    memory.size)
+  (func (export "grow") (param i32) (result i32)
+    local.get 0
+    memory.grow)
+  (func (export "copy") (param i32 i32 i32)
+    local.get 0
+    local.get 1
+    local.get 2
+    memory.copy)
+  (func (export "fill") (param i32 i32 i32)
+    local.get 0
+    local.get 1
+    local.get 2
+    memory.fill)
+
+  (func (export "double") (param i32) (result i32)
+    local.get 0
+    call $double)
+  (func (export "call_back") (param i32) (result i32)
+    local.get 0
+    call $call_back)
+
+  (func (export "unreachable")
+    unreachable)
+  (func $recurse (export "recurse")
+    call $recurse)
+  (func (export "loop")
+    loop $forever
+      br $forever
+    end))
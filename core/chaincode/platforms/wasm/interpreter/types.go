@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interpreter
+
+import "fmt"
+
+// ValueType is the type of a WASM value. Only integer values are
+// supported.
+type ValueType byte
+
+const (
+	// I32 is the type of 32 bit integers
+	I32 ValueType = 0x7f
+	// I64 is the type of 64 bit integers
+	I64 ValueType = 0x7e
+
+	// unknown is the type of values of an unreachable stack, used in the
+	// validation of functions
+	unknown ValueType = 0
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	default:
+		return fmt.Sprintf("0x%x", byte(t))
+	}
+}
+
+// FuncType is the type of a WASM function.
+type FuncType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+// Equal returns whether the function types are the same.
+func (t FuncType) Equal(other FuncType) bool {
+	return equalTypes(t.Params, other.Params) && equalTypes(t.Results, other.Results)
+}
+
+func equalTypes(a, b []ValueType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ExternKind is the kind of an import or export of a module.
+type ExternKind byte
+
+const (
+	FunctionKind ExternKind = 0x00
+	TableKind    ExternKind = 0x01
+	MemoryKind   ExternKind = 0x02
+	GlobalKind   ExternKind = 0x03
+)
+
+func (k ExternKind) String() string {
+	switch k {
+	case FunctionKind:
+		return "function"
+	case TableKind:
+		return "table"
+	case MemoryKind:
+		return "memory"
+	case GlobalKind:
+		return "global"
+	default:
+		return fmt.Sprintf("0x%x", byte(k))
+	}
+}
+
+// Import is an import of a module.
+type Import struct {
+	Module string
+	Name   string
+	Kind   ExternKind
+	// Type is the type of an imported function
+	Type FuncType
+}
+
+// limits are the limits of the size of a memory or a table
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+// global is a global variable of a module
+type global struct {
+	valueType ValueType
+	mutable   bool
+	init      uint64
+}
+
+// export is an export of a module
+type export struct {
+	kind  ExternKind
+	index uint32
+}
+
+// element is an element segment initializing the table of a module
+type element struct {
+	offset uint32
+	funcs  []uint32
+}
+
+// segment is a data segment initializing the memory of a module
+type segment struct {
+	offset uint32
+	init   []byte
+}
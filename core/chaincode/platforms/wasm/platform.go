@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.platform.wasm")
+
+const (
+	// ModuleFile is the name of the file holding the compiled WASM module
+	// of chaincode, in the chaincode directory
+	ModuleFile = "chaincode.wasm"
+
+	// modulePath is the path of the WASM module in the code package
+	modulePath = "src/" + ModuleFile
+)
+
+// Platform for chaincodes compiled to WebAssembly. Such chaincodes are
+// executed in-process by the peer instead of in a container.
+type Platform struct {
+}
+
+// Name returns the name of this platform
+func (p *Platform) Name() string {
+	return pb.ChaincodeSpec_WASM.String()
+}
+
+// ValidatePath validates that the chaincode path is a directory holding
+// the WASM module of the chaincode.
+func (p *Platform) ValidatePath(path string) error {
+	if path == "" {
+		return errors.New("chaincode path cannot be empty")
+	}
+
+	fi, err := os.Stat(filepath.Join(path, ModuleFile))
+	if os.IsNotExist(err) {
+		return errors.Errorf("no %s found in chaincode path %s", ModuleFile, path)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error validating chaincode path")
+	}
+	if !fi.Mode().IsRegular() {
+		return errors.Errorf("%s in chaincode path %s is not a regular file", ModuleFile, path)
+	}
+
+	return nil
+}
+
+// ValidateCodePackage validates that the code package only holds the WASM
+// module of the chaincode and its metadata, and that the module complies
+// with the ABI between the peer and chaincode.
+func (p *Platform) ValidateCodePackage(code []byte) error {
+	if len(code) == 0 {
+		// Nothing to validate if no CodePackage was included
+		return nil
+	}
+
+	re := regexp.MustCompile(`^(/)?META-INF/.*`)
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return errors.Wrap(err, "failure opening codepackage gzip stream")
+	}
+	tr := tar.NewReader(gr)
+
+	var module []byte
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "error reading codepackage")
+		}
+
+		if header.Name != modulePath && !re.MatchString(header.Name) {
+			return errors.Errorf("illegal file detected in payload: \"%s\"", header.Name)
+		}
+		// Acceptable flags:
+		//      ISREG      == 0100000
+		//      -rw-rw-rw- == 0666
+		if header.Mode&^0100666 != 0 {
+			return errors.Errorf("illegal file mode detected for file %s: %o", header.Name, header.Mode)
+		}
+
+		if header.Name == modulePath {
+			module, err = ioutil.ReadAll(tr)
+			if err != nil {
+				return errors.Wrapf(err, "error reading %s", header.Name)
+			}
+		}
+	}
+	if module == nil {
+		return errors.Errorf("no %s found in the code package", ModuleFile)
+	}
+
+	return ValidateModule(module)
+}
+
+// GetDeploymentPayload packages the WASM module of the chaincode as
+// src/chaincode.wasm, along with the metadata found in the META-INF
+// directory of the chaincode path, in .tar.gz format.
+func (p *Platform) GetDeploymentPayload(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("chaincode path cannot be empty")
+	}
+	path = strings.TrimSuffix(path, "/")
+
+	logger.Debugf("Packaging WASM chaincode from path %s", path)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	if err := cutil.WriteFileToPackage(filepath.Join(path, ModuleFile), modulePath, tw); err != nil {
+		return nil, errors.WithMessage(err, "error writing chaincode package contents")
+	}
+	if err := writeMetadata(tw, path); err != nil {
+		return nil, errors.WithMessage(err, "error writing chaincode package contents")
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error writing chaincode package contents")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "error writing chaincode package contents")
+	}
+
+	return payload.Bytes(), nil
+}
+
+// writeMetadata writes the files of the META-INF directory of the chaincode
+// path, if any, to the package.
+func writeMetadata(tw *tar.Writer, path string) error {
+	metadataDir := filepath.Join(path, "META-INF")
+	if _, err := os.Stat(metadataDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.Walk(metadataDir, func(localpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsDir() {
+			return nil
+		}
+
+		packagepath, err := filepath.Rel(path, localpath)
+		if err != nil {
+			return err
+		}
+		packagepath = filepath.ToSlash(packagepath)
+
+		// Hidden files are not supported as metadata, therefore ignore them.
+		if strings.HasPrefix(info.Name(), ".") {
+			logger.Warningf("Ignoring hidden file in metadata directory: %s", packagepath)
+			return nil
+		}
+
+		fileBytes, err := ioutil.ReadFile(localpath)
+		if err != nil {
+			return err
+		}
+		if err := ccmetadata.ValidateMetadataFile(packagepath, fileBytes); err != nil {
+			return err
+		}
+
+		return cutil.WriteBytesToPackage(packagepath, fileBytes, tw)
+	})
+}
+
+// GenerateDockerfile fails as WASM chaincode doesn't run in a container.
+func (p *Platform) GenerateDockerfile() (string, error) {
+	return "", errors.New("WASM chaincode is executed in-process and has no Dockerfile")
+}
+
+// GenerateDockerBuild fails as WASM chaincode doesn't run in a container.
+func (p *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+	return errors.New("WASM chaincode is executed in-process and has no container image")
+}
+
+// GetMetadataProvider fetches metadata provider given deployment spec
+func (p *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
+	return &ccmetadata.TargzMetadataProvider{Code: code}
+}
+
+// ExtractModule extracts the WASM module from the code package of a WASM
+// chaincode.
+func ExtractModule(code []byte) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading as gzip stream")
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error inspecting next tar header")
+		}
+		if header.Name != modulePath {
+			continue
+		}
+
+		module, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s from tar", header.Name)
+		}
+		return module, nil
+	}
+
+	return nil, errors.Errorf("did not find %s inside the code package", ModuleFile)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasm_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = platforms.Platform(&wasm.Platform{})
+
+type packageFile struct {
+	name    string
+	mode    int64
+	content []byte
+}
+
+func codePackage(t *testing.T, files ...packageFile) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{Name: f.name, Size: int64(len(f.content)), Mode: f.mode, Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func packageEntries(t *testing.T, code []byte) []string {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var entries []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		require.NoError(t, err)
+		entries = append(entries, header.Name)
+	}
+}
+
+func readModule(t *testing.T, path string) []byte {
+	module, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return module
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, pb.ChaincodeSpec_WASM.String(), (&wasm.Platform{}).Name())
+}
+
+func TestValidatePath(t *testing.T) {
+	platform := &wasm.Platform{}
+
+	assert.NoError(t, platform.ValidatePath("testdata/chaincode"))
+
+	err := platform.ValidatePath("")
+	assert.EqualError(t, err, "chaincode path cannot be empty")
+
+	err = platform.ValidatePath("testdata/missing")
+	assert.EqualError(t, err, "no chaincode.wasm found in chaincode path testdata/missing")
+
+	tempDir, err := ioutil.TempDir("", "wasm-platform")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, wasm.ModuleFile), 0755))
+	err = platform.ValidatePath(tempDir)
+	assert.EqualError(t, err, "chaincode.wasm in chaincode path "+tempDir+" is not a regular file")
+}
+
+func TestGetDeploymentPayload(t *testing.T) {
+	platform := &wasm.Platform{}
+
+	code, err := platform.GetDeploymentPayload("testdata/chaincode/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"src/chaincode.wasm",
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+	}, packageEntries(t, code))
+	assert.NoError(t, platform.ValidateCodePackage(code))
+
+	module, err := wasm.ExtractModule(code)
+	require.NoError(t, err)
+	assert.Equal(t, readModule(t, "testdata/chaincode/chaincode.wasm"), module)
+
+	metadata, err := platform.GetMetadataProvider(code).GetMetadataAsTarEntries()
+	require.NoError(t, err)
+	assert.NotEmpty(t, metadata)
+
+	_, err = platform.GetDeploymentPayload("")
+	assert.EqualError(t, err, "chaincode path cannot be empty")
+
+	_, err = platform.GetDeploymentPayload("testdata/missing")
+	assert.Contains(t, err.Error(), "error writing chaincode package contents")
+}
+
+func TestValidateCodePackage(t *testing.T) {
+	platform := &wasm.Platform{}
+	module := readModule(t, "testdata/chaincode/chaincode.wasm")
+
+	assert.NoError(t, platform.ValidateCodePackage(nil))
+
+	err := platform.ValidateCodePackage([]byte("garbage"))
+	assert.Contains(t, err.Error(), "failure opening codepackage gzip stream")
+
+	err = platform.ValidateCodePackage(codePackage(t, packageFile{"src/main.rs", 0100644, []byte("fn main() {}")}))
+	assert.EqualError(t, err, `illegal file detected in payload: "src/main.rs"`)
+
+	err = platform.ValidateCodePackage(codePackage(t, packageFile{"src/chaincode.wasm", 0100755, module}))
+	assert.EqualError(t, err, "illegal file mode detected for file src/chaincode.wasm: 100755")
+
+	err = platform.ValidateCodePackage(codePackage(t, packageFile{"META-INF/statedb/couchdb/indexes/index.json", 0100644, []byte("{}")}))
+	assert.EqualError(t, err, "no chaincode.wasm found in the code package")
+
+	err = platform.ValidateCodePackage(codePackage(t, packageFile{"src/chaincode.wasm", 0100644, []byte("\x00asm garbage")}))
+	assert.Contains(t, err.Error(), "invalid WASM module")
+
+	err = platform.ValidateCodePackage(codePackage(t, packageFile{"src/chaincode.wasm", 0100644, module}))
+	assert.NoError(t, err)
+}
+
+func TestValidateModule(t *testing.T) {
+	assert.NoError(t, wasm.ValidateModule(readModule(t, "testdata/chaincode/chaincode.wasm")))
+
+	tests := []struct {
+		module string
+		err    string
+	}{
+		{"wasi", "function proc_exit imported from module wasi_snapshot_preview1, only the fabric module can be imported"},
+		{"unknown", "unknown host function get_time"},
+		{"signature", "host function get_state imported with a wrong signature"},
+		{"nomemory", "chaincode must export its memory as memory"},
+		{"noinvoke", "chaincode must export the invoke function"},
+		{"badinit", "function init exported with a wrong signature"},
+		{"float", "invalid WASM module: code section: function 1: offset 4: floating point instruction 0xbf is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.module, func(t *testing.T) {
+			err := wasm.ValidateModule(readModule(t, filepath.Join("testdata", "invalid", tt.module+".wasm")))
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestGenerateDockerBuild(t *testing.T) {
+	platform := &wasm.Platform{}
+
+	_, err := platform.GenerateDockerfile()
+	assert.EqualError(t, err, "WASM chaincode is executed in-process and has no Dockerfile")
+
+	err = platform.GenerateDockerBuild("testdata/chaincode", nil, nil)
+	assert.EqualError(t, err, "WASM chaincode is executed in-process and has no container image")
+}
+
+func TestExtractModule(t *testing.T) {
+	_, err := wasm.ExtractModule([]byte("garbage"))
+	assert.Contains(t, err.Error(), "error reading as gzip stream")
+
+	_, err = wasm.ExtractModule(codePackage(t, packageFile{"connection.json", 0100644, []byte("{}")}))
+	assert.EqualError(t, err, "did not find chaincode.wasm inside the code package")
+}
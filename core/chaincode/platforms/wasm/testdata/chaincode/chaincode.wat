@@ -0,0 +1,427 @@
+;; Test chaincode for the ABI between the peer and WASM chaincode.
+;; chaincode.wasm is the binary encoding of this module.
+;;
+;; init writes "initialized" to the key "init". invoke dispatches on the
+;; first byte of its first argument:
+;;   p key value                put_state
+;;   g key                      get_state, responds with the value
+;;   d key                      del_state
+;;   r start end                get_state_by_range, responds with the pairs
+;;   P collection key value     put_private_data
+;;   G collection key           get_private_data, responds with the value
+;;   D collection key           del_private_data
+;;   R collection start end     get_private_data_by_range, responds with
+;;                              the pairs
+;;   e name payload name payload  set_event, twice
+;;   t                          responds with the transaction ID
+;;   c                          responds with the channel ID
+;;   x message                  logs the message
+;;   f message                  fails with the message
+;;   m                          grows the memory by 1024 pages, and fails
+;;                              when it can't
+;;   b                          calls get_state with memory out of range
+;;   l                          loops forever
+(module
+  (import "fabric" "get_args" (func $get_args (result i64)))
+  (import "fabric" "get_tx_id" (func $get_tx_id (result i64)))
+  (import "fabric" "get_channel_id" (func $get_channel_id (result i64)))
+  (import "fabric" "get_state" (func $get_state (param i32 i32) (result i64)))
+  (import "fabric" "put_state" (func $put_state (param i32 i32 i32 i32)))
+  (import "fabric" "del_state" (func $del_state (param i32 i32)))
+  (import "fabric" "get_state_by_range" (func $get_state_by_range (param i32 i32 i32 i32) (result i64)))
+  (import "fabric" "get_private_data" (func $get_private_data (param i32 i32 i32 i32) (result i64)))
+  (import "fabric" "put_private_data" (func $put_private_data (param i32 i32 i32 i32 i32 i32)))
+  (import "fabric" "del_private_data" (func $del_private_data (param i32 i32 i32 i32)))
+  (import "fabric" "get_private_data_by_range" (func $get_private_data_by_range (param i32 i32 i32 i32 i32 i32) (result i64)))
+  (import "fabric" "set_event" (func $set_event (param i32 i32 i32 i32)))
+  (import "fabric" "set_response" (func $set_response (param i32 i32)))
+  (import "fabric" "log" (func $log (param i32 i32)))
+
+  (memory (export "memory") 1)
+
+  (data (i32.const 0) "init")
+  (data (i32.const 4) "initialized")
+  (data (i32.const 16) "out of memory")
+  (data (i32.const 32) "unknown function")
+
+  ;; $heap is the offset of the free memory, $args the offset of the
+  ;; arguments of the transaction
+  (global $heap (mut i32) (i32.const 1024))
+  (global $args (mut i32) (i32.const 0))
+
+  ;; alloc is a bump allocator growing the memory when needed
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    (local $offset i32) (local $end i32)
+    global.get $heap
+    local.set $offset
+    local.get $offset
+    local.get $size
+    i32.add
+    local.set $end
+    block $done
+      local.get $end
+      memory.size
+      i32.const 16
+      i32.shl
+      i32.le_u
+      br_if $done
+      local.get $end
+      memory.size
+      i32.const 16
+      i32.shl
+      i32.sub
+      i32.const 16
+      i32.shr_u
+      i32.const 1
+      i32.add
+      memory.grow
+      i32.const -1
+      i32.ne
+      br_if $done
+      unreachable
+    end
+    local.get $end
+    global.set $heap
+    local.get $offset)
+
+  ;; $arg returns the offset of the length prefix of the argument $i
+  (func $arg (param $i i32) (result i32)
+    (local $p i32)
+    global.get $args
+    local.set $p
+    block $found
+      loop $next
+        local.get $i
+        i32.eqz
+        br_if $found
+        local.get $p
+        local.get $p
+        i32.load
+        i32.add
+        i32.const 4
+        i32.add
+        local.set $p
+        local.get $i
+        i32.const 1
+        i32.sub
+        local.set $i
+        br $next
+      end
+    end
+    local.get $p)
+
+  ;; $ptr and $len return the offset and the length of the argument $i
+  (func $ptr (param $i i32) (result i32)
+    local.get $i
+    call $arg
+    i32.const 4
+    i32.add)
+
+  (func $len (param $i i32) (result i32)
+    local.get $i
+    call $arg
+    i32.load)
+
+  ;; $respond sets the data returned by a host function as the response
+  (func $respond (param $data i64)
+    local.get $data
+    i64.const 32
+    i64.shr_u
+    i32.wrap_i64
+    local.get $data
+    i32.wrap_i64
+    call $set_response)
+
+  (func $init (export "init") (result i32)
+    i32.const 0
+    i32.const 4
+    i32.const 4
+    i32.const 11
+    call $put_state
+    i32.const 200)
+
+  (func $invoke (export "invoke") (result i32)
+    (local $op i32)
+    call $get_args
+    i64.const 32
+    i64.shr_u
+    i32.wrap_i64
+    global.set $args
+    i32.const 0
+    call $ptr
+    i32.load8_u
+    local.set $op
+
+    ;; p key value
+    local.get $op
+    i32.const 112
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      call $put_state
+      i32.const 200
+      return
+    end
+
+    ;; g key
+    local.get $op
+    i32.const 103
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      call $get_state
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; d key
+    local.get $op
+    i32.const 100
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      call $del_state
+      i32.const 200
+      return
+    end
+
+    ;; r start end
+    local.get $op
+    i32.const 114
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      call $get_state_by_range
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; P collection key value
+    local.get $op
+    i32.const 80
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      i32.const 3
+      call $ptr
+      i32.const 3
+      call $len
+      call $put_private_data
+      i32.const 200
+      return
+    end
+
+    ;; G collection key
+    local.get $op
+    i32.const 71
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      call $get_private_data
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; D collection key
+    local.get $op
+    i32.const 68
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      call $del_private_data
+      i32.const 200
+      return
+    end
+
+    ;; R collection start end
+    local.get $op
+    i32.const 82
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      i32.const 3
+      call $ptr
+      i32.const 3
+      call $len
+      call $get_private_data_by_range
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; e name payload name payload
+    local.get $op
+    i32.const 101
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      i32.const 2
+      call $ptr
+      i32.const 2
+      call $len
+      call $set_event
+      i32.const 3
+      call $ptr
+      i32.const 3
+      call $len
+      i32.const 4
+      call $ptr
+      i32.const 4
+      call $len
+      call $set_event
+      i32.const 200
+      return
+    end
+
+    ;; t
+    local.get $op
+    i32.const 116
+    i32.eq
+    if
+      call $get_tx_id
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; c
+    local.get $op
+    i32.const 99
+    i32.eq
+    if
+      call $get_channel_id
+      call $respond
+      i32.const 200
+      return
+    end
+
+    ;; x message
+    local.get $op
+    i32.const 120
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      call $log
+      i32.const 200
+      return
+    end
+
+    ;; f message
+    local.get $op
+    i32.const 102
+    i32.eq
+    if
+      i32.const 1
+      call $ptr
+      i32.const 1
+      call $len
+      call $set_response
+      i32.const 500
+      return
+    end
+
+    ;; m
+    local.get $op
+    i32.const 109
+    i32.eq
+    if
+      i32.const 1024
+      memory.grow
+      i32.const -1
+      i32.eq
+      if
+        i32.const 16
+        i32.const 13
+        call $set_response
+        i32.const 500
+        return
+      end
+      i32.const 200
+      return
+    end
+
+    ;; b
+    local.get $op
+    i32.const 98
+    i32.eq
+    if
+      i32.const -16
+      i32.const 1024
+      call $get_state
+      drop
+      i32.const 200
+      return
+    end
+
+    ;; l
+    local.get $op
+    i32.const 108
+    i32.eq
+    if
+      loop $forever
+        br $forever
+      end
+    end
+
+    i32.const 32
+    i32.const 16
+    call $set_response
+    i32.const 400)
+)
@@ -0,0 +1,9 @@
+;; Exports an init function with a wrong signature.
+(module
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $init (export "init") (param $arg i32) (result i32)
+    i32.const 200)
+  (func $invoke (export "invoke") (result i32)
+    i32.const 200))
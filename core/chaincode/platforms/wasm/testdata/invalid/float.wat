@@ -0,0 +1,13 @@
+;; Computes with floating point values, whose NaNs aren't deterministic.
+(module
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $invoke (export "invoke") (result i32)
+    i64.const 0
+    f64.reinterpret_i64
+    i64.const 0
+    f64.reinterpret_i64
+    f64.add
+    drop
+    i32.const 200))
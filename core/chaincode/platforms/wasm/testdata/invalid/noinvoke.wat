@@ -0,0 +1,5 @@
+;; Doesn't export the invoke function.
+(module
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0))
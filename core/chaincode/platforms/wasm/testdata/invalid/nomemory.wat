@@ -0,0 +1,7 @@
+;; Doesn't export its memory.
+(module
+  (memory 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $invoke (export "invoke") (result i32)
+    i32.const 200))
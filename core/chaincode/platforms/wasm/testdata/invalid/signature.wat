@@ -0,0 +1,8 @@
+;; Imports get_state with a wrong signature.
+(module
+  (import "fabric" "get_state" (func $get_state (param i32) (result i64)))
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $invoke (export "invoke") (result i32)
+    i32.const 200))
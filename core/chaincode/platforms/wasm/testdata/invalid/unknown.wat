@@ -0,0 +1,8 @@
+;; Imports a function the host module doesn't have.
+(module
+  (import "fabric" "get_time" (func $get_time (result i64)))
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $invoke (export "invoke") (result i32)
+    i32.const 200))
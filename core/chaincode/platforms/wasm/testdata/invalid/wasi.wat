@@ -0,0 +1,8 @@
+;; Imports a WASI function, which isn't available to chaincode.
+(module
+  (import "wasi_snapshot_preview1" "proc_exit" (func $proc_exit (param i32)))
+  (memory (export "memory") 1)
+  (func $alloc (export "alloc") (param $size i32) (result i32)
+    i32.const 0)
+  (func $invoke (export "invoke") (result i32)
+    i32.const 200))
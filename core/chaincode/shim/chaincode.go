@@ -53,7 +53,7 @@ const (
 type ChaincodeStub struct {
 	TxID                       string
 	ChannelId                  string
-	chaincodeEvent             *pb.ChaincodeEvent
+	chaincodeEvents            []*pb.ChaincodeEvent
 	args                       [][]byte
 	handler                    *Handler
 	signedProposal             *pb.SignedProposal
@@ -66,6 +66,10 @@ type ChaincodeStub struct {
 	binding   []byte
 
 	decorations map[string][]byte
+
+	// writeBatch buffers the writes of the transaction when the peer
+	// supports receiving them in a single message
+	writeBatch []*pb.WriteRecord
 }
 
 // Peer address derived from command line or env var
@@ -442,11 +446,24 @@ func (stub *ChaincodeStub) GetDecorations() map[string][]byte {
 
 // InvokeChaincode documentation can be found in interfaces.go
 func (stub *ChaincodeStub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	return stub.invokeChaincode(chaincodeName, args, channel, false)
+}
+
+// InvokeChaincodeReadOnly documentation can be found in interfaces.go
+func (stub *ChaincodeStub) InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response {
+	return stub.invokeChaincode(chaincodeName, args, channel, true)
+}
+
+func (stub *ChaincodeStub) invokeChaincode(chaincodeName string, args [][]byte, channel string, readOnly bool) pb.Response {
 	// Internally we handle chaincode name as a composite name
 	if channel != "" {
 		chaincodeName = chaincodeName + "/" + channel
 	}
-	return stub.handler.handleInvokeChaincode(chaincodeName, args, stub.ChannelId, stub.TxID)
+	// the called chaincode must observe the writes made so far
+	if err := stub.flushWriteBatch(); err != nil {
+		return Error(err.Error())
+	}
+	return stub.handler.handleInvokeChaincode(chaincodeName, args, readOnly, stub.ChannelId, stub.TxID)
 }
 
 // --------- State functions ----------
@@ -458,8 +475,21 @@ func (stub *ChaincodeStub) GetState(key string) ([]byte, error) {
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultipleStates documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	// Access public data by setting the collection to empty string
+	collection := ""
+	return stub.handler.handleGetStateMultiple(collection, keys, stub.ChannelId, stub.TxID)
+}
+
 // SetStateValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) SetStateValidationParameter(key string, ep []byte) error {
+	if err := stub.flushWriteBatch(); err != nil {
+		return err
+	}
 	return stub.handler.handlePutStateMetadataEntry("", key, stub.validationParameterMetakey, ep, stub.ChannelId, stub.TxID)
 }
 
@@ -482,7 +512,44 @@ func (stub *ChaincodeStub) PutState(key string, value []byte) error {
 	}
 	// Access public data by setting the collection to empty string
 	collection := ""
-	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	return stub.putState(collection, key, value)
+}
+
+// putState sends the write to the peer, or buffers it when the peer
+// supports batched writes
+func (stub *ChaincodeStub) putState(collection string, key string, value []byte) error {
+	if !stub.handler.runtimeParams.GetUseWriteBatch() {
+		return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	}
+	return stub.bufferWrite(&pb.WriteRecord{Collection: collection, Key: key, Value: value})
+}
+
+// delState sends the delete to the peer, or buffers it when the peer
+// supports batched writes
+func (stub *ChaincodeStub) delState(collection string, key string) error {
+	if !stub.handler.runtimeParams.GetUseWriteBatch() {
+		return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	}
+	return stub.bufferWrite(&pb.WriteRecord{Collection: collection, Key: key, IsDelete: true})
+}
+
+func (stub *ChaincodeStub) bufferWrite(record *pb.WriteRecord) error {
+	stub.writeBatch = append(stub.writeBatch, record)
+	maxSize := stub.handler.runtimeParams.GetMaxSizeWriteBatch()
+	if maxSize > 0 && uint32(len(stub.writeBatch)) >= maxSize {
+		return stub.flushWriteBatch()
+	}
+	return nil
+}
+
+// flushWriteBatch sends the buffered writes to the peer
+func (stub *ChaincodeStub) flushWriteBatch() error {
+	if len(stub.writeBatch) == 0 {
+		return nil
+	}
+	records := stub.writeBatch
+	stub.writeBatch = nil
+	return stub.handler.handlePutStateMultiple(records, stub.ChannelId, stub.TxID)
 }
 
 func (stub *ChaincodeStub) createStateQueryIterator(response *pb.QueryResponse) *StateQueryIterator {
@@ -508,7 +575,7 @@ func (stub *ChaincodeStub) GetQueryResult(query string) (StateQueryIteratorInter
 func (stub *ChaincodeStub) DelState(key string) error {
 	// Access public data by setting the collection to empty string
 	collection := ""
-	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	return stub.delState(collection, key)
 }
 
 //  ---------  private state functions  ---------
@@ -521,6 +588,17 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetMultiplePrivateData documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return stub.handler.handleGetStateMultiple(collection, keys, stub.ChannelId, stub.TxID)
+}
+
 // GetPrivateDataHash documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
 	if collection == "" {
@@ -537,7 +615,7 @@ func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value [
 	if key == "" {
 		return fmt.Errorf("key must not be an empty string")
 	}
-	return stub.handler.handlePutState(collection, key, value, stub.ChannelId, stub.TxID)
+	return stub.putState(collection, key, value)
 }
 
 // DelPrivateData documentation can be found in interfaces.go
@@ -545,7 +623,7 @@ func (stub *ChaincodeStub) DelPrivateData(collection string, key string) error {
 	if collection == "" {
 		return fmt.Errorf("collection must not be an empty string")
 	}
-	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
+	return stub.delState(collection, key)
 }
 
 // GetPrivateDataByRange documentation can be found in interfaces.go
@@ -617,6 +695,9 @@ func (stub *ChaincodeStub) GetPrivateDataValidationParameter(collection, key str
 
 // SetPrivateDataValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	if err := stub.flushWriteBatch(); err != nil {
+		return err
+	}
 	return stub.handler.handlePutStateMetadataEntry(collection, key, stub.validationParameterMetakey, ep, stub.ChannelId, stub.TxID)
 }
 
@@ -1028,10 +1109,28 @@ func (stub *ChaincodeStub) SetEvent(name string, payload []byte) error {
 	if name == "" {
 		return errors.New("event name can not be nil string")
 	}
-	stub.chaincodeEvent = &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	stub.chaincodeEvents = append(stub.chaincodeEvents, &pb.ChaincodeEvent{EventName: name, Payload: payload})
 	return nil
 }
 
+// chaincodeEvent returns the last event set by the chaincode, which is the
+// only event of the transaction for peers unaware of multiple events
+func (stub *ChaincodeStub) chaincodeEvent() *pb.ChaincodeEvent {
+	if len(stub.chaincodeEvents) == 0 {
+		return nil
+	}
+	return stub.chaincodeEvents[len(stub.chaincodeEvents)-1]
+}
+
+// multipleEvents returns the events set by the chaincode when it set more
+// than one
+func (stub *ChaincodeStub) multipleEvents() []*pb.ChaincodeEvent {
+	if len(stub.chaincodeEvents) < 2 {
+		return nil
+	}
+	return stub.chaincodeEvents
+}
+
 // ------------- Logging Control and Chaincode Loggers ---------------
 
 // As independent programs, Go language chaincodes can use any logging
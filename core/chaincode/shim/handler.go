@@ -52,6 +52,8 @@ type Handler struct {
 	// Multiple queries (and one transaction) with different txids can be executing in parallel for this chaincode
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
+	// runtimeParams are the capabilities advertised by the peer upon registration
+	runtimeParams *pb.ChaincodeAdditionalParams
 }
 
 func shorttxid(txid string) string {
@@ -207,7 +209,7 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 		// Create the ChaincodeStub which the chaincode can use to callback
 		stub := new(ChaincodeStub)
 		err := stub.init(handler, msg.ChannelId, msg.Txid, input, msg.Proposal)
-		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s] Init get error response. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent(), "[%s] Init get error response. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 			return
 		}
 		res := handler.cc.Init(stub)
@@ -215,21 +217,27 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 
 		if res.Status >= ERROR {
 			err = errors.New(res.Message)
-			if nextStateMsg = errFunc(err, []byte(res.Message), stub.chaincodeEvent, "[%s] Init get error response. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			if nextStateMsg = errFunc(err, []byte(res.Message), stub.chaincodeEvent(), "[%s] Init get error response. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 				return
 			}
 		}
 
+		// Send the writes buffered during Init to the peer
+		err = stub.flushWriteBatch()
+		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent(), "[%s] Init failed to send buffered writes. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+			return
+		}
+
 		resBytes, err := proto.Marshal(&res)
 		if err != nil {
 			payload := []byte(err.Error())
 			chaincodeLogger.Errorf("[%s] Init marshal response error [%s]. Sending %s", shorttxid(msg.Txid), err, pb.ChaincodeMessage_ERROR)
-			nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: payload, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent}
+			nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: payload, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent()}
 			return
 		}
 
 		// Send COMPLETED message to chaincode support and change state
-		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChannelId: stub.ChannelId}
+		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent(), ChaincodeEvents: stub.multipleEvents(), ChannelId: stub.ChannelId}
 		chaincodeLogger.Debugf("[%s] Init succeeded. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_COMPLETED)
 	}()
 }
@@ -267,20 +275,30 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan er
 		// Create the ChaincodeStub which the chaincode can use to callback
 		stub := new(ChaincodeStub)
 		err := stub.init(handler, msg.ChannelId, msg.Txid, input, msg.Proposal)
-		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s] Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+		if nextStateMsg = errFunc(err, stub.chaincodeEvent(), "[%s] Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 			return
 		}
 		res := handler.cc.Invoke(stub)
 
+		// Send the writes buffered during the transaction to the peer. The
+		// writes of a failed transaction are discarded by the endorser, so
+		// there's no need to send them.
+		if res.Status < ERROR {
+			err = stub.flushWriteBatch()
+			if nextStateMsg = errFunc(err, stub.chaincodeEvent(), "[%s] Transaction failed to send buffered writes. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+				return
+			}
+		}
+
 		// Endorser will handle error contained in Response.
 		resBytes, err := proto.Marshal(&res)
-		if nextStateMsg = errFunc(err, stub.chaincodeEvent, "[%s] Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
+		if nextStateMsg = errFunc(err, stub.chaincodeEvent(), "[%s] Transaction execution failed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 			return
 		}
 
 		// Send COMPLETED message to chaincode support and change state
 		chaincodeLogger.Debugf("[%s] Transaction completed. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_COMPLETED)
-		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent, ChannelId: stub.ChannelId}
+		nextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Payload: resBytes, Txid: msg.Txid, ChaincodeEvent: stub.chaincodeEvent(), ChaincodeEvents: stub.multipleEvents(), ChannelId: stub.ChannelId}
 	}()
 }
 
@@ -299,7 +317,6 @@ func (handler *Handler) callPeerWithChaincodeMsg(msg *pb.ChaincodeMessage, chann
 	return handler.sendReceive(msg, respChan)
 }
 
-// TODO: Implement a method to get multiple keys at a time [FAB-1244]
 // handleGetState communicates with the peer to fetch the requested state information from the ledger.
 func (handler *Handler) handleGetState(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_STATE
@@ -329,6 +346,85 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetStateMultiple communicates with the peer to fetch the values of
+// multiple keys from the ledger. When the peer doesn't support fetching
+// multiple keys at a time, the keys are fetched one by one.
+func (handler *Handler) handleGetStateMultiple(collection string, keys []string, channelId string, txid string) ([][]byte, error) {
+	if !handler.runtimeParams.GetUseGetMultipleKeys() {
+		values := make([][]byte, 0, len(keys))
+		for _, key := range keys {
+			value, err := handler.handleGetState(collection, key, channelId, txid)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	maxSize := int(handler.runtimeParams.GetMaxSizeGetMultipleKeys())
+	if maxSize <= 0 {
+		maxSize = len(keys)
+	}
+
+	values := make([][]byte, 0, len(keys))
+	for start := 0; start < len(keys); start += maxSize {
+		end := start + maxSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		result, err := handler.sendGetStateMultiple(collection, keys[start:end], channelId, txid)
+		if err != nil {
+			return nil, err
+		}
+		if len(result) != end-start {
+			return nil, errors.Errorf("[%s] GetStateMultiple received %d values for %d keys", shorttxid(txid), len(result), end-start)
+		}
+		values = append(values, result...)
+	}
+
+	return values, nil
+}
+
+func (handler *Handler) sendGetStateMultiple(collection string, keys []string, channelId string, txid string) ([][]byte, error) {
+	// Construct payload for GET_STATE_MULTIPLE
+	payloadBytes, _ := proto.Marshal(&pb.GetStateMultiple{Collection: collection, Keys: keys})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_MULTIPLE)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_STATE_MULTIPLE", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetStateMultiple received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		result := &pb.GetStateMultipleResult{}
+		if err := proto.Unmarshal(responseMsg.Payload, result); err != nil {
+			chaincodeLogger.Errorf("[%s] GetStateMultiple could not unmarshal result", shorttxid(responseMsg.Txid))
+			return nil, errors.Wrap(err, "could not unmarshal get state multiple response")
+		}
+		// a missing key is returned by the peer as an empty value
+		for i, value := range result.Values {
+			if len(value) == 0 {
+				result.Values[i] = nil
+			}
+		}
+		return result.Values, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetStateMultiple received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetPrivateDataHash(collection string, key string, channelId string, txid string) ([]byte, error) {
 	// Construct payload for GET_PRIVATE_DATA_HASH
 	payloadBytes, _ := proto.Marshal(&pb.GetState{Collection: collection, Key: key})
@@ -396,7 +492,6 @@ func (handler *Handler) handleGetStateMetadata(collection string, key string, ch
 	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
-// TODO: Implement a method to set multiple keys at a time [FAB-1244]
 // handlePutState communicates with the peer to put state information into the ledger.
 func (handler *Handler) handlePutState(collection string, key string, value []byte, channelId string, txid string) error {
 	// Construct payload for PUT_STATE
@@ -428,6 +523,38 @@ func (handler *Handler) handlePutState(collection string, key string, value []by
 	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handlePutStateMultiple communicates with the peer to apply multiple writes
+// to the ledger in a single message.
+func (handler *Handler) handlePutStateMultiple(records []*pb.WriteRecord, channelId string, txid string) error {
+	// Construct payload for PUT_STATE_MULTIPLE
+	payloadBytes, _ := proto.Marshal(&pb.PutStateMultiple{Records: records})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s] Sending %s with %d records", shorttxid(msg.Txid), pb.ChaincodeMessage_PUT_STATE_MULTIPLE, len(records))
+
+	// Execute the request and get response
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("[%s] error sending PUT_STATE_MULTIPLE", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully updated state", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handlePutStateMetadataEntry(collection string, key string, metakey string, metadata []byte, channelID string, txID string) error {
 	// Construct payload for PUT_STATE_METADATA
 	md := &pb.StateMetadata{Metakey: metakey, Value: metadata}
@@ -713,7 +840,9 @@ func (handler *Handler) createResponse(status int32, payload []byte) pb.Response
 }
 
 // handleInvokeChaincode communicates with the peer to invoke another chaincode.
-func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byte, channelId string, txid string) pb.Response {
+// readOnly asks the peer to make the invocation read-only if the chaincode
+// is on another channel.
+func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byte, readOnly bool, channelId string, txid string) pb.Response {
 	//we constructed a valid object. No need to check for error
 	payloadBytes, _ := proto.Marshal(&pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: chaincodeName}, Input: &pb.ChaincodeInput{Args: args}})
 
@@ -727,7 +856,7 @@ func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byt
 	defer handler.deleteChannel(channelId, txid)
 
 	// Send INVOKE_CHAINCODE message to peer chaincode support
-	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INVOKE_CHAINCODE, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INVOKE_CHAINCODE, Payload: payloadBytes, Txid: txid, ChannelId: channelId, ReadOnly: readOnly}
 	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_INVOKE_CHAINCODE)
 
 	var responseMsg pb.ChaincodeMessage
@@ -819,6 +948,13 @@ func (handler *Handler) handleEstablished(msg *pb.ChaincodeMessage, errc chan er
 //handle created state
 func (handler *Handler) handleCreated(msg *pb.ChaincodeMessage, errc chan error) error {
 	if msg.Type == pb.ChaincodeMessage_REGISTERED {
+		// peers which don't support the additional parameters send an
+		// empty payload, which leaves all of them disabled
+		runtimeParams := &pb.ChaincodeAdditionalParams{}
+		if err := proto.Unmarshal(msg.Payload, runtimeParams); err != nil {
+			return errors.Wrapf(err, "[%s] error unmarshaling additional parameters of %s", msg.Txid, msg.Type)
+		}
+		handler.runtimeParams = runtimeParams
 		handler.state = established
 		return nil
 	}
@@ -75,6 +75,15 @@ type ChaincodeStubInterface interface {
 	// If `channel` is empty, the caller's channel is assumed.
 	InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response
 
+	// InvokeChaincodeReadOnly calls the specified chaincode like
+	// InvokeChaincode, except that a called chaincode on a different channel
+	// is read-only: its PutState and DelState calls fail, and so does the
+	// invocation, instead of having no effect. The endorsing peer may then
+	// record what the called chaincode read in the transaction for auditing.
+	// A called chaincode on the same channel is invoked as with
+	// InvokeChaincode.
+	InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response
+
 	// GetState returns the value of the specified `key` from the
 	// ledger. Note that GetState doesn't read data from the writeset, which
 	// has not been committed to the ledger. In other words, GetState doesn't
@@ -82,6 +91,14 @@ type ChaincodeStubInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetMultipleStates returns the values of the specified `keys` from the
+	// ledger, in the order of the keys. When the peer supports it, the keys
+	// are fetched in a single round trip. Like GetState, GetMultipleStates
+	// doesn't consider data modified by PutState that has not been committed.
+	// A nil value is returned for the keys which don't exist in the state
+	// database.
+	GetMultipleStates(keys ...string) ([][]byte, error)
+
 	// PutState puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal. PutState doesn't effect the ledger
 	// until the transaction is validated and successfully committed.
@@ -90,6 +107,10 @@ type ChaincodeStubInterface interface {
 	// composite keys, which internally get prefixed with 0x00 as composite
 	// key namespace. In addition, if using CouchDB, keys can only contain
 	// valid UTF-8 strings and cannot begin with an underscore ("_").
+	// When the peer supports it, the writes of the transaction are buffered
+	// and sent to the peer together at the end of the transaction, in which
+	// case an error of the write fails the transaction instead of being
+	// returned by PutState.
 	PutState(key string, value []byte) error
 
 	// DelState records the specified `key` to be deleted in the writeset of
@@ -240,6 +261,13 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetMultiplePrivateData returns the values of the specified `keys` from
+	// the specified `collection`, in the order of the keys. When the peer
+	// supports it, the keys are fetched in a single round trip. Like
+	// GetPrivateData, GetMultiplePrivateData doesn't consider data modified
+	// by PutPrivateData that has not been committed.
+	GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error)
+
 	// GetPrivateDataHash returns the hash of the value of the specified `key` from the specified
 	// `collection`
 	GetPrivateDataHash(collection, key string) ([]byte, error)
@@ -346,7 +374,9 @@ type ChaincodeStubInterface interface {
 	// SetEvent allows the chaincode to set an event on the response to the
 	// proposal to be included as part of a transaction. The event will be
 	// available within the transaction in the committed block regardless of the
-	// validity of the transaction.
+	// validity of the transaction. Each call adds an event to the transaction,
+	// in order; peers which do not support multiple events only keep the last
+	// one.
 	SetEvent(name string, payload []byte) error
 }
 
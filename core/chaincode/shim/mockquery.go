@@ -0,0 +1,345 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+// mockQuery is the subset of a CouchDB Mango query supported by the
+// MockStub. The selector supports field matching with dotted field names
+// for nested fields, the $eq, $ne, $gt, $gte, $lt, $lte, $in, $nin, $exists,
+// $regex and $size condition operators and the $and, $or, $nor and $not
+// combination operators. The results can be sorted, skipped and limited.
+type mockQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Sort     []interface{}          `json:"sort"`
+	Limit    int                    `json:"limit"`
+	Skip     int                    `json:"skip"`
+}
+
+// sortField is a field the query results are sorted by
+type sortField struct {
+	field string
+	desc  bool
+}
+
+// queryResult is a record matching the query along with its parsed value
+type queryResult struct {
+	kv  *queryresult.KV
+	doc interface{}
+}
+
+func parseMockQuery(query string) (*mockQuery, error) {
+	q := &mockQuery{}
+	if err := json.Unmarshal([]byte(query), q); err != nil {
+		return nil, errors.Wrap(err, "invalid query")
+	}
+	if q.Selector == nil {
+		return nil, errors.New("invalid query: selector is missing")
+	}
+	if q.Limit < 0 || q.Skip < 0 {
+		return nil, errors.New("invalid query: limit and skip must not be negative")
+	}
+	return q, nil
+}
+
+func (q *mockQuery) sortFields() ([]sortField, error) {
+	var fields []sortField
+	for _, s := range q.Sort {
+		switch s := s.(type) {
+		case string:
+			fields = append(fields, sortField{field: s})
+		case map[string]interface{}:
+			if len(s) != 1 {
+				return nil, errors.New("invalid sort: each sort field must have a single direction")
+			}
+			for field, direction := range s {
+				switch direction {
+				case "asc":
+					fields = append(fields, sortField{field: field})
+				case "desc":
+					fields = append(fields, sortField{field: field, desc: true})
+				default:
+					return nil, errors.Errorf("invalid sort direction %v for field %s", direction, field)
+				}
+			}
+		default:
+			return nil, errors.Errorf("invalid sort field %v", s)
+		}
+	}
+	return fields, nil
+}
+
+// execute returns the records of kvs which match the query, in the order
+// requested by the query. Records whose value is not a JSON object never
+// match, like in CouchDB.
+func (q *mockQuery) execute(kvs []*queryresult.KV) ([]*queryresult.KV, error) {
+	fields, err := q.sortFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*queryResult
+	for _, kv := range kvs {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(kv.Value, &doc); err != nil {
+			continue
+		}
+		matched, err := matchSelector(q.Selector, doc)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			results = append(results, &queryResult{kv: kv, doc: doc})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		for _, f := range fields {
+			vi, _ := lookupField(results[i].doc, f.field)
+			vj, _ := lookupField(results[j].doc, f.field)
+			c, ok := compareValues(vi, vj)
+			if !ok || c == 0 {
+				continue
+			}
+			if f.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	if q.Skip >= len(results) {
+		return nil, nil
+	}
+	results = results[q.Skip:]
+	if q.Limit > 0 && q.Limit < len(results) {
+		results = results[:q.Limit]
+	}
+
+	matches := make([]*queryresult.KV, 0, len(results))
+	for _, r := range results {
+		matches = append(matches, r.kv)
+	}
+	return matches, nil
+}
+
+// matchSelector reports whether the document matches all the conditions
+// of the selector
+func matchSelector(selector map[string]interface{}, doc interface{}) (bool, error) {
+	for field, condition := range selector {
+		var matched bool
+		var err error
+		if strings.HasPrefix(field, "$") {
+			matched, err = matchCombination(field, condition, doc)
+		} else {
+			value, found := lookupField(doc, field)
+			matched, err = matchCondition(condition, value, found)
+		}
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchCombination(operator string, operand interface{}, doc interface{}) (bool, error) {
+	if operator == "$not" {
+		selector, ok := operand.(map[string]interface{})
+		if !ok {
+			return false, errors.New("invalid query: $not requires an object")
+		}
+		matched, err := matchSelector(selector, doc)
+		return !matched, err
+	}
+
+	selectors, ok := operand.([]interface{})
+	if !ok {
+		return false, errors.Errorf("invalid query: %s requires an array", operator)
+	}
+	var matches int
+	for _, s := range selectors {
+		selector, ok := s.(map[string]interface{})
+		if !ok {
+			return false, errors.Errorf("invalid query: %s requires an array of objects", operator)
+		}
+		matched, err := matchSelector(selector, doc)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			matches++
+		}
+	}
+
+	switch operator {
+	case "$and":
+		return matches == len(selectors), nil
+	case "$or":
+		return matches > 0, nil
+	case "$nor":
+		return matches == 0, nil
+	default:
+		return false, errors.Errorf("invalid query: unsupported operator %s", operator)
+	}
+}
+
+// matchCondition reports whether the value of a field matches the
+// condition of the selector for that field
+func matchCondition(condition interface{}, value interface{}, found bool) (bool, error) {
+	operators, ok := condition.(map[string]interface{})
+	if !ok {
+		// implicit equality
+		return found && reflect.DeepEqual(condition, value), nil
+	}
+	if !hasOperators(operators) {
+		// a nested selector applies to the sub-document
+		if !found {
+			return false, nil
+		}
+		return matchSelector(operators, value)
+	}
+
+	for operator, operand := range operators {
+		matched, err := matchOperator(operator, operand, value, found)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func hasOperators(condition map[string]interface{}) bool {
+	for key := range condition {
+		if strings.HasPrefix(key, "$") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOperator(operator string, operand interface{}, value interface{}, found bool) (bool, error) {
+	switch operator {
+	case "$exists":
+		exists, ok := operand.(bool)
+		if !ok {
+			return false, errors.New("invalid query: $exists requires a boolean")
+		}
+		return found == exists, nil
+	case "$eq":
+		return found && reflect.DeepEqual(operand, value), nil
+	case "$ne":
+		return !found || !reflect.DeepEqual(operand, value), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		if !found {
+			return false, nil
+		}
+		c, ok := compareValues(value, operand)
+		if !ok {
+			return false, nil
+		}
+		switch operator {
+		case "$gt":
+			return c > 0, nil
+		case "$gte":
+			return c >= 0, nil
+		case "$lt":
+			return c < 0, nil
+		default:
+			return c <= 0, nil
+		}
+	case "$in", "$nin":
+		candidates, ok := operand.([]interface{})
+		if !ok {
+			return false, errors.Errorf("invalid query: %s requires an array", operator)
+		}
+		in := false
+		for _, candidate := range candidates {
+			if found && reflect.DeepEqual(candidate, value) {
+				in = true
+				break
+			}
+		}
+		if operator == "$in" {
+			return in, nil
+		}
+		return !in, nil
+	case "$regex":
+		pattern, ok := operand.(string)
+		if !ok {
+			return false, errors.New("invalid query: $regex requires a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid query")
+		}
+		s, ok := value.(string)
+		return found && ok && re.MatchString(s), nil
+	case "$size":
+		size, ok := operand.(float64)
+		if !ok {
+			return false, errors.New("invalid query: $size requires a number")
+		}
+		array, ok := value.([]interface{})
+		return found && ok && float64(len(array)) == size, nil
+	default:
+		return false, errors.Errorf("invalid query: unsupported operator %s", operator)
+	}
+}
+
+// lookupField returns the value of the field of the document, where the
+// field name uses dots to refer to the fields of nested objects
+func lookupField(doc interface{}, field string) (interface{}, bool) {
+	value := doc
+	for _, name := range strings.Split(field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// compareValues compares two numbers or two strings. The second return
+// value is false when the values can't be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case string:
+		b, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(a, b), true
+	default:
+		return 0, false
+	}
+}
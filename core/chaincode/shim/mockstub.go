@@ -11,6 +11,7 @@ package shim
 import (
 	"container/list"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
@@ -60,13 +61,32 @@ type MockStub struct {
 
 	PvtState map[string]map[string][]byte
 
+	// PvtStateHashes keeps the hashes of the private data, first map index is
+	// the collection, second map index is the key. It is maintained along with
+	// PvtState and can be populated directly to mock the private data of
+	// collections this peer is not a member of.
+	PvtStateHashes map[string]map[string][]byte
+
 	// stores per-key endorsement policy, first map index is the collection, second map index is the key
 	EndorsementPolicies map[string]map[string][]byte
 
+	// History keeps the modifications of each key of the public state, oldest first
+	History map[string][]*queryresult.KeyModification
+
 	// channel to store ChaincodeEvents
 	ChaincodeEventsChannel chan *pb.ChaincodeEvent
 
 	Decorations map[string][]byte
+
+	// Creator is the serialized identity returned by GetCreator
+	Creator []byte
+
+	// TransientMap is the transient data returned by GetTransient
+	TransientMap map[string][]byte
+
+	// the events set by the current transaction, which are delivered to
+	// ChaincodeEventsChannel when the transaction ends
+	chaincodeEvents []*pb.ChaincodeEvent
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -108,10 +128,16 @@ func (stub *MockStub) MockTransactionStart(txid string) {
 	stub.TxID = txid
 	stub.setSignedProposal(&pb.SignedProposal{})
 	stub.setTxTimestamp(util.CreateUtcTimestamp())
+	stub.chaincodeEvents = nil
 }
 
-// End a mocked transaction, clearing the UUID.
+// End a mocked transaction, clearing the UUID and delivering the events set
+// by the transaction to ChaincodeEventsChannel, in order.
 func (stub *MockStub) MockTransactionEnd(uuid string) {
+	for _, event := range stub.chaincodeEvents {
+		stub.ChaincodeEventsChannel <- event
+	}
+	stub.chaincodeEvents = nil
 	stub.signedProposal = nil
 	stub.TxID = ""
 }
@@ -124,23 +150,38 @@ func (stub *MockStub) MockPeerChaincode(invokableChaincodeName string, otherStub
 }
 
 // Initialise this chaincode,  also starts and ends a transaction.
+// The changes of the transaction are rolled back if Init returns an error.
 func (stub *MockStub) MockInit(uuid string, args [][]byte) pb.Response {
 	stub.args = args
 	stub.MockTransactionStart(uuid)
+	snapshot := stub.snapshot()
 	res := stub.cc.Init(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(uuid, res, snapshot)
 	return res
 }
 
 // Invoke this chaincode, also starts and ends a transaction.
+// The changes of the transaction are rolled back if Invoke returns an error.
 func (stub *MockStub) MockInvoke(uuid string, args [][]byte) pb.Response {
 	stub.args = args
 	stub.MockTransactionStart(uuid)
+	snapshot := stub.snapshot()
 	res := stub.cc.Invoke(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(uuid, res, snapshot)
 	return res
 }
 
+// endTransaction ends the transaction, rolling back its changes and
+// discarding its events if the chaincode returned an error
+func (stub *MockStub) endTransaction(uuid string, res pb.Response, snapshot *mockState) {
+	if res.Status >= ERROR {
+		mockLogger.Debug("MockStub", stub.Name, "Rolling back transaction", uuid, "with status", res.Status)
+		stub.restore(snapshot)
+		stub.chaincodeEvents = nil
+	}
+	stub.MockTransactionEnd(uuid)
+}
+
 func (stub *MockStub) GetDecorations() map[string][]byte {
 	return stub.Decorations
 }
@@ -150,12 +191,17 @@ func (stub *MockStub) MockInvokeWithSignedProposal(uuid string, args [][]byte, s
 	stub.args = args
 	stub.MockTransactionStart(uuid)
 	stub.signedProposal = sp
+	snapshot := stub.snapshot()
 	res := stub.cc.Invoke(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(uuid, res, snapshot)
 	return res
 }
 
 func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
 	m, in := stub.PvtState[collection]
 
 	if !in {
@@ -165,11 +211,49 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 	return m[key], nil
 }
 
+func (stub *MockStub) GetMultiplePrivateData(collection string, keys ...string) ([][]byte, error) {
+	var values [][]byte
+	for _, key := range keys {
+		value, err := stub.GetPrivateData(collection, key)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// GetPrivateDataHash returns the hash of the private data, which is
+// available even when the private data itself is not.
 func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+
+	m, in := stub.PvtStateHashes[collection]
+
+	if !in {
+		return nil, nil
+	}
+
+	return m[key], nil
 }
 
+// PutPrivateData writes the specified `value` and `key` into the collection
+// and keeps track of the hash of the value.
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+
+	// If the value is nil or empty, delete the key
+	if len(value) == 0 {
+		return stub.DelPrivateData(collection, key)
+	}
+
 	m, in := stub.PvtState[collection]
 	if !in {
 		stub.PvtState[collection] = make(map[string][]byte)
@@ -178,26 +262,66 @@ func (stub *MockStub) PutPrivateData(collection string, key string, value []byte
 
 	m[key] = value
 
+	hashes, in := stub.PvtStateHashes[collection]
+	if !in {
+		hashes = make(map[string][]byte)
+		stub.PvtStateHashes[collection] = hashes
+	}
+	hashes[key] = util.ComputeSHA256(value)
+
 	return nil
 }
 
+// DelPrivateData removes the specified `key`, its hash and its endorsement
+// policy from the collection.
 func (stub *MockStub) DelPrivateData(collection string, key string) error {
-	return errors.New("Not Implemented")
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+
+	delete(stub.PvtState[collection], key)
+	delete(stub.PvtStateHashes[collection], key)
+	delete(stub.EndorsementPolicies[collection], key)
+
+	return nil
 }
 
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return &mockQueryIterator{results: stub.privateDataRange(collection, startKey, endKey)}, nil
 }
 
 func (stub *MockStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, attributes []string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &mockQueryIterator{results: stub.privateDataRange(collection, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))}, nil
 }
 
+// GetPrivateDataQueryResult performs a rich query against the collection.
+// The query supports a subset of the CouchDB Mango query syntax, see
+// GetQueryResult.
 func (stub *MockStub) GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	results, err := executeQuery(query, stub.privateDataRange(collection, "", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &mockQueryIterator{results: results}, nil
 }
 
 // GetState retrieves the value for a given key from the ledger
@@ -207,6 +331,16 @@ func (stub *MockStub) GetState(key string) ([]byte, error) {
 	return value, nil
 }
 
+// GetMultipleStates retrieves the values of the specified `keys` from the ledger.
+func (stub *MockStub) GetMultipleStates(keys ...string) ([][]byte, error) {
+	var values [][]byte
+	for _, key := range keys {
+		value, _ := stub.GetState(key)
+		values = append(values, value)
+	}
+	return values, nil
+}
+
 // PutState writes the specified `value` and `key` into the ledger.
 func (stub *MockStub) PutState(key string, value []byte) error {
 	if stub.TxID == "" {
@@ -223,6 +357,7 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 
 	mockLogger.Debug("MockStub", stub.Name, "Putting", key, value)
 	stub.State[key] = value
+	stub.recordHistory(key, value, false)
 
 	// insert key into ordered list of keys
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
@@ -260,7 +395,11 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 // DelState removes the specified `key` and its value from the ledger.
 func (stub *MockStub) DelState(key string) error {
 	mockLogger.Debug("MockStub", stub.Name, "Deleting", key, stub.State[key])
+	if _, ok := stub.State[key]; ok {
+		stub.recordHistory(key, nil, true)
+	}
 	delete(stub.State, key)
+	delete(stub.EndorsementPolicies[""], key)
 
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
 		if strings.Compare(key, elem.Value.(string)) == 0 {
@@ -279,21 +418,29 @@ func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIterat
 }
 
 // GetQueryResult function can be invoked by a chaincode to perform a
-// rich query against state database.  Only supported by state database implementations
-// that support rich query.  The query string is in the syntax of the underlying
-// state database. An iterator is returned which can be used to iterate (next) over
-// the query result set
+// rich query against state database. The mock supports a subset of the
+// CouchDB Mango query syntax: a selector with field matching, the $eq, $ne,
+// $gt, $gte, $lt, $lte, $in, $nin, $exists, $regex, $size, $and, $or, $nor
+// and $not operators, as well as sort, skip and limit. An iterator is
+// returned which can be used to iterate (next) over the query result set
 func (stub *MockStub) GetQueryResult(query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("not implemented")
+	results, err := executeQuery(query, stub.stateRange("", ""))
+	if err != nil {
+		return nil, err
+	}
+	return &mockQueryIterator{results: results}, nil
 }
 
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
-// key values across time. GetHistoryForKey is intended to be used for read-only queries.
+// key values across time, most recent first. GetHistoryForKey is intended to be
+// used for read-only queries.
 func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	return nil, errors.New("not implemented")
+	history := stub.History[key]
+	modifications := make([]*queryresult.KeyModification, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		modifications = append(modifications, history[i])
+	}
+	return &mockHistoryQueryIterator{modifications: modifications}, nil
 }
 
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
@@ -322,19 +469,36 @@ func (stub *MockStub) SplitCompositeKey(compositeKey string) (string, []string,
 	return splitCompositeKey(compositeKey)
 }
 
+// GetStateByRangeWithPagination returns a page of the keys in the range. The
+// bookmark is the key the page starts at, and the returned bookmark is the key
+// the next page starts at, or empty when there are no more keys.
 func (stub *MockStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+	return paginate(stub.stateRange(startKey, endKey), pageSize, bookmark)
 }
 
 func (stub *MockStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
 	pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(stub.stateRange(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue)), pageSize, bookmark)
 }
 
 func (stub *MockStub) GetQueryResultWithPagination(query string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	results, err := executeQuery(query, stub.stateRange("", ""))
+	if err != nil {
+		return nil, nil, err
+	}
+	return paginate(results, pageSize, bookmark)
 }
 
 // InvokeChaincode calls a peered chaincode.
@@ -347,7 +511,10 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 		chaincodeName = chaincodeName + "/" + channel
 	}
 	// TODO "args" here should possibly be a serialized pb.ChaincodeInput
-	otherStub := stub.Invokables[chaincodeName]
+	otherStub, ok := stub.Invokables[chaincodeName]
+	if !ok {
+		return Error(fmt.Sprintf("chaincode %s is not registered with the MockStub", chaincodeName))
+	}
 	mockLogger.Debug("MockStub", stub.Name, "Invoking peer chaincode", otherStub.Name, args)
 	//	function, strings := getFuncArgs(args)
 	res := otherStub.MockInvoke(stub.TxID, args)
@@ -355,14 +522,20 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
-// Not implemented
+// InvokeChaincodeReadOnly calls a peered chaincode as InvokeChaincode does,
+// as the MockStub does not model channels.
+func (stub *MockStub) InvokeChaincodeReadOnly(chaincodeName string, args [][]byte, channel string) pb.Response {
+	return stub.InvokeChaincode(chaincodeName, args, channel)
+}
+
+// GetCreator returns the identity set in Creator
 func (stub *MockStub) GetCreator() ([]byte, error) {
-	return nil, nil
+	return stub.Creator, nil
 }
 
-// Not implemented
+// GetTransient returns the transient data set in TransientMap
 func (stub *MockStub) GetTransient() (map[string][]byte, error) {
-	return nil, nil
+	return stub.TransientMap, nil
 }
 
 // Not implemented
@@ -395,8 +568,13 @@ func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return stub.TxTimestamp, nil
 }
 
+// SetEvent adds an event to the transaction, which is delivered to
+// ChaincodeEventsChannel when the transaction ends successfully
 func (stub *MockStub) SetEvent(name string, payload []byte) error {
-	stub.ChaincodeEventsChannel <- &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	if name == "" {
+		return errors.New("event name can not be nil string")
+	}
+	stub.chaincodeEvents = append(stub.chaincodeEvents, &pb.ChaincodeEvent{EventName: name, Payload: payload})
 	return nil
 }
 
@@ -437,7 +615,9 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	s.cc = cc
 	s.State = make(map[string][]byte)
 	s.PvtState = make(map[string]map[string][]byte)
+	s.PvtStateHashes = make(map[string]map[string][]byte)
 	s.EndorsementPolicies = make(map[string]map[string][]byte)
+	s.History = make(map[string][]*queryresult.KeyModification)
 	s.Invokables = make(map[string]*MockStub)
 	s.Keys = list.New()
 	s.ChaincodeEventsChannel = make(chan *pb.ChaincodeEvent, 100) //define large capacity for non-blocking setEvent calls.
@@ -446,6 +626,206 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	return s
 }
 
+// recordHistory records a modification of a key of the public state
+func (stub *MockStub) recordHistory(key string, value []byte, isDelete bool) {
+	stub.History[key] = append(stub.History[key], &queryresult.KeyModification{
+		TxId:      stub.TxID,
+		Value:     value,
+		Timestamp: stub.TxTimestamp,
+		IsDelete:  isDelete,
+	})
+}
+
+// stateRange returns the keys and values of the public state in the
+// range [startKey, endKey), where an empty endKey means no upper bound
+func (stub *MockStub) stateRange(startKey, endKey string) []*queryresult.KV {
+	var results []*queryresult.KV
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(string)
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		results = append(results, &queryresult.KV{Key: key, Value: stub.State[key]})
+	}
+	return results
+}
+
+// privateDataRange returns the keys and values of the collection in the
+// range [startKey, endKey), where an empty endKey means no upper bound
+func (stub *MockStub) privateDataRange(collection, startKey, endKey string) []*queryresult.KV {
+	m := stub.PvtState[collection]
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := make([]*queryresult.KV, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, &queryresult.KV{Key: key, Value: m[key]})
+	}
+	return results
+}
+
+func executeQuery(query string, kvs []*queryresult.KV) ([]*queryresult.KV, error) {
+	q, err := parseMockQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return q.execute(kvs)
+}
+
+// paginate returns the page of the results which starts at the key of the
+// bookmark, or at the first result when the bookmark is empty
+func paginate(results []*queryresult.KV, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if pageSize <= 0 {
+		return nil, nil, errors.New("pageSize must be greater than zero")
+	}
+
+	start := 0
+	if bookmark != "" {
+		start = len(results)
+		for i, kv := range results {
+			if kv.Key == bookmark {
+				start = i
+				break
+			}
+		}
+	}
+	results = results[start:]
+
+	nextBookmark := ""
+	if len(results) > int(pageSize) {
+		nextBookmark = results[pageSize].Key
+		results = results[:pageSize]
+	}
+
+	metadata := &pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            nextBookmark,
+	}
+	return &mockQueryIterator{results: results}, metadata, nil
+}
+
+// mockState is a copy of the ledger data of the MockStub, used to roll
+// back the changes of failed transactions
+type mockState struct {
+	state               map[string][]byte
+	pvtState            map[string]map[string][]byte
+	pvtStateHashes      map[string]map[string][]byte
+	endorsementPolicies map[string]map[string][]byte
+	history             map[string][]*queryresult.KeyModification
+}
+
+func (stub *MockStub) snapshot() *mockState {
+	history := make(map[string][]*queryresult.KeyModification, len(stub.History))
+	for key, modifications := range stub.History {
+		history[key] = append([]*queryresult.KeyModification(nil), modifications...)
+	}
+	return &mockState{
+		state:               copyMap(stub.State),
+		pvtState:            copyNestedMap(stub.PvtState),
+		pvtStateHashes:      copyNestedMap(stub.PvtStateHashes),
+		endorsementPolicies: copyNestedMap(stub.EndorsementPolicies),
+		history:             history,
+	}
+}
+
+func (stub *MockStub) restore(snapshot *mockState) {
+	stub.State = snapshot.state
+	stub.PvtState = snapshot.pvtState
+	stub.PvtStateHashes = snapshot.pvtStateHashes
+	stub.EndorsementPolicies = snapshot.endorsementPolicies
+	stub.History = snapshot.history
+
+	keys := make([]string, 0, len(stub.State))
+	for key := range stub.State {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	stub.Keys.Init()
+	for _, key := range keys {
+		stub.Keys.PushBack(key)
+	}
+}
+
+func copyMap(m map[string][]byte) map[string][]byte {
+	c := make(map[string][]byte, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func copyNestedMap(m map[string]map[string][]byte) map[string]map[string][]byte {
+	c := make(map[string]map[string][]byte, len(m))
+	for k, v := range m {
+		c[k] = copyMap(v)
+	}
+	return c
+}
+
+/*****************************
+ Query Result Iterators
+*****************************/
+
+// mockQueryIterator iterates over the results of a query computed upfront
+type mockQueryIterator struct {
+	results []*queryresult.KV
+	closed  bool
+}
+
+func (iter *mockQueryIterator) HasNext() bool {
+	return !iter.closed && len(iter.results) > 0
+}
+
+func (iter *mockQueryIterator) Next() (*queryresult.KV, error) {
+	if iter.closed {
+		return nil, errors.New("Next() called after Close()")
+	}
+	if len(iter.results) == 0 {
+		return nil, errors.New("no such key")
+	}
+	kv := iter.results[0]
+	iter.results = iter.results[1:]
+	return kv, nil
+}
+
+func (iter *mockQueryIterator) Close() error {
+	iter.closed = true
+	return nil
+}
+
+// mockHistoryQueryIterator iterates over the modifications of a key
+type mockHistoryQueryIterator struct {
+	modifications []*queryresult.KeyModification
+	closed        bool
+}
+
+func (iter *mockHistoryQueryIterator) HasNext() bool {
+	return !iter.closed && len(iter.modifications) > 0
+}
+
+func (iter *mockHistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	if iter.closed {
+		return nil, errors.New("Next() called after Close()")
+	}
+	if len(iter.modifications) == 0 {
+		return nil, errors.New("no such key")
+	}
+	modification := iter.modifications[0]
+	iter.modifications = iter.modifications[1:]
+	return modification, nil
+}
+
+func (iter *mockHistoryQueryIterator) Close() error {
+	iter.closed = true
+	return nil
+}
+
 /*****************************
  Range Query Iterator
 *****************************/
@@ -13,6 +13,8 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -284,6 +286,246 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+func TestGetMultipleStates(t *testing.T) {
+	stub := NewMockStub("multiple", nil)
+
+	stub.MockTransactionStart("1")
+	err := stub.PutState("a", []byte("valueA"))
+	assert.NoError(t, err)
+	err = stub.PutPrivateData("collection", "b", []byte("valueB"))
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("1")
+
+	values, err := stub.GetMultipleStates("a", "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("valueA"), nil}, values)
+
+	values, err = stub.GetMultiplePrivateData("collection", "b", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("valueB"), nil}, values)
+}
+
+func TestMockStubRichQuery(t *testing.T) {
+	stub := NewMockStub("richquery", nil)
+	stub.MockTransactionStart("1")
+	stub.PutState("marble1", []byte(`{"color":"blue","size":10,"owner":{"name":"tom"}}`))
+	stub.PutState("marble2", []byte(`{"color":"red","size":20,"owner":{"name":"jerry"}}`))
+	stub.PutState("marble3", []byte(`{"color":"blue","size":30,"owner":{"name":"jerry"}}`))
+	stub.PutState("notjson", []byte("blue"))
+	stub.MockTransactionEnd("1")
+
+	keys := func(iter StateQueryIteratorInterface) []string {
+		var keys []string
+		for iter.HasNext() {
+			kv, err := iter.Next()
+			assert.NoError(t, err)
+			keys = append(keys, kv.Key)
+		}
+		assert.NoError(t, iter.Close())
+		return keys
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{`{"selector":{"color":"blue"}}`, []string{"marble1", "marble3"}},
+		{`{"selector":{"owner.name":"jerry"}}`, []string{"marble2", "marble3"}},
+		{`{"selector":{"owner":{"name":"tom"}}}`, []string{"marble1"}},
+		{`{"selector":{"size":{"$gt":10,"$lte":30}}}`, []string{"marble2", "marble3"}},
+		{`{"selector":{"color":{"$in":["red","green"]}}}`, []string{"marble2"}},
+		{`{"selector":{"color":{"$nin":["red"]}}}`, []string{"marble1", "marble3"}},
+		{`{"selector":{"$or":[{"size":10},{"color":"red"}]}}`, []string{"marble1", "marble2"}},
+		{`{"selector":{"$not":{"color":"blue"}}}`, []string{"marble2"}},
+		{`{"selector":{"owner.name":{"$regex":"^j"},"color":{"$ne":"red"}}}`, []string{"marble3"}},
+		{`{"selector":{"weight":{"$exists":false}},"sort":[{"size":"desc"}],"limit":2}`, []string{"marble3", "marble2"}},
+		{`{"selector":{"color":"blue"},"sort":["size"],"skip":1}`, []string{"marble3"}},
+	}
+	for _, test := range tests {
+		iter, err := stub.GetQueryResult(test.query)
+		assert.NoError(t, err, test.query)
+		assert.Equal(t, test.expected, keys(iter), test.query)
+	}
+
+	_, err := stub.GetQueryResult(`{"selector":{"color":{"$near":"blue"}}}`)
+	assert.EqualError(t, err, "invalid query: unsupported operator $near")
+	_, err = stub.GetQueryResult(`not a query`)
+	assert.Contains(t, err.Error(), "invalid query")
+
+	iter, metadata, err := stub.GetQueryResultWithPagination(`{"selector":{"size":{"$gte":0}}}`, 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"marble1", "marble2"}, keys(iter))
+	assert.Equal(t, int32(2), metadata.FetchedRecordsCount)
+	assert.Equal(t, "marble3", metadata.Bookmark)
+
+	iter, metadata, err = stub.GetQueryResultWithPagination(`{"selector":{"size":{"$gte":0}}}`, 2, metadata.Bookmark)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"marble3"}, keys(iter))
+	assert.Equal(t, "", metadata.Bookmark)
+}
+
+func TestMockStubPrivateData(t *testing.T) {
+	stub := NewMockStub("pvtdata", nil)
+	stub.MockTransactionStart("1")
+	assert.NoError(t, stub.PutPrivateData("coll", "b", []byte(`{"color":"red"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll", "a", []byte(`{"color":"blue"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll", "c", []byte(`{"color":"blue"}`)))
+	assert.NoError(t, stub.SetPrivateDataValidationParameter("coll", "a", []byte("policy")))
+	stub.MockTransactionEnd("1")
+
+	hash, err := stub.GetPrivateDataHash("coll", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte(`{"color":"blue"}`)), hash)
+
+	iter, err := stub.GetPrivateDataByRange("coll", "a", "c")
+	assert.NoError(t, err)
+	var keys []string
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	iter, err = stub.GetPrivateDataQueryResult("coll", `{"selector":{"color":"blue"}}`)
+	assert.NoError(t, err)
+	keys = nil
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	assert.Equal(t, []string{"a", "c"}, keys)
+
+	stub.MockTransactionStart("2")
+	assert.NoError(t, stub.DelPrivateData("coll", "a"))
+	stub.MockTransactionEnd("2")
+
+	value, err := stub.GetPrivateData("coll", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	hash, err = stub.GetPrivateDataHash("coll", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+	ep, err := stub.GetPrivateDataValidationParameter("coll", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, ep)
+
+	// the hashes of collections the peer is not a member of can be mocked
+	stub.PvtStateHashes["other"] = map[string][]byte{"x": []byte("hash")}
+	hash, err = stub.GetPrivateDataHash("other", "x")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hash"), hash)
+
+	_, err = stub.GetPrivateData("", "a")
+	assert.EqualError(t, err, "collection must not be an empty string")
+}
+
+type rollbackCC struct{}
+
+func (cc *rollbackCC) Init(stub ChaincodeStubInterface) pb.Response {
+	return Success(nil)
+}
+
+func (cc *rollbackCC) Invoke(stub ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	stub.PutState(args[0], []byte(args[1]))
+	stub.PutPrivateData("coll", args[0], []byte(args[1]))
+	stub.SetStateValidationParameter(args[0], []byte("policy"))
+	stub.SetEvent("written", []byte(args[0]))
+	stub.SetEvent("written-private", []byte(args[0]))
+	if function == "fail" {
+		return Error("failed")
+	}
+	return Success(nil)
+}
+
+func TestMockStubTransactions(t *testing.T) {
+	stub := NewMockStub("transactions", &rollbackCC{})
+	stub.Creator = []byte("creator")
+	stub.TransientMap = map[string][]byte{"secret": []byte("value")}
+
+	creator, err := stub.GetCreator()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("creator"), creator)
+	transient, err := stub.GetTransient()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"secret": []byte("value")}, transient)
+
+	res := stub.MockInvoke("tx1", [][]byte{[]byte("put"), []byte("key"), []byte("value1")})
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Len(t, stub.ChaincodeEventsChannel, 2)
+	event := <-stub.ChaincodeEventsChannel
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "written", Payload: []byte("key")}, event)
+	event = <-stub.ChaincodeEventsChannel
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "written-private", Payload: []byte("key")}, event)
+
+	res = stub.MockInvoke("tx2", [][]byte{[]byte("fail"), []byte("other"), []byte("value2")})
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Len(t, stub.ChaincodeEventsChannel, 0)
+
+	// the writes of the failed transaction are rolled back
+	value, _ := stub.GetState("other")
+	assert.Nil(t, value)
+	value, _ = stub.GetPrivateData("coll", "other")
+	assert.Nil(t, value)
+	ep, _ := stub.GetStateValidationParameter("other")
+	assert.Nil(t, ep)
+	assert.Equal(t, 1, stub.Keys.Len())
+
+	res = stub.MockInvoke("tx3", [][]byte{[]byte("put"), []byte("key"), []byte("value3")})
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Len(t, stub.ChaincodeEventsChannel, 2)
+	stub.MockTransactionStart("tx4")
+	assert.NoError(t, stub.DelState("key"))
+	stub.MockTransactionEnd("tx4")
+
+	iter, err := stub.GetHistoryForKey("key")
+	assert.NoError(t, err)
+	var history []string
+	for iter.HasNext() {
+		modification, err := iter.Next()
+		assert.NoError(t, err)
+		history = append(history, fmt.Sprintf("%s:%s:%t", modification.TxId, modification.Value, modification.IsDelete))
+	}
+	assert.Equal(t, []string{"tx4::true", "tx3:value3:false", "tx1:value1:false"}, history)
+
+	assert.EqualError(t, stub.SetEvent("", nil), "event name can not be nil string")
+}
+
+func TestMockStubRangePagination(t *testing.T) {
+	stub := NewMockStub("pagination", nil)
+	stub.MockTransactionStart("1")
+	for _, key := range []string{"a", "b", "c", "d"} {
+		stub.PutState(key, []byte(key))
+	}
+	ck, _ := stub.CreateCompositeKey("color", []string{"blue", "1"})
+	stub.PutState(ck, []byte("composite"))
+	stub.MockTransactionEnd("1")
+
+	iter, metadata, err := stub.GetStateByRangeWithPagination("", "", 3, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 3, Bookmark: "d"}, metadata)
+	kv, _ := iter.Next()
+	assert.Equal(t, "a", kv.Key)
+
+	iter, metadata, err = stub.GetStateByRangeWithPagination("", "", 3, metadata.Bookmark)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 1}, metadata)
+	kv, _ = iter.Next()
+	assert.Equal(t, "d", kv.Key)
+	assert.False(t, iter.HasNext())
+
+	iter, metadata, err = stub.GetStateByPartialCompositeKeyWithPagination("color", []string{"blue"}, 10, "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), metadata.FetchedRecordsCount)
+	kv, _ = iter.Next()
+	assert.Equal(t, ck, kv.Key)
+
+	_, _, err = stub.GetStateByRangeWithPagination("a", "b", 0, "")
+	assert.EqualError(t, err, "pageSize must be greater than zero")
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// TLSProperties are the TLS settings of a chaincode server
+type TLSProperties struct {
+	// Disabled disables TLS for the chaincode server
+	Disabled bool
+	// Key is the PEM-encoded private key of the server
+	Key []byte
+	// Cert is the PEM-encoded certificate of the server
+	Cert []byte
+	// ClientCACerts are the PEM-encoded CA certificates used to
+	// authenticate the peer. Client authentication is required
+	// when set
+	ClientCACerts []byte
+}
+
+// ChaincodeServer runs chaincode as a server which the peer connects to,
+// instead of the chaincode connecting to the peer.
+type ChaincodeServer struct {
+	// CCID is the name under which the chaincode registers with the peer
+	CCID string
+	// Address is the listen address of the server
+	Address string
+	// CC is the chaincode served
+	CC Chaincode
+	// TLSProps are the TLS settings of the server
+	TLSProps TLSProperties
+	// KaOpts are the keepalive options of the server, the default
+	// keepalive options are used when nil
+	KaOpts *comm.KeepaliveOptions
+}
+
+// serverStream adapts the server side of the chaincode stream to the
+// stream expected by the shim handler
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+// CloseSend does nothing, as the stream of a server is closed by
+// returning from the handler
+func (s *serverStream) CloseSend() error {
+	return nil
+}
+
+// Connect is called by the peer to establish the chaincode stream.
+func (cs *ChaincodeServer) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(cs.CCID, &serverStream{Chaincode_ConnectServer: stream}, cs.CC)
+}
+
+// Start listens on the address of the server and serves the peer
+// connections. It blocks until the server stops.
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("ccid must be specified")
+	}
+	if cs.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if cs.CC == nil {
+		return errors.New("chaincode must be specified")
+	}
+
+	secOpts := &comm.SecureOptions{}
+	if !cs.TLSProps.Disabled {
+		if cs.TLSProps.Key == nil || cs.TLSProps.Cert == nil {
+			return errors.New("key and cert must be specified when TLS is enabled")
+		}
+		secOpts.UseTLS = true
+		secOpts.Key = cs.TLSProps.Key
+		secOpts.Certificate = cs.TLSProps.Cert
+		if cs.TLSProps.ClientCACerts != nil {
+			secOpts.RequireClientCert = true
+			secOpts.ClientRootCAs = [][]byte{cs.TLSProps.ClientCACerts}
+		}
+	}
+
+	kaOpts := cs.KaOpts
+	if kaOpts == nil {
+		kaOpts = comm.DefaultKeepaliveOptions
+	}
+
+	server, err := comm.NewGRPCServer(cs.Address, comm.ServerConfig{
+		SecOpts: secOpts,
+		KaOpts:  kaOpts,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "could not create chaincode server")
+	}
+
+	pb.RegisterChaincodeServer(server.Server(), cs)
+
+	return server.Start()
+}
@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChaincodeServerStartErrors(t *testing.T) {
+	cc := &shimTestCC{}
+	tests := []struct {
+		server *ChaincodeServer
+		err    string
+	}{
+		{&ChaincodeServer{Address: "127.0.0.1:0", CC: cc}, "ccid must be specified"},
+		{&ChaincodeServer{CCID: "cc", CC: cc}, "address must be specified"},
+		{&ChaincodeServer{CCID: "cc", Address: "127.0.0.1:0"}, "chaincode must be specified"},
+		{&ChaincodeServer{CCID: "cc", Address: "127.0.0.1:0", CC: cc}, "key and cert must be specified when TLS is enabled"},
+		{&ChaincodeServer{CCID: "cc", Address: "bad-address", CC: cc, TLSProps: TLSProperties{Disabled: true}}, "could not create chaincode server"},
+	}
+	for _, tc := range tests {
+		err := tc.server.Start()
+		assert.Contains(t, err.Error(), tc.err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	mockpeer "github.com/hyperledger/fabric/common/mocks/peer"
 	"github.com/hyperledger/fabric/common/util"
@@ -87,6 +88,8 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 		return t.putEP(stub)
 	} else if function == "getep" {
 		return t.getEP(stub)
+	} else if function == "multiget" {
+		return t.multiGet(stub, args)
 	}
 
 	return Error("Invalid invoke function name. Expecting \"invoke\" \"delete\" \"query\"")
@@ -360,6 +363,18 @@ func (t *shimTestCC) getEP(stub ChaincodeStubInterface) pb.Response {
 	return Success(ep)
 }
 
+func (t *shimTestCC) multiGet(stub ChaincodeStubInterface, args []string) pb.Response {
+	values, err := stub.GetMultipleStates(args...)
+	if err != nil {
+		return Error(err.Error())
+	}
+	var buffer bytes.Buffer
+	for _, value := range values {
+		buffer.WriteString(string(value) + ";")
+	}
+	return Success(buffer.Bytes())
+}
+
 // Test Go shim functionality that can be tested outside of a real chaincode
 // context.
 
@@ -480,6 +495,23 @@ func TestNilEventName(t *testing.T) {
 
 }
 
+func TestMultipleEvents(t *testing.T) {
+	stub := ChaincodeStub{}
+	assert.Nil(t, stub.chaincodeEvent())
+	assert.Nil(t, stub.multipleEvents())
+
+	assert.NoError(t, stub.SetEvent("first", []byte("payload1")))
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "first", Payload: []byte("payload1")}, stub.chaincodeEvent())
+	assert.Nil(t, stub.multipleEvents())
+
+	assert.NoError(t, stub.SetEvent("second", []byte("payload2")))
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "second", Payload: []byte("payload2")}, stub.chaincodeEvent())
+	assert.Equal(t, []*pb.ChaincodeEvent{
+		{EventName: "first", Payload: []byte("payload1")},
+		{EventName: "second", Payload: []byte("payload2")},
+	}, stub.multipleEvents())
+}
+
 type testCase struct {
 	name         string
 	ccLogLevel   string
@@ -1025,6 +1057,136 @@ func TestSetKeyEP(t *testing.T) {
 
 }
 
+func TestBatchedStateAccess(t *testing.T) {
+	streamGetter = mockChaincodeStreamGetter
+	cc := &shimTestCC{}
+	ccname := "shimTestCC"
+	peerSide := setupcc(ccname)
+	defer mockPeerCCSupport.RemoveCC(ccname)
+	//start the shim+chaincode
+	go Start(cc)
+
+	done := setuperror()
+
+	errorFunc := func(ind int, err error) {
+		done <- err
+	}
+
+	peerDone := make(chan struct{})
+	defer close(peerDone)
+
+	runtimeParams := utils.MarshalOrPanic(&pb.ChaincodeAdditionalParams{
+		UseWriteBatch:          true,
+		MaxSizeWriteBatch:      1000,
+		UseGetMultipleKeys:     true,
+		MaxSizeGetMultipleKeys: 1,
+	})
+
+	//start the mock peer
+	go func() {
+		respSet := &mockpeer.MockResponseSet{
+			DoneFunc:  errorFunc,
+			ErrorFunc: nil,
+			Responses: []*mockpeer.MockResponse{
+				{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTER}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_REGISTERED, Payload: runtimeParams}},
+			},
+		}
+		peerSide.SetResponses(respSet)
+		peerSide.SetKeepAlive(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE})
+		err := peerSide.Run(peerDone)
+		assert.NoError(t, err, "peer side run failed")
+	}()
+
+	//wait for init
+	processDone(t, done, false)
+
+	channelID := "testchannel"
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_READY, Txid: "1", ChannelId: channelID})
+
+	// the writes of init are sent in a single message
+	var putStateMultiple *pb.ChaincodeMessage
+	ci := &pb.ChaincodeInput{Args: [][]byte{[]byte("init"), []byte("A"), []byte("100"), []byte("B"), []byte("200")}, Decorations: nil}
+	payload := utils.MarshalOrPanic(ci)
+	respSet := &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Txid: "2"}, RespMsg: func(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+				putStateMultiple = msg
+				return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "2", ChannelId: channelID}
+			}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "2", ChannelId: channelID}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INIT, Payload: payload, Txid: "2", ChannelId: channelID})
+
+	processDone(t, done, false)
+
+	records := &pb.PutStateMultiple{}
+	assert.NoError(t, proto.Unmarshal(putStateMultiple.Payload, records))
+	assert.Equal(t, []*pb.WriteRecord{
+		{Key: "A", Value: []byte("100")},
+		{Key: "B", Value: []byte("200")},
+	}, records.Records)
+
+	// the keys are fetched in chunks of the maximum size advertised by the peer
+	var completed *pb.ChaincodeMessage
+	getStateMultipleResult := func(value string) []byte {
+		return utils.MarshalOrPanic(&pb.GetStateMultipleResult{Values: [][]byte{[]byte(value)}})
+	}
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "3", ChannelId: channelID}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: getStateMultipleResult("100"), Txid: "3", ChannelId: channelID}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_MULTIPLE, Txid: "3", ChannelId: channelID}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: getStateMultipleResult(""), Txid: "3", ChannelId: channelID}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "3", ChannelId: channelID}, RespMsg: func(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+				completed = msg
+				// keep alive messages leave the state of the shim untouched
+				return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE}
+			}},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("multiget"), []byte("A"), []byte("C")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "3", ChannelId: channelID})
+
+	processDone(t, done, false)
+
+	response := &pb.Response{}
+	assert.NoError(t, proto.Unmarshal(completed.Payload, response))
+	assert.Equal(t, int32(OK), response.Status)
+	assert.Equal(t, "100;;", string(response.Payload))
+
+	// a failure to send the buffered writes fails the transaction
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE_MULTIPLE, Txid: "4", ChannelId: channelID}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte("write failed"), Txid: "4", ChannelId: channelID}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4", ChannelId: channelID}, RespMsg: func(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+				completed = msg
+				// keep alive messages leave the state of the shim untouched
+				return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_KEEPALIVE}
+			}},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("delete"), []byte("A")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4", ChannelId: channelID})
+
+	processDone(t, done, false)
+
+	assert.Equal(t, "write failed", string(completed.Payload))
+}
+
 func TestStartInProc(t *testing.T) {
 	streamGetter = mockChaincodeStreamGetter
 	cc := &shimTestCC{}
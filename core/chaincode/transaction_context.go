@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -24,6 +25,7 @@ type TransactionContext struct {
 	HistoryQueryExecutor ledger.HistoryQueryExecutor
 	CollectionStore      privdata.CollectionStore
 	IsInitTransaction    bool
+	CrossChannelReads    *ccprovider.CrossChannelReads
 
 	// tracks open iterators used for range queries
 	queryMutex          sync.Mutex
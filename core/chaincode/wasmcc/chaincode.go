@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm/interpreter"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// pageSize is the size in bytes of a page of WASM memory
+const pageSize = 65536
+
+// Chaincode is a shim.Chaincode which executes a WASM module. Each
+// transaction is processed by a fresh instance of the module, so no state
+// is kept in memory across transactions, which may run concurrently.
+type Chaincode struct {
+	module      *interpreter.Module
+	memoryLimit uint32
+	timeout     time.Duration
+}
+
+// NewChaincode compiles the WASM module of a chaincode. The memory of the
+// instances of the module, as well as their call stack, is limited to
+// memoryLimit bytes and their execution to timeout, unless those are zero.
+func NewChaincode(module []byte, memoryLimit int64, timeout time.Duration) (*Chaincode, error) {
+	if err := wasm.ValidateModule(module); err != nil {
+		return nil, err
+	}
+	compiled, err := interpreter.Compile(module)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error compiling WASM module")
+	}
+
+	var pages uint32
+	if memoryLimit > 0 {
+		pages = uint32(memoryLimit / pageSize)
+		if memoryLimit/pageSize > math.MaxUint32 {
+			pages = math.MaxUint32
+		}
+		if pages == 0 {
+			pages = 1
+		}
+	}
+
+	return &Chaincode{
+		module:      compiled,
+		memoryLimit: pages,
+		timeout:     timeout,
+	}, nil
+}
+
+// Init calls the init function of the module, if it exports one.
+func (c *Chaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.execute(stub, wasm.InitExport)
+}
+
+// Invoke calls the invoke function of the module.
+func (c *Chaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return c.execute(stub, wasm.InvokeExport)
+}
+
+func (c *Chaincode) execute(stub shim.ChaincodeStubInterface, function string) pb.Response {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	tx := &transaction{stub: stub}
+	ctx = context.WithValue(ctx, transactionKey{}, tx)
+
+	inst, err := interpreter.Instantiate(ctx, c.module, hostModule, c.memoryLimit)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("error instantiating WASM module: %s", err))
+	}
+
+	if _, ok := c.module.ExportedFunction(function); !ok {
+		// only init is optional
+		return shim.Success(nil)
+	}
+
+	results, err := inst.Call(ctx, function)
+	if tx.err != nil {
+		return shim.Error(tx.err.Error())
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return shim.Error(fmt.Sprintf("WASM chaincode %s timed out after %s", function, c.timeout))
+		}
+		return shim.Error(fmt.Sprintf("error executing WASM chaincode %s: %s", function, err))
+	}
+
+	status := int32(results[0])
+	if status >= shim.ERRORTHRESHOLD {
+		return pb.Response{Status: status, Message: string(tx.response)}
+	}
+	return pb.Response{Status: status, Payload: tx.response}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcc
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm/interpreter"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/pkg/errors"
+)
+
+// transactionKey is the context key of the transaction being processed
+type transactionKey struct{}
+
+// transaction is the state of the transaction processed by an instance of
+// a WASM module
+type transaction struct {
+	stub     shim.ChaincodeStubInterface
+	response []byte
+	err      error
+}
+
+// hostFunction implements a host function of the ABI. It returns the
+// result of the function, if it has one.
+type hostFunction func(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error)
+
+var hostFunctions = map[string]hostFunction{
+	"get_args":                  getArgs,
+	"get_tx_id":                 getTxID,
+	"get_channel_id":            getChannelID,
+	"get_state":                 getState,
+	"put_state":                 putState,
+	"del_state":                 delState,
+	"get_state_by_range":        getStateByRange,
+	"get_private_data":          getPrivateData,
+	"put_private_data":          putPrivateData,
+	"del_private_data":          delPrivateData,
+	"get_private_data_by_range": getPrivateDataByRange,
+	"set_event":                 setEvent,
+	"set_response":              setResponse,
+	"log":                       logMessage,
+}
+
+// hostModule holds the host functions of the ABI imported by chaincode
+var hostModule = newHostModule()
+
+func newHostModule() interpreter.Imports {
+	functions := map[string]*interpreter.HostFunction{}
+	for name, signature := range wasm.HostFunctions {
+		fn, ok := hostFunctions[name]
+		if !ok {
+			panic("host function " + name + " is not implemented")
+		}
+		functions[name] = &interpreter.HostFunction{
+			Type: signature,
+			Func: fn.hostFunc(name, len(signature.Results) > 0),
+		}
+	}
+	return interpreter.Imports{wasm.HostModule: functions}
+}
+
+// hostFunc adapts the host function to the interpreter. A failing host
+// function records its error on the transaction and aborts the execution.
+func (f hostFunction) hostFunc(name string, hasResult bool) func(context.Context, *interpreter.Instance, []uint64) ([]uint64, error) {
+	return func(ctx context.Context, inst *interpreter.Instance, params []uint64) ([]uint64, error) {
+		tx := ctx.Value(transactionKey{}).(*transaction)
+		result, err := f(ctx, tx, inst, params)
+		if err != nil {
+			tx.err = errors.WithMessage(err, "host function "+name+" failed")
+			return nil, tx.err
+		}
+		if hasResult {
+			return []uint64{result}, nil
+		}
+		return nil, nil
+	}
+}
+
+// read copies length bytes of the memory of the instance at offset.
+func read(inst *interpreter.Instance, offset, length uint64) ([]byte, error) {
+	data, ok := inst.Read(uint32(offset), uint32(length))
+	if !ok {
+		return nil, errors.Errorf("out of range memory access at offset %d of length %d", uint32(offset), uint32(length))
+	}
+	return data, nil
+}
+
+// readStrings reads the strings passed as offset and length pairs.
+func readStrings(inst *interpreter.Instance, params []uint64) ([]string, error) {
+	var values []string
+	for i := 0; i+1 < len(params); i += 2 {
+		data, err := read(inst, params[i], params[i+1])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(data))
+	}
+	return values, nil
+}
+
+// write writes the data to memory allocated by the module, and returns its
+// offset and length as the result of a host function.
+func write(ctx context.Context, inst *interpreter.Instance, data []byte) (uint64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	results, err := inst.Call(ctx, wasm.AllocExport, uint64(len(data)))
+	if err != nil {
+		return 0, errors.WithMessage(err, "error allocating memory")
+	}
+	offset := uint32(results[0])
+	if !inst.Write(offset, data) {
+		return 0, errors.Errorf("allocated memory at offset %d is out of range", offset)
+	}
+	return uint64(offset)<<32 | uint64(len(data)), nil
+}
+
+// encodeList encodes the entries as a list of the ABI.
+func encodeList(entries [][]byte) []byte {
+	var list []byte
+	for _, entry := range entries {
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(entry)))
+		list = append(list, length[:]...)
+		list = append(list, entry...)
+	}
+	return list
+}
+
+// encodeRange encodes the results of a range query as a list of keys and
+// values.
+func encodeRange(iter shim.StateQueryIteratorInterface) ([]byte, error) {
+	defer iter.Close()
+
+	var entries [][]byte
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, []byte(kv.Key), kv.Value)
+	}
+	return encodeList(entries), nil
+}
+
+func getArgs(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	return write(ctx, inst, encodeList(tx.stub.GetArgs()))
+}
+
+func getTxID(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	return write(ctx, inst, []byte(tx.stub.GetTxID()))
+}
+
+func getChannelID(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	return write(ctx, inst, []byte(tx.stub.GetChannelID()))
+}
+
+func getState(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	value, err := tx.stub.GetState(args[0])
+	if err != nil {
+		return 0, err
+	}
+	return write(ctx, inst, value)
+}
+
+func putState(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	key, err := readStrings(inst, params[:2])
+	if err != nil {
+		return 0, err
+	}
+	value, err := read(inst, params[2], params[3])
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.stub.PutState(key[0], value)
+}
+
+func delState(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.stub.DelState(args[0])
+}
+
+func getStateByRange(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	iter, err := tx.stub.GetStateByRange(args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	results, err := encodeRange(iter)
+	if err != nil {
+		return 0, err
+	}
+	return write(ctx, inst, results)
+}
+
+func getPrivateData(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	value, err := tx.stub.GetPrivateData(args[0], args[1])
+	if err != nil {
+		return 0, err
+	}
+	return write(ctx, inst, value)
+}
+
+func putPrivateData(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params[:4])
+	if err != nil {
+		return 0, err
+	}
+	value, err := read(inst, params[4], params[5])
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.stub.PutPrivateData(args[0], args[1], value)
+}
+
+func delPrivateData(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.stub.DelPrivateData(args[0], args[1])
+}
+
+func getPrivateDataByRange(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	args, err := readStrings(inst, params)
+	if err != nil {
+		return 0, err
+	}
+	iter, err := tx.stub.GetPrivateDataByRange(args[0], args[1], args[2])
+	if err != nil {
+		return 0, err
+	}
+	results, err := encodeRange(iter)
+	if err != nil {
+		return 0, err
+	}
+	return write(ctx, inst, results)
+}
+
+func setEvent(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	name, err := readStrings(inst, params[:2])
+	if err != nil {
+		return 0, err
+	}
+	payload, err := read(inst, params[2], params[3])
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.stub.SetEvent(name[0], payload)
+}
+
+func setResponse(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	response, err := read(inst, params[0], params[1])
+	if err != nil {
+		return 0, err
+	}
+	tx.response = response
+	return 0, nil
+}
+
+func logMessage(ctx context.Context, tx *transaction, inst *interpreter.Instance, params []uint64) (uint64, error) {
+	message, err := read(inst, params[0], params[1])
+	if err != nil {
+		return 0, err
+	}
+	logger.Debugf("[%s] %s", shortTxID(tx.stub.GetTxID()), message)
+	return 0, nil
+}
+
+func shortTxID(txID string) string {
+	if len(txID) < 8 {
+		return txID
+	}
+	return txID[:8]
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcc
+
+import (
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// inProcStream is the peer side of the chaincode stream with chaincode
+// executed in-process
+type inProcStream struct {
+	recv <-chan *pb.ChaincodeMessage
+	send chan<- *pb.ChaincodeMessage
+}
+
+func newInProcStream(recv <-chan *pb.ChaincodeMessage, send chan<- *pb.ChaincodeMessage) *inProcStream {
+	return &inProcStream{recv: recv, send: send}
+}
+
+func (s *inProcStream) Send(msg *pb.ChaincodeMessage) (err error) {
+	// send may happen on a closed channel when the chaincode is
+	// stopped. Just catch the exception and return error
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("send failure %s", r)
+		}
+	}()
+	s.send <- msg
+	return nil
+}
+
+func (s *inProcStream) Recv() (*pb.ChaincodeMessage, error) {
+	msg, ok := <-s.recv
+	if !ok {
+		return nil, errors.New("channel is closed")
+	}
+	return msg, nil
+}
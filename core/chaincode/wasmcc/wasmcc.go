@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ChaincodeType is the type, as found in the metadata of a chaincode
+	// package, of chaincodes compiled to WebAssembly
+	ChaincodeType = "WASM"
+
+	// ContainerType is the container type of chaincodes compiled to
+	// WebAssembly, which are executed in-process
+	ContainerType = "WASM"
+)
+
+var logger = flogging.MustGetLogger("chaincode.wasmcc")
+
+// StreamHandler handles the chaincode stream established with chaincode
+// executed in-process
+type StreamHandler interface {
+	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
+}
+
+// instance is a WASM chaincode executed in-process
+type instance struct {
+	chaincode *Chaincode
+	stop      chan struct{}
+	done      chan struct{}
+	err       error
+}
+
+// WasmChaincodeRuntime is a chaincode runtime which executes chaincode
+// compiled to WebAssembly in-process, with an embedded WASM runtime, and
+// processes the chaincode stream with the shim over in-process channels.
+type WasmChaincodeRuntime struct {
+	StreamHandler StreamHandler
+
+	// MemoryLimit is the maximum size in bytes of the memory of an instance
+	// of a chaincode module, unlimited when zero
+	MemoryLimit int64
+
+	// ExecuteTimeout returns the time after which the execution of a
+	// transaction by the named chaincode is aborted
+	ExecuteTimeout func(ccName string) time.Duration
+
+	mutex     sync.Mutex
+	instances map[string]*instance
+}
+
+// Start compiles the WASM module of the code package and starts handling
+// the stream with the chaincode.
+func (r *WasmChaincodeRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	cname := ccci.Name + ":" + ccci.Version
+
+	module, err := wasm.ExtractModule(codePackage)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract WASM module of chaincode "+cname)
+	}
+	var timeout time.Duration
+	if r.ExecuteTimeout != nil {
+		timeout = r.ExecuteTimeout(ccci.Name)
+	}
+	cc, err := NewChaincode(module, r.MemoryLimit, timeout)
+	if err != nil {
+		return errors.WithMessage(err, "could not load WASM module of chaincode "+cname)
+	}
+
+	inst := &instance{
+		chaincode: cc,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	r.mutex.Lock()
+	if r.instances == nil {
+		r.instances = map[string]*instance{}
+	}
+	previous := r.instances[cname]
+	r.instances[cname] = inst
+	r.mutex.Unlock()
+
+	if previous != nil {
+		close(previous.stop)
+	}
+
+	logger.Debugf("starting WASM chaincode %s", cname)
+
+	go func() {
+		inst.err = r.run(cname, inst)
+		close(inst.done)
+	}()
+
+	return nil
+}
+
+// run connects the shim executing the chaincode with the stream handler
+// and returns when either side quits or the chaincode is stopped.
+func (r *WasmChaincodeRuntime) run(cname string, inst *instance) error {
+	peerRcvCCSend := make(chan *pb.ChaincodeMessage)
+	ccRcvPeerSend := make(chan *pb.ChaincodeMessage)
+
+	ccDone := make(chan error, 1)
+	go func() {
+		env := []string{"CORE_CHAINCODE_ID_NAME=" + cname}
+		ccDone <- shim.StartInProc(env, nil, inst.chaincode, ccRcvPeerSend, peerRcvCCSend)
+	}()
+
+	handlerDone := make(chan error, 1)
+	go func() {
+		handlerDone <- r.StreamHandler.HandleChaincodeStream(newInProcStream(peerRcvCCSend, ccRcvPeerSend))
+	}()
+
+	select {
+	case err := <-ccDone:
+		close(peerRcvCCSend)
+		logger.Debugf("WASM chaincode %s quit", cname)
+		if err != nil {
+			return fmt.Errorf("chaincode ended with err: %s", err)
+		}
+	case err := <-handlerDone:
+		close(ccRcvPeerSend)
+		logger.Debugf("chaincode support of WASM chaincode %s quit", cname)
+		if err != nil {
+			return fmt.Errorf("chaincode-support ended with err: %s", err)
+		}
+	case <-inst.stop:
+		close(ccRcvPeerSend)
+		close(peerRcvCCSend)
+		logger.Debugf("WASM chaincode %s stopped", cname)
+	}
+	return nil
+}
+
+// Stop stops the chaincode.
+func (r *WasmChaincodeRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
+	cname := ccci.Name + ":" + ccci.Version
+
+	r.mutex.Lock()
+	inst, ok := r.instances[cname]
+	delete(r.instances, cname)
+	r.mutex.Unlock()
+
+	if !ok {
+		return errors.Errorf("chaincode %s is not running", cname)
+	}
+
+	close(inst.stop)
+	return nil
+}
+
+// Wait waits for the chaincode to terminate.
+func (r *WasmChaincodeRuntime) Wait(ccci *ccprovider.ChaincodeContainerInfo) (int, error) {
+	cname := ccci.Name + ":" + ccci.Version
+
+	r.mutex.Lock()
+	inst, ok := r.instances[cname]
+	r.mutex.Unlock()
+
+	if !ok {
+		return -1, errors.Errorf("chaincode %s is not running", cname)
+	}
+
+	<-inst.done
+	return 0, inst.err
+}
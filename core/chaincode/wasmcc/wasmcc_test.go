@@ -0,0 +1,263 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmcc_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/chaincode/wasmcc"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModule = "../platforms/wasm/testdata/chaincode/chaincode.wasm"
+
+func readModule(t *testing.T) []byte {
+	module, err := ioutil.ReadFile(testModule)
+	require.NoError(t, err)
+	return module
+}
+
+func codePackage(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0100644, Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func args(values ...string) [][]byte {
+	var args [][]byte
+	for _, v := range values {
+		args = append(args, []byte(v))
+	}
+	return args
+}
+
+// decodeList decodes a list of the ABI
+func decodeList(t *testing.T, list []byte) []string {
+	var entries []string
+	for len(list) > 0 {
+		require.True(t, len(list) >= 4)
+		length := binary.LittleEndian.Uint32(list)
+		entries = append(entries, string(list[4:4+length]))
+		list = list[4+length:]
+	}
+	return entries
+}
+
+func newMockStub(t *testing.T, memoryLimit int64, timeout time.Duration) *shim.MockStub {
+	cc, err := wasmcc.NewChaincode(readModule(t), memoryLimit, timeout)
+	require.NoError(t, err)
+	stub := shim.NewMockStub("wasmcc", cc)
+	stub.ChannelID = "testchannel"
+	return stub
+}
+
+func TestChaincodeState(t *testing.T) {
+	stub := newMockStub(t, 0, 0)
+
+	res := stub.MockInit("tx0", nil)
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("initialized"), stub.State["init"])
+
+	res = stub.MockInvoke("tx1", args("p", "a", "apple"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	res = stub.MockInvoke("tx2", args("p", "b", "banana"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+
+	res = stub.MockInvoke("tx3", args("g", "a"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("apple"), res.Payload)
+
+	res = stub.MockInvoke("tx4", args("g", "missing"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Empty(t, res.Payload)
+
+	res = stub.MockInvoke("tx5", args("r", "a", "c"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []string{"a", "apple", "b", "banana"}, decodeList(t, res.Payload))
+
+	res = stub.MockInvoke("tx6", args("d", "a"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.NotContains(t, stub.State, "a")
+}
+
+func TestChaincodePrivateData(t *testing.T) {
+	stub := newMockStub(t, 0, 0)
+
+	res := stub.MockInvoke("tx1", args("P", "coll", "a", "apple"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	res = stub.MockInvoke("tx2", args("P", "coll", "b", "banana"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("apple"), stub.PvtState["coll"]["a"])
+
+	res = stub.MockInvoke("tx3", args("G", "coll", "b"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("banana"), res.Payload)
+
+	res = stub.MockInvoke("tx4", args("R", "coll", "a", "c"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []string{"a", "apple", "b", "banana"}, decodeList(t, res.Payload))
+
+	res = stub.MockInvoke("tx5", args("D", "coll", "a"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.NotContains(t, stub.PvtState["coll"], "a")
+
+	res = stub.MockInvoke("tx6", args("G", "", "a"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "host function get_private_data failed")
+}
+
+func TestChaincodeTransaction(t *testing.T) {
+	stub := newMockStub(t, 0, 0)
+
+	res := stub.MockInvoke("tx1", args("t"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("tx1"), res.Payload)
+
+	res = stub.MockInvoke("tx2", args("c"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, []byte("testchannel"), res.Payload)
+
+	res = stub.MockInvoke("tx3", args("x", "logged"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+
+	res = stub.MockInvoke("tx4", args("e", "first", "payload1", "second", "payload2"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "first", Payload: []byte("payload1")}, <-stub.ChaincodeEventsChannel)
+	assert.Equal(t, &pb.ChaincodeEvent{EventName: "second", Payload: []byte("payload2")}, <-stub.ChaincodeEventsChannel)
+}
+
+func TestChaincodeErrors(t *testing.T) {
+	stub := newMockStub(t, 0, 0)
+
+	res := stub.MockInvoke("tx1", args("f", "something went wrong"))
+	assert.Equal(t, int32(500), res.Status)
+	assert.Equal(t, "something went wrong", res.Message)
+	assert.Empty(t, res.Payload)
+
+	res = stub.MockInvoke("tx2", args("?"))
+	assert.Equal(t, int32(400), res.Status)
+	assert.Equal(t, "unknown function", res.Message)
+
+	res = stub.MockInvoke("tx3", args("b"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "host function get_state failed: out of range memory access at offset 4294967280 of length 1024", res.Message)
+
+	res = stub.MockInvoke("tx4", args("m"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+}
+
+func TestChaincodeMemoryLimit(t *testing.T) {
+	stub := newMockStub(t, 16*65536, 0)
+
+	res := stub.MockInvoke("tx1", args("m"))
+	assert.Equal(t, int32(500), res.Status)
+	assert.Equal(t, "out of memory", res.Message)
+
+	// a fresh instance of the module processes each transaction
+	res = stub.MockInvoke("tx2", args("p", "a", "apple"))
+	assert.Equal(t, int32(shim.OK), res.Status)
+}
+
+func TestChaincodeTimeout(t *testing.T) {
+	stub := newMockStub(t, 0, 100*time.Millisecond)
+
+	res := stub.MockInvoke("tx1", args("l"))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "WASM chaincode invoke timed out after 100ms", res.Message)
+}
+
+func TestNewChaincodeErrors(t *testing.T) {
+	_, err := wasmcc.NewChaincode([]byte("garbage"), 0, 0)
+	assert.Contains(t, err.Error(), "invalid WASM module")
+}
+
+type streamHandler struct {
+	received chan *pb.ChaincodeMessage
+}
+
+func (s *streamHandler) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	s.received <- msg
+	_, err = stream.Recv()
+	return err
+}
+
+func TestWasmChaincodeRuntime(t *testing.T) {
+	handler := &streamHandler{received: make(chan *pb.ChaincodeMessage, 1)}
+	var timeoutFor string
+	var mutex sync.Mutex
+	r := &wasmcc.WasmChaincodeRuntime{
+		StreamHandler: handler,
+		ExecuteTimeout: func(ccName string) time.Duration {
+			mutex.Lock()
+			defer mutex.Unlock()
+			timeoutFor = ccName
+			return time.Second
+		},
+	}
+	ccci := &ccprovider.ChaincodeContainerInfo{Name: "cc", Version: "1.0", ContainerType: wasmcc.ContainerType}
+
+	_, err := r.Wait(ccci)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not running")
+	err = r.Stop(ccci)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not running")
+
+	err = r.Start(ccci, codePackage(t, map[string][]byte{"src/chaincode.wasm": readModule(t)}))
+	require.NoError(t, err)
+	mutex.Lock()
+	assert.Equal(t, "cc", timeoutFor)
+	mutex.Unlock()
+
+	msg := <-handler.received
+	assert.Equal(t, pb.ChaincodeMessage_REGISTER, msg.Type)
+	chaincodeID := &pb.ChaincodeID{}
+	require.NoError(t, proto.Unmarshal(msg.Payload, chaincodeID))
+	assert.Equal(t, "cc:1.0", chaincodeID.Name)
+
+	err = r.Stop(ccci)
+	assert.NoError(t, err)
+
+	exitCode, err := r.Wait(ccci)
+	assert.Equal(t, -1, exitCode)
+	assert.EqualError(t, err, "chaincode cc:1.0 is not running")
+}
+
+func TestWasmChaincodeRuntimeStartErrors(t *testing.T) {
+	r := &wasmcc.WasmChaincodeRuntime{}
+	ccci := &ccprovider.ChaincodeContainerInfo{Name: "cc", Version: "1.0", ContainerType: wasmcc.ContainerType}
+
+	err := r.Start(ccci, []byte("garbage"))
+	assert.Contains(t, err.Error(), "could not extract WASM module of chaincode cc:1.0")
+
+	err = r.Start(ccci, codePackage(t, map[string][]byte{"src/chaincode.wasm": []byte("garbage")}))
+	assert.Contains(t, err.Error(), "could not load WASM module of chaincode cc:1.0")
+}
@@ -37,6 +37,20 @@ func (_m *Capabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// ErasureCodedCollections provides a mock function with given fields:
+func (_m *Capabilities) ErasureCodedCollections() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FabToken provides a mock function with given fields:
 func (_m *Capabilities) FabToken() bool {
 	ret := _m.Called()
@@ -79,6 +93,20 @@ func (_m *Capabilities) KeyLevelEndorsement() bool {
 	return r0
 }
 
+// LifecycleV20 provides a mock function with given fields:
+func (_m *Capabilities) LifecycleV20() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // MetadataLifecycle provides a mock function with given fields:
 func (_m *Capabilities) MetadataLifecycle() bool {
 	ret := _m.Called()
@@ -176,3 +204,17 @@ func (_m *Capabilities) V1_3Validation() bool {
 
 	return r0
 }
+
+// WasmChaincode provides a mock function with given fields:
+func (_m *Capabilities) WasmChaincode() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/configtx"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -53,6 +54,9 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// PolicyManager returns the policy manager of this channel
+	PolicyManager() policies.Manager
 }
 
 //Validator interface which defines API to validate block transactions
@@ -660,6 +664,18 @@ func (ds *dynamicCapabilities) StorePvtDataOfInvalidTx() bool {
 	return ds.support.Capabilities().StorePvtDataOfInvalidTx()
 }
 
+func (ds *dynamicCapabilities) WasmChaincode() bool {
+	return ds.support.Capabilities().WasmChaincode()
+}
+
+func (ds *dynamicCapabilities) ErasureCodedCollections() bool {
+	return ds.support.Capabilities().ErasureCodedCollections()
+}
+
+func (ds *dynamicCapabilities) LifecycleV20() bool {
+	return ds.support.Capabilities().LifecycleV20()
+}
+
 // FabToken returns true if fabric token function is supported.
 func (ds *dynamicCapabilities) FabToken() bool {
 	return ds.support.Capabilities().FabToken()
@@ -21,12 +21,16 @@ import (
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
 	"github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/mocks"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/testdata"
 	ccp "github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/handlers/validation/builtin"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -44,6 +48,7 @@ import (
 	mb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -76,6 +81,10 @@ func v13Capabilities() *mockconfig.MockApplicationCapabilities {
 	return &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, PrivateChannelDataRv: true, V1_3ValidationRv: true, KeyLevelEndorsementRv: true}
 }
 
+func v20Capabilities() *mockconfig.MockApplicationCapabilities {
+	return &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, PrivateChannelDataRv: true, V1_3ValidationRv: true, KeyLevelEndorsementRv: true, LifecycleV20Rv: true}
+}
+
 func fabTokenCapabilities() *mockconfig.MockApplicationCapabilities {
 	return &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true, FabTokenRv: true}
 }
@@ -96,6 +105,10 @@ func setupLedgerAndValidatorWithV13Capabilities(t *testing.T) (ledger.PeerLedger
 	return setupLedgerAndValidatorWithCapabilities(t, v13Capabilities())
 }
 
+func setupLedgerAndValidatorWithV20Capabilities(t *testing.T) (ledger.PeerLedger, txvalidator.Validator) {
+	return setupLedgerAndValidatorWithCapabilities(t, v20Capabilities())
+}
+
 func setupLedgerAndValidatorWithFabTokenCapabilities(t *testing.T) (ledger.PeerLedger, txvalidator.Validator) {
 	return setupLedgerAndValidatorWithCapabilities(t, fabTokenCapabilities())
 }
@@ -111,6 +124,10 @@ func setupLedgerAndValidatorWithCapabilities(t *testing.T, c *mockconfig.MockApp
 }
 
 func setupLedgerAndValidatorExplicitWithMSP(t *testing.T, cpb *mockconfig.MockApplicationCapabilities, plugin validation.Plugin, mspMgr msp.MSPManager) (ledger.PeerLedger, txvalidator.Validator) {
+	return setupLedgerAndValidatorExplicitWithPolicyManager(t, cpb, plugin, mspMgr, nil)
+}
+
+func setupLedgerAndValidatorExplicitWithPolicyManager(t *testing.T, cpb *mockconfig.MockApplicationCapabilities, plugin validation.Plugin, mspMgr msp.MSPManager, policyManager policies.Manager) (ledger.PeerLedger, txvalidator.Validator) {
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/validatortest")
 	ledgermgmt.InitializeTestEnv()
 	gb, err := ctxt.MakeGenesisBlock("TestLedger")
@@ -120,7 +137,7 @@ func setupLedgerAndValidatorExplicitWithMSP(t *testing.T, cpb *mockconfig.MockAp
 	vcs := struct {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
-	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: cpb, MSPManagerVal: mspMgr}, semaphore.NewWeighted(10)}
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: cpb, MSPManagerVal: mspMgr, PolicyManagerVal: policyManager}, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	pm := &mocks.PluginMapper{}
 	factory := &mocks.PluginFactory{}
@@ -1133,6 +1150,232 @@ func testInvokeOKSCC(t *testing.T, l ledger.PeerLedger, v txvalidator.Validator)
 	assertValid(b, t)
 }
 
+func TestInvokeLifecycle(t *testing.T) {
+	implicitCollection := privdata.ImplicitCollectionNameForOrg("SampleOrg")
+
+	t.Run("ApprovalOfSingleOrg", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, nil, implicitCollection)
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+	})
+
+	t.Run("CommittedDefinition", func(t *testing.T) {
+		policy := &lifecycleEndorsementPolicy{}
+		l, v := setupLedgerAndValidatorWithLifecycleEndorsement(t, policy)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, []string{"cc"})
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+		assert.Len(t, policy.signatureSet, 1)
+		assert.Equal(t, signerSerialized, policy.signatureSet[0].Identity)
+	})
+
+	t.Run("CommittedDefinitionNotEndorsedByEnoughOrgs", func(t *testing.T) {
+		policy := &lifecycleEndorsementPolicy{err: errors.New("signature set did not satisfy policy")}
+		l, v := setupLedgerAndValidatorWithLifecycleEndorsement(t, policy)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, []string{"cc"})
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	})
+
+	t.Run("CommittedDefinitionWithoutLifecycleEndorsementPolicy", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, []string{"cc"})
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	})
+
+	t.Run("ApprovalsOfMultipleOrgs", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, nil, implicitCollection, privdata.ImplicitCollectionNameForOrg("OtherOrg"))
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+
+	t.Run("ApprovalAndDefinition", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, []string{"cc"}, implicitCollection)
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+
+	t.Run("CommittedDefinitionWithoutLifecycleV20", func(t *testing.T) {
+		// without the capability the state of the lifecycle SCC is not
+		// read, so writing to it is not allowed
+		policy := &lifecycleEndorsementPolicy{}
+		mspmgr := &mocks2.MSPManager{}
+		idThatSatisfiesPrincipal := &mocks2.Identity{}
+		idThatSatisfiesPrincipal.SatisfiesPrincipalReturns(nil)
+		idThatSatisfiesPrincipal.GetIdentifierReturns(&msp.IdentityIdentifier{})
+		mspmgr.DeserializeIdentityReturns(idThatSatisfiesPrincipal, nil)
+		policyManager := &mockpolicies.Manager{
+			PolicyMap: map[string]policies.Policy{
+				lifecycle.LifecycleEndorsementPolicyRef: policy,
+			},
+		}
+		l, v := setupLedgerAndValidatorExplicitWithPolicyManager(t, v13Capabilities(), &builtin.DefaultValidation{}, mspmgr, policyManager)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, []string{"cc"})
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+		assert.Nil(t, policy.signatureSet)
+
+		b = lifecycleBlock(t, nil, implicitCollection)
+		err = v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+
+	t.Run("WriteToExplicitCollection", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		b := lifecycleBlock(t, nil, "mycollection")
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+}
+
+// lifecycleEndorsementPolicy records the signature set it evaluates
+type lifecycleEndorsementPolicy struct {
+	err          error
+	signatureSet []*common.SignedData
+}
+
+func (p *lifecycleEndorsementPolicy) Evaluate(signatureSet []*common.SignedData) error {
+	p.signatureSet = signatureSet
+	return p.err
+}
+
+func setupLedgerAndValidatorWithLifecycleEndorsement(t *testing.T, policy policies.Policy) (ledger.PeerLedger, txvalidator.Validator) {
+	mspmgr := &mocks2.MSPManager{}
+	idThatSatisfiesPrincipal := &mocks2.Identity{}
+	idThatSatisfiesPrincipal.SatisfiesPrincipalReturns(nil)
+	idThatSatisfiesPrincipal.GetIdentifierReturns(&msp.IdentityIdentifier{})
+	mspmgr.DeserializeIdentityReturns(idThatSatisfiesPrincipal, nil)
+
+	policyManager := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			lifecycle.LifecycleEndorsementPolicyRef: policy,
+		},
+	}
+	return setupLedgerAndValidatorExplicitWithPolicyManager(t, v20Capabilities(), &builtin.DefaultValidation{}, mspmgr, policyManager)
+}
+
+// lifecycleBlock returns a block with a transaction of the lifecycle SCC which
+// writes the given public keys, and writes a key to each of the given collections
+func lifecycleBlock(t *testing.T, keys []string, collections ...string) *common.Block {
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	for _, key := range keys {
+		rwsetBuilder.AddToWriteSet(lifecycle.LifecycleNamespace, key, []byte("value"))
+	}
+	for _, collection := range collections {
+		rwsetBuilder.AddToPvtAndHashedWriteSet(lifecycle.LifecycleNamespace, collection, lifecycle.ApprovalKey("cc"), []byte("value"))
+	}
+	rwset, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := rwset.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	tx := getEnv(lifecycle.LifecycleNamespace, nil, rwsetBytes, t)
+	return &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 1}}
+}
+
+func TestInvokeOKLifecycleDefinedCC(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithV20Capabilities(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	putCCDefinition(l, ccID, &lb.ChaincodeDefinition{
+		Sequence:            1,
+		Version:             ccVersion,
+		EndorsementPlugin:   "escc",
+		ValidationPlugin:    "vscc",
+		ValidationParameter: signedByAnyMember([]string{"SampleOrg"}),
+	}, t)
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertValid(b, t)
+}
+
+func TestInvokeLifecycleDefinedCCWithoutLifecycleV20(t *testing.T) {
+	l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+	defer ledgermgmt.CleanupTestEnv()
+	defer l.Close()
+
+	ccID := "mycc"
+
+	// Without the capability the definition of the lifecycle SCC is ignored,
+	// so a chaincode which was not instantiated through lscc is unknown
+	putCCDefinition(l, ccID, &lb.ChaincodeDefinition{
+		Sequence:            1,
+		Version:             ccVersion,
+		EndorsementPlugin:   "escc",
+		ValidationPlugin:    "vscc",
+		ValidationParameter: signedByAnyMember([]string{"SampleOrg"}),
+	}, t)
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+}
+
+func putCCDefinition(theLedger ledger.PeerLedger, ccname string, definition *lb.ChaincodeDefinition, t *testing.T) {
+	txid := util.GenerateUUID()
+	simulator, err := theLedger.NewTxSimulator(txid)
+	assert.NoError(t, err)
+	simulator.SetState(lifecycle.LifecycleNamespace, ccname, utils.MarshalOrPanic(definition))
+	simulator.Done()
+
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimulationBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	bcInfo, err := theLedger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	block0 := testutil.ConstructBlock(t, 1, bcInfo.CurrentBlockHash, [][]byte{pubSimulationBytes}, true)
+	err = theLedger.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block0}, &ledger.CommitOptions{})
+	assert.NoError(t, err)
+}
+
 func TestInvokeNOKWritesToLSCC(t *testing.T) {
 	t.Run("1.2Capability", func(t *testing.T) {
 		l, v := setupLedgerAndValidatorWithV12Capabilities(t)
@@ -16,7 +16,9 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	coreUtil "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -137,6 +139,13 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			continue
 		}
 
+		// the state of the lifecycle SCC is only read when the channel has
+		// the capability, so it may not be written to before it's enabled
+		if ns.NameSpace == lifecycle.LifecycleNamespace && !v.support.Capabilities().LifecycleV20() {
+			return errors.Errorf("transaction %s attempted to write to the namespace of the lifecycle SCC without the V2_0 application capability", chdr.TxId),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+
 		// Check to make sure we did not already populate this chaincode
 		// name to avoid checking the same namespace twice
 		if ns.NameSpace != ccID || !alwaysEnforceOriginalNamespace {
@@ -239,6 +248,26 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 			return err, peer.TxValidationCode_INVALID_OTHER_REASON
 		}
 
+		// the writes of the lifecycle SCC are not endorsed by any member
+		// of the channel, but by the orgs on whose behalf they are made
+		if ccID == lifecycle.LifecycleNamespace && v.support.Capabilities().LifecycleV20() {
+			policy, err = v.lifecyclePolicy(txRWSet)
+			if err != nil {
+				logger.Errorf("lifecyclePolicy for txId = %s returned error: %+v", chdr.TxId, err)
+				return err, peer.TxValidationCode_ILLEGAL_WRITESET
+			}
+			if policy == nil {
+				// the commit of a chaincode definition is governed by a channel
+				// policy rather than by a signature policy which VSCC evaluates
+				if err = v.checkLifecycleEndorsement(payload); err != nil {
+					logger.Errorf("checkLifecycleEndorsement for txId = %s returned error: %+v", chdr.TxId, err)
+					return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+				}
+				logger.Debugf("[%s] VSCCValidateTx completes env bytes %p", chainID, envBytes)
+				return nil, peer.TxValidationCode_VALID
+			}
+		}
+
 		// validate the transaction as an invocation of this system chaincode;
 		// vscc will have to do custom validation for this system chaincode
 		// currently, VSCC does custom validation for LSCC only; if an hlf
@@ -293,6 +322,26 @@ func (v *VsccValidatorImpl) getCDataForCC(chid, ccid string) (ccprovider.Chainco
 	}
 	defer qe.Done()
 
+	// chaincodes defined through the lifecycle SCC take precedence
+	// over the ones instantiated through lscc
+	if v.support.Capabilities().LifecycleV20() {
+		definition, err := lifecycle.GetChaincodeDefinition(ccid, qe)
+		if err != nil {
+			return nil, &commonerrors.VSCCInfoLookupFailureError{
+				Reason: fmt.Sprintf("Could not retrieve definition for chaincode %s, error %s", ccid, err),
+			}
+		}
+		if definition != nil {
+			if definition.Definition.ValidationPlugin == "" {
+				return nil, errors.Errorf("definition of [%s] is invalid, validation plugin must be set", ccid)
+			}
+			if len(definition.Definition.ValidationParameter) == 0 {
+				return nil, errors.Errorf("definition of [%s] is invalid, validation parameter must be set", ccid)
+			}
+			return definition, nil
+		}
+	}
+
 	bytes, err := qe.GetState("lscc", ccid)
 	if err != nil {
 		return nil, &commonerrors.VSCCInfoLookupFailureError{
@@ -364,6 +413,93 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 	return cc, vscc, policy, nil
 }
 
+// lifecyclePolicy returns the policy which the writes to the namespace of
+// the lifecycle SCC must satisfy. The approval of an org is written to the
+// implicit collection of that org and must be endorsed by a member of that
+// org. It returns a nil policy for the commit of a chaincode definition,
+// which is governed by the LifecycleEndorsement policy of the channel.
+func (v *VsccValidatorImpl) lifecyclePolicy(txRWSet *rwsetutil.TxRwSet) ([]byte, error) {
+	var nsRWSet *rwsetutil.NsRwSet
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.NameSpace == lifecycle.LifecycleNamespace {
+			nsRWSet = ns
+		}
+	}
+	if nsRWSet == nil {
+		return nil, nil
+	}
+
+	approvingOrgs := map[string]struct{}{}
+	approvingOrg := ""
+	for _, coll := range nsRWSet.CollHashedRwSets {
+		if coll.HashedRwSet == nil || (len(coll.HashedRwSet.HashedWrites) == 0 && len(coll.HashedRwSet.MetadataWrites) == 0) {
+			continue
+		}
+		org, isImplicit := privdata.MSPIDIfImplicitCollection(coll.CollectionName)
+		if !isImplicit {
+			return nil, errors.Errorf("write to collection %s, which is not the implicit collection of an org", coll.CollectionName)
+		}
+		approvingOrgs[org] = struct{}{}
+		approvingOrg = org
+	}
+
+	switch {
+	case len(approvingOrgs) > 1:
+		return nil, errors.New("approvals of multiple orgs written in the same transaction")
+	case len(approvingOrgs) == 1 && nsRWSet.KvRwSet != nil && len(nsRWSet.KvRwSet.Writes) > 0:
+		return nil, errors.Errorf("approval of org %s written along with a chaincode definition in the same transaction", approvingOrg)
+	case len(approvingOrgs) == 1:
+		return utils.Marshal(cauthdsl.SignedByMspMember(approvingOrg))
+	}
+	return nil, nil
+}
+
+// checkLifecycleEndorsement evaluates the LifecycleEndorsement policy of the
+// channel over the endorsements of the transaction
+func (v *VsccValidatorImpl) checkLifecycleEndorsement(payload *common.Payload) error {
+	policy, ok := v.support.PolicyManager().GetPolicy(lifecycle.LifecycleEndorsementPolicyRef)
+	if !ok {
+		return errors.Errorf("policy %s is not defined on the channel", lifecycle.LifecycleEndorsementPolicyRef)
+	}
+
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return err
+	}
+	if len(tx.Actions) != 1 {
+		return errors.Errorf("only one action per transaction is supported, tx contains %d", len(tx.Actions))
+	}
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return err
+	}
+	if cap.Action == nil {
+		return errors.New("nil chaincode endorsed action")
+	}
+
+	prp := cap.Action.ProposalResponsePayload
+	var signatureSet []*common.SignedData
+	for _, endorsement := range cap.Action.Endorsements {
+		data := make([]byte, len(prp)+len(endorsement.Endorser))
+		copy(data, prp)
+		copy(data[len(prp):], endorsement.Endorser)
+
+		signatureSet = append(signatureSet, &common.SignedData{
+			// set the data that is signed; concatenation of proposal response bytes and endorser ID
+			Data: data,
+			// set the identity that signs the message: it's the endorser
+			Identity: endorsement.Endorser,
+			// set the signature
+			Signature: endorsement.Signature,
+		})
+	}
+
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("endorsements do not satisfy policy %s", lifecycle.LifecycleEndorsementPolicyRef))
+	}
+	return nil
+}
+
 // txWritesToNamespace returns true if the supplied NsRwSet
 // performs a ledger write
 func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/golang/protobuf/proto"
@@ -515,6 +516,52 @@ type TransactionParams struct {
 
 	// this is additional data passed to the chaincode
 	ProposalDecorations map[string][]byte
+
+	// CrossChannelReads, when set, collects the reads performed by the
+	// chaincodes invoked on other channels during the transaction
+	CrossChannelReads *CrossChannelReads
+
+	// ChaincodeEvents is set by the execution of the chaincode to the events
+	// it set, in order, when it set more than one
+	ChaincodeEvents []*pb.ChaincodeEvent
+}
+
+// CrossChannelReads collects the reads performed on other channels by the
+// chaincodes invoked read-only across channels during a transaction.
+type CrossChannelReads struct {
+	mutex   sync.Mutex
+	reads   []*pb.CrossChannelRead
+	heights []uint64
+}
+
+// Add records the reads of a cross-channel invocation, performed at the
+// given height of the ledger of the other channel.
+func (c *CrossChannelReads) Add(read *pb.CrossChannelRead, blockHeight uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reads = append(c.reads, read)
+	c.heights = append(c.heights, blockHeight)
+}
+
+// Reads returns the reads recorded so far, in the order of the invocations.
+func (c *CrossChannelReads) Reads() []*pb.CrossChannelRead {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]*pb.CrossChannelRead(nil), c.reads...)
+}
+
+// Heights returns the heights of the ledgers at which the recorded reads
+// were performed, in the order of the reads.
+func (c *CrossChannelReads) Heights() []uint64 {
+	if c == nil {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]uint64(nil), c.heights...)
 }
 
 // ChaincodeProvider provides an abstraction layer that is
@@ -532,11 +579,17 @@ type ChaincodeProvider interface {
 }
 
 func DeploymentSpecToChaincodeContainerInfo(cds *pb.ChaincodeDeploymentSpec) *ChaincodeContainerInfo {
+	containerType := cds.ExecEnv.String()
+	if cds.CCType() == pb.ChaincodeSpec_WASM.String() {
+		// chaincode compiled to WebAssembly is executed in-process
+		containerType = pb.ChaincodeSpec_WASM.String()
+	}
+
 	return &ChaincodeContainerInfo{
 		Name:          cds.Name(),
 		Version:       cds.Version(),
 		Path:          cds.Path(),
 		Type:          cds.CCType(),
-		ContainerType: cds.ExecEnv.String(),
+		ContainerType: containerType,
 	}
 }
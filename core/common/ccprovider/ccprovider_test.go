@@ -162,3 +162,28 @@ func setupDirectoryStructure(t *testing.T) (string, map[string][]byte) {
 
 	return tmp, hashes
 }
+
+func TestCrossChannelReads(t *testing.T) {
+	var nilReads *ccprovider.CrossChannelReads
+	assert.Nil(t, nilReads.Reads())
+	assert.Nil(t, nilReads.Heights())
+
+	reads := &ccprovider.CrossChannelReads{}
+	assert.Empty(t, reads.Reads())
+	assert.Empty(t, reads.Heights())
+
+	first := &peer.CrossChannelRead{ChannelId: "channel1", ChaincodeName: "cc1"}
+	second := &peer.CrossChannelRead{ChannelId: "channel2", ChaincodeName: "cc2"}
+	reads.Add(first, 1)
+	reads.Add(second, 2)
+	recorded := reads.Reads()
+	assert.Equal(t, []*peer.CrossChannelRead{first, second}, recorded)
+	heights := reads.Heights()
+	assert.Equal(t, []uint64{1, 2}, heights)
+
+	// the recorded reads and heights are copies
+	recorded[0] = nil
+	heights[0] = 0
+	assert.Equal(t, []*peer.CrossChannelRead{first, second}, reads.Reads())
+	assert.Equal(t, []uint64{1, 2}, reads.Heights())
+}
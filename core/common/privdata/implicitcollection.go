@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+// implicitCollectionPrefix prefixes the names of the implicit collections,
+// which hold the org-scoped state of the lifecycle SCC
+const implicitCollectionPrefix = "_implicit_org_"
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the given org
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return implicitCollectionPrefix + mspID
+}
+
+// MSPIDIfImplicitCollection returns the MSP ID of the org whose implicit
+// collection has the given name, and false if the collection isn't implicit
+func MSPIDIfImplicitCollection(collectionName string) (string, bool) {
+	if !strings.HasPrefix(collectionName, implicitCollectionPrefix) {
+		return "", false
+	}
+	mspID := strings.TrimPrefix(collectionName, implicitCollectionPrefix)
+	if mspID == "" {
+		return "", false
+	}
+	return mspID, true
+}
+
+// GenerateImplicitCollectionForOrg returns the configuration of the implicit
+// collection of the given org, which is disseminated to and readable by the
+// members of that org only. Implicit collections are not defined by any
+// chaincode; they exist in the namespace of the lifecycle SCC for every org.
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+		MemberOnlyRead: true,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
@@ -26,6 +27,10 @@ type Support interface {
 	// GetIdentityDeserializer returns an IdentityDeserializer
 	// instance for the specified chain
 	GetIdentityDeserializer(chainID string) msp.IdentityDeserializer
+
+	// GetApplicationCapabilities returns the application capabilities of
+	// the specified chain, or nil if they are not known yet
+	GetApplicationCapabilities(chainID string) channelconfig.ApplicationCapabilities
 }
 
 // StateGetter retrieves data from the state
@@ -34,6 +39,9 @@ type State interface {
 	GetState(namespace string, key string) ([]byte, error)
 }
 
+// lifecycleNamespace is the namespace of the lifecycle SCC
+const lifecycleNamespace = "+lifecycle"
+
 type NoSuchCollectionError common.CollectionCriteria
 
 func (f NoSuchCollectionError) Error() string {
@@ -52,9 +60,22 @@ func NewSimpleCollectionStore(s Support) CollectionStore {
 	return &simpleCollectionStore{s}
 }
 
+// lifecycleEnabled returns whether chaincodes may be defined through the lifecycle
+// SCC on the specified chain. When the capabilities of the chain are not known yet,
+// the state is trusted, as the lifecycle SCC can only be written to with the capability.
+func (c *simpleCollectionStore) lifecycleEnabled(chainID string) bool {
+	ac := c.s.GetApplicationCapabilities(chainID)
+	return ac == nil || ac.LifecycleV20()
+}
+
 func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.CollectionConfigPackage, error) {
+	retrieve := RetrieveCollectionConfigPackageFromState
+	if c.lifecycleEnabled(cc.Channel) {
+		retrieve = retrieveCollectionConfigPackageFromState
+	}
+
 	if qe != nil {
-		return RetrieveCollectionConfigPackageFromState(cc, qe)
+		return retrieve(cc, qe)
 	}
 
 	qe, err := c.s.GetQueryExecutorForLedger(cc.Channel)
@@ -62,12 +83,30 @@ func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.Collec
 		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve query executor for collection criteria %#v", cc))
 	}
 	defer qe.Done()
-	return RetrieveCollectionConfigPackageFromState(cc, qe)
+	return retrieve(cc, qe)
+}
+
+// retrieveCollectionConfigPackageFromState retrieves the collection config package of
+// a chaincode defined through the lifecycle SCC, which follows the state layout of lscc,
+// and falls back to lscc for chaincodes which were not defined through the lifecycle SCC
+func retrieveCollectionConfigPackageFromState(cc common.CollectionCriteria, state State) (*common.CollectionConfigPackage, error) {
+	definition, err := state.GetState(lifecycleNamespace, cc.Namespace)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving definition for collection criteria %#v", cc))
+	}
+	if definition == nil {
+		return RetrieveCollectionConfigPackageFromState(cc, state)
+	}
+	return retrieveCollectionConfigPackageFromNamespace(lifecycleNamespace, cc, state)
 }
 
 // RetrieveCollectionConfigPackageFromState retrieves the collection config package from the given key from the given state
 func RetrieveCollectionConfigPackageFromState(cc common.CollectionCriteria, state State) (*common.CollectionConfigPackage, error) {
-	cb, err := state.GetState("lscc", BuildCollectionKVSKey(cc.Namespace))
+	return retrieveCollectionConfigPackageFromNamespace("lscc", cc, state)
+}
+
+func retrieveCollectionConfigPackageFromNamespace(namespace string, cc common.CollectionCriteria, state State) (*common.CollectionConfigPackage, error) {
+	cb, err := state.GetState(namespace, BuildCollectionKVSKey(cc.Namespace))
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection for collection criteria %#v", cc))
 	}
@@ -93,6 +132,15 @@ func ParseCollectionConfig(colBytes []byte) (*common.CollectionConfigPackage, er
 }
 
 func (c *simpleCollectionStore) retrieveCollectionConfig(cc common.CollectionCriteria, qe ledger.QueryExecutor) (*common.StaticCollectionConfig, error) {
+	if cc.Namespace == lifecycleNamespace && c.lifecycleEnabled(cc.Channel) {
+		// the lifecycle SCC only has the implicit collections of the orgs
+		mspID, isImplicit := MSPIDIfImplicitCollection(cc.Collection)
+		if !isImplicit {
+			return nil, NoSuchCollectionError(cc)
+		}
+		return GenerateImplicitCollectionForOrg(mspID), nil
+	}
+
 	collections, err := c.retrieveCollectionConfigPackage(cc, qe)
 	if err != nil {
 		return nil, err
@@ -11,6 +11,8 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mc "github.com/hyperledger/fabric/common/mocks/config"
 	lm "github.com/hyperledger/fabric/common/mocks/ledger"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
@@ -22,8 +24,9 @@ import (
 )
 
 type mockStoreSupport struct {
-	Qe   *lm.MockQueryExecutor
-	QErr error
+	Qe           *lm.MockQueryExecutor
+	QErr         error
+	Capabilities channelconfig.ApplicationCapabilities
 }
 
 func (c *mockStoreSupport) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
@@ -34,6 +37,100 @@ func (c *mockStoreSupport) GetIdentityDeserializer(chainID string) msp.IdentityD
 	return &mockDeserializer{}
 }
 
+func (c *mockStoreSupport) GetApplicationCapabilities(chainID string) channelconfig.ApplicationCapabilities {
+	return c.Capabilities
+}
+
+func TestCollectionStoreLifecycle(t *testing.T) {
+	wState := map[string]map[string][]byte{
+		"lscc":             {},
+		lifecycleNamespace: {},
+	}
+	support := &mockStoreSupport{
+		Qe:           &lm.MockQueryExecutor{State: wState},
+		Capabilities: &mc.MockApplicationCapabilities{LifecycleV20Rv: true},
+	}
+	cs := NewSimpleCollectionStore(support)
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: "mycollection"}
+
+	collectionPackage := func(name string) []byte {
+		ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{Name: name},
+			},
+		}}}
+		ccpBytes, err := proto.Marshal(ccp)
+		assert.NoError(t, err)
+		return ccpBytes
+	}
+
+	// The chaincode is instantiated through lscc
+	wState["lscc"][BuildCollectionKVSKey(ccr.Namespace)] = collectionPackage("lscc-collection")
+	ccp, err := cs.RetrieveCollectionConfigPackage(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "lscc-collection", ccp.Config[0].GetStaticCollectionConfig().Name)
+
+	// The chaincode is defined through the lifecycle SCC, which supersedes lscc
+	wState[lifecycleNamespace][ccr.Namespace] = []byte("definition")
+	wState[lifecycleNamespace][BuildCollectionKVSKey(ccr.Namespace)] = collectionPackage("lifecycle-collection")
+	ccp, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "lifecycle-collection", ccp.Config[0].GetStaticCollectionConfig().Name)
+
+	// The definition of the chaincode has no collections
+	delete(wState[lifecycleNamespace], BuildCollectionKVSKey(ccr.Namespace))
+	_, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.Equal(t, NoSuchCollectionError(ccr), err)
+
+	// The lifecycle SCC has the implicit collections of the orgs only
+	implicit := common.CollectionCriteria{Channel: "ch", Namespace: lifecycleNamespace, Collection: ImplicitCollectionNameForOrg("Org1MSP")}
+	ap, err := cs.RetrieveCollectionAccessPolicy(implicit)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Org1MSP"}, ap.MemberOrgs())
+	assert.True(t, ap.IsMemberOnlyRead())
+	pc, err := cs.RetrieveCollectionPersistenceConfigs(implicit)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pc.BlockToLive())
+
+	notImplicit := common.CollectionCriteria{Channel: "ch", Namespace: lifecycleNamespace, Collection: "mycollection"}
+	_, err = cs.RetrieveCollectionAccessPolicy(notImplicit)
+	assert.Equal(t, NoSuchCollectionError(notImplicit), err)
+
+	// Without the capability only lscc is consulted
+	support.Capabilities = &mc.MockApplicationCapabilities{}
+	wState[lifecycleNamespace][BuildCollectionKVSKey(ccr.Namespace)] = collectionPackage("lifecycle-collection")
+	ccp, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "lscc-collection", ccp.Config[0].GetStaticCollectionConfig().Name)
+	_, err = cs.RetrieveCollectionAccessPolicy(implicit)
+	assert.Equal(t, NoSuchCollectionError(implicit), err)
+
+	// and the state is trusted until the capabilities are known
+	support.Capabilities = nil
+	ccp, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "lifecycle-collection", ccp.Config[0].GetStaticCollectionConfig().Name)
+}
+
+func TestImplicitCollections(t *testing.T) {
+	name := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", name)
+
+	mspID, isImplicit := MSPIDIfImplicitCollection(name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	_, isImplicit = MSPIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+	_, isImplicit = MSPIDIfImplicitCollection("_implicit_org_")
+	assert.False(t, isImplicit)
+
+	conf := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, name, conf.Name)
+	assert.True(t, conf.MemberOnlyRead)
+	assert.Equal(t, cauthdsl.SignedByMspMember("Org1MSP"), conf.MemberOrgsPolicy.GetSignaturePolicy())
+}
+
 func TestCollectionStore(t *testing.T) {
 	wState := make(map[string]map[string][]byte)
 	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{State: wState}}
@@ -46,6 +143,7 @@ func TestCollectionStore(t *testing.T) {
 
 	support.QErr = nil
 	wState["lscc"] = make(map[string][]byte)
+	wState[lifecycleNamespace] = make(map[string][]byte)
 
 	_, err = cs.RetrieveCollection(common.CollectionCriteria{})
 	assert.Error(t, err)
@@ -46,3 +46,32 @@ func (ccid *CCID) GetName() string {
 	}
 	return ccid.Name
 }
+
+// PeerConnection contains the information chaincode needs to connect
+// to the peer
+type PeerConnection struct {
+	Address   string
+	TLSConfig *TLSConfig
+}
+
+// TLSConfig contains the PEM-encoded TLS material chaincode uses to
+// connect to the peer
+type TLSConfig struct {
+	ClientCert []byte
+	ClientKey  []byte
+	RootCert   []byte
+}
+
+// ResourceLimits are the limits on the resources of a chaincode container.
+// A zero value keeps the setting of the container runtime.
+type ResourceLimits struct {
+	// Memory is the memory limit in bytes
+	Memory int64
+	// CPUShares is the relative CPU weight of the container
+	CPUShares int64
+	// CPUQuota is the CPU time in microseconds the container may use
+	// during each CPUPeriod
+	CPUQuota int64
+	// CPUPeriod is the length in microseconds of a CPU scheduling period
+	CPUPeriod int64
+}
@@ -38,7 +38,8 @@ var _ = Describe("Container", func() {
 					FilesToUpload: map[string][]byte{
 						"Foo": []byte("bar"),
 					},
-					Builder: &mock.Builder{},
+					Builder:   &mock.Builder{},
+					Resources: &ccintf.ResourceLimits{Memory: 1024},
 				}
 			})
 
@@ -47,7 +48,7 @@ var _ = Describe("Container", func() {
 					err := startReq.Do(fakeVM)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(fakeVM.StartCallCount()).To(Equal(1))
-					ccid, args, env, filesToUpload, builder := fakeVM.StartArgsForCall(0)
+					ccid, args, env, filesToUpload, builder, resources := fakeVM.StartArgsForCall(0)
 					Expect(ccid).To(Equal(ccintf.CCID{Name: "start-name"}))
 					Expect(args).To(Equal([]string{"foo", "bar"}))
 					Expect(env).To(Equal([]string{"Bar", "Foo"}))
@@ -55,6 +56,7 @@ var _ = Describe("Container", func() {
 						"Foo": []byte("bar"),
 					}))
 					Expect(builder).To(Equal(&mock.Builder{}))
+					Expect(resources).To(Equal(&ccintf.ResourceLimits{Memory: 1024}))
 				})
 
 				Context("when the vm provider fails", func() {
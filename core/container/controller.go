@@ -28,7 +28,7 @@ type Builder interface {
 
 //VM is an abstract virtual image for supporting arbitrary virual machines
 type VM interface {
-	Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder Builder) error
+	Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder Builder, resources *ccintf.ResourceLimits) error
 	Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error
 	Wait(ccid ccintf.CCID) (int, error)
 	HealthCheck(context.Context) error
@@ -118,6 +118,7 @@ type StartContainerReq struct {
 	Args          []string
 	Env           []string
 	FilesToUpload map[string][]byte
+	Resources     *ccintf.ResourceLimits
 }
 
 // PlatformBuilder implements the Build interface using
@@ -148,7 +149,7 @@ func (b *PlatformBuilder) Build() (io.Reader, error) {
 }
 
 func (si StartContainerReq) Do(v VM) error {
-	return v.Start(si.CCID, si.Args, si.Env, si.FilesToUpload, si.Builder)
+	return v.Start(si.CCID, si.Args, si.Env, si.FilesToUpload, si.Builder, si.Resources)
 }
 
 func (si StartContainerReq) GetCCID() ccintf.CCID {
@@ -171,7 +171,30 @@ func getDockerHostConfig() *docker.HostConfig {
 	}
 }
 
-func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool) error {
+// hostConfigWithLimits returns a copy of the host config where the resource
+// limits set for the chaincode override the limits of the peer configuration
+func hostConfigWithLimits(hostConfig *docker.HostConfig, resources *ccintf.ResourceLimits) *docker.HostConfig {
+	if resources == nil {
+		return hostConfig
+	}
+
+	hc := *hostConfig
+	if resources.Memory > 0 {
+		hc.Memory = resources.Memory
+	}
+	if resources.CPUShares > 0 {
+		hc.CPUShares = resources.CPUShares
+	}
+	if resources.CPUQuota > 0 {
+		hc.CPUQuota = resources.CPUQuota
+	}
+	if resources.CPUPeriod > 0 {
+		hc.CPUPeriod = resources.CPUPeriod
+	}
+	return &hc
+}
+
+func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool, resources *ccintf.ResourceLimits) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
 	logger.Debugw("create container")
 	_, err := client.CreateContainer(docker.CreateContainerOptions{
@@ -183,7 +206,7 @@ func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID st
 			AttachStdout: attachStdout,
 			AttachStderr: attachStdout,
 		},
-		HostConfig: getDockerHostConfig(),
+		HostConfig: hostConfigWithLimits(getDockerHostConfig(), resources),
 	})
 	if err != nil {
 		return err
@@ -225,7 +248,7 @@ func (vm *DockerVM) deployImage(client dockerClient, ccid ccintf.CCID, reader io
 }
 
 // Start starts a container using a previously created docker image
-func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder, resources *ccintf.ResourceLimits) error {
 	imageName, err := vm.GetVMNameForDocker(ccid)
 	if err != nil {
 		return err
@@ -243,7 +266,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 
 	vm.stopInternal(client, containerName, 0, false, false)
 
-	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout, resources)
 	if err == docker.ErrNoSuchImage {
 		reader, err := builder.Build()
 		if err != nil {
@@ -255,7 +278,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 			return err
 		}
 
-		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout, resources)
 		if err != nil {
 			logger.Errorf("failed to create container: %s", err)
 			return err
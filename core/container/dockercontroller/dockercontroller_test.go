@@ -41,14 +41,14 @@ func TestIntegrationPath(t *testing.T) {
 	dc := NewDockerVM("", util.GenerateUUID(), NewBuildMetrics(&disabled.Provider{}))
 	ccid := ccintf.CCID{Name: "simple"}
 
-	err := dc.Start(ccid, nil, nil, nil, InMemBuilder{})
+	err := dc.Start(ccid, nil, nil, nil, InMemBuilder{}, nil)
 	require.NoError(t, err)
 
 	// Stop, killing, and deleting
 	err = dc.Stop(ccid, 0, true, true)
 	require.NoError(t, err)
 
-	err = dc.Start(ccid, nil, nil, nil, nil)
+	err = dc.Start(ccid, nil, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	// Stop, killing, but not deleting
@@ -81,6 +81,34 @@ func TestGetDockerHostConfig(t *testing.T) {
 	assert.Equal(t, int64(0), hostConfig.CPUShares)
 }
 
+func TestHostConfigWithLimits(t *testing.T) {
+	base := &docker.HostConfig{
+		NetworkMode: "host",
+		Memory:      1024,
+		CPUShares:   256,
+		CPUQuota:    10000,
+		CPUPeriod:   50000,
+	}
+
+	assert.True(t, base == hostConfigWithLimits(base, nil))
+
+	hc := hostConfigWithLimits(base, &ccintf.ResourceLimits{Memory: 2048, CPUQuota: 20000})
+	assert.Equal(t, &docker.HostConfig{
+		NetworkMode: "host",
+		Memory:      2048,
+		CPUShares:   256,
+		CPUQuota:    20000,
+		CPUPeriod:   50000,
+	}, hc)
+	assert.Equal(t, int64(1024), base.Memory, "the peer host config must not be modified")
+
+	hc = hostConfigWithLimits(base, &ccintf.ResourceLimits{CPUShares: 512, CPUPeriod: 100000})
+	assert.Equal(t, int64(1024), hc.Memory)
+	assert.Equal(t, int64(512), hc.CPUShares)
+	assert.Equal(t, int64(10000), hc.CPUQuota)
+	assert.Equal(t, int64(100000), hc.CPUPeriod)
+}
+
 func Test_Start(t *testing.T) {
 	gt := NewGomegaWithT(t)
 	dvm := DockerVM{
@@ -100,26 +128,26 @@ func Test_Start(t *testing.T) {
 	// case 1: getMockClient returns error
 	dvm.getClientFnc = getMockClient
 	getClientErr = true
-	err := dvm.Start(ccid, args, env, files, nil)
+	err := dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).To(HaveOccurred())
 	getClientErr = false
 
 	// case 2: dockerClient.CreateContainer returns error
 	createErr = true
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).To(HaveOccurred())
 	createErr = false
 
 	// case 3: dockerClient.UploadToContainer returns error
 	uploadErr = true
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).To(HaveOccurred())
 	uploadErr = false
 
 	// case 4: dockerClient.StartContainer returns docker.noSuchImgErr, BuildImage fails
 	noSuchImgErr = true
 	buildErr = true
-	err = dvm.Start(ccid, args, env, files, &mockBuilder{buildFunc: func() (io.Reader, error) { return &bytes.Buffer{}, nil }})
+	err = dvm.Start(ccid, args, env, files, &mockBuilder{buildFunc: func() (io.Reader, error) { return &bytes.Buffer{}, nil }}, nil)
 	gt.Expect(err).To(HaveOccurred())
 	buildErr = false
 
@@ -150,34 +178,34 @@ func Test_Start(t *testing.T) {
 	// docker.noSuchImgErr and dockerClient.Start returns error
 	viper.Set("vm.docker.attachStdout", true)
 	startErr = true
-	err = dvm.Start(ccid, args, env, files, bldr)
+	err = dvm.Start(ccid, args, env, files, bldr, nil)
 	gt.Expect(err).To(HaveOccurred())
 	startErr = false
 
 	// Success cases
-	err = dvm.Start(ccid, args, env, files, bldr)
+	err = dvm.Start(ccid, args, env, files, bldr, nil)
 	gt.Expect(err).NotTo(HaveOccurred())
 	noSuchImgErr = false
 
 	// dockerClient.StopContainer returns error
 	stopErr = true
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).NotTo(HaveOccurred())
 	stopErr = false
 
 	// dockerClient.KillContainer returns error
 	killErr = true
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).NotTo(HaveOccurred())
 	killErr = false
 
 	// dockerClient.RemoveContainer returns error
 	removeErr = true
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).NotTo(HaveOccurred())
 	removeErr = false
 
-	err = dvm.Start(ccid, args, env, files, nil)
+	err = dvm.Start(ccid, args, env, files, nil, nil)
 	gt.Expect(err).NotTo(HaveOccurred())
 }
 
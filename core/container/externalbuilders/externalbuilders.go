@@ -0,0 +1,408 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.externalbuilders")
+
+// DefaultEnvWhitelist is the list of environment variables which are
+// always passed to the external builders
+var DefaultEnvWhitelist = []string{"LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+// Config is the core.yaml configuration of an external builder
+type Config struct {
+	Name                 string   `mapstructure:"name" yaml:"name"`
+	Path                 string   `mapstructure:"path" yaml:"path"`
+	EnvironmentWhitelist []string `mapstructure:"environmentWhitelist" yaml:"environmentWhitelist"`
+}
+
+// BuildInfo describes the chaincode package to build, and is handed to
+// the builders as metadata.json in the metadata directory
+type BuildInfo struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// RunConfig is handed to the run hook as chaincode.json in the run
+// metadata directory
+type RunConfig struct {
+	CCID        string `json:"chaincode_id"`
+	PeerAddress string `json:"peer_address"`
+	ClientCert  string `json:"client_cert"`
+	ClientKey   string `json:"client_key"`
+	RootCert    string `json:"root_cert"`
+}
+
+// BuildContext is the directory tree a chaincode package is built in
+type BuildContext struct {
+	CCID        string
+	ScratchDir  string
+	SourceDir   string
+	MetadataDir string
+	BldDir      string
+	ReleaseDir  string
+	RunDir      string
+}
+
+// NewBuildContext extracts the code package into a new scratch directory
+// and writes the build information next to it
+func NewBuildContext(ccid string, buildInfo *BuildInfo, codePackage []byte) (*BuildContext, error) {
+	scratchDir, err := ioutil.TempDir("", "fabric-"+sanitize(ccid))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create temp dir")
+	}
+
+	bc := &BuildContext{
+		CCID:        ccid,
+		ScratchDir:  scratchDir,
+		SourceDir:   filepath.Join(scratchDir, "src"),
+		MetadataDir: filepath.Join(scratchDir, "metadata"),
+		BldDir:      filepath.Join(scratchDir, "bld"),
+		ReleaseDir:  filepath.Join(scratchDir, "release"),
+		RunDir:      filepath.Join(scratchDir, "run"),
+	}
+
+	for _, dir := range []string{bc.SourceDir, bc.MetadataDir, bc.BldDir, bc.ReleaseDir, bc.RunDir} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			bc.Cleanup()
+			return nil, errors.WithMessage(err, "could not create build directory")
+		}
+	}
+
+	if err := Untar(bytes.NewBuffer(codePackage), bc.SourceDir); err != nil {
+		bc.Cleanup()
+		return nil, errors.WithMessage(err, "could not untar source package")
+	}
+
+	buildInfoBytes, err := json.Marshal(buildInfo)
+	if err != nil {
+		bc.Cleanup()
+		return nil, errors.Wrap(err, "could not marshal build info")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.MetadataDir, "metadata.json"), buildInfoBytes, 0600); err != nil {
+		bc.Cleanup()
+		return nil, errors.Wrap(err, "could not write build info")
+	}
+
+	return bc, nil
+}
+
+// Cleanup removes the directory tree of the build context
+func (bc *BuildContext) Cleanup() {
+	os.RemoveAll(bc.ScratchDir)
+}
+
+// Builder is an external builder, a directory holding the executable
+// detect, build, release and run hooks in its bin subdirectory
+type Builder struct {
+	Name         string
+	Location     string
+	EnvWhitelist []string
+}
+
+// CreateBuilders creates the external builders from their configuration
+func CreateBuilders(configs []Config) []*Builder {
+	var builders []*Builder
+	for _, config := range configs {
+		builders = append(builders, &Builder{
+			Name:         config.Name,
+			Location:     config.Path,
+			EnvWhitelist: config.EnvironmentWhitelist,
+		})
+	}
+	return builders
+}
+
+// Detect returns whether the builder is able to build the package of
+// the build context
+func (b *Builder) Detect(bc *BuildContext) bool {
+	detect := filepath.Join(b.Location, "bin", "detect")
+	cmd := b.NewCommand(detect, bc.SourceDir, bc.MetadataDir)
+
+	if err := RunCommand(logger.With("command", "detect", "builder", b.Name), cmd); err != nil {
+		logger.Debugf("builder '%s' detect failed for '%s': %s", b.Name, bc.CCID, err)
+		return false
+	}
+
+	return true
+}
+
+// Build builds the package of the build context into the build output
+// directory
+func (b *Builder) Build(bc *BuildContext) error {
+	build := filepath.Join(b.Location, "bin", "build")
+	cmd := b.NewCommand(build, bc.SourceDir, bc.MetadataDir, bc.BldDir)
+
+	if err := RunCommand(logger.With("command", "build", "builder", b.Name), cmd); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("builder '%s' failed", b.Name))
+	}
+
+	return nil
+}
+
+// Release lets the builder provide the release metadata of the built
+// chaincode. The release hook is optional.
+func (b *Builder) Release(bc *BuildContext) error {
+	release := filepath.Join(b.Location, "bin", "release")
+	if _, err := os.Stat(release); os.IsNotExist(err) {
+		logger.Debugf("builder '%s' does not provide a release hook", b.Name)
+		return nil
+	}
+	cmd := b.NewCommand(release, bc.BldDir, bc.ReleaseDir)
+
+	if err := RunCommand(logger.With("command", "release", "builder", b.Name), cmd); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("builder '%s' release failed", b.Name))
+	}
+
+	return nil
+}
+
+// Run starts the built chaincode as a local process through the run hook
+func (b *Builder) Run(bc *BuildContext, peerConnection *ccintf.PeerConnection) (*Session, error) {
+	runConfig := &RunConfig{
+		CCID:        bc.CCID,
+		PeerAddress: peerConnection.Address,
+	}
+	if peerConnection.TLSConfig != nil {
+		runConfig.ClientCert = string(peerConnection.TLSConfig.ClientCert)
+		runConfig.ClientKey = string(peerConnection.TLSConfig.ClientKey)
+		runConfig.RootCert = string(peerConnection.TLSConfig.RootCert)
+	}
+
+	runConfigBytes, err := json.Marshal(runConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal run config")
+	}
+	if err := ioutil.WriteFile(filepath.Join(bc.RunDir, "chaincode.json"), runConfigBytes, 0600); err != nil {
+		return nil, errors.Wrap(err, "could not write run config")
+	}
+
+	run := filepath.Join(b.Location, "bin", "run")
+	cmd := b.NewCommand(run, bc.BldDir, bc.RunDir)
+
+	sess, err := Start(logger.With("command", "run", "builder", b.Name, "chaincode", bc.CCID), cmd)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("builder '%s' run failed to start", b.Name))
+	}
+
+	return sess, nil
+}
+
+// NewCommand creates an exec.Cmd for the builder hook with the
+// whitelisted environment of the peer
+func (b *Builder) NewCommand(name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	for _, key := range append(append([]string{}, DefaultEnvWhitelist...), b.EnvWhitelist...) {
+		if val, ok := os.LookupEnv(key); ok {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, val))
+		}
+	}
+	return cmd
+}
+
+// Instance is a chaincode package built by an external builder
+type Instance struct {
+	BuildContext *BuildContext
+	Builder      *Builder
+	Session      *Session
+}
+
+// Detector builds and runs chaincode packages with the first external
+// builder which detects them.
+type Detector struct {
+	Builders []*Builder
+
+	mutex     sync.Mutex
+	instances map[string]*Instance
+}
+
+// Build builds the code package with the first external builder which
+// detects it, and returns false when none of them does.
+func (d *Detector) Build(ccid string, buildInfo *BuildInfo, codePackage []byte) (bool, error) {
+	if len(d.Builders) == 0 {
+		return false, nil
+	}
+
+	bc, err := NewBuildContext(ccid, buildInfo, codePackage)
+	if err != nil {
+		return false, errors.WithMessage(err, "could not create build context")
+	}
+
+	for _, builder := range d.Builders {
+		if !builder.Detect(bc) {
+			continue
+		}
+
+		if err := builder.Build(bc); err != nil {
+			bc.Cleanup()
+			return false, errors.WithMessage(err, fmt.Sprintf("external builder failed to build '%s'", ccid))
+		}
+		if err := builder.Release(bc); err != nil {
+			bc.Cleanup()
+			return false, errors.WithMessage(err, fmt.Sprintf("external builder failed to release '%s'", ccid))
+		}
+
+		logger.Infof("chaincode '%s' built by external builder '%s'", ccid, builder.Name)
+		d.setInstance(ccid, &Instance{BuildContext: bc, Builder: builder})
+		return true, nil
+	}
+
+	bc.Cleanup()
+	return false, nil
+}
+
+// Run starts the chaincode built by an external builder
+func (d *Detector) Run(ccid string, peerConnection *ccintf.PeerConnection) error {
+	instance := d.instance(ccid)
+	if instance == nil {
+		return errors.Errorf("chaincode '%s' was not built by an external builder", ccid)
+	}
+
+	sess, err := instance.Builder.Run(instance.BuildContext, peerConnection)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	instance.Session = sess
+	d.mutex.Unlock()
+
+	return nil
+}
+
+// Owns returns whether the chaincode was built by an external builder
+func (d *Detector) Owns(ccid string) bool {
+	return d.instance(ccid) != nil
+}
+
+// Stop terminates the chaincode process and removes its build
+func (d *Detector) Stop(ccid string) error {
+	d.mutex.Lock()
+	instance := d.instances[ccid]
+	delete(d.instances, ccid)
+	d.mutex.Unlock()
+
+	if instance == nil {
+		return errors.Errorf("chaincode '%s' was not built by an external builder", ccid)
+	}
+
+	defer instance.BuildContext.Cleanup()
+	if instance.Session != nil {
+		return instance.Session.Kill()
+	}
+
+	return nil
+}
+
+// Wait waits for the chaincode process to exit
+func (d *Detector) Wait(ccid string) (int, error) {
+	d.mutex.Lock()
+	instance := d.instances[ccid]
+	var sess *Session
+	if instance != nil {
+		sess = instance.Session
+	}
+	d.mutex.Unlock()
+
+	if sess == nil {
+		return -1, errors.Errorf("chaincode '%s' is not running", ccid)
+	}
+
+	return sess.Wait()
+}
+
+func (d *Detector) instance(ccid string) *Instance {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.instances[ccid]
+}
+
+func (d *Detector) setInstance(ccid string, instance *Instance) {
+	d.mutex.Lock()
+	if d.instances == nil {
+		d.instances = map[string]*Instance{}
+	}
+	previous := d.instances[ccid]
+	d.instances[ccid] = instance
+	d.mutex.Unlock()
+
+	if previous != nil {
+		if previous.Session != nil {
+			previous.Session.Kill()
+		}
+		previous.BuildContext.Cleanup()
+	}
+}
+
+// Untar extracts the gzipped tar stream into the destination directory
+func Untar(buffer io.Reader, dst string) error {
+	zr, err := gzip.NewReader(buffer)
+	if err != nil {
+		return errors.Wrap(err, "error reading as gzip stream")
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "error inspecting next tar header")
+		}
+
+		target := filepath.Join(dst, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(dst)+string(os.PathSeparator)) {
+			return errors.Errorf("illegal file path in tar: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return errors.Wrapf(err, "could not create directory %s", header.Name)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return errors.Wrapf(err, "could not create directory %s", filepath.Dir(header.Name))
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode)&0700|0600)
+			if err != nil {
+				return errors.Wrapf(err, "could not create file %s", header.Name)
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return errors.Wrapf(err, "could not write file %s", header.Name)
+			}
+		default:
+			return errors.Errorf("invalid file type '%v' in tar for %s", header.Typeflag, header.Name)
+		}
+	}
+}
+
+func sanitize(ccid string) string {
+	return strings.NewReplacer(":", "-", string(os.PathSeparator), "-").Replace(ccid)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0600, Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestCreateBuilders(t *testing.T) {
+	builders := externalbuilders.CreateBuilders([]externalbuilders.Config{
+		{Name: "builder1", Path: "/builders/builder1"},
+		{Name: "builder2", Path: "/builders/builder2", EnvironmentWhitelist: []string{"GOPROXY"}},
+	})
+	assert.Equal(t, []*externalbuilders.Builder{
+		{Name: "builder1", Location: "/builders/builder1"},
+		{Name: "builder2", Location: "/builders/builder2", EnvWhitelist: []string{"GOPROXY"}},
+	}, builders)
+}
+
+func TestNewBuildContext(t *testing.T) {
+	bc, err := externalbuilders.NewBuildContext("cc:1.0", &externalbuilders.BuildInfo{Type: "GOLANG", Path: "cc/path"}, codePackage(t, map[string]string{
+		"src/main.go": "package main",
+	}))
+	require.NoError(t, err)
+	defer bc.Cleanup()
+
+	source, err := ioutil.ReadFile(filepath.Join(bc.SourceDir, "src", "main.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package main", string(source))
+
+	metadata, err := ioutil.ReadFile(filepath.Join(bc.MetadataDir, "metadata.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"GOLANG","path":"cc/path"}`, string(metadata))
+
+	bc.Cleanup()
+	_, err = os.Stat(bc.ScratchDir)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = externalbuilders.NewBuildContext("cc:1.0", &externalbuilders.BuildInfo{}, []byte("garbage"))
+	assert.Contains(t, err.Error(), "could not untar source package: error reading as gzip stream")
+}
+
+func TestUntarIllegalPath(t *testing.T) {
+	dst, err := ioutil.TempDir("", "untar")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	err = externalbuilders.Untar(bytes.NewBuffer(codePackage(t, map[string]string{"../escape": "boom"})), dst)
+	assert.EqualError(t, err, "illegal file path in tar: ../escape")
+}
+
+func TestDetector(t *testing.T) {
+	os.Setenv("RUN_EXIT", "3")
+	defer os.Unsetenv("RUN_EXIT")
+
+	tempDir, err := ioutil.TempDir("", "detector")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	defer os.Setenv("TMPDIR", os.Getenv("TMPDIR"))
+	os.Setenv("TMPDIR", tempDir)
+
+	detector := &externalbuilders.Detector{
+		Builders: []*externalbuilders.Builder{
+			{Name: "missing", Location: "testdata/missing"},
+			{Name: "good", Location: "testdata/goodbuilder", EnvWhitelist: []string{"RUN_EXIT"}},
+		},
+	}
+	pkg := codePackage(t, map[string]string{"src/main.go": "package main"})
+
+	built, err := detector.Build("cc:1.0", &externalbuilders.BuildInfo{Type: "NODE"}, pkg)
+	assert.NoError(t, err)
+	assert.False(t, built)
+	assert.False(t, detector.Owns("cc:1.0"))
+
+	built, err = detector.Build("cc:1.0", &externalbuilders.BuildInfo{Type: "GOLANG"}, pkg)
+	assert.NoError(t, err)
+	assert.True(t, built)
+	assert.True(t, detector.Owns("cc:1.0"))
+
+	_, err = detector.Wait("cc:1.0")
+	assert.EqualError(t, err, "chaincode 'cc:1.0' is not running")
+
+	err = detector.Run("cc:1.0", &ccintf.PeerConnection{
+		Address: "peer:7052",
+		TLSConfig: &ccintf.TLSConfig{
+			ClientCert: []byte("cert"),
+			ClientKey:  []byte("key"),
+			RootCert:   []byte("root"),
+		},
+	})
+	require.NoError(t, err)
+
+	exitCode, err := detector.Wait("cc:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+
+	instance := instanceDir(t, tempDir)
+	release, err := ioutil.ReadFile(filepath.Join(instance, "release", "release.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "released\n", string(release))
+
+	runConfigBytes, err := ioutil.ReadFile(filepath.Join(instance, "bld", "chaincode.json.copy"))
+	require.NoError(t, err)
+	runConfig := &externalbuilders.RunConfig{}
+	require.NoError(t, json.Unmarshal(runConfigBytes, runConfig))
+	assert.Equal(t, &externalbuilders.RunConfig{
+		CCID:        "cc:1.0",
+		PeerAddress: "peer:7052",
+		ClientCert:  "cert",
+		ClientKey:   "key",
+		RootCert:    "root",
+	}, runConfig)
+
+	err = detector.Stop("cc:1.0")
+	assert.NoError(t, err)
+	assert.False(t, detector.Owns("cc:1.0"))
+	_, err = os.Stat(instance)
+	assert.True(t, os.IsNotExist(err))
+
+	err = detector.Stop("cc:1.0")
+	assert.EqualError(t, err, "chaincode 'cc:1.0' was not built by an external builder")
+	err = detector.Run("cc:1.0", &ccintf.PeerConnection{})
+	assert.EqualError(t, err, "chaincode 'cc:1.0' was not built by an external builder")
+}
+
+func TestDetectorStopRunning(t *testing.T) {
+	os.Setenv("RUN_SLEEP", "30")
+	defer os.Unsetenv("RUN_SLEEP")
+
+	detector := &externalbuilders.Detector{
+		Builders: []*externalbuilders.Builder{
+			{Name: "good", Location: "testdata/goodbuilder", EnvWhitelist: []string{"RUN_SLEEP"}},
+		},
+	}
+
+	built, err := detector.Build("cc:1.0", &externalbuilders.BuildInfo{Type: "GOLANG"}, codePackage(t, map[string]string{"src/main.go": "package main"}))
+	require.NoError(t, err)
+	require.True(t, built)
+
+	err = detector.Run("cc:1.0", &ccintf.PeerConnection{Address: "peer:7052"})
+	require.NoError(t, err)
+
+	exitCodeCh := make(chan int, 1)
+	go func() {
+		exitCode, _ := detector.Wait("cc:1.0")
+		exitCodeCh <- exitCode
+	}()
+
+	err = detector.Stop("cc:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, -1, <-exitCodeCh)
+}
+
+func TestDetectorBuildFailure(t *testing.T) {
+	detector := &externalbuilders.Detector{
+		Builders: []*externalbuilders.Builder{
+			{Name: "fail", Location: "testdata/failbuilder"},
+			{Name: "good", Location: "testdata/goodbuilder"},
+		},
+	}
+
+	built, err := detector.Build("cc:1.0", &externalbuilders.BuildInfo{Type: "GOLANG"}, codePackage(t, map[string]string{"src/main.go": "package main"}))
+	assert.False(t, built)
+	assert.Contains(t, err.Error(), "external builder failed to build 'cc:1.0': builder 'fail' failed")
+	assert.False(t, detector.Owns("cc:1.0"))
+
+	built, err = (&externalbuilders.Detector{}).Build("cc:1.0", nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, built)
+}
+
+// instanceDir finds the scratch directory of the built instance in the
+// temp directory
+func instanceDir(t *testing.T, tempDir string) string {
+	matches, err := filepath.Glob(filepath.Join(tempDir, "fabric-cc-1.0*", "bld", "chaincode.json.copy"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	return filepath.Dir(filepath.Dir(matches[0]))
+}
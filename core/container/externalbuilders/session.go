@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package externalbuilders
+
+import (
+	"bufio"
+	"io"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// Session is a process started for an external builder hook
+type Session struct {
+	command *exec.Cmd
+	mutex   sync.Mutex
+	done    chan struct{}
+	exitErr error
+}
+
+// Start starts the command as a session, logging its standard error
+// stream line by line.
+func Start(logger *flogging.FabricLogger, cmd *exec.Cmd) (*Session, error) {
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get stderr of command")
+	}
+
+	// run the command in its own process group so that the processes
+	// it spawns are terminated along with it
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "could not start %s", cmd.Path)
+	}
+
+	sess := &Session{
+		command: cmd,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		logLines(logger, stderr)
+		sess.exitErr = cmd.Wait()
+		close(sess.done)
+	}()
+
+	return sess, nil
+}
+
+// Wait waits for the process to exit and returns its exit code
+func (s *Session) Wait() (int, error) {
+	<-s.done
+	if s.exitErr == nil {
+		return 0, nil
+	}
+	if exitErr, ok := s.exitErr.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus(), nil
+		}
+	}
+	return -1, s.exitErr
+}
+
+// Kill terminates the process and the processes it spawned
+func (s *Session) Kill() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+
+	if err := syscall.Kill(-s.command.Process.Pid, syscall.SIGKILL); err != nil {
+		return errors.Wrap(err, "could not kill process")
+	}
+	return nil
+}
+
+// RunCommand runs the command to completion and returns an error if the
+// command failed
+func RunCommand(logger *flogging.FabricLogger, cmd *exec.Cmd) error {
+	sess, err := Start(logger, cmd)
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := sess.Wait()
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return errors.Errorf("%s exited with %d", cmd.Path, exitCode)
+	}
+
+	return nil
+}
+
+func logLines(logger *flogging.FabricLogger, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		logger.Info(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		logger.Errorf("could not read output: %s", err)
+	}
+}
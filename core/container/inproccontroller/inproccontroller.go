@@ -205,7 +205,7 @@ func (ipc *inprocContainer) launchInProc(id string, args []string, env []string)
 }
 
 //Start starts a previously registered system codechain
-func (vm *InprocVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
+func (vm *InprocVM) Start(ccid ccintf.CCID, args []string, env []string, filesToUpload map[string][]byte, builder container.Builder, resources *ccintf.ResourceLimits) error {
 	path := ccid.GetName()
 
 	ipctemplate := vm.registry.getType(path)
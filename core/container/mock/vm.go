@@ -21,7 +21,7 @@ type VM struct {
 	healthCheckReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StartStub        func(ccintf.CCID, []string, []string, map[string][]byte, container.Builder) error
+	StartStub        func(ccintf.CCID, []string, []string, map[string][]byte, container.Builder, *ccintf.ResourceLimits) error
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
 		arg1 ccintf.CCID
@@ -29,6 +29,7 @@ type VM struct {
 		arg3 []string
 		arg4 map[string][]byte
 		arg5 container.Builder
+		arg6 *ccintf.ResourceLimits
 	}
 	startReturns struct {
 		result1 error
@@ -127,7 +128,7 @@ func (fake *VM) HealthCheckReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *VM) Start(arg1 ccintf.CCID, arg2 []string, arg3 []string, arg4 map[string][]byte, arg5 container.Builder) error {
+func (fake *VM) Start(arg1 ccintf.CCID, arg2 []string, arg3 []string, arg4 map[string][]byte, arg5 container.Builder, arg6 *ccintf.ResourceLimits) error {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
@@ -146,11 +147,12 @@ func (fake *VM) Start(arg1 ccintf.CCID, arg2 []string, arg3 []string, arg4 map[s
 		arg3 []string
 		arg4 map[string][]byte
 		arg5 container.Builder
-	}{arg1, arg2Copy, arg3Copy, arg4, arg5})
-	fake.recordInvocation("Start", []interface{}{arg1, arg2Copy, arg3Copy, arg4, arg5})
+		arg6 *ccintf.ResourceLimits
+	}{arg1, arg2Copy, arg3Copy, arg4, arg5, arg6})
+	fake.recordInvocation("Start", []interface{}{arg1, arg2Copy, arg3Copy, arg4, arg5, arg6})
 	fake.startMutex.Unlock()
 	if fake.StartStub != nil {
-		return fake.StartStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.StartStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1
@@ -165,17 +167,17 @@ func (fake *VM) StartCallCount() int {
 	return len(fake.startArgsForCall)
 }
 
-func (fake *VM) StartCalls(stub func(ccintf.CCID, []string, []string, map[string][]byte, container.Builder) error) {
+func (fake *VM) StartCalls(stub func(ccintf.CCID, []string, []string, map[string][]byte, container.Builder, *ccintf.ResourceLimits) error) {
 	fake.startMutex.Lock()
 	defer fake.startMutex.Unlock()
 	fake.StartStub = stub
 }
 
-func (fake *VM) StartArgsForCall(i int) (ccintf.CCID, []string, []string, map[string][]byte, container.Builder) {
+func (fake *VM) StartArgsForCall(i int) (ccintf.CCID, []string, []string, map[string][]byte, container.Builder, *ccintf.ResourceLimits) {
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	argsForCall := fake.startArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *VM) StartReturns(result1 error) {
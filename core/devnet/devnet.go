@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package devnet provides a single-process development network: a solo
+// orderer embedded in a peer running in chaincode development mode, an
+// application channel the peer joins at startup and a REST endpoint which
+// submits proposals to the peer.
+package devnet
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/genesis"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("devnet")
+
+const (
+	// SystemChannelID is the ID of the ordering system channel of a devnet
+	SystemChannelID = "devnet-system-channel"
+
+	// DefaultOrdererProfile is the configtxgen profile used by default for
+	// the ordering system channel
+	DefaultOrdererProfile = "SampleDevModeSolo"
+
+	// DefaultChannelProfile is the configtxgen profile used by default for
+	// the application channel
+	DefaultChannelProfile = "SampleSingleMSPChannel"
+
+	// DefaultChannelID is the ID of the application channel used by default
+	DefaultChannelID = "myc"
+
+	// channelCreationTimeout is how long to wait for the orderer to create
+	// the application channel
+	channelCreationTimeout = 30 * time.Second
+)
+
+// Config holds the settings of a devnet
+type Config struct {
+	// ChannelID is the ID of the application channel joined by the peer
+	ChannelID string
+	// OrdererProfile is the configtxgen profile of the ordering system
+	// channel; it must use the solo consensus type
+	OrdererProfile string
+	// ChannelProfile is the configtxgen profile of the application channel
+	ChannelProfile string
+	// OrdererAddress is the address advertised for the embedded orderer,
+	// which is the address of the peer itself
+	OrdererAddress string
+}
+
+// Network is a devnet: the embedded orderer along with the application
+// channel of the peer
+type Network struct {
+	*Orderer
+	config Config
+}
+
+// NewNetwork starts the embedded orderer of the devnet. The genesis block of
+// the ordering system channel is generated from the orderer profile and is
+// only used when the ledger factory is empty.
+func NewNetwork(config Config, lf blockledger.Factory, signer crypto.LocalSigner) (*Network, error) {
+	genesisBlock, err := GenesisBlock(config)
+	if err != nil {
+		return nil, err
+	}
+	orderer, err := NewOrderer(lf, genesisBlock, signer)
+	if err != nil {
+		return nil, err
+	}
+	return &Network{Orderer: orderer, config: config}, nil
+}
+
+// NewLedgerFactory returns a factory of the ledgers of the embedded orderer,
+// which stores its blocks in the directory. The ledgers don't report
+// metrics, which would collide with those of the block storage of the peer.
+func NewLedgerFactory(directory string) (blockledger.Factory, error) {
+	// The factory lists the channels from the directory of the chains,
+	// which must exist beforehand
+	if err := os.MkdirAll(filepath.Join(directory, fsblkstorage.ChainsDir), 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create the ledger directory of the devnet orderer")
+	}
+	return fileledger.New(directory, &disabled.Provider{}), nil
+}
+
+// GenesisBlock returns the genesis block of the ordering system channel of
+// the devnet, which advertises the address of the peer as the orderer
+// endpoint
+func GenesisBlock(config Config) (*cb.Block, error) {
+	profile := genesisconfig.Load(config.OrdererProfile)
+	if profile.Orderer == nil {
+		return nil, errors.Errorf("profile %s has no orderer configuration", config.OrdererProfile)
+	}
+	if profile.Orderer.OrdererType != "solo" {
+		return nil, errors.Errorf("profile %s uses the %s consensus type, only solo is supported", config.OrdererProfile, profile.Orderer.OrdererType)
+	}
+	profile.Orderer.Addresses = []string{config.OrdererAddress}
+
+	channelGroup, err := encoder.NewChannelGroup(profile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the system channel configuration")
+	}
+	return genesis.NewFactoryImpl(channelGroup).Block(SystemChannelID), nil
+}
+
+// JoinChannel creates the application channel of the devnet if needed and
+// joins the peer to it with the join function, unless joined reports that
+// the peer is already a member of the channel.
+func (n *Network) JoinChannel(signer crypto.LocalSigner, joined func(channelID string) bool, join func(block *cb.Block) error) error {
+	if joined(n.config.ChannelID) {
+		logger.Infof("Peer already joined devnet channel %s", n.config.ChannelID)
+		return nil
+	}
+
+	env, err := encoder.MakeChannelCreationTransaction(n.config.ChannelID, signer, genesisconfig.Load(n.config.ChannelProfile))
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the channel creation transaction")
+	}
+	block, err := n.CreateChannel(env, channelCreationTimeout)
+	if err != nil {
+		return err
+	}
+	if err := join(block); err != nil {
+		return errors.WithMessage(err, "failed to join channel "+n.config.ChannelID)
+	}
+
+	logger.Infof("Peer joined devnet channel %s", n.config.ChannelID)
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package devnet_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics/prometheus"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/core/devnet"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConfig(t *testing.T) func() {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	err := msptesttools.LoadMSPSetupForTesting()
+	require.NoError(t, err)
+	return cleanup
+}
+
+func devnetConfig() devnet.Config {
+	return devnet.Config{
+		ChannelID:      "myc",
+		OrdererProfile: devnet.DefaultOrdererProfile,
+		ChannelProfile: devnet.DefaultChannelProfile,
+		OrdererAddress: "peer0:7051",
+	}
+}
+
+func TestGenesisBlock(t *testing.T) {
+	defer setupConfig(t)()
+
+	block, err := devnet.GenesisBlock(devnetConfig())
+	require.NoError(t, err)
+
+	channelID, err := utils.GetChainIDFromBlock(block)
+	require.NoError(t, err)
+	assert.Equal(t, devnet.SystemChannelID, channelID)
+
+	env, err := utils.ExtractEnvelope(block, 0)
+	require.NoError(t, err)
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"peer0:7051"}, bundle.ChannelConfig().OrdererAddresses())
+
+	config := devnetConfig()
+	config.OrdererProfile = "SampleDevModeKafka"
+	_, err = devnet.GenesisBlock(config)
+	assert.EqualError(t, err, "profile SampleDevModeKafka uses the kafka consensus type, only solo is supported")
+
+	config.OrdererProfile = devnet.DefaultChannelProfile
+	_, err = devnet.GenesisBlock(config)
+	assert.EqualError(t, err, "profile SampleSingleMSPChannel has no orderer configuration")
+}
+
+func TestNetwork(t *testing.T) {
+	defer setupConfig(t)()
+
+	signer := localmsp.NewSigner()
+	lf := ramledger.New(10)
+	network, err := devnet.NewNetwork(devnetConfig(), lf, signer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{devnet.SystemChannelID}, lf.ChainIDs())
+
+	joined := map[string]bool{}
+	var genesisBlock *cb.Block
+	join := func(block *cb.Block) error {
+		channelID, err := utils.GetChainIDFromBlock(block)
+		require.NoError(t, err)
+		joined[channelID] = true
+		genesisBlock = block
+		return nil
+	}
+	isJoined := func(channelID string) bool { return joined[channelID] }
+
+	err = network.JoinChannel(signer, isJoined, join)
+	require.NoError(t, err)
+	assert.True(t, joined["myc"])
+	require.NotNil(t, genesisBlock)
+	assert.Equal(t, uint64(0), genesisBlock.Header.Number)
+
+	// the peer is a member of the channel, it isn't joined again
+	err = network.JoinChannel(signer, isJoined, func(*cb.Block) error {
+		t.Fatal("join should not be called")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// the channel exists, the peer joins it with its genesis block
+	delete(joined, "myc")
+	existingGenesisBlock := genesisBlock
+	err = network.JoinChannel(signer, isJoined, join)
+	require.NoError(t, err)
+	assert.Equal(t, existingGenesisBlock, genesisBlock)
+
+	err = network.JoinChannel(signer, func(string) bool { return false }, func(*cb.Block) error {
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "failed to join channel myc: boom")
+
+	// transactions on the channel are ordered into blocks
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "myc", signer, &cb.Envelope{}, 0, 0)
+	require.NoError(t, err)
+	err = network.Order(env)
+	require.NoError(t, err)
+
+	rl, err := lf.GetOrCreate("myc")
+	require.NoError(t, err)
+	deadline := time.Now().Add(10 * time.Second)
+	for rl.Height() < 2 && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	require.Equal(t, uint64(2), rl.Height())
+	block := blockledger.GetBlock(rl, 1)
+	require.Len(t, block.Data.Data, 1)
+	ordered, err := utils.ExtractEnvelope(block, 0)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(env, ordered))
+}
+
+func TestNewNetworkExistingLedger(t *testing.T) {
+	defer setupConfig(t)()
+
+	signer := localmsp.NewSigner()
+	lf := ramledger.New(10)
+	_, err := devnet.NewNetwork(devnetConfig(), lf, signer)
+	require.NoError(t, err)
+
+	// the system channel isn't bootstrapped again
+	_, err = devnet.NewNetwork(devnetConfig(), lf, signer)
+	require.NoError(t, err)
+	rl, err := lf.GetOrCreate(devnet.SystemChannelID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), rl.Height())
+}
+
+func TestNetworkWithPrometheus(t *testing.T) {
+	defer setupConfig(t)()
+
+	dir, err := ioutil.TempDir("", "devnet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the peer registers the metrics of its block storage and deliver
+	// service with the provider
+	provider := &prometheus.Provider{}
+	peerLedger := fileledger.New(filepath.Join(dir, "peer"), provider)
+	defer peerLedger.Close()
+	deliver.NewMetrics(provider)
+
+	signer := localmsp.NewSigner()
+	lf, err := devnet.NewLedgerFactory(filepath.Join(dir, "devnet"))
+	require.NoError(t, err)
+	defer lf.Close()
+	network, err := devnet.NewNetwork(devnetConfig(), lf, signer)
+	require.NoError(t, err)
+
+	err = network.JoinChannel(signer, func(string) bool { return false }, func(*cb.Block) error { return nil })
+	require.NoError(t, err)
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "myc", signer, &cb.Envelope{}, 0, 0)
+	require.NoError(t, err)
+	assert.NoError(t, network.Order(env))
+}
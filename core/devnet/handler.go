@@ -0,0 +1,305 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/endorser.go -fake-name Endorser . Endorser
+
+// Endorser processes signed proposals
+type Endorser interface {
+	ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+}
+
+//go:generate counterfeiter -o mock/broadcaster.go -fake-name Broadcaster . Broadcaster
+
+// Broadcaster submits transactions for ordering
+type Broadcaster interface {
+	Order(env *cb.Envelope) error
+}
+
+//go:generate counterfeiter -o mock/transaction_finder.go -fake-name TransactionFinder . TransactionFinder
+
+// TransactionFinder retrieves committed transactions
+type TransactionFinder interface {
+	GetTransactionByID(channelID, txID string) (*pb.ProcessedTransaction, error)
+}
+
+// PeerLedgers is a TransactionFinder looking up transactions in the ledgers
+// of the peer
+type PeerLedgers func(channelID string) ledger.PeerLedger
+
+// GetTransactionByID returns the transaction with the given ID committed on
+// the channel
+func (p PeerLedgers) GetTransactionByID(channelID, txID string) (*pb.ProcessedTransaction, error) {
+	l := p(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	return l.GetTransactionByID(txID)
+}
+
+// Request is the body of an invoke or query request
+type Request struct {
+	ChannelID string            `json:"channel,omitempty"`
+	Chaincode string            `json:"chaincode"`
+	Args      []string          `json:"args"`
+	Transient map[string]string `json:"transient,omitempty"`
+}
+
+// Response is the result of an invoke or query request
+type Response struct {
+	TxID           string               `json:"tx_id"`
+	Status         int32                `json:"status"`
+	Message        string               `json:"message,omitempty"`
+	Payload        []byte               `json:"payload,omitempty"`
+	RWSet          []*NsRWSet           `json:"rwset,omitempty"`
+	Events         []*pb.ChaincodeEvent `json:"events,omitempty"`
+	ValidationCode string               `json:"validation_code,omitempty"`
+}
+
+// NsRWSet is the read-write set of a transaction for a namespace
+type NsRWSet struct {
+	Namespace   string             `json:"namespace"`
+	KVRWSet     *kvrwset.KVRWSet   `json:"kv_rwset,omitempty"`
+	Collections []*CollHashedRWSet `json:"collections,omitempty"`
+}
+
+// CollHashedRWSet is the hashed read-write set of a transaction for a
+// private data collection
+type CollHashedRWSet struct {
+	Collection  string               `json:"collection"`
+	HashedRWSet *kvrwset.HashedRWSet `json:"hashed_rwset,omitempty"`
+}
+
+// Handler is an http.Handler which submits proposals to the peer. A POST to
+// a path ending with "invoke" endorses the proposal, orders the resulting
+// transaction and waits for it to be committed; a POST to a path ending
+// with "query" only endorses the proposal. Both return the response of the
+// chaincode along with the read-write set and the events of the proposal.
+type Handler struct {
+	ChannelID     string
+	Signer        msp.SigningIdentity
+	Endorser      Endorser
+	Orderer       Broadcaster
+	Transactions  TransactionFinder
+	CommitTimeout time.Duration
+}
+
+// CheckOperationsEndpoint verifies that the operations endpoint the Handler
+// is registered at cannot be used by unauthenticated remote clients, as the
+// Handler signs proposals and transactions with the identity of the peer.
+// Either TLS is enabled, in which case the handlers of the operations
+// endpoint require a verified client certificate, or the endpoint only
+// listens on a loopback address.
+func CheckOperationsEndpoint(listenAddress string, tlsEnabled bool) error {
+	if tlsEnabled {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return errors.Wrapf(err, "invalid operations listen address %s", listenAddress)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return errors.Errorf("operations endpoint %s is neither protected by TLS client authentication nor bound to a loopback address", listenAddress)
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		sendError(resp, http.StatusMethodNotAllowed, errors.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	var invoke bool
+	switch path.Base(req.URL.Path) {
+	case "invoke":
+		invoke = true
+	case "query":
+	default:
+		sendError(resp, http.StatusNotFound, errors.Errorf("unknown operation: %s", req.URL.Path))
+		return
+	}
+
+	request := &Request{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		sendError(resp, http.StatusBadRequest, errors.Wrap(err, "invalid request body"))
+		return
+	}
+	if request.Chaincode == "" {
+		sendError(resp, http.StatusBadRequest, errors.New("chaincode name is required"))
+		return
+	}
+	if request.ChannelID == "" {
+		request.ChannelID = h.ChannelID
+	}
+
+	prop, response, result, err := h.endorse(req.Context(), request)
+	if err != nil {
+		sendError(resp, http.StatusInternalServerError, err)
+		return
+	}
+
+	if invoke && response.Response.Status < shim.ERRORTHRESHOLD {
+		result.ValidationCode, err = h.submit(request.ChannelID, result.TxID, prop, response)
+		if err != nil {
+			sendError(resp, http.StatusInternalServerError, err)
+			return
+		}
+		logger.Debugf("Transaction %s committed with validation code %s", result.TxID, result.ValidationCode)
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(resp).Encode(result); err != nil {
+		logger.Errorf("Failed encoding devnet response: %v", err)
+	}
+}
+
+// endorse creates and signs the proposal of the request and returns it
+// along with its endorsement and the result of the endorsement
+func (h *Handler) endorse(ctx context.Context, request *Request) (*pb.Proposal, *pb.ProposalResponse, *Response, error) {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range request.Args {
+		input.Args = append(input.Args, []byte(arg))
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: request.Chaincode},
+			Input:       input,
+		},
+	}
+	var transient map[string][]byte
+	if len(request.Transient) != 0 {
+		transient = make(map[string][]byte, len(request.Transient))
+		for k, v := range request.Transient {
+			transient[k] = []byte(v)
+		}
+	}
+
+	creator, err := h.Signer.Serialize()
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "failed to serialize the signing identity")
+	}
+	prop, txID, err := utils.CreateChaincodeProposalWithTransient(cb.HeaderType_ENDORSER_TRANSACTION, request.ChannelID, cis, creator, transient)
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "failed to create the proposal")
+	}
+	signedProp, err := utils.GetSignedProposal(prop, h.Signer)
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "failed to sign the proposal")
+	}
+
+	response, err := h.Endorser.ProcessProposal(ctx, signedProp)
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "failed to endorse the proposal")
+	}
+	if response.Response == nil {
+		return nil, nil, nil, errors.New("received a proposal response without a response")
+	}
+
+	result := &Response{
+		TxID:    txID,
+		Status:  response.Response.Status,
+		Message: response.Response.Message,
+		Payload: response.Response.Payload,
+	}
+	if err := addChaincodeAction(result, response.Payload); err != nil {
+		return nil, nil, nil, err
+	}
+	return prop, response, result, nil
+}
+
+// addChaincodeAction sets the read-write set and the events of the
+// chaincode action carried by the proposal response payload
+func addChaincodeAction(result *Response, payload []byte) error {
+	if len(payload) == 0 {
+		return nil
+	}
+	prp, err := utils.GetProposalResponsePayload(payload)
+	if err != nil {
+		return errors.WithMessage(err, "invalid proposal response payload")
+	}
+	action, err := utils.GetChaincodeAction(prp.Extension)
+	if err != nil {
+		return errors.WithMessage(err, "invalid chaincode action")
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(action.Results); err != nil {
+		return errors.WithMessage(err, "invalid read-write set")
+	}
+	for _, ns := range txRWSet.NsRwSets {
+		nsRWSet := &NsRWSet{Namespace: ns.NameSpace, KVRWSet: ns.KvRwSet}
+		for _, coll := range ns.CollHashedRwSets {
+			nsRWSet.Collections = append(nsRWSet.Collections, &CollHashedRWSet{
+				Collection:  coll.CollectionName,
+				HashedRWSet: coll.HashedRwSet,
+			})
+		}
+		result.RWSet = append(result.RWSet, nsRWSet)
+	}
+
+	result.Events, err = utils.GetChaincodeActionEvents(action)
+	if err != nil {
+		return errors.WithMessage(err, "invalid chaincode event")
+	}
+	return nil
+}
+
+// submit orders the transaction of the endorsed proposal and returns its
+// validation code once it has been committed
+func (h *Handler) submit(channelID, txID string, prop *pb.Proposal, response *pb.ProposalResponse) (string, error) {
+	env, err := utils.CreateSignedTx(prop, h.Signer, response)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create the transaction")
+	}
+	if err := h.Orderer.Order(env); err != nil {
+		return "", errors.WithMessage(err, "failed to order the transaction")
+	}
+
+	deadline := time.Now().Add(h.CommitTimeout)
+	for {
+		tx, err := h.Transactions.GetTransactionByID(channelID, txID)
+		if err == nil {
+			return pb.TxValidationCode(tx.ValidationCode).String(), nil
+		}
+		if time.Now().After(deadline) {
+			return "", errors.WithMessage(err, fmt.Sprintf("timed out waiting for transaction %s to be committed", txID))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func sendError(resp http.ResponseWriter, code int, err error) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	json.NewEncoder(resp).Encode(map[string]string{"error": err.Error()})
+}
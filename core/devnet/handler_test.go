@@ -0,0 +1,262 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package devnet_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/devnet"
+	"github.com/hyperledger/fabric/core/devnet/mock"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHandler(t *testing.T) (*devnet.Handler, *mock.Endorser, *mock.Broadcaster, *mock.TransactionFinder) {
+	err := msptesttools.LoadMSPSetupForTesting()
+	require.NoError(t, err)
+
+	endorser := &mock.Endorser{}
+	orderer := &mock.Broadcaster{}
+	transactions := &mock.TransactionFinder{}
+	handler := &devnet.Handler{
+		ChannelID:     "myc",
+		Signer:        mgmt.GetLocalSigningIdentityOrPanic(),
+		Endorser:      endorser,
+		Orderer:       orderer,
+		Transactions:  transactions,
+		CommitTimeout: time.Second,
+	}
+	return handler, endorser, orderer, transactions
+}
+
+func proposalResponse(t *testing.T, status int32) *pb.ProposalResponse {
+	b := rwsetutil.NewRWSetBuilder()
+	b.AddToWriteSet("mycc", "key", []byte("value"))
+	b.AddToHashedReadSet("mycc", "coll", "pvtkey", nil)
+	simRes, err := b.GetTxSimulationResults()
+	require.NoError(t, err)
+	results, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	event, err := proto.Marshal(&pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: "evt", Payload: []byte("evt-payload")})
+	require.NoError(t, err)
+
+	response := &pb.Response{Status: status, Payload: []byte("result")}
+	payload, err := utils.GetBytesProposalResponsePayload([]byte("hash"), response, results, event, &pb.ChaincodeID{Name: "mycc"})
+	require.NoError(t, err)
+	return &pb.ProposalResponse{
+		Response:    response,
+		Payload:     payload,
+		Endorsement: &pb.Endorsement{},
+	}
+}
+
+func serve(handler http.Handler, method, path, body string) (int, map[string]interface{}) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+
+	var result map[string]interface{}
+	json.Unmarshal(resp.Body.Bytes(), &result)
+	return resp.Code, result
+}
+
+func TestHandlerQuery(t *testing.T) {
+	handler, endorser, orderer, _ := newHandler(t)
+	endorser.ProcessProposalReturns(proposalResponse(t, 200), nil)
+
+	code, result := serve(handler, http.MethodPost, "/devnet/query", `{"chaincode":"mycc","args":["get","key"],"transient":{"secret":"s3cr3t"}}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 0, orderer.OrderCallCount())
+
+	require.Equal(t, 1, endorser.ProcessProposalCallCount())
+	_, signedProp := endorser.ProcessProposalArgsForCall(0)
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	require.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	require.NoError(t, err)
+	channelHeader, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "myc", channelHeader.ChannelId)
+	assert.Equal(t, result["tx_id"], channelHeader.TxId)
+	payload, err := utils.GetChaincodeProposalPayload(prop.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("s3cr3t"), payload.TransientMap["secret"])
+
+	assert.EqualValues(t, 200, result["status"])
+	assert.Equal(t, "cmVzdWx0", result["payload"])
+	assert.Nil(t, result["validation_code"])
+
+	rwset := result["rwset"].([]interface{})
+	require.Len(t, rwset, 1)
+	ns := rwset[0].(map[string]interface{})
+	assert.Equal(t, "mycc", ns["namespace"])
+	writes := ns["kv_rwset"].(map[string]interface{})["writes"].([]interface{})
+	require.Len(t, writes, 1)
+	assert.Equal(t, "key", writes[0].(map[string]interface{})["key"])
+	collections := ns["collections"].([]interface{})
+	require.Len(t, collections, 1)
+	assert.Equal(t, "coll", collections[0].(map[string]interface{})["collection"])
+
+	events := result["events"].([]interface{})
+	require.Len(t, events, 1)
+	assert.Equal(t, "evt", events[0].(map[string]interface{})["event_name"])
+}
+
+func TestHandlerInvoke(t *testing.T) {
+	handler, endorser, orderer, transactions := newHandler(t)
+	endorser.ProcessProposalReturns(proposalResponse(t, 200), nil)
+	transactions.GetTransactionByIDReturnsOnCall(0, nil, errors.New("not found"))
+	transactions.GetTransactionByIDReturnsOnCall(1, &pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_VALID)}, nil)
+
+	code, result := serve(handler, http.MethodPost, "/devnet/invoke", `{"channel":"otherc","chaincode":"mycc","args":["put","key","value"]}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "VALID", result["validation_code"])
+
+	require.Equal(t, 1, orderer.OrderCallCount())
+	env := orderer.OrderArgsForCall(0)
+	chdr, err := utils.ChannelHeader(env)
+	require.NoError(t, err)
+	assert.Equal(t, "otherc", chdr.ChannelId)
+	assert.Equal(t, result["tx_id"], chdr.TxId)
+
+	require.Equal(t, 2, transactions.GetTransactionByIDCallCount())
+	channelID, txID := transactions.GetTransactionByIDArgsForCall(1)
+	assert.Equal(t, "otherc", channelID)
+	assert.Equal(t, result["tx_id"], txID)
+}
+
+func TestHandlerInvokeChaincodeError(t *testing.T) {
+	handler, endorser, orderer, _ := newHandler(t)
+	endorser.ProcessProposalReturns(&pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}, nil)
+
+	code, result := serve(handler, http.MethodPost, "/devnet/invoke", `{"chaincode":"mycc","args":["fail"]}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.EqualValues(t, 500, result["status"])
+	assert.Equal(t, "chaincode failed", result["message"])
+	assert.Nil(t, result["rwset"])
+	assert.Equal(t, 0, orderer.OrderCallCount())
+}
+
+func TestHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		setup       func(*mock.Endorser, *mock.Broadcaster, *mock.TransactionFinder)
+		code        int
+		expectedErr string
+	}{
+		{
+			name:        "invalid method",
+			method:      http.MethodGet,
+			path:        "/devnet/query",
+			code:        http.StatusMethodNotAllowed,
+			expectedErr: "invalid request method: GET",
+		},
+		{
+			name:        "unknown operation",
+			method:      http.MethodPost,
+			path:        "/devnet/deploy",
+			body:        `{"chaincode":"mycc"}`,
+			code:        http.StatusNotFound,
+			expectedErr: "unknown operation: /devnet/deploy",
+		},
+		{
+			name:        "invalid body",
+			method:      http.MethodPost,
+			path:        "/devnet/query",
+			body:        `{`,
+			code:        http.StatusBadRequest,
+			expectedErr: "invalid request body: unexpected EOF",
+		},
+		{
+			name:        "missing chaincode",
+			method:      http.MethodPost,
+			path:        "/devnet/query",
+			body:        `{"args":["get"]}`,
+			code:        http.StatusBadRequest,
+			expectedErr: "chaincode name is required",
+		},
+		{
+			name:   "endorsement failure",
+			method: http.MethodPost,
+			path:   "/devnet/query",
+			body:   `{"chaincode":"mycc"}`,
+			setup: func(e *mock.Endorser, _ *mock.Broadcaster, _ *mock.TransactionFinder) {
+				e.ProcessProposalReturns(nil, errors.New("boom"))
+			},
+			code:        http.StatusInternalServerError,
+			expectedErr: "failed to endorse the proposal: boom",
+		},
+		{
+			name:   "ordering failure",
+			method: http.MethodPost,
+			path:   "/devnet/invoke",
+			body:   `{"chaincode":"mycc"}`,
+			setup: func(_ *mock.Endorser, o *mock.Broadcaster, _ *mock.TransactionFinder) {
+				o.OrderReturns(errors.New("boom"))
+			},
+			code:        http.StatusInternalServerError,
+			expectedErr: "failed to order the transaction: boom",
+		},
+		{
+			name:   "commit timeout",
+			method: http.MethodPost,
+			path:   "/devnet/invoke",
+			body:   `{"chaincode":"mycc"}`,
+			setup: func(_ *mock.Endorser, _ *mock.Broadcaster, tf *mock.TransactionFinder) {
+				tf.GetTransactionByIDReturns(nil, errors.New("not found"))
+			},
+			code:        http.StatusInternalServerError,
+			expectedErr: "timed out waiting for transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, endorser, orderer, transactions := newHandler(t)
+			handler.CommitTimeout = 100 * time.Millisecond
+			endorser.ProcessProposalReturns(proposalResponse(t, 200), nil)
+			if tt.setup != nil {
+				tt.setup(endorser, orderer, transactions)
+			}
+
+			code, result := serve(handler, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.code, code)
+			assert.Contains(t, result["error"], tt.expectedErr)
+		})
+	}
+}
+
+func TestCheckOperationsEndpoint(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:9443", "localhost:9443", "[::1]:9443"} {
+		assert.NoError(t, devnet.CheckOperationsEndpoint(address, false), address)
+	}
+	for _, address := range []string{"0.0.0.0:9443", ":9443", "10.0.0.1:9443", "peer0.example.com:9443"} {
+		err := devnet.CheckOperationsEndpoint(address, false)
+		assert.EqualError(t, err, "operations endpoint "+address+" is neither protected by TLS client authentication nor bound to a loopback address")
+		// Handlers of the operations endpoint require client certificates with TLS
+		assert.NoError(t, devnet.CheckOperationsEndpoint(address, true), address)
+	}
+
+	err := devnet.CheckOperationsEndpoint("9443", false)
+	assert.Contains(t, err.Error(), "invalid operations listen address 9443")
+}
@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package devnet
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/server"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/solo"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Orderer is a solo ordering service embedded in the peer. It serves the
+// AtomicBroadcast API, so that it can be registered on the gRPC server of
+// the peer, and orders transactions submitted in-process.
+type Orderer struct {
+	ab.AtomicBroadcastServer
+	registrar *multichannel.Registrar
+}
+
+// NewOrderer creates an Orderer storing its blocks with the ledger factory.
+// The system channel is bootstrapped from the genesis block when the ledger
+// factory has no channel yet. The orderer doesn't report metrics, as the
+// metrics of its broadcast and deliver services would collide with those
+// of the peer.
+func NewOrderer(lf blockledger.Factory, genesisBlock *cb.Block, signer crypto.LocalSigner) (*Orderer, error) {
+	if len(lf.ChainIDs()) == 0 {
+		chainID, err := utils.GetChainIDFromBlock(genesisBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid genesis block")
+		}
+		rl, err := lf.GetOrCreate(chainID)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create the system channel")
+		}
+		if err := rl.Append(genesisBlock); err != nil {
+			return nil, errors.WithMessage(err, "failed to write the genesis block")
+		}
+	}
+
+	conf := localconfig.Defaults
+	metricsProvider := &disabled.Provider{}
+	registrar := multichannel.NewRegistrar(conf, lf, signer, metricsProvider)
+	registrar.Initialize(map[string]consensus.Consenter{"solo": solo.New()})
+
+	return &Orderer{
+		AtomicBroadcastServer: server.NewServer(registrar, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, false, false),
+		registrar:             registrar,
+	}, nil
+}
+
+// Order submits a transaction or a config update for ordering
+func (o *Orderer) Order(env *cb.Envelope) error {
+	_, isConfig, cs, err := o.registrar.BroadcastChannelSupport(env)
+	if err != nil {
+		return err
+	}
+
+	if isConfig {
+		config, configSeq, err := cs.ProcessConfigUpdateMsg(env)
+		if err != nil {
+			return errors.WithMessage(err, "config update rejected")
+		}
+		if err := cs.WaitReady(); err != nil {
+			return err
+		}
+		return cs.Configure(config, configSeq)
+	}
+
+	configSeq, err := cs.ProcessNormalMsg(env)
+	if err != nil {
+		return errors.WithMessage(err, "transaction rejected")
+	}
+	if err := cs.WaitReady(); err != nil {
+		return err
+	}
+	return cs.Order(env, configSeq)
+}
+
+// CreateChannel submits the channel creation transaction unless the channel
+// already exists, and returns the genesis block of the channel once it has
+// been created.
+func (o *Orderer) CreateChannel(env *cb.Envelope, timeout time.Duration) (*cb.Block, error) {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid channel creation transaction")
+	}
+
+	if o.registrar.GetChain(chdr.ChannelId) == nil {
+		if err := o.Order(env); err != nil {
+			return nil, errors.WithMessage(err, "failed to create channel "+chdr.ChannelId)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if cs := o.registrar.GetChain(chdr.ChannelId); cs != nil {
+			if block := blockledger.GetBlock(cs, 0); block != nil {
+				return block, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for channel %s to be created", chdr.ChannelId)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -294,7 +294,7 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid
 }
 
 // endorse the proposal by calling the ESCC
-func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid string, signedProp *pb.SignedProposal, proposal *pb.Proposal, response *pb.Response, simRes []byte, event *pb.ChaincodeEvent, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator, cd ccprovider.ChaincodeDefinition) (*pb.ProposalResponse, error) {
+func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid string, signedProp *pb.SignedProposal, proposal *pb.Proposal, response *pb.Response, simRes []byte, event *pb.ChaincodeEvent, events []*pb.ChaincodeEvent, crossChannelReads []*pb.CrossChannelRead, visibility []byte, ccid *pb.ChaincodeID, txsim ledger.TxSimulator, cd ccprovider.ChaincodeDefinition) (*pb.ProposalResponse, error) {
 	endorserLogger.Debugf("[%s][%s] Entry chaincode: %s", chainID, shorttxid(txid), ccid)
 	defer endorserLogger.Debugf("[%s][%s] Exit", chainID, shorttxid(txid))
 
@@ -340,6 +340,9 @@ func (e *Endorser) endorseProposal(_ context.Context, chainID string, txid strin
 		Visibility:     visibility,
 		Proposal:       proposal,
 		TxID:           txid,
+
+		ChaincodeEvents:   events,
+		CrossChannelReads: crossChannelReads,
 	}
 	return e.s.EndorseWithPlugin(ctx)
 }
@@ -488,6 +491,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		Proposal:             prop,
 		TXSimulator:          txsim,
 		HistoryQueryExecutor: historyQueryExecutor,
+		CrossChannelReads:    &ccprovider.CrossChannelReads{},
 	}
 	// this could be a request to a chainless SysCC
 
@@ -529,7 +533,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
 		// Note: To endorseProposal(), we pass the released txsim. Hence, an error would occur if we try to use this txsim
-		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, txParams.ChaincodeEvents, txParams.CrossChannelReads.Reads(), hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
 
 		// if error, capture endorsement failure metric
 		meterLabels := []string{
@@ -557,6 +561,10 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	// chaincode invocation
 	pResp.Response = res
 
+	// The heights of the ledgers of the other channels differ between
+	// endorsers, so they are reported outside of the endorsed payload
+	pResp.CrossChannelReadHeights = txParams.CrossChannelReads.Heights()
+
 	// total failed proposals = ProposalsReceived-SuccessfulProposals
 	e.Metrics.SuccessfulProposals.Add(1)
 	success = true
@@ -633,6 +633,80 @@ func attachPluginEndorser(support *em.MockSupport, signerReturnErr error) {
 	})
 }
 
+func TestEndorserCrossChannelReads(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	crossChannelReads := []*pb.CrossChannelRead{
+		{ChannelId: "otherchannel", ChaincodeName: "othercc", Results: []byte("reads")},
+	}
+	support := &em.MockSupport{
+		Mock:                           m,
+		GetApplicationConfigBoolRv:     true,
+		GetApplicationConfigRv:         &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:          errors.New(""),
+		ChaincodeDefinitionRv:          &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                    &pb.Response{Status: 200},
+		ExecuteCrossChannelReads:       crossChannelReads,
+		ExecuteCrossChannelReadHeights: []uint64{42},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	prp, err := utils.GetProposalResponsePayload(pResp.Payload)
+	assert.NoError(t, err)
+	action, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+	assert.Len(t, action.CrossChannelReads, 1)
+	assert.True(t, proto.Equal(crossChannelReads[0], action.CrossChannelReads[0]))
+
+	// the heights are outside of the payload signed by the endorser
+	assert.Equal(t, []uint64{42}, pResp.CrossChannelReadHeights)
+}
+
+func TestEndorserChaincodeEvents(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	events := []*pb.ChaincodeEvent{
+		{ChaincodeId: "ccid", EventName: "first"},
+		{ChaincodeId: "ccid", EventName: "second"},
+	}
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200},
+		ExecuteEvent:               events[1],
+		ExecuteEvents:              events,
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	prp, err := utils.GetProposalResponsePayload(pResp.Payload)
+	assert.NoError(t, err)
+	action, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+	event, err := utils.GetChaincodeEvents(action.Events)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(events[1], event))
+	assert.Len(t, action.ChaincodeEvents, 2)
+	assert.True(t, proto.Equal(events[0], action.ChaincodeEvents[0]))
+	assert.True(t, proto.Equal(events[1], action.ChaincodeEvents[1]))
+}
+
 func TestEndorseWithPlugin(t *testing.T) {
 	m := &mock.Mock{}
 	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
@@ -1,6 +1,7 @@
 // Code generated by mockery v1.0.0
 package mocks
 
+import gossip "github.com/hyperledger/fabric/protos/gossip"
 import ledger "github.com/hyperledger/fabric/core/ledger"
 import mock "github.com/stretchr/testify/mock"
 import protostransientstore "github.com/hyperledger/fabric/protos/transientstore"
@@ -33,6 +34,29 @@ func (_m *Store) GetMinTransientBlkHt() (uint64, error) {
 	return r0, r1
 }
 
+// GetShards provides a mock function with given fields: txid
+func (_m *Store) GetShards(txid string) ([]*gossip.PrivatePayload, error) {
+	ret := _m.Called(txid)
+
+	var r0 []*gossip.PrivatePayload
+	if rf, ok := ret.Get(0).(func(string) []*gossip.PrivatePayload); ok {
+		r0 = rf(txid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*gossip.PrivatePayload)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(txid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxPvtRWSetByTxid provides a mock function with given fields: txid, filter
 func (_m *Store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (transientstore.RWSetScanner, error) {
 	ret := _m.Called(txid, filter)
@@ -84,6 +108,20 @@ func (_m *Store) PersistWithConfig(txid string, blockHeight uint64, privateSimul
 	return r0
 }
 
+// PersistShard provides a mock function with given fields: txid, blockHeight, shard
+func (_m *Store) PersistShard(txid string, blockHeight uint64, shard *gossip.PrivatePayload) error {
+	ret := _m.Called(txid, blockHeight, shard)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint64, *gossip.PrivatePayload) error); ok {
+		r0 = rf(txid, blockHeight, shard)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PurgeByHeight provides a mock function with given fields: maxBlockNumToRetain
 func (_m *Store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 	ret := _m.Called(maxBlockNumToRetain)
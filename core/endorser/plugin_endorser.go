@@ -63,6 +63,12 @@ type Context struct {
 	Event          []byte
 	ChaincodeID    *pb.ChaincodeID
 	SimRes         []byte
+	// ChaincodeEvents holds the events set by the chaincode, when it set
+	// more than one; Event then holds the last of them
+	ChaincodeEvents []*pb.ChaincodeEvent
+	// CrossChannelReads holds the reads performed on other channels
+	// during the simulation, when they are recorded
+	CrossChannelReads []*pb.CrossChannelRead
 }
 
 // String returns a text representation of this context
@@ -237,7 +243,14 @@ func proposalResponsePayloadFromContext(ctx Context) ([]byte, error) {
 		return nil, errors.Wrap(err, "could not compute proposal hash")
 	}
 
-	prpBytes, err := putils.GetBytesProposalResponsePayload(pHashBytes, ctx.Response, ctx.SimRes, ctx.Event, ctx.ChaincodeID)
+	prpBytes, err := putils.GetBytesProposalResponsePayloadForAction(pHashBytes, &pb.ChaincodeAction{
+		Events:            ctx.Event,
+		Results:           ctx.SimRes,
+		Response:          ctx.Response,
+		ChaincodeId:       ctx.ChaincodeID,
+		CrossChannelReads: ctx.CrossChannelReads,
+		ChaincodeEvents:   ctx.ChaincodeEvents,
+	})
 	if err != nil {
 		endorserLogger.Warning("Failed marshaling the proposal response payload to bytes", err)
 		return nil, errors.New("failure while marshaling the ProposalResponsePayload")
@@ -118,6 +118,67 @@ func TestPluginEndorserGreenPath(t *testing.T) {
 	plugin.AssertCalled(t, "Init", sif)
 }
 
+func TestPluginEndorserChaincodeAction(t *testing.T) {
+	proposal, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		},
+	}, []byte{1, 2, 3})
+	assert.NoError(t, err)
+	var payload []byte
+	pluginMapper := &mocks.PluginMapper{}
+	pluginFactory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	plugin.On("Endorse", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		payload = args.Get(0).([]byte)
+	}).Return(&peer.Endorsement{}, []byte{1, 2, 3}, nil)
+	pluginMapper.On("PluginFactoryByName", endorser.PluginName("plugin")).Return(pluginFactory)
+	plugin.On("Init", mock.Anything, mock.Anything).Return(nil)
+	pluginFactory.On("New").Return(plugin)
+	cs := &mocks.ChannelStateRetriever{}
+	cs.On("NewQueryCreator", "mychannel").Return(&mocks.QueryCreator{}, nil)
+	pluginEndorser := endorser.NewPluginEndorser(&endorser.PluginSupport{
+		ChannelStateRetriever:   cs,
+		SigningIdentityFetcher:  &mocks.SigningIdentityFetcher{},
+		PluginMapper:            pluginMapper,
+		TransientStoreRetriever: mockTransientStoreRetriever,
+	})
+	crossChannelReads := []*peer.CrossChannelRead{
+		{ChannelId: "otherchannel", ChaincodeName: "othercc", Results: []byte("reads")},
+	}
+	events := []*peer.ChaincodeEvent{
+		{ChaincodeId: "mycc", EventName: "first"},
+		{ChaincodeId: "mycc", EventName: "second"},
+	}
+	ctx := endorser.Context{
+		Response:          &peer.Response{Status: 200},
+		PluginName:        "plugin",
+		Proposal:          proposal,
+		ChaincodeID:       &peer.ChaincodeID{Name: "mycc"},
+		Channel:           "mychannel",
+		SimRes:            []byte("simulation-results"),
+		Event:             []byte("last-event"),
+		ChaincodeEvents:   events,
+		CrossChannelReads: crossChannelReads,
+	}
+
+	_, err = pluginEndorser.EndorseWithPlugin(ctx)
+	assert.NoError(t, err)
+
+	prp, err := utils.GetProposalResponsePayload(payload)
+	assert.NoError(t, err)
+	action, err := utils.GetChaincodeAction(prp.Extension)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&peer.ChaincodeAction{
+		Results:           []byte("simulation-results"),
+		Response:          &peer.Response{Status: 200},
+		ChaincodeId:       &peer.ChaincodeID{Name: "mycc"},
+		Events:            []byte("last-event"),
+		ChaincodeEvents:   events,
+		CrossChannelReads: crossChannelReads,
+	}, action))
+}
+
 func TestPluginEndorserErrors(t *testing.T) {
 	pluginMapper := &mocks.PluginMapper{}
 	pluginFactory := &mocks.PluginFactory{}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -53,18 +54,15 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 	for _, pvtRwset := range privData.NsPvtRwset {
 		namespace := pvtRwset.Namespace
 		if _, found := txPvtRwSetWithConfig.CollectionConfigs[namespace]; !found {
-			cb, err := txsim.GetState("lscc", privdata.BuildCollectionKVSKey(namespace))
-			if err != nil {
-				return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
-			}
-			if cb == nil {
-				return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+			var colCP *common.CollectionConfigPackage
+			var err error
+			if namespace == lifecycle.LifecycleNamespace {
+				colCP, err = implicitCollectionConfigPackage(pvtRwset)
+			} else {
+				colCP, err = as.collectionConfigPackage(namespace, txsim)
 			}
-
-			colCP := &common.CollectionConfigPackage{}
-			err = proto.Unmarshal(cb, colCP)
 			if err != nil {
-				return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
+				return nil, err
 			}
 
 			txPvtRwSetWithConfig.CollectionConfigs[namespace] = colCP
@@ -74,6 +72,55 @@ func (as *rwSetAssembler) AssemblePvtRWSet(privData *rwset.TxPvtReadWriteSet, tx
 	return txPvtRwSetWithConfig, nil
 }
 
+// collectionConfigPackage retrieves the collections of a chaincode defined through
+// the lifecycle SCC, or of a chaincode instantiated through lscc otherwise
+func (as *rwSetAssembler) collectionConfigPackage(namespace string, txsim CollectionConfigRetriever) (*common.CollectionConfigPackage, error) {
+	cd, err := lifecycle.GetChaincodeDefinition(namespace, txsim)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
+	}
+	if cd != nil {
+		if cd.Definition.Collections == nil {
+			return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+		}
+		return cd.Definition.Collections, nil
+	}
+
+	cb, err := txsim.GetState("lscc", privdata.BuildCollectionKVSKey(namespace))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error while retrieving collection config for chaincode %#v", namespace))
+	}
+	if cb == nil {
+		return nil, errors.New(fmt.Sprintf("no collection config for chaincode %#v", namespace))
+	}
+
+	colCP := &common.CollectionConfigPackage{}
+	err = proto.Unmarshal(cb, colCP)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid configuration for collection criteria %#v", namespace)
+	}
+	return colCP, nil
+}
+
+// implicitCollectionConfigPackage returns the configurations of the implicit
+// collections of the orgs which the lifecycle SCC writes to, as they are not
+// defined by any chaincode
+func implicitCollectionConfigPackage(pvtRwset *rwset.NsPvtReadWriteSet) (*common.CollectionConfigPackage, error) {
+	colCP := &common.CollectionConfigPackage{}
+	for _, collPvtRwset := range pvtRwset.CollectionPvtRwset {
+		mspID, isImplicit := privdata.MSPIDIfImplicitCollection(collPvtRwset.CollectionName)
+		if !isImplicit {
+			return nil, errors.New(fmt.Sprintf("no collection config for collection %#v of chaincode %#v", collPvtRwset.CollectionName, pvtRwset.Namespace))
+		}
+		colCP.Config = append(colCP.Config, &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: privdata.GenerateImplicitCollectionForOrg(mspID),
+			},
+		})
+	}
+	return colCP, nil
+}
+
 func (as *rwSetAssembler) trimCollectionConfigs(pvtData *transientstore.TxPvtReadWriteSetWithConfigInfo) {
 	flags := make(map[string]map[string]struct{})
 	for _, pvtRWset := range pvtData.PvtRwset.NsPvtRwset {
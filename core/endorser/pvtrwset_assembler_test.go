@@ -54,6 +54,7 @@ func TestAssemblePvtRWSet(t *testing.T) {
 	assert.NoError(t, err)
 
 	configRetriever := &mockCollectionConfigRetriever{}
+	configRetriever.On("GetState", "+lifecycle", "myCC").Return([]byte(nil), nil)
 	configRetriever.On("GetState", "lscc", privdata.BuildCollectionKVSKey("myCC")).Return(colB, nil)
 
 	assembler := rwSetAssembler{}
@@ -88,3 +89,36 @@ func TestAssemblePvtRWSet(t *testing.T) {
 	assert.Equal(t, 1, len(pvtReadWriteSetWithConfigInfo.PvtRwset.NsPvtRwset))
 
 }
+
+func TestAssemblePvtRWSetLifecycle(t *testing.T) {
+	assembler := rwSetAssembler{}
+	configRetriever := &mockCollectionConfigRetriever{}
+
+	privData := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "+lifecycle",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{
+						CollectionName: "_implicit_org_Org1MSP",
+						Rwset:          []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					},
+				},
+			},
+		},
+	}
+
+	// The implicit collections of the orgs are not read from the state
+	pvtReadWriteSetWithConfigInfo, err := assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.NoError(t, err)
+	configs, found := pvtReadWriteSetWithConfigInfo.CollectionConfigs["+lifecycle"]
+	assert.True(t, found)
+	assert.Equal(t, 1, len(configs.Config))
+	assert.True(t, proto.Equal(privdata.GenerateImplicitCollectionForOrg("Org1MSP"), configs.Config[0].GetStaticCollectionConfig()))
+	configRetriever.AssertNotCalled(t, "GetState")
+
+	privData.NsPvtRwset[0].CollectionPvtRwset[0].CollectionName = "mycollection"
+	_, err = assembler.AssemblePvtRWSet(privData, configRetriever)
+	assert.EqualError(t, err, `no collection config for collection "mycollection" of chaincode "+lifecycle"`)
+}
@@ -173,7 +173,12 @@ func (s *SupportImpl) IsJavaCC(buf []byte) (bool, error) {
 // CheckInstantiationPolicy returns an error if the instantiation in the supplied
 // ChaincodeDefinition differs from the instantiation policy stored on the ledger
 func (s *SupportImpl) CheckInstantiationPolicy(name, version string, cd ccprovider.ChaincodeDefinition) error {
-	return ccprovider.CheckInstantiationPolicy(name, version, cd.(*ccprovider.ChaincodeData))
+	cdLedger, isLegacy := cd.(*ccprovider.ChaincodeData)
+	if !isLegacy {
+		// chaincodes defined through the lifecycle SCC have no instantiation policy
+		return nil
+	}
+	return ccprovider.CheckInstantiationPolicy(name, version, cdLedger)
 }
 
 // GetApplicationConfig returns the configtxapplication.SharedConfig for the Channel
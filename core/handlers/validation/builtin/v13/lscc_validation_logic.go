@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/erasure"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
@@ -19,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/state"
@@ -62,7 +64,7 @@ func (vscc *Validator) checkInstantiationPolicy(chainName string, env *common.En
 	return nil
 }
 
-func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfig) error {
+func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfig, ac channelconfig.ApplicationCapabilities) error {
 	newCollectionsMap := make(map[string]bool, len(newCollectionConfigs))
 	// Process each collection config from a set of collection configs
 	for _, newCollectionConfig := range newCollectionConfigs {
@@ -98,6 +100,12 @@ func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfi
 				collectionName, maximumPeerCount, requiredPeerCount)
 
 		}
+		// peers without the capability don't know the erasure coding configuration
+		if ec := newCollection.GetErasureCoding(); ec != nil && ac.ErasureCodedCollections() {
+			if err := erasure.Validate(int(ec.DataShards), int(ec.ParityShards)); err != nil {
+				return fmt.Errorf("collection-name: %s -- invalid erasure coding configuration: %s", collectionName, err)
+			}
+		}
 
 		// make sure that the signature policy is meaningful (only consists of ORs)
 		err := validateSpOrConcat(newCollection.MemberOrgsPolicy.GetSignaturePolicy().Rule)
@@ -310,7 +318,7 @@ func (vscc *Validator) validateRWSetAndCollection(
 
 	if ac.V1_2Validation() {
 		newCollectionConfigs := newCollectionConfigPackage.GetConfig()
-		if err := validateNewCollectionConfigs(newCollectionConfigs); err != nil {
+		if err := validateNewCollectionConfigs(newCollectionConfigs, ac); err != nil {
 			return policyErr(err)
 		}
 
@@ -389,16 +397,21 @@ func (vscc *Validator) ValidateLSCCInvocation(
 			return policyErr(fmt.Errorf("Wrong number of arguments for invocation lscc(%s): received %d", lsccFunc, len(lsccArgs)))
 		}
 
-		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(
-			// XXX We should definitely _not_ have this external dependency in VSCC
-			// as adding a platform could cause non-determinism.  This is yet another
-			// reason why all of this custom LSCC validation at commit time has no
-			// long term hope of staying deterministic and needs to be removed.
+		// XXX We should definitely _not_ have this external dependency in VSCC
+		// as adding a platform could cause non-determinism.  This is yet another
+		// reason why all of this custom LSCC validation at commit time has no
+		// long term hope of staying deterministic and needs to be removed.
+		// Platforms added since are therefore gated by a capability.
+		platformRegistry := []platforms.Platform{
 			&golang.Platform{},
 			&node.Platform{},
 			&java.Platform{},
 			&car.Platform{},
-		))
+		}
+		if ac.WasmChaincode() {
+			platformRegistry = append(platformRegistry, &wasm.Platform{})
+		}
+		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(platformRegistry...))
 
 		if err != nil {
 			return policyErr(fmt.Errorf("GetChaincodeDeploymentSpec error %s", err))
@@ -917,6 +917,55 @@ func TestValidateDeployNOKNilChaincodeSpec(t *testing.T) {
 	assert.EqualError(t, err, "GetChaincodeDeploymentSpec error Unknown chaincodeType: ")
 }
 
+func TestValidateDeployWasm(t *testing.T) {
+	state := make(map[string]map[string][]byte)
+	mp := (&scc.MocksccProviderFactory{
+		Qe:                    lm.NewMockQueryExecutor(state),
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+	}).NewSystemChaincodeProvider().(*scc.MocksccProviderImpl)
+
+	mockAclProvider := &aclmocks.MockACLProvider{}
+	lccc := lscc.New(mp, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	stublccc := shim.NewMockStub("lscc", lccc)
+	state["lscc"] = stublccc.State
+
+	ccname := "mycc"
+	ccver := "1"
+
+	defaultPolicy, err := getSignedByMSPAdminPolicy(mspid)
+	assert.NoError(t, err)
+	res, err := createCCDataRWset(ccname, ccname, ccver, defaultPolicy)
+	assert.NoError(t, err)
+
+	cdsBytes, err := proto.Marshal(&peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: ccname, Version: ccver},
+			Type:        peer.ChaincodeSpec_WASM,
+		},
+	})
+	assert.NoError(t, err)
+	tx, err := createLSCCTxPutCds(ccname, ccver, lscc.DEPLOY, res, cdsBytes, true)
+	assert.NoError(t, err)
+	envBytes, err := utils.GetBytesEnvelope(tx)
+	assert.NoError(t, err)
+
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{envBytes}}, Header: &common.BlockHeader{}}
+
+	// WASM chaincode can't be deployed without the capability
+	v := newValidationInstance(state)
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.EqualError(t, err, "GetChaincodeDeploymentSpec error Unknown chaincodeType: WASM")
+
+	qec := &mocks2.QueryExecutorCreator{}
+	qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+	v = newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{WasmChaincodeRv: true})
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.NoError(t, err)
+}
+
 func TestValidateDeployOK(t *testing.T) {
 	state := make(map[string]map[string][]byte)
 	mp := (&scc.MocksccProviderFactory{
@@ -1979,6 +2028,28 @@ func TestValidateRWSetAndCollectionForDeploy(t *testing.T) {
 	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
 	assert.EqualError(t, err, "collection-name: mycollection3 -- maximum peer count (1) cannot be greater than the required peer count (2)")
 
+	// Test 12: erasure coding with too many shards -> success as erasure coding is not validated without the v1.4.3 capability
+	requiredPeerCount = 1
+	maximumPeerCount = 2
+	coll3 = createCollectionConfig(collName3, policyEnvelope, requiredPeerCount, maximumPeerCount, blockToLive)
+	coll3.GetStaticCollectionConfig().ErasureCoding = &common.ErasureCodingConfig{DataShards: 200, ParityShards: 100}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.NoError(t, err)
+
+	// Enable v1.4.3 validation mode
+	ac = capabilities.NewApplicationProvider(map[string]*common.Capability{
+		capabilities.ApplicationV1_4_3: {},
+	})
+
+	// Test 12: erasure coding with too many shards -> error
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.EqualError(t, err, "collection-name: mycollection3 -- invalid erasure coding configuration: total number of shards (300) exceeds the maximum of 256")
+
+	// Test 12: valid erasure coding -> success
+	coll3.GetStaticCollectionConfig().ErasureCoding = &common.ErasureCodingConfig{DataShards: 4, ParityShards: 2}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll2, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.NoError(t, err)
+
 	// Test 12: AND concatenation of orgs in access policy -> error
 	requiredPeerCount = 1
 	maximumPeerCount = 2
@@ -13,13 +13,14 @@ import (
 // collNameValidator validates the presence of a collection in a namespace
 // This is expected to be instantiated in the context of a simulator/queryexecutor
 type collNameValidator struct {
+	ledgerID       string
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider
 	queryExecutor  *lockBasedQueryExecutor
 	cache          collConfigCache
 }
 
-func newCollNameValidator(ccInfoProvider ledger.DeployedChaincodeInfoProvider, qe *lockBasedQueryExecutor) *collNameValidator {
-	return &collNameValidator{ccInfoProvider, qe, make(collConfigCache)}
+func newCollNameValidator(ledgerID string, ccInfoProvider ledger.DeployedChaincodeInfoProvider, qe *lockBasedQueryExecutor) *collNameValidator {
+	return &collNameValidator{ledgerID, ccInfoProvider, qe, make(collConfigCache)}
 }
 
 func (v *collNameValidator) validateCollName(ns, coll string) error {
@@ -30,18 +31,29 @@ func (v *collNameValidator) validateCollName(ns, coll string) error {
 		}
 		v.cache.populate(ns, conf)
 	}
-	if !v.cache.containsCollName(ns, coll) {
+	if v.cache.containsCollName(ns, coll) {
+		return nil
+	}
+	// collections which are not part of the collection config package of the namespace,
+	// such as the implicit collections of the orgs in the namespace of the lifecycle SCC,
+	// are resolved one at a time
+	collConfig, err := v.ccInfoProvider.CollectionInfo(v.ledgerID, ns, coll, v.queryExecutor)
+	if err != nil {
+		return err
+	}
+	if collConfig == nil {
 		return &ledger.InvalidCollNameError{
 			Ns:   ns,
 			Coll: coll,
 		}
 	}
+	v.cache.add(ns, coll)
 	return nil
 }
 
 func (v *collNameValidator) retrieveCollConfigFromStateDB(ns string) (*common.CollectionConfigPackage, error) {
 	logger.Debugf("retrieveCollConfigFromStateDB() begin - ns=[%s]", ns)
-	ccInfo, err := v.ccInfoProvider.ChaincodeInfo(ns, v.queryExecutor)
+	ccInfo, err := v.ccInfoProvider.ChaincodeInfo(v.ledgerID, ns, v.queryExecutor)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +84,10 @@ func (c collConfigCache) populate(ns string, pkg *common.CollectionConfigPackage
 	}
 }
 
+func (c collConfigCache) add(ns, coll string) {
+	c[collConfigkey{ns, coll}] = true
+}
+
 func (c collConfigCache) isPopulatedFor(ns string) bool {
 	return c[collConfigkey{ns, ""}]
 }
@@ -10,6 +10,9 @@ import (
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +52,27 @@ func TestCollectionValidation(t *testing.T) {
 
 	err = sim.SetPrivateData("ns1", "coll1", "key1", []byte("val1"))
 	assert.NoError(t, err)
+
+	// collections outside of the collection config package are resolved individually
+	ccInfoProvider := txMgr.(*LockBasedTxMgr).ccInfoProvider.(*mock.DeployedChaincodeInfoProvider)
+	ccInfoProvider.CollectionInfoStub = func(channelName, ccName, collName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+		if ccName == "ns1" && collName == "implicit-coll" {
+			return &common.StaticCollectionConfig{Name: collName}, nil
+		}
+		return nil, nil
+	}
+	callCount := ccInfoProvider.CollectionInfoCallCount()
+	err = sim.SetPrivateData("ns1", "implicit-coll", "key1", []byte("val1"))
+	assert.NoError(t, err)
+	_, err = sim.GetPrivateData("ns1", "implicit-coll", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, callCount+1, ccInfoProvider.CollectionInfoCallCount())
+	channelName, _, _, _ := ccInfoProvider.CollectionInfoArgsForCall(callCount)
+	assert.Equal(t, "testLedger", channelName)
+
+	ccInfoProvider.CollectionInfoReturns(nil, errors.New("collection info error"))
+	err = sim.SetPrivateData("ns2", "coll3", "key1", []byte("val1"))
+	assert.EqualError(t, err, "collection info error")
 }
 
 func TestPvtGetNoCollection(t *testing.T) {
@@ -32,9 +32,10 @@ type DeployedChaincodeInfoProvider struct {
 		result1 []*ledger.ChaincodeLifecycleInfo
 		result2 error
 	}
-	ChaincodeInfoStub        func(chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error)
+	ChaincodeInfoStub        func(channelName, chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error)
 	chaincodeInfoMutex       sync.RWMutex
 	chaincodeInfoArgsForCall []struct {
+		channelName   string
 		chaincodeName string
 		qe            ledger.SimpleQueryExecutor
 	}
@@ -46,9 +47,10 @@ type DeployedChaincodeInfoProvider struct {
 		result1 *ledger.DeployedChaincodeInfo
 		result2 error
 	}
-	CollectionInfoStub        func(chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error)
+	CollectionInfoStub        func(channelName, chaincodeName, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error)
 	collectionInfoMutex       sync.RWMutex
 	collectionInfoArgsForCall []struct {
+		channelName    string
 		chaincodeName  string
 		collectionName string
 		qe             ledger.SimpleQueryExecutor
@@ -156,17 +158,18 @@ func (fake *DeployedChaincodeInfoProvider) UpdatedChaincodesReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *DeployedChaincodeInfoProvider) ChaincodeInfo(chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error) {
+func (fake *DeployedChaincodeInfoProvider) ChaincodeInfo(channelName string, chaincodeName string, qe ledger.SimpleQueryExecutor) (*ledger.DeployedChaincodeInfo, error) {
 	fake.chaincodeInfoMutex.Lock()
 	ret, specificReturn := fake.chaincodeInfoReturnsOnCall[len(fake.chaincodeInfoArgsForCall)]
 	fake.chaincodeInfoArgsForCall = append(fake.chaincodeInfoArgsForCall, struct {
+		channelName   string
 		chaincodeName string
 		qe            ledger.SimpleQueryExecutor
-	}{chaincodeName, qe})
-	fake.recordInvocation("ChaincodeInfo", []interface{}{chaincodeName, qe})
+	}{channelName, chaincodeName, qe})
+	fake.recordInvocation("ChaincodeInfo", []interface{}{channelName, chaincodeName, qe})
 	fake.chaincodeInfoMutex.Unlock()
 	if fake.ChaincodeInfoStub != nil {
-		return fake.ChaincodeInfoStub(chaincodeName, qe)
+		return fake.ChaincodeInfoStub(channelName, chaincodeName, qe)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -180,10 +183,10 @@ func (fake *DeployedChaincodeInfoProvider) ChaincodeInfoCallCount() int {
 	return len(fake.chaincodeInfoArgsForCall)
 }
 
-func (fake *DeployedChaincodeInfoProvider) ChaincodeInfoArgsForCall(i int) (string, ledger.SimpleQueryExecutor) {
+func (fake *DeployedChaincodeInfoProvider) ChaincodeInfoArgsForCall(i int) (string, string, ledger.SimpleQueryExecutor) {
 	fake.chaincodeInfoMutex.RLock()
 	defer fake.chaincodeInfoMutex.RUnlock()
-	return fake.chaincodeInfoArgsForCall[i].chaincodeName, fake.chaincodeInfoArgsForCall[i].qe
+	return fake.chaincodeInfoArgsForCall[i].channelName, fake.chaincodeInfoArgsForCall[i].chaincodeName, fake.chaincodeInfoArgsForCall[i].qe
 }
 
 func (fake *DeployedChaincodeInfoProvider) ChaincodeInfoReturns(result1 *ledger.DeployedChaincodeInfo, result2 error) {
@@ -208,18 +211,19 @@ func (fake *DeployedChaincodeInfoProvider) ChaincodeInfoReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
-func (fake *DeployedChaincodeInfoProvider) CollectionInfo(chaincodeName string, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
+func (fake *DeployedChaincodeInfoProvider) CollectionInfo(channelName string, chaincodeName string, collectionName string, qe ledger.SimpleQueryExecutor) (*common.StaticCollectionConfig, error) {
 	fake.collectionInfoMutex.Lock()
 	ret, specificReturn := fake.collectionInfoReturnsOnCall[len(fake.collectionInfoArgsForCall)]
 	fake.collectionInfoArgsForCall = append(fake.collectionInfoArgsForCall, struct {
+		channelName    string
 		chaincodeName  string
 		collectionName string
 		qe             ledger.SimpleQueryExecutor
-	}{chaincodeName, collectionName, qe})
-	fake.recordInvocation("CollectionInfo", []interface{}{chaincodeName, collectionName, qe})
+	}{channelName, chaincodeName, collectionName, qe})
+	fake.recordInvocation("CollectionInfo", []interface{}{channelName, chaincodeName, collectionName, qe})
 	fake.collectionInfoMutex.Unlock()
 	if fake.CollectionInfoStub != nil {
-		return fake.CollectionInfoStub(chaincodeName, collectionName, qe)
+		return fake.CollectionInfoStub(channelName, chaincodeName, collectionName, qe)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -233,10 +237,10 @@ func (fake *DeployedChaincodeInfoProvider) CollectionInfoCallCount() int {
 	return len(fake.collectionInfoArgsForCall)
 }
 
-func (fake *DeployedChaincodeInfoProvider) CollectionInfoArgsForCall(i int) (string, string, ledger.SimpleQueryExecutor) {
+func (fake *DeployedChaincodeInfoProvider) CollectionInfoArgsForCall(i int) (string, string, string, ledger.SimpleQueryExecutor) {
 	fake.collectionInfoMutex.RLock()
 	defer fake.collectionInfoMutex.RUnlock()
-	return fake.collectionInfoArgsForCall[i].chaincodeName, fake.collectionInfoArgsForCall[i].collectionName, fake.collectionInfoArgsForCall[i].qe
+	return fake.collectionInfoArgsForCall[i].channelName, fake.collectionInfoArgsForCall[i].chaincodeName, fake.collectionInfoArgsForCall[i].collectionName, fake.collectionInfoArgsForCall[i].qe
 }
 
 func (fake *DeployedChaincodeInfoProvider) CollectionInfoReturns(result1 *common.StaticCollectionConfig, result2 error) {
@@ -28,6 +28,9 @@ type MockSupport struct {
 	ExecuteResp                      *pb.Response
 	ExecuteEvent                     *pb.ChaincodeEvent
 	ExecuteError                     error
+	ExecuteCrossChannelReads         []*pb.CrossChannelRead
+	ExecuteCrossChannelReadHeights   []uint64
+	ExecuteEvents                    []*pb.ChaincodeEvent
 	ChaincodeDefinitionRv            ccprovider.ChaincodeDefinition
 	ChaincodeDefinitionError         error
 	GetTxSimulatorRv                 *mc.MockTxSim
@@ -99,6 +102,10 @@ func (s *MockSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams,
 }
 
 func (s *MockSupport) Execute(txParams *ccprovider.TransactionParams, cid, name, version, txid string, signedProp *pb.SignedProposal, prop *pb.Proposal, spec *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+	for i, read := range s.ExecuteCrossChannelReads {
+		txParams.CrossChannelReads.Add(read, s.ExecuteCrossChannelReadHeights[i])
+	}
+	txParams.ChaincodeEvents = s.ExecuteEvents
 	return s.ExecuteResp, s.ExecuteEvent, s.ExecuteError
 }
 
@@ -28,10 +28,11 @@ import (
 )
 
 type Support struct {
-	LedgerVal     ledger.PeerLedger
-	MSPManagerVal msp.MSPManager
-	ApplyVal      error
-	ACVal         channelconfig.ApplicationCapabilities
+	LedgerVal        ledger.PeerLedger
+	MSPManagerVal    msp.MSPManager
+	ApplyVal         error
+	ACVal            channelconfig.ApplicationCapabilities
+	PolicyManagerVal policies.Manager
 
 	sync.Mutex
 	capabilitiesInvokeCount int
@@ -63,7 +64,11 @@ func (ms *Support) Apply(configtx *common.ConfigEnvelope) error {
 	return ms.ApplyVal
 }
 
+// PolicyManager returns PolicyManagerVal, or an empty policy manager if it is not set
 func (ms *Support) PolicyManager() policies.Manager {
+	if ms.PolicyManagerVal != nil {
+		return ms.PolicyManagerVal
+	}
 	return &mockpolicies.Manager{}
 }
 
@@ -213,6 +213,24 @@ var _ = Describe("System", func() {
 		}))
 	})
 
+	It("hosts registered handlers", func() {
+		system.RegisterHandler("/custom", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		err := system.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := client.Get(fmt.Sprintf("https://%s/custom", system.Addr()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTeapot))
+		resp.Body.Close()
+
+		resp, err = unauthClient.Get(fmt.Sprintf("https://%s/custom", system.Addr()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		resp.Body.Close()
+	})
+
 	Context("when the metrics provider is disabled", func() {
 		BeforeEach(func() {
 			options.Metrics = operations.MetricsOptions{
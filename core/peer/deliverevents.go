@@ -56,6 +56,23 @@ func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
 	return brs.Send(response)
 }
 
+// SendEventFilteredBlockResponse generates deliver response with block
+// message when the block contains a chaincode event selected by the filter,
+// and skips the block otherwise
+func (brs *blockResponseSender) SendEventFilteredBlockResponse(block *common.Block, filter *deliver.EventFilter) error {
+	b := blockEvent(*block)
+	filteredBlock, err := b.toFilteredBlock(filter)
+	if err != nil {
+		logger.Warningf("Failed to filter the chaincode events of the block due to: %s", err)
+		return brs.SendStatusResponse(common.Status_BAD_REQUEST)
+	}
+	if len(filteredBlock.FilteredTransactions) == 0 {
+		logger.Debugf("Skipping block [%d] without matching chaincode events", block.Header.Number)
+		return nil
+	}
+	return brs.SendBlockResponse(block)
+}
+
 // filteredBlockResponseSender structure used to send filtered block responses
 type filteredBlockResponseSender struct {
 	peer.Deliver_DeliverFilteredServer
@@ -77,9 +94,16 @@ func (fbrs *filteredBlockResponseSender) IsFiltered() bool {
 
 // SendBlockResponse generates deliver response with block message
 func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
+	return fbrs.SendEventFilteredBlockResponse(block, nil)
+}
+
+// SendEventFilteredBlockResponse generates deliver response with filtered
+// block message, which only carries the transactions with a chaincode event
+// selected by the filter and only the selected events
+func (fbrs *filteredBlockResponseSender) SendEventFilteredBlockResponse(block *common.Block, filter *deliver.EventFilter) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
-	filteredBlock, err := b.toFilteredBlock()
+	filteredBlock, err := b.toFilteredBlock(filter)
 	if err != nil {
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
@@ -154,7 +178,10 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 	}
 }
 
-func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
+// toFilteredBlock returns the filtered block of the block. When a filter is
+// given, only the transactions with a chaincode event selected by the filter
+// are kept.
+func (block *blockEvent) toFilteredBlock(filter *deliver.EventFilter) (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
 	}
@@ -206,11 +233,17 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 				return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
 			}
 
-			filteredTransaction.Data, err = transactionActions(tx.Actions).toFilteredActions()
+			filteredActions, err := transactionActions(tx.Actions).toFilteredActions(filter)
 			if err != nil {
 				logger.Errorf(err.Error())
 				return nil, err
 			}
+			if filter != nil && len(filteredActions.TransactionActions.ChaincodeActions) == 0 {
+				continue
+			}
+			filteredTransaction.Data = filteredActions
+		} else if filter != nil {
+			continue
 		}
 
 		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
@@ -219,7 +252,7 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	return filteredBlock, nil
 }
 
-func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
+func (ta transactionActions) toFilteredActions(filter *deliver.EventFilter) (*peer.FilteredTransaction_TransactionActions, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
 	for _, action := range ta {
 		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
@@ -241,12 +274,15 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
 		}
 
-		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
+		ccEvents, err := utils.GetChaincodeActionEvents(caPayload)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
 		}
 
-		if ccEvent.GetChaincodeId() != "" {
+		for _, ccEvent := range ccEvents {
+			if ccEvent.GetChaincodeId() == "" || !filter.Match(ccEvent.ChaincodeId, ccEvent.EventName) {
+				continue
+			}
 			filteredAction := &peer.FilteredChaincodeAction{
 				ChaincodeEvent: &peer.ChaincodeEvent{
 					TxId:        ccEvent.TxId,
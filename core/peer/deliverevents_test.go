@@ -8,6 +8,7 @@ package peer
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"sync"
 	"testing"
@@ -167,6 +168,80 @@ func TestFilteredBlockResponseSenderIsFiltered(t *testing.T) {
 	assert.True(t, filtered.IsFiltered(), "should return true from IsFiltered")
 }
 
+func TestEventFilteredBlockResponses(t *testing.T) {
+	multipleEvents, err := createChaincodeActionWithEvents("mycc", "txID1", "transfer", "mint")
+	assert.NoError(t, err)
+	singleEvent, err := createChaincodeAction("othercc", "other", "txID2")
+	assert.NoError(t, err)
+	var envelopes []*common.Envelope
+	for i, action := range []*peer.ChaincodeActionPayload{multipleEvents, singleEvent} {
+		payload, err := createEndorsement("testChainID", fmt.Sprintf("txID%d", i+1), action)
+		assert.NoError(t, err)
+		envelopes = append(envelopes, &common.Envelope{Payload: utils.MarshalOrPanic(payload)})
+	}
+	block, err := createTestBlock(envelopes)
+	assert.NoError(t, err)
+
+	var responses []*peer.DeliverResponse
+	deliverServer := &mockDeliverServer{}
+	deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+		responses = append(responses, args.Get(0).(*peer.DeliverResponse))
+	}).Return(nil)
+	eventNames := func(tx *peer.FilteredTransaction) []string {
+		var names []string
+		for _, action := range tx.GetTransactionActions().ChaincodeActions {
+			names = append(names, action.ChaincodeEvent.ChaincodeId+"/"+action.ChaincodeEvent.EventName)
+		}
+		return names
+	}
+	newFilter := func(filters ...*orderer.ChaincodeEventFilter) *deliver.EventFilter {
+		filter, err := deliver.NewEventFilter(filters)
+		assert.NoError(t, err)
+		return filter
+	}
+
+	fbrs := &filteredBlockResponseSender{Deliver_DeliverFilteredServer: deliverServer}
+	err = fbrs.SendBlockResponse(block)
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	filteredBlock := responses[0].GetFilteredBlock()
+	assert.Len(t, filteredBlock.FilteredTransactions, 2)
+	assert.Equal(t, []string{"mycc/transfer", "mycc/mint"}, eventNames(filteredBlock.FilteredTransactions[0]))
+	assert.Equal(t, []string{"othercc/other"}, eventNames(filteredBlock.FilteredTransactions[1]))
+
+	// only the matching events of the matching transactions are sent
+	responses = nil
+	err = fbrs.SendEventFilteredBlockResponse(block, newFilter(&orderer.ChaincodeEventFilter{ChaincodeName: "mycc", EventNamePattern: "trans.*"}))
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	filteredBlock = responses[0].GetFilteredBlock()
+	assert.Len(t, filteredBlock.FilteredTransactions, 1)
+	assert.Equal(t, "txID1", filteredBlock.FilteredTransactions[0].Txid)
+	assert.Equal(t, []string{"mycc/transfer"}, eventNames(filteredBlock.FilteredTransactions[0]))
+
+	// filtered blocks without matching events are still sent
+	responses = nil
+	err = fbrs.SendEventFilteredBlockResponse(block, newFilter(&orderer.ChaincodeEventFilter{ChaincodeName: "thirdcc"}))
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.Equal(t, uint64(0), responses[0].GetFilteredBlock().Number)
+	assert.Empty(t, responses[0].GetFilteredBlock().FilteredTransactions)
+
+	// full blocks are sent unmodified when they contain a matching event
+	brs := &blockResponseSender{Deliver_DeliverServer: deliverServer}
+	responses = nil
+	err = brs.SendEventFilteredBlockResponse(block, newFilter(&orderer.ChaincodeEventFilter{ChaincodeName: "othercc"}))
+	assert.NoError(t, err)
+	assert.Len(t, responses, 1)
+	assert.True(t, proto.Equal(block, responses[0].GetBlock()))
+
+	// and skipped otherwise
+	responses = nil
+	err = brs.SendEventFilteredBlockResponse(block, newFilter(&orderer.ChaincodeEventFilter{ChaincodeName: "mycc", EventNamePattern: "burn"}))
+	assert.NoError(t, err)
+	assert.Empty(t, responses)
+}
+
 func TestEventsServer_DeliverFiltered(t *testing.T) {
 	viper.Set("peer.authentication.timewindow", "1s")
 	tests := []testCase{
@@ -500,6 +575,43 @@ func createChaincodeAction(chaincodeName string, eventName string, txID string)
 	return chaincodeActionPayload, err
 }
 
+func createChaincodeActionWithEvents(chaincodeName string, txID string, eventNames ...string) (*peer.ChaincodeActionPayload, error) {
+	action := &peer.ChaincodeAction{
+		ChaincodeId: &peer.ChaincodeID{
+			Name: chaincodeName,
+		},
+	}
+	for _, eventName := range eventNames {
+		action.ChaincodeEvents = append(action.ChaincodeEvents, &peer.ChaincodeEvent{
+			ChaincodeId: chaincodeName,
+			EventName:   eventName,
+			TxId:        txID,
+		})
+	}
+	eventsBytes, err := proto.Marshal(action.ChaincodeEvents[len(action.ChaincodeEvents)-1])
+	if err != nil {
+		return nil, err
+	}
+	action.Events = eventsBytes
+
+	actionBytes, err := proto.Marshal(action)
+	if err != nil {
+		return nil, err
+	}
+	proposalResBytes, err := proto.Marshal(&peer.ProposalResponsePayload{
+		Extension: actionBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: proposalResBytes,
+			Endorsements:            []*peer.Endorsement{},
+		},
+	}, nil
+}
+
 func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	// block
 	block := &common.Block{
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"net"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -377,11 +380,20 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 			oc:        oc,
 			Channel:   bundle.ChannelConfig(),
 		})
+	}
+
+	// The identities gossip knows of are re-validated whenever the MSP definitions of the
+	// channel change, in order to purge peers whose certificates have been revoked.
+	// This must take place after the MSP manager of the channel is updated.
+	lastMSPConfigDigest := mspConfigDigest(bundle.ConfigtxValidator().ConfigProto())
+	gossipRevocationCallback := func(bundle *channelconfig.Bundle) {
+		digest := mspConfigDigest(bundle.ConfigtxValidator().ConfigProto())
+		if bytes.Equal(digest, lastMSPConfigDigest) {
+			return
+		}
+		lastMSPConfigDigest = digest
+		peerLogger.Infof("MSP configuration of channel %s has changed, re-validating the identities of peers", cid)
 		service.GetGossipService().SuspectPeers(func(identity api.PeerIdentityType) bool {
-			// TODO: this is a place-holder that would somehow make the MSP layer suspect
-			// that a given certificate is revoked, or its intermediate CA is revoked.
-			// In the meantime, before we have such an ability, we return true in order
-			// to suspect ALL identities in order to validate all of them.
 			return true
 		})
 	}
@@ -421,6 +433,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		gossipCallbackWrapper,
 		trustedRootsCallbackWrapper,
 		mspCallback,
+		gossipRevocationCallback,
 		peerSingletonCallback,
 		cp.updateChannelConfig,
 	)
@@ -542,6 +555,20 @@ func GetChannelConfig(cid string) channelconfig.Resources {
 	return nil
 }
 
+// GetApplicationCapabilities returns the application capabilities of the chain
+// with chain ID, or nil if the chain has not been created or has no application config
+func GetApplicationCapabilities(cid string) channelconfig.ApplicationCapabilities {
+	cc := GetChannelConfig(cid)
+	if cc == nil {
+		return nil
+	}
+	ac, ok := cc.ApplicationConfig()
+	if !ok {
+		return nil
+	}
+	return ac.Capabilities()
+}
+
 // GetPolicyManager returns the policy manager of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetPolicyManager(cid string) policies.Manager {
@@ -564,6 +591,31 @@ func GetCurrConfigBlock(cid string) *common.Block {
 	return nil
 }
 
+// mspConfigDigest returns a digest of the MSP definitions
+// of the organizations in the given channel configuration
+func mspConfigDigest(config *common.Config) []byte {
+	h := sha256.New()
+	var digestGroup func(path string, group *common.ConfigGroup)
+	digestGroup = func(path string, group *common.ConfigGroup) {
+		if mspConfig, exists := group.Values[channelconfig.MSPKey]; exists {
+			h.Write([]byte(path))
+			h.Write(mspConfig.Value)
+		}
+		names := make([]string, 0, len(group.Groups))
+		for name := range group.Groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			digestGroup(path+"/"+name, group.Groups[name])
+		}
+	}
+	if channelGroup := config.GetChannelGroup(); channelGroup != nil {
+		digestGroup("", channelGroup)
+	}
+	return h.Sum(nil)
+}
+
 // updates the trusted roots for the peer based on updates to channels
 func updateTrustedRoots(cm channelconfig.Resources) {
 	// this is triggered on per channel basis so first update the roots for the channel
@@ -795,6 +847,10 @@ func (*CollectionSupport) GetIdentityDeserializer(chainID string) msp.IdentityDe
 	return mspmgmt.GetManagerForChain(chainID)
 }
 
+func (*CollectionSupport) GetApplicationCapabilities(chainID string) channelconfig.ApplicationCapabilities {
+	return GetApplicationCapabilities(chainID)
+}
+
 //
 //  Deliver service support structs for the peer
 //
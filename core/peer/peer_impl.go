@@ -24,6 +24,7 @@ import (
 // level data for the peer to instance level data.
 type Operations interface {
 	CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) error
+	GetApplicationCapabilities(cid string) channelconfig.ApplicationCapabilities
 	GetChannelConfig(cid string) channelconfig.Resources
 	GetChannelsInfo() []*pb.ChannelInfo
 	GetCurrConfigBlock(cid string) *common.Block
@@ -35,29 +36,31 @@ type Operations interface {
 }
 
 type peerImpl struct {
-	createChainFromBlock func(cb *common.Block, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) error
-	getChannelConfig     func(cid string) channelconfig.Resources
-	getChannelsInfo      func() []*pb.ChannelInfo
-	getCurrConfigBlock   func(cid string) *common.Block
-	getLedger            func(cid string) ledger.PeerLedger
-	getMSPIDs            func(cid string) []string
-	getPolicyManager     func(cid string) policies.Manager
-	initChain            func(cid string)
-	initialize           func(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider, mapper txvalidator.PluginMapper, pr *platforms.Registry, deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider, membershipProvider ledger.MembershipInfoProvider, metricsProvider metrics.Provider)
+	createChainFromBlock       func(cb *common.Block, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) error
+	getApplicationCapabilities func(cid string) channelconfig.ApplicationCapabilities
+	getChannelConfig           func(cid string) channelconfig.Resources
+	getChannelsInfo            func() []*pb.ChannelInfo
+	getCurrConfigBlock         func(cid string) *common.Block
+	getLedger                  func(cid string) ledger.PeerLedger
+	getMSPIDs                  func(cid string) []string
+	getPolicyManager           func(cid string) policies.Manager
+	initChain                  func(cid string)
+	initialize                 func(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider, mapper txvalidator.PluginMapper, pr *platforms.Registry, deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider, membershipProvider ledger.MembershipInfoProvider, metricsProvider metrics.Provider)
 }
 
 // Default provides in implementation of the Peer interface that provides
 // access to the package level state.
 var Default Operations = &peerImpl{
-	createChainFromBlock: CreateChainFromBlock,
-	getChannelConfig:     GetChannelConfig,
-	getChannelsInfo:      GetChannelsInfo,
-	getCurrConfigBlock:   GetCurrConfigBlock,
-	getLedger:            GetLedger,
-	getMSPIDs:            GetMSPIDs,
-	getPolicyManager:     GetPolicyManager,
-	initChain:            InitChain,
-	initialize:           Initialize,
+	createChainFromBlock:       CreateChainFromBlock,
+	getApplicationCapabilities: GetApplicationCapabilities,
+	getChannelConfig:           GetChannelConfig,
+	getChannelsInfo:            GetChannelsInfo,
+	getCurrConfigBlock:         GetCurrConfigBlock,
+	getLedger:                  GetLedger,
+	getMSPIDs:                  GetMSPIDs,
+	getPolicyManager:           GetPolicyManager,
+	initChain:                  InitChain,
+	initialize:                 Initialize,
 }
 
 var DefaultSupport Support = &supportImpl{operations: Default}
@@ -65,6 +68,9 @@ var DefaultSupport Support = &supportImpl{operations: Default}
 func (p *peerImpl) CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) error {
 	return p.createChainFromBlock(cb, ccp, sccp)
 }
+func (p *peerImpl) GetApplicationCapabilities(cid string) channelconfig.ApplicationCapabilities {
+	return p.getApplicationCapabilities(cid)
+}
 func (p *peerImpl) GetChannelConfig(cid string) channelconfig.Resources {
 	return p.getChannelConfig(cid)
 }
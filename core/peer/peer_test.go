@@ -33,6 +33,7 @@ import (
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -242,3 +243,33 @@ func TestGossipChannelConfigOrdererAddressesByOrgs(t *testing.T) {
 		"Org2": {"o2"},
 	}, gcp.OrdererAddressesByOrgs())
 }
+
+func TestMSPConfigDigest(t *testing.T) {
+	newConfig := func(mspValue []byte, anchorPeersValue []byte) *common.Config {
+		return &common.Config{
+			ChannelGroup: &common.ConfigGroup{
+				Groups: map[string]*common.ConfigGroup{
+					channelconfig.ApplicationGroupKey: {
+						Groups: map[string]*common.ConfigGroup{
+							"Org1": {
+								Values: map[string]*common.ConfigValue{
+									channelconfig.MSPKey:         {Value: mspValue},
+									channelconfig.AnchorPeersKey: {Value: anchorPeersValue},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	digest := mspConfigDigest(newConfig([]byte("msp"), []byte("anchor peers")))
+	assert.Equal(t, digest, mspConfigDigest(newConfig([]byte("msp"), []byte("anchor peers"))))
+	// Changes to other values of the organization don't change the digest
+	assert.Equal(t, digest, mspConfigDigest(newConfig([]byte("msp"), []byte("other anchor peers"))))
+	// But changes to the MSP definition do
+	assert.NotEqual(t, digest, mspConfigDigest(newConfig([]byte("msp with CRL"), []byte("anchor peers"))))
+	// An empty config has a digest too
+	assert.NotNil(t, mspConfigDigest(&common.Config{}))
+}
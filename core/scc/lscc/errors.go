@@ -126,3 +126,10 @@ type PrivateChannelDataNotAvailable string
 func (f PrivateChannelDataNotAvailable) Error() string {
 	return "as V1_2 or later capability is not enabled, private channel collections and data are not available"
 }
+
+// WasmChaincodeNotAvailable when V1_4_3 capability is not enabled
+type WasmChaincodeNotAvailable string
+
+func (f WasmChaincodeNotAvailable) Error() string {
+	return "as V1_4_3 capability is not enabled, WASM chaincode can't be instantiated"
+}
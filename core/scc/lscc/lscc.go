@@ -856,6 +856,9 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		if err != nil {
 			return shim.Error(fmt.Sprintf("error unmarshaling ChaincodeDeploymentSpec: %s", err))
 		}
+		if cds.CCType() == pb.ChaincodeSpec_WASM.String() && !ac.Capabilities().WasmChaincode() {
+			return shim.Error(WasmChaincodeNotAvailable("").Error())
+		}
 
 		// optional arguments here (they can each be nil and may or may not be present)
 		// args[3] is a marshalled SignaturePolicyEnvelope representing the endorsement policy
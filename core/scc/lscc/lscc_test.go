@@ -215,6 +215,16 @@ func TestDeploy(t *testing.T) {
 	assert.NotEqual(t, int32(shim.OK), res.Status)
 	assert.Equal(t, "error unmarshaling ChaincodeDeploymentSpec: unexpected EOF", res.Message)
 
+	wasmCDS := utils.MarshalOrPanic(&pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "wasmcc", Version: "0"},
+			Type:        pb.ChaincodeSpec_WASM,
+		},
+	})
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("chain"), wasmCDS}, nil)
+	assert.NotEqual(t, int32(shim.OK), res.Status)
+	assert.Equal(t, WasmChaincodeNotAvailable("").Error(), res.Message)
+
 	testDeploy(t, "example02", "1.0", path, false, false, true, "", scc, stub, nil)
 	testDeploy(t, "example02", "1.0", path, false, false, true, "chaincode with name 'example02' already exists", scc, stub, nil)
 
@@ -267,10 +277,10 @@ func TestDeploy(t *testing.T) {
 	// message is expected.
 	errMessage = "invalid collection configuration supplied for chaincode example02:1.0"
 	testDeploy(t, "example02", "1.0", path, false, false, true, errMessage, scc, stub, []byte("invalid collection"))
-	// Should contain an entry for the chaincodeData only
-	assert.Equal(t, 1, len(stub.State))
+	// The writes of the failed deploy are rolled back
+	assert.Equal(t, 0, len(stub.State))
 	_, ok := stub.State["example02"]
-	assert.Equal(t, true, ok)
+	assert.Equal(t, false, ok)
 
 	collName1 := "mycollection1"
 	policyEnvelope := cauthdsl.SignedByAnyMember([]string{"SampleOrg"})
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -68,6 +69,12 @@ type Store interface {
 	// GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
 	// write sets persisted from different endorsers (via Gossip)
 	GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (RWSetScanner, error)
+	// PersistShard stores an erasure-coded shard of the private write set of a transaction
+	// in the transient store based on txid and the block height the shard was received at.
+	// Shards are purged along with the private write sets.
+	PersistShard(txid string, blockHeight uint64, shard *gossip.PrivatePayload) error
+	// GetShards returns the erasure-coded shards of the private write sets of a transaction
+	GetShards(txid string) ([]*gossip.PrivatePayload, error)
 	// PurgeByTxids removes private write sets of a given set of transactions from the
 	// transient store
 	PurgeByTxids(txids []string) error
@@ -238,6 +245,40 @@ func (s *store) PersistWithConfig(txid string, blockHeight uint64,
 	return s.db.WriteBatch(dbBatch, true)
 }
 
+// PersistShard stores an erasure-coded shard of the private write set of a transaction
+// in the transient store based on txid and the block height the shard was received at.
+// The shard is indexed by the purge indexes of the private write sets, hence it is purged
+// by PurgeByTxids() and PurgeByHeight() as well.
+func (s *store) PersistShard(txid string, blockHeight uint64, shard *gossip.PrivatePayload) error {
+	logger.Debugf("Persisting shard of private data to transient store for txid [%s] at block height [%d]", txid, blockHeight)
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	uuid := util.GenerateUUID()
+	shardBytes, err := proto.Marshal(shard)
+	if err != nil {
+		return err
+	}
+	dbBatch.Put(createCompositeKeyForShard(txid, uuid, blockHeight), shardBytes)
+	dbBatch.Put(createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid), emptyValue)
+	dbBatch.Put(createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight), emptyValue)
+	return s.db.WriteBatch(dbBatch, true)
+}
+
+// GetShards returns the erasure-coded shards of the private write sets of a transaction
+func (s *store) GetShards(txid string) ([]*gossip.PrivatePayload, error) {
+	iter := s.db.GetIterator(createShardRangeStartKey(txid), createShardRangeEndKey(txid))
+	defer iter.Release()
+	var shards []*gossip.PrivatePayload
+	for iter.Next() {
+		shard := &gossip.PrivatePayload{}
+		if err := proto.Unmarshal(iter.Value(), shard); err != nil {
+			return nil, err
+		}
+		shards = append(shards, shard)
+	}
+	return shards, iter.Error()
+}
+
 // GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
 // write sets persisted from different endorsers.
 func (s *store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (RWSetScanner, error) {
@@ -283,6 +324,8 @@ func (s *store) PurgeByTxids(txids []string) error {
 			}
 			compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
 			dbBatch.Delete(compositeKeyPvtRWSet)
+			// The entry may be a shard instead
+			dbBatch.Delete(createCompositeKeyForShard(txid, uuid, blockHeight))
 
 			// Remove purge index -- purgeIndexByHeight
 			compositeKeyPurgeIndexByHeight := createCompositeKeyForPurgeIndexByHeight(blockHeight, txid, uuid)
@@ -330,6 +373,8 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 
 		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
 		dbBatch.Delete(compositeKeyPvtRWSet)
+		// The entry may be a shard instead
+		dbBatch.Delete(createCompositeKeyForShard(txid, uuid, blockHeight))
 
 		// Remove purge index -- purgeIndexByTxid
 		compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
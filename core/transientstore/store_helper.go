@@ -22,6 +22,7 @@ var (
 	prwsetPrefix             = []byte("P")[0] // key prefix for storing private write set in transient store.
 	purgeIndexByHeightPrefix = []byte("H")[0] // key prefix for storing index on private write set using received at block height.
 	purgeIndexByTxidPrefix   = []byte("T")[0] // key prefix for storing index on private write set using txid
+	shardPrefix              = []byte("S")[0] // key prefix for storing erasure-coded shards of private write sets
 	compositeKeySep          = byte(0x00)
 )
 
@@ -36,6 +37,18 @@ func createCompositeKeyForPvtRWSet(txid string, uuid string, blockHeight uint64)
 	return compositeKey
 }
 
+// createCompositeKeyForShard creates a key for storing an erasure-coded shard of a private
+// write set in the transient store. The structure of the key is <shardPrefix>~txid~uuid~blockHeight.
+// Shards share the purge indexes of the private write sets.
+func createCompositeKeyForShard(txid string, uuid string, blockHeight uint64) []byte {
+	var compositeKey []byte
+	compositeKey = append(compositeKey, shardPrefix)
+	compositeKey = append(compositeKey, compositeKeySep)
+	compositeKey = append(compositeKey, createCompositeKeyWithoutPrefixForTxid(txid, uuid, blockHeight)...)
+
+	return compositeKey
+}
+
 // createCompositeKeyForPurgeIndexByTxid creates a key to index private write set based on
 // txid such that purge based on txid can be achieved. The structure
 // of the key is <purgeIndexByTxidPrefix>~txid~uuid~blockHeight.
@@ -135,6 +148,26 @@ func createTxidRangeEndKey(txid string) []byte {
 	return endKey
 }
 
+// createShardRangeStartKey returns a startKey to do a range query on the shards in transient store using txid
+func createShardRangeStartKey(txid string) []byte {
+	var startKey []byte
+	startKey = append(startKey, shardPrefix)
+	startKey = append(startKey, compositeKeySep)
+	startKey = append(startKey, []byte(txid)...)
+	startKey = append(startKey, compositeKeySep)
+	return startKey
+}
+
+// createShardRangeEndKey returns a endKey to do a range query on the shards in transient store using txid
+func createShardRangeEndKey(txid string) []byte {
+	var endKey []byte
+	endKey = append(endKey, shardPrefix)
+	endKey = append(endKey, compositeKeySep)
+	endKey = append(endKey, []byte(txid)...)
+	endKey = append(endKey, byte(0xff))
+	return endKey
+}
+
 // createPurgeIndexByHeightRangeStartKey returns a startKey to do a range query on index stored in transient store
 // using blockHeight
 func createPurgeIndexByHeightRangeStartKey(blockHeight uint64) []byte {
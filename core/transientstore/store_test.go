@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/spf13/viper"
@@ -457,6 +458,61 @@ func TestTransientStorePurgeByHeight(t *testing.T) {
 	env.Cleanup()
 }
 
+func TestTransientStoreShards(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+
+	shard := func(txid string, index uint32) *gossip.PrivatePayload {
+		return &gossip.PrivatePayload{
+			TxId:           txid,
+			Namespace:      "ns",
+			CollectionName: "coll",
+			PrivateRwset:   []byte{byte(index)},
+			Shard:          &gossip.ErasureShard{Index: index},
+		}
+	}
+
+	assert.NoError(env.TestStore.PersistShard("txid-1", 10, shard("txid-1", 0)))
+	assert.NoError(env.TestStore.PersistShard("txid-1", 11, shard("txid-1", 1)))
+	assert.NoError(env.TestStore.PersistShard("txid-2", 12, shard("txid-2", 0)))
+
+	// Shards do not show up as private write sets
+	iter, err := env.TestStore.GetTxPvtRWSetByTxid("txid-1", nil)
+	assert.NoError(err)
+	res, err := iter.NextWithConfig()
+	assert.NoError(err)
+	assert.Nil(res)
+	iter.Close()
+
+	shards, err := env.TestStore.GetShards("txid-1")
+	assert.NoError(err)
+	assert.Len(shards, 2)
+	for _, s := range shards {
+		assert.True(proto.Equal(shard("txid-1", s.Shard.Index), s))
+	}
+
+	minBlkHt, err := env.TestStore.GetMinTransientBlkHt()
+	assert.NoError(err)
+	assert.Equal(uint64(10), minBlkHt)
+
+	// Shards are purged along with private write sets
+	assert.NoError(env.TestStore.PurgeByHeight(11))
+	shards, err = env.TestStore.GetShards("txid-1")
+	assert.NoError(err)
+	assert.Len(shards, 1)
+	assert.Equal(uint32(1), shards[0].Shard.Index)
+
+	assert.NoError(env.TestStore.PurgeByTxids([]string{"txid-1"}))
+	shards, err = env.TestStore.GetShards("txid-1")
+	assert.NoError(err)
+	assert.Empty(shards)
+
+	shards, err = env.TestStore.GetShards("txid-2")
+	assert.NoError(err)
+	assert.Len(shards, 1)
+}
+
 func TestTransientStoreRetrievalWithFilter(t *testing.T) {
 	env := NewTestStoreEnv(t)
 	store := env.TestStore
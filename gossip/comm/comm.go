@@ -47,6 +47,9 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// Connections returns the PKI-IDs of the remote peers that have an open connection
+	Connections() []common.PKIidType
+
 	// Stop stops the module
 	Stop()
 }
@@ -63,6 +66,15 @@ type SendResult struct {
 	RemotePeer
 }
 
+// NewSendResult creates a SendResult for the given remote peer,
+// with the given error, or nil if the send succeeded
+func NewSendResult(peer RemotePeer, err error) SendResult {
+	return SendResult{
+		error:      err,
+		RemotePeer: peer,
+	}
+}
+
 // Error returns the error of the SendResult, or an empty string
 // if an error hasn't occurred
 func (sr SendResult) Error() string {
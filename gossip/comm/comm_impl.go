@@ -357,6 +357,10 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConn(peer)
 }
 
+func (c *commImpl) Connections() []common.PKIidType {
+	return c.connStore.connectedPKIids()
+}
+
 func (c *commImpl) closeSubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
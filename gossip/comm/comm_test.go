@@ -601,7 +601,9 @@ func TestCloseConn(t *testing.T) {
 	case <-time.After(time.Second):
 		assert.Fail(t, "Didn't receive a message within a timely period")
 	}
+	assert.Equal(t, []common.PKIidType{common.PKIidType("pkiID")}, comm1.Connections())
 	comm1.CloseConn(&RemotePeer{PKIID: common.PKIidType("pkiID")})
+	assert.Empty(t, comm1.Connections())
 	time.Sleep(time.Second * 10)
 	gotErr := false
 	msg2Send := createGossipMsg()
@@ -8,10 +8,12 @@ package comm
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"sync/atomic"
 
+	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
@@ -131,6 +133,16 @@ func (cs *connectionStore) connNum() int {
 	return len(cs.pki2Conn)
 }
 
+func (cs *connectionStore) connectedPKIids() []common.PKIidType {
+	cs.RLock()
+	defer cs.RUnlock()
+	res := make([]common.PKIidType, 0, len(cs.pki2Conn))
+	for pkiID := range cs.pki2Conn {
+		res = append(res, common.PKIidType(pkiID))
+	}
+	return res
+}
+
 func (cs *connectionStore) closeConn(peer *RemotePeer) {
 	cs.Lock()
 	defer cs.Unlock()
@@ -247,6 +259,9 @@ func (conn *connection) close() {
 	conn.stopChan <- struct{}{}
 
 	conn.drainOutputBuffer()
+	// The per peer series are deleted, so the metrics of peers
+	// which come and go do not accumulate
+	conn.metrics.DeletePeer(conn.peerLabel())
 	conn.Lock()
 	defer conn.Unlock()
 
@@ -275,6 +290,8 @@ func (conn *connection) send(msg *proto.SignedGossipMessage, onErr func(error),
 	m := &msgSending{
 		envelope: msg.Envelope,
 		onErr:    onErr,
+		msgType:  messageType(msg.GossipMessage),
+		channel:  string(msg.Channel),
 	}
 
 	select {
@@ -285,9 +302,35 @@ func (conn *connection) send(msg *proto.SignedGossipMessage, onErr func(error),
 			conn.outBuff <- m // try again, and wait to send
 		} else {
 			conn.metrics.BufferOverflow.Add(1)
+			conn.metrics.PeerDroppedMessages.With("peer", conn.peerLabel()).Add(1)
+			conn.dropped(m)
 			conn.logger.Debugf("Buffer to %s overflowed, dropping message %s", conn.info.Endpoint, msg)
 		}
 	}
+	conn.reportSendBufferSize()
+}
+
+// dropped records the given outgoing message as dropped, either because
+// the send buffer overflowed or because the connection was closed
+func (conn *connection) dropped(m *msgSending) {
+	conn.metrics.DroppedMessagesByType.With("type", m.msgType, "channel", m.channel).Add(1)
+	conn.metrics.DroppedBytes.With("type", m.msgType, "channel", m.channel).Add(float64(pb.Size(m.envelope)))
+}
+
+func (conn *connection) reportSendBufferSize() {
+	if conn.toDie() {
+		// The series of the peer is deleted once the connection is closed
+		return
+	}
+	conn.metrics.PeerSendBufferSize.With("peer", conn.peerLabel()).Set(float64(len(conn.outBuff)))
+}
+
+// peerLabel returns the value of the peer label of the metrics of the connection
+func (conn *connection) peerLabel() string {
+	if conn.info != nil && conn.info.Endpoint != "" {
+		return conn.info.Endpoint
+	}
+	return hex.EncodeToString(conn.pkiID)
 }
 
 func (conn *connection) serviceConnection() error {
@@ -327,12 +370,15 @@ func (conn *connection) writeToStream() {
 		}
 		select {
 		case m := <-conn.outBuff:
+			conn.reportSendBufferSize()
 			err := stream.Send(m.envelope)
 			if err != nil {
 				go m.onErr(err)
 				return
 			}
 			conn.metrics.SentMessages.Add(1)
+			conn.metrics.SentMessagesByType.With("type", m.msgType, "channel", m.channel).Add(1)
+			conn.metrics.SentBytes.With("type", m.msgType, "channel", m.channel).Add(float64(pb.Size(m.envelope)))
 		case stop := <-conn.stopChan:
 			conn.logger.Debug("Closing writing to stream")
 			conn.stopChan <- stop
@@ -346,7 +392,8 @@ func (conn *connection) drainOutputBuffer() {
 	// There may be multiple concurrent readers.
 	for {
 		select {
-		case <-conn.outBuff:
+		case m := <-conn.outBuff:
+			conn.dropped(m)
 		default:
 			return
 		}
@@ -376,6 +423,10 @@ func (conn *connection) readFromStream(errChan chan error, quit chan struct{}, m
 		if err != nil {
 			errChan <- err
 			conn.logger.Warningf("Got error, aborting: %v", err)
+		} else {
+			msgType, channel := messageType(msg.GossipMessage), string(msg.Channel)
+			conn.metrics.ReceivedMessagesByType.With("type", msgType, "channel", channel).Add(1)
+			conn.metrics.ReceivedBytes.With("type", msgType, "channel", channel).Add(float64(pb.Size(envelope)))
 		}
 		select {
 		case msgChan <- msg:
@@ -408,4 +459,56 @@ func (conn *connection) getStream() stream {
 type msgSending struct {
 	envelope *proto.Envelope
 	onErr    func(error)
+	msgType  string
+	channel  string
+}
+
+// messageType returns the type of the given message, as used in metrics labels
+func messageType(msg *proto.GossipMessage) string {
+	switch msg.Content.(type) {
+	case *proto.GossipMessage_AliveMsg:
+		return "alive"
+	case *proto.GossipMessage_MemReq:
+		return "membership_request"
+	case *proto.GossipMessage_MemRes:
+		return "membership_response"
+	case *proto.GossipMessage_DataMsg:
+		return "data"
+	case *proto.GossipMessage_Hello:
+		return "pull_hello"
+	case *proto.GossipMessage_DataDig:
+		return "pull_digest"
+	case *proto.GossipMessage_DataReq:
+		return "pull_request"
+	case *proto.GossipMessage_DataUpdate:
+		return "pull_response"
+	case *proto.GossipMessage_Empty:
+		return "empty"
+	case *proto.GossipMessage_Conn:
+		return "conn_establish"
+	case *proto.GossipMessage_StateInfo:
+		return "stateinfo"
+	case *proto.GossipMessage_StateSnapshot:
+		return "stateinfo_snapshot"
+	case *proto.GossipMessage_StateInfoPullReq:
+		return "stateinfo_pull_request"
+	case *proto.GossipMessage_StateRequest:
+		return "state_request"
+	case *proto.GossipMessage_StateResponse:
+		return "state_response"
+	case *proto.GossipMessage_LeadershipMsg:
+		return "leadership"
+	case *proto.GossipMessage_PeerIdentity:
+		return "peer_identity"
+	case *proto.GossipMessage_Ack:
+		return "ack"
+	case *proto.GossipMessage_PrivateReq:
+		return "pvtdata_request"
+	case *proto.GossipMessage_PrivateRes:
+		return "pvtdata_response"
+	case *proto.GossipMessage_PrivateData:
+		return "pvtdata"
+	default:
+		return "unknown"
+	}
 }
@@ -11,9 +11,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/metrics/mocks"
 	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,4 +86,61 @@ func TestMetrics(t *testing.T) {
 
 	assert.Equal(t, uint32(1), atomic.LoadUint32(&overflown))
 
+	for _, counter := range []*metricsfakes.Counter{
+		testMetricProvider.FakeSentMessagesByType,
+		testMetricProvider.FakeReceivedMessagesByType,
+		testMetricProvider.FakeDroppedMessagesByType,
+	} {
+		assert.Equal(t, []string{"type", "data", "channel", ""}, counter.WithArgsForCall(0))
+		assert.EqualValues(t, 1, counter.AddArgsForCall(0))
+	}
+
+	for _, counter := range []*metricsfakes.Counter{
+		testMetricProvider.FakeSentBytes,
+		testMetricProvider.FakeReceivedBytes,
+		testMetricProvider.FakeDroppedBytes,
+	} {
+		assert.Equal(t, []string{"type", "data", "channel", ""}, counter.WithArgsForCall(0))
+		assert.True(t, counter.AddArgsForCall(0) > 0)
+	}
+
+	assert.Equal(t, "peer", testMetricProvider.FakePeerDroppedMessages.WithArgsForCall(0)[0])
+	assert.EqualValues(t, 1, testMetricProvider.FakePeerDroppedMessages.AddArgsForCall(0))
+	assert.Equal(t, "peer", testMetricProvider.FakePeerSendBufferSize.WithArgsForCall(0)[0])
+	assert.NotZero(t, testMetricProvider.FakePeerSendBufferSize.SetCallCount())
+}
+
+func TestMetricsOnConnectionClose(t *testing.T) {
+	// Scenario: Messages still in the send buffer when a connection is closed
+	// are dropped, but are not attributed to a send buffer overflow of the peer
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	commMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).CommMetrics
+
+	conn := newConnection(nil, nil, nil, nil, commMetrics, ConnConfig{SendBuffSize: 10})
+	conn.info = &proto.ConnectionInfo{Endpoint: "peer0:7051"}
+	conn.send(createGossipMsg(), func(error) {}, nonBlockingSend)
+	conn.send(createGossipMsg(), func(error) {}, nonBlockingSend)
+	assert.Equal(t, 2, testMetricProvider.FakePeerSendBufferSize.SetCallCount())
+
+	conn.close()
+	assert.Equal(t, 2, testMetricProvider.FakeDroppedMessagesByType.AddCallCount())
+	assert.Equal(t, 2, testMetricProvider.FakeDroppedBytes.AddCallCount())
+	assert.Zero(t, testMetricProvider.FakePeerDroppedMessages.AddCallCount())
+	// The send buffer size of a closed connection is no longer reported
+	assert.Equal(t, 2, testMetricProvider.FakePeerSendBufferSize.SetCallCount())
+}
+
+func TestMessageType(t *testing.T) {
+	for expected, msg := range map[string]*proto.GossipMessage{
+		"alive":       {Content: &proto.GossipMessage_AliveMsg{}},
+		"stateinfo":   {Content: &proto.GossipMessage_StateInfo{}},
+		"data":        {Content: &proto.GossipMessage_DataMsg{}},
+		"pull_hello":  {Content: &proto.GossipMessage_Hello{}},
+		"pull_digest": {Content: &proto.GossipMessage_DataDig{}},
+		"pvtdata":     {Content: &proto.GossipMessage_PrivateData{}},
+		"leadership":  {Content: &proto.GossipMessage_LeadershipMsg{}},
+		"unknown":     {},
+	} {
+		assert.Equal(t, expected, messageType(msg))
+	}
 }
@@ -185,6 +185,11 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// Connections returns the PKI-IDs of the remote peers that have an open connection
+func (mock *commMock) Connections() []common.PKIidType {
+	return nil
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
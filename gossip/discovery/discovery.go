@@ -123,10 +123,17 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetDeadMembers returns the members in the view that are considered dead
+	GetDeadMembers() []NetworkMember
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
 
+	// PurgeMember removes the member with the given PKI-ID from the view,
+	// and discards the alive messages it sent
+	PurgeMember(pkiID common.PKIidType)
+
 	// Connect makes this instance to connect to a remote instance
 	// The identifier param is a function that can be used to identify
 	// the peer, and to assert its PKI-ID, whether its in the peer's org or not,
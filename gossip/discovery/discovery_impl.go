@@ -718,6 +718,25 @@ func (d *gossipDiscoveryImpl) expireDeadMembers(dead []common.PKIidType) {
 	}
 }
 
+// PurgeMember removes the member with the given PKI-ID from the view,
+// and discards the alive messages it sent
+func (d *gossipDiscoveryImpl) PurgeMember(pkiID common.PKIidType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.msgStore.Purge(func(m interface{}) bool {
+		return bytes.Equal(m.(*proto.SignedGossipMessage).GetAliveMsg().Membership.PkiId, pkiID)
+	})
+	if _, known := d.id2Member[string(pkiID)]; known {
+		d.logger.Infof("Purging member with PKIID: %s", pkiID)
+	}
+	d.aliveMembership.Remove(pkiID)
+	d.deadMembership.Remove(pkiID)
+	delete(d.id2Member, string(pkiID))
+	delete(d.deadLastTS, string(pkiID))
+	delete(d.aliveLastTS, string(pkiID))
+}
+
 func (d *gossipDiscoveryImpl) getDeadMembers() []common.PKIidType {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
@@ -940,6 +959,31 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+func (d *gossipDiscoveryImpl) GetDeadMembers() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []NetworkMember{}
+	for _, m := range d.deadMembership.ToSlice() {
+		member := m.GetAliveMsg()
+		var internalEndpoint string
+		if netMember, known := d.id2Member[string(member.Membership.PkiId)]; known {
+			internalEndpoint = netMember.InternalEndpoint
+		}
+		response = append(response, NetworkMember{
+			PKIid:            member.Membership.PkiId,
+			Endpoint:         member.Membership.Endpoint,
+			Metadata:         member.Membership.Metadata,
+			InternalEndpoint: internalEndpoint,
+			Envelope:         m.Envelope,
+		})
+	}
+	return response
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -843,6 +843,12 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	deadEndpoints := []string{}
+	for _, member := range instances[0].GetDeadMembers() {
+		deadEndpoints = append(deadEndpoints, member.Endpoint)
+	}
+	assert.ElementsMatch(t, []string{"localhost:2614", "localhost:2615"}, deadEndpoints)
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
@@ -1487,6 +1493,34 @@ func TestAliveMsgStore(t *testing.T) {
 	}
 }
 
+func TestPurgeMember(t *testing.T) {
+	t.Parallel()
+
+	inst1 := createDiscoveryInstanceWithNoGossip(33610, "d1", []string{})
+	defer inst1.Stop()
+	inst2 := createDiscoveryInstanceWithNoGossip(33611, "d2", []string{})
+	defer inst2.Stop()
+
+	aliveMsg, err := inst2.discoveryImpl().createSignedAliveMessage(true)
+	assert.NoError(t, err)
+	d := inst1.discoveryImpl()
+	assert.True(t, d.msgStore.Add(aliveMsg))
+	d.handleAliveMessage(aliveMsg)
+	pkiID := common.PKIidType("localhost:33611")
+	assert.NotNil(t, d.Lookup(pkiID))
+
+	d.PurgeMember(pkiID)
+	assert.Nil(t, d.Lookup(pkiID))
+	assert.Empty(t, d.GetMembership())
+	assert.Empty(t, d.GetDeadMembers())
+	// The alive message was discarded as well, hence it is considered new again
+	assert.True(t, d.msgStore.CheckValid(aliveMsg))
+
+	// Purging an unknown member has no effect
+	d.PurgeMember(common.PKIidType("localhost:33612"))
+	assert.Empty(t, d.GetMembership())
+}
+
 func TestMemRespDisclosurePol(t *testing.T) {
 	t.Parallel()
 	pol := func(remotePeer *NetworkMember) (Sieve, EnvelopeFilter) {
@@ -34,6 +34,14 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) Priority() uint32 {
+	return mi.msg.GetLeadershipMsg().Priority
+}
+
+func (mi *msgImpl) LedgerHeight() uint64 {
+	return mi.msg.GetLeadershipMsg().LedgerHeight
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
 }
@@ -54,11 +62,15 @@ type gossip interface {
 
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
+
+	// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+	SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage
 }
 
 type adapterImpl struct {
 	gossip    gossip
 	selfPKIid common.PKIidType
+	priority  uint32
 
 	incTime uint64
 	seqNum  uint64
@@ -72,12 +84,14 @@ type adapterImpl struct {
 	metrics  *metrics.ElectionMetrics
 }
 
-// NewAdapter creates new leader election adapter
-func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID,
+// NewAdapter creates new leader election adapter, which advertises
+// the given leadership priority of the peer
+func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID, priority uint32,
 	metrics *metrics.ElectionMetrics) LeaderElectionAdapter {
 	return &adapterImpl{
 		gossip:    gossip,
 		selfPKIid: pkiid,
+		priority:  priority,
 
 		incTime: uint64(time.Now().UnixNano()),
 		seqNum:  uint64(0),
@@ -134,6 +148,8 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
 		},
+		Priority:     ai.priority,
+		LedgerHeight: ai.ledgerHeight(),
 	}
 
 	msg := &proto.GossipMessage{
@@ -145,6 +161,15 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 	return &msgImpl{msg}
 }
 
+// ledgerHeight returns the ledger height the peer publishes in the channel
+func (ai *adapterImpl) ledgerHeight() uint64 {
+	stateInfo := ai.gossip.SelfChannelInfo(ai.channel)
+	if stateInfo == nil {
+		return 0
+	}
+	return stateInfo.GetStateInfo().GetProperties().GetLedgerHeight()
+}
+
 func (ai *adapterImpl) Peers() []Peer {
 	peers := ai.gossip.Peers()
 
@@ -39,7 +39,7 @@ func TestNewAdapter(t *testing.T) {
 	peersCluster := newClusterOfPeers("0")
 	peersCluster.addPeer("peer0", mockGossip)
 
-	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
+	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"), 0,
 		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
 }
 
@@ -51,7 +51,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	}
 	mockGossip := newGossip("peer0", selfNetworkMember)
 
-	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
+	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"), 0,
 		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
 	msg := adapter.CreateMessage(true)
 
@@ -72,6 +72,32 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	if !msg.IsProposal() || msg.IsDeclaration() {
 		t.Error("Newly created msg should be Proposal msg")
 	}
+	assert.Equal(t, uint32(0), msg.Priority())
+	assert.Equal(t, uint64(0), msg.LedgerHeight())
+}
+
+func TestAdapterImpl_CreateMessageWithCandidacy(t *testing.T) {
+	selfNetworkMember := &discovery.NetworkMember{
+		Endpoint: "p0",
+		Metadata: []byte{},
+		PKIid:    []byte{byte(0)},
+	}
+	mockGossip := newGossip("peer0", selfNetworkMember)
+	mockGossip.stateInfo = &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Content: &proto.GossipMessage_StateInfo{
+				StateInfo: &proto.StateInfo{
+					Properties: &proto.Properties{LedgerHeight: 42},
+				},
+			},
+		},
+	}
+
+	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"), 7,
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+	msg := adapter.CreateMessage(false)
+	assert.Equal(t, uint32(7), msg.Priority())
+	assert.Equal(t, uint64(42), msg.LedgerHeight())
 }
 
 func TestAdapterImpl_Peers(t *testing.T) {
@@ -172,6 +198,11 @@ type peerMockGossip struct {
 	acceptorLock *sync.RWMutex
 	clusterLock  *sync.RWMutex
 	id           string
+	stateInfo    *proto.SignedGossipMessage
+}
+
+func (g *peerMockGossip) SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage {
+	return g.stateInfo
 }
 
 func (g *peerMockGossip) Peers() []discovery.NetworkMember {
@@ -287,7 +318,7 @@ func createCluster(peers ...int) (*clusterOfPeers, map[string]*adapterImpl) {
 		}
 
 		mockGossip := newGossip(peerEndpoint, peerMember)
-		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"),
+		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"), 0,
 			metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
 		adapters[peerEndpoint] = adapter.(*adapterImpl)
 		cluster.addPeer(peerEndpoint, mockGossip)
@@ -302,7 +333,7 @@ func TestReportMetrics(t *testing.T) {
 	electionMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).ElectionMetrics
 
 	mockGossip := newGossip("", &discovery.NetworkMember{})
-	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), electionMetrics)
+	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), 0, electionMetrics)
 
 	adapter.ReportMetrics(true)
 
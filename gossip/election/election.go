@@ -18,7 +18,8 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing their advertised priorities,
+//   optionally their ledger heights, and then their IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -50,7 +51,7 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
+// 			a better candidate,
 //			become a follower
 //		Else, you're a follower:
 //			If haven't received a leadership declaration within
@@ -63,9 +64,13 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A candidate is better than another if it has a higher priority,
+// or the same priority and a higher ledger height, when the ledger
+// height is taken into account, or else a lower ID.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -124,6 +129,11 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// Priority returns the leadership priority of the peer sent the message
+	Priority() uint32
+	// LedgerHeight returns the ledger height of the peer sent the message,
+	// at the time the message was created
+	LedgerHeight() uint64
 }
 
 func noopCallback(_ bool) {
@@ -141,6 +151,9 @@ type ElectionConfig struct {
 	MembershipSampleInterval time.Duration
 	LeaderAliveThreshold     time.Duration
 	LeaderElectionDuration   time.Duration
+	// PreferHighestLedgerHeight makes peers with the same priority
+	// prefer the candidate with the highest ledger height
+	PreferHighestLedgerHeight bool
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
@@ -150,7 +163,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
-		proposals:     util.NewSet(),
+		proposals:     make(map[string]Msg),
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
@@ -162,6 +175,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 	if callback != nil {
 		le.callback = callback
 	}
+	le.self = adapter.CreateMessage(false)
 
 	go le.start()
 	return le
@@ -170,7 +184,8 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 // leaderElectionSvcImpl is an implementation of a LeaderElectionService
 type leaderElectionSvcImpl struct {
 	id        peerID
-	proposals *util.Set
+	self      Msg
+	proposals map[string]Msg
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -224,13 +239,13 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals[string(msg.SenderID())] = msg
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.prefers(msg, le.self) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -311,12 +326,14 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	}
 	// Leader doesn't exist, let's see if there is a better candidate than us
 	// for being a leader
-	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+	le.Lock()
+	for _, proposal := range le.proposals {
+		if le.prefers(proposal, le.self) {
+			le.Unlock()
 			return
 		}
 	}
+	le.Unlock()
 	// If we got here, there is no one that proposed being a leader
 	// that's a better candidate than us.
 	le.beLeader()
@@ -328,14 +345,31 @@ func (le *leaderElectionSvcImpl) propose() {
 	le.logger.Debug(le.id, ": Entering")
 	le.logger.Debug(le.id, ": Exiting")
 	leadershipProposal := le.adapter.CreateMessage(false)
+	le.Lock()
+	le.self = leadershipProposal
+	le.Unlock()
 	le.adapter.Gossip(leadershipProposal)
 }
 
+// prefers returns whether the peer that sent a is a better
+// candidate for being a leader than the peer that sent b
+func (le *leaderElectionSvcImpl) prefers(a, b Msg) bool {
+	if a.Priority() != b.Priority() {
+		return a.Priority() > b.Priority()
+	}
+	if le.config.PreferHighestLedgerHeight && a.LedgerHeight() != b.LedgerHeight() {
+		return a.LedgerHeight() > b.LedgerHeight()
+	}
+	return bytes.Compare(a.SenderID(), b.SenderID()) < 0
+}
+
 func (le *leaderElectionSvcImpl) follower() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
 
-	le.proposals.Clear()
+	le.Lock()
+	le.proposals = make(map[string]Msg)
+	le.Unlock()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
 	le.adapter.ReportMetrics(false)
 	select {
@@ -347,6 +381,9 @@ func (le *leaderElectionSvcImpl) follower() {
 
 func (le *leaderElectionSvcImpl) leader() {
 	leaderDeclaration := le.adapter.CreateMessage(true)
+	le.Lock()
+	le.self = leaderDeclaration
+	le.Unlock()
 	le.adapter.Gossip(leaderDeclaration)
 	le.adapter.ReportMetrics(true)
 	le.waitForInterrupt(le.config.LeaderAliveThreshold / 2)
@@ -35,6 +35,8 @@ func init() {
 type msg struct {
 	sender   string
 	proposal bool
+	priority uint32
+	height   uint64
 }
 
 func (m *msg) SenderID() peerID {
@@ -49,6 +51,14 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) Priority() uint32 {
+	return m.priority
+}
+
+func (m *msg) LedgerHeight() uint64 {
+	return m.height
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
@@ -58,6 +68,8 @@ type peer struct {
 	msgChan            chan Msg
 	leaderFromCallback bool
 	callbackInvoked    bool
+	priority           uint32
+	height             uint64
 	lock               sync.RWMutex
 	LeaderElectionService
 }
@@ -102,7 +114,9 @@ func (p *peer) Accept() <-chan Msg {
 }
 
 func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return &msg{proposal: !isDeclaration, sender: p.id, priority: p.priority, height: p.height}
 }
 
 func (p *peer) Peers() []Peer {
@@ -158,16 +172,39 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
+// candidacy describes the priority and ledger height a test peer advertises
+type candidacy struct {
+	id       int
+	priority uint32
+	height   uint64
+}
+
+func createCandidates(preferHighestLedgerHeight bool, candidates ...candidacy) []*peer {
+	peers := make([]*peer, len(candidates))
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	for i, c := range candidates {
+		peers[i] = createPeerWithCandidacy(c, preferHighestLedgerHeight, peerMap, l, func(mock.Arguments) {})
+	}
+	return peers
+}
+
 func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
-	idStr := fmt.Sprintf("p%d", id)
-	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	return createPeerWithCandidacy(candidacy{id: id}, false, peerMap, l, f)
+}
+
+func createPeerWithCandidacy(c candidacy, preferHighestLedgerHeight bool, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
+	idStr := fmt.Sprintf("p%d", c.id)
+	ch := make(chan Msg, 100)
+	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: ch, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false,
+		priority: c.priority, height: c.height}
 	p.On("ReportMetrics", mock.Anything).Run(f)
 	config := ElectionConfig{
-		StartupGracePeriod:       testStartupGracePeriod,
-		MembershipSampleInterval: testMembershipSampleInterval,
-		LeaderAliveThreshold:     testLeaderAliveThreshold,
-		LeaderElectionDuration:   testLeaderElectionDuration,
+		StartupGracePeriod:        testStartupGracePeriod,
+		MembershipSampleInterval:  testMembershipSampleInterval,
+		LeaderAliveThreshold:      testLeaderAliveThreshold,
+		LeaderElectionDuration:    testLeaderElectionDuration,
+		PreferHighestLedgerHeight: preferHighestLedgerHeight,
 	}
 	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, config)
 	l.Lock()
@@ -255,6 +292,39 @@ func TestInitPeersAtSameTime(t *testing.T) {
 	waitForBoolFunc(t, peers[len(peers)-1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[len(peers)-1].id)
 }
 
+func TestPriority(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, and p2 advertises a higher priority
+	// expected outcome: p2 is the leader although its ID isn't the lowest
+	peers := createCandidates(false, candidacy{id: 0}, candidacy{id: 1}, candidacy{id: 2, priority: 1}, candidacy{id: 3})
+	time.Sleep(testStartupGracePeriod + testLeaderElectionDuration)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestPreferHighestLedgerHeight(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, with the highest
+	// ledger height being preferred among peers of the same priority
+	// expected outcome: the peer with the highest priority is the leader,
+	// and then the peer with the highest ledger height once it yields
+	peers := createCandidates(true,
+		candidacy{id: 0, height: 5},
+		candidacy{id: 1, priority: 1},
+		candidacy{id: 2, height: 5},
+		candidacy{id: 3, height: 10})
+	time.Sleep(testStartupGracePeriod + testLeaderElectionDuration)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p1"}, leaders)
+
+	peers[1].Yield()
+	isP3Leader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p3"
+	}
+	waitForBoolFunc(t, isP3Leader, true)
+}
+
 func TestInitPeersStartAtIntervals(t *testing.T) {
 	t.Parallel()
 	// Scenario: Peers are spawned one by one in a slow rate
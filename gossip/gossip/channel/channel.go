@@ -94,6 +94,10 @@ type GossipChannel interface {
 	// LeaveChannel makes the peer leave the channel
 	LeaveChannel()
 
+	// PurgeMessagesOf removes the messages created by the peer with
+	// the given PKI-ID from the message stores of the channel
+	PurgeMessagesOf(pkiID common.PKIidType)
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -327,6 +331,15 @@ func (gc *gossipChannel) Self() *proto.SignedGossipMessage {
 	return gc.stateInfoMsg
 }
 
+// PurgeMessagesOf removes the messages created by the peer with
+// the given PKI-ID from the message stores of the channel
+func (gc *gossipChannel) PurgeMessagesOf(pkiID common.PKIidType) {
+	gc.stateInfoMsgStore.purge(pkiID)
+	gc.leaderMsgStore.Purge(func(o interface{}) bool {
+		return bytes.Equal(o.(*proto.SignedGossipMessage).GetLeadershipMsg().PkiId, pkiID)
+	})
+}
+
 // LeaveChannel makes the peer leave the channel
 func (gc *gossipChannel) LeaveChannel() {
 	gc.Lock()
@@ -1007,11 +1020,14 @@ func (cache *stateInfoCache) Add(msg *proto.SignedGossipMessage) bool {
 }
 
 func (cache *stateInfoCache) delete(msg *proto.SignedGossipMessage) {
+	cache.purge(msg.GetStateInfo().PkiId)
+}
+
+func (cache *stateInfoCache) purge(pkiID common.PKIidType) {
 	cache.Purge(func(o interface{}) bool {
-		pkiID := o.(*proto.SignedGossipMessage).GetStateInfo().PkiId
-		return bytes.Equal(pkiID, msg.GetStateInfo().PkiId)
+		return bytes.Equal(o.(*proto.SignedGossipMessage).GetStateInfo().PkiId, pkiID)
 	})
-	cache.Remove(msg.GetStateInfo().PkiId)
+	cache.Remove(pkiID)
 }
 
 func (cache *stateInfoCache) Stop() {
@@ -94,6 +94,14 @@ func (cs *channelState) getGossipChannelByChainID(chainID common.ChainID) channe
 	return cs.channels[string(chainID)]
 }
 
+func (cs *channelState) purgeMessagesOf(pkiID common.PKIidType) {
+	cs.RLock()
+	defer cs.RUnlock()
+	for _, gc := range cs.channels {
+		gc.PurgeMessagesOf(pkiID)
+	}
+}
+
 func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID common.ChainID,
 	metrics *metrics.MembershipMetrics) {
 	if cs.isStopping() {
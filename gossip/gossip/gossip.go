@@ -85,6 +85,29 @@ type Gossip interface {
 	Stop()
 }
 
+// Inspector exposes the internal membership and connection state
+// of the gossip component, for troubleshooting purposes
+type Inspector interface {
+	// DeadPeers returns the NetworkMembers considered dead
+	DeadPeers() []discovery.NetworkMember
+
+	// ConnectedPeers returns the PKI-IDs of the peers the
+	// gossip component currently holds connections to
+	ConnectedPeers() []common.PKIidType
+}
+
+// Prober probes remote endpoints on behalf of the gossip component,
+// in order to validate connectivity to them
+type Prober interface {
+	// Probe probes a remote endpoint and returns nil if it responds,
+	// and an error if it doesn't
+	Probe(endpoint string) error
+
+	// Handshake authenticates a remote endpoint and returns
+	// its identity on success
+	Handshake(endpoint string) (api.PeerIdentityType, error)
+}
+
 // emittedGossipMessage encapsulates signed gossip message to compose
 // with routing filter to be used while message is forwarded
 type emittedGossipMessage struct {
@@ -71,6 +71,29 @@ type gossipServiceImpl struct {
 func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType,
 	secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics) Gossip {
+	commFactory := func(idMapper identity.Mapper) (comm.Comm, error) {
+		commConfig := comm.CommConfig{
+			DialTimeout:  conf.DialTimeout,
+			ConnTimeout:  conf.ConnTimeout,
+			RecvBuffSize: conf.RecvBuffSize,
+			SendBuffSize: conf.SendBuffSize,
+		}
+		return comm.NewCommInstance(s, conf.TLSCerts, idMapper, selfIdentity, secureDialOpts, sa,
+			gossipMetrics.CommMetrics, commConfig)
+	}
+	return NewGossipServiceWithComm(conf, sa, mcs, selfIdentity, commFactory, gossipMetrics)
+}
+
+// CommFactory creates the communication layer of a gossip instance,
+// given the identity mapper the instance uses
+type CommFactory func(idMapper identity.Mapper) (comm.Comm, error)
+
+// NewGossipServiceWithComm creates a gossip instance that communicates through
+// the communication layer created by the given CommFactory. It is used to run
+// gossip instances over transports other than gRPC, such as in-memory networks.
+func NewGossipServiceWithComm(conf *Config, sa api.SecurityAdvisor,
+	mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType,
+	commFactory CommFactory, gossipMetrics *metrics.GossipMetrics) Gossip {
 	var err error
 
 	lgr := util.GetLogger(util.GossipLogger, conf.ID)
@@ -96,17 +119,10 @@ func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	g.idMapper = identity.NewIdentityMapper(mcs, selfIdentity, func(pkiID common.PKIidType, identity api.PeerIdentityType) {
 		g.comm.CloseConn(&comm.RemotePeer{PKIID: pkiID})
 		g.certPuller.Remove(string(pkiID))
+		g.purgeMessagesOf(pkiID)
 	}, sa)
 
-	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
-	}
-	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
-		gossipMetrics.CommMetrics, commConfig)
-
+	g.comm, err = commFactory(g.idMapper)
 	if err != nil {
 		lgr.Error("Failed instntiating communication layer:", err)
 		return nil
@@ -206,6 +222,19 @@ func (g *gossipServiceImpl) SuspectPeers(isSuspected api.PeerSuspector) {
 	g.certStore.suspectPeers(isSuspected)
 }
 
+// purgeMessagesOf removes the peer with the given PKI-ID from the membership,
+// and removes the messages it created from the message stores
+func (g *gossipServiceImpl) purgeMessagesOf(pkiID common.PKIidType) {
+	if g.disc != nil {
+		g.disc.PurgeMember(pkiID)
+	}
+	g.stateInfoMsgStore.Purge(func(o interface{}) bool {
+		return bytes.Equal(o.(*proto.SignedGossipMessage).GetStateInfo().PkiId, pkiID)
+	})
+	g.chanState.purgeMessagesOf(pkiID)
+	g.logger.Info("Purged the messages of peer", pkiID)
+}
+
 func (g *gossipServiceImpl) periodicalIdentityValidation(suspectFunc api.PeerSuspector, interval time.Duration) {
 	for {
 		select {
@@ -716,6 +745,29 @@ func (g *gossipServiceImpl) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead
+func (g *gossipServiceImpl) DeadPeers() []discovery.NetworkMember {
+	return g.disc.GetDeadMembers()
+}
+
+// ConnectedPeers returns the PKI-IDs of the peers the
+// gossip component currently holds connections to
+func (g *gossipServiceImpl) ConnectedPeers() []common.PKIidType {
+	return g.comm.Connections()
+}
+
+// Probe probes a remote endpoint and returns nil if it responds,
+// and an error if it doesn't
+func (g *gossipServiceImpl) Probe(endpoint string) error {
+	return g.comm.Probe(&comm.RemotePeer{Endpoint: endpoint})
+}
+
+// Handshake authenticates a remote endpoint and returns
+// its identity on success
+func (g *gossipServiceImpl) Handshake(endpoint string) (api.PeerIdentityType, error) {
+	return g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *gossipServiceImpl) PeersOfChannel(channel common.ChainID) []discovery.NetworkMember {
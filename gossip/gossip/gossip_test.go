@@ -1479,6 +1479,10 @@ func TestIdentityExpiration(t *testing.T) {
 		peers[i].SuspectPeers(func(_ api.PeerIdentityType) bool {
 			return true
 		})
+		// The revoked peer is purged from the membership right away
+		for _, member := range peers[i].Peers() {
+			assert.NotEqual(t, revokedPkiID, member.PKIid)
+		}
 	}
 	// Ensure that no one talks to the peer that is revoked
 	ensureRevokedPeerIsIgnored := func() bool {
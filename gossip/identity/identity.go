@@ -14,9 +14,12 @@ import (
 
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
 	errors "github.com/pkg/errors"
 )
 
+var logger = util.GetLogger(util.IdentityLogger, "")
+
 var (
 	// identityUsageThreshold sets the maximum time that an identity
 	// can not be used to verify some signature before it will be deleted
@@ -211,6 +214,8 @@ func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector)
 			continue
 		}
 		if err := is.mcs.ValidateIdentity(storedIdentity.fetchIdentity()); err != nil {
+			logger.Warningf("Security event: identity of peer %s of organization %s is no longer valid (%v), purging it",
+				storedIdentity.pkiID, string(storedIdentity.orgId), err)
 			revokedIdentities = append(revokedIdentities, storedIdentity)
 		}
 	}
@@ -232,11 +237,14 @@ func (is *identityMapperImpl) IdentityInfo() api.PeerIdentitySet {
 	return res
 }
 
+// delete removes the identity from the Mapper, and then invokes the purge trigger.
+// The trigger is invoked without holding the lock, as it may purge state that is
+// guarded by locks which are held while identities are looked up.
 func (is *identityMapperImpl) delete(pkiID common.PKIidType, identity api.PeerIdentityType) {
 	is.Lock()
-	defer is.Unlock()
-	is.onPurge(pkiID, identity)
 	delete(is.pkiID2Cert, string(pkiID))
+	is.Unlock()
+	is.onPurge(pkiID, identity)
 }
 
 type storedIdentity struct {
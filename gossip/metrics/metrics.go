@@ -98,6 +98,16 @@ type CommMetrics struct {
 	SentMessages     metrics.Counter
 	BufferOverflow   metrics.Counter
 	ReceivedMessages metrics.Counter
+
+	SentMessagesByType     metrics.Counter
+	SentBytes              metrics.Counter
+	ReceivedMessagesByType metrics.Counter
+	ReceivedBytes          metrics.Counter
+	DroppedMessagesByType  metrics.Counter
+	DroppedBytes           metrics.Counter
+
+	PeerSendBufferSize  metrics.Gauge
+	PeerDroppedMessages metrics.Counter
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
@@ -105,6 +115,26 @@ func newCommMetrics(p metrics.Provider) *CommMetrics {
 		SentMessages:     p.NewCounter(SentMessagesOpts),
 		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
 		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+
+		SentMessagesByType:     p.NewCounter(SentMessagesByTypeOpts),
+		SentBytes:              p.NewCounter(SentBytesOpts),
+		ReceivedMessagesByType: p.NewCounter(ReceivedMessagesByTypeOpts),
+		ReceivedBytes:          p.NewCounter(ReceivedBytesOpts),
+		DroppedMessagesByType:  p.NewCounter(DroppedMessagesByTypeOpts),
+		DroppedBytes:           p.NewCounter(DroppedBytesOpts),
+
+		PeerSendBufferSize:  p.NewGauge(PeerSendBufferSizeOpts),
+		PeerDroppedMessages: p.NewCounter(PeerDroppedMessagesOpts),
+	}
+}
+
+// DeletePeer deletes the series of the per peer metrics of the given peer,
+// if the metrics provider supports deleting them
+func (m *CommMetrics) DeletePeer(peer string) {
+	for _, meter := range []interface{}{m.PeerSendBufferSize, m.PeerDroppedMessages} {
+		if deleter, ok := meter.(metrics.LabelDeleter); ok {
+			deleter.DeleteLabelValues("peer", peer)
+		}
 	}
 }
 
@@ -132,6 +162,78 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	SentMessagesByTypeOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_sent_by_type",
+		Help:         "Number of messages sent, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	SentBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "bytes_sent",
+		Help:         "Number of bytes of messages sent, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	ReceivedMessagesByTypeOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_received_by_type",
+		Help:         "Number of messages received, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	ReceivedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "bytes_received",
+		Help:         "Number of bytes of messages received, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	DroppedMessagesByTypeOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_dropped_by_type",
+		Help:         "Number of outgoing messages dropped, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	DroppedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "bytes_dropped",
+		Help:         "Number of bytes of outgoing messages dropped, by message type and channel",
+		LabelNames:   []string{"type", "channel"},
+		StatsdFormat: "%{#fqname}.%{type}.%{channel}",
+	}
+
+	PeerSendBufferSizeOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "peer_send_buffer_size",
+		Help:         "Number of messages waiting in the send buffer of the connection to a remote peer",
+		LabelNames:   []string{"peer"},
+		StatsdFormat: "%{#fqname}.%{peer}",
+	}
+
+	PeerDroppedMessagesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "peer_messages_dropped",
+		Help:         "Number of messages to a remote peer dropped because its send buffer overflowed",
+		LabelNames:   []string{"peer"},
+		StatsdFormat: "%{#fqname}.%{peer}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
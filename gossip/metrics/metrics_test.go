@@ -42,6 +42,14 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	assert.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	assert.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	assert.NotNil(t, gossipMetrics.CommMetrics.SentMessagesByType)
+	assert.NotNil(t, gossipMetrics.CommMetrics.SentBytes)
+	assert.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessagesByType)
+	assert.NotNil(t, gossipMetrics.CommMetrics.ReceivedBytes)
+	assert.NotNil(t, gossipMetrics.CommMetrics.DroppedMessagesByType)
+	assert.NotNil(t, gossipMetrics.CommMetrics.DroppedBytes)
+	assert.NotNil(t, gossipMetrics.CommMetrics.PeerSendBufferSize)
+	assert.NotNil(t, gossipMetrics.CommMetrics.PeerDroppedMessages)
 
 	assert.NotNil(t, gossipMetrics.MembershipMetrics)
 	assert.NotNil(t, gossipMetrics.MembershipMetrics.Total)
@@ -57,3 +65,40 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PullDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.RetrieveDuration)
 }
+
+type deletingCounter struct {
+	metricsfakes.Counter
+	deleted [][]string
+}
+
+func (c *deletingCounter) DeleteLabelValues(labelValues ...string) {
+	c.deleted = append(c.deleted, labelValues)
+}
+
+type deletingGauge struct {
+	metricsfakes.Gauge
+	deleted [][]string
+}
+
+func (g *deletingGauge) DeleteLabelValues(labelValues ...string) {
+	g.deleted = append(g.deleted, labelValues)
+}
+
+func TestCommMetricsDeletePeer(t *testing.T) {
+	counter := &deletingCounter{}
+	gauge := &deletingGauge{}
+	commMetrics := &CommMetrics{
+		PeerDroppedMessages: counter,
+		PeerSendBufferSize:  gauge,
+	}
+	commMetrics.DeletePeer("peer0:7051")
+	assert.Equal(t, [][]string{{"peer", "peer0:7051"}}, counter.deleted)
+	assert.Equal(t, [][]string{{"peer", "peer0:7051"}}, gauge.deleted)
+
+	// Meters which do not support deleting series are left as they are
+	commMetrics = &CommMetrics{
+		PeerDroppedMessages: &metricsfakes.Counter{},
+		PeerSendBufferSize:  &metricsfakes.Gauge{},
+	}
+	assert.NotPanics(t, func() { commMetrics.DeletePeer("peer0:7051") })
+}
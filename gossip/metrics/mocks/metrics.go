@@ -25,6 +25,15 @@ type TestMetricProvider struct {
 	FakeBufferOverflow   *metricsfakes.Counter
 	FakeReceivedMessages *metricsfakes.Counter
 
+	FakeSentMessagesByType     *metricsfakes.Counter
+	FakeSentBytes              *metricsfakes.Counter
+	FakeReceivedMessagesByType *metricsfakes.Counter
+	FakeReceivedBytes          *metricsfakes.Counter
+	FakeDroppedMessagesByType  *metricsfakes.Counter
+	FakeDroppedBytes           *metricsfakes.Counter
+	FakePeerSendBufferSize     *metricsfakes.Gauge
+	FakePeerDroppedMessages    *metricsfakes.Counter
+
 	FakeTotalGauge *metricsfakes.Gauge
 
 	FakeValidationDuration             *metricsfakes.Histogram
@@ -51,6 +60,15 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
 
+	fakeSentMessagesByType := testUtilConstructCounter()
+	fakeSentBytes := testUtilConstructCounter()
+	fakeReceivedMessagesByType := testUtilConstructCounter()
+	fakeReceivedBytes := testUtilConstructCounter()
+	fakeDroppedMessagesByType := testUtilConstructCounter()
+	fakeDroppedBytes := testUtilConstructCounter()
+	fakePeerSendBufferSize := testUtilConstructGauge()
+	fakePeerDroppedMessages := testUtilConstructCounter()
+
 	fakeTotalGauge := testUtilConstructGauge()
 
 	fakeValidationDuration := testUtilConstructHist()
@@ -71,6 +89,20 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.SentMessagesByTypeOpts.Name:
+			return fakeSentMessagesByType
+		case gmetrics.SentBytesOpts.Name:
+			return fakeSentBytes
+		case gmetrics.ReceivedMessagesByTypeOpts.Name:
+			return fakeReceivedMessagesByType
+		case gmetrics.ReceivedBytesOpts.Name:
+			return fakeReceivedBytes
+		case gmetrics.DroppedMessagesByTypeOpts.Name:
+			return fakeDroppedMessagesByType
+		case gmetrics.DroppedBytesOpts.Name:
+			return fakeDroppedBytes
+		case gmetrics.PeerDroppedMessagesOpts.Name:
+			return fakePeerDroppedMessages
 		}
 		return nil
 	}
@@ -109,6 +141,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeDeclarationGauge
 		case gmetrics.TotalOpts.Name:
 			return fakeTotalGauge
+		case gmetrics.PeerSendBufferSizeOpts.Name:
+			return fakePeerSendBufferSize
 		}
 		return nil
 	}
@@ -122,6 +156,14 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeSentMessagesByType,
+		fakeSentBytes,
+		fakeReceivedMessagesByType,
+		fakeReceivedBytes,
+		fakeDroppedMessagesByType,
+		fakeDroppedBytes,
+		fakePeerSendBufferSize,
+		fakePeerDroppedMessages,
 		fakeTotalGauge,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
@@ -26,6 +26,7 @@ import (
 	privdatacommon "github.com/hyperledger/fabric/gossip/privdata/common"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/common"
+	gossip2 "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -64,6 +65,10 @@ type TransientStore interface {
 	// after successful block commit, PurgeByHeight() is still required to remove orphan entries (as
 	// transaction that gets endorsed may not be submitted by the client for commit)
 	PurgeByHeight(maxBlockNumToRetain uint64) error
+
+	// ShardPersister persists the erasure-coded shards of private data,
+	// which are purged along with the private write sets
+	ShardPersister
 }
 
 // Coordinator orchestrates the flow of the new
@@ -77,6 +82,10 @@ type Coordinator interface {
 	// StorePvtData used to persist private data into transient store
 	StorePvtData(txid string, privData *transientstore2.TxPvtReadWriteSetWithConfigInfo, blckHeight uint64) error
 
+	// StorePvtDataShard stores an erasure-coded shard of private data
+	// until the corresponding block is committed
+	StorePvtDataShard(payload *gossip2.PrivatePayload) error
+
 	// GetPvtDataAndBlockByNum get block by number and returns also all related private data
 	// the order of private data in slice of PvtDataCollections doesn't implies the order of
 	// transactions in the block related to these private data, to get the correct placement
@@ -90,7 +99,14 @@ type Coordinator interface {
 	Close()
 }
 
-type dig2sources map[privdatacommon.DigKey][]*peer.Endorsement
+// digestSources holds the endorsers of missing private data, along with
+// the hash of the private RWSet the fetched private data is expected to match
+type digestSources struct {
+	endorsements []*peer.Endorsement
+	rwSetHash    []byte
+}
+
+type dig2sources map[privdatacommon.DigKey]digestSources
 
 func (d2s dig2sources) keys() []privdatacommon.DigKey {
 	res := make([]privdatacommon.DigKey, 0, len(d2s))
@@ -124,6 +140,9 @@ type Support struct {
 	TransientStore
 	Fetcher
 	CapabilityProvider
+	// ShardStore holds the erasure-coded shards of private data
+	// received by the peer. If nil, the shards are kept in the TransientStore.
+	ShardStore *ShardStore
 }
 
 type coordinator struct {
@@ -144,6 +163,9 @@ type CoordinatorConfig struct {
 // NewCoordinator creates a new instance of coordinator
 func NewCoordinator(support Support, selfSignedData common.SignedData, metrics *metrics.PrivdataMetrics,
 	config CoordinatorConfig) Coordinator {
+	if support.ShardStore == nil {
+		support.ShardStore = NewShardStore(support.TransientStore)
+	}
 	return &coordinator{Support: support,
 		selfSignedData:                 selfSignedData,
 		transientBlockRetention:        config.TransientBlockRetention,
@@ -157,6 +179,12 @@ func (c *coordinator) StorePvtData(txID string, privData *transientstore2.TxPvtR
 	return c.TransientStore.PersistWithConfig(txID, blkHeight, privData)
 }
 
+// StorePvtDataShard stores an erasure-coded shard of private data
+// until the corresponding block is committed
+func (c *coordinator) StorePvtDataShard(payload *gossip2.PrivatePayload) error {
+	return c.ShardStore.Put(payload)
+}
+
 // StoreBlock stores block with private data into the ledger
 func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDataCollections) error {
 	if block.Data == nil {
@@ -308,7 +336,7 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 }
 
 func (c *coordinator) fetchFromPeers(blockSeq uint64, ownedRWsets map[rwSetKey][]byte, privateInfo *privateDataInfo) {
-	dig2src := make(map[privdatacommon.DigKey][]*peer.Endorsement)
+	dig2src := make(dig2sources)
 	privateInfo.missingKeys.foreach(func(k rwSetKey) {
 		logger.Debug("Fetching", k, "from peers")
 		dig := privdatacommon.DigKey{
@@ -318,7 +346,14 @@ func (c *coordinator) fetchFromPeers(blockSeq uint64, ownedRWsets map[rwSetKey][
 			Namespace:  k.namespace,
 			BlockSeq:   blockSeq,
 		}
-		dig2src[dig] = privateInfo.sources[k]
+		rwSetHash, err := hex.DecodeString(k.hash)
+		if err != nil {
+			logger.Warning("Failed decoding hash of", k, ":", err)
+		}
+		dig2src[dig] = digestSources{
+			endorsements: privateInfo.sources[k],
+			rwSetHash:    rwSetHash,
+		}
 	})
 	fetchedData, err := c.fetch(dig2src)
 	if err != nil {
@@ -136,6 +136,18 @@ func (store *mockTransientStore) PurgeByHeight(maxBlockNumToRetain uint64) error
 	return store.Called(maxBlockNumToRetain).Error(0)
 }
 
+func (store *mockTransientStore) PersistShard(txid string, blockHeight uint64, shard *proto.PrivatePayload) error {
+	return store.Called(txid, blockHeight, shard).Error(0)
+}
+
+func (store *mockTransientStore) GetShards(txid string) ([]*proto.PrivatePayload, error) {
+	args := store.Called(txid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*proto.PrivatePayload), args.Error(1)
+}
+
 func (store *mockTransientStore) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter) (transientstore.RWSetScanner, error) {
 	store.lastReqTxID = txid
 	store.lastReqFilter = filter
@@ -272,8 +284,8 @@ func (f *fetcherMock) On(methodName string, arguments ...interface{}) *fetchCall
 }
 
 func (f *fetcherMock) fetch(dig2src dig2sources) (*privdatacommon.FetchedPvtDataContainer, error) {
-	for _, endorsements := range dig2src {
-		for _, endorsement := range endorsements {
+	for _, sources := range dig2src {
+		for _, endorsement := range sources.endorsements {
 			_, exists := f.expectedEndorsers[string(endorsement.Endorser)]
 			if !exists {
 				f.t.Fatalf("Encountered a non-expected endorser: %s", string(endorsement.Endorser))
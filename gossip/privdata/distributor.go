@@ -15,6 +15,7 @@ import (
 	"time"
 
 	proto2 "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/erasure"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
@@ -128,6 +129,15 @@ func (d *distributorImpl) Distribute(txID string, privData *transientstore.TxPvt
 type dissemination struct {
 	msg      *proto.SignedGossipMessage
 	criteria gossip2.SendCriteria
+	// shards is set if the message carries an erasure-coded shard
+	shards *shardDissemination
+}
+
+// shardDissemination tracks the acknowledgements of the
+// shards of an erasure-coded private rwset
+type shardDissemination struct {
+	required uint32
+	acked    uint32
 }
 
 func (d *distributorImpl) computeDisseminationPlan(txID string,
@@ -163,7 +173,17 @@ func (d *distributorImpl) computeDisseminationPlan(txID string,
 				return nil, errors.Errorf("No collection access policy filter computed for %v", collectionName)
 			}
 
-			pvtDataMsg, err := d.createPrivateDataMessage(txID, namespace, collection, &common.CollectionConfigPackage{Config: []*common.CollectionConfig{colCP}}, blkHt)
+			ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{colCP}}
+			if ec := colCP.GetStaticCollectionConfig().GetErasureCoding(); ec != nil {
+				dPlan, err := d.shardDisseminationPlan(txID, namespace, collection, ccp, blkHt, colAP, colFilter, ec)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+				disseminationPlan = append(disseminationPlan, dPlan...)
+				continue
+			}
+
+			pvtDataMsg, err := d.createPrivateDataMessage(txID, namespace, collection, ccp, blkHt, nil)
 			if err != nil {
 				return nil, errors.WithStack(err)
 			}
@@ -189,9 +209,7 @@ func (d *distributorImpl) getCollectionConfig(config *common.CollectionConfigPac
 	return nil, errors.New(fmt.Sprint("no configuration for collection", collection.CollectionName, "found"))
 }
 
-func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAccessPolicy, colFilter privdata.Filter, pvtDataMsg *proto.SignedGossipMessage) ([]*dissemination, error) {
-	var disseminationPlan []*dissemination
-
+func (d *distributorImpl) collectionRoutingFilter(colFilter privdata.Filter) (filter.RoutingFilter, error) {
 	routingFilter, err := d.gossipAdapter.PeerFilter(gossipCommon.ChainID(d.chainID), func(signature api.PeerSignature) bool {
 		return colFilter(common.SignedData{
 			Data:      signature.Message,
@@ -204,6 +222,16 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 		logger.Error("Failed to retrieve peer routing filter for channel", d.chainID, ":", err)
 		return nil, err
 	}
+	return routingFilter, nil
+}
+
+func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAccessPolicy, colFilter privdata.Filter, pvtDataMsg *proto.SignedGossipMessage) ([]*dissemination, error) {
+	var disseminationPlan []*dissemination
+
+	routingFilter, err := d.collectionRoutingFilter(colFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	eligiblePeers := d.eligiblePeersOfChannel(routingFilter)
 	identitySets := d.identitiesOfEligiblePeers(eligiblePeers, colAP)
@@ -267,6 +295,100 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	return disseminationPlan, nil
 }
 
+// shardDisseminationPlan splits the private rwset of the collection into erasure-coded
+// shards, and plans to send each shard to a different peer of the collection.
+// The peers are picked from the member organizations in a round robin manner.
+// No more shards than the maximum peer count of the collection are sent, and the number
+// of shards that need to be acknowledged is the required peer count of the collection,
+// but never less than the number of shards needed to reconstruct the private rwset.
+func (d *distributorImpl) shardDisseminationPlan(txID, namespace string, collection *rwset.CollectionPvtReadWriteSet,
+	ccp *common.CollectionConfigPackage, blkHt uint64, colAP privdata.CollectionAccessPolicy, colFilter privdata.Filter,
+	ec *common.ErasureCodingConfig) ([]*dissemination, error) {
+	shards, err := erasure.Encode(collection.Rwset, int(ec.DataShards), int(ec.ParityShards))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprint("failed erasure coding private data of collection ", collection.CollectionName))
+	}
+
+	maxShards := len(shards)
+	if maximumPeerCount := colAP.MaximumPeerCount(); maximumPeerCount < maxShards {
+		maxShards = maximumPeerCount
+	}
+	if maxShards < int(ec.DataShards) {
+		return nil, errors.Errorf("collection %s has a maximum peer count of %d, but %d data shards need to be disseminated",
+			collection.CollectionName, colAP.MaximumPeerCount(), ec.DataShards)
+	}
+	required := int(ec.DataShards)
+	if requiredPeerCount := colAP.RequiredPeerCount(); requiredPeerCount > required {
+		required = requiredPeerCount
+	}
+
+	routingFilter, err := d.collectionRoutingFilter(colFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	eligiblePeers := d.eligiblePeersOfChannel(routingFilter)
+	var peersByOrg []api.PeerIdentitySet
+	for _, peers := range d.identitiesOfEligiblePeers(eligiblePeers, colAP) {
+		shuffled := make(api.PeerIdentitySet, len(peers))
+		for i, j := range rand.Perm(len(peers)) {
+			shuffled[i] = peers[j]
+		}
+		peersByOrg = append(peersByOrg, shuffled)
+	}
+	var selectedPeers []api.PeerIdentityInfo
+	for round := 0; len(selectedPeers) < maxShards; round++ {
+		selected := false
+		for _, peers := range peersByOrg {
+			if round < len(peers) && len(selectedPeers) < maxShards {
+				selectedPeers = append(selectedPeers, peers[round])
+				selected = true
+			}
+		}
+		if !selected {
+			break
+		}
+	}
+	if len(selectedPeers) < required {
+		return nil, errors.Errorf("collection %s requires at least %d peers to disseminate shards to, but only %d are eligible",
+			collection.CollectionName, required, len(selectedPeers))
+	}
+
+	sd := &shardDissemination{required: uint32(required)}
+	var disseminationPlan []*dissemination
+	for i, peer := range selectedPeers {
+		shard := &proto.ErasureShard{
+			Index:        uint32(i),
+			DataShards:   ec.DataShards,
+			ParityShards: ec.ParityShards,
+			Size:         uint64(len(collection.Rwset)),
+		}
+		shardCollection := &rwset.CollectionPvtReadWriteSet{
+			CollectionName: collection.CollectionName,
+			Rwset:          shards[i],
+		}
+		pvtDataMsg, err := d.createPrivateDataMessage(txID, namespace, shardCollection, ccp, blkHt, shard)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		pkiID := peer.PKIId
+		disseminationPlan = append(disseminationPlan, &dissemination{
+			msg:    pvtDataMsg,
+			shards: sd,
+			criteria: gossip2.SendCriteria{
+				Timeout:  d.pushAckTimeout,
+				Channel:  gossipCommon.ChainID(d.chainID),
+				MaxPeers: 1,
+				MinAck:   1,
+				IsEligible: func(member discovery.NetworkMember) bool {
+					return bytes.Equal(member.PKIid, pkiID)
+				},
+			},
+		})
+	}
+	return disseminationPlan, nil
+}
+
 func (d *distributorImpl) identitiesOfEligiblePeers(eligiblePeers []discovery.NetworkMember, colAP privdata.CollectionAccessPolicy) map[string]api.PeerIdentitySet {
 	return d.gossipAdapter.IdentityInfo().
 		Filter(func(info api.PeerIdentityInfo) bool {
@@ -308,14 +430,40 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 			defer wg.Done()
 			defer d.reportSendDuration(start)
 			err := d.SendByCriteria(dis.msg, dis.criteria)
+			m := dis.msg.GetPrivateData().Payload
+			if dis.shards != nil {
+				// A shard that is not acknowledged fails the dissemination
+				// only if too few shards remain to reconstruct the private RWSet
+				if err != nil {
+					logger.Warning("Failed disseminating shard", m.Shard.Index, "of private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
+					return
+				}
+				atomic.AddUint32(&dis.shards.acked, 1)
+				return
+			}
 			if err != nil {
 				atomic.AddUint32(&failures, 1)
-				m := dis.msg.GetPrivateData().Payload
 				logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
 			}
 		}(dis)
 	}
 	wg.Wait()
+	shardDisseminations := make(map[*shardDissemination]struct{})
+	for _, dis := range disseminationPlan {
+		if dis.shards == nil {
+			continue
+		}
+		if _, exists := shardDisseminations[dis.shards]; exists {
+			continue
+		}
+		shardDisseminations[dis.shards] = struct{}{}
+		if acked := atomic.LoadUint32(&dis.shards.acked); acked < dis.shards.required {
+			m := dis.msg.GetPrivateData().Payload
+			logger.Error("Only", acked, "shards of private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName,
+				"were acknowledged, while", dis.shards.required, "are required")
+			atomic.AddUint32(&failures, 1)
+		}
+	}
 	failureCount := atomic.LoadUint32(&failures)
 	if failureCount != 0 {
 		return errors.Errorf("Failed disseminating %d out of %d private dissemination plans", failureCount, len(disseminationPlan))
@@ -330,7 +478,7 @@ func (d *distributorImpl) reportSendDuration(startTime time.Time) {
 func (d *distributorImpl) createPrivateDataMessage(txID, namespace string,
 	collection *rwset.CollectionPvtReadWriteSet,
 	ccp *common.CollectionConfigPackage,
-	blkHt uint64) (*proto.SignedGossipMessage, error) {
+	blkHt uint64, shard *proto.ErasureShard) (*proto.SignedGossipMessage, error) {
 	msg := &proto.GossipMessage{
 		Channel: []byte(d.chainID),
 		Nonce:   util.RandomUInt64(),
@@ -344,6 +492,7 @@ func (d *distributorImpl) createPrivateDataMessage(txID, namespace string,
 					PrivateRwset:      collection.Rwset,
 					PrivateSimHeight:  blkHt,
 					CollectionConfigs: ccp,
+					Shard:             shard,
 				},
 			},
 		},
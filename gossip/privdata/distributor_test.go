@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/erasure"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
 	gcommon "github.com/hyperledger/fabric/gossip/common"
@@ -271,3 +272,151 @@ func TestDistributor(t *testing.T) {
 	)
 	assert.True(t, testMetricProvider.FakeSendDuration.ObserveArgsForCall(0) > 0)
 }
+
+func TestDistributorErasureCoding(t *testing.T) {
+	channelID := "test"
+	members := []discovery.NetworkMember{
+		{PKIid: gcommon.PKIidType{1}},
+		{PKIid: gcommon.PKIidType{2}},
+		{PKIid: gcommon.PKIidType{3}},
+	}
+	identities := api.PeerIdentitySet{
+		{PKIId: gcommon.PKIidType{1}, Organization: api.OrgIdentityType("org1")},
+		{PKIId: gcommon.PKIidType{2}, Organization: api.OrgIdentityType("org1")},
+		{PKIId: gcommon.PKIidType{3}, Organization: api.OrgIdentityType("org2")},
+	}
+	sentTo := func(pkiID gcommon.PKIidType) interface{} {
+		return mock.MatchedBy(func(criteria gossip2.SendCriteria) bool {
+			return criteria.IsEligible(discovery.NetworkMember{PKIid: pkiID})
+		})
+	}
+	newGossip := func(members []discovery.NetworkMember) *gossipMock {
+		g := &gossipMock{
+			PeerSignature: api.PeerSignature{
+				Signature:    []byte{3, 4, 5},
+				Message:      []byte{6, 7, 8},
+				PeerIdentity: []byte{0, 1, 2},
+			},
+		}
+		g.On("PeersOfChannel", gcommon.ChainID(channelID)).Return(members)
+		g.On("IdentityInfo").Return(identities)
+		return g
+	}
+
+	colConfig := &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name:              "c1",
+				RequiredPeerCount: 1,
+				MaximumPeerCount:  3,
+				ErasureCoding:     &common.ErasureCodingConfig{DataShards: 2, ParityShards: 1},
+			},
+		},
+	}
+	newAccessFactory := func(requiredPeerCount, maximumPeerCount int) *collectionAccessFactoryMock {
+		policyMock := &collectionAccessPolicyMock{}
+		policyMock.Setup(requiredPeerCount, maximumPeerCount, func(_ common.SignedData) bool {
+			return true
+		}, []string{"org1", "org2"}, false)
+		accessFactoryMock := &collectionAccessFactoryMock{}
+		accessFactoryMock.On("AccessPolicy", colConfig, channelID).Return(policyMock, nil)
+		return accessFactoryMock
+	}
+	accessFactoryMock := newAccessFactory(1, 3)
+
+	pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+	txPvtData := &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: pvtData[0].WriteSet,
+		CollectionConfigs: map[string]*common.CollectionConfigPackage{
+			"ns1": {Config: []*common.CollectionConfig{colConfig}},
+		},
+	}
+	metrics := metrics.NewGossipMetrics(mocks.TestUtilConstructMetricProvider().FakeProvider).PrivdataMetrics
+
+	// Each peer receives a different shard, and any 2 of them
+	// suffice to reconstruct the private rwset
+	g := newGossip(members)
+	shards := make([][]byte, 3)
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		payload := args.Get(0).(*proto.SignedGossipMessage).GetPrivateData().Payload
+		criteria := args.Get(1).(gossip2.SendCriteria)
+		assert.Equal(t, 1, criteria.MaxPeers)
+		assert.Equal(t, 1, criteria.MinAck)
+		assert.Equal(t, uint32(2), payload.Shard.DataShards)
+		assert.Equal(t, uint32(1), payload.Shard.ParityShards)
+		shards[payload.Shard.Index] = payload.PrivateRwset
+	}).Return(nil)
+	d := NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	err := d.Distribute("tx1", txPvtData, 0)
+	assert.NoError(t, err)
+	g.AssertNumberOfCalls(t, "SendByCriteria", 3)
+	rwSet := pvtData[0].WriteSet.NsPvtRwset[0].CollectionPvtRwset[0].Rwset
+	reconstructed, err := erasure.Decode([][]byte{nil, shards[1], shards[2]}, 2, 1, len(rwSet))
+	assert.NoError(t, err)
+	assert.Equal(t, rwSet, reconstructed)
+
+	// A single unacknowledged shard can be tolerated
+	g = newGossip(members)
+	g.On("SendByCriteria", mock.Anything, sentTo(gcommon.PKIidType{1})).Return(errors.New("timed out"))
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+	d = NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	assert.NoError(t, d.Distribute("tx1", txPvtData, 0))
+
+	// But not two of them
+	g = newGossip(members)
+	g.On("SendByCriteria", mock.Anything, sentTo(gcommon.PKIidType{1})).Return(errors.New("timed out"))
+	g.On("SendByCriteria", mock.Anything, sentTo(gcommon.PKIidType{3})).Return(errors.New("timed out"))
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+	d = NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed disseminating 1 out of 3 private dissemination plans")
+
+	// Too few peers to disseminate the data shards to
+	g = newGossip(members[:1])
+	d = NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "collection c1 requires at least 2 peers to disseminate shards to, but only 1 are eligible")
+
+	// No more shards than the maximum peer count are sent, hence all of them need to be
+	// acknowledged. The only peer of org2 is always sent a shard, as orgs are picked round robin.
+	g = newGossip(members)
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+	d = NewDistributor(channelID, g, newAccessFactory(1, 2), metrics, 0)
+	assert.NoError(t, d.Distribute("tx1", txPvtData, 0))
+	g.AssertNumberOfCalls(t, "SendByCriteria", 2)
+
+	g = newGossip(members)
+	g.On("SendByCriteria", mock.Anything, sentTo(gcommon.PKIidType{3})).Return(errors.New("timed out"))
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+	d = NewDistributor(channelID, g, newAccessFactory(1, 2), metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed disseminating 1 out of 2 private dissemination plans")
+
+	// The maximum peer count is too low for the data shards to be disseminated
+	g = newGossip(members)
+	d = NewDistributor(channelID, g, newAccessFactory(1, 1), metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "collection c1 has a maximum peer count of 1, but 2 data shards need to be disseminated")
+	g.AssertNotCalled(t, "SendByCriteria", mock.Anything, mock.Anything)
+
+	// The required peer count exceeds the number of data shards,
+	// hence all 3 shards need to be acknowledged
+	g = newGossip(members)
+	g.On("SendByCriteria", mock.Anything, sentTo(gcommon.PKIidType{1})).Return(errors.New("timed out"))
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+	d = NewDistributor(channelID, g, newAccessFactory(3, 3), metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed disseminating 1 out of 3 private dissemination plans")
+
+	// Too few peers to satisfy the required peer count
+	g = newGossip(members[:2])
+	d = NewDistributor(channelID, g, newAccessFactory(3, 3), metrics, 0)
+	err = d.Distribute("tx1", txPvtData, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "collection c1 requires at least 3 peers to disseminate shards to, but only 2 are eligible")
+}
@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -73,14 +74,16 @@ type puller struct {
 	channel       string
 	cs            privdata.CollectionStore
 	btlPullMargin uint64
+	shards        *ShardStore
 	gossip
 	PrivateDataRetriever
 	CollectionAccessFactory
 }
 
-// NewPuller creates new private data puller
+// NewPuller creates new private data puller. The shards of private data in the given
+// ShardStore are served to remote peers, and are used for reconstructing private data.
 func NewPuller(metrics *metrics.PrivdataMetrics, cs privdata.CollectionStore, g gossip,
-	dataRetriever PrivateDataRetriever, factory CollectionAccessFactory, channel string, btlPullMargin uint64) *puller {
+	dataRetriever PrivateDataRetriever, shards *ShardStore, factory CollectionAccessFactory, channel string, btlPullMargin uint64) *puller {
 	p := &puller{
 		metrics:                 metrics,
 		pubSub:                  util.NewPubSub(),
@@ -88,6 +91,7 @@ func NewPuller(metrics *metrics.PrivdataMetrics, cs privdata.CollectionStore, g
 		channel:                 channel,
 		cs:                      cs,
 		btlPullMargin:           btlPullMargin,
+		shards:                  shards,
 		gossip:                  g,
 		PrivateDataRetriever:    dataRetriever,
 		CollectionAccessFactory: factory,
@@ -151,6 +155,11 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 	// group all digest by block number
 	block2dig := groupDigestsByBlockNum(msg.GetPrivateReq().Digests)
 
+	signedData := fcommon.SignedData{
+		Identity:  message.GetConnectionInfo().Identity,
+		Data:      authInfo.SignedData,
+		Signature: authInfo.Signature,
+	}
 	for blockNum, digests := range block2dig {
 		start := time.Now()
 		dig2rwSets, wasFetchedFromLedger, err := p.CollectionRWSet(digests, blockNum)
@@ -159,11 +168,62 @@ func (p *puller) createResponse(message proto.ReceivedMessage) []*proto.PvtDataE
 			logger.Warningf("could not obtain private collection rwset for block %d, because of %s, continue...", blockNum, err)
 			continue
 		}
-		returned = append(returned, p.filterNotEligible(dig2rwSets, wasFetchedFromLedger, fcommon.SignedData{
-			Identity:  message.GetConnectionInfo().Identity,
-			Data:      authInfo.SignedData,
-			Signature: authInfo.Signature,
-		}, connectionEndpoint)...)
+		returned = append(returned, p.filterNotEligible(dig2rwSets, wasFetchedFromLedger, signedData, connectionEndpoint)...)
+		if !wasFetchedFromLedger {
+			returned = append(returned, p.shardsOf(digests, dig2rwSets, signedData, connectionEndpoint)...)
+		}
+	}
+	return returned
+}
+
+// shardsOf returns the erasure-coded shards the peer holds for the given digests,
+// which no private rwset is available for
+func (p *puller) shardsOf(digests []*proto.PvtDataDigest, dig2rwSets Dig2PvtRWSetWithConfig, signedData fcommon.SignedData, endpoint string) []*proto.PvtDataElement {
+	var returned []*proto.PvtDataElement
+	for _, dig := range digests {
+		if rwSets := dig2rwSets[privdatacommon.DigKey{
+			TxId:       dig.TxId,
+			Namespace:  dig.Namespace,
+			Collection: dig.Collection,
+			BlockSeq:   dig.BlockSeq,
+			SeqInBlock: dig.SeqInBlock,
+		}]; rwSets != nil && len(rwSets.RWSet) != 0 {
+			continue
+		}
+		payload, err := p.shards.Get(dig.TxId, dig.Namespace, dig.Collection)
+		if err != nil {
+			logger.Warning("Failed retrieving shard for", dig, ":", err)
+			continue
+		}
+		if payload == nil {
+			continue
+		}
+		var collectionConfig *fcommon.CollectionConfig
+		for _, config := range payload.CollectionConfigs.GetConfig() {
+			if config.GetStaticCollectionConfig().GetName() == dig.Collection {
+				collectionConfig = config
+			}
+		}
+		if collectionConfig == nil {
+			logger.Debug("No collection config for shard of txID", dig.TxId, "at collection", dig.Collection, "skipping...")
+			continue
+		}
+		colAP, err := p.AccessPolicy(collectionConfig, p.channel)
+		if err != nil {
+			logger.Debug("No policy found for channel", p.channel, ", collection", dig.Collection, "txID", dig.TxId, ":", err, "skipping...")
+			continue
+		}
+		colFilter := colAP.AccessFilter()
+		if colFilter == nil || !colFilter(signedData) {
+			logger.Debug("Peer", endpoint, "isn't eligible for shard of txID", dig.TxId, "at collection", dig.Collection)
+			continue
+		}
+		logger.Debug("Found shard", payload.Shard.Index, "for TxID", dig.TxId, ", collection", dig.Collection, "for", endpoint)
+		returned = append(returned, &proto.PvtDataElement{
+			Digest:  dig,
+			Payload: [][]byte{payload.PrivateRwset},
+			Shard:   payload.Shard,
+		})
 	}
 	return returned
 }
@@ -243,6 +303,8 @@ func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdataco
 	// Distribute requests to peers, and obtain subscriptions for all their messages
 	// matchDigestToPeer returns a map from a peer to the digests which we would ask it for
 	var peer2digests peer2Digests
+	// Shards of erasure-coded private RWSets collected so far
+	collectors := make(map[privdatacommon.DigKey]*shardCollector)
 	// We expect all private RWSets represented as digests to be collected
 	itemsLeftToCollect := len(dig2Filter)
 	// As long as we still have some data to collect and new members to ask the data for:
@@ -278,25 +340,86 @@ func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdataco
 		logger.Debug("Matched", len(dig2Filter), "digests to", len(peer2digests), "peer(s)")
 		subscriptions := p.scatterRequests(peer2digests)
 		responses := p.gatherResponses(subscriptions)
+		var available []*proto.PvtDataElement
 		for _, resp := range responses {
 			if len(resp.Payload) == 0 {
 				logger.Debug("Got empty response for", resp.Digest)
+				available = append(available, resp)
 				continue
 			}
-			delete(dig2Filter, privdatacommon.DigKey{
+			dig := privdatacommon.DigKey{
 				TxId:       resp.Digest.TxId,
 				BlockSeq:   resp.Digest.BlockSeq,
 				SeqInBlock: resp.Digest.SeqInBlock,
 				Namespace:  resp.Digest.Namespace,
 				Collection: resp.Digest.Collection,
-			})
+			}
+			if resp.Shard != nil {
+				// A shard completes the digest only once enough
+				// shards were collected to reconstruct the private RWSet
+				if _, exists := dig2Filter[dig]; !exists {
+					continue
+				}
+				rws := p.collectShard(collectors, dig, resp, dig2Filter[dig].rwSetHash)
+				if rws == nil {
+					continue
+				}
+				resp = &proto.PvtDataElement{
+					Digest:  resp.Digest,
+					Payload: [][]byte{rws},
+				}
+			}
+			delete(dig2Filter, dig)
 			itemsLeftToCollect--
+			available = append(available, resp)
 		}
-		res.AvailableElements = append(res.AvailableElements, responses...)
+		res.AvailableElements = append(res.AvailableElements, available...)
 	}
 	return res, nil
 }
 
+// collectShard adds the shard in the given element to the shards collected for the digest,
+// and returns the private RWSet once it can be reconstructed out of the collected shards.
+// The shard the peer holds itself, if any, is collected as well.
+// If the hash of the private RWSet is known, only a private RWSet matching it is returned,
+// otherwise the shards are kept so that shards pulled from other peers are collected as well.
+func (p *puller) collectShard(collectors map[privdatacommon.DigKey]*shardCollector, dig privdatacommon.DigKey, el *proto.PvtDataElement, rwSetHash []byte) []byte {
+	collector, exists := collectors[dig]
+	if !exists {
+		collector = newShardCollector(el.Shard)
+		collectors[dig] = collector
+		local, err := p.shards.Get(dig.TxId, dig.Namespace, dig.Collection)
+		if err != nil {
+			logger.Warning("Failed retrieving local shard for", dig, ":", err)
+		}
+		if local != nil {
+			if err := collector.add(local.Shard, local.PrivateRwset); err != nil {
+				logger.Debug("Ignoring local shard for", dig, ":", err)
+			}
+		}
+	}
+	if err := collector.add(el.Shard, el.Payload[0]); err != nil {
+		logger.Warning("Ignoring shard for", dig, ":", err)
+		return nil
+	}
+	if !collector.complete() {
+		return nil
+	}
+	var verify func([]byte) bool
+	if len(rwSetHash) != 0 {
+		verify = func(rws []byte) bool {
+			return bytes.Equal(util2.ComputeSHA256(rws), rwSetHash)
+		}
+	}
+	rws, err := collector.reconstruct(verify)
+	if err != nil {
+		logger.Warning("Failed reconstructing private RWSet for", dig, "out of", collector.count, "shards:", err)
+		return nil
+	}
+	logger.Debug("Reconstructed private RWSet for", dig, "out of shards")
+	return rws
+}
+
 func (p *puller) gatherResponses(subscriptions []util.Subscription) []*proto.PvtDataElement {
 	var res []*proto.PvtDataElement
 	privateElements := make(chan *proto.PvtDataElement, len(subscriptions))
@@ -403,6 +526,8 @@ func (p *puller) assignDigestsToPeers(members []discovery.NetworkMember, dig2Fil
 type collectionRoutingFilter struct {
 	anyPeer       filter.RoutingFilter
 	preferredPeer filter.RoutingFilter
+	// rwSetHash is the hash of the private RWSet, if known
+	rwSetHash []byte
 }
 
 type digestToFilterMapping map[privdatacommon.DigKey]collectionRoutingFilter
@@ -453,7 +578,7 @@ func (p *puller) computeFilters(dig2src dig2sources) (digestToFilterMapping, err
 
 		sources := sources
 		endorserPeer, err := p.PeerFilter(common.ChainID(p.channel), func(peerSignature api.PeerSignature) bool {
-			for _, endorsement := range sources {
+			for _, endorsement := range sources.endorsements {
 				if bytes.Equal(endorsement.Endorser, []byte(peerSignature.PeerIdentity)) {
 					return true
 				}
@@ -467,6 +592,7 @@ func (p *puller) computeFilters(dig2src dig2sources) (digestToFilterMapping, err
 		filters[digest] = collectionRoutingFilter{
 			anyPeer:       anyPeerInCollection,
 			preferredPeer: endorserPeer,
+			rwSetHash:     sources.rwSetHash,
 		}
 	}
 	return filters, nil
@@ -14,6 +14,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -279,7 +280,7 @@ func (gn *gossipNetwork) newPullerWithMetrics(metrics *metrics.PrivdataMetrics,
 	g.network = gn
 	g.On("PeersOfChannel", mock.Anything).Return(knownMembers)
 
-	p := NewPuller(metrics, ps, g, &dataRetrieverMock{}, factory, "A", btlPullMarginDefault)
+	p := NewPuller(metrics, ps, g, &dataRetrieverMock{}, NewShardStore(newMemShardPersister()), factory, "A", btlPullMarginDefault)
 	gn.peers = append(gn.peers, g)
 	return p
 }
@@ -412,6 +413,128 @@ func TestPullerDataNotAvailable(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPullerReconstructsFromShards(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 pulls erasure-coded private data which none of p2 and p3 hold in full,
+	// but each of them holds one of its shards, and two shards suffice to reconstruct it
+	rwSet := []byte("private data disseminated in shards")
+	payloads := shardPayloads(t, "txID1", rwSet, 2, 1, 0)
+	p1, dig := setupShardHolders(t, map[string]*proto.PrivatePayload{
+		"p2": payloads[0],
+		"p3": payloads[2],
+	})
+
+	for _, rwSetHash := range [][]byte{nil, util2.ComputeSHA256(rwSet)} {
+		dasf := &digestsAndSourceFactory{}
+		fetchedMessages, err := p1.fetch(dasf.mapDigest(toDigKey(dig)).toSources().withHash(rwSetHash).create())
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{rwSet}, reconstructedPayload(t, fetchedMessages))
+	}
+}
+
+func TestPullerSkipsCorruptShards(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 pulls erasure-coded private data, each of p2, p3 and p4 holds one of its shards,
+	// but the shard p2 holds is corrupt. Whichever peers are asked first, p1 keeps pulling shards
+	// until it reconstructs private data that matches the hash of the private RWSet.
+	rwSet := []byte("private data disseminated in shards")
+	payloads := shardPayloads(t, "txID1", rwSet, 2, 1, 0)
+	corrupt := *payloads[0]
+	corrupt.PrivateRwset = append([]byte{}, corrupt.PrivateRwset...)
+	corrupt.PrivateRwset[0] ^= 0xff
+	p1, dig := setupShardHolders(t, map[string]*proto.PrivatePayload{
+		"p2": &corrupt,
+		"p3": payloads[1],
+		"p4": payloads[2],
+	})
+
+	dasf := &digestsAndSourceFactory{}
+	fetchedMessages, err := p1.fetch(dasf.mapDigest(toDigKey(dig)).toSources().withHash(util2.ComputeSHA256(rwSet)).create())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{rwSet}, reconstructedPayload(t, fetchedMessages))
+}
+
+func TestPullerRejectsMismatchingShards(t *testing.T) {
+	t.Parallel()
+	// Scenario: p1 pulls erasure-coded private data, but no subset of the shards
+	// held by p2 and p3 reconstructs private data matching the hash of the private RWSet
+	payloads := shardPayloads(t, "txID1", []byte("private data disseminated in shards"), 2, 1, 0)
+	p1, dig := setupShardHolders(t, map[string]*proto.PrivatePayload{
+		"p2": payloads[0],
+		"p3": payloads[2],
+	})
+
+	dasf := &digestsAndSourceFactory{}
+	fetchedMessages, err := p1.fetch(dasf.mapDigest(toDigKey(dig)).toSources().withHash(util2.ComputeSHA256([]byte("other private data"))).create())
+	assert.NoError(t, err)
+	assert.Nil(t, reconstructedPayload(t, fetchedMessages))
+}
+
+// setupShardHolders creates p1, and a peer for each of the given shards that holds it
+func setupShardHolders(t *testing.T, shards map[string]*proto.PrivatePayload) (*puller, *proto.PvtDataDigest) {
+	gn := &gossipNetwork{}
+	var holders []string
+	var members []peerData
+	for id := range shards {
+		holders = append(holders, id)
+		members = append(members, peerData{id, uint64(1)})
+	}
+	policyStore := newCollectionStore().withPolicy("col1", uint64(100)).thatMapsTo(holders...)
+	factoryMock := &collectionAccessFactoryMock{}
+	policyMock := &collectionAccessPolicyMock{}
+	policyMock.Setup(1, 2, func(data fcommon.SignedData) bool {
+		return bytes.Equal(data.Identity, []byte("p1"))
+	}, []string{"org1", "org2"}, false)
+	factoryMock.On("AccessPolicy", mock.Anything, mock.Anything).Return(policyMock, nil)
+
+	p1 := gn.newPuller("p1", policyStore, factoryMock, membership(members...)...)
+
+	dig := &proto.PvtDataDigest{
+		TxId:       "txID1",
+		Collection: "col1",
+		Namespace:  "ns1",
+	}
+	store := Dig2PvtRWSetWithConfig{
+		privdatacommon.DigKey{
+			TxId:       "txID1",
+			Collection: "col1",
+			Namespace:  "ns1",
+		}: &util.PrivateRWSetWithConfig{
+			RWSet: []util.PrivateRWSet{},
+		},
+	}
+	for id, payload := range shards {
+		payload.Namespace, payload.CollectionName = "ns1", "col1"
+		payload.CollectionConfigs = &fcommon.CollectionConfigPackage{
+			Config: []*fcommon.CollectionConfig{
+				{
+					Payload: &fcommon.CollectionConfig_StaticCollectionConfig{
+						StaticCollectionConfig: &fcommon.StaticCollectionConfig{
+							Name:          "col1",
+							ErasureCoding: &fcommon.ErasureCodingConfig{DataShards: 2, ParityShards: 1},
+						},
+					},
+				},
+			},
+		}
+		p := gn.newPuller(id, newCollectionStore().withPolicy("col1", uint64(100)).thatMapsTo("p1"), factoryMock)
+		assert.NoError(t, p.shards.Put(payload))
+		p.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", mock.MatchedBy(protoMatcher(dig)), mock.Anything).Return(store, false, nil)
+	}
+	return p1, dig
+}
+
+func reconstructedPayload(t *testing.T, fetched *privdatacommon.FetchedPvtDataContainer) [][]byte {
+	var reconstructed [][]byte
+	for _, el := range fetched.AvailableElements {
+		assert.Nil(t, el.Shard)
+		if len(el.Payload) != 0 {
+			reconstructed = el.Payload
+		}
+	}
+	return reconstructed
+}
+
 func TestPullerNoPeersKnown(t *testing.T) {
 	t.Parallel()
 	// Scenario: p1 doesn't know any peer and therefore fails fetching
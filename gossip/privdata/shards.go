@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/erasure"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+// ShardPersister persists the erasure-coded shards of private data.
+// It is implemented by the transient store, which purges the shards
+// along with the private data.
+type ShardPersister interface {
+	// PersistShard stores an erasure-coded shard of the private write set of a transaction
+	// based on txid and the block height the shard was received at
+	PersistShard(txid string, blockHeight uint64, shard *proto.PrivatePayload) error
+	// GetShards returns the erasure-coded shards of the private write sets of a transaction
+	GetShards(txid string) ([]*proto.PrivatePayload, error)
+}
+
+// ShardStore holds the erasure-coded shards of private data that the peer
+// received upon endorsement, until the corresponding blocks are committed.
+type ShardStore struct {
+	store ShardPersister
+}
+
+// NewShardStore creates a ShardStore that keeps shards in the given ShardPersister
+func NewShardStore(store ShardPersister) *ShardStore {
+	return &ShardStore{
+		store: store,
+	}
+}
+
+// Put stores the shard carried by the given private payload
+func (s *ShardStore) Put(payload *proto.PrivatePayload) error {
+	if err := validateShard(payload.Shard, len(payload.PrivateRwset)); err != nil {
+		return err
+	}
+	return s.store.PersistShard(payload.TxId, payload.PrivateSimHeight, payload)
+}
+
+// Get returns the private payload carrying the shard of the given
+// transaction, namespace and collection, or nil if there is none
+func (s *ShardStore) Get(txID, namespace, collection string) (*proto.PrivatePayload, error) {
+	payloads, err := s.store.GetShards(txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed retrieving shards of txID %s", txID))
+	}
+	for _, payload := range payloads {
+		if payload.Namespace == namespace && payload.CollectionName == collection {
+			return payload, nil
+		}
+	}
+	return nil, nil
+}
+
+func validateShard(shard *proto.ErasureShard, shardSize int) error {
+	if shard == nil {
+		return errors.New("no shard provided")
+	}
+	if err := erasure.Validate(int(shard.DataShards), int(shard.ParityShards)); err != nil {
+		return errors.WithMessage(err, "invalid shard")
+	}
+	if shard.Index >= shard.DataShards+shard.ParityShards {
+		return errors.Errorf("invalid shard: index %d is out of range", shard.Index)
+	}
+	if shard.Size > uint64(shardSize)*uint64(shard.DataShards) {
+		return errors.Errorf("invalid shard: size %d exceeds the capacity of the shards", shard.Size)
+	}
+	return nil
+}
+
+// maxReconstructionAttempts bounds the number of shard subsets tried
+// when reconstructing a private rwset out of its shards
+const maxReconstructionAttempts = 64
+
+// shardCollector accumulates the shards of a private rwset
+// until it can be reconstructed out of them
+type shardCollector struct {
+	params *proto.ErasureShard
+	shards [][]byte
+	count  uint32
+}
+
+func newShardCollector(params *proto.ErasureShard) *shardCollector {
+	return &shardCollector{
+		params: params,
+		shards: make([][]byte, params.DataShards+params.ParityShards),
+	}
+}
+
+// add adds the given shard, and returns an error if it
+// doesn't belong to the private rwset being collected
+func (sc *shardCollector) add(shard *proto.ErasureShard, data []byte) error {
+	if err := validateShard(shard, len(data)); err != nil {
+		return err
+	}
+	if shard.DataShards != sc.params.DataShards || shard.ParityShards != sc.params.ParityShards || shard.Size != sc.params.Size {
+		return errors.Errorf("shard parameters %v do not match %v", shard, sc.params)
+	}
+	if sc.shards[shard.Index] == nil {
+		sc.shards[shard.Index] = data
+		sc.count++
+	}
+	return nil
+}
+
+// complete returns whether enough shards were
+// collected to reconstruct the private rwset
+func (sc *shardCollector) complete() bool {
+	return sc.count >= sc.params.DataShards
+}
+
+// reconstruct reconstructs the private rwset out of the collected shards.
+// If verify is non-nil, subsets of the collected shards are tried until the
+// reconstructed private rwset passes verification, so that a corrupt shard
+// doesn't prevent reconstruction once enough intact shards are collected.
+func (sc *shardCollector) reconstruct(verify func([]byte) bool) ([]byte, error) {
+	dataShards := int(sc.params.DataShards)
+	decode := func(shards [][]byte) ([]byte, error) {
+		return erasure.Decode(shards, dataShards, int(sc.params.ParityShards), int(sc.params.Size))
+	}
+	if verify == nil {
+		return decode(sc.shards)
+	}
+
+	var available []int
+	for i, shard := range sc.shards {
+		if shard != nil {
+			available = append(available, i)
+		}
+	}
+	if len(available) < dataShards {
+		return nil, errors.Errorf("%d shards are needed for reconstruction, only %d are available", dataShards, len(available))
+	}
+
+	// Try the subsets of dataShards shards out of the available ones, in lexicographic order
+	subset := make([]int, dataShards)
+	for i := range subset {
+		subset[i] = i
+	}
+	for attempt := 0; attempt < maxReconstructionAttempts; attempt++ {
+		shards := make([][]byte, len(sc.shards))
+		for _, i := range subset {
+			shards[available[i]] = sc.shards[available[i]]
+		}
+		if data, err := decode(shards); err == nil && verify(data) {
+			return data, nil
+		}
+		// Advance to the next subset
+		i := dataShards - 1
+		for i >= 0 && subset[i] == len(available)-dataShards+i {
+			i--
+		}
+		if i < 0 {
+			break
+		}
+		subset[i]++
+		for j := i + 1; j < dataShards; j++ {
+			subset[j] = subset[j-1] + 1
+		}
+	}
+	return nil, errors.New("no subset of the collected shards reconstructs the expected private rwset")
+}
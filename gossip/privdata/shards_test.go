@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/erasure"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func shardPayloads(t *testing.T, txID string, data []byte, dataShards, parityShards int, height uint64) []*proto.PrivatePayload {
+	shards, err := erasure.Encode(data, dataShards, parityShards)
+	require.NoError(t, err)
+	var payloads []*proto.PrivatePayload
+	for i, shard := range shards {
+		payloads = append(payloads, &proto.PrivatePayload{
+			TxId:             txID,
+			Namespace:        "ns1",
+			CollectionName:   "c1",
+			PrivateRwset:     shard,
+			PrivateSimHeight: height,
+			Shard: &proto.ErasureShard{
+				Index:        uint32(i),
+				DataShards:   uint32(dataShards),
+				ParityShards: uint32(parityShards),
+				Size:         uint64(len(data)),
+			},
+		})
+	}
+	return payloads
+}
+
+// memShardPersister is an in-memory ShardPersister
+type memShardPersister struct {
+	lock   sync.Mutex
+	shards map[string][]*proto.PrivatePayload
+	err    error
+}
+
+func newMemShardPersister() *memShardPersister {
+	return &memShardPersister{
+		shards: make(map[string][]*proto.PrivatePayload),
+	}
+}
+
+func (p *memShardPersister) PersistShard(txid string, blockHeight uint64, shard *proto.PrivatePayload) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.shards[txid] = append(p.shards[txid], shard)
+	return nil
+}
+
+func (p *memShardPersister) GetShards(txid string) ([]*proto.PrivatePayload, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.shards[txid], nil
+}
+
+func TestShardStore(t *testing.T) {
+	persister := newMemShardPersister()
+	store := NewShardStore(persister)
+	tx1 := shardPayloads(t, "tx1", []byte("private data of tx1"), 2, 1, 5)
+	tx2 := shardPayloads(t, "tx2", []byte("private data of tx2"), 2, 1, 10)
+
+	assert.NoError(t, store.Put(tx1[0]))
+	assert.NoError(t, store.Put(tx2[1]))
+	assert.Equal(t, []*proto.PrivatePayload{tx1[0]}, persister.shards["tx1"])
+
+	for _, tc := range []struct {
+		txID, collection string
+		expected         *proto.PrivatePayload
+	}{
+		{txID: "tx1", collection: "c1", expected: tx1[0]},
+		{txID: "tx2", collection: "c1", expected: tx2[1]},
+		{txID: "tx1", collection: "c2"},
+		{txID: "tx3", collection: "c1"},
+	} {
+		payload, err := store.Get(tc.txID, "ns1", tc.collection)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, payload)
+	}
+
+	persister.err = errors.New("disk is full")
+	assert.EqualError(t, store.Put(tx1[1]), "disk is full")
+	_, err := store.Get("tx1", "ns1", "c1")
+	assert.EqualError(t, err, "failed retrieving shards of txID tx1: disk is full")
+}
+
+func TestShardStoreInvalidShards(t *testing.T) {
+	persister := newMemShardPersister()
+	store := NewShardStore(persister)
+	payload := shardPayloads(t, "tx1", []byte("private data"), 2, 1, 5)[0]
+
+	noShard := *payload
+	noShard.Shard = nil
+	assert.EqualError(t, store.Put(&noShard), "no shard provided")
+
+	outOfRange := *payload
+	outOfRange.Shard = &proto.ErasureShard{Index: 3, DataShards: 2, ParityShards: 1, Size: 12}
+	assert.EqualError(t, store.Put(&outOfRange), "invalid shard: index 3 is out of range")
+
+	tooLarge := *payload
+	tooLarge.Shard = &proto.ErasureShard{Index: 0, DataShards: 2, ParityShards: 1, Size: 13}
+	assert.EqualError(t, store.Put(&tooLarge), "invalid shard: size 13 exceeds the capacity of the shards")
+
+	noDataShards := *payload
+	noDataShards.Shard = &proto.ErasureShard{Index: 0, DataShards: 0, ParityShards: 1}
+	assert.EqualError(t, store.Put(&noDataShards), "invalid shard: number of data shards must be positive, got 0")
+
+	assert.Empty(t, persister.shards)
+}
+
+func TestShardCollector(t *testing.T) {
+	data := []byte("private data to be reconstructed")
+	payloads := shardPayloads(t, "tx1", data, 3, 2, 5)
+
+	sc := newShardCollector(payloads[4].Shard)
+	assert.False(t, sc.complete())
+	require.NoError(t, sc.add(payloads[4].Shard, payloads[4].PrivateRwset))
+	// Adding the same shard twice doesn't count twice
+	require.NoError(t, sc.add(payloads[4].Shard, payloads[4].PrivateRwset))
+	require.NoError(t, sc.add(payloads[1].Shard, payloads[1].PrivateRwset))
+	assert.False(t, sc.complete())
+
+	// Shards of a different encoding are rejected
+	other := shardPayloads(t, "tx1", data, 2, 2, 5)[0]
+	assert.Error(t, sc.add(other.Shard, other.PrivateRwset))
+	assert.False(t, sc.complete())
+
+	require.NoError(t, sc.add(payloads[3].Shard, payloads[3].PrivateRwset))
+	assert.True(t, sc.complete())
+	reconstructed, err := sc.reconstruct(nil)
+	require.NoError(t, err)
+	assert.Equal(t, data, reconstructed)
+}
+
+func TestShardCollectorVerifiesReconstruction(t *testing.T) {
+	data := []byte("private data to be reconstructed")
+	payloads := shardPayloads(t, "tx1", data, 2, 2, 5)
+	verify := func(rws []byte) bool {
+		return bytes.Equal(rws, data)
+	}
+
+	sc := newShardCollector(payloads[0].Shard)
+	corrupt := append([]byte{}, payloads[0].PrivateRwset...)
+	corrupt[0] ^= 0xff
+	require.NoError(t, sc.add(payloads[0].Shard, corrupt))
+	require.NoError(t, sc.add(payloads[2].Shard, payloads[2].PrivateRwset))
+	assert.True(t, sc.complete())
+
+	// Without verification, the corrupt shard yields corrupt private data
+	reconstructed, err := sc.reconstruct(nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, data, reconstructed)
+
+	// With verification, no subset of the shards passes it yet
+	_, err = sc.reconstruct(verify)
+	assert.EqualError(t, err, "no subset of the collected shards reconstructs the expected private rwset")
+
+	// Once another intact shard is collected, the corrupt shard is left out
+	require.NoError(t, sc.add(payloads[3].Shard, payloads[3].PrivateRwset))
+	reconstructed, err = sc.reconstruct(verify)
+	require.NoError(t, err)
+	assert.Equal(t, data, reconstructed)
+}
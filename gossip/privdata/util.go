@@ -296,7 +296,20 @@ func (f *digestsAndSourceFactory) toSources(peers ...string) *digestsAndSourceFa
 			Endorser: []byte(p),
 		})
 	}
-	f.d2s[*f.lastDig] = endorsements
+	f.d2s[*f.lastDig] = digestSources{
+		endorsements: endorsements,
+		rwSetHash:    f.d2s[*f.lastDig].rwSetHash,
+	}
+	return f
+}
+
+func (f *digestsAndSourceFactory) withHash(rwSetHash []byte) *digestsAndSourceFactory {
+	if f.d2s == nil {
+		f.d2s = make(dig2sources)
+	}
+	sources := f.d2s[*f.lastDig]
+	sources.rwSetHash = rwSetHash
+	f.d2s[*f.lastDig] = sources
 	return f
 }
 
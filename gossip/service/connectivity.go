@@ -0,0 +1,326 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// DefConnectivityCheckInterval is the default time between
+// consecutive connectivity checks
+const DefConnectivityCheckInterval = 5 * time.Minute
+
+// ConnectivityConfig configures the periodic validation of the
+// gossip endpoints the peer depends on
+type ConnectivityConfig struct {
+	// Interval is the time between consecutive checks
+	Interval time.Duration
+	// DialTimeout is the timeout of TLS connection attempts
+	DialTimeout time.Duration
+	// TLSEnabled indicates whether peers communicate over TLS
+	TLSEnabled bool
+}
+
+// ConnectivityCheckProvider creates ConnectivityCheckers
+type ConnectivityCheckProvider interface {
+	// NewConnectivityChecker returns a ConnectivityChecker which validates
+	// the external endpoint and the anchor peers of the peer
+	NewConnectivityChecker() (*ConnectivityChecker, error)
+}
+
+// ConnectivityChecker periodically validates that the advertised external
+// endpoint of the peer and the anchor peers of its channels are reachable,
+// and that they are served by the expected peers
+type ConnectivityChecker struct {
+	config      ConnectivityConfig
+	prober      gossip.Prober
+	mcs         api.MessageCryptoService
+	secAdv      api.SecurityAdvisor
+	myOrg       string
+	self        func() discovery.NetworkMember
+	anchorPeers func() map[string]map[string][]api.AnchorPeer
+
+	lock                sync.RWMutex
+	externalEndpointErr error
+	anchorPeersErr      error
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+// NewConnectivityChecker returns a ConnectivityChecker which validates
+// the external endpoint and the anchor peers of the peer
+func (g *gossipServiceImpl) NewConnectivityChecker() (*ConnectivityChecker, error) {
+	return g.newConnectivityChecker(getConnectivityConfiguration())
+}
+
+func (g *gossipServiceImpl) newConnectivityChecker(config ConnectivityConfig) (*ConnectivityChecker, error) {
+	prober, ok := g.gossipSvc.(gossip.Prober)
+	if !ok {
+		return nil, errors.New("gossip component does not support probing remote endpoints")
+	}
+	return &ConnectivityChecker{
+		config:      config,
+		prober:      prober,
+		mcs:         g.mcs,
+		secAdv:      g.secAdv,
+		myOrg:       string(g.secAdv.OrgByPeerIdentity(api.PeerIdentityType(g.peerIdentity))),
+		self:        g.SelfMembershipInfo,
+		anchorPeers: g.channelAnchorPeers,
+		stopChan:    make(chan struct{}),
+	}, nil
+}
+
+// channelAnchorPeers returns the anchor peers of the organizations
+// of each channel the peer joined
+func (g *gossipServiceImpl) channelAnchorPeers() map[string]map[string][]api.AnchorPeer {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	anchorPeers := make(map[string]map[string][]api.AnchorPeer, len(g.anchorPeers))
+	for chainID, orgs := range g.anchorPeers {
+		anchorPeers[chainID] = orgs
+	}
+	return anchorPeers
+}
+
+// Start checks the endpoints right away, and then periodically
+// until Stop is called. The checks are performed in the background.
+func (cc *ConnectivityChecker) Start() {
+	go func() {
+		cc.Check()
+		ticker := time.NewTicker(cc.config.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				cc.Check()
+			case <-cc.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic checks
+func (cc *ConnectivityChecker) Stop() {
+	cc.stopOnce.Do(func() {
+		close(cc.stopChan)
+	})
+}
+
+// Check validates the external endpoint and the anchor peers,
+// and records the results
+func (cc *ConnectivityChecker) Check() {
+	externalEndpointErr := cc.checkExternalEndpoint()
+	if externalEndpointErr != nil {
+		logger.Warningf("External endpoint check failed: %v", externalEndpointErr)
+	}
+	anchorPeersErr := cc.checkAnchorPeers()
+	if anchorPeersErr != nil {
+		logger.Warningf("Anchor peers check failed: %v", anchorPeersErr)
+	}
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	cc.externalEndpointErr = externalEndpointErr
+	cc.anchorPeersErr = anchorPeersErr
+}
+
+// ExternalEndpoint returns a health checker which reports the result
+// of the latest check of the external endpoint
+func (cc *ConnectivityChecker) ExternalEndpoint() healthz.HealthChecker {
+	return healthCheckerFunc(func(context.Context) error {
+		cc.lock.RLock()
+		defer cc.lock.RUnlock()
+		return cc.externalEndpointErr
+	})
+}
+
+// AnchorPeers returns a health checker which reports the result
+// of the latest check of the anchor peers
+func (cc *ConnectivityChecker) AnchorPeers() healthz.HealthChecker {
+	return healthCheckerFunc(func(context.Context) error {
+		cc.lock.RLock()
+		defer cc.lock.RUnlock()
+		return cc.anchorPeersErr
+	})
+}
+
+type healthCheckerFunc func(ctx context.Context) error
+
+func (f healthCheckerFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+func (cc *ConnectivityChecker) checkExternalEndpoint() error {
+	self := cc.self()
+	if self.Endpoint == "" {
+		return nil
+	}
+	return cc.checkEndpoint(self.Endpoint, func(identity api.PeerIdentityType) error {
+		pkiID := cc.mcs.GetPKIidOfCert(identity)
+		if !bytes.Equal(pkiID, self.PKIid) {
+			return errors.Errorf("wrong PKI ID: external endpoint %s is served by a peer with PKI-ID %s, expected %s",
+				self.Endpoint, hex.EncodeToString(pkiID), hex.EncodeToString(self.PKIid))
+		}
+		return nil
+	})
+}
+
+// checkAnchorPeers checks the anchor peers of the channels. Only the anchor peers
+// of the organization of the peer fail the check, as the anchor peers of other
+// organizations are out of the control of its operators. The connectivity to
+// them is reported as degraded in the log instead.
+func (cc *ConnectivityChecker) checkAnchorPeers() error {
+	self := cc.self()
+	checked := make(map[string]error)
+	var failures, degraded []string
+
+	anchorPeers := cc.anchorPeers()
+	chainIDs := make([]string, 0, len(anchorPeers))
+	for chainID := range anchorPeers {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+	for _, chainID := range chainIDs {
+		orgs := anchorPeers[chainID]
+		orgNames := make([]string, 0, len(orgs))
+		for org := range orgs {
+			orgNames = append(orgNames, org)
+		}
+		sort.Strings(orgNames)
+		for _, org := range orgNames {
+			// Gossip only connects to anchor peers of other organizations
+			// if the peer has an external endpoint
+			if org != cc.myOrg && self.Endpoint == "" {
+				continue
+			}
+			for _, ap := range orgs[org] {
+				endpoint := net.JoinHostPort(ap.Host, strconv.Itoa(ap.Port))
+				if endpoint == self.Endpoint || endpoint == self.InternalEndpoint {
+					continue
+				}
+				err, exists := checked[endpoint]
+				if !exists {
+					err = cc.checkEndpoint(endpoint, func(identity api.PeerIdentityType) error {
+						if actual := string(cc.secAdv.OrgByPeerIdentity(identity)); actual != org {
+							return errors.Errorf("anchor peer %s belongs to organization %s, expected %s", endpoint, actual, org)
+						}
+						return nil
+					})
+					checked[endpoint] = err
+				}
+				if err == nil {
+					continue
+				}
+				failure := fmt.Sprintf("channel %s: %v", chainID, err)
+				if org == cc.myOrg {
+					failures = append(failures, failure)
+				} else {
+					degraded = append(degraded, failure)
+				}
+			}
+		}
+	}
+	if len(degraded) > 0 {
+		logger.Warningf("Connectivity to anchor peers of other organizations is degraded: %s", strings.Join(degraded, "; "))
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// checkEndpoint validates the TLS certificate of the given endpoint,
+// authenticates it, and verifies its identity with the expect function
+func (cc *ConnectivityChecker) checkEndpoint(endpoint string, expect func(identity api.PeerIdentityType) error) error {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return errors.Wrapf(err, "invalid endpoint %s", endpoint)
+	}
+	if cc.config.TLSEnabled {
+		if err := cc.checkTLSName(endpoint, host); err != nil {
+			return err
+		}
+	}
+	if err := cc.prober.Probe(endpoint); err != nil {
+		return errors.Errorf("%s is unreachable: %v", endpoint, err)
+	}
+	identity, err := cc.prober.Handshake(endpoint)
+	if err != nil {
+		return errors.Errorf("handshake with %s failed: %v", endpoint, err)
+	}
+	return expect(identity)
+}
+
+// checkTLSName verifies that the TLS certificate served at the given
+// endpoint is valid for the host the endpoint is advertised with
+func (cc *ConnectivityChecker) checkTLSName(endpoint, host string) error {
+	var leaf *x509.Certificate
+	dialer := &net.Dialer{Timeout: cc.config.DialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", endpoint, &tls.Config{
+		// The certificate chain is validated by the gossip handshake,
+		// only the name the certificate is issued for is checked here
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			leaf = cert
+			return nil
+		},
+	})
+	if conn != nil {
+		conn.Close()
+	}
+	if leaf == nil {
+		if err == nil {
+			err = errors.New("no TLS certificate presented")
+		}
+		return errors.Errorf("%s is unreachable: %v", endpoint, err)
+	}
+	if err := leaf.VerifyHostname(host); err != nil {
+		names := append([]string{}, leaf.DNSNames...)
+		for _, ip := range leaf.IPAddresses {
+			names = append(names, ip.String())
+		}
+		return errors.Errorf("TLS name mismatch: the TLS certificate of %s is valid for %v, not %s", endpoint, names, host)
+	}
+	return nil
+}
+
+func getConnectivityConfiguration() ConnectivityConfig {
+	return ConnectivityConfig{
+		Interval:    util.GetDurationOrDefault("peer.gossip.connectivityCheck.interval", DefConnectivityCheckInterval),
+		DialTimeout: util.GetDurationOrDefault("peer.gossip.dialTimeout", comm.DefConnTimeout),
+		TLSEnabled:  viper.GetBool("peer.tls.enabled"),
+	}
+}
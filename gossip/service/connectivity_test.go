@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockProber struct {
+	sync.Mutex
+	unreachable map[string]bool
+	identities  map[string]api.PeerIdentityType
+}
+
+func (p *mockProber) Probe(endpoint string) error {
+	p.Lock()
+	defer p.Unlock()
+	if p.unreachable[endpoint] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (p *mockProber) Handshake(endpoint string) (api.PeerIdentityType, error) {
+	p.Lock()
+	defer p.Unlock()
+	identity, exists := p.identities[endpoint]
+	if !exists {
+		return nil, errors.New("authentication failed")
+	}
+	return identity, nil
+}
+
+func newTestConnectivityChecker(prober *mockProber, self discovery.NetworkMember, anchorPeers map[string]map[string][]api.AnchorPeer) *ConnectivityChecker {
+	return &ConnectivityChecker{
+		config: ConnectivityConfig{Interval: time.Hour, DialTimeout: time.Second},
+		prober: prober,
+		mcs:    &naiveCryptoService{},
+		secAdv: &secAdvMock{},
+		myOrg:  "Org1MSP",
+		self: func() discovery.NetworkMember {
+			return self
+		},
+		anchorPeers: func() map[string]map[string][]api.AnchorPeer {
+			return anchorPeers
+		},
+		stopChan: make(chan struct{}),
+	}
+}
+
+func TestConnectivityCheckerExternalEndpoint(t *testing.T) {
+	self := discovery.NetworkMember{Endpoint: "peer0:7051", PKIid: common.PKIidType("Org1MSP")}
+	prober := &mockProber{
+		unreachable: map[string]bool{},
+		identities:  map[string]api.PeerIdentityType{"peer0:7051": api.PeerIdentityType("Org1MSP")},
+	}
+	cc := newTestConnectivityChecker(prober, self, nil)
+
+	// No check was performed yet
+	assert.NoError(t, cc.ExternalEndpoint().HealthCheck(context.Background()))
+
+	cc.Check()
+	assert.NoError(t, cc.ExternalEndpoint().HealthCheck(context.Background()))
+
+	prober.identities["peer0:7051"] = api.PeerIdentityType("Org2MSP")
+	cc.Check()
+	assert.EqualError(t, cc.ExternalEndpoint().HealthCheck(context.Background()),
+		"wrong PKI ID: external endpoint peer0:7051 is served by a peer with PKI-ID 4f7267324d5350, expected 4f7267314d5350")
+
+	delete(prober.identities, "peer0:7051")
+	cc.Check()
+	assert.EqualError(t, cc.ExternalEndpoint().HealthCheck(context.Background()),
+		"handshake with peer0:7051 failed: authentication failed")
+
+	prober.unreachable["peer0:7051"] = true
+	cc.Check()
+	assert.EqualError(t, cc.ExternalEndpoint().HealthCheck(context.Background()),
+		"peer0:7051 is unreachable: connection refused")
+
+	// Peers without an external endpoint have nothing to check
+	cc = newTestConnectivityChecker(prober, discovery.NetworkMember{}, nil)
+	cc.Check()
+	assert.NoError(t, cc.ExternalEndpoint().HealthCheck(context.Background()))
+}
+
+func TestConnectivityCheckerAnchorPeers(t *testing.T) {
+	anchorPeers := map[string]map[string][]api.AnchorPeer{
+		"A": {
+			"Org1MSP": {{Host: "peer0", Port: 7051}, {Host: "peer1", Port: 7051}},
+			"Org2MSP": {{Host: "peer2", Port: 7051}},
+		},
+		"B": {
+			"Org1MSP": {{Host: "peer1", Port: 7051}},
+			"Org2MSP": {{Host: "peer3", Port: 7051}},
+		},
+	}
+	prober := &mockProber{
+		unreachable: map[string]bool{"peer3:7051": true},
+		identities: map[string]api.PeerIdentityType{
+			"peer0:7051": api.PeerIdentityType("Org1MSP"),
+			"peer1:7051": api.PeerIdentityType("Org3MSP"),
+			"peer2:7051": api.PeerIdentityType("Org2MSP"),
+		},
+	}
+	self := discovery.NetworkMember{Endpoint: "peer0:7051", PKIid: common.PKIidType("Org1MSP")}
+	cc := newTestConnectivityChecker(prober, self, anchorPeers)
+	cc.Check()
+	// The unreachable anchor peer of Org2MSP only degrades connectivity
+	assert.EqualError(t, cc.AnchorPeers().HealthCheck(context.Background()),
+		"channel A: anchor peer peer1:7051 belongs to organization Org3MSP, expected Org1MSP; "+
+			"channel B: anchor peer peer1:7051 belongs to organization Org3MSP, expected Org1MSP")
+
+	prober.identities["peer1:7051"] = api.PeerIdentityType("Org1MSP")
+	cc.Check()
+	assert.NoError(t, cc.AnchorPeers().HealthCheck(context.Background()))
+
+	// Anchor peers of other organizations are not checked
+	// if the peer has no external endpoint
+	cc = newTestConnectivityChecker(prober, discovery.NetworkMember{InternalEndpoint: "peer0:7051"}, anchorPeers)
+	prober.identities["peer2:7051"] = api.PeerIdentityType("Org3MSP")
+	cc.Check()
+	assert.NoError(t, cc.AnchorPeers().HealthCheck(context.Background()))
+}
+
+func TestConnectivityCheckerTLSNameMismatch(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverPair, err := ca.NewServerCertKeyPair("peer0.org1.example.com")
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(serverPair.Cert, serverPair.Key)
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	endpoint := listener.Addr().String()
+	prober := &mockProber{
+		identities: map[string]api.PeerIdentityType{endpoint: api.PeerIdentityType("Org1MSP")},
+	}
+	self := discovery.NetworkMember{Endpoint: endpoint, PKIid: common.PKIidType("Org1MSP")}
+	cc := newTestConnectivityChecker(prober, self, nil)
+	cc.config.TLSEnabled = true
+	cc.Check()
+	assert.EqualError(t, cc.ExternalEndpoint().HealthCheck(context.Background()),
+		"TLS name mismatch: the TLS certificate of "+endpoint+" is valid for [peer0.org1.example.com], not 127.0.0.1")
+
+	listener.Close()
+	cc.Check()
+	err = cc.ExternalEndpoint().HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), endpoint+" is unreachable")
+}
+
+func TestConnectivityCheckerStartStop(t *testing.T) {
+	prober := &mockProber{unreachable: map[string]bool{"peer0:7051": true}}
+	cc := newTestConnectivityChecker(prober, discovery.NetworkMember{Endpoint: "peer0:7051"}, nil)
+	cc.config.Interval = 10 * time.Millisecond
+	cc.Start()
+	defer cc.Stop()
+	deadline := time.Now().Add(5 * time.Second)
+	for cc.ExternalEndpoint().HealthCheck(context.Background()) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("External endpoint wasn't checked in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	prober.Lock()
+	prober.unreachable = map[string]bool{}
+	prober.identities = map[string]api.PeerIdentityType{"peer0:7051": nil}
+	prober.Unlock()
+	deadline = time.Now().Add(5 * time.Second)
+	for cc.ExternalEndpoint().HealthCheck(context.Background()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("External endpoint wasn't checked again in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestNewConnectivityChecker(t *testing.T) {
+	g := &gossipServiceImpl{gossipSvc: &gossipMock{}, secAdv: &secAdvMock{}}
+	_, err := g.NewConnectivityChecker()
+	assert.EqualError(t, err, "gossip component does not support probing remote endpoints")
+
+	g.anchorPeers = map[string]map[string][]api.AnchorPeer{
+		"A": {"Org1MSP": {{Host: "peer0", Port: 7051}}},
+	}
+	anchorPeers := g.channelAnchorPeers()
+	assert.Equal(t, g.anchorPeers, anchorPeers)
+}
+
+func TestConnectivityCheckerWithGossipComm(t *testing.T) {
+	// Scenario: Two peers run real gossip instances, and the first peer
+	// checks its endpoints and anchor peers through its gossip comm layer
+	peers := startPeers(t, 2)
+	defer stopPeers(peers)
+
+	g0 := peers[0].(*gossipGRPC).gossipServiceImpl
+	g1 := peers[1].(*gossipGRPC).gossipServiceImpl
+	g0.secAdv = &secAdvMock{}
+	// The identities of the peers are their internal endpoints
+	endpoint0, endpoint1 := string(g0.peerIdentity), string(g1.peerIdentity)
+	pkiID0 := g0.gossipSvc.SelfMembershipInfo().PKIid
+
+	cc, err := g0.newConnectivityChecker(ConnectivityConfig{Interval: time.Hour, DialTimeout: time.Second, TLSEnabled: true})
+	require.NoError(t, err)
+	// Both peers are organizations of their own, as the secAdvMock
+	// maps identities to organizations verbatim
+	assert.Equal(t, string(g0.peerIdentity), cc.myOrg)
+
+	// The external endpoint is served by the peer itself
+	cc.self = func() discovery.NetworkMember {
+		return discovery.NetworkMember{
+			Endpoint:         endpoint0,
+			InternalEndpoint: endpoint0,
+			PKIid:            pkiID0,
+		}
+	}
+	cc.Check()
+	assert.NoError(t, cc.ExternalEndpoint().HealthCheck(context.Background()))
+	assert.NoError(t, cc.AnchorPeers().HealthCheck(context.Background()))
+
+	// The external endpoint is served by the other peer
+	cc.self = func() discovery.NetworkMember {
+		return discovery.NetworkMember{
+			Endpoint:         endpoint1,
+			InternalEndpoint: endpoint0,
+			PKIid:            pkiID0,
+		}
+	}
+	cc.Check()
+	err = cc.ExternalEndpoint().HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wrong PKI ID")
+
+	closedPort := func() int {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+		return l.Addr().(*net.TCPAddr).Port
+	}()
+	host, port1, err := net.SplitHostPort(endpoint1)
+	require.NoError(t, err)
+	p1, err := strconv.Atoi(port1)
+	require.NoError(t, err)
+
+	cc.self = func() discovery.NetworkMember {
+		return discovery.NetworkMember{
+			Endpoint:         endpoint0,
+			InternalEndpoint: endpoint0,
+			PKIid:            pkiID0,
+		}
+	}
+	org0, org1 := endpoint0, endpoint1
+	g0.lock.Lock()
+	g0.anchorPeers = map[string]map[string][]api.AnchorPeer{
+		"A": {
+			org0: {{Host: host, Port: p1}},
+			org1: {{Host: host, Port: p1}, {Host: host, Port: closedPort}},
+		},
+	}
+	g0.lock.Unlock()
+
+	// The anchor peer of the organization of the peer is served by
+	// a peer of another organization, while the unreachable anchor peer
+	// of the other organization only degrades connectivity
+	cc.Check()
+	err = cc.AnchorPeers().HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, fmt.Sprintf("channel A: anchor peer %s belongs to organization %s, expected %s",
+		endpoint1, org1, org0), err.Error())
+
+	g0.lock.Lock()
+	g0.anchorPeers["A"] = map[string][]api.AnchorPeer{
+		org1: {{Host: host, Port: p1}, {Host: host, Port: closedPort}},
+	}
+	g0.lock.Unlock()
+	cc.Check()
+	assert.NoError(t, cc.AnchorPeers().HealthCheck(context.Background()))
+}
+
+func TestUpdateAnchorsCopiesAnchorPeers(t *testing.T) {
+	// Scenario: The join channel message passed to gossip is modified after
+	// the anchor peers are updated, which must not affect the anchor peers
+	// the connectivity checker validates
+	joined := make(chan struct{})
+	gMock := &gossipMock{}
+	gMock.On("JoinChan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		jcm := args.Get(0).(*joinChannelMessage)
+		jcm.members2AnchorPeers["Org0"] = append(jcm.members2AnchorPeers["Org0"], api.AnchorPeer{Host: "peer0", Port: 7051})
+		delete(jcm.members2AnchorPeers, "Org1")
+		close(joined)
+	})
+
+	g := &gossipServiceImpl{secAdv: &secAdvMock{}, peerIdentity: api.PeerIdentityType("Org0"), gossipSvc: gMock}
+	g.updateAnchors(&configMock{
+		orgs2AppOrgs: map[string]channelconfig.ApplicationOrg{
+			"Org0": &appOrgMock{id: "Org0"},
+			"Org1": &appOrgMock{id: "Org1"},
+		},
+	})
+	<-joined
+
+	anchorPeers := g.channelAnchorPeers()["A"]
+	assert.Len(t, anchorPeers, 2)
+	assert.Empty(t, anchorPeers["Org0"])
+	assert.Contains(t, anchorPeers, "Org1")
+}
@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"crypto/tls"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/metrics"
+	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/committer"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
@@ -108,6 +110,9 @@ type gossipServiceImpl struct {
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
 	metrics         *gossipMetrics.GossipMetrics
+	stateServer     *state.StreamServer
+	blockStreamer   state.BlockStreamer
+	anchorPeers     map[string]map[string][]api.AnchorPeer
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -168,6 +173,8 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, metricsProvider
 
 		gossip, err = integration.NewGossipComponent(peerIdentity, endpoint, s, secAdv,
 			mcs, secureDialOpts, certs, gossipMetrics, bootPeers...)
+		stateServer := state.NewStreamServer(mcs, getStreamServerConfiguration())
+		gproto.RegisterStateTransferServer(s, stateServer)
 		gossipServiceInstance = &gossipServiceImpl{
 			mcs:             mcs,
 			gossipSvc:       gossip,
@@ -179,6 +186,9 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, metricsProvider
 			peerIdentity:    peerIdentity,
 			secAdv:          secAdv,
 			metrics:         gossipMetrics,
+			stateServer:     stateServer,
+			blockStreamer: state.NewStreamClient(mcs, peerIdentity, tlsCertHash(certs),
+				secureDialOpts, getStreamClientConfiguration()),
 		}
 	})
 	return errors.WithStack(err)
@@ -240,7 +250,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 	defer g.lock.Unlock()
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", chainID)
-	servicesAdapter := &state.ServicesMediator{GossipAdapter: g, MCSAdapter: g.mcs}
+	servicesAdapter := &state.ServicesMediator{GossipAdapter: g, MCSAdapter: g.mcs, BlockStreamer: g.blockStreamer}
 
 	// Embed transient store and committer APIs to fulfill
 	// DataStore interface to capture ability of retrieving
@@ -252,8 +262,9 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 	// Initialize private data fetcher
 	dataRetriever := privdata2.NewDataRetriever(storeSupport)
 	collectionAccessFactory := privdata2.NewCollectionAccessFactory(support.IdDeserializeFactory)
+	shardStore := privdata2.NewShardStore(support.Store)
 	fetcher := privdata2.NewPuller(g.metrics.PrivdataMetrics, support.Cs, g.gossipSvc, dataRetriever,
-		collectionAccessFactory, chainID, privdata2.GetBtlPullMargin())
+		shardStore, collectionAccessFactory, chainID, privdata2.GetBtlPullMargin())
 
 	coordinatorConfig := privdata2.CoordinatorConfig{
 		TransientBlockRetention:        privdata2.GetTransientBlockRetention(),
@@ -269,6 +280,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 		Committer:          support.Committer,
 		Fetcher:            fetcher,
 		CapabilityProvider: support.CapabilityProvider,
+		ShardStore:         shardStore,
 	}, g.createSelfSignedData(), g.metrics.PrivdataMetrics, coordinatorConfig)
 
 	reconcilerConfig := privdata2.GetReconcilerConfig()
@@ -291,7 +303,10 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, oac OrdererAddress
 	g.privateHandlers[chainID].reconciler.Start()
 
 	g.chains[chainID] = state.NewGossipStateProvider(chainID, servicesAdapter, coordinator,
-		g.metrics.StateMetrics, getStateConfiguration())
+		g.metrics.StateMetrics, getStateConfiguration(chainID))
+	if g.stateServer != nil {
+		g.stateServer.Register(chainID, coordinator)
+	}
 	if g.deliveryService[chainID] == nil {
 		var err error
 		g.deliveryService[chainID], err = g.deliveryFactory.Service(g, oac, g.mcs)
@@ -366,6 +381,17 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 		}
 	}
 
+	g.lock.Lock()
+	if g.anchorPeers == nil {
+		g.anchorPeers = make(map[string]map[string][]api.AnchorPeer)
+	}
+	anchorPeers := make(map[string][]api.AnchorPeer, len(jcm.members2AnchorPeers))
+	for org, orgAnchorPeers := range jcm.members2AnchorPeers {
+		anchorPeers[org] = append([]api.AnchorPeer(nil), orgAnchorPeers...)
+	}
+	g.anchorPeers[config.ChainID()] = anchorPeers
+	g.lock.Unlock()
+
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", config.ChainID())
 	g.JoinChan(jcm, gossipCommon.ChainID(config.ChainID()))
@@ -400,6 +426,9 @@ func (g *gossipServiceImpl) Stop() {
 			logger.Infof("Stopping leader election for %s", chainID)
 			le.Stop()
 		}
+		if g.stateServer != nil {
+			g.stateServer.Deregister(chainID)
+		}
 		g.chains[chainID].Stop()
 		g.privateHandlers[chainID].close()
 
@@ -413,12 +442,14 @@ func (g *gossipServiceImpl) Stop() {
 func (g *gossipServiceImpl) newLeaderElectionComponent(chainID string, callback func(bool),
 	electionMetrics *gossipMetrics.ElectionMetrics) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
-	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID), electionMetrics)
+	priority := uint32(viper.GetInt("peer.gossip.election.priority"))
+	adapter := election.NewAdapter(g, PKIid, gossipCommon.ChainID(chainID), priority, electionMetrics)
 	config := election.ElectionConfig{
-		StartupGracePeriod:       util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", election.DefStartupGracePeriod),
-		MembershipSampleInterval: util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval),
-		LeaderAliveThreshold:     util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold),
-		LeaderElectionDuration:   util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration),
+		StartupGracePeriod:        util.GetDurationOrDefault("peer.gossip.election.startupGracePeriod", election.DefStartupGracePeriod),
+		MembershipSampleInterval:  util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval),
+		LeaderAliveThreshold:      util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold),
+		LeaderElectionDuration:    util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration),
+		PreferHighestLedgerHeight: viper.GetBool("peer.gossip.election.preferHighestLedgerHeight"),
 	}
 	return election.NewLeaderElectionService(adapter, string(PKIid), callback, config)
 }
@@ -464,7 +495,7 @@ func orgListFromConfig(config Config) []string {
 	return orgList
 }
 
-func getStateConfiguration() *state.Configuration {
+func getStateConfiguration(chainID string) *state.Configuration {
 	config := &state.Configuration{
 		AntiEntropyInterval:             state.DefAntiEntropyInterval,
 		AntiEntropyStateResponseTimeout: state.DefAntiEntropyStateResponseTimeout,
@@ -474,6 +505,7 @@ func getStateConfiguration() *state.Configuration {
 		ChannelBufferSize:               state.DefChannelBufferSize,
 		EnableStateTransfer:             true,
 		BlockingMode:                    state.Blocking,
+		StreamParallelism:               state.DefStreamParallelism,
 	}
 
 	if viper.IsSet("peer.gossip.state.checkInterval") {
@@ -508,5 +540,81 @@ func getStateConfiguration() *state.Configuration {
 		config.BlockingMode = state.NonBlocking
 	}
 
+	config.EnableStreaming = streamingEnabled(chainID)
+
+	if viper.IsSet("peer.gossip.state.streaming.parallelism") {
+		config.StreamParallelism = viper.GetInt("peer.gossip.state.streaming.parallelism")
+	}
+
+	return config
+}
+
+// streamingEnabled returns whether missing blocks of the given channel
+// are streamed from remote peers rather than requested over gossip
+func streamingEnabled(chainID string) bool {
+	if !viper.GetBool("peer.gossip.state.streaming.enabled") {
+		return false
+	}
+	channels := viper.GetStringSlice("peer.gossip.state.streaming.channels")
+	if len(channels) == 0 {
+		return true
+	}
+	for _, ch := range channels {
+		if ch == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+func getStreamServerConfiguration() state.StreamServerConfig {
+	config := state.StreamServerConfig{
+		BatchSize:            state.DefStreamBatchSize,
+		MaxConcurrentStreams: state.DefStreamMaxConcurrentStreams,
+		TimeWindow:           util.GetDurationOrDefault("peer.gossip.state.streaming.timeWindow", state.DefStreamTimeWindow),
+	}
+
+	if viper.IsSet("peer.gossip.state.streaming.batchSize") {
+		config.BatchSize = uint64(viper.GetInt("peer.gossip.state.streaming.batchSize"))
+	}
+
+	if viper.IsSet("peer.gossip.state.streaming.maxConcurrentStreams") {
+		config.MaxConcurrentStreams = viper.GetInt("peer.gossip.state.streaming.maxConcurrentStreams")
+	}
+
+	return config
+}
+
+func getStreamClientConfiguration() state.StreamClientConfig {
+	config := state.StreamClientConfig{
+		DialTimeout:  util.GetDurationOrDefault("peer.gossip.dialTimeout", state.DefStreamDialTimeout),
+		Compress:     viper.GetBool("peer.gossip.state.streaming.compression"),
+		BatchSize:    state.DefStreamBatchSize,
+		MaxBlockSize: state.DefStreamMaxBlockSize,
+	}
+
+	if viper.IsSet("peer.gossip.state.streaming.batchSize") {
+		config.BatchSize = uint64(viper.GetInt("peer.gossip.state.streaming.batchSize"))
+	}
+
+	if viper.IsSet("peer.gossip.state.streaming.maxBlockSize") {
+		config.MaxBlockSize = uint64(viper.GetInt("peer.gossip.state.streaming.maxBlockSize"))
+	}
+
 	return config
 }
+
+// tlsCertHash returns a function which returns the hash of the
+// current TLS client certificate of the peer, if TLS is used
+func tlsCertHash(certs *gossipCommon.TLSCertificates) func() []byte {
+	return func() []byte {
+		if certs == nil {
+			return nil
+		}
+		cert, isCert := certs.TLSClientCert.Load().(*tls.Certificate)
+		if !isCert || len(cert.Certificate) == 0 {
+			return nil
+		}
+		return commonutil.ComputeSHA256(cert.Certificate[0])
+	}
+}
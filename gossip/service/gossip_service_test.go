@@ -38,9 +38,11 @@ import (
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
 	"github.com/hyperledger/fabric/protos/common"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	transientstore2 "github.com/hyperledger/fabric/protos/transientstore"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 )
@@ -76,6 +78,14 @@ func (*mockTransientStore) PurgeByTxids(txids []string) error {
 	panic("implement me")
 }
 
+func (*mockTransientStore) PersistShard(txid string, blockHeight uint64, shard *gproto.PrivatePayload) error {
+	panic("implement me")
+}
+
+func (*mockTransientStore) GetShards(txid string) ([]*gproto.PrivatePayload, error) {
+	panic("implement me")
+}
+
 func TestInitGossipService(t *testing.T) {
 	// Test whenever gossip service is indeed singleton
 	grpcServer := grpc.NewServer()
@@ -886,3 +896,24 @@ func TestChannelConfig(t *testing.T) {
 	gService.updateAnchors(mc)
 	assert.True(t, gService.amIinChannel(string(orgInChannelA), mc))
 }
+
+func TestStreamingStateConfiguration(t *testing.T) {
+	defer func() {
+		viper.Set("peer.gossip.state.streaming.enabled", false)
+		viper.Set("peer.gossip.state.streaming.parallelism", state.DefStreamParallelism)
+		viper.Set("peer.gossip.state.streaming.channels", []string{})
+	}()
+
+	assert.False(t, getStateConfiguration("A").EnableStreaming)
+	assert.Equal(t, state.DefStreamParallelism, getStateConfiguration("A").StreamParallelism)
+
+	viper.Set("peer.gossip.state.streaming.enabled", true)
+	viper.Set("peer.gossip.state.streaming.parallelism", 5)
+	assert.True(t, getStateConfiguration("A").EnableStreaming)
+	assert.True(t, getStateConfiguration("B").EnableStreaming)
+	assert.Equal(t, 5, getStateConfiguration("A").StreamParallelism)
+
+	viper.Set("peer.gossip.state.streaming.channels", []string{"A"})
+	assert.True(t, getStateConfiguration("A").EnableStreaming)
+	assert.False(t, getStateConfiguration("B").EnableStreaming)
+}
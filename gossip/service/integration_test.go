@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/util"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	transientstore2 "github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/spf13/viper"
@@ -46,6 +47,14 @@ func (*transientStoreMock) PurgeByTxids(txids []string) error {
 	panic("implement me")
 }
 
+func (*transientStoreMock) PersistShard(txid string, blockHeight uint64, shard *gproto.PrivatePayload) error {
+	panic("implement me")
+}
+
+func (*transientStoreMock) GetShards(txid string) ([]*gproto.PrivatePayload, error) {
+	panic("implement me")
+}
+
 type embeddingDeliveryService struct {
 	startOnce sync.Once
 	stopOnce  sync.Once
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// LeadershipYielder relinquishes the leadership of the peer in channels
+type LeadershipYielder interface {
+	// YieldLeadership relinquishes the leadership of the peer in the given
+	// channel, until another peer is elected or a timeout expires
+	YieldLeadership(chainID string) error
+}
+
+// YieldLeadership relinquishes the leadership of the peer in the given
+// channel, until another peer is elected or a timeout expires. It is meant
+// to hand the leadership off before the peer is taken down for maintenance.
+func (g *gossipServiceImpl) YieldLeadership(chainID string) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("channel %s does not use leader election", chainID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer is not the leader of channel %s", chainID)
+	}
+	logger.Infof("Yielding leadership of channel %s", chainID)
+	le.Yield()
+	return nil
+}
+
+// NewYieldHandler returns an http.Handler which makes the given yielder
+// relinquish its leadership in the channel named by the channel query
+// parameter of PUT requests
+func NewYieldHandler(yielder LeadershipYielder) http.Handler {
+	return &yieldHandler{yielder: yielder}
+}
+
+type yieldHandler struct {
+	yielder LeadershipYielder
+}
+
+func (h *yieldHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPut {
+		sendError(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+	chainID := req.URL.Query().Get("channel")
+	if chainID == "" {
+		sendError(resp, http.StatusBadRequest, errors.New("channel must be specified"))
+		return
+	}
+	if err := h.yielder.YieldLeadership(chainID); err != nil {
+		sendError(resp, http.StatusBadRequest, err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func sendError(resp http.ResponseWriter, code int, err error) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	json.NewEncoder(resp).Encode(map[string]string{"error": err.Error()})
+}
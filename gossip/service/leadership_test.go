@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYieldLeadership(t *testing.T) {
+	leader := &mockLeaderElection{leader: true}
+	follower := &mockLeaderElection{}
+	g := &gossipServiceImpl{
+		leaderElection: map[string]election.LeaderElectionService{
+			"leading":   leader,
+			"following": follower,
+		},
+	}
+
+	assert.EqualError(t, g.YieldLeadership("static"), "channel static does not use leader election")
+	assert.EqualError(t, g.YieldLeadership("following"), "peer is not the leader of channel following")
+	assert.False(t, follower.yielded)
+	assert.NoError(t, g.YieldLeadership("leading"))
+	assert.True(t, leader.yielded)
+}
+
+func TestYieldHandler(t *testing.T) {
+	leader := &mockLeaderElection{leader: true}
+	handler := NewYieldHandler(&gossipServiceImpl{
+		leaderElection: map[string]election.LeaderElectionService{"mychannel": leader},
+	})
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "bad method", method: http.MethodGet, target: "/gossip/yield?channel=mychannel", expectedCode: http.StatusMethodNotAllowed, expectedBody: `{"error":"invalid request method: GET"}`},
+		{name: "no channel", method: http.MethodPut, target: "/gossip/yield", expectedCode: http.StatusBadRequest, expectedBody: `{"error":"channel must be specified"}`},
+		{name: "unknown channel", method: http.MethodPut, target: "/gossip/yield?channel=yourchannel", expectedCode: http.StatusBadRequest, expectedBody: `{"error":"channel yourchannel does not use leader election"}`},
+		{name: "success", method: http.MethodPut, target: "/gossip/yield?channel=mychannel", expectedCode: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, resp.Body.String())
+			}
+		})
+	}
+	assert.True(t, leader.yielded)
+}
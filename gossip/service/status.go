@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/spf13/viper"
+)
+
+// Member describes a member of the gossip network
+type Member struct {
+	PKIID            string `json:"pki_id"`
+	Endpoint         string `json:"endpoint,omitempty"`
+	InternalEndpoint string `json:"internal_endpoint,omitempty"`
+	MSPID            string `json:"mspid,omitempty"`
+	Identity         string `json:"identity,omitempty"`
+	Connected        bool   `json:"connected"`
+}
+
+// Chaincode describes a chaincode a peer publishes in a channel
+type Chaincode struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ChannelPeer describes the state a peer publishes in a channel
+type ChannelPeer struct {
+	PKIID        string      `json:"pki_id"`
+	Endpoint     string      `json:"endpoint,omitempty"`
+	LedgerHeight uint64      `json:"ledger_height"`
+	LeftChannel  bool        `json:"left_channel,omitempty"`
+	Chaincodes   []Chaincode `json:"chaincodes,omitempty"`
+}
+
+// Channel describes the gossip state of a channel the peer has joined
+type Channel struct {
+	ChannelID string `json:"channel_id"`
+	// Leader is true if the peer pulls blocks of the channel from the ordering service
+	Leader bool `json:"leader"`
+	// LeaderElection is true if the leader is elected dynamically
+	LeaderElection bool          `json:"leader_election"`
+	Self           *ChannelPeer  `json:"self,omitempty"`
+	Peers          []ChannelPeer `json:"peers"`
+}
+
+// Status is a snapshot of the gossip membership and channel state of the peer
+type Status struct {
+	Self     Member    `json:"self"`
+	Alive    []Member  `json:"alive"`
+	Dead     []Member  `json:"dead"`
+	Channels []Channel `json:"channels"`
+}
+
+// StatusProvider provides snapshots of the gossip state of the peer
+type StatusProvider interface {
+	// Status returns a snapshot of the gossip state of the peer
+	Status() *Status
+}
+
+// Status returns a snapshot of the gossip membership and channel state of the peer
+func (g *gossipServiceImpl) Status() *Status {
+	identities := make(map[string]api.PeerIdentityInfo)
+	for _, info := range g.IdentityInfo() {
+		identities[string(info.PKIId)] = info
+	}
+	connected := make(map[string]struct{})
+	var dead []discovery.NetworkMember
+	if inspector, ok := g.gossipSvc.(gossip.Inspector); ok {
+		for _, pkiID := range inspector.ConnectedPeers() {
+			connected[string(pkiID)] = struct{}{}
+		}
+		dead = inspector.DeadPeers()
+	}
+
+	toMember := func(nm discovery.NetworkMember) Member {
+		m := Member{
+			PKIID:            hex.EncodeToString(nm.PKIid),
+			Endpoint:         nm.Endpoint,
+			InternalEndpoint: nm.InternalEndpoint,
+		}
+		_, m.Connected = connected[string(nm.PKIid)]
+		if info, exists := identities[string(nm.PKIid)]; exists {
+			m.MSPID = string(info.Organization)
+			sID := &msp.SerializedIdentity{}
+			if err := proto.Unmarshal(info.Identity, sID); err == nil {
+				m.MSPID = sID.Mspid
+				m.Identity = string(sID.IdBytes)
+			}
+		}
+		return m
+	}
+
+	status := &Status{
+		Self:     toMember(g.SelfMembershipInfo()),
+		Alive:    []Member{},
+		Dead:     []Member{},
+		Channels: []Channel{},
+	}
+	for _, nm := range g.Peers() {
+		status.Alive = append(status.Alive, toMember(nm))
+	}
+	for _, nm := range dead {
+		status.Dead = append(status.Dead, toMember(nm))
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	for chainID := range g.chains {
+		ch := Channel{
+			ChannelID: chainID,
+			Leader:    viper.GetBool("peer.gossip.orgLeader"),
+			Peers:     []ChannelPeer{},
+		}
+		if le, exists := g.leaderElection[chainID]; exists {
+			ch.LeaderElection = true
+			ch.Leader = le.IsLeader()
+		}
+		if self := g.SelfChannelInfo(gossipCommon.ChainID(chainID)); self != nil {
+			ch.Self = toChannelPeer(g.SelfMembershipInfo(), self.GetStateInfo().GetProperties())
+		}
+		for _, nm := range g.PeersOfChannel(gossipCommon.ChainID(chainID)) {
+			ch.Peers = append(ch.Peers, *toChannelPeer(nm, nm.Properties))
+		}
+		status.Channels = append(status.Channels, ch)
+	}
+	sort.Slice(status.Channels, func(i, j int) bool {
+		return status.Channels[i].ChannelID < status.Channels[j].ChannelID
+	})
+
+	return status
+}
+
+func toChannelPeer(nm discovery.NetworkMember, props *gproto.Properties) *ChannelPeer {
+	cp := &ChannelPeer{
+		PKIID:    hex.EncodeToString(nm.PKIid),
+		Endpoint: nm.Endpoint,
+	}
+	if props == nil {
+		return cp
+	}
+	cp.LedgerHeight = props.LedgerHeight
+	cp.LeftChannel = props.LeftChannel
+	for _, cc := range props.Chaincodes {
+		cp.Chaincodes = append(cp.Chaincodes, Chaincode{Name: cc.Name, Version: cc.Version})
+	}
+	return cp
+}
+
+// NewStatusHandler returns an http.Handler which serves the gossip status
+// snapshots of the given provider as JSON
+func NewStatusHandler(provider StatusProvider) http.Handler {
+	return &statusHandler{provider: provider}
+}
+
+type statusHandler struct {
+	provider StatusProvider
+}
+
+func (h *statusHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		sendError(resp, http.StatusMethodNotAllowed, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(resp).Encode(h.provider.Status()); err != nil {
+		logger.Errorf("Failed encoding gossip status: %v", err)
+	}
+}
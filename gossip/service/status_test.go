@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/election"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLeaderElection struct {
+	election.LeaderElectionService
+	leader  bool
+	yielded bool
+}
+
+func (le *mockLeaderElection) IsLeader() bool {
+	return le.leader
+}
+
+func (le *mockLeaderElection) Yield() {
+	le.leader = false
+	le.yielded = true
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+	gossips := startPeers(t, 3, 0)
+	defer stopPeers(gossips)
+	addPeersToChannel(t, 3, "chanA", gossips, []int{0, 1})
+	waitForFullMembership(t, gossips, 3, time.Second*30, time.Second*2)
+
+	g := gossips[0].(*gossipGRPC).gossipServiceImpl
+	g.lock.Lock()
+	g.chains["chanA"] = nil
+	g.leaderElection["chanA"] = &mockLeaderElection{leader: true}
+	g.lock.Unlock()
+	defer func() {
+		g.lock.Lock()
+		delete(g.chains, "chanA")
+		delete(g.leaderElection, "chanA")
+		g.lock.Unlock()
+	}()
+
+	var status *Status
+	waitUntilOrFailBlocking(t, func() {
+		for {
+			status = g.Status()
+			if len(status.Channels) == 1 && len(status.Channels[0].Peers) == 1 {
+				return
+			}
+			time.Sleep(time.Second)
+		}
+	}, time.Second*30)
+
+	self := gossips[0].SelfMembershipInfo()
+	assert.Equal(t, self.Endpoint, status.Self.Endpoint)
+	assert.Equal(t, self.InternalEndpoint, status.Self.InternalEndpoint)
+	assert.Len(t, status.Alive, 2)
+	assert.Empty(t, status.Dead)
+	for _, m := range status.Alive {
+		assert.NotEmpty(t, m.PKIID)
+		assert.NotEmpty(t, m.Endpoint)
+		assert.True(t, m.Connected)
+	}
+
+	ch := status.Channels[0]
+	assert.Equal(t, "chanA", ch.ChannelID)
+	assert.True(t, ch.LeaderElection)
+	assert.True(t, ch.Leader)
+	require.NotNil(t, ch.Self)
+	assert.Equal(t, self.Endpoint, ch.Self.Endpoint)
+	assert.Equal(t, gossips[1].SelfMembershipInfo().Endpoint, ch.Peers[0].Endpoint)
+
+	t.Run("handler", func(t *testing.T) {
+		handler := NewStatusHandler(g)
+
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip", nil))
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		served := &Status{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), served))
+		assert.Equal(t, status.Self, served.Self)
+		assert.Equal(t, "chanA", served.Channels[0].ChannelID)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	})
+}
@@ -0,0 +1,462 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	"github.com/hyperledger/fabric/gossip/identity"
+	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultChannel = "testchannel"
+	peerPort       = 7051
+	pollInterval   = 50 * time.Millisecond
+)
+
+// Config describes a simulated cluster of gossip peers
+type Config struct {
+	// Peers is the number of peers in the cluster
+	Peers int
+	// Orgs is the number of organizations the peers are spread across.
+	// The first peer of every organization is its anchor peer.
+	Orgs int
+	// Channel is the channel all peers join
+	Channel string
+	// Tune, if set, is applied to the gossip configuration of every peer
+	// after the defaults of the cluster are populated
+	Tune func(id int, conf *gossip.Config)
+	// MetricsProvider is used to create the gossip metrics of the peers,
+	// and if nil metrics are disabled
+	MetricsProvider metrics.Provider
+}
+
+// Peer is a gossip instance that is part of a simulated cluster
+type Peer struct {
+	gossip.Gossip
+	ID       int
+	Endpoint string
+	Org      api.OrgIdentityType
+	Identity api.PeerIdentityType
+
+	lock        sync.RWMutex
+	stopped     bool
+	stopChan    chan struct{}
+	blocks      map[uint64]struct{}
+	privateData map[string]struct{}
+}
+
+// HasBlock returns whether the peer received the block with the given sequence number
+func (p *Peer) HasBlock(seqNum uint64) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, exists := p.blocks[seqNum]
+	return exists
+}
+
+// HasPrivateData returns whether the peer received private data of the given transaction
+func (p *Peer) HasPrivateData(txID string) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, exists := p.privateData[txID]
+	return exists
+}
+
+// Running returns whether the peer has not been stopped
+func (p *Peer) Running() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return !p.stopped
+}
+
+// Cluster is a set of gossip instances that communicate over an in-memory Network
+type Cluster struct {
+	Network *Network
+	Peers   []*Peer
+	channel common.ChainID
+	crypto  *CryptoService
+}
+
+// NewCluster creates a cluster of gossip peers according to the given configuration,
+// and makes all of them join the channel
+func NewCluster(config Config) (*Cluster, error) {
+	if config.Peers <= 0 {
+		return nil, errors.New("a cluster needs at least one peer")
+	}
+	if config.Orgs <= 0 {
+		config.Orgs = 1
+	}
+	if config.Orgs > config.Peers {
+		return nil, errors.Errorf("cannot spread %d peers across %d organizations", config.Peers, config.Orgs)
+	}
+	if config.Channel == "" {
+		config.Channel = defaultChannel
+	}
+	if config.MetricsProvider == nil {
+		config.MetricsProvider = &disabled.Provider{}
+	}
+
+	c := &Cluster{
+		Network: NewNetwork(),
+		channel: common.ChainID(config.Channel),
+		crypto:  &CryptoService{},
+	}
+
+	joinMsg := &joinChanMsg{anchorPeers: make(map[string][]api.AnchorPeer)}
+	for id := 0; id < config.Peers; id++ {
+		org := orgName(id % config.Orgs)
+		host := fmt.Sprintf("peer%d.%s", id, org)
+		if id < config.Orgs {
+			joinMsg.orgs = append(joinMsg.orgs, org)
+			joinMsg.anchorPeers[string(org)] = []api.AnchorPeer{{Host: host, Port: peerPort}}
+		}
+		endpoint := fmt.Sprintf("%s:%d", host, peerPort)
+		c.Peers = append(c.Peers, &Peer{
+			ID:          id,
+			Endpoint:    endpoint,
+			Org:         org,
+			Identity:    NewIdentity(org, endpoint),
+			stopChan:    make(chan struct{}),
+			blocks:      make(map[uint64]struct{}),
+			privateData: make(map[string]struct{}),
+		})
+	}
+
+	gossipMetrics := gossipmetrics.NewGossipMetrics(config.MetricsProvider)
+	for _, p := range c.Peers {
+		conf := defaultConfig(p.ID, p.Endpoint)
+		if anchor := c.Peers[p.ID%config.Orgs]; anchor != p {
+			conf.BootstrapPeers = []string{anchor.Endpoint}
+		}
+		if config.Tune != nil {
+			config.Tune(p.ID, conf)
+		}
+		commFactory := func(idMapper identity.Mapper) (comm.Comm, error) {
+			return c.Network.NewComm(p.Endpoint, p.Identity, idMapper, conf.SendBuffSize)
+		}
+		p.Gossip = gossip.NewGossipServiceWithComm(conf, &SecurityAdvisor{}, c.crypto, p.Identity, commFactory, gossipMetrics)
+		if p.Gossip == nil {
+			c.Stop()
+			return nil, errors.Errorf("failed creating gossip instance of %s", p.Endpoint)
+		}
+		p.JoinChan(joinMsg, c.channel)
+		p.UpdateLedgerHeight(1, c.channel)
+		c.listen(p)
+	}
+	return c, nil
+}
+
+func orgName(i int) api.OrgIdentityType {
+	return api.OrgIdentityType(fmt.Sprintf("Org%dMSP", i+1))
+}
+
+func defaultConfig(id int, endpoint string) *gossip.Config {
+	return &gossip.Config{
+		ID:                           fmt.Sprintf("p%d", id),
+		MaxBlockCountToStore:         100,
+		MaxPropagationBurstLatency:   10 * time.Millisecond,
+		MaxPropagationBurstSize:      10,
+		PropagateIterations:          1,
+		PropagatePeerNum:             3,
+		PullInterval:                 time.Second,
+		PullPeerNum:                  3,
+		InternalEndpoint:             endpoint,
+		ExternalEndpoint:             endpoint,
+		PublishCertPeriod:            2 * time.Second,
+		PublishStateInfoInterval:     200 * time.Millisecond,
+		RequestStateInfoInterval:     200 * time.Millisecond,
+		TimeForMembershipTracker:     5 * time.Second,
+		DigestWaitTime:               200 * time.Millisecond,
+		RequestWaitTime:              200 * time.Millisecond,
+		ResponseWaitTime:             200 * time.Millisecond,
+		DialTimeout:                  comm.DefDialTimeout,
+		ConnTimeout:                  comm.DefConnTimeout,
+		RecvBuffSize:                 comm.DefRecvBuffSize,
+		SendBuffSize:                 comm.DefSendBuffSize,
+		MsgExpirationTimeout:         channel.DefMsgExpirationTimeout,
+		AliveTimeInterval:            200 * time.Millisecond,
+		AliveExpirationTimeout:       2 * time.Second,
+		AliveExpirationCheckInterval: 200 * time.Millisecond,
+		ReconnectInterval:            500 * time.Millisecond,
+	}
+}
+
+// listen records the blocks and private data the given peer receives
+func (c *Cluster) listen(p *Peer) {
+	blocks, _ := p.Accept(func(o interface{}) bool {
+		msg := o.(*proto.GossipMessage)
+		return msg.IsDataMsg() && bytes.Equal(msg.Channel, c.channel)
+	}, false)
+	_, privateData := p.Accept(func(o interface{}) bool {
+		msg := o.(proto.ReceivedMessage).GetGossipMessage()
+		return msg.IsPrivateDataMsg() && bytes.Equal(msg.Channel, c.channel)
+	}, true)
+
+	go func() {
+		for {
+			select {
+			case msg := <-blocks:
+				p.lock.Lock()
+				p.blocks[msg.GetDataMsg().Payload.SeqNum] = struct{}{}
+				p.lock.Unlock()
+			case <-p.stopChan:
+				return
+			}
+		}
+	}()
+	go func() {
+		for msg := range privateData {
+			p.lock.Lock()
+			p.privateData[msg.GetGossipMessage().GetPrivateData().Payload.TxId] = struct{}{}
+			p.lock.Unlock()
+			msg.Ack(nil)
+		}
+	}()
+}
+
+// Endpoints returns the endpoints of the peers with the given IDs,
+// to be used when partitioning the network
+func (c *Cluster) Endpoints(ids ...int) []string {
+	var endpoints []string
+	for _, id := range ids {
+		endpoints = append(endpoints, c.Peers[id].Endpoint)
+	}
+	return endpoints
+}
+
+// PeersOf returns the IDs of the peers of the given organization
+func (c *Cluster) PeersOf(org api.OrgIdentityType) []int {
+	var ids []int
+	for _, p := range c.Peers {
+		if bytes.Equal(p.Org, org) {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// StopPeer stops the peer with the given ID
+func (c *Cluster) StopPeer(id int) {
+	p := c.Peers[id]
+	p.lock.Lock()
+	if p.stopped {
+		p.lock.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.stopChan)
+	p.lock.Unlock()
+	p.Stop()
+}
+
+// Stop stops all peers of the cluster
+func (c *Cluster) Stop() {
+	var wg sync.WaitGroup
+	for _, p := range c.Peers {
+		if p.Gossip == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			c.StopPeer(id)
+		}(p.ID)
+	}
+	wg.Wait()
+}
+
+// MembershipConverged returns whether every running peer considers alive exactly
+// the running peers it can reach, both in the network and in the channel
+func (c *Cluster) MembershipConverged() bool {
+	for _, p := range c.Peers {
+		if !p.Running() {
+			continue
+		}
+		expected := make(map[string]struct{})
+		for _, q := range c.Peers {
+			if q == p || !q.Running() {
+				continue
+			}
+			if c.Network.reachable(p.Endpoint, q.Endpoint) && c.Network.reachable(q.Endpoint, p.Endpoint) {
+				expected[string(q.Identity)] = struct{}{}
+			}
+		}
+		if !sameMembers(expected, p.Peers()) || !sameMembers(expected, p.PeersOfChannel(c.channel)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameMembers(expected map[string]struct{}, members []discovery.NetworkMember) bool {
+	if len(expected) != len(members) {
+		return false
+	}
+	for _, member := range members {
+		if _, exists := expected[string(member.PKIid)]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// AwaitMembership waits until the membership of all peers converges,
+// and returns the time it took
+func (c *Cluster) AwaitMembership(timeout time.Duration) (time.Duration, error) {
+	return await(timeout, "membership agreement", c.MembershipConverged)
+}
+
+// DisseminateBlock gossips a block with the given sequence number from the peer with the given ID.
+// Blocks are disseminated only among peers of the same organization.
+func (c *Cluster) DisseminateBlock(origin int, seqNum uint64) {
+	p := c.Peers[origin]
+	p.lock.Lock()
+	p.blocks[seqNum] = struct{}{}
+	p.lock.Unlock()
+	p.Gossip.Gossip(&proto.GossipMessage{
+		Channel: c.channel,
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: &proto.Payload{
+					SeqNum: seqNum,
+					Data:   []byte(fmt.Sprintf("block %d", seqNum)),
+				},
+			},
+		},
+	})
+}
+
+// AwaitBlock waits until the peers with the given IDs, or all running peers if none are given,
+// received the block with the given sequence number, and returns the time it took
+func (c *Cluster) AwaitBlock(seqNum uint64, timeout time.Duration, ids ...int) (time.Duration, error) {
+	peers := c.peers(ids...)
+	return await(timeout, fmt.Sprintf("dissemination of block %d", seqNum), func() bool {
+		for _, p := range peers {
+			if !p.HasBlock(seqNum) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// DistributePrivateData sends private data of the given transaction from the peer with the given ID
+// to at most maxPeers of the eligible peers, and waits for minAck of them to acknowledge it
+func (c *Cluster) DistributePrivateData(origin int, txID string, eligible []int, maxPeers, minAck int, timeout time.Duration) error {
+	eligibleIDs := make(map[string]struct{})
+	for _, p := range c.peers(eligible...) {
+		eligibleIDs[string(p.Identity)] = struct{}{}
+	}
+	msg, err := (&proto.GossipMessage{
+		Channel: c.channel,
+		Nonce:   util.RandomUInt64(),
+		Tag:     proto.GossipMessage_CHAN_ONLY,
+		Content: &proto.GossipMessage_PrivateData{
+			PrivateData: &proto.PrivateDataMessage{
+				Payload: &proto.PrivatePayload{
+					TxId:           txID,
+					Namespace:      "ns",
+					CollectionName: "collection",
+				},
+			},
+		},
+	}).NoopSign()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return c.Peers[origin].SendByCriteria(msg, gossip.SendCriteria{
+		Channel:  c.channel,
+		Timeout:  timeout,
+		MaxPeers: maxPeers,
+		MinAck:   minAck,
+		IsEligible: func(member discovery.NetworkMember) bool {
+			_, isEligible := eligibleIDs[string(member.PKIid)]
+			return isEligible
+		},
+	})
+}
+
+// AwaitPrivateData waits until the peers with the given IDs received
+// private data of the given transaction, and returns the time it took
+func (c *Cluster) AwaitPrivateData(txID string, timeout time.Duration, ids ...int) (time.Duration, error) {
+	peers := c.peers(ids...)
+	return await(timeout, fmt.Sprintf("distribution of private data of %s", txID), func() bool {
+		for _, p := range peers {
+			if !p.HasPrivateData(txID) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// peers returns the peers with the given IDs, or all running peers if no IDs are given
+func (c *Cluster) peers(ids ...int) []*Peer {
+	var peers []*Peer
+	if len(ids) == 0 {
+		for _, p := range c.Peers {
+			if p.Running() {
+				peers = append(peers, p)
+			}
+		}
+		return peers
+	}
+	for _, id := range ids {
+		peers = append(peers, c.Peers[id])
+	}
+	return peers
+}
+
+func await(timeout time.Duration, what string, condition func() bool) (time.Duration, error) {
+	start := time.Now()
+	for {
+		if condition() {
+			return time.Since(start), nil
+		}
+		if time.Since(start) > timeout {
+			return time.Since(start), errors.Errorf("timed out after %v waiting for %s", timeout, what)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+type joinChanMsg struct {
+	orgs        []api.OrgIdentityType
+	anchorPeers map[string][]api.AnchorPeer
+}
+
+// SequenceNumber returns the sequence number of the configuration block
+// the joinChanMsg originated from
+func (*joinChanMsg) SequenceNumber() uint64 {
+	return uint64(time.Now().UnixNano())
+}
+
+// Members returns the organizations of the channel
+func (jcm *joinChanMsg) Members() []api.OrgIdentityType {
+	return jcm.orgs
+}
+
+// AnchorPeersOf returns the anchor peers of the given organization
+func (jcm *joinChanMsg) AnchorPeersOf(org api.OrgIdentityType) []api.AnchorPeer {
+	return jcm.anchorPeers[string(org)]
+}
@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const convergenceTimeout = 30 * time.Second
+
+func init() {
+	util.SetupTestLogging()
+}
+
+func TestNewClusterValidation(t *testing.T) {
+	_, err := NewCluster(Config{})
+	assert.EqualError(t, err, "a cluster needs at least one peer")
+
+	_, err = NewCluster(Config{Peers: 2, Orgs: 3})
+	assert.EqualError(t, err, "cannot spread 2 peers across 3 organizations")
+}
+
+func TestMembershipAgreement(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{Peers: 8, Orgs: 2})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	assert.NoError(t, err)
+	assert.Len(t, c.PeersOf(orgName(0)), 4)
+	assert.Len(t, c.PeersOf(orgName(1)), 4)
+}
+
+func TestMembershipPartitionAndHeal(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{Peers: 6})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	c.Network.Partition(c.Endpoints(0, 1, 2), c.Endpoints(3, 4, 5))
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+	assert.Len(t, c.Peers[0].Peers(), 2)
+	assert.Len(t, c.Peers[5].Peers(), 2)
+
+	c.Network.Heal()
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+	assert.Len(t, c.Peers[0].Peers(), 5)
+}
+
+func TestMembershipStoppedPeer(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{Peers: 4})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	c.StopPeer(3)
+	assert.False(t, c.Peers[3].Running())
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+	assert.Len(t, c.Peers[0].Peers(), 2)
+}
+
+func TestBlockDissemination(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{
+		Peers: 10,
+		Tune: func(_ int, conf *gossip.Config) {
+			conf.PullInterval = 500 * time.Millisecond
+		},
+	})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	c.Network.SetConditions(LinkConditions{
+		Latency: 5 * time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Loss:    0.2,
+	})
+	for seqNum := uint64(1); seqNum <= 5; seqNum++ {
+		c.DisseminateBlock(0, seqNum)
+	}
+	for seqNum := uint64(1); seqNum <= 5; seqNum++ {
+		_, err = c.AwaitBlock(seqNum, convergenceTimeout)
+		assert.NoError(t, err)
+	}
+	stats := c.Network.Stats()
+	assert.NotZero(t, stats.Dropped)
+	assert.True(t, stats.Delivered+stats.Dropped <= stats.Sent)
+}
+
+func TestBlockDisseminationStaysInOrg(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{Peers: 6, Orgs: 2})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	c.DisseminateBlock(0, 1)
+	org1 := c.PeersOf(orgName(0))
+	_, err = c.AwaitBlock(1, convergenceTimeout, org1...)
+	assert.NoError(t, err)
+	for _, id := range c.PeersOf(orgName(1)) {
+		assert.False(t, c.Peers[id].HasBlock(1))
+	}
+}
+
+func TestBlockDisseminationAfterHeal(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{
+		Peers: 6,
+		Tune: func(_ int, conf *gossip.Config) {
+			conf.PullInterval = 500 * time.Millisecond
+		},
+	})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	c.Network.Partition(c.Endpoints(0, 1, 2), c.Endpoints(3, 4, 5))
+	c.DisseminateBlock(0, 1)
+	_, err = c.AwaitBlock(1, convergenceTimeout, 0, 1, 2)
+	require.NoError(t, err)
+	_, err = c.AwaitBlock(1, time.Second, 3, 4, 5)
+	assert.Error(t, err)
+
+	// Once the partition heals, the block is pulled by the other side
+	c.Network.Heal()
+	_, err = c.AwaitBlock(1, convergenceTimeout)
+	assert.NoError(t, err)
+}
+
+func TestPrivateDataDistribution(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCluster(Config{Peers: 6, Orgs: 2})
+	require.NoError(t, err)
+	defer c.Stop()
+
+	_, err = c.AwaitMembership(convergenceTimeout)
+	require.NoError(t, err)
+
+	eligible := []int{2, 3, 5}
+	err = c.DistributePrivateData(0, "tx1", eligible, 3, 3, 5*time.Second)
+	require.NoError(t, err)
+	_, err = c.AwaitPrivateData("tx1", convergenceTimeout, eligible...)
+	assert.NoError(t, err)
+	for _, id := range []int{0, 1, 4} {
+		assert.False(t, c.Peers[id].HasPrivateData("tx1"))
+	}
+
+	// Peers that are partitioned away cannot acknowledge the private data
+	c.Network.Partition(c.Endpoints(0, 1, 2), c.Endpoints(3, 4, 5))
+	err = c.DistributePrivateData(0, "tx2", []int{3}, 1, 1, time.Second)
+	assert.Error(t, err)
+	assert.False(t, c.Peers[3].HasPrivateData("tx2"))
+}
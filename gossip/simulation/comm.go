@@ -0,0 +1,596 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/util"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+)
+
+const (
+	deadEndpointsChanSize = 100
+	acceptChanSize        = 10
+)
+
+// memComm is an implementation of comm.Comm that sends messages
+// over an in-memory Network instead of gRPC
+type memComm struct {
+	network       *Network
+	endpoint      string
+	pkiID         common.PKIidType
+	selfIdentity  api.PeerIdentityType
+	idMapper      identity.Mapper
+	logger        util.Logger
+	sendBuffSize  int
+	pubSub        *util.PubSub
+	msgPublisher  *comm.ChannelDeMultiplexer
+	deadEndpoints chan common.PKIidType
+	lock          sync.Mutex
+	conns         map[string]*memConn
+	subscriptions []chan proto.ReceivedMessage
+	exitChan      chan struct{}
+	stopWG        sync.WaitGroup
+	stopping      int32
+}
+
+// NewComm creates a communication layer for a peer with the given endpoint, identity
+// and identity mapper, that is attached to the network.
+// The send buffer size bounds the amount of messages in flight towards each remote peer.
+func (n *Network) NewComm(endpoint string, selfIdentity api.PeerIdentityType, idMapper identity.Mapper, sendBuffSize int) (comm.Comm, error) {
+	c := &memComm{
+		network:       n,
+		endpoint:      endpoint,
+		pkiID:         idMapper.GetPKIidOfCert(selfIdentity),
+		selfIdentity:  selfIdentity,
+		idMapper:      idMapper,
+		logger:        util.GetLogger(util.SimulationLogger, endpoint),
+		sendBuffSize:  sendBuffSize,
+		pubSub:        util.NewPubSub(),
+		msgPublisher:  comm.NewChannelDemultiplexer(),
+		deadEndpoints: make(chan common.PKIidType, deadEndpointsChanSize),
+		conns:         make(map[string]*memConn),
+		exitChan:      make(chan struct{}),
+	}
+	if !n.register(c) {
+		return nil, errors.Errorf("endpoint %s is already in use", endpoint)
+	}
+	return c, nil
+}
+
+// GetPKIid returns this instance's PKI id
+func (c *memComm) GetPKIid() common.PKIidType {
+	return c.pkiID
+}
+
+// Send sends a message to remote peers
+func (c *memComm) Send(msg *proto.SignedGossipMessage, peers ...*comm.RemotePeer) {
+	if c.isStopping() || len(peers) == 0 {
+		return
+	}
+	for _, peer := range peers {
+		go c.sendToEndpoint(peer, cloneEnvelope(msg.Envelope), false)
+	}
+}
+
+// SendWithAck sends a message to remote peers, waiting for acknowledgement from minAck of them, or until a certain timeout expires
+func (c *memComm) SendWithAck(msg *proto.SignedGossipMessage, timeout time.Duration, minAck int, peers ...*comm.RemotePeer) comm.AggregatedSendResult {
+	if len(peers) == 0 {
+		return nil
+	}
+	var err error
+
+	// Roll a random NONCE to be used as a send ID to differentiate
+	// between different invocations
+	msg.Nonce = util.RandomUInt64()
+	// Replace the envelope in the message to update the NONCE
+	msg, err = msg.NoopSign()
+
+	if c.isStopping() || err != nil {
+		if err == nil {
+			err = errors.New("comm is stopping")
+		}
+		var results comm.AggregatedSendResult
+		for _, p := range peers {
+			results = append(results, comm.NewSendResult(*p, err))
+		}
+		return results
+	}
+
+	acks := make(chan comm.SendResult, len(peers))
+	for _, p := range peers {
+		sub := c.pubSub.Subscribe(topicForAck(msg.Nonce, p.PKIID), timeout)
+		go func(p *comm.RemotePeer, envelope *proto.Envelope) {
+			c.sendToEndpoint(p, envelope, true)
+			acks <- comm.NewSendResult(*p, waitForAck(sub))
+		}(p, cloneEnvelope(msg.Envelope))
+	}
+
+	var results comm.AggregatedSendResult
+	successAcks := 0
+	for len(results) < len(peers) && successAcks < minAck {
+		ack := <-acks
+		results = append(results, ack)
+		if ack.Error() == "" {
+			successAcks++
+		}
+	}
+	return results
+}
+
+// Probe returns nil if the remote peer is reachable, and an error if it's not
+func (c *memComm) Probe(peer *comm.RemotePeer) error {
+	if c.isStopping() {
+		return errors.New("Stopping")
+	}
+	_, err := c.dial(peer.Endpoint)
+	return err
+}
+
+// Handshake authenticates a remote peer and returns
+// (its identity, nil) on success and (nil, error)
+func (c *memComm) Handshake(peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	if c.isStopping() {
+		return nil, errors.New("Stopping")
+	}
+	remote, err := c.dial(peer.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	connInfo, err := c.authenticate(remote)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := remote.authenticate(c); err != nil {
+		return nil, err
+	}
+	if len(peer.PKIID) > 0 && !bytes.Equal(connInfo.ID, peer.PKIID) {
+		return nil, errors.New("PKI-ID of remote peer doesn't match expected PKI-ID")
+	}
+	return connInfo.Identity, nil
+}
+
+// Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
+func (c *memComm) Accept(acceptor common.MessageAcceptor) <-chan proto.ReceivedMessage {
+	genericChan := c.msgPublisher.AddChannel(acceptor)
+	specificChan := make(chan proto.ReceivedMessage, acceptChanSize)
+
+	if c.isStopping() {
+		c.logger.Warning("Accept() called but comm module is stopping, returning empty channel")
+		return specificChan
+	}
+
+	c.lock.Lock()
+	c.subscriptions = append(c.subscriptions, specificChan)
+	c.lock.Unlock()
+
+	c.stopWG.Add(1)
+	go func() {
+		defer c.stopWG.Done()
+		// Once stopping, keep draining messages that are being delivered,
+		// until the publisher is closed
+		defer func() {
+			for range genericChan {
+			}
+		}()
+		for {
+			select {
+			case msg := <-genericChan:
+				if msg == nil {
+					return
+				}
+				select {
+				case specificChan <- msg.(*receivedMessage):
+				case <-c.exitChan:
+					return
+				}
+			case <-c.exitChan:
+				return
+			}
+		}
+	}()
+	return specificChan
+}
+
+// PresumedDead returns a read-only channel for node endpoints that are suspected to be offline
+func (c *memComm) PresumedDead() <-chan common.PKIidType {
+	return c.deadEndpoints
+}
+
+// CloseConn closes a connection to a certain endpoint
+func (c *memComm) CloseConn(peer *comm.RemotePeer) {
+	c.lock.Lock()
+	conn, exists := c.conns[string(peer.PKIID)]
+	delete(c.conns, string(peer.PKIID))
+	c.lock.Unlock()
+	if exists {
+		conn.close()
+	}
+}
+
+// Connections returns the PKI-IDs of the remote peers that have an open connection
+func (c *memComm) Connections() []common.PKIidType {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var pkiIDs []common.PKIidType
+	for _, conn := range c.conns {
+		pkiIDs = append(pkiIDs, conn.info.ID)
+	}
+	return pkiIDs
+}
+
+// Stop stops the module, and detaches it from the network
+func (c *memComm) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopping, 0, 1) {
+		return
+	}
+	c.network.deregister(c)
+	c.lock.Lock()
+	for pkiID, conn := range c.conns {
+		conn.close()
+		delete(c.conns, pkiID)
+	}
+	c.lock.Unlock()
+	close(c.exitChan)
+	c.msgPublisher.Close()
+	c.stopWG.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, ch := range c.subscriptions {
+		close(ch)
+	}
+}
+
+func (c *memComm) isStopping() bool {
+	return atomic.LoadInt32(&c.stopping) == 1
+}
+
+// dial returns the comm instance that listens on the given endpoint,
+// or an error if it doesn't exist or is unreachable
+func (c *memComm) dial(endpoint string) (*memComm, error) {
+	remote := c.network.lookup(endpoint)
+	if remote == nil || remote.isStopping() {
+		return nil, errors.Errorf("connection to %s refused", endpoint)
+	}
+	if !c.network.reachable(c.endpoint, endpoint) {
+		return nil, errors.Errorf("%s is unreachable", endpoint)
+	}
+	return remote, nil
+}
+
+// authenticate verifies the connection message of the remote peer, in the same way
+// the gRPC based comm does during a handshake, and returns the remote peer's connection info
+func (c *memComm) authenticate(remote *memComm) (*proto.ConnectionInfo, error) {
+	cMsg, err := remote.createConnectionMsg()
+	if err != nil {
+		return nil, err
+	}
+	m, err := cMsg.Envelope.ToGossipMessage()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	receivedMsg := m.GetConn()
+	if receivedMsg == nil || receivedMsg.PkiId == nil {
+		return nil, errors.Errorf("%s sent an invalid connection message", remote.endpoint)
+	}
+	if err := c.idMapper.Put(receivedMsg.PkiId, receivedMsg.Identity); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("identity store rejected %s", remote.endpoint))
+	}
+	verifier := func(peerIdentity []byte, signature, message []byte) error {
+		pkiID := c.idMapper.GetPKIidOfCert(api.PeerIdentityType(peerIdentity))
+		return c.idMapper.Verify(pkiID, signature, message)
+	}
+	if err := m.Verify(receivedMsg.Identity, verifier); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed verifying signature from %s", remote.endpoint))
+	}
+	return &proto.ConnectionInfo{
+		ID:       receivedMsg.PkiId,
+		Identity: receivedMsg.Identity,
+		Endpoint: remote.endpoint,
+		Auth: &proto.AuthInfo{
+			Signature:  m.Signature,
+			SignedData: m.Payload,
+		},
+	}, nil
+}
+
+func (c *memComm) createConnectionMsg() (*proto.SignedGossipMessage, error) {
+	sMsg := &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Tag: proto.GossipMessage_EMPTY,
+			Content: &proto.GossipMessage_Conn{
+				Conn: &proto.ConnEstablish{
+					Identity: c.selfIdentity,
+					PkiId:    c.pkiID,
+				},
+			},
+		},
+	}
+	_, err := sMsg.Sign(c.idMapper.Sign)
+	return sMsg, errors.WithStack(err)
+}
+
+// getConnection returns an existing connection to the given peer,
+// or establishes a new one by authenticating both sides
+func (c *memComm) getConnection(peer *comm.RemotePeer) (*memConn, error) {
+	if c.isStopping() {
+		return nil, errors.New("Stopping")
+	}
+	c.lock.Lock()
+	conn, exists := c.conns[string(peer.PKIID)]
+	c.lock.Unlock()
+	if exists {
+		return conn, nil
+	}
+
+	remote, err := c.dial(peer.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if len(peer.PKIID) > 0 && !bytes.Equal(remote.pkiID, peer.PKIID) {
+		return nil, errors.Errorf("%s claims to be a different peer, expected %s", peer.Endpoint, peer.PKIID)
+	}
+	remoteInfo, err := c.authenticate(remote)
+	if err != nil {
+		return nil, err
+	}
+	localInfo, err := remote.authenticate(c)
+	if err != nil {
+		return nil, err
+	}
+
+	conn = c.addConn(newMemConn(c, remote, remoteInfo, localInfo))
+	remote.addConn(newMemConn(remote, c, localInfo, remoteInfo))
+	return conn, nil
+}
+
+// addConn stores the given connection unless a connection to the same peer
+// already exists, and returns the connection that is in use
+func (c *memComm) addConn(conn *memConn) *memConn {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.isStopping() {
+		return conn
+	}
+	if existing, exists := c.conns[string(conn.info.ID)]; exists {
+		return existing
+	}
+	c.conns[string(conn.info.ID)] = conn
+	go conn.serviceConnection()
+	return conn
+}
+
+func (c *memComm) sendToEndpoint(peer *comm.RemotePeer, envelope *proto.Envelope, blocking bool) {
+	if c.isStopping() {
+		return
+	}
+	conn, err := c.getConnection(peer)
+	if err != nil {
+		c.logger.Debugf("Failed obtaining connection for %v reason: %v", peer, err)
+		c.disconnect(peer.PKIID)
+		return
+	}
+	if err := conn.send(envelope, blocking); err != nil {
+		c.logger.Debugf("%v isn't responsive: %v", peer, err)
+		c.disconnect(peer.PKIID)
+	}
+}
+
+func (c *memComm) disconnect(pkiID common.PKIidType) {
+	if c.isStopping() {
+		return
+	}
+	select {
+	case c.deadEndpoints <- pkiID:
+	case <-c.exitChan:
+		return
+	}
+	c.CloseConn(&comm.RemotePeer{PKIID: pkiID})
+}
+
+// receive handles a message that arrived from the peer with the given connection info
+func (c *memComm) receive(envelope *proto.Envelope, connInfo *proto.ConnectionInfo) {
+	if c.isStopping() {
+		return
+	}
+	m, err := envelope.ToGossipMessage()
+	if err != nil {
+		c.logger.Warningf("Got invalid message from %s: %v", connInfo.Endpoint, err)
+		return
+	}
+	if m.IsAck() {
+		c.pubSub.Publish(topicForAck(m.Nonce, connInfo.ID), m.GetAck())
+		return
+	}
+	c.msgPublisher.DeMultiplex(&receivedMessage{
+		SignedGossipMessage: m,
+		comm:                c,
+		connInfo:            connInfo,
+	})
+}
+
+type delivery struct {
+	envelope  *proto.Envelope
+	deliverAt time.Time
+}
+
+// memConn is a connection from a local peer to a remote peer.
+// Messages sent over it are delivered in order, after the latency of the link elapses.
+type memConn struct {
+	local     *memComm
+	remote    *memComm
+	info      *proto.ConnectionInfo
+	localInfo *proto.ConnectionInfo
+	outBuff   chan *delivery
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+}
+
+func newMemConn(local, remote *memComm, info, localInfo *proto.ConnectionInfo) *memConn {
+	return &memConn{
+		local:     local,
+		remote:    remote,
+		info:      info,
+		localInfo: localInfo,
+		outBuff:   make(chan *delivery, local.sendBuffSize),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+func (conn *memConn) send(envelope *proto.Envelope, blocking bool) error {
+	network := conn.local.network
+	if !network.reachable(conn.local.endpoint, conn.remote.endpoint) || conn.remote.isStopping() {
+		return errors.Errorf("connection to %s is broken", conn.remote.endpoint)
+	}
+	network.reportSent()
+	conditions := network.linkConditions(conn.local.endpoint, conn.remote.endpoint)
+	if network.lost(conditions) {
+		network.reportDropped()
+		return nil
+	}
+	d := &delivery{
+		envelope:  envelope,
+		deliverAt: time.Now().Add(network.delay(conditions)),
+	}
+	if blocking {
+		select {
+		case conn.outBuff <- d:
+		case <-conn.stopChan:
+			network.reportDropped()
+		}
+		return nil
+	}
+	select {
+	case conn.outBuff <- d:
+	default:
+		conn.local.logger.Debug("Buffer to", conn.remote.endpoint, "overflowed, dropping message")
+		network.reportDropped()
+	}
+	return nil
+}
+
+func (conn *memConn) serviceConnection() {
+	network := conn.local.network
+	for {
+		select {
+		case d := <-conn.outBuff:
+			if wait := time.Until(d.deliverAt); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-conn.stopChan:
+					return
+				}
+			}
+			// A partition that was formed while the message was in flight loses it
+			if !network.reachable(conn.local.endpoint, conn.remote.endpoint) {
+				network.reportDropped()
+				continue
+			}
+			network.reportDelivered()
+			conn.remote.receive(d.envelope, conn.localInfo)
+		case <-conn.stopChan:
+			return
+		}
+	}
+}
+
+func (conn *memConn) close() {
+	conn.stopOnce.Do(func() {
+		close(conn.stopChan)
+	})
+}
+
+// receivedMessage is an implementation of proto.ReceivedMessage
+// for messages that were received over the in-memory network
+type receivedMessage struct {
+	*proto.SignedGossipMessage
+	comm     *memComm
+	connInfo *proto.ConnectionInfo
+}
+
+// Respond sends a msg to the source that sent the receivedMessage
+func (m *receivedMessage) Respond(msg *proto.GossipMessage) {
+	sMsg, err := msg.NoopSign()
+	if err != nil {
+		m.comm.logger.Errorf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
+		return
+	}
+	m.comm.sendToEndpoint(&comm.RemotePeer{
+		Endpoint: m.connInfo.Endpoint,
+		PKIID:    m.connInfo.ID,
+	}, cloneEnvelope(sMsg.Envelope), true)
+}
+
+// GetGossipMessage returns the inner GossipMessage
+func (m *receivedMessage) GetGossipMessage() *proto.SignedGossipMessage {
+	return m.SignedGossipMessage
+}
+
+// GetSourceEnvelope returns the Envelope the receivedMessage was
+// constructed with
+func (m *receivedMessage) GetSourceEnvelope() *proto.Envelope {
+	return m.Envelope
+}
+
+// GetConnectionInfo returns information about the remote peer
+// that sent the message
+func (m *receivedMessage) GetConnectionInfo() *proto.ConnectionInfo {
+	return m.connInfo
+}
+
+// Ack returns to the sender an acknowledgement for the message
+func (m *receivedMessage) Ack(err error) {
+	ackMsg := &proto.GossipMessage{
+		Nonce: m.GetGossipMessage().Nonce,
+		Content: &proto.GossipMessage_Ack{
+			Ack: &proto.Acknowledgement{},
+		},
+	}
+	if err != nil {
+		ackMsg.GetAck().Error = err.Error()
+	}
+	m.Respond(ackMsg)
+}
+
+func waitForAck(sub util.Subscription) error {
+	msg, err := sub.Listen()
+	if err != nil {
+		return err
+	}
+	ack, isAck := msg.(*proto.Acknowledgement)
+	if !isAck {
+		return errors.Errorf("Received a message of type %s, expected *proto.Acknowledgement", reflect.TypeOf(msg))
+	}
+	if ack.Error != "" {
+		return errors.New(ack.Error)
+	}
+	return nil
+}
+
+// cloneEnvelope copies an envelope before it is sent, so that peers never share
+// messages, as if they were marshaled to the wire
+func cloneEnvelope(envelope *proto.Envelope) *proto.Envelope {
+	return pb.Clone(envelope).(*proto.Envelope)
+}
+
+func topicForAck(nonce uint64, pkiID common.PKIidType) string {
+	return fmt.Sprintf("%d %s", nonce, hex.EncodeToString(pkiID))
+}
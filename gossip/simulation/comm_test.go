@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestComm(t *testing.T, network *Network, endpoint string) (comm.Comm, api.PeerIdentityType) {
+	selfIdentity := NewIdentity(api.OrgIdentityType("Org1MSP"), endpoint)
+	idMapper := identity.NewIdentityMapper(&CryptoService{}, selfIdentity, func(_ common.PKIidType, _ api.PeerIdentityType) {}, &SecurityAdvisor{})
+	c, err := network.NewComm(endpoint, selfIdentity, idMapper, comm.DefSendBuffSize)
+	require.NoError(t, err)
+	return c, selfIdentity
+}
+
+func createGossipMsg(nonce uint64) *proto.SignedGossipMessage {
+	msg, _ := (&proto.GossipMessage{
+		Tag:   proto.GossipMessage_EMPTY,
+		Nonce: nonce,
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{},
+		},
+	}).NoopSign()
+	return msg
+}
+
+func acceptAll(interface{}) bool {
+	return true
+}
+
+func TestSecurityAdvisor(t *testing.T) {
+	sa := &SecurityAdvisor{}
+	assert.Equal(t, api.OrgIdentityType("Org1MSP"), sa.OrgByPeerIdentity(NewIdentity(api.OrgIdentityType("Org1MSP"), "p0:7051")))
+	assert.Nil(t, sa.OrgByPeerIdentity(api.PeerIdentityType("p0:7051")))
+}
+
+func TestNetworkReachability(t *testing.T) {
+	network := NewNetwork()
+	assert.True(t, network.reachable("a", "b"))
+
+	network.Partition([]string{"a", "b"}, []string{"c"})
+	assert.True(t, network.reachable("a", "b"))
+	assert.False(t, network.reachable("a", "c"))
+	assert.False(t, network.reachable("c", "b"))
+	// Endpoints that are not part of any group are isolated
+	assert.False(t, network.reachable("a", "d"))
+
+	network.Heal()
+	assert.True(t, network.reachable("a", "c"))
+}
+
+func TestCommEndpointInUse(t *testing.T) {
+	network := NewNetwork()
+	c, _ := newTestComm(t, network, "p0:7051")
+	defer c.Stop()
+	selfIdentity := NewIdentity(api.OrgIdentityType("Org1MSP"), "p0:7051")
+	idMapper := identity.NewIdentityMapper(&CryptoService{}, selfIdentity, func(_ common.PKIidType, _ api.PeerIdentityType) {}, &SecurityAdvisor{})
+	_, err := network.NewComm("p0:7051", selfIdentity, idMapper, comm.DefSendBuffSize)
+	assert.EqualError(t, err, "endpoint p0:7051 is already in use")
+}
+
+func TestCommSendAndRespond(t *testing.T) {
+	network := NewNetwork()
+	c1, id1 := newTestComm(t, network, "p1:7051")
+	defer c1.Stop()
+	c2, id2 := newTestComm(t, network, "p2:7051")
+	defer c2.Stop()
+
+	identity, err := c1.Handshake(&comm.RemotePeer{Endpoint: "p2:7051"})
+	assert.NoError(t, err)
+	assert.Equal(t, id2, identity)
+
+	inc2 := c2.Accept(acceptAll)
+	c1.Send(createGossipMsg(1), &comm.RemotePeer{Endpoint: "p2:7051", PKIID: c2.GetPKIid()})
+	select {
+	case msg := <-inc2:
+		assert.Equal(t, uint64(1), msg.GetGossipMessage().Nonce)
+		assert.Equal(t, id1, msg.GetConnectionInfo().Identity)
+		assert.Equal(t, "p1:7051", msg.GetConnectionInfo().Endpoint)
+		msg.Respond(createGossipMsg(2).GossipMessage)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Didn't receive message in time")
+	}
+
+	inc1 := c1.Accept(acceptAll)
+	select {
+	case msg := <-inc1:
+		assert.Equal(t, uint64(2), msg.GetGossipMessage().Nonce)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Didn't receive response in time")
+	}
+	assert.Len(t, c1.Connections(), 1)
+	assert.Len(t, c2.Connections(), 1)
+	assert.Equal(t, Stats{Sent: 2, Delivered: 2}, network.Stats())
+}
+
+func TestCommSendWithAck(t *testing.T) {
+	network := NewNetwork()
+	c1, _ := newTestComm(t, network, "p1:7051")
+	defer c1.Stop()
+	c2, _ := newTestComm(t, network, "p2:7051")
+	defer c2.Stop()
+	c3, _ := newTestComm(t, network, "p3:7051")
+	defer c3.Stop()
+
+	for _, c := range []comm.Comm{c2, c3} {
+		go func(inc <-chan proto.ReceivedMessage) {
+			for msg := range inc {
+				msg.Ack(nil)
+			}
+		}(c.Accept(acceptAll))
+	}
+
+	peers := []*comm.RemotePeer{
+		{Endpoint: "p2:7051", PKIID: c2.GetPKIid()},
+		{Endpoint: "p3:7051", PKIID: c3.GetPKIid()},
+	}
+	res := c1.SendWithAck(createGossipMsg(0), time.Second, 2, peers...)
+	assert.Equal(t, 2, res.AckCount())
+
+	network.Partition([]string{"p1:7051", "p2:7051"}, []string{"p3:7051"})
+	res = c1.SendWithAck(createGossipMsg(0), time.Second, 2, peers...)
+	assert.Equal(t, 1, res.AckCount())
+	assert.Equal(t, 1, res.NackCount())
+}
+
+func TestCommLatencyAndLoss(t *testing.T) {
+	network := NewNetwork()
+	c1, _ := newTestComm(t, network, "p1:7051")
+	defer c1.Stop()
+	c2, _ := newTestComm(t, network, "p2:7051")
+	defer c2.Stop()
+	inc2 := c2.Accept(acceptAll)
+	peer2 := &comm.RemotePeer{Endpoint: "p2:7051", PKIID: c2.GetPKIid()}
+
+	network.SetLinkConditions("p1:7051", "p2:7051", LinkConditions{Latency: 200 * time.Millisecond})
+	start := time.Now()
+	c1.Send(createGossipMsg(1), peer2)
+	select {
+	case <-inc2:
+		assert.True(t, time.Since(start) >= 200*time.Millisecond)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Didn't receive message in time")
+	}
+
+	network.SetLinkConditions("p1:7051", "p2:7051", LinkConditions{Loss: 1})
+	c1.Send(createGossipMsg(2), peer2)
+	select {
+	case <-inc2:
+		t.Fatal("Message should have been lost")
+	case <-time.After(time.Millisecond * 500):
+	}
+	assert.Equal(t, Stats{Sent: 2, Delivered: 1, Dropped: 1}, network.Stats())
+
+	network.ResetLinkConditions()
+	c1.Send(createGossipMsg(3), peer2)
+	select {
+	case msg := <-inc2:
+		assert.Equal(t, uint64(3), msg.GetGossipMessage().Nonce)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Didn't receive message in time")
+	}
+}
+
+func TestCommPartitionPresumesDead(t *testing.T) {
+	network := NewNetwork()
+	c1, _ := newTestComm(t, network, "p1:7051")
+	defer c1.Stop()
+	c2, _ := newTestComm(t, network, "p2:7051")
+	defer c2.Stop()
+
+	network.Partition([]string{"p1:7051"}, []string{"p2:7051"})
+	assert.Error(t, c1.Probe(&comm.RemotePeer{Endpoint: "p2:7051"}))
+	_, err := c1.Handshake(&comm.RemotePeer{Endpoint: "p2:7051"})
+	assert.EqualError(t, err, "p2:7051 is unreachable")
+
+	c1.Send(createGossipMsg(1), &comm.RemotePeer{Endpoint: "p2:7051", PKIID: c2.GetPKIid()})
+	select {
+	case pkiID := <-c1.PresumedDead():
+		assert.Equal(t, c2.GetPKIid(), pkiID)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Peer wasn't presumed dead in time")
+	}
+
+	network.Heal()
+	assert.NoError(t, c1.Probe(&comm.RemotePeer{Endpoint: "p2:7051"}))
+	c2.Stop()
+	assert.Error(t, c1.Probe(&comm.RemotePeer{Endpoint: "p2:7051"}))
+}
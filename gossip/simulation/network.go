@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LinkConditions describes the quality of a directed link between two simulated peers
+type LinkConditions struct {
+	// Latency is the time it takes a message to traverse the link
+	Latency time.Duration
+	// Jitter is the upper bound of a random delay that is added on top of the latency
+	Jitter time.Duration
+	// Loss is the probability, between 0 and 1, that a message sent over the link is lost
+	Loss float64
+}
+
+// Stats counts the messages that were sent over a simulated network
+type Stats struct {
+	// Sent is the number of messages that peers sent
+	Sent uint64
+	// Delivered is the number of messages that reached their destination
+	Delivered uint64
+	// Dropped is the number of messages that were lost, either randomly,
+	// due to a full send buffer, or because a partition was formed while they were in flight
+	Dropped uint64
+}
+
+type link struct {
+	from string
+	to   string
+}
+
+// Network is an in-memory network that connects simulated peers.
+// It applies programmable latency, loss and partitions to the messages they exchange.
+type Network struct {
+	lock       sync.RWMutex
+	nodes      map[string]*memComm
+	conditions LinkConditions
+	links      map[link]LinkConditions
+	partitions map[string]int
+
+	randLock sync.Mutex
+	random   *rand.Rand
+
+	sent      uint64
+	delivered uint64
+	dropped   uint64
+}
+
+// NewNetwork creates a new in-memory network with perfect links and no partitions
+func NewNetwork() *Network {
+	return &Network{
+		nodes:  make(map[string]*memComm),
+		links:  make(map[link]LinkConditions),
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetConditions sets the conditions of all links that weren't configured
+// by SetLinkConditions
+func (n *Network) SetConditions(conditions LinkConditions) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.conditions = conditions
+}
+
+// SetLinkConditions sets the conditions of the link from one endpoint to another.
+// Links are directed, so the conditions of the opposite direction are left untouched.
+func (n *Network) SetLinkConditions(from, to string, conditions LinkConditions) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.links[link{from: from, to: to}] = conditions
+}
+
+// ResetLinkConditions removes all the per link conditions that were set by SetLinkConditions
+func (n *Network) ResetLinkConditions() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.links = make(map[link]LinkConditions)
+}
+
+// Partition splits the network into the given groups of endpoints.
+// Endpoints can only communicate with endpoints in their own group,
+// and endpoints that are not listed in any group are isolated.
+func (n *Network) Partition(groups ...[]string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, endpoint := range group {
+			n.partitions[endpoint] = i
+		}
+	}
+}
+
+// Heal removes all partitions from the network
+func (n *Network) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.partitions = nil
+}
+
+// Stats returns the message counters of the network
+func (n *Network) Stats() Stats {
+	return Stats{
+		Sent:      atomic.LoadUint64(&n.sent),
+		Delivered: atomic.LoadUint64(&n.delivered),
+		Dropped:   atomic.LoadUint64(&n.dropped),
+	}
+}
+
+func (n *Network) register(c *memComm) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if _, exists := n.nodes[c.endpoint]; exists {
+		return false
+	}
+	n.nodes[c.endpoint] = c
+	return true
+}
+
+func (n *Network) deregister(c *memComm) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.nodes[c.endpoint] == c {
+		delete(n.nodes, c.endpoint)
+	}
+}
+
+func (n *Network) lookup(endpoint string) *memComm {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.nodes[endpoint]
+}
+
+// reachable returns whether a message can currently travel from one endpoint to another
+func (n *Network) reachable(from, to string) bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if n.partitions == nil {
+		return true
+	}
+	fromGroup, fromExists := n.partitions[from]
+	toGroup, toExists := n.partitions[to]
+	return fromExists && toExists && fromGroup == toGroup
+}
+
+func (n *Network) linkConditions(from, to string) LinkConditions {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if conditions, exists := n.links[link{from: from, to: to}]; exists {
+		return conditions
+	}
+	return n.conditions
+}
+
+// lost returns whether a message should be lost on a link with the given conditions
+func (n *Network) lost(conditions LinkConditions) bool {
+	if conditions.Loss <= 0 {
+		return false
+	}
+	n.randLock.Lock()
+	defer n.randLock.Unlock()
+	return n.random.Float64() < conditions.Loss
+}
+
+// delay returns the time it takes a message to traverse a link with the given conditions
+func (n *Network) delay(conditions LinkConditions) time.Duration {
+	if conditions.Jitter <= 0 {
+		return conditions.Latency
+	}
+	n.randLock.Lock()
+	defer n.randLock.Unlock()
+	return conditions.Latency + time.Duration(n.random.Int63n(int64(conditions.Jitter)))
+}
+
+func (n *Network) reportSent() {
+	atomic.AddUint64(&n.sent, 1)
+}
+
+func (n *Network) reportDelivered() {
+	atomic.AddUint64(&n.delivered, 1)
+}
+
+func (n *Network) reportDropped() {
+	atomic.AddUint64(&n.dropped, 1)
+}
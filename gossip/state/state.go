@@ -8,6 +8,7 @@ package state
 
 import (
 	"bytes"
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -63,6 +64,11 @@ type Configuration struct {
 	ChannelBufferSize               int
 	EnableStateTransfer             bool
 	BlockingMode                    bool
+	// EnableStreaming makes anti-entropy fetch missing blocks over block streams
+	// rather than over state requests, falling back to the latter upon failures
+	EnableStreaming bool
+	// StreamParallelism is the maximum number of peers blocks are streamed from at once
+	StreamParallelism int
 }
 
 // GossipAdapter defines gossip/communication required interface for state provider
@@ -109,6 +115,10 @@ type ledgerResources interface {
 	// StorePvtData used to persist private date into transient store
 	StorePvtData(txid string, privData *transientstore.TxPvtReadWriteSetWithConfigInfo, blckHeight uint64) error
 
+	// StorePvtDataShard stores an erasure-coded shard of private data
+	// until the corresponding block is committed
+	StorePvtDataShard(payload *proto.PrivatePayload) error
+
 	// GetPvtDataAndBlockByNum get block by number and returns also all related private data
 	// the order of private data in slice of PvtDataCollections doesn't imply the order of
 	// transactions in the block related to these private data, to get the correct placement
@@ -127,6 +137,8 @@ type ledgerResources interface {
 type ServicesMediator struct {
 	GossipAdapter
 	MCSAdapter
+	// BlockStreamer is optional, and is required for streaming blocks from remote peers
+	BlockStreamer
 }
 
 // GossipStateProviderImpl the implementation of the GossipStateProvider interface
@@ -347,6 +359,17 @@ func (s *GossipStateProviderImpl) privateDataMessage(msg proto.ReceivedMessage)
 		return
 	}
 
+	if pvtDataMsg.Payload.Shard != nil {
+		if err := s.ledger.StorePvtDataShard(pvtDataMsg.Payload); err != nil {
+			logger.Errorf("Wasn't able to store shard of private data for collection %s, due to %s", collectionName, err)
+			msg.Ack(err)
+			return
+		}
+		msg.Ack(nil)
+		logger.Debug("Shard", pvtDataMsg.Payload.Shard.Index, "of private data for collection", collectionName, "has been stored")
+		return
+	}
+
 	txPvtRwSet := &rwset.TxPvtReadWriteSet{
 		DataModel: rwset.TxReadWriteSet_KV,
 		NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
@@ -455,41 +478,14 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 			Signature: connInfo.Auth.Signature,
 			Identity:  connInfo.Identity,
 		}
-		block, pvtData, err := s.ledger.GetPvtDataAndBlockByNum(seqNum, peerAuthInfo)
-
+		payload, err := readPayload(s.ledger, seqNum, peerAuthInfo)
 		if err != nil {
-			logger.Errorf("cannot read block number %d from ledger, because %+v, skipping...", seqNum, err)
+			logger.Errorf("%+v, skipping...", err)
 			continue
 		}
 
-		if block == nil {
-			logger.Errorf("Wasn't able to read block with sequence number %d from ledger, skipping....", seqNum)
-			continue
-		}
-
-		blockBytes, err := pb.Marshal(block)
-
-		if err != nil {
-			logger.Errorf("Could not marshal block: %+v", errors.WithStack(err))
-			continue
-		}
-
-		var pvtBytes [][]byte
-		if pvtData != nil {
-			// Marshal private data
-			pvtBytes, err = pvtData.Marshal()
-			if err != nil {
-				logger.Errorf("Failed to marshal private rwset for block %d due to %+v", seqNum, errors.WithStack(err))
-				continue
-			}
-		}
-
 		// Appending result to the response
-		response.Payloads = append(response.Payloads, &proto.Payload{
-			SeqNum:      seqNum,
-			Data:        blockBytes,
-			PrivateData: pvtBytes,
-		})
+		response.Payloads = append(response.Payloads, payload)
 	}
 	// Sending back response with missing blocks
 	msg.Respond(&proto.GossipMessage{
@@ -636,6 +632,10 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 				continue
 			}
 
+			if s.config.EnableStreaming && s.mediator.BlockStreamer != nil {
+				s.streamBlocksInRange(uint64(ourHeight), uint64(maxHeight)-1)
+				continue
+			}
 			s.requestBlocksInRange(uint64(ourHeight), uint64(maxHeight)-1)
 		}
 	}
@@ -716,6 +716,115 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 	}
 }
 
+// streamBlocksInRange acquires blocks with sequence numbers in the range [start...end]
+// by streaming them from remote peers. The range is fetched in windows no longer than
+// the maximum block distance, each one partitioned into contiguous sub-ranges which
+// are streamed in parallel from different peers. Blocks which weren't streamed
+// successfully are requested through state requests.
+func (s *GossipStateProviderImpl) streamBlocksInRange(start uint64, end uint64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for prev := start; prev <= end; {
+		next := min(end, prev+uint64(s.config.MaxBlockDistance)-1)
+
+		peers := s.filterPeers(s.hasRequiredHeight(next + 1))
+		if len(peers) == 0 {
+			logger.Warningf("Cannot stream blocks in range [%d...%d], there are no peers to stream missing blocks from", prev, next)
+			return
+		}
+		parallelism := s.config.StreamParallelism
+		if parallelism < 1 {
+			parallelism = 1
+		}
+		if parallelism > len(peers) {
+			parallelism = len(peers)
+		}
+		ranges := partitionRange(prev, next, parallelism)
+		// Stream each sub-range from a different, randomly selected peer
+		indices := util.GetRandomIndices(len(ranges), len(peers)-1)
+
+		received := make([]uint64, len(ranges))
+		var wg sync.WaitGroup
+		for i, r := range ranges {
+			wg.Add(1)
+			go func(i int, r blockRange, peer *comm.RemotePeer) {
+				defer wg.Done()
+				received[i] = s.streamRange(ctx, peer, r)
+			}(i, r, peers[indices[i]])
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-s.stopCh:
+			s.stopCh <- struct{}{}
+			cancel()
+			<-done
+			return
+		}
+
+		for i, r := range ranges {
+			if received[i] > r.end {
+				continue
+			}
+			logger.Infof("Falling back to state requests for blocks in range [%d...%d]", received[i], r.end)
+			s.requestBlocksInRange(received[i], r.end)
+		}
+		prev = next + 1
+	}
+}
+
+// streamRange streams the blocks in the given range from the given peer, verifies
+// them and adds them to the payloads buffer. It returns the sequence number
+// of the first block of the range which wasn't streamed successfully.
+func (s *GossipStateProviderImpl) streamRange(ctx context.Context, peer *comm.RemotePeer, r blockRange) uint64 {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Abort the stream if the peer doesn't send blocks in time
+	timer := time.AfterFunc(s.config.AntiEntropyStateResponseTimeout, cancel)
+	defer timer.Stop()
+
+	logger.Debugf("State transfer, with peer %s, streaming blocks in range [%d...%d], "+
+		"for chainID %s", peer.Endpoint, r.start, r.end, s.chainID)
+
+	next := r.start
+	stream, err := s.mediator.StreamBlocks(ctx, peer, s.chainID, r.start, r.end)
+	if err != nil {
+		logger.Warningf("Failed streaming blocks in range [%d...%d] from %s, due to %+v", r.start, r.end, peer.Endpoint, err)
+		return next
+	}
+	defer stream.Close()
+
+	for next <= r.end {
+		batch, err := stream.Recv()
+		if err != nil {
+			logger.Warningf("Block stream from %s ended at block %d before block %d, due to %v", peer.Endpoint, next, r.end, err)
+			return next
+		}
+		timer.Stop()
+		for _, payload := range batch.Payloads {
+			if payload.SeqNum != next {
+				logger.Warningf("Expected block %d from %s, but got block %d", next, peer.Endpoint, payload.SeqNum)
+				return next
+			}
+			if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
+				logger.Warningf("Error verifying block with sequence number %d, due to %+v", payload.SeqNum, errors.WithStack(err))
+				return next
+			}
+			if err := s.addPayload(payload, Blocking); err != nil {
+				logger.Warningf("Block [%d] received from block stream wasn't added to payload buffer: %v", payload.SeqNum, err)
+			}
+			next++
+		}
+		timer.Reset(s.config.AntiEntropyStateResponseTimeout)
+	}
+	return next
+}
+
 // stateRequestMessage generates state request message for given blocks in range [beginSeq...endSeq]
 func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq uint64) *proto.GossipMessage {
 	return &proto.GossipMessage{
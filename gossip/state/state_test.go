@@ -210,6 +210,14 @@ func (*mockTransientStore) PurgeByTxids(txids []string) error {
 	panic("implement me")
 }
 
+func (*mockTransientStore) PersistShard(txid string, blockHeight uint64, shard *proto.PrivatePayload) error {
+	panic("implement me")
+}
+
+func (*mockTransientStore) GetShards(txid string) ([]*proto.PrivatePayload, error) {
+	panic("implement me")
+}
+
 type mockCommitter struct {
 	*mock.Mock
 	sync.Mutex
@@ -1355,6 +1363,11 @@ func (mock *coordinatorMock) StorePvtData(txid string, privData *transientstore2
 	return mock.Called().Error(0)
 }
 
+// StorePvtDataShard stores an erasure-coded shard of private data
+func (mock *coordinatorMock) StorePvtDataShard(payload *proto.PrivatePayload) error {
+	return mock.Called(payload).Error(0)
+}
+
 type receivedMessageMock struct {
 	mock.Mock
 }
@@ -0,0 +1,444 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sync"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	corecomm "github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+const (
+	DefStreamParallelism          = 3
+	DefStreamBatchSize            = 10
+	DefStreamMaxConcurrentStreams = 10
+	DefStreamDialTimeout          = 3 * time.Second
+	DefStreamTimeWindow           = 15 * time.Minute
+	DefStreamMaxBlockSize         = 100 * 1024 * 1024
+)
+
+// BlockSource defines the abilities of the ledger required to stream blocks to remote peers
+type BlockSource interface {
+	// GetPvtDataAndBlockByNum get block by number and returns also all related private data
+	GetPvtDataAndBlockByNum(seqNum uint64, peerAuthInfo common.SignedData) (*common.Block, util.PvtDataCollections, error)
+
+	// Get recent block sequence number
+	LedgerHeight() (uint64, error)
+}
+
+// BlockStreamer opens streams of blocks from remote peers
+type BlockStreamer interface {
+	// StreamBlocks opens a stream of the blocks of the given channel with
+	// sequence numbers in the range [start...end] from the given peer
+	StreamBlocks(ctx context.Context, peer *comm.RemotePeer, chainID string, start uint64, end uint64) (BlockStream, error)
+}
+
+// BlockStream is a stream of batches of blocks sent by a remote peer
+type BlockStream interface {
+	// Recv returns the next batch of blocks of the stream
+	Recv() (*proto.RemoteStateResponse, error)
+
+	// Close closes the stream
+	Close()
+}
+
+// StreamServerConfig keeps the configuration parameters of the block stream server
+type StreamServerConfig struct {
+	// BatchSize is the maximum number of blocks sent in a single response
+	BatchSize uint64
+	// MaxConcurrentStreams is the maximum number of streams served at once
+	MaxConcurrentStreams int
+	// TimeWindow is the maximum time difference between the timestamp
+	// of a request and the local time of the peer
+	TimeWindow time.Duration
+}
+
+// StreamServer serves streams of blocks of the channels registered
+// to it to remote peers which are eligible to read these channels
+type StreamServer struct {
+	mcs       MCSAdapter
+	config    StreamServerConfig
+	semaphore chan struct{}
+	lock      sync.RWMutex
+	sources   map[string]BlockSource
+
+	noncesLock sync.Mutex
+	// nonces holds the timestamps of the requests served within the
+	// time window, by the identity and the nonce of the request
+	nonces map[requestNonce]time.Time
+}
+
+type requestNonce struct {
+	identity string
+	nonce    uint64
+}
+
+// NewStreamServer creates a new block stream server
+func NewStreamServer(mcs MCSAdapter, config StreamServerConfig) *StreamServer {
+	return &StreamServer{
+		mcs:       mcs,
+		config:    config,
+		semaphore: make(chan struct{}, config.MaxConcurrentStreams),
+		sources:   make(map[string]BlockSource),
+		nonces:    make(map[requestNonce]time.Time),
+	}
+}
+
+// Register makes the blocks of the given channel available to remote peers
+func (s *StreamServer) Register(chainID string, source BlockSource) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sources[chainID] = source
+}
+
+// Deregister stops serving the blocks of the given channel
+func (s *StreamServer) Deregister(chainID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.sources, chainID)
+}
+
+func (s *StreamServer) source(chainID string) BlockSource {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.sources[chainID]
+}
+
+// StreamBlocks streams the blocks requested by the StateStreamRequest
+// in the given envelope, in batches
+func (s *StreamServer) StreamBlocks(env *proto.Envelope, stream proto.StateTransfer_StreamBlocksServer) error {
+	select {
+	case s.semaphore <- struct{}{}:
+		defer func() { <-s.semaphore }()
+	default:
+		return errors.Errorf("too many concurrent block streams (%d)", s.config.MaxConcurrentStreams)
+	}
+
+	request, err := s.authenticate(stream.Context(), env)
+	if err != nil {
+		logger.Warningf("Rejecting block stream request: %+v", err)
+		return err
+	}
+	chainID := string(request.Channel)
+	source := s.source(chainID)
+	if source == nil {
+		return errors.Errorf("channel %s is not served", chainID)
+	}
+
+	height, err := source.LedgerHeight()
+	if err != nil {
+		return errors.Wrap(err, "failed obtaining ledger height")
+	}
+	if request.StartSeqNum >= height {
+		return errors.Errorf("blocks [%d...%d] are not available, ledger height is %d", request.StartSeqNum, request.EndSeqNum, height)
+	}
+	end := min(request.EndSeqNum, height-1)
+
+	logger.Debugf("[%s] Streaming blocks [%d...%d] to %s", chainID, request.StartSeqNum, end, string(request.Identity))
+	peerAuthInfo := common.SignedData{
+		Data:      env.Payload,
+		Signature: env.Signature,
+		Identity:  request.Identity,
+	}
+	batch := &proto.RemoteStateResponse{}
+	for seqNum := request.StartSeqNum; seqNum <= end; seqNum++ {
+		payload, err := readPayload(source, seqNum, peerAuthInfo)
+		if err != nil {
+			return err
+		}
+		batch.Payloads = append(batch.Payloads, payload)
+		if uint64(len(batch.Payloads)) < s.config.BatchSize && seqNum < end {
+			continue
+		}
+		resp, err := marshalBatch(batch, request.Compress)
+		if err != nil {
+			return err
+		}
+		// Send blocks until the flow control window of the stream is exhausted,
+		// so blocks are read from the ledger as fast as the remote peer consumes them
+		if err := stream.Send(resp); err != nil {
+			return errors.WithStack(err)
+		}
+		batch = &proto.RemoteStateResponse{}
+	}
+	return nil
+}
+
+// authenticate verifies that the given envelope contains a StateStreamRequest
+// signed by an identity eligible to read the requested channel
+func (s *StreamServer) authenticate(ctx context.Context, env *proto.Envelope) (*proto.StateStreamRequest, error) {
+	if env == nil || len(env.Payload) == 0 {
+		return nil, errors.New("empty state stream request")
+	}
+	request := &proto.StateStreamRequest{}
+	if err := pb.Unmarshal(env.Payload, request); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling state stream request")
+	}
+	remoteCertHash := corecomm.ExtractCertificateHashFromContext(ctx)
+	if len(remoteCertHash) != 0 && !bytes.Equal(remoteCertHash, request.TlsCertHash) {
+		return nil, errors.Errorf("expected %v in remote hash of TLS cert, but got %v", remoteCertHash, request.TlsCertHash)
+	}
+	if err := s.mcs.VerifyByChannel(common2.ChainID(request.Channel), request.Identity, env.Signature, env.Payload); err != nil {
+		return nil, errors.WithMessage(err, "failed verifying state stream request")
+	}
+	if request.StartSeqNum > request.EndSeqNum {
+		return nil, errors.Errorf("invalid sequence interval [%d...%d]", request.StartSeqNum, request.EndSeqNum)
+	}
+	if err := s.checkFreshness(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// checkFreshness verifies that the request was created within the time window,
+// and that it was not served before, so captured requests cannot be replayed
+func (s *StreamServer) checkFreshness(request *proto.StateStreamRequest) error {
+	if request.Timestamp == nil {
+		return errors.New("state stream request has no timestamp")
+	}
+	requestTime, err := ptypes.Timestamp(request.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "invalid state stream request timestamp")
+	}
+	now := time.Now()
+	if math.Abs(float64(now.UnixNano()-requestTime.UnixNano())) > float64(s.config.TimeWindow.Nanoseconds()) {
+		return errors.Errorf("state stream request timestamp %s is more than %s apart from current time %s", requestTime, s.config.TimeWindow, now)
+	}
+
+	s.noncesLock.Lock()
+	defer s.noncesLock.Unlock()
+	// Requests created outside of the time window are rejected anyway,
+	// hence their nonces need not be remembered any longer
+	for n, t := range s.nonces {
+		if now.Sub(t) > s.config.TimeWindow {
+			delete(s.nonces, n)
+		}
+	}
+	n := requestNonce{identity: string(request.Identity), nonce: request.Nonce}
+	if _, exists := s.nonces[n]; exists {
+		return errors.Errorf("state stream request with nonce %d was already served", request.Nonce)
+	}
+	s.nonces[n] = requestTime
+	return nil
+}
+
+// readPayload reads the block with the given sequence number along with the
+// private data the given peer is eligible to, and wraps them into a payload
+func readPayload(source BlockSource, seqNum uint64, peerAuthInfo common.SignedData) (*proto.Payload, error) {
+	block, pvtData, err := source.GetPvtDataAndBlockByNum(seqNum, peerAuthInfo)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("cannot read block number %d from ledger", seqNum))
+	}
+	if block == nil {
+		return nil, errors.Errorf("wasn't able to read block with sequence number %d from ledger", seqNum)
+	}
+	blockBytes, err := pb.Marshal(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal block")
+	}
+	var pvtBytes [][]byte
+	if pvtData != nil {
+		pvtBytes, err = pvtData.Marshal()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal private rwset for block %d", seqNum)
+		}
+	}
+	return &proto.Payload{
+		SeqNum:      seqNum,
+		Data:        blockBytes,
+		PrivateData: pvtBytes,
+	}, nil
+}
+
+func marshalBatch(batch *proto.RemoteStateResponse, compress bool) (*proto.StateStreamResponse, error) {
+	raw, err := pb.Marshal(batch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling batch")
+	}
+	if !compress {
+		return &proto.StateStreamResponse{Batch: raw}, nil
+	}
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(raw); err != nil {
+		return nil, errors.Wrap(err, "failed compressing batch")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed compressing batch")
+	}
+	return &proto.StateStreamResponse{Batch: buf.Bytes(), Compressed: true}, nil
+}
+
+// unmarshalBatch unmarshals a batch, which is rejected if it's bigger than
+// maxSize bytes once decompressed
+func unmarshalBatch(resp *proto.StateStreamResponse, maxSize uint64) (*proto.RemoteStateResponse, error) {
+	raw := resp.Batch
+	if resp.Compressed {
+		r, err := gzip.NewReader(bytes.NewReader(resp.Batch))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing batch")
+		}
+		// Read one byte past the limit to tell a batch of exactly maxSize
+		// bytes from a bigger one
+		raw, err = ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing batch")
+		}
+	}
+	if uint64(len(raw)) > maxSize {
+		return nil, errors.Errorf("batch exceeds the maximum size of %d bytes", maxSize)
+	}
+	batch := &proto.RemoteStateResponse{}
+	if err := pb.Unmarshal(raw, batch); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling batch")
+	}
+	return batch, nil
+}
+
+// StreamClientConfig keeps the configuration parameters of the block stream client
+type StreamClientConfig struct {
+	DialTimeout time.Duration
+	// Compress asks remote peers to compress the blocks they stream
+	Compress bool
+	// BatchSize is the maximum number of blocks expected in a single response
+	BatchSize uint64
+	// MaxBlockSize is the maximum size in bytes of a block along with its
+	// private data. Responses bigger than BatchSize blocks of that size are
+	// rejected.
+	MaxBlockSize uint64
+}
+
+type streamClient struct {
+	signer      api.MessageCryptoService
+	identity    api.PeerIdentityType
+	tlsCertHash func() []byte
+	dialOpts    api.PeerSecureDialOpts
+	config      StreamClientConfig
+}
+
+// NewStreamClient creates a BlockStreamer which signs its requests with the given
+// message crypto service on behalf of the given identity, and binds them to the
+// TLS certificate whose hash is returned by tlsCertHash
+func NewStreamClient(signer api.MessageCryptoService, identity api.PeerIdentityType, tlsCertHash func() []byte,
+	dialOpts api.PeerSecureDialOpts, config StreamClientConfig) BlockStreamer {
+	if config.BatchSize == 0 {
+		config.BatchSize = DefStreamBatchSize
+	}
+	if config.MaxBlockSize == 0 {
+		config.MaxBlockSize = DefStreamMaxBlockSize
+	}
+	return &streamClient{
+		signer:      signer,
+		identity:    identity,
+		tlsCertHash: tlsCertHash,
+		dialOpts:    dialOpts,
+		config:      config,
+	}
+}
+
+// StreamBlocks opens a stream of the blocks of the given channel with
+// sequence numbers in the range [start...end] from the given peer
+func (c *streamClient) StreamBlocks(ctx context.Context, peer *comm.RemotePeer, chainID string, start uint64, end uint64) (BlockStream, error) {
+	request := &proto.StateStreamRequest{
+		Channel:     []byte(chainID),
+		StartSeqNum: start,
+		EndSeqNum:   end,
+		Identity:    c.identity,
+		TlsCertHash: c.tlsCertHash(),
+		Compress:    c.config.Compress,
+		Nonce:       util.RandomUInt64(),
+		Timestamp:   ptypes.TimestampNow(),
+	}
+	payload, err := pb.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling state stream request")
+	}
+	signature, err := c.signer.Sign(payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed signing state stream request")
+	}
+
+	dialOpts := append(c.dialOpts(), grpc.WithBlock())
+	dialCtx, cancel := context.WithTimeout(ctx, c.config.DialTimeout)
+	defer cancel()
+	cc, err := grpc.DialContext(dialCtx, peer.Endpoint, dialOpts...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	stream, err := proto.NewStateTransferClient(cc).StreamBlocks(ctx, &proto.Envelope{
+		Payload:   payload,
+		Signature: signature,
+	})
+	if err != nil {
+		cc.Close()
+		return nil, errors.WithStack(err)
+	}
+	maxBatchSize := min(end-start+1, c.config.BatchSize) * c.config.MaxBlockSize
+	return &blockStream{stream: stream, conn: cc, maxBatchSize: maxBatchSize}, nil
+}
+
+type blockStream struct {
+	stream       proto.StateTransfer_StreamBlocksClient
+	conn         *grpc.ClientConn
+	maxBatchSize uint64
+}
+
+func (bs *blockStream) Recv() (*proto.RemoteStateResponse, error) {
+	resp, err := bs.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBatch(resp, bs.maxBatchSize)
+}
+
+func (bs *blockStream) Close() {
+	bs.conn.Close()
+}
+
+// blockRange is the range of blocks with sequence numbers [start...end]
+type blockRange struct {
+	start uint64
+	end   uint64
+}
+
+// partitionRange splits the range [start...end] into at most n contiguous
+// sub-ranges whose sizes differ by at most one block
+func partitionRange(start uint64, end uint64, n int) []blockRange {
+	total := end - start + 1
+	if uint64(n) > total {
+		n = int(total)
+	}
+	size, remainder := total/uint64(n), total%uint64(n)
+	ranges := make([]blockRange, 0, n)
+	for i := 0; i < n; i++ {
+		length := size
+		if uint64(i) < remainder {
+			length++
+		}
+		ranges = append(ranges, blockRange{start: start, end: start + length - 1})
+		start += length
+	}
+	return ranges
+}
@@ -0,0 +1,478 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/state/mocks"
+	gutil "github.com/hyperledger/fabric/gossip/util"
+	pcomm "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+func TestPartitionRange(t *testing.T) {
+	for _, tc := range []struct {
+		start, end uint64
+		n          int
+		expected   []blockRange
+	}{
+		{start: 1, end: 1, n: 3, expected: []blockRange{{1, 1}}},
+		{start: 1, end: 2, n: 3, expected: []blockRange{{1, 1}, {2, 2}}},
+		{start: 1, end: 9, n: 3, expected: []blockRange{{1, 3}, {4, 6}, {7, 9}}},
+		{start: 10, end: 20, n: 3, expected: []blockRange{{10, 13}, {14, 17}, {18, 20}}},
+		{start: 5, end: 104, n: 1, expected: []blockRange{{5, 104}}},
+	} {
+		t.Run(fmt.Sprintf("[%d...%d]/%d", tc.start, tc.end, tc.n), func(t *testing.T) {
+			assert.Equal(t, tc.expected, partitionRange(tc.start, tc.end, tc.n))
+		})
+	}
+}
+
+func TestStreamBlocks(t *testing.T) {
+	t.Parallel()
+	chainID := "testchainid"
+	source := new(coordinatorMock)
+	source.On("LedgerHeight", mock.Anything).Return(uint64(26), nil)
+	for seqNum := uint64(0); seqNum < 26; seqNum++ {
+		source.On("GetPvtDataAndBlockByNum", seqNum).Return(pcomm.NewBlock(seqNum, []byte{}), gutil.PvtDataCollections{}, nil)
+	}
+
+	var acceptorErr error
+	var acceptorLock sync.Mutex
+	acceptor := func(identity api.PeerIdentityType) error {
+		acceptorLock.Lock()
+		defer acceptorLock.Unlock()
+		return acceptorErr
+	}
+
+	server := NewStreamServer(&cryptoServiceMock{acceptor: acceptor}, StreamServerConfig{
+		BatchSize:            10,
+		MaxConcurrentStreams: 1,
+		TimeWindow:           time.Minute,
+	})
+	server.Register(chainID, source)
+
+	lsnr, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	gRPCServer := grpc.NewServer()
+	proto.RegisterStateTransferServer(gRPCServer, server)
+	go gRPCServer.Serve(lsnr)
+	defer gRPCServer.Stop()
+
+	peer := &comm.RemotePeer{Endpoint: lsnr.Addr().String()}
+	newClientWithConfig := func(config StreamClientConfig) BlockStreamer {
+		return NewStreamClient(&cryptoServiceMock{}, api.PeerIdentityType("peer"), func() []byte { return nil },
+			func() []grpc.DialOption { return []grpc.DialOption{grpc.WithInsecure()} }, config)
+	}
+	newClient := func(compress bool) BlockStreamer {
+		return newClientWithConfig(StreamClientConfig{DialTimeout: time.Second, Compress: compress})
+	}
+
+	readAll := func(stream BlockStream) ([][]uint64, error) {
+		var batches [][]uint64
+		for {
+			batch, err := stream.Recv()
+			if err == io.EOF {
+				return batches, nil
+			}
+			if err != nil {
+				return batches, err
+			}
+			var seqNums []uint64
+			for _, payload := range batch.Payloads {
+				block := &pcomm.Block{}
+				assert.NoError(t, pb.Unmarshal(payload.Data, block))
+				assert.Equal(t, payload.SeqNum, block.Header.Number)
+				seqNums = append(seqNums, payload.SeqNum)
+			}
+			batches = append(batches, seqNums)
+		}
+	}
+
+	for _, compress := range []bool{false, true} {
+		t.Run(fmt.Sprintf("compress=%v", compress), func(t *testing.T) {
+			stream, err := newClient(compress).StreamBlocks(context.Background(), peer, chainID, 1, 25)
+			assert.NoError(t, err)
+			defer stream.Close()
+			batches, err := readAll(stream)
+			assert.NoError(t, err)
+			assert.Equal(t, [][]uint64{
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				{11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+				{21, 22, 23, 24, 25},
+			}, batches)
+		})
+	}
+
+	t.Run("beyond ledger height", func(t *testing.T) {
+		stream, err := newClient(true).StreamBlocks(context.Background(), peer, chainID, 24, 40)
+		assert.NoError(t, err)
+		defer stream.Close()
+		batches, err := readAll(stream)
+		assert.NoError(t, err)
+		assert.Equal(t, [][]uint64{{24, 25}}, batches)
+
+		stream, err = newClient(true).StreamBlocks(context.Background(), peer, chainID, 26, 40)
+		assert.NoError(t, err)
+		defer stream.Close()
+		_, err = readAll(stream)
+		assert.Contains(t, err.Error(), "blocks [26...40] are not available, ledger height is 26")
+	})
+
+	t.Run("batch too big", func(t *testing.T) {
+		for _, compress := range []bool{false, true} {
+			stream, err := newClientWithConfig(StreamClientConfig{
+				DialTimeout:  time.Second,
+				Compress:     compress,
+				BatchSize:    10,
+				MaxBlockSize: 1,
+			}).StreamBlocks(context.Background(), peer, chainID, 1, 5)
+			assert.NoError(t, err)
+			defer stream.Close()
+			batches, err := readAll(stream)
+			assert.Empty(t, batches)
+			assert.EqualError(t, err, "batch exceeds the maximum size of 5 bytes")
+		}
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		stream, err := newClient(false).StreamBlocks(context.Background(), peer, "otherchainid", 1, 5)
+		assert.NoError(t, err)
+		defer stream.Close()
+		_, err = readAll(stream)
+		assert.Contains(t, err.Error(), "channel otherchainid is not served")
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		acceptorLock.Lock()
+		acceptorErr = errors.New("identity isn't in the channel")
+		acceptorLock.Unlock()
+		defer func() {
+			acceptorLock.Lock()
+			acceptorErr = nil
+			acceptorLock.Unlock()
+		}()
+
+		stream, err := newClient(false).StreamBlocks(context.Background(), peer, chainID, 1, 5)
+		assert.NoError(t, err)
+		defer stream.Close()
+		batches, err := readAll(stream)
+		assert.Empty(t, batches)
+		assert.Contains(t, err.Error(), "failed verifying state stream request: identity isn't in the channel")
+	})
+
+	t.Run("deregistered channel", func(t *testing.T) {
+		server.Deregister(chainID)
+		defer server.Register(chainID, source)
+
+		stream, err := newClient(false).StreamBlocks(context.Background(), peer, chainID, 1, 5)
+		assert.NoError(t, err)
+		defer stream.Close()
+		_, err = readAll(stream)
+		assert.Contains(t, err.Error(), "channel testchainid is not served")
+	})
+}
+
+func TestUnmarshalBatchCompressionBomb(t *testing.T) {
+	t.Parallel()
+	batch := &proto.RemoteStateResponse{Payloads: []*proto.Payload{{SeqNum: 1, Data: make([]byte, 1024)}}}
+	resp, err := marshalBatch(batch, true)
+	assert.NoError(t, err)
+	size := uint64(pb.Size(batch))
+
+	unmarshaled, err := unmarshalBatch(resp, size)
+	assert.NoError(t, err)
+	assert.True(t, pb.Equal(batch, unmarshaled))
+	_, err = unmarshalBatch(resp, size-1)
+	assert.EqualError(t, err, fmt.Sprintf("batch exceeds the maximum size of %d bytes", size-1))
+
+	// 64MB of zeros compress to less than 256KB
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	_, err = w.Write(make([]byte, 64*1024*1024))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	assert.True(t, buf.Len() < 256*1024)
+
+	_, err = unmarshalBatch(&proto.StateStreamResponse{Batch: buf.Bytes(), Compressed: true}, 1024*1024)
+	assert.EqualError(t, err, "batch exceeds the maximum size of 1048576 bytes")
+}
+
+func TestStreamServerTooManyStreams(t *testing.T) {
+	server := NewStreamServer(&cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}, StreamServerConfig{
+		BatchSize:            10,
+		MaxConcurrentStreams: 1,
+		TimeWindow:           time.Minute,
+	})
+	server.semaphore <- struct{}{}
+	err := server.StreamBlocks(&proto.Envelope{}, nil)
+	assert.EqualError(t, err, "too many concurrent block streams (1)")
+}
+
+func TestStreamServerFreshness(t *testing.T) {
+	server := NewStreamServer(&cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}, StreamServerConfig{
+		BatchSize:            10,
+		MaxConcurrentStreams: 1,
+		TimeWindow:           time.Minute,
+	})
+
+	envelope := func(identity string, nonce uint64, timestamp time.Time) *proto.Envelope {
+		request := &proto.StateStreamRequest{
+			Channel:     []byte("testchainid"),
+			StartSeqNum: 1,
+			EndSeqNum:   5,
+			Identity:    []byte(identity),
+			Nonce:       nonce,
+		}
+		if !timestamp.IsZero() {
+			ts, err := ptypes.TimestampProto(timestamp)
+			assert.NoError(t, err)
+			request.Timestamp = ts
+		}
+		payload, err := pb.Marshal(request)
+		assert.NoError(t, err)
+		return &proto.Envelope{Payload: payload, Signature: []byte{1, 2, 3}}
+	}
+
+	_, err := server.authenticate(context.Background(), envelope("peer0", 1, time.Now()))
+	assert.NoError(t, err)
+
+	// A replayed request is rejected, while a request of another peer
+	// which happens to use the same nonce is served
+	_, err = server.authenticate(context.Background(), envelope("peer0", 1, time.Now()))
+	assert.EqualError(t, err, "state stream request with nonce 1 was already served")
+	_, err = server.authenticate(context.Background(), envelope("peer1", 1, time.Now()))
+	assert.NoError(t, err)
+
+	_, err = server.authenticate(context.Background(), envelope("peer0", 2, time.Time{}))
+	assert.EqualError(t, err, "state stream request has no timestamp")
+
+	_, err = server.authenticate(context.Background(), envelope("peer0", 2, time.Now().Add(-2*time.Minute)))
+	assert.Contains(t, err.Error(), "is more than 1m0s apart from current time")
+	_, err = server.authenticate(context.Background(), envelope("peer0", 2, time.Now().Add(2*time.Minute)))
+	assert.Contains(t, err.Error(), "is more than 1m0s apart from current time")
+
+	// Nonces of requests outside of the time window are forgotten
+	server.nonces[requestNonce{identity: "peer2", nonce: 3}] = time.Now().Add(-2 * time.Minute)
+	_, err = server.authenticate(context.Background(), envelope("peer0", 4, time.Now()))
+	assert.NoError(t, err)
+	assert.Len(t, server.nonces, 3)
+	assert.NotContains(t, server.nonces, requestNonce{identity: "peer2", nonce: 3})
+}
+
+// blockStreamerMock streams blocks to the state provider, and fails the streams
+// opened to the failing peer after they have sent their first block
+type blockStreamerMock struct {
+	lock      sync.Mutex
+	requested []blockRange
+	failing   string
+}
+
+func (bs *blockStreamerMock) StreamBlocks(ctx context.Context, peer *comm.RemotePeer, chainID string, start uint64, end uint64) (BlockStream, error) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	bs.requested = append(bs.requested, blockRange{start: start, end: end})
+	return &blockStreamMock{next: start, end: end, fail: peer.Endpoint == bs.failing}, nil
+}
+
+func (bs *blockStreamerMock) requests() []blockRange {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	requested := append([]blockRange{}, bs.requested...)
+	sort.Slice(requested, func(i, j int) bool {
+		return requested[i].start < requested[j].start
+	})
+	return requested
+}
+
+type blockStreamMock struct {
+	next uint64
+	end  uint64
+	fail bool
+	sent int
+}
+
+func (s *blockStreamMock) Recv() (*proto.RemoteStateResponse, error) {
+	if s.next > s.end {
+		return nil, io.EOF
+	}
+	if s.fail && s.sent > 0 {
+		return nil, errors.New("connection reset")
+	}
+	payload := blockPayload(s.next)
+	s.next++
+	s.sent++
+	return &proto.RemoteStateResponse{Payloads: []*proto.Payload{payload}}, nil
+}
+
+func (s *blockStreamMock) Close() {}
+
+func blockPayload(seqNum uint64) *proto.Payload {
+	b, _ := pb.Marshal(pcomm.NewBlock(seqNum, []byte{}))
+	return &proto.Payload{SeqNum: seqNum, Data: b}
+}
+
+func newStreamingStateProvider(streamer BlockStreamer, conf *Configuration, peers []discovery.NetworkMember) (*GossipStateProviderImpl, *mocks.GossipMock, chan proto.ReceivedMessage, *committedBlocks) {
+	g := &mocks.GossipMock{}
+	commChannel := make(chan proto.ReceivedMessage)
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, commChannel)
+	g.On("UpdateLedgerHeight", mock.Anything, mock.Anything)
+	g.On("PeersOfChannel", mock.Anything).Return(peers)
+
+	committed := &committedBlocks{}
+	coord := new(coordinatorMock)
+	coord.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+	coord.On("StoreBlock", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		committed.add(args.Get(0).(*pcomm.Block).Header.Number)
+	}).Return(nil, nil)
+	coord.On("Close")
+
+	mediator := &ServicesMediator{
+		GossipAdapter: g,
+		MCSAdapter:    &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor},
+		BlockStreamer: streamer,
+	}
+	stateMetrics := metrics.NewGossipMetrics(&disabled.Provider{}).StateMetrics
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, coord, stateMetrics, conf).(*GossipStateProviderImpl)
+	return s, g, commChannel, committed
+}
+
+type committedBlocks struct {
+	sync.Mutex
+	seqNums []uint64
+}
+
+func (c *committedBlocks) add(seqNum uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.seqNums = append(c.seqNums, seqNum)
+}
+
+func (c *committedBlocks) get() []uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return append([]uint64{}, c.seqNums...)
+}
+
+func streamingPeers(n int, height uint64) []discovery.NetworkMember {
+	var peers []discovery.NetworkMember
+	for i := 0; i < n; i++ {
+		peers = append(peers, discovery.NetworkMember{
+			Endpoint:   fmt.Sprintf("peer%d", i),
+			PKIid:      []byte(fmt.Sprintf("peer%d", i)),
+			Properties: &proto.Properties{LedgerHeight: height},
+		})
+	}
+	return peers
+}
+
+func expectedSeqNums(start, end uint64) []uint64 {
+	var seqNums []uint64
+	for seqNum := start; seqNum <= end; seqNum++ {
+		seqNums = append(seqNums, seqNum)
+	}
+	return seqNums
+}
+
+func TestStreamBlocksInRange(t *testing.T) {
+	t.Parallel()
+	conf := *config
+	// Anti-entropy is not expected to kick in during the test
+	conf.AntiEntropyInterval = time.Hour
+	conf.MaxBlockDistance = 10
+	conf.StreamParallelism = 2
+
+	streamer := &blockStreamerMock{}
+	s, _, _, committed := newStreamingStateProvider(streamer, &conf, streamingPeers(3, 31))
+	defer s.Stop()
+
+	s.streamBlocksInRange(1, 30)
+
+	// The range is fetched in windows of 10 blocks, each one split between 2 peers
+	assert.Equal(t, []blockRange{{1, 5}, {6, 10}, {11, 15}, {16, 20}, {21, 25}, {26, 30}}, streamer.requests())
+	waitUntilTrueOrTimeout(t, func() bool {
+		return len(committed.get()) == 30
+	}, 10*time.Second)
+	assert.Equal(t, expectedSeqNums(1, 30), committed.get())
+}
+
+func TestStreamBlocksInRangeFallback(t *testing.T) {
+	t.Parallel()
+	conf := *config
+	// Anti-entropy is not expected to kick in during the test
+	conf.AntiEntropyInterval = time.Hour
+	conf.StreamParallelism = 3
+
+	// A single peer, which fails all of its streams after the first block
+	streamer := &blockStreamerMock{failing: "peer0"}
+	s, g, commChannel, committed := newStreamingStateProvider(streamer, &conf, streamingPeers(1, 31))
+	defer s.Stop()
+
+	var requested []blockRange
+	var lock sync.Mutex
+	g.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		request := args.Get(0).(*proto.GossipMessage)
+		lock.Lock()
+		requested = append(requested, blockRange{
+			start: request.GetStateRequest().StartSeqNum,
+			end:   request.GetStateRequest().EndSeqNum,
+		})
+		lock.Unlock()
+
+		response := &proto.RemoteStateResponse{}
+		for seqNum := request.GetStateRequest().StartSeqNum; seqNum <= request.GetStateRequest().EndSeqNum; seqNum++ {
+			response.Payloads = append(response.Payloads, blockPayload(seqNum))
+		}
+		responseMsg := new(receivedMessageMock)
+		responseMsg.On("GetGossipMessage").Return(&proto.SignedGossipMessage{
+			GossipMessage: &proto.GossipMessage{
+				Nonce:   request.Nonce,
+				Channel: request.Channel,
+				Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+			},
+		})
+		go func() {
+			commChannel <- responseMsg
+		}()
+	})
+
+	s.streamBlocksInRange(1, 30)
+
+	// There is a single peer to stream from, so the range isn't partitioned
+	assert.Equal(t, []blockRange{{1, 30}}, streamer.requests())
+	// The blocks which weren't streamed are requested through state requests
+	lock.Lock()
+	assert.Equal(t, []blockRange{{2, 12}, {13, 23}, {24, 30}}, requested)
+	lock.Unlock()
+	waitUntilTrueOrTimeout(t, func() bool {
+		return len(committed.get()) == 30
+	}, 10*time.Second)
+	assert.Equal(t, expectedSeqNums(1, 30), committed.get())
+}
@@ -20,11 +20,13 @@ const (
 	DiscoveryLogger   = "gossip.discovery"
 	ElectionLogger    = "gossip.election"
 	GossipLogger      = "gossip.gossip"
+	IdentityLogger    = "gossip.identity"
 	CommMockLogger    = "gossip.comm.mock"
 	PullLogger        = "gossip.pull"
 	ServiceLogger     = "gossip.service"
 	StateLogger       = "gossip.state"
 	PrivateDataLogger = "gossip.privdata"
+	SimulationLogger  = "gossip.simulation"
 )
 
 var loggers = make(map[string]Logger)
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"time"
+)
+
+// smoothingFactor is the weight given to the most recent sample when
+// updating the moving averages of the adaptive timeout.
+const smoothingFactor = 0.2
+
+// AdaptiveTimeoutConfig bounds the batch timeout chosen by an adaptive Receiver.
+// The upper bound is always the channel's configured BatchTimeout.
+type AdaptiveTimeoutConfig struct {
+	// MinBatchTimeout is the lower bound of the effective batch timeout.
+	MinBatchTimeout time.Duration
+	// ArrivalGapFactor is the number of average inter-arrival gaps to wait
+	// for further messages when batches are not filling up.
+	ArrivalGapFactor float64
+}
+
+// adaptiveTimeout tracks exponentially weighted moving averages of the
+// message inter-arrival gap and of the time it takes to fill a batch, and
+// derives the effective batch timeout from them.
+type adaptiveTimeout struct {
+	config AdaptiveTimeoutConfig
+
+	lastArrival time.Time
+	avgGap      time.Duration
+	avgFill     time.Duration
+	// lastCutFull is true if the last batch was cut because it was full
+	// rather than because its timer expired.
+	lastCutFull bool
+}
+
+func newAdaptiveTimeout(config AdaptiveTimeoutConfig) *adaptiveTimeout {
+	return &adaptiveTimeout{config: config}
+}
+
+// observeArrival records that a message arrived at the given time.
+func (a *adaptiveTimeout) observeArrival(now time.Time) {
+	if !a.lastArrival.IsZero() {
+		a.avgGap = ewma(a.avgGap, now.Sub(a.lastArrival))
+	}
+	a.lastArrival = now
+}
+
+// observeCut records how long the batch took to fill and whether it was cut
+// because it reached its size limits.
+func (a *adaptiveTimeout) observeCut(fill time.Duration, full bool) {
+	a.lastCutFull = full
+	if full {
+		a.avgFill = ewma(a.avgFill, fill)
+	}
+}
+
+// timeout returns the batch timeout to use for a new batch, bounded by the
+// configured minimum and by max.
+//
+// While batches are being cut because they are full, there is enough load to
+// fill a block, so the timer should leave twice the average fill duration
+// before firing. Otherwise waiting only pays off as long as more messages
+// keep arriving, so the timer is set to a few average inter-arrival gaps.
+func (a *adaptiveTimeout) timeout(max time.Duration) time.Duration {
+	if a.avgGap == 0 {
+		return max
+	}
+
+	var t time.Duration
+	if a.lastCutFull && a.avgFill > 0 {
+		t = 2 * a.avgFill
+	} else {
+		t = time.Duration(a.config.ArrivalGapFactor * float64(a.avgGap))
+	}
+
+	switch {
+	case t > max:
+		return max
+	case t < a.config.MinBatchTimeout:
+		if a.config.MinBatchTimeout > max {
+			return max
+		}
+		return a.config.MinBatchTimeout
+	default:
+		return t
+	}
+}
+
+func ewma(avg, sample time.Duration) time.Duration {
+	if avg == 0 {
+		return sample
+	}
+	return time.Duration(smoothingFactor*float64(sample) + (1-smoothingFactor)*float64(avg))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveTimeout(t *testing.T) {
+	config := AdaptiveTimeoutConfig{
+		MinBatchTimeout:  10 * time.Millisecond,
+		ArrivalGapFactor: 4,
+	}
+	max := 2 * time.Second
+
+	t.Run("no traffic", func(t *testing.T) {
+		a := newAdaptiveTimeout(config)
+		assert.Equal(t, max, a.timeout(max))
+	})
+
+	t.Run("sparse arrivals", func(t *testing.T) {
+		a := newAdaptiveTimeout(config)
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			a.observeArrival(start.Add(time.Duration(i) * 100 * time.Millisecond))
+		}
+		assert.Equal(t, 400*time.Millisecond, a.timeout(max))
+	})
+
+	t.Run("bounded by the configured batch timeout", func(t *testing.T) {
+		a := newAdaptiveTimeout(config)
+		start := time.Now()
+		a.observeArrival(start)
+		a.observeArrival(start.Add(time.Second))
+		assert.Equal(t, max, a.timeout(max))
+	})
+
+	t.Run("bounded by the minimum batch timeout", func(t *testing.T) {
+		a := newAdaptiveTimeout(config)
+		start := time.Now()
+		a.observeArrival(start)
+		a.observeArrival(start.Add(time.Millisecond))
+		assert.Equal(t, 10*time.Millisecond, a.timeout(max))
+		assert.Equal(t, 5*time.Millisecond, a.timeout(5*time.Millisecond))
+	})
+
+	t.Run("full batches", func(t *testing.T) {
+		a := newAdaptiveTimeout(config)
+		start := time.Now()
+		a.observeArrival(start)
+		a.observeArrival(start.Add(time.Millisecond))
+		a.observeCut(300*time.Millisecond, true)
+		assert.Equal(t, 600*time.Millisecond, a.timeout(max))
+
+		a.observeCut(time.Second, false)
+		assert.Equal(t, 10*time.Millisecond, a.timeout(max))
+	})
+}
+
+func TestEWMA(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, ewma(0, 100*time.Millisecond))
+	assert.Equal(t, 120*time.Millisecond, ewma(100*time.Millisecond, 200*time.Millisecond))
+}
@@ -29,6 +29,10 @@ type Receiver interface {
 
 	// Cut returns the current batch and starts a new one
 	Cut() []*cb.Envelope
+
+	// BatchTimeout returns how long a newly started batch may stay pending
+	// before it should be cut.
+	BatchTimeout() time.Duration
 }
 
 type receiver struct {
@@ -39,6 +43,8 @@ type receiver struct {
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
+
+	adaptive *adaptiveTimeout
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -50,6 +56,18 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 	}
 }
 
+// NewAdaptiveReceiverImpl creates a Receiver implementation whose batch timeout
+// adapts to the observed message arrival rate and block fill duration, within
+// the bounds given by config and the channel's configured BatchTimeout.
+func NewAdaptiveReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics, config AdaptiveTimeoutConfig) Receiver {
+	return &receiver{
+		sharedConfigFetcher: sharedConfigFetcher,
+		Metrics:             metrics,
+		ChannelID:           channelID,
+		adaptive:            newAdaptiveTimeout(config),
+	}
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -67,6 +85,10 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //
 // Note that messageBatches can not be greater than 2.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
+	if r.adaptive != nil {
+		r.adaptive.observeArrival(time.Now())
+	}
+
 	if len(r.pendingBatch) == 0 {
 		// We are beginning a new batch, mark the time
 		r.PendingBatchStartTime = time.Now()
@@ -85,7 +107,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 		// cut pending batch, if it has any messages
 		if len(r.pendingBatch) > 0 {
-			messageBatch := r.Cut()
+			messageBatch := r.cut(true)
 			messageBatches = append(messageBatches, messageBatch)
 		}
 
@@ -103,7 +125,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	if messageWillOverflowBatchSizeBytes {
 		logger.Debugf("The current message, with %v bytes, will overflow the pending batch of %v bytes.", messageSizeBytes, r.pendingBatchSizeBytes)
 		logger.Debugf("Pending batch would overflow if current message is added, cutting batch now.")
-		messageBatch := r.Cut()
+		messageBatch := r.cut(true)
 		r.PendingBatchStartTime = time.Now()
 		messageBatches = append(messageBatches, messageBatch)
 	}
@@ -115,7 +137,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
-		messageBatch := r.Cut()
+		messageBatch := r.cut(true)
 		messageBatches = append(messageBatches, messageBatch)
 		pending = false
 	}
@@ -125,7 +147,34 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
-	r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
+	return r.cut(false)
+}
+
+// BatchTimeout returns the channel's configured BatchTimeout, or, for an
+// adaptive receiver, the effective timeout derived from recent traffic.
+func (r *receiver) BatchTimeout() time.Duration {
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+
+	if r.adaptive == nil {
+		return ordererConfig.BatchTimeout()
+	}
+
+	timeout := r.adaptive.timeout(ordererConfig.BatchTimeout())
+	logger.Debugf("[channel: %s] Effective batch timeout is %s", r.ChannelID, timeout)
+	return timeout
+}
+
+// cut returns the current batch and starts a new one, full indicates whether
+// the batch is cut because it reached the batch size limits.
+func (r *receiver) cut(full bool) []*cb.Envelope {
+	fillDuration := time.Since(r.PendingBatchStartTime)
+	r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(fillDuration.Seconds())
+	if r.adaptive != nil && len(r.pendingBatch) > 0 {
+		r.adaptive.observeCut(fillDuration, full)
+	}
 	r.PendingBatchStartTime = time.Time{}
 	batch := r.pendingBatch
 	r.pendingBatch = nil
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -183,4 +185,49 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 	})
+
+	Describe("BatchTimeout", func() {
+		BeforeEach(func() {
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   10,
+				PreferredMaxBytes: 100,
+			})
+		})
+
+		It("returns the configured batch timeout", func() {
+			Expect(bc.BatchTimeout()).To(Equal(2 * time.Second))
+		})
+
+		Context("when the receiver is adaptive", func() {
+			BeforeEach(func() {
+				bc = blockcutter.NewAdaptiveReceiverImpl("mychannel", fakeConfigFetcher, metrics, blockcutter.AdaptiveTimeoutConfig{
+					MinBatchTimeout:  10 * time.Millisecond,
+					ArrivalGapFactor: 4,
+				})
+			})
+
+			It("returns the configured batch timeout until messages arrive", func() {
+				Expect(bc.BatchTimeout()).To(Equal(2 * time.Second))
+			})
+
+			It("shortens the batch timeout when messages arrive quickly", func() {
+				message := &cb.Envelope{Payload: []byte("data")}
+				bc.Ordered(message)
+				bc.Ordered(message)
+				Expect(bc.BatchTimeout()).To(BeNumerically("<", 2*time.Second))
+				Expect(bc.BatchTimeout()).To(BeNumerically(">=", 10*time.Millisecond))
+			})
+		})
+
+		Context("when the orderer config cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeConfigFetcher.OrdererConfigReturns(nil, false)
+			})
+
+			It("panics", func() {
+				Expect(func() { bc.BatchTimeout() }).To(Panic())
+			})
+		})
+	})
 })
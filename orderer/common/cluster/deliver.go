@@ -47,6 +47,9 @@ type BlockPuller struct {
 	endpoint     string
 	conn         *grpc.ClientConn
 	cancelStream func()
+	// lackingBlocks maps endpoints to the highest sequence they were found to
+	// lack, as the blocks up to it have been pruned from their ledger.
+	lackingBlocks map[string]uint64
 }
 
 // Clone returns a copy of this BlockPuller initialized
@@ -61,6 +64,7 @@ func (p *BlockPuller) Clone() *BlockPuller {
 	copy.endpoint = ""
 	copy.conn = nil
 	copy.cancelStream = nil
+	copy.lackingBlocks = nil
 	return &copy
 }
 
@@ -176,6 +180,11 @@ func (p *BlockPuller) pullBlocks(seq uint64, reConnected bool) error {
 		}
 
 		block, err := extractBlockFromResponse(resp)
+		if err == ErrBlockPruned {
+			p.Logger.Warningf("%s has pruned block [%d], it will not be pulled from for blocks up to it", p.endpoint, nextExpectedSequence)
+			p.markLackingBlock(p.endpoint, nextExpectedSequence)
+			return err
+		}
 		if err != nil {
 			p.Logger.Errorf("Received a bad block from %s: %v", p.endpoint, err)
 			return err
@@ -233,6 +242,15 @@ func (p *BlockPuller) popBlock(seq uint64) *common.Block {
 	return block
 }
 
+func (p *BlockPuller) markLackingBlock(endpoint string, seq uint64) {
+	if p.lackingBlocks == nil {
+		p.lackingBlocks = make(map[string]uint64)
+	}
+	if lackingSeq, exists := p.lackingBlocks[endpoint]; !exists || seq > lackingSeq {
+		p.lackingBlocks[endpoint] = seq
+	}
+}
+
 func (p *BlockPuller) isDisconnected() bool {
 	return p.conn == nil
 }
@@ -248,6 +266,18 @@ func (p *BlockPuller) connectToSomeEndpoint(minRequestedSequence uint64) {
 		return
 	}
 
+	// Skip the endpoints which pruned the requested block
+	for endpoint, endpointInfo := range endpointsInfo {
+		if lackingSeq, exists := p.lackingBlocks[endpoint]; exists && minRequestedSequence <= lackingSeq {
+			endpointInfo.conn.Close()
+			delete(endpointsInfo, endpoint)
+		}
+	}
+	if len(endpointsInfo) == 0 {
+		p.Logger.Warningf("None of the endpoints of %v has block [%d] anymore", p.Endpoints, minRequestedSequence)
+		return
+	}
+
 	// Choose a random endpoint out of the available endpoints
 	chosenEndpoint := randomEndpoint(endpointsInfo)
 	// Disconnect all connections but this endpoint
@@ -419,6 +449,9 @@ func extractBlockFromResponse(resp *orderer.DeliverResponse) (*common.Block, err
 		if t.Status == common.Status_SERVICE_UNAVAILABLE {
 			return nil, ErrServiceUnavailable
 		}
+		if t.Status == common.Status_GONE {
+			return nil, ErrBlockPruned
+		}
 		return nil, errors.Errorf("faulty node, received: %v", resp)
 	default:
 		return nil, errors.Errorf("response is of type %v, but expected a block", reflect.TypeOf(resp.Type))
@@ -631,6 +631,53 @@ func TestBlockPullerFailover(t *testing.T) {
 	dialer.assertAllConnectionsClosed(t)
 }
 
+func TestBlockPullerSkipsPrunedEndpoint(t *testing.T) {
+	// Scenario:
+	// The block puller is expected to pull blocks 1 to 3.
+	// There are two ordering nodes, node 1 has pruned block 1,
+	// and node 2 initially lags behind, so the block puller
+	// first connects to node 1, which replies that block 1 is gone.
+	// The block puller is then expected to skip node 1,
+	// and pull the blocks from node 2.
+
+	osn1 := newClusterNode(t)
+	defer osn1.stop()
+
+	osn1.addExpectProbeAssert()
+	osn1.addExpectPullAssert(1)
+	osn1.addExpectProbeAssert()
+	osn1.enqueueResponse(3)
+	osn1.blocks() <- &orderer.DeliverResponse{
+		Type: &orderer.DeliverResponse_Status{Status: common.Status_GONE},
+	}
+	// Close the deliver stream of node 1
+	osn1.blocks() <- nil
+	osn1.enqueueResponse(3)
+
+	osn2 := newClusterNode(t)
+	defer osn2.stop()
+
+	osn2.addExpectProbeAssert()
+	osn2.addExpectProbeAssert()
+	osn2.addExpectPullAssert(1)
+	// Node 2 lags behind at the first probe
+	osn2.enqueueResponse(0)
+	osn2.enqueueResponse(3)
+	for i := 1; i <= 3; i++ {
+		osn2.enqueueResponse(uint64(i))
+	}
+
+	dialer := newCountingDialer()
+	bp := newBlockPuller(dialer, osn1.srv.Address(), osn2.srv.Address())
+
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, uint64(i), bp.PullBlock(uint64(i)).Header.Number)
+	}
+
+	bp.Close()
+	dialer.assertAllConnectionsClosed(t)
+}
+
 func TestBlockPullerNoneResponsiveOrderer(t *testing.T) {
 	// Scenario: There are two ordering nodes, and the block puller
 	// connects to one of them.
@@ -919,6 +966,13 @@ func TestBlockPullerBadBlocks(t *testing.T) {
 		return resp
 	}
 
+	notFoundStatusType := func(resp *orderer.DeliverResponse) *orderer.DeliverResponse {
+		resp.Type = &orderer.DeliverResponse_Status{
+			Status: common.Status_NOT_FOUND,
+		}
+		return resp
+	}
+
 	changeSequence := func(resp *orderer.DeliverResponse) *orderer.DeliverResponse {
 		resp.GetBlock().Header.Number = 3
 		return resp
@@ -954,6 +1008,11 @@ func TestBlockPullerBadBlocks(t *testing.T) {
 			corruptBlock:   statusType,
 			expectedErrMsg: "faulty node, received: status:INTERNAL_SERVER_ERROR ",
 		},
+		{
+			name:           "not found status",
+			corruptBlock:   notFoundStatusType,
+			expectedErrMsg: "faulty node, received: status:NOT_FOUND ",
+		},
 		{
 			name:           "wrong number",
 			corruptBlock:   changeSequence,
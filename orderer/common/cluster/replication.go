@@ -430,6 +430,10 @@ var ErrForbidden = errors.New("forbidden pulling the channel")
 // ErrServiceUnavailable denotes that an ordering node is not servicing at the moment.
 var ErrServiceUnavailable = errors.New("service unavailable")
 
+// ErrBlockPruned denotes that an ordering node no longer has the requested block,
+// as it has been pruned from its ledger.
+var ErrBlockPruned = errors.New("block has been pruned")
+
 // ErrNotInChannel denotes that an ordering node is not in the channel
 var ErrNotInChannel = errors.New("not in the channel")
 
@@ -56,6 +56,8 @@ type General struct {
 	LocalMSPID        string
 	BCCSP             *bccsp.FactoryOpts
 	Authentication    Authentication
+	BlockCutter       BlockCutter
+	Deduplication     Deduplication
 }
 
 type Cluster struct {
@@ -109,6 +111,26 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// BlockCutter contains configuration for the block cutter.
+type BlockCutter struct {
+	AdaptiveBatchTimeout AdaptiveBatchTimeout
+}
+
+// AdaptiveBatchTimeout contains configuration for deriving the effective
+// batch timeout from the observed transaction arrival rate.
+type AdaptiveBatchTimeout struct {
+	Enabled          bool
+	MinBatchTimeout  time.Duration
+	ArrivalGapFactor float64
+}
+
+// Deduplication contains configuration for rejecting messages whose
+// transaction ID was already ordered in a recent block.
+type Deduplication struct {
+	Enabled     bool
+	BlockWindow uint32
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -117,8 +139,23 @@ type Profile struct {
 
 // FileLedger contains configuration for the file-based ledger.
 type FileLedger struct {
-	Location string
-	Prefix   string
+	Location  string
+	Prefix    string
+	Retention LedgerRetention
+}
+
+// LedgerRetention contains the retention policies of the file-based ledger.
+// The Default policy applies to the channels not listed in Channels.
+type LedgerRetention struct {
+	Default  RetentionPolicy
+	Channels map[string]RetentionPolicy
+}
+
+// RetentionPolicy bounds the blocks kept by the ledger of a channel. Zero
+// values are not enforced.
+type RetentionPolicy struct {
+	Blocks uint64
+	Bytes  uint64
 }
 
 // RAMLedger contains configuration for the RAM ledger.
@@ -241,6 +278,17 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		BlockCutter: BlockCutter{
+			AdaptiveBatchTimeout: AdaptiveBatchTimeout{
+				Enabled:          false,
+				MinBatchTimeout:  50 * time.Millisecond,
+				ArrivalGapFactor: 4,
+			},
+		},
+		Deduplication: Deduplication{
+			Enabled:     false,
+			BlockWindow: 100,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -401,6 +449,17 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.General.BlockCutter.AdaptiveBatchTimeout.Enabled && c.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout == 0:
+			logger.Infof("General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout unset, setting to %s", Defaults.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout)
+			c.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout = Defaults.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout
+		case c.General.BlockCutter.AdaptiveBatchTimeout.Enabled && c.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor <= 0:
+			logger.Infof("General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor unset, setting to %v", Defaults.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor)
+			c.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor = Defaults.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor
+
+		case c.General.Deduplication.Enabled && c.General.Deduplication.BlockWindow == 0:
+			logger.Infof("General.Deduplication.BlockWindow unset, setting to %d", Defaults.General.Deduplication.BlockWindow)
+			c.General.Deduplication.BlockWindow = Defaults.General.Deduplication.BlockWindow
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
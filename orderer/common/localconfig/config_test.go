@@ -162,6 +162,15 @@ func TestClusterDefaults(t *testing.T) {
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
 }
 
+func TestAdaptiveBatchTimeoutDefaults(t *testing.T) {
+	uconf := &TopLevel{}
+	uconf.General.BlockCutter.AdaptiveBatchTimeout.Enabled = true
+	uconf.completeInitialization("/dummy/path")
+
+	assert.Equal(t, Defaults.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout, uconf.General.BlockCutter.AdaptiveBatchTimeout.MinBatchTimeout)
+	assert.Equal(t, Defaults.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor, uconf.General.BlockCutter.AdaptiveBatchTimeout.ArrivalGapFactor)
+}
+
 func TestSystemChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -235,3 +244,16 @@ func TestConnectionTimeout(t *testing.T) {
 		assert.Equal(t, cfg.General.ConnectionTimeout, 10*time.Second)
 	})
 }
+
+func TestFileLedgerRetention(t *testing.T) {
+	envVar := "ORDERER_FILELEDGER_RETENTION_DEFAULT_BYTES"
+	os.Setenv(envVar, "10 GB")
+	defer os.Unsetenv(envVar)
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, RetentionPolicy{Bytes: 10 * 1024 * 1024 * 1024}, cfg.FileLedger.Retention.Default)
+	assert.Empty(t, cfg.FileLedger.Retention.Channels)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateTxID is returned by the deduplication filter on rejection.
+var ErrDuplicateTxID = errors.New("duplicate transaction ID")
+
+// TxIDIndex tracks the transaction IDs contained in the recent blocks of a channel.
+type TxIDIndex interface {
+	// Contains returns whether the transaction ID appears in one of the indexed blocks
+	Contains(txID string) bool
+}
+
+// NewDedupFilter creates a filter which rejects messages whose transaction ID
+// was already ordered in one of the blocks tracked by the given index.
+func NewDedupFilter(index TxIDIndex) *DedupRule {
+	return &DedupRule{index: index}
+}
+
+// DedupRule implements the Rule interface.
+type DedupRule struct {
+	index TxIDIndex
+}
+
+// Apply returns an error if the transaction ID of the message was seen in a recent block.
+// Messages without a transaction ID, such as config updates, are always accepted.
+func (r *DedupRule) Apply(message *common.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract channel header")
+	}
+
+	if chdr.TxId == "" {
+		return nil
+	}
+
+	if r.index.Contains(chdr.TxId) {
+		return errors.Wrapf(ErrDuplicateTxID, "transaction %s was already ordered", chdr.TxId)
+	}
+	return nil
+}
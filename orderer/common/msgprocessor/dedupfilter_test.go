@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTxIDIndex map[string]struct{}
+
+func (m mockTxIDIndex) Contains(txID string) bool {
+	_, ok := m[txID]
+	return ok
+}
+
+func makeTxIDEnvelope(txID string) *cb.Envelope {
+	chdr := utils.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, "mychannel", 0)
+	chdr.TxId = txID
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: utils.MakePayloadHeader(chdr, &cb.SignatureHeader{}),
+		}),
+	}
+}
+
+func TestDedupRule(t *testing.T) {
+	df := NewDedupFilter(mockTxIDIndex{"seen": {}})
+
+	t.Run("New TxID", func(t *testing.T) {
+		assert.NoError(t, df.Apply(makeTxIDEnvelope("unseen")))
+	})
+
+	t.Run("Duplicate TxID", func(t *testing.T) {
+		err := df.Apply(makeTxIDEnvelope("seen"))
+		assert.EqualError(t, err, "transaction seen was already ordered: duplicate transaction ID")
+		assert.Equal(t, ErrDuplicateTxID, errors.Cause(err))
+	})
+
+	t.Run("Empty TxID", func(t *testing.T) {
+		assert.NoError(t, df.Apply(makeTxIDEnvelope("")))
+	})
+
+	t.Run("Malformed Payload", func(t *testing.T) {
+		err := df.Apply(&cb.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not extract channel header")
+	})
+}
@@ -57,7 +57,9 @@ func NewStandardChannel(support StandardChannelSupport, filters *RuleSet) *Stand
 //
 // In maintenance mode, require the signature of /Channel/Orderer/Writer. This will filter out configuration
 // changes that are not related to consensus-type migration (e.g on /Channel/Application).
-func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config localconfig.TopLevel) *RuleSet {
+//
+// If txIDIndex is not nil, messages whose transaction ID appears in the index are rejected.
+func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config localconfig.TopLevel, txIDIndex TxIDIndex) *RuleSet {
 	rules := []Rule{
 		EmptyRejectRule,
 		NewSizeFilter(filterSupport),
@@ -69,6 +71,10 @@ func CreateStandardChannelFilters(filterSupport channelconfig.Resources, config
 		rules = append(rules[:2], append([]Rule{expirationRule}, rules[2:]...)...)
 	}
 
+	if txIDIndex != nil {
+		rules = append(rules, NewDedupFilter(txIDIndex))
+	}
+
 	return NewRuleSet(rules)
 }
 
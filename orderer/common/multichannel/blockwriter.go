@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -39,6 +40,7 @@ type BlockWriter struct {
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
 	committingBlock    sync.Mutex
+	signatureCollector consensus.SignatureCollector
 }
 
 func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSupport) *BlockWriter {
@@ -175,6 +177,15 @@ func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte)
 	}()
 }
 
+// SetSignatureCollector makes the blocks written from now on carry the signatures
+// of other orderers collected by the collector, or only the signature of this
+// orderer if the collector is nil.
+func (bw *BlockWriter) SetSignatureCollector(collector consensus.SignatureCollector) {
+	bw.committingBlock.Lock()
+	defer bw.committingBlock.Unlock()
+	bw.signatureCollector = collector
+}
+
 // commitBlock should only ever be invoked with the bw.committingBlock held
 // this ensures that the encoded config sequence numbers stay in sync
 func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
@@ -184,7 +195,10 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 	}
 
 	bw.addLastConfigSignature(bw.lastBlock)
-	bw.addBlockSignature(bw.lastBlock)
+	if !bw.addBlockSignature(bw.lastBlock) {
+		logger.Warningf("[channel: %s] Collection of the signatures of block [%d] was aborted, not writing it", bw.support.ChainID(), bw.lastBlock.Header.Number)
+		return
+	}
 
 	err := bw.support.Append(bw.lastBlock)
 	if err != nil {
@@ -193,7 +207,9 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 	logger.Debugf("[channel: %s] Wrote block [%d]", bw.support.ChainID(), bw.lastBlock.GetHeader().Number)
 }
 
-func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
+// addBlockSignature signs the block, and returns false if the signatures of
+// other orderers it must carry couldn't be collected.
+func (bw *BlockWriter) addBlockSignature(block *cb.Block) bool {
 	blockSignature := &cb.MetadataSignature{
 		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(bw.support)),
 	}
@@ -205,12 +221,20 @@ func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
 
 	blockSignature.Signature = utils.SignOrPanic(bw.support, util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, block.Header.Bytes()))
 
+	signatures := []*cb.MetadataSignature{blockSignature}
+	if bw.signatureCollector != nil {
+		var ok bool
+		signatures, ok = bw.signatureCollector.Collect(block, blockSignatureValue, blockSignature)
+		if !ok {
+			return false
+		}
+	}
+
 	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&cb.Metadata{
-		Value: blockSignatureValue,
-		Signatures: []*cb.MetadataSignature{
-			blockSignature,
-		},
+		Value:      blockSignatureValue,
+		Signatures: signatures,
 	})
+	return true
 }
 
 func (bw *BlockWriter) addLastConfigSignature(block *cb.Block) {
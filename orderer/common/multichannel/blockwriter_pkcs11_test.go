@@ -0,0 +1,119 @@
+// +build pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	"github.com/hyperledger/fabric/common/localmsp"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
+	cryptogenmsp "github.com/hyperledger/fabric/common/tools/cryptogen/msp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBlockSignatureWithPKCS11Key signs a block with the local signer of the
+// orderer, backed by a local MSP whose BCCSP is PKCS11, the same way as the
+// orderer does when General.BCCSP is PKCS11.
+func TestBlockSignatureWithPKCS11Key(t *testing.T) {
+	lib, pin, label := pkcs11.FindPKCS11Lib()
+	bccspConfig := &factory.FactoryOpts{
+		ProviderName: "PKCS11",
+		Pkcs11Opts: &pkcs11.PKCS11Opts{
+			SecLevel:   256,
+			HashFamily: "SHA2",
+			Library:    lib,
+			Pin:        pin,
+			Label:      label,
+		},
+	}
+	require.NoError(t, factory.InitFactories(bccspConfig))
+	hsm := factory.GetDefault()
+
+	// The signing key of the orderer is generated in, and never leaves, the HSM
+	key, err := hsm.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	assert.True(t, key.Private())
+	pubKey, err := csp.GetECPublicKey(key)
+	require.NoError(t, err)
+
+	mspDir, err := ioutil.TempDir("", "pkcs11-msp")
+	require.NoError(t, err)
+	defer os.RemoveAll(mspDir)
+
+	signCA, err := ca.NewCA(filepath.Join(mspDir, "ca"), "example.com", "ca.example.com", "US", "California", "San Francisco", "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, cryptogenmsp.GenerateVerifyingMSP(mspDir, signCA, signCA, false))
+	for _, dir := range []string{"signcerts", "keystore"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(mspDir, dir), 0755))
+	}
+	_, err = signCA.SignCertificate(filepath.Join(mspDir, "signcerts"), "orderer.example.com", nil, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	require.NoError(t, err)
+
+	err = mspmgmt.LoadLocalMspWithType(mspDir, bccspConfig, "OrdererMSP", msp.ProviderTypeToString(msp.FABRIC))
+	require.NoError(t, err)
+
+	rlf := ramledger.New(2)
+	l, err := rlf.GetOrCreate("mychannel")
+	require.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: localmsp.NewSigner(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+		},
+		lastBlock: cb.NewBlock(1, lastBlock.Header.Hash()),
+	}
+	bw.commitBlock(nil)
+
+	it, _ := l.Iterator(&orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{}})
+	committedBlock, status := it.Next()
+	require.Equal(t, cb.Status_SUCCESS, status)
+
+	md := utils.GetMetadataFromBlockOrPanic(committedBlock, cb.BlockMetadataIndex_SIGNATURES)
+	require.Len(t, md.Signatures, 1)
+	signatureHeader, err := utils.GetSignatureHeader(md.Signatures[0].SignatureHeader)
+	require.NoError(t, err)
+
+	// The signature is verified by the identity of the orderer, as peers and
+	// other orderers do with the channel MSPs.
+	identity, err := mspmgmt.GetLocalMSP().DeserializeIdentity(signatureHeader.Creator)
+	require.NoError(t, err)
+	require.NoError(t, identity.Validate())
+	signedBytes := util.ConcatenateBytes(md.Value, md.Signatures[0].SignatureHeader, committedBlock.Header.Bytes())
+	assert.NoError(t, identity.Verify(signedBytes, md.Signatures[0].Signature))
+
+	// The key of the signature is the one in the HSM
+	digest, err := hsm.Hash(signedBytes, &bccsp.SHA256Opts{})
+	require.NoError(t, err)
+	pkcs11PubKey, err := key.PublicKey()
+	require.NoError(t, err)
+	valid, err := hsm.Verify(pkcs11PubKey, md.Signatures[0].Signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -9,6 +9,7 @@ package multichannel
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -94,6 +95,68 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+type mockSignatureCollector struct {
+	value      []byte
+	signature  *cb.MetadataSignature
+	signatures []*cb.MetadataSignature
+	aborted    bool
+}
+
+func (msc *mockSignatureCollector) Collect(block *cb.Block, value []byte, signature *cb.MetadataSignature) ([]*cb.MetadataSignature, bool) {
+	msc.value = value
+	msc.signature = signature
+	if msc.aborted {
+		return nil, false
+	}
+	return append([]*cb.MetadataSignature{signature}, msc.signatures...), true
+}
+
+func TestBlockSignatureCollector(t *testing.T) {
+	rlf := ramledger.New(3)
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := cb.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	collector := &mockSignatureCollector{
+		signatures: []*cb.MetadataSignature{
+			{SignatureHeader: []byte("header2"), Signature: []byte("signature2")},
+			{SignatureHeader: []byte("header3"), Signature: []byte("signature3")},
+		},
+	}
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+			Validator:   &mockconfigtx.Validator{},
+			ReadWriter:  l,
+		},
+		lastBlock: cb.NewBlock(1, lastBlock.Header.Hash()),
+	}
+	bw.SetSignatureCollector(collector)
+
+	bw.commitBlock(nil)
+	assert.Equal(t, uint64(2), l.Height())
+	committedBlock := blockledger.GetBlock(l, 1)
+	md := utils.GetMetadataFromBlockOrPanic(committedBlock, cb.BlockMetadataIndex_SIGNATURES)
+	assert.Equal(t, collector.value, md.Value)
+	assert.Len(t, md.Signatures, 3)
+	for i, signature := range []*cb.MetadataSignature{collector.signature, collector.signatures[0], collector.signatures[1]} {
+		assert.True(t, proto.Equal(signature, md.Signatures[i]))
+	}
+
+	collector.aborted = true
+	bw.lastBlock = cb.NewBlock(2, committedBlock.Header.Hash())
+	bw.commitBlock(nil)
+	assert.Equal(t, uint64(2), l.Height(), "a block whose signatures weren't collected isn't written")
+
+	collector.aborted = false
+	bw.SetSignatureCollector(nil)
+	bw.commitBlock(nil)
+	assert.Equal(t, uint64(3), l.Height())
+	md = utils.GetMetadataFromBlockOrPanic(blockledger.GetBlock(l, 2), cb.BlockMetadataIndex_SIGNATURES)
+	assert.Len(t, md.Signatures, 1)
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
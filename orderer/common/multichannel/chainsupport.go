@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -27,6 +30,9 @@ type ChainSupport struct {
 	consensus.Chain
 	cutter blockcutter.Receiver
 	crypto.LocalSigner
+
+	// txIDIndex is nil unless deduplication is enabled
+	txIDIndex *txIDIndex
 }
 
 func newChainSupport(
@@ -49,15 +55,18 @@ func newChainSupport(
 	cs := &ChainSupport{
 		ledgerResources: ledgerResources,
 		LocalSigner:     signer,
-		cutter: blockcutter.NewReceiverImpl(
-			ledgerResources.ConfigtxValidator().ChainID(),
-			ledgerResources,
-			blockcutterMetrics,
-		),
+		cutter:          newBlockCutter(ledgerResources, registrar.config.General.BlockCutter, blockcutterMetrics),
+	}
+
+	// Set up the transaction ID index used for deduplication
+	var txIDIndex msgprocessor.TxIDIndex
+	if dedup := registrar.config.General.Deduplication; dedup.Enabled {
+		cs.txIDIndex = rebuildTxIDIndex(dedup.BlockWindow, ledgerResources)
+		txIDIndex = cs.txIDIndex
 	}
 
 	// Set up the msgprocessor
-	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.config))
+	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.config, txIDIndex))
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
@@ -79,6 +88,19 @@ func newChainSupport(
 	return cs
 }
 
+func newBlockCutter(ledgerResources *ledgerResources, config localconfig.BlockCutter, metrics *blockcutter.Metrics) blockcutter.Receiver {
+	chainID := ledgerResources.ConfigtxValidator().ChainID()
+	if !config.AdaptiveBatchTimeout.Enabled {
+		return blockcutter.NewReceiverImpl(chainID, ledgerResources, metrics)
+	}
+
+	logger.Infof("[channel: %s] Using adaptive batch timeout with a lower bound of %s", chainID, config.AdaptiveBatchTimeout.MinBatchTimeout)
+	return blockcutter.NewAdaptiveReceiverImpl(chainID, ledgerResources, metrics, blockcutter.AdaptiveTimeoutConfig{
+		MinBatchTimeout:  config.AdaptiveBatchTimeout.MinBatchTimeout,
+		ArrivalGapFactor: config.AdaptiveBatchTimeout.ArrivalGapFactor,
+	})
+}
+
 // Block returns a block with the following number,
 // or nil if such a block doesn't exist.
 func (cs *ChainSupport) Block(number uint64) *cb.Block {
@@ -106,6 +128,28 @@ func (cs *ChainSupport) BlockCutter() blockcutter.Receiver {
 	return cs.cutter
 }
 
+// SharedConfig returns the channel's orderer config. Its BatchTimeout is the
+// one chosen by the block cutter, so that consenters arm their batch timers
+// with the effective, possibly adaptive, batch timeout.
+func (cs *ChainSupport) SharedConfig() channelconfig.Orderer {
+	return &cutterOrdererConfig{
+		Orderer: cs.ledgerResources.SharedConfig(),
+		cutter:  cs.cutter,
+	}
+}
+
+// cutterOrdererConfig overrides the BatchTimeout of an orderer config with
+// the one of a block cutter.
+type cutterOrdererConfig struct {
+	channelconfig.Orderer
+	cutter blockcutter.Receiver
+}
+
+// BatchTimeout returns the batch timeout of the block cutter.
+func (oc *cutterOrdererConfig) BatchTimeout() time.Duration {
+	return oc.cutter.BatchTimeout()
+}
+
 // Validate passes through to the underlying configtx.Validator
 func (cs *ChainSupport) Validate(configEnv *cb.ConfigEnvelope) error {
 	return cs.ConfigtxValidator().Validate(configEnv)
@@ -147,8 +191,15 @@ func (cs *ChainSupport) Sequence() uint64 {
 
 // Append appends a new block to the ledger in its raw form,
 // unlike WriteBlock that also mutates its metadata.
+// If deduplication is enabled, the transaction IDs of the block are indexed.
 func (cs *ChainSupport) Append(block *cb.Block) error {
-	return cs.ledgerResources.ReadWriter.Append(block)
+	if err := cs.ledgerResources.ReadWriter.Append(block); err != nil {
+		return err
+	}
+	if cs.txIDIndex != nil {
+		cs.txIDIndex.add(block)
+	}
+	return nil
 }
 
 // VerifyBlockSignature verifies a signature of a block.
@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/deliver/mock"
@@ -19,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/pkg/errors"
@@ -106,3 +108,28 @@ func testConfigEnvelope(t *testing.T) *common.ConfigEnvelope {
 		},
 	}
 }
+
+func TestChainSupportSharedConfig(t *testing.T) {
+	ms := &mutableResourcesMock{
+		Resources: config.Resources{
+			ConfigtxValidatorVal: &mockconfigtx.Validator{ChainIDVal: "mychannel"},
+			OrdererConfigVal: &config.Orderer{
+				ConsensusTypeVal: "solo",
+				BatchTimeoutVal:  2 * time.Second,
+			},
+		},
+	}
+	cutter := mockblockcutter.NewReceiver()
+	cutter.BatchTimeoutVal = 500 * time.Millisecond
+	cs := &ChainSupport{
+		ledgerResources: &ledgerResources{
+			configResources: &configResources{
+				mutableResources: ms,
+			},
+		},
+		cutter: cutter,
+	}
+
+	assert.Equal(t, "solo", cs.SharedConfig().ConsensusType())
+	assert.Equal(t, 500*time.Millisecond, cs.SharedConfig().BatchTimeout())
+}
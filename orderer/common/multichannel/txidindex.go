@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// txIDIndex holds the transaction IDs of the last window blocks of a channel.
+type txIDIndex struct {
+	mutex  sync.RWMutex
+	window int
+	// blocks holds the transaction IDs of each indexed block, oldest first
+	blocks [][]string
+	// txIDs counts the occurrences of each transaction ID in blocks
+	txIDs map[string]int
+}
+
+func newTxIDIndex(window uint32) *txIDIndex {
+	return &txIDIndex{
+		window: int(window),
+		txIDs:  make(map[string]int),
+	}
+}
+
+// rebuildTxIDIndex creates an index of the transaction IDs of the last window
+// blocks of the ledger, or of the blocks which have not been pruned.
+func rebuildTxIDIndex(window uint32, ledger blockledger.Reader) *txIDIndex {
+	index := newTxIDIndex(window)
+
+	height := ledger.Height()
+	start := uint64(0)
+	if height > uint64(window) {
+		start = height - uint64(window)
+	}
+	itr, oldest := ledger.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
+	itr.Close()
+	if oldest > start {
+		start = oldest
+	}
+	for number := start; number < height; number++ {
+		block := blockledger.GetBlock(ledger, number)
+		if block == nil {
+			logger.Panicf("Failed to retrieve block [%d] while rebuilding the transaction ID index", number)
+		}
+		index.add(block)
+	}
+
+	return index
+}
+
+// Contains returns whether the transaction ID appears in one of the indexed blocks.
+func (idx *txIDIndex) Contains(txID string) bool {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+	return idx.txIDs[txID] > 0
+}
+
+// add indexes the transaction IDs of the block, evicting the oldest block
+// if the window is exceeded.
+func (idx *txIDIndex) add(block *cb.Block) {
+	var txIDs []string
+	for i := range block.GetData().GetData() {
+		env, err := utils.ExtractEnvelope(block, i)
+		if err != nil {
+			logger.Warningf("Failed to extract envelope [%d] of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			logger.Warningf("Failed to extract channel header of envelope [%d] of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		if chdr.TxId != "" {
+			txIDs = append(txIDs, chdr.TxId)
+		}
+	}
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.blocks = append(idx.blocks, txIDs)
+	for _, txID := range txIDs {
+		idx.txIDs[txID]++
+	}
+
+	for len(idx.blocks) > idx.window {
+		for _, txID := range idx.blocks[0] {
+			idx.txIDs[txID]--
+			if idx.txIDs[txID] <= 0 {
+				delete(idx.txIDs, txID)
+			}
+		}
+		idx.blocks[0] = nil
+		idx.blocks = idx.blocks[1:]
+	}
+}
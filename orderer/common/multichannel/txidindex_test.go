@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTxIDEnvelope(txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "mychannel",
+					TxId:      txID,
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{}),
+			},
+		}),
+	}
+}
+
+func makeTxIDBlock(number uint64, txIDs ...string) *cb.Block {
+	block := cb.NewBlock(number, nil)
+	for _, txID := range txIDs {
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(makeTxIDEnvelope(txID)))
+	}
+	return block
+}
+
+func TestTxIDIndex(t *testing.T) {
+	index := newTxIDIndex(2)
+
+	index.add(makeTxIDBlock(0, "a", "b"))
+	index.add(makeTxIDBlock(1, "c", ""))
+	assert.True(t, index.Contains("a"))
+	assert.True(t, index.Contains("b"))
+	assert.True(t, index.Contains("c"))
+	assert.False(t, index.Contains(""))
+	assert.False(t, index.Contains("d"))
+
+	// Block 0 falls out of the window
+	index.add(makeTxIDBlock(2, "d", "c"))
+	assert.False(t, index.Contains("a"))
+	assert.False(t, index.Contains("b"))
+	assert.True(t, index.Contains("c"))
+	assert.True(t, index.Contains("d"))
+
+	// The first occurrence of c falls out of the window, but not the second
+	index.add(makeTxIDBlock(3))
+	assert.True(t, index.Contains("c"))
+	assert.Len(t, index.blocks, 2)
+
+	index.add(makeTxIDBlock(4))
+	assert.False(t, index.Contains("c"))
+	assert.Empty(t, index.txIDs)
+}
+
+func TestRebuildTxIDIndex(t *testing.T) {
+	_, rl := newRAMLedgerAndFactory(10, "mychannel", makeTxIDBlock(0))
+	for i := 1; i < 5; i++ {
+		err := rl.Append(blockledger.CreateNextBlock(rl, []*cb.Envelope{makeTxIDEnvelope(fmt.Sprintf("tx%d", i))}))
+		assert.NoError(t, err)
+	}
+
+	index := rebuildTxIDIndex(3, rl)
+	assert.False(t, index.Contains("tx1"))
+	for i := 2; i < 5; i++ {
+		assert.True(t, index.Contains(fmt.Sprintf("tx%d", i)))
+	}
+}
+
+func TestRebuildTxIDIndexPrunedLedger(t *testing.T) {
+	_, rl := newRAMLedgerAndFactory(3, "mychannel", makeTxIDBlock(0))
+	for i := 1; i < 5; i++ {
+		err := rl.Append(blockledger.CreateNextBlock(rl, []*cb.Envelope{makeTxIDEnvelope(fmt.Sprintf("tx%d", i))}))
+		assert.NoError(t, err)
+	}
+
+	index := rebuildTxIDIndex(10, rl)
+	assert.False(t, index.Contains("tx1"))
+	for i := 2; i < 5; i++ {
+		assert.True(t, index.Contains(fmt.Sprintf("tx%d", i)))
+	}
+}
+
+func TestChainSupportAppendIndexesTxIDs(t *testing.T) {
+	_, rl := newRAMLedgerAndFactory(10, "mychannel", makeTxIDBlock(0))
+	cs := &ChainSupport{
+		ledgerResources: &ledgerResources{ReadWriter: rl},
+		txIDIndex:       newTxIDIndex(10),
+	}
+
+	err := cs.Append(blockledger.CreateNextBlock(rl, []*cb.Envelope{makeTxIDEnvelope("tx1")}))
+	assert.NoError(t, err)
+	assert.True(t, cs.txIDIndex.Contains("tx1"))
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"runtime/debug"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// deliverEventsServer serves the peer's Deliver service on the orderer, so
+// that clients which only follow the chains, such as audit nodes, can receive
+// filtered blocks from the orderer. Requests are subject to the same access
+// control as the requests of the AtomicBroadcast Deliver service.
+type deliverEventsServer struct {
+	*server
+}
+
+// NewDeliverEventsServer creates a peer.DeliverServer backed by the deliver
+// handler of the given AtomicBroadcastServer, which must have been created by
+// NewServer.
+func NewDeliverEventsServer(abServer ab.AtomicBroadcastServer) peer.DeliverServer {
+	return &deliverEventsServer{server: abServer.(*server)}
+}
+
+// Deliver sends a stream of blocks to a client after ordering
+func (s *deliverEventsServer) Deliver(srv peer.Deliver_DeliverServer) error {
+	logger.Debugf("Starting new Deliver events handler")
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Criticalf("Deliver events client triggered panic: %s\n%s", r, debug.Stack())
+		}
+		logger.Debugf("Closing Deliver events stream")
+	}()
+
+	return s.handleDeliver(srv.Context(), "Deliver", srv, &blockEventsSender{
+		Deliver_DeliverServer: srv,
+	})
+}
+
+// DeliverFiltered sends a stream of filtered blocks to a client after
+// ordering. The filtered blocks carry the ID, the type and the channel of
+// each transaction, but none of their content.
+func (s *deliverEventsServer) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
+	logger.Debugf("Starting new DeliverFiltered handler")
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Criticalf("DeliverFiltered client triggered panic: %s\n%s", r, debug.Stack())
+		}
+		logger.Debugf("Closing DeliverFiltered stream")
+	}()
+
+	return s.handleDeliver(srv.Context(), "DeliverFiltered", srv, &filteredBlockEventsSender{
+		Deliver_DeliverFilteredServer: srv,
+	})
+}
+
+type blockEventsSender struct {
+	peer.Deliver_DeliverServer
+}
+
+func (bes *blockEventsSender) SendStatusResponse(status cb.Status) error {
+	return bes.Send(&peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	})
+}
+
+func (bes *blockEventsSender) SendBlockResponse(block *cb.Block) error {
+	return bes.Send(&peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Block{Block: block},
+	})
+}
+
+type filteredBlockEventsSender struct {
+	peer.Deliver_DeliverFilteredServer
+}
+
+func (fbes *filteredBlockEventsSender) SendStatusResponse(status cb.Status) error {
+	return fbes.Send(&peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	})
+}
+
+// IsFiltered is a marker method which indicates that this response sender
+// sends filtered blocks.
+func (fbes *filteredBlockEventsSender) IsFiltered() bool {
+	return true
+}
+
+func (fbes *filteredBlockEventsSender) SendBlockResponse(block *cb.Block) error {
+	filteredBlock, err := toFilteredBlock(block)
+	if err != nil {
+		logger.Warningf("Failed to generate filtered block due to: %s", err)
+		return fbes.SendStatusResponse(cb.Status_BAD_REQUEST)
+	}
+	return fbes.Send(&peer.DeliverResponse{
+		Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: filteredBlock},
+	})
+}
+
+// toFilteredBlock strips a block down to the channel headers of its
+// transactions. As the orderer does not validate transactions, all of them
+// are marked as not validated.
+func toFilteredBlock(block *cb.Block) (*peer.FilteredBlock, error) {
+	if block.Header == nil {
+		return nil, errors.New("block header is nil")
+	}
+
+	filteredBlock := &peer.FilteredBlock{
+		Number: block.Header.Number,
+	}
+	for txIndex, data := range block.GetData().GetData() {
+		env, err := utils.GetEnvelopeFromBlock(data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error getting tx from block")
+		}
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not extract payload from envelope")
+		}
+		if payload.Header == nil {
+			return nil, errors.Errorf("transaction payload header is nil, block num %d, tx index %d", block.Header.Number, txIndex)
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		filteredBlock.ChannelId = chdr.ChannelId
+		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, &peer.FilteredTransaction{
+			Txid:             chdr.TxId,
+			Type:             cb.HeaderType(chdr.Type),
+			TxValidationCode: peer.TxValidationCode_NOT_VALIDATED,
+		})
+	}
+
+	return filteredBlock, nil
+}
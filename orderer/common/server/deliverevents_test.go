@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestDeliverEventsNoPanic(t *testing.T) {
+	// Defer recovers from the panic
+	_ = (&deliverEventsServer{server: &server{}}).Deliver(nil)
+	_ = (&deliverEventsServer{server: &server{}}).DeliverFiltered(nil)
+}
+
+type mockDeliverFilteredSrv struct {
+	grpc.ServerStream
+	responses []*peer.DeliverResponse
+}
+
+func (m *mockDeliverFilteredSrv) Recv() (*cb.Envelope, error) {
+	panic("Unimplimented")
+}
+
+func (m *mockDeliverFilteredSrv) Send(resp *peer.DeliverResponse) error {
+	m.responses = append(m.responses, resp)
+	return nil
+}
+
+func txEnvelope(chdr *cb.ChannelHeader) []byte {
+	return utils.MarshalOrPanic(&cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(chdr),
+			},
+			Data: []byte("transaction content"),
+		}),
+	})
+}
+
+func TestToFilteredBlock(t *testing.T) {
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: 7},
+		Data: &cb.BlockData{
+			Data: [][]byte{
+				txEnvelope(&cb.ChannelHeader{ChannelId: "mychannel", TxId: "tx1", Type: int32(cb.HeaderType_ENDORSER_TRANSACTION)}),
+				txEnvelope(&cb.ChannelHeader{ChannelId: "mychannel", TxId: "tx2", Type: int32(cb.HeaderType_CONFIG)}),
+			},
+		},
+	}
+
+	filteredBlock, err := toFilteredBlock(block)
+	assert.NoError(t, err)
+	assert.Equal(t, &peer.FilteredBlock{
+		ChannelId: "mychannel",
+		Number:    7,
+		FilteredTransactions: []*peer.FilteredTransaction{
+			{Txid: "tx1", Type: cb.HeaderType_ENDORSER_TRANSACTION, TxValidationCode: peer.TxValidationCode_NOT_VALIDATED},
+			{Txid: "tx2", Type: cb.HeaderType_CONFIG, TxValidationCode: peer.TxValidationCode_NOT_VALIDATED},
+		},
+	}, filteredBlock)
+
+	t.Run("header only block", func(t *testing.T) {
+		filteredBlock, err := toFilteredBlock(&cb.Block{Header: &cb.BlockHeader{Number: 8}})
+		assert.NoError(t, err)
+		assert.Equal(t, &peer.FilteredBlock{Number: 8}, filteredBlock)
+	})
+
+	t.Run("nil header", func(t *testing.T) {
+		_, err := toFilteredBlock(&cb.Block{})
+		assert.EqualError(t, err, "block header is nil")
+	})
+
+	t.Run("bad envelope", func(t *testing.T) {
+		_, err := toFilteredBlock(&cb.Block{
+			Header: &cb.BlockHeader{Number: 9},
+			Data:   &cb.BlockData{Data: [][]byte{{0xff}}},
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error getting tx from block")
+	})
+
+	t.Run("nil payload header", func(t *testing.T) {
+		_, err := toFilteredBlock(&cb.Block{
+			Header: &cb.BlockHeader{Number: 9},
+			Data: &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(&cb.Envelope{
+				Payload: utils.MarshalOrPanic(&cb.Payload{}),
+			})}},
+		})
+		assert.EqualError(t, err, "transaction payload header is nil, block num 9, tx index 0")
+	})
+}
+
+func TestFilteredBlockEventsSender(t *testing.T) {
+	srv := &mockDeliverFilteredSrv{}
+	sender := &filteredBlockEventsSender{Deliver_DeliverFilteredServer: srv}
+	assert.True(t, sender.IsFiltered())
+
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: 3},
+		Data: &cb.BlockData{
+			Data: [][]byte{txEnvelope(&cb.ChannelHeader{ChannelId: "mychannel", TxId: "tx1"})},
+		},
+	}
+	assert.NoError(t, sender.SendBlockResponse(block))
+	assert.NoError(t, sender.SendBlockResponse(&cb.Block{}))
+	assert.NoError(t, sender.SendStatusResponse(cb.Status_SUCCESS))
+
+	assert.Len(t, srv.responses, 3)
+	filteredBlock := srv.responses[0].GetFilteredBlock()
+	assert.NotNil(t, filteredBlock)
+	assert.Equal(t, "mychannel", filteredBlock.ChannelId)
+	assert.Equal(t, uint64(3), filteredBlock.Number)
+	assert.Equal(t, "tx1", filteredBlock.FilteredTransactions[0].Txid)
+	assert.Equal(t, cb.Status_BAD_REQUEST, srv.responses[1].GetStatus())
+	assert.Equal(t, cb.Status_SUCCESS, srv.responses[2].GetStatus())
+}
@@ -50,6 +50,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
@@ -106,11 +107,20 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	defer opsSystem.Stop()
 	metricsProvider := opsSystem.Provider
 
-	lf, _ := createLedgerFactory(conf, metricsProvider)
+	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+	if err != nil {
+		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+	}
+	lf, _ := createLedgerFactory(conf, systemChannelName, metricsProvider)
 	sysChanLastConfigBlock := extractSysChanLastConfig(lf, bootstrapBlock)
 	clusterBootBlock := selectClusterBootBlock(bootstrapBlock, sysChanLastConfigBlock)
 
 	clusterType := isClusterType(clusterBootBlock)
+	if clusterType {
+		if err := etcdraft.ValidateEncryptionConfig(conf); err != nil {
+			logger.Fatalf("Invalid etcdraft configuration: %s", err)
+		}
+	}
 	signer := localmsp.NewSigner()
 
 	clusterClientConfig := initializeClusterClientConfig(conf, clusterType, bootstrapBlock)
@@ -181,6 +191,7 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 
 	initializeProfilingService(conf)
 	ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+	peer.RegisterDeliverServer(grpcServer.Server(), NewDeliverEventsServer(server))
 	logger.Info("Beginning to serve requests")
 	grpcServer.Start()
 }
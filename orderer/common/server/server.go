@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -178,6 +179,14 @@ func (s *server) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
 		logger.Debugf("Closing Deliver stream")
 	}()
 
+	return s.handleDeliver(srv.Context(), "Deliver", srv, &responseSender{
+		AtomicBroadcast_DeliverServer: srv,
+	})
+}
+
+// handleDeliver serves a deliver stream, subjecting its requests to the
+// access control of the orderer.
+func (s *server) handleDeliver(ctx context.Context, function string, receiver deliver.Receiver, sender deliver.ResponseSender) error {
 	policyChecker := func(env *cb.Envelope, channelID string) error {
 		chain := s.GetChain(channelID)
 		if chain == nil {
@@ -191,17 +200,15 @@ func (s *server) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
 	deliverServer := &deliver.Server{
 		PolicyChecker: deliver.PolicyCheckerFunc(policyChecker),
 		Receiver: &deliverMsgTracer{
-			Receiver: srv,
+			Receiver: receiver,
 			msgTracer: msgTracer{
 				debug:    s.debug,
-				function: "Deliver",
+				function: function,
 			},
 		},
-		ResponseSender: &responseSender{
-			AtomicBroadcast_DeliverServer: srv,
-		},
+		ResponseSender: sender,
 	}
-	return s.dh.Handle(srv.Context(), deliverServer)
+	return s.dh.Handle(ctx, deliverServer)
 }
 
 func (s *server) sendProducer(srv ab.AtomicBroadcast_DeliverServer) func(msg proto.Message) error {
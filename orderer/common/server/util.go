@@ -20,7 +20,7 @@ import (
 	config "github.com/hyperledger/fabric/orderer/common/localconfig"
 )
 
-func createLedgerFactory(conf *config.TopLevel, metricsProvider metrics.Provider) (blockledger.Factory, string) {
+func createLedgerFactory(conf *config.TopLevel, systemChannelID string, metricsProvider metrics.Provider) (blockledger.Factory, string) {
 	var lf blockledger.Factory
 	var ld string
 	switch conf.General.LedgerType {
@@ -30,7 +30,7 @@ func createLedgerFactory(conf *config.TopLevel, metricsProvider metrics.Provider
 			ld = createTempDir(conf.FileLedger.Prefix)
 		}
 		logger.Debug("Ledger dir:", ld)
-		lf = fileledger.New(ld, metricsProvider)
+		lf = fileledger.NewWithRetention(ld, metricsProvider, retentionPolicies(conf.FileLedger.Retention, systemChannelID))
 		// The file-based ledger stores the blocks for each channel
 		// in a fsblkstorage.ChainsDir sub-directory that we have
 		// to create separately. Otherwise the call to the ledger
@@ -51,6 +51,22 @@ func createLedgerFactory(conf *config.TopLevel, metricsProvider metrics.Provider
 	return lf, ld
 }
 
+func retentionPolicies(conf config.LedgerRetention, systemChannelID string) fileledger.RetentionPolicies {
+	policies := fileledger.RetentionPolicies{
+		Default:       fsblkstorage.RetentionPolicy(conf.Default),
+		Channels:      make(map[string]fsblkstorage.RetentionPolicy),
+		SystemChannel: systemChannelID,
+	}
+	for channelID, policy := range conf.Channels {
+		if channelID == systemChannelID {
+			logger.Warningf("Ignoring the retention policy of the system channel %s, its ledger is never pruned", channelID)
+			continue
+		}
+		policies.Channels[channelID] = fsblkstorage.RetentionPolicy(policy)
+	}
+	return policies
+}
+
 func createTempDir(dirPrefix string) string {
 	dirPath, err := ioutil.TempDir("", dirPrefix)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	config "github.com/hyperledger/fabric/orderer/common/localconfig"
@@ -54,7 +55,7 @@ func TestCreateLedgerFactory(t *testing.T) {
 			conf.General.LedgerType = tc.ledgerType
 			conf.FileLedger.Location = tc.ledgerDir
 			conf.FileLedger.Prefix = tc.ledgerDirPrefix
-			lf, ld := createLedgerFactory(conf, &disabled.Provider{})
+			lf, ld := createLedgerFactory(conf, "system", &disabled.Provider{})
 
 			defer func() {
 				if ld != "" {
@@ -67,6 +68,20 @@ func TestCreateLedgerFactory(t *testing.T) {
 	}
 }
 
+func TestRetentionPolicies(t *testing.T) {
+	policies := retentionPolicies(config.LedgerRetention{
+		Default: config.RetentionPolicy{Blocks: 10},
+		Channels: map[string]config.RetentionPolicy{
+			"foo":    {Bytes: 1000},
+			"system": {Blocks: 5},
+		},
+	}, "system")
+	assert.Equal(t, fsblkstorage.RetentionPolicy{Blocks: 10}, policies.Default)
+	assert.Equal(t, map[string]fsblkstorage.RetentionPolicy{"foo": {Bytes: 1000}}, policies.Channels,
+		"Expected the retention policy of the system channel to be ignored")
+	assert.Equal(t, "system", policies.SystemChannel)
+}
+
 func TestCreateSubDir(t *testing.T) {
 	testCases := []struct {
 		name          string
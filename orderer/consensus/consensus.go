@@ -67,6 +67,17 @@ type Chain interface {
 	Halt()
 }
 
+// SignatureCollector collects the signatures of other orderers over the blocks
+// committed by an orderer, for channels whose BlockValidation policy requires the
+// signatures of several orderers.
+type SignatureCollector interface {
+	// Collect returns the signatures over the block, the given signature of this
+	// orderer included, once they satisfy the BlockValidation policy of the channel.
+	// The signatures are over the value, the signature header and the block header.
+	// It returns false if the collection was aborted.
+	Collect(block *cb.Block, value []byte, signature *cb.MetadataSignature) ([]*cb.MetadataSignature, bool)
+}
+
 //go:generate counterfeiter -o mocks/mock_consenter_support.go . ConsenterSupport
 
 // ConsenterSupport provides the resources available to a Consenter implementation.
@@ -101,6 +112,10 @@ type ConsenterSupport interface {
 	// WriteConfigBlock commits a block to the ledger, and applies the config update inside.
 	WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte)
 
+	// SetSignatureCollector makes the blocks written by WriteBlock and WriteConfigBlock
+	// carry the signatures of other orderers, as collected by the given collector.
+	SetSignatureCollector(collector SignatureCollector)
+
 	// Sequence returns the current config squence.
 	Sequence() uint64
 
@@ -98,6 +98,9 @@ type Options struct {
 	SnapDir              string
 	SnapshotIntervalSize uint32
 
+	// EntryCipher encrypts WAL entries and snapshots on disk, nil disables encryption
+	EntryCipher *EntryCipher
+
 	// This is configurable mainly for testing purpose. Users are not
 	// expected to alter this. Instead, DefaultSnapshotCatchUpEntries is used.
 	SnapshotCatchUpEntries uint64
@@ -124,6 +127,13 @@ type Options struct {
 
 	EvictionSuspicion   time.Duration
 	LeaderCheckInterval time.Duration
+
+	// CollectBlockSignatures makes the blocks carry the signatures of as many
+	// consenters as required by the BlockValidation policy of the channel.
+	CollectBlockSignatures bool
+	// SignatureResendInterval is the interval at which the signature of a block
+	// is sent again to the other consenters, DefaultSignatureResendInterval if 0.
+	SignatureResendInterval time.Duration
 }
 
 type submit struct {
@@ -191,6 +201,8 @@ type Chain struct {
 	logger  *flogging.FabricLogger
 
 	periodicChecker *PeriodicCheck
+
+	signatureCollector *BlockSignatureCollector // nil unless blocks carry the signatures of several consenters
 }
 
 // NewChain constructs a chain object.
@@ -205,7 +217,7 @@ func NewChain(
 	lg := opts.Logger.With("channel", support.ChainID(), "node", opts.RaftID)
 
 	fresh := !wal.Exist(opts.WALDir)
-	storage, err := CreateStorage(lg, opts.WALDir, opts.SnapDir, opts.MemoryStorage)
+	storage, err := CreateStorage(lg, opts.WALDir, opts.SnapDir, opts.MemoryStorage, opts.EntryCipher)
 	if err != nil {
 		return nil, errors.Errorf("failed to restore persisted raft data: %s", err)
 	}
@@ -273,6 +285,19 @@ func NewChain(
 		opts:   opts,
 	}
 
+	if opts.CollectBlockSignatures {
+		c.signatureCollector = &BlockSignatureCollector{
+			Channel:        c.channelID,
+			RaftID:         c.raftID,
+			Support:        support,
+			RPC:            rpc,
+			Consenters:     c.consenterIDs,
+			Clock:          c.clock,
+			ResendInterval: opts.SignatureResendInterval,
+			Logger:         lg,
+		}
+	}
+
 	// Sets initial values for metrics
 	c.Metrics.ClusterSize.Set(float64(len(c.opts.BlockMetadata.ConsenterIds)))
 	c.Metrics.IsLeader.Set(float64(0)) // all nodes start out as followers
@@ -322,6 +347,10 @@ func (c *Chain) Start() {
 		return
 	}
 
+	if c.signatureCollector != nil {
+		c.support.SetSignatureCollector(c.signatureCollector)
+	}
+
 	isJoin := c.support.Height() > 1
 	if isJoin && c.opts.MigrationInit {
 		isJoin = false
@@ -478,6 +507,12 @@ func (c *Chain) Halt() {
 		return
 	}
 
+	// The block writer may wait for signatures while holding
+	// up the application of entries, abort it first.
+	if c.signatureCollector != nil {
+		c.signatureCollector.Abort()
+	}
+
 	select {
 	case c.haltC <- struct{}{}:
 	case <-c.doneC:
@@ -508,6 +543,10 @@ func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
 		return err
 	}
 
+	if len(req.Metadata) > 0 {
+		return c.consensusMetadata(req.Metadata, sender)
+	}
+
 	stepMsg := &raftpb.Message{}
 	if err := proto.Unmarshal(req.Payload, stepMsg); err != nil {
 		return fmt.Errorf("failed to unmarshal StepRequest payload to Raft Message: %s", err)
@@ -520,6 +559,18 @@ func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
 	return nil
 }
 
+func (c *Chain) consensusMetadata(metadata []byte, sender uint64) error {
+	if c.signatureCollector == nil {
+		return errors.Errorf("node %d doesn't collect block signatures", c.raftID)
+	}
+
+	signature, err := unmarshalBlockSignature(metadata)
+	if err != nil {
+		return err
+	}
+	return c.signatureCollector.Receive(sender, signature)
+}
+
 // Submit forwards the incoming request to:
 // - the local serveRequest goroutine if this is leader
 // - the actual leader via the transport mechanism
@@ -1146,6 +1197,15 @@ func (c *Chain) configureComm() error {
 	return nil
 }
 
+func (c *Chain) consenterIDs() []uint64 {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	ids := make([]uint64, len(c.opts.BlockMetadata.ConsenterIds))
+	copy(ids, c.opts.BlockMetadata.ConsenterIds)
+	return ids
+}
+
 func (c *Chain) remotePeers() ([]cluster.RemoteNode, error) {
 	c.raftMetadataLock.RLock()
 	defer c.raftMetadataLock.RUnlock()
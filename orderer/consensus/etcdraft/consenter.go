@@ -14,6 +14,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/viperutil"
@@ -54,9 +55,13 @@ type ChainGetter interface {
 
 // Config contains etcdraft configurations
 type Config struct {
-	WALDir            string // WAL data of <my-channel> is stored in WALDir/<my-channel>
-	SnapDir           string // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
-	EvictionSuspicion string // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	WALDir            string           // WAL data of <my-channel> is stored in WALDir/<my-channel>
+	SnapDir           string           // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
+	EvictionSuspicion string           // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	Encryption        EncryptionConfig // At-rest encryption of WAL entries and snapshots
+
+	// CollectBlockSignatures makes blocks carry the signatures of as many consenters as the BlockValidation policy requires
+	CollectBlockSignatures bool
 }
 
 // Consenter implements etcdraft consenter
@@ -72,6 +77,7 @@ type Consenter struct {
 	OrdererConfig  localconfig.TopLevel
 	Cert           []byte
 	Metrics        *Metrics
+	EntryCipher    *EntryCipher
 }
 
 // TargetChannel extracts the channel from the given proto.Message.
@@ -204,9 +210,12 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 
 		WALDir:            path.Join(c.EtcdRaftConfig.WALDir, support.ChainID()),
 		SnapDir:           path.Join(c.EtcdRaftConfig.SnapDir, support.ChainID()),
+		EntryCipher:       c.EntryCipher,
 		EvictionSuspicion: evictionSuspicion,
 		Cert:              c.Cert,
 		Metrics:           c.Metrics,
+
+		CollectBlockSignatures: c.EtcdRaftConfig.CollectBlockSignatures,
 	}
 
 	rpc := &cluster.RPC{
@@ -267,6 +276,15 @@ func New(
 		logger.Panicf("Failed to decode etcdraft configuration: %s", err)
 	}
 
+	// The configuration is checked by ValidateEncryptionConfig at startup
+	entryCipher, err := newConfiguredEntryCipher(factory.GetDefault(), conf.General.BCCSP, cfg.Encryption)
+	if err != nil {
+		logger.Panicf("Failed to initialize WAL and snapshot encryption: %s", err)
+	}
+	if entryCipher != nil {
+		logger.Infof("WAL entries and snapshots are encrypted with key %s", cfg.Encryption.Keys[0])
+	}
+
 	consenter := &Consenter{
 		CreateChain:           r.CreateChain,
 		Cert:                  srvConf.SecOpts.Certificate,
@@ -277,6 +295,7 @@ func New(
 		Dialer:                clusterDialer,
 		Metrics:               NewMetrics(metricsProvider),
 		InactiveChainRegistry: icr,
+		EntryCipher:           entryCipher,
 	}
 	consenter.Dispatcher = &Dispatcher{
 		Logger:        logger,
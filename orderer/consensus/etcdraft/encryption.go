@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+// encryptedDataPrefix marks data encrypted by an EntryCipher. A marshaled
+// protobuf message never starts with a zero byte, hence data persisted
+// before encryption was enabled can be told apart from encrypted data.
+var encryptedDataPrefix = []byte{0x00, 'e', 'n', 'c'}
+
+// macKeyDerivationLabel is the argument the MAC key of an encryption key
+// is derived with.
+var macKeyDerivationLabel = []byte("etcdraft at-rest encryption MAC key")
+
+// EncryptionConfig contains the configuration of the at-rest encryption of
+// the WAL entries and snapshots of etcd/raft.
+type EncryptionConfig struct {
+	Enabled bool
+	// Keys are the hex encoded subject key identifiers of the AES keys in the
+	// BCCSP. The first key encrypts newly written data, and all of them are used
+	// to decrypt data written before a key rotation.
+	Keys []string
+}
+
+// EntryCipher encrypts and decrypts the data persisted by RaftStorage with
+// AES keys held by the BCCSP. Data is encrypted in CBC mode and then
+// authenticated with an HMAC-SHA256 tag, whose key is derived from the AES key.
+type EntryCipher struct {
+	csp        bccsp.BCCSP
+	currentSKI []byte
+	keys       map[string]cipherKey
+}
+
+type cipherKey struct {
+	key    bccsp.Key
+	macKey []byte
+}
+
+// NewEntryCipher creates an EntryCipher out of the keys, given as hex encoded
+// subject key identifiers, which are retrieved from the BCCSP.
+func NewEntryCipher(csp bccsp.BCCSP, keys []string) (*EntryCipher, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no encryption keys provided")
+	}
+
+	c := &EntryCipher{
+		csp:  csp,
+		keys: make(map[string]cipherKey),
+	}
+	for i, k := range keys {
+		ski, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid encryption key identifier %s", k)
+		}
+		if len(ski) > 255 {
+			return nil, errors.Errorf("encryption key identifier %s is too long", k)
+		}
+		key, err := csp.GetKey(ski)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve encryption key %s", k)
+		}
+		if !key.Symmetric() {
+			return nil, errors.Errorf("encryption key %s is not a symmetric key", k)
+		}
+		macKey, err := csp.KeyDeriv(key, &bccsp.HMACDeriveKeyOpts{Temporary: true, Arg: macKeyDerivationLabel})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to derive MAC key of encryption key %s", k)
+		}
+		macKeyBytes, err := macKey.Bytes()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to export MAC key of encryption key %s", k)
+		}
+		if i == 0 {
+			c.currentSKI = ski
+		}
+		c.keys[string(ski)] = cipherKey{key: key, macKey: macKeyBytes}
+	}
+
+	return c, nil
+}
+
+// ValidateEncryptionConfig checks that the at-rest encryption configured in
+// the etcdraft section of the orderer configuration can be set up with the
+// keys of the BCCSP, so that configuration errors are reported at startup.
+func ValidateEncryptionConfig(conf *localconfig.TopLevel) error {
+	var cfg Config
+	if err := viperutil.Decode(conf.Consensus, &cfg); err != nil {
+		return errors.Wrap(err, "failed to decode etcdraft configuration")
+	}
+	_, err := newConfiguredEntryCipher(factory.GetDefault(), conf.General.BCCSP, cfg.Encryption)
+	return err
+}
+
+// newConfiguredEntryCipher creates the EntryCipher of the encryption
+// configuration, or returns nil if encryption is disabled.
+func newConfiguredEntryCipher(csp bccsp.BCCSP, bccspConf *factory.FactoryOpts, conf EncryptionConfig) (*EntryCipher, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+	// The PKCS11 BCCSP only keeps EC keys in the HSM, which can't wrap the
+	// AES keys, and looks up AES keys in its software keystore instead
+	if bccspConf != nil && bccspConf.ProviderName == "PKCS11" {
+		return nil, errors.New("WAL and snapshot encryption is not supported with the PKCS11 BCCSP, " +
+			"as it does not keep AES keys in the HSM")
+	}
+	return NewEntryCipher(csp, conf.Keys)
+}
+
+// Encrypt encrypts the data with the current key, and appends a MAC
+// which covers the key identifier and the ciphertext.
+func (c *EntryCipher) Encrypt(data []byte) ([]byte, error) {
+	key := c.keys[string(c.currentSKI)]
+	ciphertext, err := c.csp.Encrypt(key.key, data, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt data")
+	}
+
+	out := make([]byte, 0, len(encryptedDataPrefix)+1+len(c.currentSKI)+len(ciphertext)+sha256.Size)
+	out = append(out, encryptedDataPrefix...)
+	out = append(out, byte(len(c.currentSKI)))
+	out = append(out, c.currentSKI...)
+	out = append(out, ciphertext...)
+	return append(out, computeMAC(key.macKey, out)...), nil
+}
+
+// Decrypt authenticates and decrypts data encrypted by Encrypt with any of
+// the keys of the cipher.
+func (c *EntryCipher) Decrypt(data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return nil, errors.New("data is not encrypted")
+	}
+
+	header := data[len(encryptedDataPrefix):]
+	if len(header) == 0 || len(header) < 1+int(header[0])+sha256.Size {
+		return nil, errors.New("encrypted data is truncated")
+	}
+	ski := header[1 : 1+int(header[0])]
+	authenticated, mac := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	ciphertext := authenticated[len(encryptedDataPrefix)+1+len(ski):]
+
+	key, ok := c.keys[string(ski)]
+	if !ok {
+		return nil, errors.Errorf("data is encrypted with unknown key %s", hex.EncodeToString(ski))
+	}
+	if !hmac.Equal(mac, computeMAC(key.macKey, authenticated)) {
+		return nil, errors.New("encrypted data failed authentication")
+	}
+
+	plaintext, err := c.csp.Decrypt(key.key, ciphertext, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt data")
+	}
+	return plaintext, nil
+}
+
+func computeMAC(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedDataPrefix)
+}
+
+// sealEntries returns copies of the entries whose data is encrypted, or the
+// entries themselves if there is no cipher.
+func sealEntries(c *EntryCipher, entries []raftpb.Entry) ([]raftpb.Entry, error) {
+	if c == nil {
+		return entries, nil
+	}
+
+	sealed := make([]raftpb.Entry, len(entries))
+	for i, e := range entries {
+		sealed[i] = e
+		if len(e.Data) == 0 {
+			continue
+		}
+		data, err := c.Encrypt(e.Data)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to encrypt entry at index %d", e.Index))
+		}
+		sealed[i].Data = data
+	}
+	return sealed, nil
+}
+
+// openEntries decrypts the data of the entries in place. Entries written
+// before encryption was enabled are left as they are, and the number of such
+// entries is returned.
+func openEntries(c *EntryCipher, entries []raftpb.Entry) (plaintext int, err error) {
+	for i := range entries {
+		if !isEncrypted(entries[i].Data) {
+			if len(entries[i].Data) != 0 {
+				plaintext++
+			}
+			continue
+		}
+		if c == nil {
+			return 0, errors.Errorf("entry at index %d is encrypted but encryption is not enabled", entries[i].Index)
+		}
+		data, err := c.Decrypt(entries[i].Data)
+		if err != nil {
+			return 0, errors.WithMessage(err, fmt.Sprintf("failed to decrypt entry at index %d", entries[i].Index))
+		}
+		entries[i].Data = data
+	}
+	return plaintext, nil
+}
+
+// sealSnapshot returns a copy of the snapshot whose data is encrypted, or
+// the snapshot itself if there is no cipher.
+func sealSnapshot(c *EntryCipher, snapshot raftpb.Snapshot) (raftpb.Snapshot, error) {
+	if c == nil || len(snapshot.Data) == 0 {
+		return snapshot, nil
+	}
+
+	data, err := c.Encrypt(snapshot.Data)
+	if err != nil {
+		return snapshot, errors.WithMessage(err, fmt.Sprintf("failed to encrypt snapshot at index %d", snapshot.Metadata.Index))
+	}
+	snapshot.Data = data
+	return snapshot, nil
+}
+
+// openSnapshot decrypts the data of the snapshot in place, if it is encrypted.
+func openSnapshot(c *EntryCipher, snapshot *raftpb.Snapshot) error {
+	if !isEncrypted(snapshot.Data) {
+		return nil
+	}
+	if c == nil {
+		return errors.Errorf("snapshot at index %d is encrypted but encryption is not enabled", snapshot.Metadata.Index)
+	}
+
+	data, err := c.Decrypt(snapshot.Data)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to decrypt snapshot at index %d", snapshot.Metadata.Index))
+	}
+	snapshot.Data = data
+	return nil
+}
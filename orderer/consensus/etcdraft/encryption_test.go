@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/etcdserver/api/snap"
+	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/raftpb"
+	"go.etcd.io/etcd/wal"
+	"go.etcd.io/etcd/wal/walpb"
+	"go.uber.org/zap"
+)
+
+func newTestCSP(t *testing.T) bccsp.BCCSP {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	require.NoError(t, err)
+	return csp
+}
+
+func newTestKey(t *testing.T, csp bccsp.BCCSP) string {
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	return hex.EncodeToString(key.SKI())
+}
+
+func TestNewEntryCipher(t *testing.T) {
+	csp := newTestCSP(t)
+
+	_, err := NewEntryCipher(csp, nil)
+	assert.EqualError(t, err, "no encryption keys provided")
+
+	_, err = NewEntryCipher(csp, []string{"not hex"})
+	assert.Contains(t, err.Error(), "invalid encryption key identifier not hex")
+
+	_, err = NewEntryCipher(csp, []string{"0102"})
+	assert.Contains(t, err.Error(), "failed to retrieve encryption key 0102")
+
+	ecKey, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	_, err = NewEntryCipher(csp, []string{hex.EncodeToString(ecKey.SKI())})
+	assert.Contains(t, err.Error(), "is not a symmetric key")
+
+	c, err := NewEntryCipher(csp, []string{newTestKey(t, csp)})
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestNewConfiguredEntryCipher(t *testing.T) {
+	csp := newTestCSP(t)
+	key := newTestKey(t, csp)
+
+	c, err := newConfiguredEntryCipher(csp, nil, EncryptionConfig{Keys: []string{key}})
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+
+	c, err = newConfiguredEntryCipher(csp, &factory.FactoryOpts{ProviderName: "SW"}, EncryptionConfig{Enabled: true, Keys: []string{key}})
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+
+	_, err = newConfiguredEntryCipher(csp, &factory.FactoryOpts{ProviderName: "PKCS11"}, EncryptionConfig{Enabled: true, Keys: []string{key}})
+	assert.EqualError(t, err, "WAL and snapshot encryption is not supported with the PKCS11 BCCSP, as it does not keep AES keys in the HSM")
+
+	_, err = newConfiguredEntryCipher(csp, nil, EncryptionConfig{Enabled: true})
+	assert.EqualError(t, err, "no encryption keys provided")
+}
+
+func TestValidateEncryptionConfig(t *testing.T) {
+	conf := &localconfig.TopLevel{
+		General: localconfig.General{BCCSP: &factory.FactoryOpts{ProviderName: "PKCS11"}},
+	}
+	assert.NoError(t, ValidateEncryptionConfig(conf))
+
+	conf.Consensus = map[string]interface{}{
+		"Encryption": map[string]interface{}{"Enabled": true, "Keys": []string{"0102"}},
+	}
+	assert.EqualError(t, ValidateEncryptionConfig(conf), "WAL and snapshot encryption is not supported with the PKCS11 BCCSP, as it does not keep AES keys in the HSM")
+
+	conf.General.BCCSP.ProviderName = "SW"
+	assert.Contains(t, ValidateEncryptionConfig(conf).Error(), "failed to retrieve encryption key 0102")
+
+	conf.Consensus = map[string]interface{}{"Encryption": "not a map"}
+	assert.Contains(t, ValidateEncryptionConfig(conf).Error(), "failed to decode etcdraft configuration")
+}
+
+func TestEntryCipher(t *testing.T) {
+	csp := newTestCSP(t)
+	oldKey, newKey := newTestKey(t, csp), newTestKey(t, csp)
+
+	oldCipher, err := NewEntryCipher(csp, []string{oldKey})
+	require.NoError(t, err)
+	rotatedCipher, err := NewEntryCipher(csp, []string{newKey, oldKey})
+	require.NoError(t, err)
+	newCipher, err := NewEntryCipher(csp, []string{newKey})
+	require.NoError(t, err)
+
+	plaintext := []byte("block bytes")
+	ciphertext, err := oldCipher.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.True(t, isEncrypted(ciphertext))
+	assert.NotContains(t, string(ciphertext), string(plaintext))
+
+	// Data encrypted before a key rotation is decrypted with the previous key
+	decrypted, err := rotatedCipher.Decrypt(ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = newCipher.Decrypt(ciphertext)
+	assert.Contains(t, err.Error(), "data is encrypted with unknown key "+oldKey)
+
+	_, err = newCipher.Decrypt(plaintext)
+	assert.EqualError(t, err, "data is not encrypted")
+
+	_, err = newCipher.Decrypt(encryptedDataPrefix)
+	assert.EqualError(t, err, "encrypted data is truncated")
+
+	_, err = oldCipher.Decrypt(ciphertext[:len(ciphertext)-sha256.Size])
+	assert.EqualError(t, err, "encrypted data failed authentication")
+}
+
+func TestEntryCipherAuthentication(t *testing.T) {
+	csp := newTestCSP(t)
+	c, err := NewEntryCipher(csp, []string{newTestKey(t, csp)})
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("block bytes"))
+	require.NoError(t, err)
+
+	// Flipping any bit of the key identifier, the ciphertext or the MAC
+	// is detected before the data is decrypted
+	for i := len(encryptedDataPrefix) + 1; i < len(ciphertext); i++ {
+		tampered := append([]byte(nil), ciphertext...)
+		tampered[i] ^= 0x01
+		_, err := c.Decrypt(tampered)
+		assert.Error(t, err, "tampering with byte %d was not detected", i)
+		if i >= len(encryptedDataPrefix)+1+len(c.currentSKI) {
+			assert.EqualError(t, err, "encrypted data failed authentication")
+		}
+	}
+
+	// A ciphertext whose MAC was stripped and replaced is rejected
+	unauthenticated := ciphertext[:len(ciphertext)-sha256.Size]
+	unauthenticated = append(unauthenticated, make([]byte, sha256.Size)...)
+	_, err = c.Decrypt(unauthenticated)
+	assert.EqualError(t, err, "encrypted data failed authentication")
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	lg := flogging.NewFabricLogger(zap.NewExample())
+	dir, err := ioutil.TempDir("", "etcdraft-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	walDir, snapDir := path.Join(dir, "wal"), path.Join(dir, "snapshot")
+
+	csp := newTestCSP(t)
+	cipher, err := NewEntryCipher(csp, []string{newTestKey(t, csp)})
+	require.NoError(t, err)
+
+	secret := []byte("confidential transaction")
+
+	// Write entries before encryption is enabled
+	store, err := CreateStorage(lg, walDir, snapDir, raft.NewMemoryStorage(), nil)
+	require.NoError(t, err)
+	err = store.Store([]raftpb.Entry{{Term: 1, Index: 1, Data: secret}}, raftpb.HardState{Term: 1, Commit: 1}, raftpb.Snapshot{})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// Enable encryption, the unencrypted entry is still readable
+	ram := raft.NewMemoryStorage()
+	store, err = CreateStorage(lg, walDir, snapDir, ram, cipher)
+	require.NoError(t, err)
+	ents, err := ram.Entries(1, 2, 1<<20)
+	require.NoError(t, err)
+	assert.Equal(t, secret, ents[0].Data)
+
+	err = store.Store([]raftpb.Entry{{Term: 1, Index: 2, Data: secret}}, raftpb.HardState{Term: 1, Commit: 2}, raftpb.Snapshot{})
+	require.NoError(t, err)
+	err = store.TakeSnapshot(2, raftpb.ConfState{Nodes: []uint64{1}}, secret)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// The new entry and the snapshot are encrypted on disk
+	w, err := wal.Open(lg.Zap(), walDir, walpb.Snapshot{})
+	require.NoError(t, err)
+	_, _, ents, err = w.ReadAll()
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, secret, ents[0].Data)
+	assert.True(t, isEncrypted(ents[1].Data))
+
+	snapshot, err := snap.New(lg.Zap(), snapDir).Load()
+	require.NoError(t, err)
+	assert.True(t, isEncrypted(snapshot.Data))
+
+	// Both are decrypted when the storage is loaded
+	ram = raft.NewMemoryStorage()
+	store, err = CreateStorage(lg, walDir, snapDir, ram, cipher)
+	require.NoError(t, err)
+	defer store.Close()
+	loaded, err := ram.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, secret, loaded.Data)
+	lastIndex, err := ram.LastIndex()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), lastIndex)
+}
+
+func TestEncryptedStorageWithoutCipher(t *testing.T) {
+	lg := flogging.NewFabricLogger(zap.NewExample())
+	dir, err := ioutil.TempDir("", "etcdraft-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	walDir, snapDir := filepath.Join(dir, "wal"), filepath.Join(dir, "snapshot")
+
+	csp := newTestCSP(t)
+	cipher, err := NewEntryCipher(csp, []string{newTestKey(t, csp)})
+	require.NoError(t, err)
+
+	store, err := CreateStorage(lg, walDir, snapDir, raft.NewMemoryStorage(), cipher)
+	require.NoError(t, err)
+	err = store.Store([]raftpb.Entry{{Term: 1, Index: 1, Data: []byte("data")}}, raftpb.HardState{Term: 1, Commit: 1}, raftpb.Snapshot{})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	_, err = CreateStorage(lg, walDir, snapDir, raft.NewMemoryStorage(), nil)
+	assert.EqualError(t, err, "failed to create or read WAL: failed to read WAL: entry at index 1 is encrypted but encryption is not enabled")
+}
@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSignatureResendInterval is the interval at which the signature of
+	// a block is sent again to the consenters while its collection is ongoing.
+	DefaultSignatureResendInterval = 5 * time.Second
+
+	// maxBufferedSignatureBlocks bounds how far ahead of the ledger height the
+	// signatures received from other consenters are buffered.
+	maxBufferedSignatureBlocks = 100
+)
+
+// BlockSignatureCollector collects the signatures of the other consenters of
+// a chain over the blocks it writes, until they satisfy the BlockValidation
+// policy of the channel. Consenters sign the same bytes for a given block, as
+// the value of the SIGNATURES metadata and the block header are identical on
+// all of them, so that their signatures can be put in the same block.
+type BlockSignatureCollector struct {
+	Channel        string
+	RaftID         uint64
+	Support        consensus.ConsenterSupport
+	RPC            RPC
+	Consenters     func() []uint64
+	Clock          clock.Clock
+	ResendInterval time.Duration
+	Logger         *flogging.FabricLogger
+
+	initOnce  sync.Once
+	abortOnce sync.Once
+	abortC    chan struct{}
+	signalC   chan struct{}
+
+	lock       sync.Mutex
+	collecting bool
+	pending    uint64
+	received   map[uint64]map[uint64]*etcdraft.BlockSignature
+}
+
+func (sc *BlockSignatureCollector) init() {
+	sc.initOnce.Do(func() {
+		sc.abortC = make(chan struct{})
+		sc.signalC = make(chan struct{}, 1)
+		sc.received = make(map[uint64]map[uint64]*etcdraft.BlockSignature)
+	})
+}
+
+// Collect sends the signature of this consenter over the block to the other
+// consenters, and returns the signatures received from them once they satisfy
+// the BlockValidation policy together with it. It returns false if the
+// collection is aborted.
+func (sc *BlockSignatureCollector) Collect(block *common.Block, value []byte, signature *common.MetadataSignature) ([]*common.MetadataSignature, bool) {
+	sc.init()
+
+	number := block.Header.Number
+	headerBytes := block.Header.Bytes()
+
+	sc.lock.Lock()
+	sc.collecting = true
+	sc.pending = number
+	for n := range sc.received {
+		if n < number {
+			delete(sc.received, n)
+		}
+	}
+	sc.lock.Unlock()
+
+	defer func() {
+		sc.lock.Lock()
+		defer sc.lock.Unlock()
+		sc.collecting = false
+		delete(sc.received, number)
+	}()
+
+	own := &etcdraft.BlockSignature{
+		BlockNumber:     number,
+		SignatureHeader: signature.SignatureHeader,
+		Signature:       signature.Signature,
+	}
+
+	interval := sc.ResendInterval
+	if interval == 0 {
+		interval = DefaultSignatureResendInterval
+	}
+	ticker := sc.Clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	sc.broadcast(own)
+	for {
+		signatures := sc.signatures(number, signature)
+		err := sc.Support.VerifyBlockSignature(signedData(signatures, value, headerBytes), nil)
+		if err == nil {
+			sc.Logger.Debugf("Collected %d signatures of block [%d]", len(signatures), number)
+			return signatures, true
+		}
+		sc.Logger.Debugf("%d signatures of block [%d] don't satisfy the block validation policy yet: %s", len(signatures), number, err)
+
+		select {
+		case <-sc.signalC:
+		case <-ticker.C():
+			sc.Logger.Infof("Still collecting the signatures of block [%d], sending ours again", number)
+			sc.broadcast(own)
+		case <-sc.abortC:
+			sc.Logger.Infof("Aborted the collection of the signatures of block [%d]", number)
+			return nil, false
+		}
+	}
+}
+
+// Receive handles the signature of a block sent by another consenter. The
+// signature is kept if the block is being collected or hasn't been written yet,
+// and a signature is sent back if the block is already written, unless the
+// sender wrote it too.
+func (sc *BlockSignatureCollector) Receive(sender uint64, signature *etcdraft.BlockSignature) error {
+	sc.init()
+
+	if !sc.isConsenter(sender) {
+		return errors.Errorf("node %d is not a consenter of channel %s", sender, sc.Channel)
+	}
+	if _, err := utils.GetSignatureHeader(signature.SignatureHeader); err != nil {
+		return errors.WithMessage(err, "invalid signature header")
+	}
+
+	number := signature.BlockNumber
+
+	sc.lock.Lock()
+	if sc.collecting && number == sc.pending {
+		sc.store(sender, signature)
+		sc.lock.Unlock()
+		return nil
+	}
+
+	height := sc.Support.Height()
+	if number >= height {
+		if number < height+maxBufferedSignatureBlocks {
+			sc.store(sender, signature)
+		}
+		sc.lock.Unlock()
+		return nil
+	}
+	sc.lock.Unlock()
+
+	if signature.Committed {
+		return nil
+	}
+	return sc.reply(sender, number)
+}
+
+// Abort aborts the ongoing and future collections.
+func (sc *BlockSignatureCollector) Abort() {
+	sc.init()
+	sc.abortOnce.Do(func() {
+		close(sc.abortC)
+	})
+}
+
+// store should only be invoked with sc.lock held.
+func (sc *BlockSignatureCollector) store(sender uint64, signature *etcdraft.BlockSignature) {
+	signatures, ok := sc.received[signature.BlockNumber]
+	if !ok {
+		signatures = make(map[uint64]*etcdraft.BlockSignature)
+		sc.received[signature.BlockNumber] = signatures
+	}
+	signatures[sender] = signature
+
+	select {
+	case sc.signalC <- struct{}{}:
+	default:
+	}
+}
+
+// signatures returns the given signature of this consenter and the signatures
+// of the block received from other consenters, ordered by sender.
+func (sc *BlockSignatureCollector) signatures(number uint64, own *common.MetadataSignature) []*common.MetadataSignature {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	received := sc.received[number]
+	senders := make([]uint64, 0, len(received))
+	for sender := range received {
+		senders = append(senders, sender)
+	}
+	sort.Slice(senders, func(i, j int) bool { return senders[i] < senders[j] })
+
+	signatures := []*common.MetadataSignature{own}
+	for _, sender := range senders {
+		signatures = append(signatures, &common.MetadataSignature{
+			SignatureHeader: received[sender].SignatureHeader,
+			Signature:       received[sender].Signature,
+		})
+	}
+	return signatures
+}
+
+// reply sends a signature over the written block to the sender.
+func (sc *BlockSignatureCollector) reply(sender uint64, number uint64) error {
+	block := sc.Support.Block(number)
+	if block == nil {
+		return errors.Errorf("block [%d] is not available", number)
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.WithMessage(err, "failed reading the signatures of block")
+	}
+
+	header, err := sc.Support.NewSignatureHeader()
+	if err != nil {
+		return errors.WithMessage(err, "failed creating signature header")
+	}
+	headerBytes := utils.MarshalOrPanic(header)
+	signature, err := sc.Support.Sign(util.ConcatenateBytes(metadata.Value, headerBytes, block.Header.Bytes()))
+	if err != nil {
+		return errors.WithMessage(err, "failed signing block")
+	}
+
+	sc.Logger.Debugf("Sending signature of block [%d] to node %d", number, sender)
+	return sc.send(sender, &etcdraft.BlockSignature{
+		BlockNumber:     number,
+		SignatureHeader: headerBytes,
+		Signature:       signature,
+		Committed:       true,
+	})
+}
+
+func (sc *BlockSignatureCollector) broadcast(signature *etcdraft.BlockSignature) {
+	for _, id := range sc.Consenters() {
+		if id == sc.RaftID {
+			continue
+		}
+		if err := sc.send(id, signature); err != nil {
+			sc.Logger.Warningf("Failed sending signature of block [%d] to node %d: %s", signature.BlockNumber, id, err)
+		}
+	}
+}
+
+func (sc *BlockSignatureCollector) send(destination uint64, signature *etcdraft.BlockSignature) error {
+	return sc.RPC.SendConsensus(destination, &orderer.ConsensusRequest{
+		Channel:  sc.Channel,
+		Metadata: utils.MarshalOrPanic(signature),
+	})
+}
+
+func (sc *BlockSignatureCollector) isConsenter(id uint64) bool {
+	for _, consenter := range sc.Consenters() {
+		if consenter == id {
+			return true
+		}
+	}
+	return false
+}
+
+// signedData returns the data the given signatures are over, which are
+// consistent with cluster.SignatureSetFromBlock.
+func signedData(signatures []*common.MetadataSignature, value []byte, headerBytes []byte) []*common.SignedData {
+	var sd []*common.SignedData
+	for _, signature := range signatures {
+		header, err := utils.GetSignatureHeader(signature.SignatureHeader)
+		if err != nil {
+			continue
+		}
+		sd = append(sd, &common.SignedData{
+			Identity:  header.Creator,
+			Data:      util.ConcatenateBytes(value, signature.SignatureHeader, headerBytes),
+			Signature: signature.Signature,
+		})
+	}
+	return sd
+}
+
+// unmarshalBlockSignature unmarshals the metadata of a ConsensusRequest.
+func unmarshalBlockSignature(metadata []byte) (*etcdraft.BlockSignature, error) {
+	signature := &etcdraft.BlockSignature{}
+	if err := proto.Unmarshal(metadata, signature); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block signature")
+	}
+	return signature, nil
+}
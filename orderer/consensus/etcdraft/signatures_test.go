@@ -0,0 +1,402 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
+	cryptogenmsp "github.com/hyperledger/fabric/common/tools/cryptogen/msp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs with the key of an orderer identity issued by the CA of
+// the orderer MSP.
+type testSigner struct {
+	identity []byte
+	csp      bccsp.BCCSP
+	key      bccsp.Key
+}
+
+func (s *testSigner) NewSignatureHeader() (*common.SignatureHeader, error) {
+	return &common.SignatureHeader{Creator: s.identity, Nonce: []byte("nonce")}, nil
+}
+
+func (s *testSigner) Sign(message []byte) ([]byte, error) {
+	digest, err := s.csp.Hash(message, &bccsp.SHA256Opts{})
+	if err != nil {
+		return nil, err
+	}
+	return s.csp.Sign(s.key, digest, nil)
+}
+
+// newTestOrderers returns the signers of n orderers of the same MSP, and the
+// policy requiring the signatures of k of them.
+func newTestOrderers(t *testing.T, n int, k int32) ([]*testSigner, policies.Policy) {
+	dir, err := ioutil.TempDir("", "block-signatures")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), "example.com", "ca.example.com", "US", "California", "San Francisco", "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, cryptogenmsp.GenerateVerifyingMSP(filepath.Join(dir, "msp"), signCA, signCA, false))
+
+	mspConfig, err := msp.GetVerifyingMspConfig(filepath.Join(dir, "msp"), "OrdererMSP", "bccsp")
+	require.NoError(t, err)
+	ordererMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_1}})
+	require.NoError(t, err)
+	require.NoError(t, ordererMSP.Setup(mspConfig))
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "certs"), 0755))
+
+	var signers []*testSigner
+	var identities [][]byte
+	var signedBy []*common.SignaturePolicy
+	for i := 0; i < n; i++ {
+		key, err := cryptoProvider.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+		require.NoError(t, err)
+		publicKey, err := csp.GetECPublicKey(key)
+		require.NoError(t, err)
+		cert, err := signCA.SignCertificate(filepath.Join(dir, "certs"), fmt.Sprintf("orderer%d.example.com", i+1), nil, nil, publicKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		require.NoError(t, err)
+
+		identity := utils.MarshalOrPanic(&mspproto.SerializedIdentity{
+			Mspid:   "OrdererMSP",
+			IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+		})
+		signers = append(signers, &testSigner{identity: identity, csp: cryptoProvider, key: key})
+		identities = append(identities, identity)
+		signedBy = append(signedBy, cauthdsl.SignedBy(int32(i)))
+	}
+
+	envelope := cauthdsl.Envelope(cauthdsl.NOutOf(k, signedBy), identities)
+	policy, _, err := cauthdsl.NewPolicyProvider(ordererMSP).NewPolicy(utils.MarshalOrPanic(envelope))
+	require.NoError(t, err)
+	return signers, policy
+}
+
+// signatureNetwork routes the signatures sent by collectors to the
+// collectors of their destinations.
+type signatureNetwork struct {
+	lock       sync.Mutex
+	collectors map[uint64]*BlockSignatureCollector
+	sent       map[uint64]int
+}
+
+type signatureRPC struct {
+	from    uint64
+	network *signatureNetwork
+}
+
+func (r *signatureRPC) SendConsensus(destination uint64, msg *orderer.ConsensusRequest) error {
+	signature, err := unmarshalBlockSignature(msg.Metadata)
+	if err != nil {
+		return err
+	}
+
+	r.network.lock.Lock()
+	defer r.network.lock.Unlock()
+	r.network.sent[r.from]++
+	collector, ok := r.network.collectors[destination]
+	if !ok {
+		return fmt.Errorf("node %d is unreachable", destination)
+	}
+	go collector.Receive(r.from, signature)
+	return nil
+}
+
+func (r *signatureRPC) SendSubmit(destination uint64, request *orderer.SubmitRequest) error {
+	panic("not implemented")
+}
+
+func (n *signatureNetwork) sentBy(id uint64) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.sent[id]
+}
+
+type signatureNode struct {
+	collector *BlockSignatureCollector
+	support   *consensusmocks.FakeConsenterSupport
+	signer    *testSigner
+	ledger    map[uint64]*common.Block
+}
+
+func newSignatureNetwork(t *testing.T, clock *fakeclock.FakeClock, height uint64, signers []*testSigner, policy policies.Policy) (*signatureNetwork, map[uint64]*signatureNode) {
+	network := &signatureNetwork{
+		collectors: make(map[uint64]*BlockSignatureCollector),
+		sent:       make(map[uint64]int),
+	}
+	var ids []uint64
+	for i := range signers {
+		ids = append(ids, uint64(i+1))
+	}
+
+	nodes := make(map[uint64]*signatureNode)
+	for i, signer := range signers {
+		id := uint64(i + 1)
+		node := &signatureNode{
+			support: &consensusmocks.FakeConsenterSupport{},
+			signer:  signer,
+			ledger:  make(map[uint64]*common.Block),
+		}
+		node.support.HeightStub = func() uint64 { return height + uint64(len(node.ledger)) }
+		node.support.BlockStub = func(number uint64) *common.Block { return node.ledger[number] }
+		node.support.NewSignatureHeaderStub = signer.NewSignatureHeader
+		node.support.SignStub = signer.Sign
+		node.support.VerifyBlockSignatureStub = func(sd []*common.SignedData, _ *common.ConfigEnvelope) error {
+			return policy.Evaluate(sd)
+		}
+		node.collector = &BlockSignatureCollector{
+			Channel:        "mychannel",
+			RaftID:         id,
+			Support:        node.support,
+			RPC:            &signatureRPC{from: id, network: network},
+			Consenters:     func() []uint64 { return ids },
+			Clock:          clock,
+			ResendInterval: time.Second,
+			Logger:         flogging.MustGetLogger("test"),
+		}
+		network.collectors[id] = node.collector
+		nodes[id] = node
+	}
+	return network, nodes
+}
+
+var signatureValue = utils.MarshalOrPanic(&common.OrdererBlockMetadata{LastConfig: &common.LastConfig{Index: 0}})
+
+// sign returns the signature of the node over the block, as the block writer does.
+func (node *signatureNode) sign(t *testing.T, block *common.Block) *common.MetadataSignature {
+	header, err := node.signer.NewSignatureHeader()
+	require.NoError(t, err)
+	signature := &common.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(header)}
+	signature.Signature, err = node.signer.Sign(util.ConcatenateBytes(signatureValue, signature.SignatureHeader, block.Header.Bytes()))
+	require.NoError(t, err)
+	return signature
+}
+
+// collect collects the signatures of the block in the background.
+func (node *signatureNode) collect(t *testing.T, block *common.Block) <-chan []*common.MetadataSignature {
+	result := make(chan []*common.MetadataSignature, 1)
+	signature := node.sign(t, block)
+	go func() {
+		signatures, ok := node.collector.Collect(block, signatureValue, signature)
+		if ok {
+			result <- signatures
+		}
+		close(result)
+	}()
+	return result
+}
+
+// commit writes the block with the given signatures to the ledger of the node.
+func (node *signatureNode) commit(block *common.Block, signatures []*common.MetadataSignature) {
+	node.ledger[block.Header.Number] = blockWithSignatures(block, signatures)
+}
+
+func blockWithSignatures(block *common.Block, signatures []*common.MetadataSignature) *common.Block {
+	block = proto.Clone(block).(*common.Block)
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Value:      signatureValue,
+		Signatures: signatures,
+	})
+	return block
+}
+
+type policyVerifier struct {
+	policy policies.Policy
+}
+
+func (pv *policyVerifier) VerifyBlockSignature(sd []*common.SignedData, _ *common.ConfigEnvelope) error {
+	return pv.policy.Evaluate(sd)
+}
+
+func TestBlockSignatureCollectorThreshold(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	signers, policy := newTestOrderers(t, 3, 2)
+	clock := fakeclock.NewFakeClock(time.Now())
+	_, nodes := newSignatureNetwork(t, clock, 5, signers, policy)
+
+	block := common.NewBlock(5, []byte("previous hash"))
+	result1 := nodes[1].collect(t, block)
+	result2 := nodes[2].collect(t, block)
+
+	var signatures1, signatures2 []*common.MetadataSignature
+	gt.Eventually(result1).Should(Receive(&signatures1))
+	gt.Eventually(result2).Should(Receive(&signatures2))
+	assert.Len(t, signatures1, 2)
+	assert.Len(t, signatures2, 2)
+
+	// The collected signatures satisfy the 2 out of 3 block validation policy,
+	// and a single one of them doesn't.
+	verifier := &policyVerifier{policy: policy}
+	assert.NoError(t, cluster.VerifyBlockSignature(blockWithSignatures(block, signatures1), verifier, nil))
+	assert.NoError(t, cluster.VerifyBlockSignature(blockWithSignatures(block, signatures2), verifier, nil))
+	err := cluster.VerifyBlockSignature(blockWithSignatures(block, signatures1[:1]), verifier, nil)
+	assert.EqualError(t, err, "signature set did not satisfy policy")
+	err = cluster.VerifyBlockSignature(blockWithSignatures(block, signatures2[1:]), verifier, nil)
+	assert.EqualError(t, err, "signature set did not satisfy policy")
+
+	// Signatures over another block don't count
+	otherBlock := common.NewBlock(5, []byte("other previous hash"))
+	otherSignatures := []*common.MetadataSignature{signatures1[0], nodes[3].sign(t, otherBlock)}
+	err = cluster.VerifyBlockSignature(blockWithSignatures(block, otherSignatures), verifier, nil)
+	assert.EqualError(t, err, "signature set did not satisfy policy")
+}
+
+func TestBlockSignatureCollectorCommittedBlock(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	signers, policy := newTestOrderers(t, 3, 3)
+	clock := fakeclock.NewFakeClock(time.Now())
+	network, nodes := newSignatureNetwork(t, clock, 5, signers, policy)
+
+	// Nodes 2 and 3 already wrote the block, and sign it again for node 1
+	block := common.NewBlock(5, []byte("previous hash"))
+	committed := []*common.MetadataSignature{nodes[2].sign(t, block), nodes[3].sign(t, block)}
+	nodes[2].commit(block, committed)
+	nodes[3].commit(block, committed)
+
+	var signatures []*common.MetadataSignature
+	gt.Eventually(nodes[1].collect(t, block)).Should(Receive(&signatures))
+	assert.Len(t, signatures, 3)
+	assert.NoError(t, cluster.VerifyBlockSignature(blockWithSignatures(block, signatures), &policyVerifier{policy: policy}, nil))
+	assert.Equal(t, 2, network.sentBy(1))
+	assert.Equal(t, 1, network.sentBy(2))
+	assert.Equal(t, 1, network.sentBy(3))
+
+	// A signature over a block written by its sender isn't answered
+	err := nodes[2].collector.Receive(3, &etcdraft.BlockSignature{
+		BlockNumber:     5,
+		SignatureHeader: committed[1].SignatureHeader,
+		Signature:       committed[1].Signature,
+		Committed:       true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, network.sentBy(2))
+
+	// A block missing from the ledger can't be signed again
+	delete(nodes[3].ledger, 5)
+	nodes[3].ledger[6] = block
+	err = nodes[3].collector.Receive(1, &etcdraft.BlockSignature{
+		BlockNumber:     5,
+		SignatureHeader: signatures[0].SignatureHeader,
+		Signature:       signatures[0].Signature,
+	})
+	assert.EqualError(t, err, "block [5] is not available")
+}
+
+func TestBlockSignatureCollectorAbort(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	signers, policy := newTestOrderers(t, 3, 2)
+	clock := fakeclock.NewFakeClock(time.Now())
+	network, nodes := newSignatureNetwork(t, clock, 5, signers, policy)
+
+	block := common.NewBlock(5, []byte("previous hash"))
+	result := nodes[1].collect(t, block)
+
+	// The signature is sent again until enough signatures are collected
+	gt.Eventually(clock.WatcherCount).Should(Equal(1))
+	gt.Eventually(func() int { return network.sentBy(1) }).Should(Equal(2))
+	clock.Increment(time.Second)
+	gt.Eventually(func() int { return network.sentBy(1) }).Should(Equal(4))
+
+	nodes[1].collector.Abort()
+	gt.Eventually(result).Should(BeClosed())
+
+	// Collections after the abort are aborted too
+	_, ok := nodes[1].collector.Collect(block, signatureValue, nodes[1].sign(t, block))
+	assert.False(t, ok)
+}
+
+func TestBlockSignatureCollectorReceive(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	signers, policy := newTestOrderers(t, 3, 2)
+	clock := fakeclock.NewFakeClock(time.Now())
+	network, nodes := newSignatureNetwork(t, clock, 5, signers, policy)
+
+	block := common.NewBlock(5, []byte("previous hash"))
+	signature := nodes[2].sign(t, block)
+
+	err := nodes[1].collector.Receive(4, &etcdraft.BlockSignature{
+		BlockNumber:     5,
+		SignatureHeader: signature.SignatureHeader,
+		Signature:       signature.Signature,
+	})
+	assert.EqualError(t, err, "node 4 is not a consenter of channel mychannel")
+
+	err = nodes[1].collector.Receive(2, &etcdraft.BlockSignature{
+		BlockNumber:     5,
+		SignatureHeader: []byte{1, 2, 3},
+		Signature:       signature.Signature,
+	})
+	assert.Contains(t, err.Error(), "invalid signature header")
+
+	// Signatures too far ahead of the ledger are dropped
+	err = nodes[1].collector.Receive(2, &etcdraft.BlockSignature{
+		BlockNumber:     5 + maxBufferedSignatureBlocks,
+		SignatureHeader: signature.SignatureHeader,
+		Signature:       signature.Signature,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, nodes[1].collector.received)
+
+	// Signatures received before the block is written are kept for its collection
+	err = nodes[1].collector.Receive(2, &etcdraft.BlockSignature{
+		BlockNumber:     5,
+		SignatureHeader: signature.SignatureHeader,
+		Signature:       signature.Signature,
+	})
+	assert.NoError(t, err)
+
+	var signatures []*common.MetadataSignature
+	gt.Eventually(nodes[1].collect(t, block)).Should(Receive(&signatures))
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, signature, signatures[1])
+	assert.NoError(t, cluster.VerifyBlockSignature(blockWithSignatures(block, signatures), &policyVerifier{policy: policy}, nil))
+	assert.Equal(t, 0, network.sentBy(2))
+}
+
+func TestChainConsensusBlockSignature(t *testing.T) {
+	chain := &Chain{startC: make(chan struct{}), doneC: make(chan struct{}), raftID: 1}
+	close(chain.startC)
+
+	err := chain.Consensus(&orderer.ConsensusRequest{Channel: "mychannel", Metadata: []byte{1}}, 2)
+	assert.EqualError(t, err, "node 1 doesn't collect block signatures")
+
+	chain.signatureCollector = &BlockSignatureCollector{Consenters: func() []uint64 { return []uint64{1, 2} }}
+	err = chain.Consensus(&orderer.ConsensusRequest{Channel: "mychannel", Metadata: []byte{1}}, 2)
+	assert.Contains(t, err.Error(), "failed to unmarshal block signature")
+}
@@ -57,17 +57,24 @@ type RaftStorage struct {
 	wal  *wal.WAL
 	snap *snap.Snapshotter
 
+	// cipher encrypts the data of WAL entries and snapshots, it is nil
+	// if at-rest encryption is disabled
+	cipher *EntryCipher
+
 	// a queue that keeps track of indices of snapshots on disk
 	snapshotIndex []uint64
 }
 
 // CreateStorage attempts to create a storage to persist etcd/raft data.
 // If data presents in specified disk, they are loaded to reconstruct storage state.
+// If cipher is not nil, the data of WAL entries and snapshots is encrypted on disk.
+// Data persisted before encryption was enabled is still read as is.
 func CreateStorage(
 	lg *flogging.FabricLogger,
 	walDir string,
 	snapDir string,
 	ram MemoryStorage,
+	cipher *EntryCipher,
 ) (*RaftStorage, error) {
 
 	sn, err := createSnapshotter(lg, snapDir)
@@ -86,9 +93,13 @@ func CreateStorage(
 		// snapshot found
 		lg.Debugf("Loaded snapshot at Term %d and Index %d, Nodes: %+v",
 			snapshot.Metadata.Term, snapshot.Metadata.Index, snapshot.Metadata.ConfState.Nodes)
+
+		if err := openSnapshot(cipher, snapshot); err != nil {
+			return nil, errors.Errorf("failed to load snapshot: %s", err)
+		}
 	}
 
-	w, st, ents, err := createOrReadWAL(lg, walDir, snapshot)
+	w, st, ents, err := createOrReadWAL(lg, walDir, snapshot, cipher)
 	if err != nil {
 		return nil, errors.Errorf("failed to create or read WAL: %s", err)
 	}
@@ -114,6 +125,7 @@ func CreateStorage(
 		walDir:        walDir,
 		snapDir:       snapDir,
 		snapshotIndex: ListSnapshots(lg, snapDir),
+		cipher:        cipher,
 	}, nil
 }
 
@@ -172,7 +184,7 @@ func createSnapshotter(logger *flogging.FabricLogger, snapDir string) (*snap.Sna
 	return snap.New(logger.Zap(), snapDir), nil
 }
 
-func createOrReadWAL(lg *flogging.FabricLogger, walDir string, snapshot *raftpb.Snapshot) (w *wal.WAL, st raftpb.HardState, ents []raftpb.Entry, err error) {
+func createOrReadWAL(lg *flogging.FabricLogger, walDir string, snapshot *raftpb.Snapshot, cipher *EntryCipher) (w *wal.WAL, st raftpb.HardState, ents []raftpb.Entry, err error) {
 	if !wal.Exist(walDir) {
 		lg.Infof("No WAL data found, creating new WAL at path '%s'", walDir)
 		// TODO(jay_guo) add metadata to be persisted with wal once we need it.
@@ -231,6 +243,18 @@ func createOrReadWAL(lg *flogging.FabricLogger, walDir string, snapshot *raftpb.
 		break
 	}
 
+	plaintext, err := openEntries(cipher, ents)
+	if err != nil {
+		w.Close()
+		return nil, st, nil, errors.Errorf("failed to read WAL: %s", err)
+	}
+	if cipher != nil && plaintext > 0 {
+		// Entries written before encryption was enabled are kept as they are,
+		// and are purged from disk together with the WAL segments they are in
+		// once enough snapshots are taken.
+		lg.Infof("Found %d unencrypted entries in WAL, new entries will be encrypted", plaintext)
+	}
+
 	return w, st, ents, nil
 }
 
@@ -242,7 +266,12 @@ func (rs *RaftStorage) Snapshot() raftpb.Snapshot {
 
 // Store persists etcd/raft data
 func (rs *RaftStorage) Store(entries []raftpb.Entry, hardstate raftpb.HardState, snapshot raftpb.Snapshot) error {
-	if err := rs.wal.Save(hardstate, entries); err != nil {
+	sealed, err := sealEntries(rs.cipher, entries)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.wal.Save(hardstate, sealed); err != nil {
 		return err
 	}
 
@@ -283,7 +312,12 @@ func (rs *RaftStorage) saveSnap(snap raftpb.Snapshot) error {
 		return errors.Errorf("failed to save snapshot to WAL: %s", err)
 	}
 
-	if err := rs.snap.SaveSnap(snap); err != nil {
+	sealed, err := sealSnapshot(rs.cipher, snap)
+	if err != nil {
+		return err
+	}
+
+	if err := rs.snap.SaveSnap(sealed); err != nil {
 		return errors.Errorf("failed to save snapshot to disk: %s", err)
 	}
 
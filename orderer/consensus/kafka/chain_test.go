@@ -21,6 +21,7 @@ import (
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
 	mockkafka "github.com/hyperledger/fabric/orderer/consensus/kafka/mock"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 	mockmultichannel "github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
@@ -3747,6 +3748,11 @@ func (r *mockReceiver) Cut() []*cb.Envelope {
 	return args.Get(0).([]*cb.Envelope)
 }
 
+func (r *mockReceiver) BatchTimeout() time.Duration {
+	args := r.Called()
+	return args.Get(0).(time.Duration)
+}
+
 type mockConsenterSupport struct {
 	mock.Mock
 }
@@ -3819,6 +3825,10 @@ func (c *mockConsenterSupport) WriteConfigBlock(block *cb.Block, encodedMetadata
 	return
 }
 
+func (c *mockConsenterSupport) SetSignatureCollector(collector consensus.SignatureCollector) {
+	c.Called(collector)
+}
+
 func (c *mockConsenterSupport) Sequence() uint64 {
 	args := c.Called()
 	return args.Get(0).(uint64)
@@ -161,6 +161,11 @@ type FakeConsenterSupport struct {
 	sequenceReturnsOnCall map[int]struct {
 		result1 uint64
 	}
+	SetSignatureCollectorStub        func(consensus.SignatureCollector)
+	setSignatureCollectorMutex       sync.RWMutex
+	setSignatureCollectorArgsForCall []struct {
+		arg1 consensus.SignatureCollector
+	}
 	SharedConfigStub        func() channelconfig.Orderer
 	sharedConfigMutex       sync.RWMutex
 	sharedConfigArgsForCall []struct {
@@ -967,6 +972,37 @@ func (fake *FakeConsenterSupport) SequenceReturnsOnCall(i int, result1 uint64) {
 	}{result1}
 }
 
+func (fake *FakeConsenterSupport) SetSignatureCollector(arg1 consensus.SignatureCollector) {
+	fake.setSignatureCollectorMutex.Lock()
+	fake.setSignatureCollectorArgsForCall = append(fake.setSignatureCollectorArgsForCall, struct {
+		arg1 consensus.SignatureCollector
+	}{arg1})
+	fake.recordInvocation("SetSignatureCollector", []interface{}{arg1})
+	fake.setSignatureCollectorMutex.Unlock()
+	if fake.SetSignatureCollectorStub != nil {
+		fake.SetSignatureCollectorStub(arg1)
+	}
+}
+
+func (fake *FakeConsenterSupport) SetSignatureCollectorCallCount() int {
+	fake.setSignatureCollectorMutex.RLock()
+	defer fake.setSignatureCollectorMutex.RUnlock()
+	return len(fake.setSignatureCollectorArgsForCall)
+}
+
+func (fake *FakeConsenterSupport) SetSignatureCollectorCalls(stub func(consensus.SignatureCollector)) {
+	fake.setSignatureCollectorMutex.Lock()
+	defer fake.setSignatureCollectorMutex.Unlock()
+	fake.SetSignatureCollectorStub = stub
+}
+
+func (fake *FakeConsenterSupport) SetSignatureCollectorArgsForCall(i int) consensus.SignatureCollector {
+	fake.setSignatureCollectorMutex.RLock()
+	defer fake.setSignatureCollectorMutex.RUnlock()
+	argsForCall := fake.setSignatureCollectorArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeConsenterSupport) SharedConfig() channelconfig.Orderer {
 	fake.sharedConfigMutex.Lock()
 	ret, specificReturn := fake.sharedConfigReturnsOnCall[len(fake.sharedConfigArgsForCall)]
@@ -1256,6 +1292,8 @@ func (fake *FakeConsenterSupport) Invocations() map[string][][]interface{} {
 	defer fake.processNormalMsgMutex.RUnlock()
 	fake.sequenceMutex.RLock()
 	defer fake.sequenceMutex.RUnlock()
+	fake.setSignatureCollectorMutex.RLock()
+	defer fake.setSignatureCollectorMutex.RUnlock()
 	fake.sharedConfigMutex.RLock()
 	defer fake.sharedConfigMutex.RUnlock()
 	fake.signMutex.RLock()
@@ -8,6 +8,7 @@ package blockcutter
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -29,6 +30,9 @@ type Receiver struct {
 	// SkipAppendCurBatch causes Ordered to skip appending to curBatch
 	SkipAppendCurBatch bool
 
+	// BatchTimeoutVal is returned by BatchTimeout
+	BatchTimeoutVal time.Duration
+
 	// Lock to serialize writes access to curBatch
 	mutex sync.Mutex
 
@@ -98,6 +102,11 @@ func (mbc *Receiver) Cut() []*cb.Envelope {
 	return res
 }
 
+// BatchTimeout returns BatchTimeoutVal
+func (mbc *Receiver) BatchTimeout() time.Duration {
+	return mbc.BatchTimeoutVal
+}
+
 func (mbc *Receiver) CurBatch() []*cb.Envelope {
 	mbc.mutex.Lock()
 	defer mbc.mutex.Unlock()
@@ -11,6 +11,7 @@ import (
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -69,6 +70,9 @@ type ConsenterSupport struct {
 
 	// BlockVerificationErr is returned by VerifyBlockSignature
 	BlockVerificationErr error
+
+	// SignatureCollectorVal is the collector set by SetSignatureCollector
+	SignatureCollectorVal consensus.SignatureCollector
 }
 
 // Block returns the block with the given number or nil if not found
@@ -116,6 +120,11 @@ func (mcs *ConsenterSupport) WriteConfigBlock(block *cb.Block, encodedMetadataVa
 	mcs.WriteBlock(block, encodedMetadataValue)
 }
 
+// SetSignatureCollector sets SignatureCollectorVal
+func (mcs *ConsenterSupport) SetSignatureCollector(collector consensus.SignatureCollector) {
+	mcs.SignatureCollectorVal = collector
+}
+
 // ChainID returns the chain ID this specific consenter instance is associated with
 func (mcs *ConsenterSupport) ChainID() string {
 	return mcs.ChainIDVal
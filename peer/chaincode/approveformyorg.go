@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeApproveForMyOrgCmd *cobra.Command
+
+const approveForMyOrgCmdName = "approveformyorg"
+
+const approveForMyOrgDesc = "Approve the chaincode definition for my org."
+
+// approveForMyOrgCmd returns the cobra command for approving a chaincode definition
+func approveForMyOrgCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeApproveForMyOrgCmd = &cobra.Command{
+		Use:   approveForMyOrgCmdName,
+		Short: fmt.Sprint(approveForMyOrgDesc),
+		Long:  fmt.Sprint(approveForMyOrgDesc),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return approveForMyOrg(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"version",
+		"sequence",
+		"policy",
+		"escc",
+		"vscc",
+		"collections-config",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(chaincodeApproveForMyOrgCmd, flagList)
+
+	return chaincodeApproveForMyOrgCmd
+}
+
+// approveForMyOrg records the approval of the chaincode definition by the
+// org of the peer; the peer must be a peer of the org of the client
+func approveForMyOrg(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	definition, err := getChaincodeDefinition(cmd)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+
+	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+		Name:       chaincodeName,
+		Definition: definition,
+	}
+	_, err = lifecycleInvokeOrQuery(lifecycle.ApproveChaincodeDefinitionForMyOrgFuncName, args, true, cf)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Approved definition of chaincode %s:%s with sequence %d on channel %s", chaincodeName, chaincodeVersion, sequence, channelID)
+	return nil
+}
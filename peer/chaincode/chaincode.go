@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,6 +38,7 @@ var platformRegistry = platforms.NewRegistry(
 	&car.Platform{},
 	&java.Platform{},
 	&node.Platform{},
+	&wasm.Platform{},
 )
 
 func addFlags(cmd *cobra.Command) {
@@ -72,6 +74,7 @@ var (
 	chaincodeQueryHex     bool
 	channelID             string
 	chaincodeVersion      string
+	sequence              int64
 	policy                string
 	escc                  string
 	vscc                  string
@@ -116,6 +119,8 @@ func resetFlags() {
 		fmt.Sprint("Name of the chaincode"))
 	flags.StringVarP(&chaincodeVersion, "version", "v", common.UndefinedParamValue,
 		fmt.Sprint("Version of the chaincode specified in install/instantiate/upgrade commands"))
+	flags.Int64VarP(&sequence, "sequence", "", 0,
+		fmt.Sprint("The sequence number of the chaincode definition on the channel, specified in lifecycle commands"))
 	flags.StringVarP(&chaincodeUsr, "username", "u", common.UndefinedParamValue,
 		fmt.Sprint("Username for chaincode operations when security is enabled"))
 	flags.StringVarP(&channelID, "channelID", "C", "",
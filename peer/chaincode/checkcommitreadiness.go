@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeCheckCommitReadinessCmd *cobra.Command
+
+const checkCommitReadinessCmdName = "checkcommitreadiness"
+
+const checkCommitReadinessDesc = "Check which orgs approved the chaincode definition."
+
+// checkCommitReadinessCmd returns the cobra command for checking the approvals of a chaincode definition
+func checkCommitReadinessCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeCheckCommitReadinessCmd = &cobra.Command{
+		Use:   checkCommitReadinessCmdName,
+		Short: fmt.Sprint(checkCommitReadinessDesc),
+		Long:  fmt.Sprint(checkCommitReadinessDesc),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCommitReadiness(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"version",
+		"sequence",
+		"policy",
+		"escc",
+		"vscc",
+		"collections-config",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeCheckCommitReadinessCmd, flagList)
+
+	return chaincodeCheckCommitReadinessCmd
+}
+
+// checkCommitReadiness prints whether each org of the channel approved
+// the chaincode definition
+func checkCommitReadiness(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	definition, err := getChaincodeDefinition(cmd)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.CheckCommitReadinessArgs{
+		Name:       chaincodeName,
+		Definition: definition,
+	}
+	resultBytes, err := lifecycleInvokeOrQuery(lifecycle.CheckCommitReadinessFuncName, args, false, cf)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	if err = proto.Unmarshal(resultBytes, result); err != nil {
+		return fmt.Errorf("error unmarshaling result of %s: %s", checkCommitReadinessCmdName, err)
+	}
+
+	orgs := make([]string, 0, len(result.Approvals))
+	for org := range result.Approvals {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	fmt.Printf("Approvals of the definition of chaincode %s:%s with sequence %d on channel %s:\n", chaincodeName, chaincodeVersion, sequence, channelID)
+	for _, org := range orgs {
+		fmt.Printf("\t%s: %t\n", org, result.Approvals[org])
+	}
+	return nil
+}
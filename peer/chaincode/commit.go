@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeCommitCmd *cobra.Command
+
+const commitCmdName = "commit"
+
+const commitDesc = "Commit the chaincode definition on the channel."
+
+// commitCmd returns the cobra command for committing a chaincode definition
+func commitCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeCommitCmd = &cobra.Command{
+		Use:   commitCmdName,
+		Short: fmt.Sprint(commitDesc),
+		Long:  fmt.Sprint(commitDesc),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commit(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"version",
+		"sequence",
+		"policy",
+		"escc",
+		"vscc",
+		"collections-config",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(chaincodeCommitCmd, flagList)
+
+	return chaincodeCommitCmd
+}
+
+// commit commits the chaincode definition on the channel; the transaction
+// must be endorsed by peers of a majority of the orgs of the channel
+func commit(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	definition, err := getChaincodeDefinition(cmd)
+	if err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+
+	args := &lb.CommitChaincodeDefinitionArgs{
+		Name:       chaincodeName,
+		Definition: definition,
+	}
+	_, err = lifecycleInvokeOrQuery(lifecycle.CommitChaincodeDefinitionFuncName, args, true, cf)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Committed definition of chaincode %s:%s with sequence %d on channel %s", chaincodeName, chaincodeVersion, sequence, channelID)
+	return nil
+}
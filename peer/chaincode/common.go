@@ -153,12 +153,18 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 }
 
 type collectionConfigJson struct {
-	Name           string `json:"name"`
-	Policy         string `json:"policy"`
-	RequiredCount  int32  `json:"requiredPeerCount"`
-	MaxPeerCount   int32  `json:"maxPeerCount"`
-	BlockToLive    uint64 `json:"blockToLive"`
-	MemberOnlyRead bool   `json:"memberOnlyRead"`
+	Name           string             `json:"name"`
+	Policy         string             `json:"policy"`
+	RequiredCount  int32              `json:"requiredPeerCount"`
+	MaxPeerCount   int32              `json:"maxPeerCount"`
+	BlockToLive    uint64             `json:"blockToLive"`
+	MemberOnlyRead bool               `json:"memberOnlyRead"`
+	ErasureCoding  *erasureCodingJson `json:"erasureCoding,omitempty"`
+}
+
+type erasureCodingJson struct {
+	DataShards   uint32 `json:"dataShards"`
+	ParityShards uint32 `json:"parityShards"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -196,6 +202,14 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		var ec *pcommon.ErasureCodingConfig
+		if cconfitem.ErasureCoding != nil {
+			ec = &pcommon.ErasureCodingConfig{
+				DataShards:   cconfitem.ErasureCoding.DataShards,
+				ParityShards: cconfitem.ErasureCoding.ParityShards,
+			}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -205,6 +219,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 					MaximumPeerCount:  cconfitem.MaxPeerCount,
 					BlockToLive:       cconfitem.BlockToLive,
 					MemberOnlyRead:    cconfitem.MemberOnlyRead,
+					ErasureCoding:     ec,
 				},
 			},
 		}
@@ -311,8 +326,9 @@ func validatePeerConnectionParameters(cmdName string) error {
 		}
 	}
 
-	// currently only support multiple peer addresses for invoke
-	if cmdName != "invoke" && len(peerAddresses) > 1 {
+	// currently only support multiple peer addresses for invoke and for
+	// commit, which must be endorsed by peers of a majority of the orgs
+	if cmdName != "invoke" && cmdName != commitCmdName && len(peerAddresses) > 1 {
 		return errors.Errorf("'%s' command can only be executed against one peer. received %d", cmdName, len(peerAddresses))
 	}
 
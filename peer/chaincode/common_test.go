@@ -187,6 +187,19 @@ const sampleCollectionConfigGood = `[
 	}
 ]`
 
+const sampleCollectionConfigErasureCoded = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 3,
+		"erasureCoding": {
+			"dataShards": 2,
+			"parityShards": 1
+		}
+	}
+]`
+
 const sampleCollectionConfigBad = `[
 	{
 		"name": "foo",
@@ -210,8 +223,16 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Equal(t, pol, conf.MemberOrgsPolicy.GetSignaturePolicy())
 	assert.Equal(t, 10, int(conf.BlockToLive))
 	assert.Equal(t, true, conf.MemberOnlyRead)
+	assert.Nil(t, conf.ErasureCoding)
 	t.Logf("conf=%s", conf)
 
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigErasureCoded))
+	assert.NoError(t, err)
+	ccp = &common2.CollectionConfigPackage{}
+	proto.Unmarshal(cc, ccp)
+	conf = ccp.Config[0].GetStaticCollectionConfig()
+	assert.Equal(t, &common2.ErasureCodingConfig{DataShards: 2, ParityShards: 1}, conf.ErasureCoding)
+
 	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBad))
 	assert.Error(t, err)
 	assert.Nil(t, cc)
@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	lifecycleFuncName = "lifecycle"
+	lifecycleCmdDes   = "Perform lifecycle operations: chaincode."
+
+	lifecycleChaincodeCmdDes = "Operate the definition of a chaincode: approveformyorg|checkcommitreadiness|commit|querycommitted."
+)
+
+// LifecycleCmd returns the cobra command for the lifecycle of chaincodes
+// defined through the lifecycle SCC
+func LifecycleCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	lifecycleCmd := &cobra.Command{
+		Use:   lifecycleFuncName,
+		Short: fmt.Sprint(lifecycleCmdDes),
+		Long:  fmt.Sprint(lifecycleCmdDes),
+	}
+
+	lifecycleChaincodeCmd := &cobra.Command{
+		Use:   chainFuncName,
+		Short: fmt.Sprint(lifecycleChaincodeCmdDes),
+		Long:  fmt.Sprint(lifecycleChaincodeCmdDes),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			common.InitCmd(cmd, args)
+			common.SetOrdererEnv(cmd, args)
+		},
+	}
+	common.AddOrdererFlags(lifecycleChaincodeCmd)
+
+	lifecycleChaincodeCmd.AddCommand(approveForMyOrgCmd(cf))
+	lifecycleChaincodeCmd.AddCommand(checkCommitReadinessCmd(cf))
+	lifecycleChaincodeCmd.AddCommand(commitCmd(cf))
+	lifecycleChaincodeCmd.AddCommand(queryCommittedCmd(cf))
+
+	lifecycleCmd.AddCommand(lifecycleChaincodeCmd)
+
+	return lifecycleCmd
+}
+
+// getChaincodeDefinition assembles the chaincode definition described
+// by the command-line flags
+func getChaincodeDefinition(cmd *cobra.Command) (*lb.ChaincodeDefinition, error) {
+	if chaincodeName == common.UndefinedParamValue {
+		return nil, errors.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+
+	if chaincodeVersion == common.UndefinedParamValue {
+		return nil, errors.Errorf("chaincode version is not provided for %s", cmd.Name())
+	}
+
+	if sequence <= 0 {
+		return nil, errors.Errorf("chaincode sequence must be a positive number for %s", cmd.Name())
+	}
+
+	if escc != common.UndefinedParamValue {
+		logger.Infof("Using escc %s", escc)
+	} else {
+		logger.Info("Using default escc")
+		escc = "escc"
+	}
+
+	if vscc != common.UndefinedParamValue {
+		logger.Infof("Using vscc %s", vscc)
+	} else {
+		logger.Info("Using default vscc")
+		vscc = "vscc"
+	}
+
+	// unlike lscc, the lifecycle SCC has no default endorsement
+	// policy, as the client doesn't know the orgs of the channel
+	if policy == common.UndefinedParamValue {
+		return nil, errors.Errorf("endorsement policy is not provided for %s", cmd.Name())
+	}
+	p, err := cauthdsl.FromString(policy)
+	if err != nil {
+		return nil, errors.Errorf("invalid policy %s", policy)
+	}
+
+	var collections *pcommon.CollectionConfigPackage
+	if collectionsConfigFile != common.UndefinedParamValue {
+		collectionConfigBytes, err = getCollectionConfigFromFile(collectionsConfigFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
+		}
+		collections = &pcommon.CollectionConfigPackage{}
+		if err = proto.Unmarshal(collectionConfigBytes, collections); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal collection configuration")
+		}
+	}
+
+	return &lb.ChaincodeDefinition{
+		Sequence:            sequence,
+		Version:             chaincodeVersion,
+		EndorsementPlugin:   escc,
+		ValidationPlugin:    vscc,
+		ValidationParameter: putils.MarshalOrPanic(p),
+		Collections:         collections,
+	}, nil
+}
+
+// lifecycleInvokeOrQuery calls the given function of the lifecycle SCC
+// with the given arguments on the channel, and returns the payload of
+// the response. If invoke is true, the endorsed transaction is also sent
+// for ordering
+func lifecycleInvokeOrQuery(funcName string, args proto.Message, invoke bool, cf *ChaincodeCmdFactory) ([]byte, error) {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not marshal arguments of %s", funcName)
+	}
+
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_GOLANG,
+		ChaincodeId: &pb.ChaincodeID{Name: lifecycle.LifecycleNamespace},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}},
+	}
+
+	proposalResp, err := ChaincodeInvokeOrQuery(
+		spec,
+		channelID,
+		"",
+		invoke,
+		cf.Signer,
+		cf.Certificate,
+		cf.EndorserClients,
+		cf.DeliverClients,
+		cf.BroadcastClient)
+	if err != nil {
+		return nil, errors.Errorf("%s - proposal response: %v", err, proposalResp)
+	}
+
+	if proposalResp == nil {
+		return nil, errors.Errorf("error during %s: received nil proposal response", funcName)
+	}
+	if proposalResp.Endorsement == nil || proposalResp.Response.Status >= shim.ERRORTHRESHOLD {
+		return nil, errors.Errorf("endorsement failure during %s. response: %v", funcName, proposalResp.Response)
+	}
+
+	return proposalResp.Response.Payload, nil
+}
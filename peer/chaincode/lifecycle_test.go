@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleCmd(t *testing.T) {
+	cmd := LifecycleCmd(nil)
+	assert.Equal(t, "lifecycle", cmd.Name())
+
+	chaincodeCmd, _, err := cmd.Find([]string{"chaincode"})
+	assert.NoError(t, err)
+	for _, name := range []string{"approveformyorg", "checkcommitreadiness", "commit", "querycommitted"} {
+		subCmd, _, err := chaincodeCmd.Find([]string{name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, subCmd.Name())
+	}
+}
+
+func TestLifecycleDefinitionCmds(t *testing.T) {
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+	cmds := map[string]func(*ChaincodeCmdFactory) *cobra.Command{
+		"approveformyorg":      approveForMyOrgCmd,
+		"checkcommitreadiness": checkCommitReadinessCmd,
+		"commit":               commitCmd,
+	}
+
+	var tests = []struct {
+		name          string
+		args          []string
+		errorExpected bool
+		errMsg        string
+	}{
+		{
+			name:          "successful",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')"},
+			errorExpected: false,
+			errMsg:        "Run lifecycle cmd error",
+		},
+		{
+			name:          "successful with plugins and collections",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')", "-E", "myescc", "-V", "myvscc", "--collections-config", "testdata/collections.json"},
+			errorExpected: false,
+			errMsg:        "Run lifecycle cmd error",
+		},
+		{
+			name:          "missing channelID",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-P", "OR('Org1.member', 'Org2.member')"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command without the -C option",
+		},
+		{
+			name:          "missing name",
+			args:          []string{"-v", "1.0", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command without the -n option",
+		},
+		{
+			name:          "missing version",
+			args:          []string{"-n", "example02", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command without the -v option",
+		},
+		{
+			name:          "missing sequence",
+			args:          []string{"-n", "example02", "-v", "1.0", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command without the --sequence option",
+		},
+		{
+			name:          "missing policy",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-C", "mychannel"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command without the -P option",
+		},
+		{
+			name:          "invalid policy",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member'"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command with an invalid policy",
+		},
+		{
+			name:          "invalid collections",
+			args:          []string{"-n", "example02", "-v", "1.0", "--sequence", "1", "-C", "mychannel", "-P", "OR('Org1.member', 'Org2.member')", "--collections-config", "testdata/missing.json"},
+			errorExpected: true,
+			errMsg:        "Expected error executing lifecycle command with missing collections",
+		},
+	}
+	for cmdName, newCmd := range cmds {
+		for _, test := range tests {
+			t.Run(cmdName+"/"+test.name, func(t *testing.T) {
+				resetFlags()
+				cmd := newCmd(mockCF)
+				addFlags(cmd)
+				cmd.SetArgs(test.args)
+				err = cmd.Execute()
+				checkError(t, err, test.errorExpected, test.errMsg)
+			})
+		}
+	}
+}
+
+func TestQueryCommittedCmd(t *testing.T) {
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+	mockCF.EndorserClients[0] = common.GetMockEndorserClient(&pb.ProposalResponse{
+		Response: &pb.Response{
+			Status: 200,
+			Payload: utils.MarshalOrPanic(&lb.QueryChaincodeDefinitionResult{
+				Definition: &lb.ChaincodeDefinition{Sequence: 1, Version: "1.0"},
+			}),
+		},
+		Endorsement: &pb.Endorsement{},
+	}, nil)
+
+	resetFlags()
+	cmd := queryCommittedCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02", "-C", "mychannel"})
+	err = cmd.Execute()
+	assert.NoError(t, err)
+
+	resetFlags()
+	cmd = queryCommittedCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-C", "mychannel"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "must supply value for chaincode name parameter")
+
+	mockCF, err = getMockChaincodeCmdFactoryEndorsementFailure(500, []byte("chaincode 'example02' is not defined"))
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+	resetFlags()
+	cmd = queryCommittedCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02", "-C", "mychannel"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "endorsement failure during QueryChaincodeDefinition")
+}
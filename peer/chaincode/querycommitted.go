@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/peer/common"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeQueryCommittedCmd *cobra.Command
+
+const queryCommittedCmdName = "querycommitted"
+
+const queryCommittedDesc = "Query the committed definition of a chaincode on the channel."
+
+// queryCommittedCmd returns the cobra command for querying a committed chaincode definition
+func queryCommittedCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeQueryCommittedCmd = &cobra.Command{
+		Use:   queryCommittedCmdName,
+		Short: fmt.Sprint(queryCommittedDesc),
+		Long:  fmt.Sprint(queryCommittedDesc),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryCommitted(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeQueryCommittedCmd, flagList)
+
+	return chaincodeQueryCommittedCmd
+}
+
+// queryCommitted prints the definition of the chaincode committed on the channel
+func queryCommitted(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := &lb.QueryChaincodeDefinitionArgs{
+		Name: chaincodeName,
+	}
+	resultBytes, err := lifecycleInvokeOrQuery(lifecycle.QueryChaincodeDefinitionFuncName, args, false, cf)
+	if err != nil {
+		return err
+	}
+
+	result := &lb.QueryChaincodeDefinitionResult{}
+	if err = proto.Unmarshal(resultBytes, result); err != nil {
+		return fmt.Errorf("error unmarshaling result of %s: %s", queryCommittedCmdName, err)
+	}
+
+	definition := result.Definition
+	fmt.Printf("Committed definition of chaincode %s on channel %s:\n", chaincodeName, channelID)
+	fmt.Printf("\tVersion: %s\n\tSequence: %d\n\tEndorsement plugin: %s\n\tValidation plugin: %s\n",
+		definition.GetVersion(), definition.GetSequence(), definition.GetEndorsementPlugin(), definition.GetValidationPlugin())
+	for _, collection := range definition.GetCollections().GetConfig() {
+		if staticCollection := collection.GetStaticCollectionConfig(); staticCollection != nil {
+			fmt.Printf("\tCollection: %s\n", staticCollection.Name)
+		}
+	}
+	return nil
+}
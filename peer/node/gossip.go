@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	gossipStatusPath = "/gossip"
+	gossipYieldPath  = "/gossip/yield"
+)
+
+var (
+	operationsAddress string
+	operationsTLS     bool
+	operationsCAFile  string
+	operationsCert    string
+	operationsKey     string
+	gossipChannelID   string
+)
+
+func gossipCmd() *cobra.Command {
+	nodeGossipCmd.ResetFlags()
+	flags := nodeGossipCmd.PersistentFlags()
+	flags.StringVarP(&operationsAddress, "address", "a", "", "Address of the operations endpoint of the peer. Defaults to operations.listenAddress.")
+	flags.BoolVarP(&operationsTLS, "tls", "", false, "Use TLS when connecting to the operations endpoint. Defaults to operations.tls.enabled.")
+	flags.StringVarP(&operationsCAFile, "cafile", "", "", "Path to a PEM-encoded file of the CA certificates trusted for the operations endpoint.")
+	flags.StringVarP(&operationsCert, "certfile", "", "", "Path to a PEM-encoded client certificate used for the operations endpoint.")
+	flags.StringVarP(&operationsKey, "keyfile", "", "", "Path to a PEM-encoded client key used for the operations endpoint.")
+	gossipChannelsCmd.ResetFlags()
+	gossipChannelsCmd.Flags().StringVarP(&gossipChannelID, "channelID", "c", "", "Channel to inspect. Defaults to all channels.")
+	gossipYieldCmd.ResetFlags()
+	gossipYieldCmd.Flags().StringVarP(&gossipChannelID, "channelID", "c", "", "Channel to yield the leadership of.")
+
+	nodeGossipCmd.ResetCommands()
+	nodeGossipCmd.AddCommand(gossipMembersCmd)
+	nodeGossipCmd.AddCommand(gossipChannelsCmd)
+	nodeGossipCmd.AddCommand(gossipYieldCmd)
+	return nodeGossipCmd
+}
+
+var nodeGossipCmd = &cobra.Command{
+	Use:   "gossip",
+	Short: "Inspects the gossip state of the node.",
+	Long:  `Inspects the gossip membership and channel state of the running node through its operations endpoint.`,
+}
+
+var gossipMembersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "Lists the gossip members known to the node.",
+	Long:  `Lists the alive and dead gossip members known to the node, along with their endpoints, identities and connection state.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		cmd.SilenceUsage = true
+		status, err := fetchGossipStatus()
+		if err != nil {
+			return err
+		}
+		return printJSON(os.Stdout, struct {
+			Self  service.Member   `json:"self"`
+			Alive []service.Member `json:"alive"`
+			Dead  []service.Member `json:"dead"`
+		}{status.Self, status.Alive, status.Dead})
+	},
+}
+
+var gossipChannelsCmd = &cobra.Command{
+	Use:   "channels",
+	Short: "Lists the gossip state of the channels of the node.",
+	Long:  `Lists the ledger heights, chaincodes and leadership status published in gossip for the channels the node has joined.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		cmd.SilenceUsage = true
+		status, err := fetchGossipStatus()
+		if err != nil {
+			return err
+		}
+		if gossipChannelID == "" {
+			return printJSON(os.Stdout, status.Channels)
+		}
+		for _, ch := range status.Channels {
+			if ch.ChannelID == gossipChannelID {
+				return printJSON(os.Stdout, ch)
+			}
+		}
+		return errors.Errorf("node has not joined channel %s", gossipChannelID)
+	},
+}
+
+var gossipYieldCmd = &cobra.Command{
+	Use:   "yield",
+	Short: "Hands off the leadership of a channel.",
+	Long:  `Makes the node relinquish its leadership of a channel, so that another peer of the organization is elected to pull blocks from the ordering service, e.g. before the node is taken down for maintenance.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if gossipChannelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		cmd.SilenceUsage = true
+		_, err := operationsRequest(http.MethodPut, gossipYieldPath+"?channel="+url.QueryEscape(gossipChannelID), http.StatusNoContent)
+		return err
+	},
+}
+
+func fetchGossipStatus() (*service.Status, error) {
+	body, err := operationsRequest(http.MethodGet, gossipStatusPath, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	status := &service.Status{}
+	if err := json.Unmarshal(body, status); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the gossip status")
+	}
+	return status, nil
+}
+
+// operationsRequest sends a request to the operations endpoint of the peer,
+// and returns the body of the response if it has the expected status code
+func operationsRequest(method, path string, expectedStatus int) ([]byte, error) {
+	address := operationsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	useTLS := operationsTLS || viper.GetBool("operations.tls.enabled")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+		tlsConfig, err := operationsTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s://%s%s", scheme, address, path), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the request")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query the operations endpoint")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the response of the operations endpoint")
+	}
+	if resp.StatusCode != expectedStatus {
+		return nil, errors.Errorf("operations endpoint returned %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
+
+func operationsTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if operationsCAFile != "" {
+		caPEM, err := ioutil.ReadFile(operationsCAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA file %s", operationsCAFile)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificates found in CA file %s", operationsCAFile)
+		}
+	}
+	if operationsCert != "" || operationsKey != "" {
+		cert, err := tls.LoadX509KeyPair(operationsCert, operationsKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
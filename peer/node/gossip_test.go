@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type staticStatus service.Status
+
+func (s *staticStatus) Status() *service.Status {
+	return (*service.Status)(s)
+}
+
+type yielder struct {
+	yielded []string
+}
+
+func (y *yielder) YieldLeadership(chainID string) error {
+	if chainID != "mychannel" {
+		return errors.Errorf("channel %s does not use leader election", chainID)
+	}
+	y.yielded = append(y.yielded, chainID)
+	return nil
+}
+
+func TestGossipCmd(t *testing.T) {
+	status := &staticStatus{
+		Self:     service.Member{PKIID: "0a", Endpoint: "peer0:7051"},
+		Alive:    []service.Member{{PKIID: "0b", Endpoint: "peer1:7051", Connected: true}},
+		Dead:     []service.Member{},
+		Channels: []service.Channel{{ChannelID: "mychannel", Leader: true, Peers: []service.ChannelPeer{}}},
+	}
+	y := &yielder{}
+	mux := http.NewServeMux()
+	mux.Handle("/gossip", service.NewStatusHandler(status))
+	mux.Handle("/gossip/yield", service.NewYieldHandler(y))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("members", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"members", "--address", address})
+		assert.NoError(t, cmd.Execute())
+	})
+
+	t.Run("channels", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"channels", "--address", address, "-c", "mychannel"})
+		assert.NoError(t, cmd.Execute())
+	})
+
+	t.Run("unknown channel", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"channels", "--address", address, "-c", "yourchannel"})
+		assert.EqualError(t, cmd.Execute(), "node has not joined channel yourchannel")
+	})
+
+	t.Run("yield", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"yield", "--address", address, "-c", "mychannel"})
+		assert.NoError(t, cmd.Execute())
+		assert.Equal(t, []string{"mychannel"}, y.yielded)
+
+		cmd = gossipCmd()
+		cmd.SetArgs([]string{"yield", "--address", address, "-c", "yourchannel"})
+		assert.EqualError(t, cmd.Execute(), `operations endpoint returned 400 Bad Request: {"error":"channel yourchannel does not use leader election"}`)
+	})
+
+	t.Run("yield without channel", func(t *testing.T) {
+		gossipChannelID = ""
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"yield", "--address", address})
+		assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+	})
+
+	t.Run("endpoint not found", func(t *testing.T) {
+		notFound := httptest.NewServer(http.NotFoundHandler())
+		defer notFound.Close()
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"members", "--address", strings.TrimPrefix(notFound.URL, "http://")})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "operations endpoint returned 404 Not Found")
+	})
+
+	t.Run("bad CA file", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"members", "--address", address, "--tls", "--cafile", "does-not-exist"})
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read CA file does-not-exist")
+	})
+}
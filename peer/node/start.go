@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -44,13 +45,16 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/wasm"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	"github.com/hyperledger/fabric/core/devnet"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
@@ -83,6 +87,7 @@ import (
 	cb "github.com/hyperledger/fabric/protos/common"
 	common2 "github.com/hyperledger/fabric/protos/common"
 	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/token"
 	"github.com/hyperledger/fabric/protos/transientstore"
@@ -99,15 +104,24 @@ const (
 	chaincodeListenAddrKey = "peer.chaincodeListenAddress"
 	defaultChaincodePort   = 7052
 	grpcMaxConcurrency     = 2500
+	devnetCommitTimeout    = 30 * time.Second
 )
 
-var chaincodeDevMode bool
+var (
+	chaincodeDevMode bool
+	devnetMode       bool
+	devnetChannelID  string
+)
 
 func startCmd() *cobra.Command {
 	// Set the flags on the node start command.
 	flags := nodeStartCmd.Flags()
 	flags.BoolVarP(&chaincodeDevMode, "peer-chaincodedev", "", false,
 		"Whether peer in chaincode development mode")
+	flags.BoolVarP(&devnetMode, "devnet", "", false,
+		"Whether peer runs a single-process development network with an embedded solo orderer, implies --peer-chaincodedev")
+	flags.StringVarP(&devnetChannelID, "devnet-channel", "", devnet.DefaultChannelID,
+		"The channel created and joined by the peer in devnet mode")
 
 	return nodeStartCmd
 }
@@ -156,9 +170,13 @@ func serve(args []string) error {
 		&node.Platform{},
 		&java.Platform{},
 		&car.Platform{},
+		&wasm.Platform{},
 	)
 
-	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
+	deployedCCInfoProvider := &lifecycle.DeployedCCInfoProvider{
+		Legacy:                    &lscc.DeployedCCInfoProvider{},
+		ChannelCapabilitiesSource: peer.Default,
+	}
 
 	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
 		return mgmt.GetManagerForChain(chainID)
@@ -190,12 +208,27 @@ func serve(args []string) error {
 
 	// Parameter overrides must be processed before any parameters are
 	// cached. Failures to cache cause the server to terminate immediately.
-	if chaincodeDevMode {
+	if chaincodeDevMode || devnetMode {
 		logger.Info("Running in chaincode development mode")
 		logger.Info("Disable loading validity system chaincode")
 
 		viper.Set("chaincode.mode", chaincode.DevModeUserRunsChaincode)
 	}
+	if devnetMode {
+		logger.Info("Running a development network with an embedded solo orderer")
+
+		// The devnet endpoint signs with the identity of the peer, so it is
+		// only served to local or authenticated clients
+		err := devnet.CheckOperationsEndpoint(viper.GetString("operations.listenAddress"), viper.GetBool("operations.tls.enabled"))
+		if err != nil {
+			return errors.WithMessage(err, "refusing to serve the devnet endpoint")
+		}
+
+		// The peer is the only member of its organization, it pulls the
+		// blocks from the embedded orderer without electing a leader.
+		viper.Set("peer.gossip.useLeaderElection", false)
+		viper.Set("peer.gossip.orgLeader", true)
+	}
 
 	if err := peer.CacheConfiguration(); err != nil {
 		return err
@@ -323,6 +356,13 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	if sp, ok := service.GetGossipService().(service.StatusProvider); ok {
+		opsSystem.RegisterHandler("/gossip", service.NewStatusHandler(sp))
+	}
+	if y, ok := service.GetGossipService().(service.LeadershipYielder); ok {
+		opsSystem.RegisterHandler("/gossip/yield", service.NewYieldHandler(y))
+	}
+
 	// register prover grpc service
 	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
 	// err = registerProverService(peerServer, aclProvider, signingIdentity)
@@ -362,6 +402,14 @@ func serve(args []string) error {
 	}, ccp, sccp, txvalidator.MapBasedPluginMapper(validationPluginsByName),
 		pr, deployedCCInfoProvider, membershipInfoProvider, metricsProvider)
 
+	var devnetOrderer *devnet.Network
+	if devnetMode {
+		devnetOrderer, err = startDevnet(peerServer, peerEndpoint.Address, ccp, sccp)
+		if err != nil {
+			return err
+		}
+	}
+
 	if viper.GetBool("peer.discovery.enabled") {
 		registerDiscoveryService(peerServer, policyMgr, lifecycle)
 	}
@@ -418,6 +466,18 @@ func serve(args []string) error {
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
 
+	if devnetOrderer != nil {
+		opsSystem.RegisterHandler("/devnet/", &devnet.Handler{
+			ChannelID:     devnetChannelID,
+			Signer:        signingIdentity,
+			Endorser:      auth,
+			Orderer:       devnetOrderer,
+			Transactions:  devnet.PeerLedgers(peer.GetLedger),
+			CommitTimeout: devnetCommitTimeout,
+		})
+		logger.Infof("Devnet channel [%s] is ready, chaincodes started by the user register at %s", devnetChannelID, viper.GetString(chaincodeListenAddrKey))
+	}
+
 	go func() {
 		var grpcErr error
 		if grpcErr = peerServer.Start(); grpcErr != nil {
@@ -426,10 +486,68 @@ func serve(args []string) error {
 		serve <- grpcErr
 	}()
 
+	if viper.GetBool("peer.gossip.connectivityCheck.enabled") {
+		if ccp, ok := service.GetGossipService().(service.ConnectivityCheckProvider); ok {
+			checker, err := ccp.NewConnectivityChecker()
+			if err != nil {
+				return errors.WithMessage(err, "failed to create gossip connectivity checker")
+			}
+			if err := opsSystem.RegisterChecker("gossip.external_endpoint", checker.ExternalEndpoint()); err != nil {
+				return errors.WithMessage(err, "failed to register external endpoint health check")
+			}
+			if err := opsSystem.RegisterChecker("gossip.anchor_peers", checker.AnchorPeers()); err != nil {
+				return errors.WithMessage(err, "failed to register anchor peers health check")
+			}
+			checker.Start()
+			defer checker.Stop()
+		}
+	}
+
 	// Block until grpc server exits
 	return <-serve
 }
 
+// startDevnet starts the solo orderer embedded in the peer, which serves the
+// AtomicBroadcast API on the gRPC server of the peer, and joins the peer to
+// the devnet channel
+func startDevnet(
+	peerServer *comm.GRPCServer,
+	peerAddress string,
+	ccp ccprovider.ChaincodeProvider,
+	sccp *scc.Provider,
+) (*devnet.Network, error) {
+	lf, err := devnet.NewLedgerFactory(filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "devnet"))
+	if err != nil {
+		return nil, err
+	}
+	signer := localmsp.NewSigner()
+	network, err := devnet.NewNetwork(devnet.Config{
+		ChannelID:      devnetChannelID,
+		OrdererProfile: devnet.DefaultOrdererProfile,
+		ChannelProfile: devnet.DefaultChannelProfile,
+		OrdererAddress: peerAddress,
+	}, lf, signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to start the devnet orderer")
+	}
+	ab.RegisterAtomicBroadcastServer(peerServer.Server(), network)
+
+	joined := func(cid string) bool {
+		return peer.GetLedger(cid) != nil
+	}
+	join := func(block *cb.Block) error {
+		if err := peer.CreateChainFromBlock(block, ccp, sccp); err != nil {
+			return err
+		}
+		peer.InitChain(devnetChannelID)
+		return nil
+	}
+	if err := network.JoinChannel(signer, joined, join); err != nil {
+		return nil, errors.WithMessage(err, "failed to set up the devnet channel")
+	}
+	return network, nil
+}
+
 func handleSignals(handlers map[os.Signal]func()) {
 	var signals []os.Signal
 	for sig := range handlers {
@@ -653,6 +771,7 @@ func registerChaincodeSupport(
 	packageProvider *persistence.PackageProvider,
 	aclProvider aclmgmt.ACLProvider,
 	pr *platforms.Registry,
+	lifecycleImpl *lifecycle.Lifecycle,
 	lifecycleSCC *lifecycle.SCC,
 	ops *operations.System,
 ) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider) {
@@ -665,6 +784,7 @@ func registerChaincodeSupport(
 
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
 	lsccInst := lscc.New(sccp, aclProvider, pr)
+	lifecycleImpl.Legacy = lsccInst
 
 	dockerProvider := dockercontroller.NewProvider(
 		viper.GetString("peer.id"),
@@ -689,7 +809,7 @@ func registerChaincodeSupport(
 		ca.CertBytes(),
 		authenticator,
 		packageProvider,
-		lsccInst,
+		lifecycleImpl,
 		aclProvider,
 		container.NewVMController(
 			map[string]container.VMProvider{
@@ -748,12 +868,18 @@ func startChaincodeServer(
 		Store:    ccStore,
 	}
 
+	lifecycleImpl := &lifecycle.Lifecycle{
+		PackageParser:       ccPackageParser,
+		ChaincodeStore:      ccStore,
+		ChannelOrgSource:    peer.Default,
+		ChannelPolicySource: peer.Default,
+		LegacyPackageSource: &ccprovider.CCInfoFSImpl{},
+	}
+
 	lifecycleSCC := &lifecycle.SCC{
-		Protobuf: &lifecycle.ProtobufImpl{},
-		Functions: &lifecycle.Lifecycle{
-			PackageParser:  ccPackageParser,
-			ChaincodeStore: ccStore,
-		},
+		Protobuf:  &lifecycle.ProtobufImpl{},
+		Functions: lifecycleImpl,
+		OrgMSPID:  viper.GetString("peer.localMspId"),
 	}
 
 	// Create a self-signed CA for chaincode service
@@ -772,6 +898,7 @@ func startChaincodeServer(
 		packageProvider,
 		aclProvider,
 		pr,
+		lifecycleImpl,
 		lifecycleSCC,
 		ops,
 	)
@@ -32,7 +32,7 @@ func (m *CollectionConfigPackage) Reset()         { *m = CollectionConfigPackage
 func (m *CollectionConfigPackage) String() string { return proto.CompactTextString(m) }
 func (*CollectionConfigPackage) ProtoMessage()    {}
 func (*CollectionConfigPackage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{0}
+	return fileDescriptor_collection_beedc5e862fdd829, []int{0}
 }
 func (m *CollectionConfigPackage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionConfigPackage.Unmarshal(m, b)
@@ -75,7 +75,7 @@ func (m *CollectionConfig) Reset()         { *m = CollectionConfig{} }
 func (m *CollectionConfig) String() string { return proto.CompactTextString(m) }
 func (*CollectionConfig) ProtoMessage()    {}
 func (*CollectionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{1}
+	return fileDescriptor_collection_beedc5e862fdd829, []int{1}
 }
 func (m *CollectionConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionConfig.Unmarshal(m, b)
@@ -199,17 +199,23 @@ type StaticCollectionConfig struct {
 	// can read the private data (if set to true), or even non members can
 	// read the data (if set to false, for example if you want to implement more granular
 	// access logic in the chaincode)
-	MemberOnlyRead       bool     `protobuf:"varint,6,opt,name=member_only_read,json=memberOnlyRead,proto3" json:"member_only_read,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MemberOnlyRead bool `protobuf:"varint,6,opt,name=member_only_read,json=memberOnlyRead,proto3" json:"member_only_read,omitempty"`
+	// The erasure coding configuration of the collection. If set, instead of
+	// sending the private data in full to up to maximum_peer_count peers upon
+	// endorsement, the private data is split into data_shards + parity_shards
+	// erasure-coded shards, each of which is sent to a different member peer.
+	// Any data_shards of the shards suffice to reconstruct the private data.
+	ErasureCoding        *ErasureCodingConfig `protobuf:"bytes,7,opt,name=erasure_coding,json=erasureCoding,proto3" json:"erasure_coding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *StaticCollectionConfig) Reset()         { *m = StaticCollectionConfig{} }
 func (m *StaticCollectionConfig) String() string { return proto.CompactTextString(m) }
 func (*StaticCollectionConfig) ProtoMessage()    {}
 func (*StaticCollectionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{2}
+	return fileDescriptor_collection_beedc5e862fdd829, []int{2}
 }
 func (m *StaticCollectionConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StaticCollectionConfig.Unmarshal(m, b)
@@ -271,6 +277,13 @@ func (m *StaticCollectionConfig) GetMemberOnlyRead() bool {
 	return false
 }
 
+func (m *StaticCollectionConfig) GetErasureCoding() *ErasureCodingConfig {
+	if m != nil {
+		return m.ErasureCoding
+	}
+	return nil
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -288,7 +301,7 @@ func (m *CollectionPolicyConfig) Reset()         { *m = CollectionPolicyConfig{}
 func (m *CollectionPolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*CollectionPolicyConfig) ProtoMessage()    {}
 func (*CollectionPolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{3}
+	return fileDescriptor_collection_beedc5e862fdd829, []int{3}
 }
 func (m *CollectionPolicyConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionPolicyConfig.Unmarshal(m, b)
@@ -403,7 +416,7 @@ func (m *CollectionCriteria) Reset()         { *m = CollectionCriteria{} }
 func (m *CollectionCriteria) String() string { return proto.CompactTextString(m) }
 func (*CollectionCriteria) ProtoMessage()    {}
 func (*CollectionCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{4}
+	return fileDescriptor_collection_beedc5e862fdd829, []int{4}
 }
 func (m *CollectionCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionCriteria.Unmarshal(m, b)
@@ -451,46 +464,102 @@ func (m *CollectionCriteria) GetNamespace() string {
 	return ""
 }
 
+// ErasureCodingConfig defines how the private data of a collection
+// is erasure-coded upon dissemination
+type ErasureCodingConfig struct {
+	// the number of shards needed to reconstruct the private data
+	DataShards uint32 `protobuf:"varint,1,opt,name=data_shards,json=dataShards,proto3" json:"data_shards,omitempty"`
+	// the number of redundant shards
+	ParityShards         uint32   `protobuf:"varint,2,opt,name=parity_shards,json=parityShards,proto3" json:"parity_shards,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErasureCodingConfig) Reset()         { *m = ErasureCodingConfig{} }
+func (m *ErasureCodingConfig) String() string { return proto.CompactTextString(m) }
+func (*ErasureCodingConfig) ProtoMessage()    {}
+func (*ErasureCodingConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_collection_beedc5e862fdd829, []int{5}
+}
+func (m *ErasureCodingConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErasureCodingConfig.Unmarshal(m, b)
+}
+func (m *ErasureCodingConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErasureCodingConfig.Marshal(b, m, deterministic)
+}
+func (dst *ErasureCodingConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErasureCodingConfig.Merge(dst, src)
+}
+func (m *ErasureCodingConfig) XXX_Size() int {
+	return xxx_messageInfo_ErasureCodingConfig.Size(m)
+}
+func (m *ErasureCodingConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErasureCodingConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErasureCodingConfig proto.InternalMessageInfo
+
+func (m *ErasureCodingConfig) GetDataShards() uint32 {
+	if m != nil {
+		return m.DataShards
+	}
+	return 0
+}
+
+func (m *ErasureCodingConfig) GetParityShards() uint32 {
+	if m != nil {
+		return m.ParityShards
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*CollectionConfigPackage)(nil), "common.CollectionConfigPackage")
 	proto.RegisterType((*CollectionConfig)(nil), "common.CollectionConfig")
 	proto.RegisterType((*StaticCollectionConfig)(nil), "common.StaticCollectionConfig")
 	proto.RegisterType((*CollectionPolicyConfig)(nil), "common.CollectionPolicyConfig")
 	proto.RegisterType((*CollectionCriteria)(nil), "common.CollectionCriteria")
-}
-
-func init() { proto.RegisterFile("common/collection.proto", fileDescriptor_collection_12a2cf6632dc7d83) }
-
-var fileDescriptor_collection_12a2cf6632dc7d83 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x51, 0x6b, 0xdb, 0x30,
-	0x10, 0xc7, 0xeb, 0x36, 0x4d, 0xe7, 0x0b, 0xdb, 0x32, 0x95, 0xa5, 0x66, 0x8c, 0x2e, 0x84, 0x3d,
-	0x18, 0x36, 0x9c, 0xd1, 0x7d, 0x83, 0x86, 0x41, 0xc7, 0x02, 0x0b, 0xea, 0x9e, 0xfa, 0x62, 0x14,
-	0xf9, 0xea, 0x88, 0xca, 0x92, 0x2b, 0x2b, 0x21, 0x7e, 0xdc, 0x97, 0xd9, 0xe7, 0x1c, 0x91, 0xec,
-	0x24, 0x0d, 0x79, 0xf3, 0xdd, 0xff, 0x77, 0xe7, 0xbb, 0xfb, 0xdb, 0x70, 0xc5, 0x75, 0x51, 0x68,
-	0x35, 0xe6, 0x5a, 0x4a, 0xe4, 0x56, 0x68, 0x95, 0x94, 0x46, 0x5b, 0x4d, 0xba, 0x5e, 0xf8, 0xf0,
-	0xbe, 0x01, 0x4a, 0x2d, 0x05, 0x17, 0x58, 0x79, 0x79, 0xf4, 0x0b, 0xae, 0x26, 0xdb, 0x92, 0x89,
-	0x56, 0x8f, 0x22, 0x9f, 0x31, 0xfe, 0xc4, 0x72, 0x24, 0xdf, 0xa0, 0xcb, 0x5d, 0x22, 0x0a, 0x86,
-	0x67, 0x71, 0xef, 0x26, 0x4a, 0x7c, 0x8b, 0xe4, 0xb0, 0x80, 0x36, 0xdc, 0xa8, 0x86, 0xfe, 0xa1,
-	0x46, 0x1e, 0x20, 0xaa, 0x2c, 0xb3, 0x82, 0xa7, 0xbb, 0xd1, 0xd2, 0x6d, 0xdf, 0x20, 0xee, 0xdd,
-	0x5c, 0xb7, 0x7d, 0xef, 0x1d, 0x77, 0xd8, 0xe1, 0xee, 0x84, 0x0e, 0xaa, 0xa3, 0xca, 0x6d, 0x08,
-	0x17, 0x25, 0xab, 0xa5, 0x66, 0xd9, 0xe8, 0xdf, 0x29, 0x0c, 0x8e, 0xd7, 0x13, 0x02, 0x1d, 0xc5,
-	0x0a, 0x74, 0x6f, 0x0b, 0xa9, 0x7b, 0x26, 0x53, 0x20, 0x05, 0x16, 0x73, 0x34, 0xa9, 0x36, 0x79,
-	0x95, 0xba, 0xa3, 0xd4, 0xd1, 0xe9, 0xcb, 0x79, 0x76, 0x9d, 0x66, 0x4e, 0x6f, 0xb6, 0xed, 0xfb,
-	0xca, 0xdf, 0x26, 0xaf, 0x7c, 0x9e, 0x24, 0x70, 0x69, 0xf0, 0x79, 0x29, 0x0c, 0x66, 0x69, 0x89,
-	0x68, 0x52, 0xae, 0x97, 0xca, 0x46, 0x67, 0xc3, 0x20, 0x3e, 0xa7, 0xef, 0x5a, 0x69, 0x86, 0x68,
-	0x26, 0x1b, 0x81, 0x7c, 0x05, 0x52, 0xb0, 0xb5, 0x28, 0x96, 0xc5, 0x3e, 0xde, 0x71, 0x78, 0xbf,
-	0x51, 0x76, 0xf4, 0x08, 0x5e, 0xcf, 0xa5, 0xe6, 0x4f, 0xa9, 0xd5, 0xa9, 0x14, 0x2b, 0x8c, 0xce,
-	0x87, 0x41, 0xdc, 0xa1, 0x3d, 0x97, 0xfc, 0xa3, 0xa7, 0x62, 0x85, 0x24, 0x86, 0x7e, 0xbb, 0x8f,
-	0x92, 0x75, 0x6a, 0x90, 0x65, 0x51, 0x77, 0x18, 0xc4, 0xaf, 0xe8, 0x9b, 0x66, 0x5a, 0x25, 0x6b,
-	0x8a, 0x2c, 0x1b, 0x3d, 0xc3, 0xe0, 0xf8, 0x5e, 0x64, 0x0a, 0xfd, 0x4a, 0xe4, 0x8a, 0xd9, 0xa5,
-	0xc1, 0xf6, 0x22, 0xde, 0xa1, 0x4f, 0x5b, 0x87, 0x5a, 0xdd, 0x17, 0xfe, 0x50, 0x2b, 0x94, 0xba,
-	0xc4, 0xbb, 0x13, 0xfa, 0xb6, 0x7a, 0x29, 0xed, 0x7b, 0xf3, 0x37, 0x00, 0xb2, 0xe7, 0x8a, 0x11,
-	0x16, 0x8d, 0x60, 0x24, 0x82, 0x0b, 0xbe, 0x60, 0x4a, 0xa1, 0x6c, 0xac, 0x69, 0x43, 0x72, 0x09,
-	0xe7, 0x76, 0x9d, 0x8a, 0xcc, 0x19, 0x12, 0xd2, 0x8e, 0x5d, 0xff, 0xcc, 0xc8, 0x35, 0xc0, 0xee,
-	0x0b, 0x72, 0xb7, 0x0d, 0xe9, 0x5e, 0x86, 0x7c, 0x84, 0x70, 0x63, 0x6d, 0x55, 0x32, 0x8e, 0xee,
-	0x96, 0x21, 0xdd, 0x25, 0x6e, 0xef, 0xe1, 0xb3, 0x36, 0x79, 0xb2, 0xa8, 0x4b, 0x34, 0x12, 0xb3,
-	0x1c, 0x4d, 0xf2, 0xc8, 0xe6, 0x46, 0x70, 0xff, 0x1f, 0x54, 0xcd, 0x86, 0x0f, 0x5f, 0x72, 0x61,
-	0x17, 0xcb, 0xf9, 0x26, 0x1c, 0xef, 0xc1, 0x63, 0x0f, 0x8f, 0x3d, 0x3c, 0xf6, 0xf0, 0xbc, 0xeb,
-	0xc2, 0xef, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xf0, 0x3b, 0x7c, 0x15, 0x7d, 0x03, 0x00, 0x00,
+	proto.RegisterType((*ErasureCodingConfig)(nil), "common.ErasureCodingConfig")
+}
+
+func init() { proto.RegisterFile("common/collection.proto", fileDescriptor_collection_beedc5e862fdd829) }
+
+var fileDescriptor_collection_beedc5e862fdd829 = []byte{
+	// 547 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xd1, 0x4e, 0xdb, 0x30,
+	0x14, 0x86, 0x29, 0x94, 0xb2, 0x9e, 0xae, 0xac, 0x33, 0x1a, 0x44, 0xdb, 0x04, 0x55, 0xb7, 0x8b,
+	0x48, 0x9b, 0xd2, 0x89, 0xbd, 0x01, 0x15, 0x12, 0xd3, 0x90, 0x86, 0xcc, 0xae, 0xd8, 0x85, 0xe5,
+	0x3a, 0x87, 0xd4, 0x22, 0xb1, 0x83, 0xed, 0x22, 0x72, 0xb9, 0xd7, 0xdd, 0x53, 0x4c, 0xb5, 0x13,
+	0x1a, 0x50, 0xef, 0xea, 0xff, 0xff, 0xce, 0xa9, 0xcf, 0xf9, 0x1d, 0x38, 0x12, 0xba, 0x28, 0xb4,
+	0x9a, 0x0a, 0x9d, 0xe7, 0x28, 0x9c, 0xd4, 0x2a, 0x29, 0x8d, 0x76, 0x9a, 0xf4, 0x82, 0xf1, 0xfe,
+	0x5d, 0x0d, 0x94, 0x3a, 0x97, 0x42, 0xa2, 0x0d, 0xf6, 0xe4, 0x27, 0x1c, 0xcd, 0x9e, 0x4a, 0x66,
+	0x5a, 0xdd, 0xca, 0xec, 0x8a, 0x8b, 0x3b, 0x9e, 0x21, 0xf9, 0x06, 0x3d, 0xe1, 0x85, 0xa8, 0x33,
+	0xde, 0x89, 0x07, 0xa7, 0x51, 0x12, 0x5a, 0x24, 0x2f, 0x0b, 0x68, 0xcd, 0x4d, 0x2a, 0x18, 0xbd,
+	0xf4, 0xc8, 0x0d, 0x44, 0xd6, 0x71, 0x27, 0x05, 0x5b, 0x5f, 0x8d, 0x3d, 0xf5, 0xed, 0xc4, 0x83,
+	0xd3, 0xe3, 0xa6, 0xef, 0xb5, 0xe7, 0x5e, 0x76, 0xb8, 0xd8, 0xa2, 0x87, 0x76, 0xa3, 0x73, 0xd6,
+	0x87, 0xbd, 0x92, 0x57, 0xb9, 0xe6, 0xe9, 0xe4, 0xdf, 0x36, 0x1c, 0x6e, 0xae, 0x27, 0x04, 0xba,
+	0x8a, 0x17, 0xe8, 0xff, 0xad, 0x4f, 0xfd, 0x6f, 0x72, 0x09, 0xa4, 0xc0, 0x62, 0x8e, 0x86, 0x69,
+	0x93, 0x59, 0xe6, 0x97, 0x52, 0x45, 0xdb, 0xcf, 0xef, 0xb3, 0xee, 0x74, 0xe5, 0xfd, 0x7a, 0xda,
+	0x51, 0xa8, 0xfc, 0x65, 0x32, 0x1b, 0x74, 0x92, 0xc0, 0x81, 0xc1, 0xfb, 0xa5, 0x34, 0x98, 0xb2,
+	0x12, 0xd1, 0x30, 0xa1, 0x97, 0xca, 0x45, 0x3b, 0xe3, 0x4e, 0xbc, 0x4b, 0xdf, 0x36, 0xd6, 0x15,
+	0xa2, 0x99, 0xad, 0x0c, 0xf2, 0x15, 0x48, 0xc1, 0x1f, 0x65, 0xb1, 0x2c, 0xda, 0x78, 0xd7, 0xe3,
+	0xa3, 0xda, 0x59, 0xd3, 0x13, 0x18, 0xce, 0x73, 0x2d, 0xee, 0x98, 0xd3, 0x2c, 0x97, 0x0f, 0x18,
+	0xed, 0x8e, 0x3b, 0x71, 0x97, 0x0e, 0xbc, 0xf8, 0x5b, 0x5f, 0xca, 0x07, 0x24, 0x31, 0x8c, 0x9a,
+	0x79, 0x54, 0x5e, 0x31, 0x83, 0x3c, 0x8d, 0x7a, 0xe3, 0x4e, 0xfc, 0x8a, 0xee, 0xd7, 0xb7, 0x55,
+	0x79, 0x45, 0x91, 0xa7, 0xe4, 0x0c, 0xf6, 0xd1, 0x70, 0xbb, 0x34, 0xc8, 0x84, 0x4e, 0xa5, 0xca,
+	0xa2, 0x3d, 0x3f, 0xf5, 0x87, 0x66, 0xea, 0xf3, 0xe0, 0xce, 0xbc, 0x59, 0x8f, 0x3c, 0xc4, 0xb6,
+	0x38, 0xb9, 0x87, 0xc3, 0xcd, 0xbb, 0x21, 0x97, 0x30, 0xb2, 0x32, 0x53, 0xdc, 0xad, 0xfa, 0xd7,
+	0x5b, 0x0d, 0x29, 0x9f, 0x3c, 0xa5, 0xdc, 0xf8, 0xa1, 0xf0, 0x5c, 0x3d, 0x60, 0xae, 0x4b, 0xbc,
+	0xd8, 0xa2, 0x6f, 0xec, 0x73, 0xab, 0x9d, 0xef, 0xdf, 0x0e, 0x90, 0x56, 0xb2, 0x46, 0x3a, 0x34,
+	0x92, 0x93, 0x08, 0xf6, 0xc4, 0x82, 0x2b, 0x85, 0x79, 0x1d, 0x6f, 0x73, 0x24, 0x07, 0xb0, 0xeb,
+	0x1e, 0x99, 0x4c, 0x7d, 0xa8, 0x7d, 0xda, 0x75, 0x8f, 0x3f, 0x52, 0x72, 0x0c, 0xb0, 0x7e, 0x85,
+	0x3e, 0x9f, 0x3e, 0x6d, 0x29, 0xe4, 0x23, 0xf4, 0x57, 0xcf, 0xc3, 0x96, 0x5c, 0xa0, 0xcf, 0xa3,
+	0x4f, 0xd7, 0xc2, 0xe4, 0x0f, 0x1c, 0x6c, 0x58, 0x0e, 0x39, 0x81, 0x41, 0xca, 0x1d, 0x67, 0x76,
+	0xc1, 0x4d, 0x6a, 0xfd, 0x3d, 0x86, 0x14, 0x56, 0xd2, 0xb5, 0x57, 0xc8, 0x27, 0x18, 0x96, 0xdc,
+	0x48, 0x57, 0x35, 0xc8, 0xb6, 0x47, 0x5e, 0x07, 0x31, 0x40, 0x67, 0xd7, 0xf0, 0x59, 0x9b, 0x2c,
+	0x59, 0x54, 0x25, 0x9a, 0x1c, 0xd3, 0x0c, 0x4d, 0x72, 0xcb, 0xe7, 0x46, 0x8a, 0xf0, 0xa1, 0xda,
+	0x7a, 0x7d, 0x37, 0x5f, 0x32, 0xe9, 0x16, 0xcb, 0xf9, 0xea, 0x38, 0x6d, 0xc1, 0xd3, 0x00, 0x4f,
+	0x03, 0x3c, 0x0d, 0xf0, 0xbc, 0xe7, 0x8f, 0xdf, 0xff, 0x0f, 0x00, 0x16, 0x47, 0x68, 0x65, 0x1e,
+	0x04, 0x00, 0x00,
 }
@@ -28,6 +28,7 @@ const (
 	Status_BAD_REQUEST              Status = 400
 	Status_FORBIDDEN                Status = 403
 	Status_NOT_FOUND                Status = 404
+	Status_GONE                     Status = 410
 	Status_REQUEST_ENTITY_TOO_LARGE Status = 413
 	Status_INTERNAL_SERVER_ERROR    Status = 500
 	Status_NOT_IMPLEMENTED          Status = 501
@@ -40,6 +41,7 @@ var Status_name = map[int32]string{
 	400: "BAD_REQUEST",
 	403: "FORBIDDEN",
 	404: "NOT_FOUND",
+	410: "GONE",
 	413: "REQUEST_ENTITY_TOO_LARGE",
 	500: "INTERNAL_SERVER_ERROR",
 	501: "NOT_IMPLEMENTED",
@@ -51,6 +53,7 @@ var Status_value = map[string]int32{
 	"BAD_REQUEST":              400,
 	"FORBIDDEN":                403,
 	"NOT_FOUND":                404,
+	"GONE":                     410,
 	"REQUEST_ENTITY_TOO_LARGE": 413,
 	"INTERNAL_SERVER_ERROR":    500,
 	"NOT_IMPLEMENTED":          501,
@@ -61,7 +64,7 @@ func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{0}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{0}
 }
 
 type HeaderType int32
@@ -105,7 +108,7 @@ func (x HeaderType) String() string {
 	return proto.EnumName(HeaderType_name, int32(x))
 }
 func (HeaderType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{1}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{1}
 }
 
 // This enum enlists indexes of the block metadata array
@@ -138,7 +141,7 @@ func (x BlockMetadataIndex) String() string {
 	return proto.EnumName(BlockMetadataIndex_name, int32(x))
 }
 func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{2}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{2}
 }
 
 // LastConfig is the encoded value for the Metadata message which is encoded in the LAST_CONFIGURATION block metadata index
@@ -153,7 +156,7 @@ func (m *LastConfig) Reset()         { *m = LastConfig{} }
 func (m *LastConfig) String() string { return proto.CompactTextString(m) }
 func (*LastConfig) ProtoMessage()    {}
 func (*LastConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{0}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{0}
 }
 func (m *LastConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LastConfig.Unmarshal(m, b)
@@ -193,7 +196,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{1}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -239,7 +242,7 @@ func (m *MetadataSignature) Reset()         { *m = MetadataSignature{} }
 func (m *MetadataSignature) String() string { return proto.CompactTextString(m) }
 func (*MetadataSignature) ProtoMessage()    {}
 func (*MetadataSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{2}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{2}
 }
 func (m *MetadataSignature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetadataSignature.Unmarshal(m, b)
@@ -285,7 +288,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{3}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{3}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -358,7 +361,7 @@ func (m *ChannelHeader) Reset()         { *m = ChannelHeader{} }
 func (m *ChannelHeader) String() string { return proto.CompactTextString(m) }
 func (*ChannelHeader) ProtoMessage()    {}
 func (*ChannelHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{4}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{4}
 }
 func (m *ChannelHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelHeader.Unmarshal(m, b)
@@ -448,7 +451,7 @@ func (m *SignatureHeader) Reset()         { *m = SignatureHeader{} }
 func (m *SignatureHeader) String() string { return proto.CompactTextString(m) }
 func (*SignatureHeader) ProtoMessage()    {}
 func (*SignatureHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{5}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{5}
 }
 func (m *SignatureHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignatureHeader.Unmarshal(m, b)
@@ -497,7 +500,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{6}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{6}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -546,7 +549,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{7}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{7}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -597,7 +600,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{8}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{8}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -654,7 +657,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{9}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{9}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -706,7 +709,7 @@ func (m *BlockData) Reset()         { *m = BlockData{} }
 func (m *BlockData) String() string { return proto.CompactTextString(m) }
 func (*BlockData) ProtoMessage()    {}
 func (*BlockData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{10}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{10}
 }
 func (m *BlockData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockData.Unmarshal(m, b)
@@ -744,7 +747,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{11}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{11}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -784,7 +787,7 @@ func (m *OrdererBlockMetadata) Reset()         { *m = OrdererBlockMetadata{} }
 func (m *OrdererBlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*OrdererBlockMetadata) ProtoMessage()    {}
 func (*OrdererBlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_72f685cee4d0b877, []int{12}
+	return fileDescriptor_common_9ee4bede2cd847a3, []int{12}
 }
 func (m *OrdererBlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OrdererBlockMetadata.Unmarshal(m, b)
@@ -837,72 +840,73 @@ func init() {
 	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
 }
 
-func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_72f685cee4d0b877) }
+func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_9ee4bede2cd847a3) }
 
-var fileDescriptor_common_72f685cee4d0b877 = []byte{
-	// 1023 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_common_9ee4bede2cd847a3 = []byte{
+	// 1029 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x14, 0xde, 0xc4, 0xf9, 0xf9, 0xb2, 0x69, 0xdd, 0x49, 0x97, 0x35, 0x85, 0xd5, 0x56, 0x81, 0x45,
-	0xa5, 0x15, 0xa9, 0xe8, 0x5e, 0xe0, 0xe8, 0xd8, 0xd3, 0xd6, 0x6a, 0x62, 0x87, 0xb1, 0xb3, 0x88,
-	0x05, 0x69, 0xe4, 0x26, 0xd3, 0x24, 0xc2, 0xb1, 0x23, 0x7b, 0x52, 0xb5, 0x5c, 0xb9, 0x23, 0x24,
-	0xb8, 0xf2, 0xbf, 0x70, 0x44, 0xfc, 0x3d, 0x20, 0xae, 0x68, 0x3c, 0xb6, 0x9b, 0x94, 0x95, 0x38,
-	0xc5, 0xdf, 0x9b, 0x6f, 0xde, 0xfb, 0xe6, 0x7d, 0x2f, 0x33, 0xd0, 0x99, 0x44, 0xcb, 0x65, 0x14,
-	0x9e, 0xca, 0x9f, 0xde, 0x2a, 0x8e, 0x78, 0x84, 0x6a, 0x12, 0x1d, 0xbc, 0x9c, 0x45, 0xd1, 0x2c,
-	0x60, 0xa7, 0x69, 0xf4, 0x7a, 0x7d, 0x73, 0xca, 0x17, 0x4b, 0x96, 0x70, 0x7f, 0xb9, 0x92, 0xc4,
-	0x6e, 0x17, 0x60, 0xe0, 0x27, 0xdc, 0x88, 0xc2, 0x9b, 0xc5, 0x0c, 0xed, 0x43, 0x75, 0x11, 0x4e,
-	0xd9, 0x9d, 0x56, 0x3a, 0x2c, 0x1d, 0x55, 0x88, 0x04, 0xdd, 0x6f, 0xa1, 0x31, 0x64, 0xdc, 0x9f,
-	0xfa, 0xdc, 0x17, 0x8c, 0x5b, 0x3f, 0x58, 0xb3, 0x94, 0xf1, 0x94, 0x48, 0x80, 0xbe, 0x04, 0x48,
-	0x16, 0xb3, 0xd0, 0xe7, 0xeb, 0x98, 0x25, 0x5a, 0xf9, 0x50, 0x39, 0x6a, 0x9d, 0xbd, 0xdf, 0xcb,
-	0x14, 0xe5, 0x7b, 0xdd, 0x9c, 0x41, 0x36, 0xc8, 0xdd, 0xef, 0x60, 0xef, 0x3f, 0x04, 0xf4, 0x29,
-	0xa8, 0x05, 0x85, 0xce, 0x99, 0x3f, 0x65, 0x71, 0x56, 0x70, 0xb7, 0x88, 0x5f, 0xa6, 0x61, 0xf4,
-	0x21, 0x34, 0x8b, 0x90, 0x56, 0x4e, 0x39, 0x0f, 0x81, 0xee, 0x5b, 0xa8, 0x65, 0xbc, 0x57, 0xb0,
-	0x33, 0x99, 0xfb, 0x61, 0xc8, 0x82, 0xed, 0x84, 0xed, 0x2c, 0x9a, 0xd1, 0xde, 0x55, 0xb9, 0xfc,
-	0xce, 0xca, 0xdd, 0x1f, 0xcb, 0xd0, 0x36, 0xb6, 0x36, 0x23, 0xa8, 0xf0, 0xfb, 0x95, 0xec, 0x4d,
-	0x95, 0xa4, 0xdf, 0x48, 0x83, 0xfa, 0x2d, 0x8b, 0x93, 0x45, 0x14, 0xa6, 0x79, 0xaa, 0x24, 0x87,
-	0xe8, 0x0b, 0x68, 0x16, 0x6e, 0x68, 0xca, 0x61, 0xe9, 0xa8, 0x75, 0x76, 0xd0, 0x93, 0x7e, 0xf5,
-	0x72, 0xbf, 0x7a, 0x5e, 0xce, 0x20, 0x0f, 0x64, 0xf4, 0x02, 0x20, 0x3f, 0xcb, 0x62, 0xaa, 0x55,
-	0x0e, 0x4b, 0x47, 0x4d, 0xd2, 0xcc, 0x22, 0xd6, 0x14, 0x75, 0xa0, 0xca, 0xef, 0xc4, 0x4a, 0x35,
-	0x5d, 0xa9, 0xf0, 0x3b, 0x6b, 0x2a, 0x8c, 0x63, 0xab, 0x68, 0x32, 0xd7, 0x6a, 0xd2, 0xda, 0x14,
-	0x88, 0xee, 0xb1, 0x3b, 0xce, 0xc2, 0x54, 0x5f, 0x5d, 0x76, 0xaf, 0x08, 0xa0, 0x2e, 0xb4, 0x79,
-	0x90, 0xd0, 0x09, 0x8b, 0x39, 0x9d, 0xfb, 0xc9, 0x5c, 0x6b, 0xa4, 0x8c, 0x16, 0x0f, 0x12, 0x83,
-	0xc5, 0xfc, 0xd2, 0x4f, 0xe6, 0x5d, 0x1d, 0x76, 0xdd, 0x47, 0x96, 0x68, 0x50, 0x9f, 0xc4, 0xcc,
-	0xe7, 0x51, 0xde, 0xe3, 0x1c, 0x0a, 0x11, 0x61, 0x14, 0x4e, 0x72, 0xa3, 0x24, 0xe8, 0x62, 0xa8,
-	0x8f, 0xfc, 0xfb, 0x20, 0xf2, 0xa7, 0xe8, 0x13, 0xa8, 0x6d, 0xb8, 0xd3, 0x3a, 0xdb, 0xc9, 0x87,
-	0x48, 0xa6, 0x26, 0xd9, 0xaa, 0xe8, 0xb4, 0x98, 0x98, 0x2c, 0x4f, 0xfa, 0xdd, 0xed, 0x43, 0x03,
-	0x87, 0xb7, 0x2c, 0x88, 0x64, 0xd7, 0x57, 0x32, 0x65, 0x2e, 0x21, 0x83, 0xff, 0x33, 0x2f, 0x3f,
-	0x95, 0xa0, 0xda, 0x0f, 0xa2, 0xc9, 0xf7, 0xe8, 0xe4, 0x91, 0x92, 0x4e, 0xae, 0x24, 0x5d, 0x7e,
-	0x24, 0xe7, 0xd5, 0x86, 0x9c, 0xd6, 0xd9, 0xde, 0x16, 0xd5, 0xf4, 0xb9, 0x2f, 0x15, 0xa2, 0xcf,
-	0xa1, 0xb1, 0xcc, 0x66, 0x3d, 0x33, 0xfc, 0xd9, 0x16, 0x35, 0xff, 0x23, 0x90, 0x82, 0xd6, 0x9d,
-	0x41, 0x6b, 0xa3, 0x20, 0x7a, 0x0f, 0x6a, 0xe1, 0x7a, 0x79, 0x9d, 0xa9, 0xaa, 0x90, 0x0c, 0xa1,
-	0x8f, 0xa0, 0xbd, 0x8a, 0xd9, 0xed, 0x22, 0x5a, 0x27, 0xd2, 0x29, 0x79, 0xb2, 0xa7, 0x79, 0x50,
-	0x58, 0x85, 0x3e, 0x80, 0xa6, 0xc8, 0x29, 0x09, 0x4a, 0x4a, 0x68, 0x88, 0x40, 0xea, 0xe3, 0x4b,
-	0x68, 0x16, 0x72, 0x8b, 0xf6, 0x96, 0x0e, 0x95, 0xa2, 0xbd, 0x27, 0xd0, 0xde, 0x12, 0x89, 0x0e,
-	0x36, 0x4e, 0x23, 0x89, 0x0f, 0xb2, 0x7f, 0x80, 0x7d, 0x27, 0x9e, 0xb2, 0x98, 0xc5, 0xdb, 0x7b,
-	0x5e, 0x43, 0x2b, 0xf0, 0x13, 0x4e, 0x27, 0xe9, 0x7d, 0x93, 0xb5, 0x16, 0xe5, 0x4d, 0x78, 0xb8,
-	0x89, 0x08, 0x04, 0x0f, 0xb7, 0xd2, 0x67, 0x80, 0x26, 0x51, 0x98, 0xb0, 0x90, 0xb3, 0x98, 0x16,
-	0x25, 0xe5, 0x09, 0xf7, 0x8a, 0x95, 0xbc, 0xc6, 0xf1, 0xef, 0x25, 0xa8, 0xb9, 0xdc, 0xe7, 0xeb,
-	0x04, 0xb5, 0xa0, 0x3e, 0xb6, 0xaf, 0x6c, 0xe7, 0x6b, 0x5b, 0x7d, 0x82, 0x9e, 0x42, 0xdd, 0x1d,
-	0x1b, 0x06, 0x76, 0x5d, 0xf5, 0x8f, 0x12, 0x52, 0xa1, 0xd5, 0xd7, 0x4d, 0x4a, 0xf0, 0x57, 0x63,
-	0xec, 0x7a, 0xea, 0xcf, 0x0a, 0xda, 0x81, 0xe6, 0xb9, 0x43, 0xfa, 0x96, 0x69, 0x62, 0x5b, 0xfd,
-	0x25, 0xc5, 0xb6, 0xe3, 0xd1, 0x73, 0x67, 0x6c, 0x9b, 0xea, 0xaf, 0x0a, 0x7a, 0x01, 0x5a, 0xc6,
-	0xa6, 0xd8, 0xf6, 0x2c, 0xef, 0x1b, 0xea, 0x39, 0x0e, 0x1d, 0xe8, 0xe4, 0x02, 0xab, 0xbf, 0x29,
-	0xe8, 0x00, 0x9e, 0x59, 0xb6, 0x87, 0x89, 0xad, 0x0f, 0xa8, 0x8b, 0xc9, 0x1b, 0x4c, 0x28, 0x26,
-	0xc4, 0x21, 0xea, 0x5f, 0x0a, 0xda, 0x87, 0x5d, 0x91, 0xca, 0x1a, 0x8e, 0x06, 0x78, 0x88, 0x6d,
-	0x0f, 0x9b, 0xea, 0xdf, 0x0a, 0xd2, 0xa0, 0x23, 0x88, 0x96, 0x81, 0xe9, 0xd8, 0xd6, 0xdf, 0xe8,
-	0xd6, 0x40, 0xef, 0x0f, 0xb0, 0xfa, 0x8f, 0x72, 0xfc, 0x67, 0x09, 0x40, 0x3a, 0xee, 0x89, 0x3b,
-	0xa4, 0x05, 0xf5, 0x21, 0x76, 0x5d, 0xfd, 0x02, 0xab, 0x4f, 0x10, 0x40, 0xcd, 0x70, 0xec, 0x73,
-	0xeb, 0x42, 0x2d, 0xa1, 0x3d, 0x68, 0xcb, 0x6f, 0x3a, 0x1e, 0x99, 0xba, 0x87, 0xd5, 0x32, 0xd2,
-	0x60, 0x1f, 0xdb, 0xa6, 0x43, 0x5c, 0x4c, 0xa8, 0x47, 0x74, 0xdb, 0xd5, 0x0d, 0xcf, 0x72, 0x6c,
-	0x55, 0x41, 0xcf, 0xa1, 0xe3, 0x10, 0x13, 0x93, 0x47, 0x0b, 0x15, 0xf4, 0x0c, 0xf6, 0x4c, 0x3c,
-	0xb0, 0x84, 0x62, 0x17, 0xe3, 0x2b, 0x6a, 0xd9, 0xe7, 0x8e, 0x5a, 0x15, 0x61, 0xe3, 0x52, 0xb7,
-	0x6c, 0xc3, 0x31, 0x31, 0x1d, 0xe9, 0xc6, 0x95, 0xa8, 0x5f, 0x13, 0x05, 0x46, 0x18, 0x13, 0xaa,
-	0x9b, 0x43, 0xcb, 0xa6, 0xce, 0x08, 0x13, 0x3d, 0xcd, 0xd3, 0x10, 0x1b, 0x3c, 0xe7, 0x0a, 0xdb,
-	0x5b, 0xe9, 0x9b, 0xc7, 0x01, 0xa0, 0xad, 0x21, 0xb0, 0xc4, 0xa3, 0x82, 0x76, 0x00, 0x5c, 0xeb,
-	0xc2, 0xd6, 0xbd, 0x31, 0xc1, 0xae, 0xfa, 0x04, 0xed, 0x42, 0x6b, 0xa0, 0xbb, 0x1e, 0x2d, 0xce,
-	0xf6, 0x1c, 0x3a, 0x1b, 0x79, 0x5c, 0x7a, 0x6e, 0x0d, 0x3c, 0x4c, 0xd4, 0xb2, 0xe8, 0x46, 0x76,
-	0x0e, 0x55, 0x11, 0xdb, 0x0c, 0x67, 0x38, 0xb4, 0x3c, 0x7a, 0xa9, 0xbb, 0x97, 0x6a, 0xa5, 0xef,
-	0xc2, 0xc7, 0x51, 0x3c, 0xeb, 0xcd, 0xef, 0x57, 0x2c, 0x0e, 0xd8, 0x74, 0xc6, 0xe2, 0xde, 0x8d,
-	0x7f, 0x1d, 0x2f, 0x26, 0xf2, 0x4e, 0x4d, 0xb2, 0x59, 0x7b, 0x7b, 0x32, 0x5b, 0xf0, 0xf9, 0xfa,
-	0x5a, 0xc0, 0xd3, 0x0d, 0xf2, 0xa9, 0x24, 0xcb, 0x07, 0x33, 0xc9, 0x1e, 0xd5, 0xeb, 0x5a, 0x0a,
-	0x5f, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0xad, 0x85, 0xf1, 0xab, 0x6c, 0x07, 0x00, 0x00,
+	0x14, 0x6e, 0xe2, 0xfc, 0x7c, 0x69, 0x5a, 0x77, 0xd2, 0xb2, 0xa6, 0xb0, 0xda, 0xca, 0xb0, 0xa8,
+	0xb4, 0x22, 0x15, 0xdd, 0x0b, 0x1c, 0x1d, 0x7b, 0xda, 0x5a, 0x4d, 0xec, 0x30, 0x76, 0x16, 0xb1,
+	0x20, 0x8d, 0xdc, 0x64, 0x9a, 0x44, 0x38, 0x76, 0x64, 0x4f, 0xaa, 0x96, 0x2b, 0x77, 0x84, 0x04,
+	0x27, 0x24, 0xfe, 0x1f, 0x04, 0xff, 0x0e, 0x88, 0x2b, 0xb2, 0xc7, 0x76, 0x93, 0xb2, 0xd2, 0x9e,
+	0x32, 0xdf, 0x9b, 0x6f, 0xde, 0xfb, 0xe6, 0x7d, 0x2f, 0x63, 0xe8, 0x8c, 0xc3, 0xc5, 0x22, 0x0c,
+	0xce, 0xc4, 0x4f, 0x77, 0x19, 0x85, 0x3c, 0x44, 0x35, 0x81, 0x0e, 0x5f, 0x4c, 0xc3, 0x70, 0xea,
+	0xb3, 0xb3, 0x34, 0x7a, 0xb3, 0xba, 0x3d, 0xe3, 0xf3, 0x05, 0x8b, 0xb9, 0xb7, 0x58, 0x0a, 0xa2,
+	0xaa, 0x02, 0xf4, 0xbd, 0x98, 0xeb, 0x61, 0x70, 0x3b, 0x9f, 0xa2, 0x7d, 0xa8, 0xce, 0x83, 0x09,
+	0xbb, 0x57, 0x4a, 0x47, 0xa5, 0xe3, 0x0a, 0x11, 0x40, 0xfd, 0x16, 0x1a, 0x03, 0xc6, 0xbd, 0x89,
+	0xc7, 0xbd, 0x84, 0x71, 0xe7, 0xf9, 0x2b, 0x96, 0x32, 0xb6, 0x89, 0x00, 0xe8, 0x4b, 0x80, 0x78,
+	0x3e, 0x0d, 0x3c, 0xbe, 0x8a, 0x58, 0xac, 0x94, 0x8f, 0xa4, 0xe3, 0xd6, 0xf9, 0xfb, 0xdd, 0x4c,
+	0x51, 0x7e, 0xd6, 0xc9, 0x19, 0x64, 0x8d, 0xac, 0x7e, 0x07, 0x7b, 0xff, 0x23, 0xa0, 0x4f, 0x41,
+	0x2e, 0x28, 0x74, 0xc6, 0xbc, 0x09, 0x8b, 0xb2, 0x82, 0xbb, 0x45, 0xfc, 0x2a, 0x0d, 0xa3, 0x0f,
+	0xa1, 0x59, 0x84, 0x94, 0x72, 0xca, 0x79, 0x0c, 0xa8, 0x6f, 0xa0, 0x96, 0xf1, 0x5e, 0xc2, 0xce,
+	0x78, 0xe6, 0x05, 0x01, 0xf3, 0x37, 0x13, 0xb6, 0xb3, 0x68, 0x46, 0x7b, 0x5b, 0xe5, 0xf2, 0x5b,
+	0x2b, 0xab, 0x3f, 0x96, 0xa1, 0xad, 0x6f, 0x1c, 0x46, 0x50, 0xe1, 0x0f, 0x4b, 0xd1, 0x9b, 0x2a,
+	0x49, 0xd7, 0x48, 0x81, 0xfa, 0x1d, 0x8b, 0xe2, 0x79, 0x18, 0xa4, 0x79, 0xaa, 0x24, 0x87, 0xe8,
+	0x0b, 0x68, 0x16, 0x6e, 0x28, 0xd2, 0x51, 0xe9, 0xb8, 0x75, 0x7e, 0xd8, 0x15, 0x7e, 0x75, 0x73,
+	0xbf, 0xba, 0x6e, 0xce, 0x20, 0x8f, 0x64, 0xf4, 0x1c, 0x20, 0xbf, 0xcb, 0x7c, 0xa2, 0x54, 0x8e,
+	0x4a, 0xc7, 0x4d, 0xd2, 0xcc, 0x22, 0xe6, 0x04, 0x75, 0xa0, 0xca, 0xef, 0x93, 0x9d, 0x6a, 0xba,
+	0x53, 0xe1, 0xf7, 0xe6, 0x24, 0x31, 0x8e, 0x2d, 0xc3, 0xf1, 0x4c, 0xa9, 0x09, 0x6b, 0x53, 0x90,
+	0x74, 0x8f, 0xdd, 0x73, 0x16, 0xa4, 0xfa, 0xea, 0xa2, 0x7b, 0x45, 0x00, 0xa9, 0xd0, 0xe6, 0x7e,
+	0x4c, 0xc7, 0x2c, 0xe2, 0x74, 0xe6, 0xc5, 0x33, 0xa5, 0x91, 0x32, 0x5a, 0xdc, 0x8f, 0x75, 0x16,
+	0xf1, 0x2b, 0x2f, 0x9e, 0xa9, 0x1a, 0xec, 0x3a, 0x4f, 0x2c, 0x51, 0xa0, 0x3e, 0x8e, 0x98, 0xc7,
+	0xc3, 0xbc, 0xc7, 0x39, 0x4c, 0x44, 0x04, 0x61, 0x30, 0xce, 0x8d, 0x12, 0x40, 0xc5, 0x50, 0x1f,
+	0x7a, 0x0f, 0x7e, 0xe8, 0x4d, 0xd0, 0x27, 0x50, 0x5b, 0x73, 0xa7, 0x75, 0xbe, 0x93, 0x0f, 0x91,
+	0x48, 0x4d, 0x6a, 0xb3, 0xa2, 0xd3, 0xc9, 0xc4, 0x64, 0x79, 0xd2, 0xb5, 0xda, 0x83, 0x06, 0x0e,
+	0xee, 0x98, 0x1f, 0x8a, 0xae, 0x2f, 0x45, 0xca, 0x5c, 0x42, 0x06, 0xdf, 0x31, 0x2f, 0x3f, 0x95,
+	0xa0, 0xda, 0xf3, 0xc3, 0xf1, 0xf7, 0xe8, 0xf4, 0x89, 0x92, 0x4e, 0xae, 0x24, 0xdd, 0x7e, 0x22,
+	0xe7, 0xe5, 0x9a, 0x9c, 0xd6, 0xf9, 0xde, 0x06, 0xd5, 0xf0, 0xb8, 0x27, 0x14, 0xa2, 0xcf, 0xa1,
+	0xb1, 0xc8, 0x66, 0x3d, 0x33, 0xfc, 0x60, 0x83, 0x9a, 0xff, 0x11, 0x48, 0x41, 0x53, 0xa7, 0xd0,
+	0x5a, 0x2b, 0x88, 0xde, 0x83, 0x5a, 0xb0, 0x5a, 0xdc, 0x64, 0xaa, 0x2a, 0x24, 0x43, 0xe8, 0x23,
+	0x68, 0x2f, 0x23, 0x76, 0x37, 0x0f, 0x57, 0xb1, 0x70, 0x4a, 0xdc, 0x6c, 0x3b, 0x0f, 0x26, 0x56,
+	0xa1, 0x0f, 0xa0, 0x99, 0xe4, 0x14, 0x04, 0x29, 0x25, 0x34, 0x92, 0x40, 0xea, 0xe3, 0x0b, 0x68,
+	0x16, 0x72, 0x8b, 0xf6, 0x96, 0x8e, 0xa4, 0xa2, 0xbd, 0xa7, 0xd0, 0xde, 0x10, 0x89, 0x0e, 0xd7,
+	0x6e, 0x23, 0x88, 0x8f, 0xb2, 0x7f, 0x80, 0x7d, 0x3b, 0x9a, 0xb0, 0x88, 0x45, 0x9b, 0x67, 0x5e,
+	0x41, 0xcb, 0xf7, 0x62, 0x4e, 0xc7, 0xe9, 0x7b, 0x93, 0xb5, 0x16, 0xe5, 0x4d, 0x78, 0x7c, 0x89,
+	0x08, 0xf8, 0xc5, 0x1a, 0x7d, 0x06, 0x68, 0x1c, 0x06, 0x31, 0x0b, 0x38, 0x8b, 0x68, 0x51, 0x52,
+	0xdc, 0x70, 0xaf, 0xd8, 0xc9, 0x6b, 0x9c, 0xfc, 0x55, 0x82, 0x9a, 0xc3, 0x3d, 0xbe, 0x8a, 0x51,
+	0x0b, 0xea, 0x23, 0xeb, 0xda, 0xb2, 0xbf, 0xb6, 0xe4, 0x2d, 0xb4, 0x0d, 0x75, 0x67, 0xa4, 0xeb,
+	0xd8, 0x71, 0xe4, 0x3f, 0x4a, 0x48, 0x86, 0x56, 0x4f, 0x33, 0x28, 0xc1, 0x5f, 0x8d, 0xb0, 0xe3,
+	0xca, 0x3f, 0x4b, 0x68, 0x07, 0x9a, 0x17, 0x36, 0xe9, 0x99, 0x86, 0x81, 0x2d, 0xf9, 0x97, 0x14,
+	0x5b, 0xb6, 0x4b, 0x2f, 0xec, 0x91, 0x65, 0xc8, 0xbf, 0x4a, 0xa8, 0x09, 0x95, 0x4b, 0xdb, 0xc2,
+	0xf2, 0x6f, 0x12, 0x7a, 0x0e, 0x4a, 0x76, 0x90, 0x62, 0xcb, 0x35, 0xdd, 0x6f, 0xa8, 0x6b, 0xdb,
+	0xb4, 0xaf, 0x91, 0x4b, 0x2c, 0xff, 0x2e, 0xa1, 0x43, 0x38, 0x30, 0x2d, 0x17, 0x13, 0x4b, 0xeb,
+	0x53, 0x07, 0x93, 0xd7, 0x98, 0x50, 0x4c, 0x88, 0x4d, 0xe4, 0xbf, 0x25, 0xb4, 0x0f, 0xbb, 0x49,
+	0x56, 0x73, 0x30, 0xec, 0xe3, 0x01, 0xb6, 0x5c, 0x6c, 0xc8, 0xff, 0x48, 0x48, 0x81, 0x4e, 0x42,
+	0x34, 0x75, 0x4c, 0x47, 0x96, 0xf6, 0x5a, 0x33, 0xfb, 0x5a, 0xaf, 0x8f, 0xe5, 0x7f, 0xa5, 0x93,
+	0x3f, 0x4b, 0x00, 0xc2, 0x7c, 0x37, 0x79, 0x4e, 0x5a, 0x50, 0x1f, 0x60, 0xc7, 0xd1, 0x2e, 0xb1,
+	0xbc, 0x85, 0x00, 0x6a, 0xba, 0x6d, 0x5d, 0x98, 0x97, 0x72, 0x09, 0xed, 0x41, 0x5b, 0xac, 0xe9,
+	0x68, 0x68, 0x68, 0x2e, 0x96, 0xcb, 0x48, 0x81, 0x7d, 0x6c, 0x19, 0x36, 0x71, 0x30, 0xa1, 0x2e,
+	0xd1, 0x2c, 0x47, 0xd3, 0x5d, 0xd3, 0xb6, 0x64, 0x09, 0x3d, 0x83, 0x8e, 0x4d, 0x0c, 0x4c, 0x9e,
+	0x6c, 0x54, 0xd0, 0x01, 0xec, 0x19, 0xb8, 0x6f, 0x26, 0x8a, 0x1d, 0x8c, 0xaf, 0xa9, 0x69, 0x5d,
+	0xd8, 0x72, 0x35, 0x09, 0xeb, 0x57, 0x9a, 0x69, 0xe9, 0xb6, 0x81, 0xe9, 0x50, 0xd3, 0xaf, 0x93,
+	0xfa, 0xb5, 0xa4, 0xc0, 0x10, 0x63, 0x42, 0x35, 0x63, 0x60, 0x5a, 0xd4, 0x1e, 0x62, 0xa2, 0xa5,
+	0x79, 0x1a, 0xc9, 0x01, 0xd7, 0xbe, 0xc6, 0xd6, 0x46, 0xfa, 0xe6, 0x89, 0x0f, 0x68, 0x63, 0x1e,
+	0xcc, 0xe4, 0xfb, 0x82, 0x76, 0x00, 0x1c, 0xf3, 0xd2, 0xd2, 0xdc, 0x11, 0xc1, 0x8e, 0xbc, 0x85,
+	0x76, 0xa1, 0xd5, 0xd7, 0x1c, 0x97, 0x16, 0x77, 0x7b, 0x06, 0x9d, 0xb5, 0x3c, 0x0e, 0xbd, 0x30,
+	0xfb, 0x2e, 0x26, 0x72, 0x39, 0xe9, 0x46, 0x76, 0x0f, 0x59, 0x4a, 0x8e, 0xe9, 0xf6, 0x60, 0x60,
+	0xba, 0xf4, 0x4a, 0x73, 0xae, 0xe4, 0x4a, 0xcf, 0x81, 0x8f, 0xc3, 0x68, 0xda, 0x9d, 0x3d, 0x2c,
+	0x59, 0xe4, 0xb3, 0xc9, 0x94, 0x45, 0xdd, 0x5b, 0xef, 0x26, 0x9a, 0x8f, 0xc5, 0xf3, 0x1a, 0x67,
+	0x63, 0xf7, 0xe6, 0x74, 0x3a, 0xe7, 0xb3, 0xd5, 0x4d, 0x02, 0xcf, 0xd6, 0xc8, 0x67, 0x82, 0x2c,
+	0xbe, 0x9d, 0x71, 0xf6, 0x7d, 0xbd, 0xa9, 0xa5, 0xf0, 0xd5, 0x7f, 0x03, 0x00, 0xd4, 0x3a, 0xff,
+	0x9c, 0x77, 0x07, 0x00, 0x00,
 }
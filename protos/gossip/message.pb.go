@@ -6,6 +6,7 @@ package gossip // import "github.com/hyperledger/fabric/protos/gossip"
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
 
 import (
@@ -47,7 +48,7 @@ func (x PullMsgType) String() string {
 	return proto.EnumName(PullMsgType_name, int32(x))
 }
 func (PullMsgType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{0}
 }
 
 type GossipMessage_Tag int32
@@ -82,7 +83,7 @@ func (x GossipMessage_Tag) String() string {
 	return proto.EnumName(GossipMessage_Tag_name, int32(x))
 }
 func (GossipMessage_Tag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3, 0}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{3, 0}
 }
 
 // Envelope contains a marshalled
@@ -102,7 +103,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{0}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -160,7 +161,7 @@ func (m *SecretEnvelope) Reset()         { *m = SecretEnvelope{} }
 func (m *SecretEnvelope) String() string { return proto.CompactTextString(m) }
 func (*SecretEnvelope) ProtoMessage()    {}
 func (*SecretEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{1}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{1}
 }
 func (m *SecretEnvelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretEnvelope.Unmarshal(m, b)
@@ -210,7 +211,7 @@ func (m *Secret) Reset()         { *m = Secret{} }
 func (m *Secret) String() string { return proto.CompactTextString(m) }
 func (*Secret) ProtoMessage()    {}
 func (*Secret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{2}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{2}
 }
 func (m *Secret) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Secret.Unmarshal(m, b)
@@ -349,7 +350,7 @@ func (m *GossipMessage) Reset()         { *m = GossipMessage{} }
 func (m *GossipMessage) String() string { return proto.CompactTextString(m) }
 func (*GossipMessage) ProtoMessage()    {}
 func (*GossipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{3}
 }
 func (m *GossipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipMessage.Unmarshal(m, b)
@@ -1128,7 +1129,7 @@ func (m *StateInfo) Reset()         { *m = StateInfo{} }
 func (m *StateInfo) String() string { return proto.CompactTextString(m) }
 func (*StateInfo) ProtoMessage()    {}
 func (*StateInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{4}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{4}
 }
 func (m *StateInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfo.Unmarshal(m, b)
@@ -1189,7 +1190,7 @@ func (m *Properties) Reset()         { *m = Properties{} }
 func (m *Properties) String() string { return proto.CompactTextString(m) }
 func (*Properties) ProtoMessage()    {}
 func (*Properties) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{5}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{5}
 }
 func (m *Properties) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Properties.Unmarshal(m, b)
@@ -1242,7 +1243,7 @@ func (m *StateInfoSnapshot) Reset()         { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()    {}
 func (*StateInfoSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{6}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{6}
 }
 func (m *StateInfoSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoSnapshot.Unmarshal(m, b)
@@ -1285,7 +1286,7 @@ func (m *StateInfoPullRequest) Reset()         { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()    {}
 func (*StateInfoPullRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{7}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{7}
 }
 func (m *StateInfoPullRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoPullRequest.Unmarshal(m, b)
@@ -1328,7 +1329,7 @@ func (m *ConnEstablish) Reset()         { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()    {}
 func (*ConnEstablish) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{8}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{8}
 }
 func (m *ConnEstablish) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnEstablish.Unmarshal(m, b)
@@ -1385,7 +1386,7 @@ func (m *PeerIdentity) Reset()         { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()    {}
 func (*PeerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{9}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{9}
 }
 func (m *PeerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerIdentity.Unmarshal(m, b)
@@ -1441,7 +1442,7 @@ func (m *DataRequest) Reset()         { *m = DataRequest{} }
 func (m *DataRequest) String() string { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()    {}
 func (*DataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{10}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{10}
 }
 func (m *DataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
@@ -1497,7 +1498,7 @@ func (m *GossipHello) Reset()         { *m = GossipHello{} }
 func (m *GossipHello) String() string { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()    {}
 func (*GossipHello) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{11}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{11}
 }
 func (m *GossipHello) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipHello.Unmarshal(m, b)
@@ -1553,7 +1554,7 @@ func (m *DataUpdate) Reset()         { *m = DataUpdate{} }
 func (m *DataUpdate) String() string { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()    {}
 func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{12}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{12}
 }
 func (m *DataUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdate.Unmarshal(m, b)
@@ -1609,7 +1610,7 @@ func (m *DataDigest) Reset()         { *m = DataDigest{} }
 func (m *DataDigest) String() string { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()    {}
 func (*DataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{13}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{13}
 }
 func (m *DataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataDigest.Unmarshal(m, b)
@@ -1662,7 +1663,7 @@ func (m *DataMessage) Reset()         { *m = DataMessage{} }
 func (m *DataMessage) String() string { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()    {}
 func (*DataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{14}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{14}
 }
 func (m *DataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataMessage.Unmarshal(m, b)
@@ -1703,7 +1704,7 @@ func (m *PrivateDataMessage) Reset()         { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()    {}
 func (*PrivateDataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{15}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{15}
 }
 func (m *PrivateDataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivateDataMessage.Unmarshal(m, b)
@@ -1744,7 +1745,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{16}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{16}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -1789,22 +1790,25 @@ func (m *Payload) GetPrivateData() [][]byte {
 // data with collection name to enable routing
 // based on collection partitioning
 type PrivatePayload struct {
-	CollectionName       string                          `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
-	Namespace            string                          `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	TxId                 string                          `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	PrivateRwset         []byte                          `protobuf:"bytes,4,opt,name=private_rwset,json=privateRwset,proto3" json:"private_rwset,omitempty"`
-	PrivateSimHeight     uint64                          `protobuf:"varint,5,opt,name=private_sim_height,json=privateSimHeight,proto3" json:"private_sim_height,omitempty"`
-	CollectionConfigs    *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collection_configs,json=collectionConfigs,proto3" json:"collection_configs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
-	XXX_unrecognized     []byte                          `json:"-"`
-	XXX_sizecache        int32                           `json:"-"`
+	CollectionName    string                          `protobuf:"bytes,1,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	Namespace         string                          `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	TxId              string                          `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	PrivateRwset      []byte                          `protobuf:"bytes,4,opt,name=private_rwset,json=privateRwset,proto3" json:"private_rwset,omitempty"`
+	PrivateSimHeight  uint64                          `protobuf:"varint,5,opt,name=private_sim_height,json=privateSimHeight,proto3" json:"private_sim_height,omitempty"`
+	CollectionConfigs *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collection_configs,json=collectionConfigs,proto3" json:"collection_configs,omitempty"`
+	// shard is set if private_rwset is an erasure-coded
+	// shard of the private rwset, rather than the rwset itself
+	Shard                *ErasureShard `protobuf:"bytes,7,opt,name=shard,proto3" json:"shard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *PrivatePayload) Reset()         { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()    {}
 func (*PrivatePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{17}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{17}
 }
 func (m *PrivatePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivatePayload.Unmarshal(m, b)
@@ -1866,6 +1870,13 @@ func (m *PrivatePayload) GetCollectionConfigs() *common.CollectionConfigPackage
 	return nil
 }
 
+func (m *PrivatePayload) GetShard() *ErasureShard {
+	if m != nil {
+		return m.Shard
+	}
+	return nil
+}
+
 // AliveMessage is sent to inform remote peers
 // of a peer's existence and activity
 type AliveMessage struct {
@@ -1881,7 +1892,7 @@ func (m *AliveMessage) Reset()         { *m = AliveMessage{} }
 func (m *AliveMessage) String() string { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()    {}
 func (*AliveMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{18}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{18}
 }
 func (m *AliveMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AliveMessage.Unmarshal(m, b)
@@ -1925,19 +1936,25 @@ func (m *AliveMessage) GetIdentity() []byte {
 // Leadership Message is sent during leader election to inform
 // remote peers about intent of peer to proclaim itself as leader
 type LeadershipMessage struct {
-	PkiId                []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
-	Timestamp            *PeerTime `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	IsDeclaration        bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration,proto3" json:"is_declaration,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration,proto3" json:"is_declaration,omitempty"`
+	// priority is the leadership priority of the sender,
+	// peers with a higher priority are preferred as leaders
+	Priority uint32 `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// ledger_height is the ledger height of the sender
+	// at the time the message was created
+	LedgerHeight         uint64   `protobuf:"varint,5,opt,name=ledger_height,json=ledgerHeight,proto3" json:"ledger_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LeadershipMessage) Reset()         { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()    {}
 func (*LeadershipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{19}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{19}
 }
 func (m *LeadershipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LeadershipMessage.Unmarshal(m, b)
@@ -1978,6 +1995,20 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *LeadershipMessage) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum               uint64   `protobuf:"varint,1,opt,name=inc_num,json=incNum,proto3" json:"inc_num,omitempty"`
@@ -1991,7 +2022,7 @@ func (m *PeerTime) Reset()         { *m = PeerTime{} }
 func (m *PeerTime) String() string { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()    {}
 func (*PeerTime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{20}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{20}
 }
 func (m *PeerTime) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerTime.Unmarshal(m, b)
@@ -2039,7 +2070,7 @@ func (m *MembershipRequest) Reset()         { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()    {}
 func (*MembershipRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{21}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{21}
 }
 func (m *MembershipRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipRequest.Unmarshal(m, b)
@@ -2086,7 +2117,7 @@ func (m *MembershipResponse) Reset()         { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()    {}
 func (*MembershipResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{22}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{22}
 }
 func (m *MembershipResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipResponse.Unmarshal(m, b)
@@ -2135,7 +2166,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{23}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{23}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Member.Unmarshal(m, b)
@@ -2187,7 +2218,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{24}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{24}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -2221,7 +2252,7 @@ func (m *RemoteStateRequest) Reset()         { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()    {}
 func (*RemoteStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{25}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{25}
 }
 func (m *RemoteStateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateRequest.Unmarshal(m, b)
@@ -2268,7 +2299,7 @@ func (m *RemoteStateResponse) Reset()         { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()    {}
 func (*RemoteStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{26}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{26}
 }
 func (m *RemoteStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateResponse.Unmarshal(m, b)
@@ -2308,7 +2339,7 @@ func (m *RemotePvtDataRequest) Reset()         { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()    {}
 func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{27}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{27}
 }
 func (m *RemotePvtDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataRequest.Unmarshal(m, b)
@@ -2351,7 +2382,7 @@ func (m *PvtDataDigest) Reset()         { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()    {}
 func (*PvtDataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{28}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{28}
 }
 func (m *PvtDataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataDigest.Unmarshal(m, b)
@@ -2419,7 +2450,7 @@ func (m *RemotePvtDataResponse) Reset()         { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()    {}
 func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{29}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{29}
 }
 func (m *RemotePvtDataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataResponse.Unmarshal(m, b)
@@ -2449,17 +2480,20 @@ func (m *RemotePvtDataResponse) GetElements() []*PvtDataElement {
 type PvtDataElement struct {
 	Digest *PvtDataDigest `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
 	// the payload is a marshaled kvrwset.KVRWSet
-	Payload              [][]byte `protobuf:"bytes,2,rep,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Payload [][]byte `protobuf:"bytes,2,rep,name=payload,proto3" json:"payload,omitempty"`
+	// shard is set if the payload is an erasure-coded
+	// shard of the private rwset, rather than the rwset itself
+	Shard                *ErasureShard `protobuf:"bytes,3,opt,name=shard,proto3" json:"shard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *PvtDataElement) Reset()         { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()    {}
 func (*PvtDataElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{30}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{30}
 }
 func (m *PvtDataElement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataElement.Unmarshal(m, b)
@@ -2493,6 +2527,13 @@ func (m *PvtDataElement) GetPayload() [][]byte {
 	return nil
 }
 
+func (m *PvtDataElement) GetShard() *ErasureShard {
+	if m != nil {
+		return m.Shard
+	}
+	return nil
+}
+
 // PvtPayload augments private rwset data and tx index
 // inside the block
 type PvtDataPayload struct {
@@ -2509,7 +2550,7 @@ func (m *PvtDataPayload) Reset()         { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()    {}
 func (*PvtDataPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{31}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{31}
 }
 func (m *PvtDataPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataPayload.Unmarshal(m, b)
@@ -2554,7 +2595,7 @@ func (m *Acknowledgement) Reset()         { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()    {}
 func (*Acknowledgement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{32}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{32}
 }
 func (m *Acknowledgement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Acknowledgement.Unmarshal(m, b)
@@ -2596,7 +2637,7 @@ func (m *Chaincode) Reset()         { *m = Chaincode{} }
 func (m *Chaincode) String() string { return proto.CompactTextString(m) }
 func (*Chaincode) ProtoMessage()    {}
 func (*Chaincode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{33}
+	return fileDescriptor_message_e6d8d9a079938a18, []int{33}
 }
 func (m *Chaincode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chaincode.Unmarshal(m, b)
@@ -2637,6 +2678,226 @@ func (m *Chaincode) GetMetadata() []byte {
 	return nil
 }
 
+// StateStreamRequest is used to ask a remote peer to stream
+// a contiguous range of blocks of a channel
+type StateStreamRequest struct {
+	Channel     []byte `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	StartSeqNum uint64 `protobuf:"varint,2,opt,name=start_seq_num,json=startSeqNum,proto3" json:"start_seq_num,omitempty"`
+	EndSeqNum   uint64 `protobuf:"varint,3,opt,name=end_seq_num,json=endSeqNum,proto3" json:"end_seq_num,omitempty"`
+	// the serialized identity of the requesting peer
+	Identity []byte `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	// the hash of the TLS certificate of the requesting peer
+	TlsCertHash []byte `protobuf:"bytes,5,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	// compress asks the remote peer to compress the batches it streams
+	Compress bool `protobuf:"varint,6,opt,name=compress,proto3" json:"compress,omitempty"`
+	// nonce is a random number which makes the request unique,
+	// so the remote peer can detect replayed requests
+	Nonce uint64 `protobuf:"varint,7,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// timestamp is the time the request was created at, the remote
+	// peer only serves requests created within its time window
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *StateStreamRequest) Reset()         { *m = StateStreamRequest{} }
+func (m *StateStreamRequest) String() string { return proto.CompactTextString(m) }
+func (*StateStreamRequest) ProtoMessage()    {}
+func (*StateStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_e6d8d9a079938a18, []int{34}
+}
+func (m *StateStreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateStreamRequest.Unmarshal(m, b)
+}
+func (m *StateStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateStreamRequest.Marshal(b, m, deterministic)
+}
+func (dst *StateStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateStreamRequest.Merge(dst, src)
+}
+func (m *StateStreamRequest) XXX_Size() int {
+	return xxx_messageInfo_StateStreamRequest.Size(m)
+}
+func (m *StateStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateStreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateStreamRequest proto.InternalMessageInfo
+
+func (m *StateStreamRequest) GetChannel() []byte {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+func (m *StateStreamRequest) GetStartSeqNum() uint64 {
+	if m != nil {
+		return m.StartSeqNum
+	}
+	return 0
+}
+
+func (m *StateStreamRequest) GetEndSeqNum() uint64 {
+	if m != nil {
+		return m.EndSeqNum
+	}
+	return 0
+}
+
+func (m *StateStreamRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *StateStreamRequest) GetTlsCertHash() []byte {
+	if m != nil {
+		return m.TlsCertHash
+	}
+	return nil
+}
+
+func (m *StateStreamRequest) GetCompress() bool {
+	if m != nil {
+		return m.Compress
+	}
+	return false
+}
+
+func (m *StateStreamRequest) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *StateStreamRequest) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+// StateStreamResponse carries a batch of blocks
+// streamed by a remote peer
+type StateStreamResponse struct {
+	// the batch is a marshalled RemoteStateResponse,
+	// gzip compressed if compressed is set
+	Batch                []byte   `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Compressed           bool     `protobuf:"varint,2,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateStreamResponse) Reset()         { *m = StateStreamResponse{} }
+func (m *StateStreamResponse) String() string { return proto.CompactTextString(m) }
+func (*StateStreamResponse) ProtoMessage()    {}
+func (*StateStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_e6d8d9a079938a18, []int{35}
+}
+func (m *StateStreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateStreamResponse.Unmarshal(m, b)
+}
+func (m *StateStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateStreamResponse.Marshal(b, m, deterministic)
+}
+func (dst *StateStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateStreamResponse.Merge(dst, src)
+}
+func (m *StateStreamResponse) XXX_Size() int {
+	return xxx_messageInfo_StateStreamResponse.Size(m)
+}
+func (m *StateStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateStreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateStreamResponse proto.InternalMessageInfo
+
+func (m *StateStreamResponse) GetBatch() []byte {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *StateStreamResponse) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+// ErasureShard describes an erasure-coded shard of a private rwset
+type ErasureShard struct {
+	// the index of the shard among all shards
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// the number of shards needed to reconstruct the private rwset
+	DataShards uint32 `protobuf:"varint,2,opt,name=data_shards,json=dataShards,proto3" json:"data_shards,omitempty"`
+	// the number of redundant shards
+	ParityShards uint32 `protobuf:"varint,3,opt,name=parity_shards,json=parityShards,proto3" json:"parity_shards,omitempty"`
+	// the size of the private rwset
+	Size                 uint64   `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ErasureShard) Reset()         { *m = ErasureShard{} }
+func (m *ErasureShard) String() string { return proto.CompactTextString(m) }
+func (*ErasureShard) ProtoMessage()    {}
+func (*ErasureShard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_e6d8d9a079938a18, []int{36}
+}
+func (m *ErasureShard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErasureShard.Unmarshal(m, b)
+}
+func (m *ErasureShard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErasureShard.Marshal(b, m, deterministic)
+}
+func (dst *ErasureShard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErasureShard.Merge(dst, src)
+}
+func (m *ErasureShard) XXX_Size() int {
+	return xxx_messageInfo_ErasureShard.Size(m)
+}
+func (m *ErasureShard) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErasureShard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErasureShard proto.InternalMessageInfo
+
+func (m *ErasureShard) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ErasureShard) GetDataShards() uint32 {
+	if m != nil {
+		return m.DataShards
+	}
+	return 0
+}
+
+func (m *ErasureShard) GetParityShards() uint32 {
+	if m != nil {
+		return m.ParityShards
+	}
+	return 0
+}
+
+func (m *ErasureShard) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Envelope)(nil), "gossip.Envelope")
 	proto.RegisterType((*SecretEnvelope)(nil), "gossip.SecretEnvelope")
@@ -2672,6 +2933,9 @@ func init() {
 	proto.RegisterType((*PvtDataPayload)(nil), "gossip.PvtDataPayload")
 	proto.RegisterType((*Acknowledgement)(nil), "gossip.Acknowledgement")
 	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
+	proto.RegisterType((*StateStreamRequest)(nil), "gossip.StateStreamRequest")
+	proto.RegisterType((*StateStreamResponse)(nil), "gossip.StateStreamResponse")
+	proto.RegisterType((*ErasureShard)(nil), "gossip.ErasureShard")
 	proto.RegisterEnum("gossip.PullMsgType", PullMsgType_name, PullMsgType_value)
 	proto.RegisterEnum("gossip.GossipMessage_Tag", GossipMessage_Tag_name, GossipMessage_Tag_value)
 }
@@ -2818,126 +3082,236 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 	Metadata: "gossip/message.proto",
 }
 
-func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_7c42328ef5ef9997) }
-
-var fileDescriptor_message_7c42328ef5ef9997 = []byte{
-	// 1874 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xe3, 0xc6,
-	0x15, 0x16, 0x6d, 0x5d, 0x8f, 0x2e, 0x96, 0xc7, 0xde, 0x5d, 0xc6, 0x49, 0x13, 0x87, 0xed, 0x26,
-	0xdb, 0x7a, 0x23, 0x6f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0x5b, 0x52, 0x2c, 0x21, 0x2b, 0xad,
-	0x4b, 0x7b, 0xd1, 0xba, 0x2f, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x3b, 0xf6,
-	0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0x6f, 0xe8, 0x53, 0xff, 0x66, 0x31, 0x33, 0xbc, 0x4a, 0xf6,
-	0x02, 0x1b, 0x20, 0x6f, 0x3c, 0xf7, 0x99, 0x33, 0x67, 0xbe, 0x73, 0x86, 0xb0, 0xed, 0x86, 0x8c,
-	0x79, 0xd1, 0x7e, 0x40, 0x18, 0xc3, 0x2e, 0x19, 0x44, 0x71, 0xc8, 0x43, 0x54, 0x57, 0xdc, 0x9d,
-	0x67, 0x76, 0x18, 0x04, 0x21, 0xdd, 0xb7, 0x43, 0xdf, 0x27, 0x36, 0xf7, 0x42, 0xaa, 0x14, 0x8c,
-	0x7f, 0x69, 0xd0, 0x1c, 0xd3, 0x5b, 0xe2, 0x87, 0x11, 0x41, 0x3a, 0x34, 0x22, 0x7c, 0xef, 0x87,
-	0xd8, 0xd1, 0xb5, 0x5d, 0xed, 0x45, 0xc7, 0x4c, 0x49, 0xf4, 0x09, 0xb4, 0x98, 0xe7, 0x52, 0xcc,
-	0x6f, 0x62, 0xa2, 0xaf, 0x49, 0x59, 0xce, 0x40, 0xaf, 0x61, 0x83, 0x11, 0x3b, 0x26, 0xdc, 0x22,
-	0x89, 0x2b, 0x7d, 0x7d, 0x57, 0x7b, 0xd1, 0x3e, 0x78, 0x3a, 0x50, 0xf1, 0x07, 0xa7, 0x52, 0x9c,
-	0x06, 0x32, 0x7b, 0xac, 0x44, 0x1b, 0x13, 0xe8, 0x95, 0x35, 0x7e, 0xea, 0x52, 0x8c, 0x43, 0xa8,
-	0x2b, 0x4f, 0xe8, 0x25, 0xf4, 0x3d, 0xca, 0x49, 0x4c, 0xb1, 0x3f, 0xa6, 0x4e, 0x14, 0x7a, 0x94,
-	0x4b, 0x57, 0xad, 0x49, 0xc5, 0x5c, 0x91, 0x1c, 0xb5, 0xa0, 0x61, 0x87, 0x94, 0x13, 0xca, 0x8d,
-	0x1f, 0xdb, 0xd0, 0x3d, 0x96, 0xcb, 0x9e, 0xa9, 0x5c, 0xa2, 0x6d, 0xa8, 0xd1, 0x90, 0xda, 0x44,
-	0xda, 0x57, 0x4d, 0x45, 0x88, 0x25, 0xda, 0x0b, 0x4c, 0x29, 0xf1, 0x93, 0x65, 0xa4, 0x24, 0xda,
-	0x83, 0x75, 0x8e, 0x5d, 0x99, 0x83, 0xde, 0xc1, 0x47, 0x69, 0x0e, 0x4a, 0x3e, 0x07, 0x67, 0xd8,
-	0x35, 0x85, 0x16, 0xfa, 0x1a, 0x5a, 0xd8, 0xf7, 0x6e, 0x89, 0x15, 0x30, 0x57, 0xaf, 0xc9, 0xb4,
-	0x6d, 0xa7, 0x26, 0x87, 0x42, 0x90, 0x58, 0x4c, 0x2a, 0x66, 0x53, 0x2a, 0xce, 0x98, 0x8b, 0x7e,
-	0x07, 0x8d, 0x80, 0x04, 0x56, 0x4c, 0xae, 0xf5, 0xba, 0x34, 0xc9, 0xa2, 0xcc, 0x48, 0x70, 0x41,
-	0x62, 0xb6, 0xf0, 0x22, 0x93, 0x5c, 0xdf, 0x10, 0xc6, 0x27, 0x15, 0xb3, 0x1e, 0x90, 0xc0, 0x24,
-	0xd7, 0xe8, 0xf7, 0xa9, 0x15, 0xd3, 0x1b, 0xd2, 0x6a, 0xe7, 0x21, 0x2b, 0x16, 0x85, 0x94, 0x91,
-	0xcc, 0x8c, 0xa1, 0x57, 0xd0, 0x74, 0x30, 0xc7, 0x72, 0x81, 0x4d, 0x69, 0xb7, 0x95, 0xda, 0x8d,
-	0x30, 0xc7, 0xf9, 0xfa, 0x1a, 0x42, 0x4d, 0x2c, 0x6f, 0x0f, 0x6a, 0x0b, 0xe2, 0xfb, 0xa1, 0xde,
-	0x2a, 0xab, 0xab, 0x14, 0x4c, 0x84, 0x68, 0x52, 0x31, 0x95, 0x0e, 0xda, 0x4f, 0xdc, 0x3b, 0x9e,
-	0xab, 0x83, 0xd4, 0x47, 0x45, 0xf7, 0x23, 0xcf, 0x55, 0xbb, 0x90, 0xde, 0x47, 0x9e, 0x9b, 0xad,
-	0x47, 0xec, 0xbe, 0xbd, 0xba, 0x9e, 0x7c, 0xdf, 0xd2, 0x42, 0x6d, 0xbc, 0x2d, 0x2d, 0x6e, 0x22,
-	0x07, 0x73, 0xa2, 0x77, 0x56, 0xa3, 0xbc, 0x93, 0x92, 0x49, 0xc5, 0x04, 0x27, 0xa3, 0xd0, 0x73,
-	0xa8, 0x91, 0x20, 0xe2, 0xf7, 0x7a, 0x57, 0x1a, 0x74, 0x53, 0x83, 0xb1, 0x60, 0x8a, 0x0d, 0x48,
-	0x29, 0xda, 0x83, 0xaa, 0x1d, 0x52, 0xaa, 0xf7, 0xa4, 0xd6, 0x93, 0x54, 0x6b, 0x18, 0x52, 0x3a,
-	0x66, 0x1c, 0x5f, 0xf8, 0x1e, 0x5b, 0x4c, 0x2a, 0xa6, 0x54, 0x42, 0x07, 0x00, 0x8c, 0x63, 0x4e,
-	0x2c, 0x8f, 0x5e, 0x86, 0xfa, 0x86, 0x34, 0xd9, 0xcc, 0xae, 0x89, 0x90, 0x4c, 0xe9, 0xa5, 0xc8,
-	0x4e, 0x8b, 0xa5, 0x04, 0x3a, 0x82, 0x9e, 0xb2, 0x61, 0x14, 0x47, 0x6c, 0x11, 0x72, 0xbd, 0x5f,
-	0x3e, 0xf4, 0xcc, 0xee, 0x34, 0x51, 0x98, 0x54, 0xcc, 0xae, 0x34, 0x49, 0x19, 0x68, 0x06, 0x5b,
-	0x79, 0x5c, 0x2b, 0xba, 0xf1, 0x7d, 0x99, 0xbf, 0x4d, 0xe9, 0xe8, 0x93, 0x15, 0x47, 0x27, 0x37,
-	0xbe, 0x9f, 0x27, 0xb2, 0xcf, 0x96, 0xf8, 0xe8, 0x10, 0x94, 0x7f, 0xe1, 0x44, 0x28, 0xe9, 0xa8,
-	0x5c, 0x50, 0x26, 0x09, 0x42, 0x4e, 0xa4, 0xbb, 0xdc, 0x4d, 0x87, 0x15, 0x68, 0x34, 0x4a, 0x77,
-	0x15, 0x27, 0x25, 0xa7, 0x6f, 0x49, 0x1f, 0x1f, 0x3f, 0xe8, 0x23, 0xab, 0xca, 0x2e, 0x2b, 0x32,
-	0x44, 0x6e, 0x7c, 0x82, 0x1d, 0x55, 0xbc, 0xb2, 0x44, 0xb7, 0xcb, 0xb9, 0x79, 0x93, 0x49, 0xf3,
-	0x42, 0xed, 0xe6, 0x26, 0xa2, 0x5c, 0xbf, 0x85, 0x6e, 0x44, 0x48, 0x6c, 0x79, 0x0e, 0xa1, 0xdc,
-	0xe3, 0xf7, 0xfa, 0x93, 0xf2, 0x35, 0x3c, 0x21, 0x24, 0x9e, 0x26, 0x32, 0xb1, 0x8d, 0xa8, 0x40,
-	0x8b, 0xcb, 0x8e, 0xed, 0x2b, 0xfd, 0xa9, 0x34, 0x79, 0x96, 0xdd, 0x5c, 0xfb, 0x8a, 0x86, 0x3f,
-	0xf8, 0xc4, 0x71, 0x49, 0x40, 0xa8, 0xd8, 0xbc, 0xd0, 0x42, 0x7f, 0x06, 0x88, 0x62, 0xef, 0x56,
-	0x65, 0x41, 0x7f, 0x56, 0x4e, 0xbe, 0xda, 0xef, 0xc9, 0x2d, 0x2f, 0x57, 0x71, 0xc1, 0x02, 0xbd,
-	0x2e, 0xd8, 0x33, 0x5d, 0x97, 0xf6, 0xbf, 0x78, 0xc4, 0x3e, 0xcb, 0x58, 0xc1, 0x04, 0xbd, 0x86,
-	0x4e, 0x42, 0x59, 0xa2, 0xd0, 0xf5, 0x8f, 0xca, 0xc7, 0x76, 0xa2, 0x64, 0xe5, 0x6b, 0xdd, 0x8e,
-	0x72, 0xae, 0x61, 0xc1, 0xfa, 0x19, 0x76, 0x51, 0x17, 0x5a, 0xef, 0xe6, 0xa3, 0xf1, 0x77, 0xd3,
-	0xf9, 0x78, 0xd4, 0xaf, 0xa0, 0x16, 0xd4, 0xc6, 0xb3, 0x93, 0xb3, 0xf3, 0xbe, 0x86, 0x3a, 0xd0,
-	0x7c, 0x6b, 0x1e, 0x5b, 0x6f, 0xe7, 0x6f, 0xce, 0xfb, 0x6b, 0x42, 0x6f, 0x38, 0x39, 0x9c, 0x2b,
-	0x72, 0x1d, 0xf5, 0xa1, 0x23, 0xc9, 0xc3, 0xf9, 0xc8, 0x7a, 0x6b, 0x1e, 0xf7, 0xab, 0x68, 0x03,
-	0xda, 0x4a, 0xc1, 0x94, 0x8c, 0x5a, 0x11, 0x89, 0xff, 0xa7, 0x41, 0x2b, 0xab, 0x48, 0x34, 0x80,
-	0x16, 0xf7, 0x02, 0xc2, 0x38, 0x0e, 0x22, 0x89, 0xb8, 0xed, 0x83, 0x7e, 0xf1, 0x84, 0xce, 0xbc,
-	0x80, 0x98, 0xb9, 0x0a, 0x7a, 0x02, 0xf5, 0xe8, 0xca, 0xb3, 0x3c, 0x47, 0x02, 0x71, 0xc7, 0xac,
-	0x45, 0x57, 0xde, 0xd4, 0x41, 0x9f, 0x41, 0x3b, 0xc1, 0x69, 0x6b, 0x76, 0x38, 0xd4, 0xab, 0x52,
-	0x06, 0x09, 0x6b, 0x76, 0x38, 0x14, 0x37, 0x34, 0x8a, 0xc3, 0x88, 0xc4, 0xdc, 0x23, 0x2c, 0x41,
-	0x64, 0x94, 0x27, 0x28, 0x95, 0x98, 0x05, 0x2d, 0xe3, 0x47, 0x0d, 0x20, 0x17, 0xa1, 0x5f, 0x42,
-	0x57, 0x1e, 0x7d, 0x6c, 0x2d, 0x88, 0xe7, 0x2e, 0x78, 0xd2, 0x38, 0x3a, 0x8a, 0x39, 0x91, 0x3c,
-	0xf4, 0x39, 0x74, 0x7c, 0x72, 0xc9, 0xad, 0x62, 0x13, 0x69, 0x9a, 0x6d, 0xc1, 0x1b, 0x26, 0x8d,
-	0xe4, 0xb7, 0x20, 0x16, 0xe6, 0x51, 0x3b, 0x74, 0x08, 0xd3, 0xd7, 0x77, 0xd7, 0x8b, 0x60, 0x31,
-	0x4c, 0x25, 0x66, 0x41, 0xc9, 0x38, 0x84, 0xcd, 0x15, 0x34, 0x40, 0x2f, 0xa1, 0x49, 0x7c, 0x59,
-	0x88, 0x4c, 0xd7, 0xa4, 0x97, 0x2c, 0x73, 0x59, 0x4f, 0xce, 0x34, 0x8c, 0x3f, 0xc0, 0xf6, 0x43,
-	0x38, 0xb0, 0x9c, 0x39, 0x6d, 0x39, 0x73, 0xc6, 0x25, 0x74, 0x4b, 0xa0, 0x57, 0x38, 0x02, 0xad,
-	0x78, 0x04, 0x3b, 0xd0, 0xcc, 0xae, 0x9a, 0x6a, 0x9d, 0x19, 0x8d, 0x0c, 0xe8, 0x72, 0x9f, 0x59,
-	0x36, 0x89, 0xb9, 0xb5, 0xc0, 0x6c, 0x91, 0x1c, 0x5e, 0x9b, 0xfb, 0x6c, 0x48, 0x62, 0x3e, 0xc1,
-	0x6c, 0x61, 0xbc, 0x83, 0x4e, 0xf1, 0x4a, 0x3e, 0x16, 0x06, 0x41, 0x55, 0xb8, 0x49, 0x42, 0xc8,
-	0x6f, 0x11, 0x3a, 0x20, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x33, 0xda, 0x08, 0xa0, 0x5d, 0xb8, 0x79,
-	0x8f, 0x77, 0x7d, 0x47, 0x76, 0x24, 0xa6, 0xaf, 0xed, 0xae, 0x8b, 0xae, 0x9f, 0x90, 0x68, 0x00,
-	0xcd, 0x80, 0xb9, 0x16, 0xbf, 0x4f, 0xc6, 0x9f, 0x5e, 0xde, 0x96, 0x44, 0x16, 0x67, 0xcc, 0x3d,
-	0xbb, 0x8f, 0x88, 0xd9, 0x08, 0xd4, 0x87, 0x11, 0x42, 0xbb, 0xd0, 0x0f, 0x1f, 0x09, 0x57, 0x5c,
-	0xef, 0x5a, 0x79, 0xbd, 0x1f, 0x1c, 0xf0, 0x0e, 0x20, 0x6f, 0x75, 0x8f, 0xc4, 0xfb, 0x15, 0x54,
-	0x93, 0x58, 0x0f, 0x57, 0x49, 0xf5, 0x27, 0x45, 0xf6, 0x55, 0x64, 0xd5, 0xca, 0x7f, 0xf6, 0xc4,
-	0x7e, 0xa3, 0xce, 0x31, 0x9d, 0xde, 0x7e, 0x5d, 0x1e, 0x25, 0xdb, 0x07, 0x1b, 0x99, 0xb5, 0x62,
-	0x67, 0xb3, 0xa5, 0xf1, 0x1d, 0xa0, 0x55, 0x04, 0x44, 0xaf, 0x96, 0x1d, 0x3c, 0x5d, 0x82, 0xcb,
-	0x15, 0x3f, 0xe7, 0xd0, 0x48, 0x78, 0xe8, 0x19, 0x34, 0x18, 0xb9, 0xb6, 0xe8, 0x4d, 0x90, 0x6c,
-	0xb7, 0xce, 0xc8, 0xf5, 0xfc, 0x26, 0x10, 0xd5, 0x59, 0x38, 0x55, 0x95, 0xd7, 0xcf, 0x97, 0xd0,
-	0x79, 0x5d, 0x26, 0xa2, 0x84, 0xbf, 0xff, 0x59, 0x83, 0x5e, 0x39, 0x2c, 0xfa, 0x12, 0x36, 0xf2,
-	0xb9, 0xde, 0xa2, 0x38, 0x50, 0x99, 0x6d, 0x99, 0xbd, 0x9c, 0x3d, 0xc7, 0x01, 0x11, 0xa3, 0xb3,
-	0x90, 0xb2, 0x08, 0xdb, 0x6a, 0x74, 0x6e, 0x99, 0x39, 0x03, 0x6d, 0x41, 0x8d, 0xdf, 0xa5, 0x70,
-	0xd9, 0x32, 0xab, 0xfc, 0x6e, 0xea, 0x08, 0x24, 0x4b, 0x57, 0x14, 0xff, 0xc0, 0x08, 0x4f, 0xf0,
-	0x32, 0x5d, 0xa6, 0x29, 0x78, 0xe8, 0x25, 0xa0, 0x54, 0x89, 0x79, 0x41, 0x8a, 0x79, 0x35, 0xb9,
-	0xdd, 0x7e, 0x22, 0x39, 0xf5, 0x82, 0x04, 0xf7, 0xe6, 0x80, 0x0a, 0xcb, 0xb5, 0x43, 0x7a, 0xe9,
-	0xb9, 0x2c, 0x19, 0x63, 0x3f, 0x1b, 0xa8, 0x87, 0xca, 0x60, 0x98, 0x69, 0x0c, 0xa5, 0xc2, 0x09,
-	0xb6, 0xaf, 0xb0, 0x4b, 0xcc, 0x4d, 0x7b, 0x49, 0xc0, 0x8c, 0x7f, 0x6b, 0xd0, 0x29, 0x0e, 0xca,
-	0x68, 0x00, 0x10, 0x64, 0xf3, 0x6c, 0x72, 0x64, 0xbd, 0xf2, 0xa4, 0x6b, 0x16, 0x34, 0x3e, 0xb8,
-	0xb1, 0x14, 0xe1, 0xab, 0x5a, 0x86, 0x2f, 0xe3, 0x9f, 0x1a, 0x6c, 0xae, 0x4c, 0x1c, 0x8f, 0x01,
-	0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x8f, 0x59, 0x0e, 0xb1, 0x7d, 0x1c, 0x63, 0x91, 0x02, 0x79,
-	0x54, 0x4d, 0xb3, 0xeb, 0xb1, 0x51, 0xce, 0x34, 0xfe, 0x08, 0xcd, 0xd4, 0x5a, 0x94, 0x9f, 0x47,
-	0xed, 0x62, 0xf9, 0x79, 0xd4, 0x16, 0xe5, 0x57, 0xa8, 0xcb, 0xb5, 0x62, 0x5d, 0x1a, 0x97, 0xb0,
-	0xb9, 0xf2, 0x86, 0x40, 0xdf, 0x42, 0x9f, 0x11, 0xff, 0x52, 0x0e, 0x8f, 0x71, 0xa0, 0x62, 0x6b,
-	0xe5, 0x05, 0x67, 0x10, 0xb1, 0x21, 0x34, 0xa7, 0xb9, 0xa2, 0xb8, 0xef, 0x62, 0x18, 0xa2, 0xc9,
-	0xbd, 0x56, 0x84, 0x71, 0x01, 0x68, 0xf5, 0xd5, 0x81, 0xbe, 0x80, 0x9a, 0x7c, 0xe4, 0x3c, 0xda,
-	0xa6, 0x94, 0x58, 0xe2, 0x14, 0xc1, 0xce, 0x7b, 0x70, 0x8a, 0x60, 0xc7, 0xf8, 0x2b, 0xd4, 0x55,
-	0x0c, 0x71, 0x66, 0xa4, 0xf4, 0x0a, 0x34, 0x33, 0xfa, 0xbd, 0x18, 0xfb, 0xf0, 0x10, 0x61, 0x34,
-	0xa0, 0x26, 0x1f, 0x01, 0xc6, 0xdf, 0x00, 0xad, 0x8e, 0xba, 0xa2, 0x89, 0x31, 0x8e, 0x63, 0x6e,
-	0x95, 0xaf, 0x7e, 0x5b, 0x32, 0x4f, 0xd5, 0xfd, 0xff, 0x14, 0xda, 0x84, 0x3a, 0x56, 0xf9, 0x10,
-	0x5a, 0x84, 0x3a, 0x4a, 0x6e, 0x1c, 0xc1, 0xd6, 0x03, 0x03, 0x30, 0xda, 0x83, 0x66, 0x82, 0x32,
-	0x69, 0x2b, 0x5f, 0x81, 0xb3, 0x4c, 0xc1, 0x38, 0x86, 0xed, 0x87, 0x86, 0x4a, 0xb4, 0x9f, 0x63,
-	0xad, 0xf2, 0x91, 0x3d, 0x5a, 0x12, 0x45, 0x85, 0xd4, 0x19, 0x04, 0x1b, 0xff, 0xd5, 0xa0, 0x5b,
-	0x12, 0xe5, 0x68, 0xa1, 0x15, 0xd0, 0xe2, 0xfd, 0x00, 0xf3, 0x29, 0x40, 0x7e, 0x7b, 0x13, 0x94,
-	0x29, 0x70, 0xd0, 0xc7, 0xd0, 0xba, 0xf0, 0x43, 0xfb, 0x4a, 0xe4, 0x44, 0x5e, 0xac, 0xaa, 0xd9,
-	0x94, 0x8c, 0x53, 0x72, 0x8d, 0x76, 0xa1, 0x23, 0x52, 0xe5, 0x51, 0x4b, 0xb2, 0x12, 0x74, 0x01,
-	0x46, 0xae, 0xa7, 0xf4, 0x48, 0x70, 0x8c, 0xef, 0xe1, 0xc9, 0x83, 0x13, 0x30, 0x3a, 0x58, 0x99,
-	0x7e, 0x9e, 0x2e, 0x6d, 0x77, 0xac, 0xc4, 0x85, 0x19, 0xe8, 0x1c, 0x7a, 0x65, 0x19, 0xfa, 0x0a,
-	0xea, 0x2a, 0x1b, 0x49, 0xe1, 0x3f, 0x92, 0xb2, 0x44, 0xa9, 0xf8, 0x03, 0x23, 0x69, 0x67, 0x69,
-	0x73, 0xf8, 0x4b, 0xe6, 0x3a, 0x05, 0xf0, 0xe7, 0xb0, 0xc1, 0xef, 0xac, 0xd2, 0xf6, 0x92, 0x81,
-	0x91, 0xdf, 0x9d, 0x66, 0x1b, 0x2c, 0xbb, 0x2c, 0xfe, 0x13, 0x31, 0xbe, 0x84, 0x8d, 0xa5, 0x07,
-	0x87, 0xb8, 0x74, 0x24, 0x8e, 0xc3, 0x38, 0x39, 0x1f, 0x45, 0x18, 0xef, 0xa0, 0x95, 0x8d, 0x8d,
-	0xa2, 0x03, 0x15, 0x9a, 0x85, 0xfc, 0x16, 0x31, 0x6e, 0x49, 0xcc, 0xc4, 0x01, 0xa9, 0xf3, 0x4b,
-	0xc9, 0xf7, 0x4d, 0x4e, 0xbf, 0xf9, 0x13, 0xb4, 0x0b, 0x9d, 0x78, 0xf9, 0x71, 0xd0, 0x85, 0xd6,
-	0xd1, 0x9b, 0xb7, 0xc3, 0xef, 0xad, 0xd9, 0xe9, 0x71, 0x5f, 0x13, 0x6f, 0x80, 0xe9, 0x68, 0x3c,
-	0x3f, 0x9b, 0x9e, 0x9d, 0x4b, 0xce, 0xda, 0xc1, 0x3f, 0xa0, 0xae, 0x26, 0x21, 0xf4, 0x0d, 0x74,
-	0xd4, 0xd7, 0x29, 0x8f, 0x09, 0x0e, 0xd0, 0xca, 0xc5, 0xde, 0x59, 0xe1, 0x18, 0x95, 0x17, 0xda,
-	0x2b, 0x0d, 0x7d, 0x01, 0xd5, 0x13, 0x8f, 0xba, 0xa8, 0xfc, 0x48, 0xdf, 0x29, 0x93, 0x46, 0xe5,
-	0xe8, 0xab, 0xbf, 0xef, 0xb9, 0x1e, 0x5f, 0xdc, 0x5c, 0x88, 0x4e, 0xb3, 0xbf, 0xb8, 0x8f, 0x48,
-	0xac, 0xa6, 0xf2, 0xfd, 0x4b, 0x7c, 0x11, 0x7b, 0xf6, 0xbe, 0xfc, 0x2f, 0xc6, 0xf6, 0x95, 0xd9,
-	0x45, 0x5d, 0x92, 0x5f, 0xff, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x1d, 0xb3, 0x7e, 0x5f, 0x13,
-	0x00, 0x00,
+// StateTransferClient is the client API for StateTransfer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StateTransferClient interface {
+	// StreamBlocks streams a contiguous range of blocks of a channel, in batches.
+	// The envelope contains a marshalled StateStreamRequest and a signature over it.
+	StreamBlocks(ctx context.Context, in *Envelope, opts ...grpc.CallOption) (StateTransfer_StreamBlocksClient, error)
+}
+
+type stateTransferClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateTransferClient(cc *grpc.ClientConn) StateTransferClient {
+	return &stateTransferClient{cc}
+}
+
+func (c *stateTransferClient) StreamBlocks(ctx context.Context, in *Envelope, opts ...grpc.CallOption) (StateTransfer_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StateTransfer_serviceDesc.Streams[0], "/gossip.StateTransfer/StreamBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &stateTransferStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StateTransfer_StreamBlocksClient interface {
+	Recv() (*StateStreamResponse, error)
+	grpc.ClientStream
+}
+
+type stateTransferStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *stateTransferStreamBlocksClient) Recv() (*StateStreamResponse, error) {
+	m := new(StateStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StateTransferServer is the server API for StateTransfer service.
+type StateTransferServer interface {
+	// StreamBlocks streams a contiguous range of blocks of a channel, in batches.
+	// The envelope contains a marshalled StateStreamRequest and a signature over it.
+	StreamBlocks(*Envelope, StateTransfer_StreamBlocksServer) error
+}
+
+func RegisterStateTransferServer(s *grpc.Server, srv StateTransferServer) {
+	s.RegisterService(&_StateTransfer_serviceDesc, srv)
+}
+
+func _StateTransfer_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Envelope)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateTransferServer).StreamBlocks(m, &stateTransferStreamBlocksServer{stream})
+}
+
+type StateTransfer_StreamBlocksServer interface {
+	Send(*StateStreamResponse) error
+	grpc.ServerStream
+}
+
+type stateTransferStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *stateTransferStreamBlocksServer) Send(m *StateStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _StateTransfer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gossip.StateTransfer",
+	HandlerType: (*StateTransferServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _StateTransfer_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gossip/message.proto",
+}
+
+func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_e6d8d9a079938a18) }
+
+var fileDescriptor_message_e6d8d9a079938a18 = []byte{
+	// 2126 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xdb, 0xc8,
+	0xf5, 0x37, 0x2d, 0xc9, 0x92, 0x8e, 0x2e, 0x96, 0xc7, 0x4e, 0xc2, 0x75, 0xf6, 0xbf, 0xf1, 0x9f,
+	0x6d, 0x76, 0xd3, 0x4d, 0x56, 0x4e, 0xbd, 0x2d, 0x1a, 0x60, 0xdb, 0x06, 0xb6, 0xac, 0x8d, 0x8c,
+	0xc4, 0x8e, 0x4b, 0x3b, 0x68, 0xd3, 0x17, 0x62, 0x4c, 0x8e, 0x28, 0xd6, 0xbc, 0x99, 0x33, 0xca,
+	0xda, 0x7d, 0xe8, 0x4b, 0x51, 0x2c, 0xd0, 0x0f, 0x51, 0xa0, 0x4f, 0xfd, 0x10, 0x7d, 0xeb, 0x27,
+	0x2b, 0x66, 0x86, 0x97, 0xa1, 0x28, 0x7b, 0x91, 0x05, 0xfa, 0xc6, 0x73, 0x9d, 0x33, 0x67, 0xce,
+	0xfc, 0xce, 0x19, 0xc2, 0x96, 0x1b, 0x51, 0xea, 0xc5, 0xbb, 0x01, 0xa1, 0x14, 0xbb, 0x64, 0x18,
+	0x27, 0x11, 0x8b, 0xd0, 0x9a, 0xe4, 0x6e, 0x3f, 0xb0, 0xa3, 0x20, 0x88, 0xc2, 0x5d, 0x3b, 0xf2,
+	0x7d, 0x62, 0x33, 0x2f, 0x0a, 0xa5, 0xc2, 0xf6, 0x23, 0x37, 0x8a, 0x5c, 0x9f, 0xec, 0x0a, 0xea,
+	0x62, 0x3e, 0xdd, 0x65, 0x5e, 0x40, 0x28, 0xc3, 0x41, 0x2c, 0x15, 0x8c, 0xbf, 0x6a, 0xd0, 0x1a,
+	0x87, 0x1f, 0x88, 0x1f, 0xc5, 0x04, 0xe9, 0xd0, 0x8c, 0xf1, 0x8d, 0x1f, 0x61, 0x47, 0xd7, 0x76,
+	0xb4, 0x27, 0x5d, 0x33, 0x23, 0xd1, 0xa7, 0xd0, 0xa6, 0x9e, 0x1b, 0x62, 0x36, 0x4f, 0x88, 0xbe,
+	0x2a, 0x64, 0x05, 0x03, 0xbd, 0x84, 0x75, 0x4a, 0xec, 0x84, 0x30, 0x8b, 0xa4, 0xae, 0xf4, 0xda,
+	0x8e, 0xf6, 0xa4, 0xb3, 0x77, 0x7f, 0x28, 0x03, 0x1c, 0x9e, 0x09, 0x71, 0xb6, 0x90, 0xd9, 0xa7,
+	0x25, 0xda, 0x98, 0x40, 0xbf, 0xac, 0xf1, 0x63, 0x43, 0x31, 0xf6, 0x61, 0x4d, 0x7a, 0x42, 0xcf,
+	0x60, 0xe0, 0x85, 0x8c, 0x24, 0x21, 0xf6, 0xc7, 0xa1, 0x13, 0x47, 0x5e, 0xc8, 0x84, 0xab, 0xf6,
+	0x64, 0xc5, 0xac, 0x48, 0x0e, 0xda, 0xd0, 0xb4, 0xa3, 0x90, 0x91, 0x90, 0x19, 0xdf, 0x77, 0xa0,
+	0xf7, 0x4a, 0x84, 0x7d, 0x2c, 0x93, 0x8d, 0xb6, 0xa0, 0x11, 0x46, 0xa1, 0x4d, 0x84, 0x7d, 0xdd,
+	0x94, 0x04, 0x0f, 0xd1, 0x9e, 0xe1, 0x30, 0x24, 0x7e, 0x1a, 0x46, 0x46, 0xa2, 0xa7, 0x50, 0x63,
+	0xd8, 0x15, 0x39, 0xe8, 0xef, 0x7d, 0x92, 0xe5, 0xa0, 0xe4, 0x73, 0x78, 0x8e, 0x5d, 0x93, 0x6b,
+	0xa1, 0xaf, 0xa1, 0x8d, 0x7d, 0xef, 0x03, 0xb1, 0x02, 0xea, 0xea, 0x0d, 0x91, 0xb6, 0xad, 0xcc,
+	0x64, 0x9f, 0x0b, 0x52, 0x8b, 0xc9, 0x8a, 0xd9, 0x12, 0x8a, 0xc7, 0xd4, 0x45, 0xbf, 0x80, 0x66,
+	0x40, 0x02, 0x2b, 0x21, 0x57, 0xfa, 0x9a, 0x30, 0xc9, 0x57, 0x39, 0x26, 0xc1, 0x05, 0x49, 0xe8,
+	0xcc, 0x8b, 0x4d, 0x72, 0x35, 0x27, 0x94, 0x4d, 0x56, 0xcc, 0xb5, 0x80, 0x04, 0x26, 0xb9, 0x42,
+	0xbf, 0xcc, 0xac, 0xa8, 0xde, 0x14, 0x56, 0xdb, 0xcb, 0xac, 0x68, 0x1c, 0x85, 0x94, 0xe4, 0x66,
+	0x14, 0x3d, 0x87, 0x96, 0x83, 0x19, 0x16, 0x01, 0xb6, 0x84, 0xdd, 0x66, 0x66, 0x77, 0x88, 0x19,
+	0x2e, 0xe2, 0x6b, 0x72, 0x35, 0x1e, 0xde, 0x53, 0x68, 0xcc, 0x88, 0xef, 0x47, 0x7a, 0xbb, 0xac,
+	0x2e, 0x53, 0x30, 0xe1, 0xa2, 0xc9, 0x8a, 0x29, 0x75, 0xd0, 0x6e, 0xea, 0xde, 0xf1, 0x5c, 0x1d,
+	0x84, 0x3e, 0x52, 0xdd, 0x1f, 0x7a, 0xae, 0xdc, 0x85, 0xf0, 0x7e, 0xe8, 0xb9, 0x79, 0x3c, 0x7c,
+	0xf7, 0x9d, 0x6a, 0x3c, 0xc5, 0xbe, 0x85, 0x85, 0xdc, 0x78, 0x47, 0x58, 0xcc, 0x63, 0x07, 0x33,
+	0xa2, 0x77, 0xab, 0xab, 0xbc, 0x13, 0x92, 0xc9, 0x8a, 0x09, 0x4e, 0x4e, 0xa1, 0xc7, 0xd0, 0x20,
+	0x41, 0xcc, 0x6e, 0xf4, 0x9e, 0x30, 0xe8, 0x65, 0x06, 0x63, 0xce, 0xe4, 0x1b, 0x10, 0x52, 0xf4,
+	0x14, 0xea, 0x76, 0x14, 0x86, 0x7a, 0x5f, 0x68, 0xdd, 0xcb, 0xb4, 0x46, 0x51, 0x18, 0x8e, 0x29,
+	0xc3, 0x17, 0xbe, 0x47, 0x67, 0x93, 0x15, 0x53, 0x28, 0xa1, 0x3d, 0x00, 0xca, 0x30, 0x23, 0x96,
+	0x17, 0x4e, 0x23, 0x7d, 0x5d, 0x98, 0x6c, 0xe4, 0xd7, 0x84, 0x4b, 0x8e, 0xc2, 0x29, 0xcf, 0x4e,
+	0x9b, 0x66, 0x04, 0x3a, 0x80, 0xbe, 0xb4, 0xa1, 0x21, 0x8e, 0xe9, 0x2c, 0x62, 0xfa, 0xa0, 0x7c,
+	0xe8, 0xb9, 0xdd, 0x59, 0xaa, 0x30, 0x59, 0x31, 0x7b, 0xc2, 0x24, 0x63, 0xa0, 0x63, 0xd8, 0x2c,
+	0xd6, 0xb5, 0xe2, 0xb9, 0xef, 0x8b, 0xfc, 0x6d, 0x08, 0x47, 0x9f, 0x56, 0x1c, 0x9d, 0xce, 0x7d,
+	0xbf, 0x48, 0xe4, 0x80, 0x2e, 0xf0, 0xd1, 0x3e, 0x48, 0xff, 0x56, 0x22, 0x95, 0x74, 0x54, 0x2e,
+	0x28, 0x93, 0x04, 0x11, 0x23, 0xc2, 0x5d, 0xe1, 0xa6, 0x4b, 0x15, 0x1a, 0x1d, 0x66, 0xbb, 0x4a,
+	0xd2, 0x92, 0xd3, 0x37, 0x85, 0x8f, 0x87, 0x4b, 0x7d, 0xe4, 0x55, 0xd9, 0xa3, 0x2a, 0x83, 0xe7,
+	0xc6, 0x27, 0xd8, 0x91, 0xc5, 0x2b, 0x4a, 0x74, 0xab, 0x9c, 0x9b, 0x37, 0xb9, 0xb4, 0x28, 0xd4,
+	0x5e, 0x61, 0xc2, 0xcb, 0xf5, 0x1b, 0xe8, 0xc5, 0x84, 0x24, 0x96, 0xe7, 0x90, 0x90, 0x79, 0xec,
+	0x46, 0xbf, 0x57, 0xbe, 0x86, 0xa7, 0x84, 0x24, 0x47, 0xa9, 0x8c, 0x6f, 0x23, 0x56, 0x68, 0x7e,
+	0xd9, 0xb1, 0x7d, 0xa9, 0xdf, 0x17, 0x26, 0x0f, 0xf2, 0x9b, 0x6b, 0x5f, 0x86, 0xd1, 0x77, 0x3e,
+	0x71, 0x5c, 0x12, 0x90, 0x90, 0x6f, 0x9e, 0x6b, 0xa1, 0xdf, 0x02, 0xc4, 0x89, 0xf7, 0x41, 0x66,
+	0x41, 0x7f, 0x50, 0x4e, 0xbe, 0xdc, 0xef, 0xe9, 0x07, 0x56, 0xae, 0x62, 0xc5, 0x02, 0xbd, 0x54,
+	0xec, 0xa9, 0xae, 0x0b, 0xfb, 0xff, 0xbb, 0xc5, 0x3e, 0xcf, 0x98, 0x62, 0x82, 0x5e, 0x42, 0x37,
+	0xa5, 0x2c, 0x5e, 0xe8, 0xfa, 0x27, 0xe5, 0x63, 0x3b, 0x95, 0xb2, 0xf2, 0xb5, 0xee, 0xc4, 0x05,
+	0xd7, 0xb0, 0xa0, 0x76, 0x8e, 0x5d, 0xd4, 0x83, 0xf6, 0xbb, 0x93, 0xc3, 0xf1, 0xb7, 0x47, 0x27,
+	0xe3, 0xc3, 0xc1, 0x0a, 0x6a, 0x43, 0x63, 0x7c, 0x7c, 0x7a, 0xfe, 0x7e, 0xa0, 0xa1, 0x2e, 0xb4,
+	0xde, 0x9a, 0xaf, 0xac, 0xb7, 0x27, 0x6f, 0xde, 0x0f, 0x56, 0xb9, 0xde, 0x68, 0xb2, 0x7f, 0x22,
+	0xc9, 0x1a, 0x1a, 0x40, 0x57, 0x90, 0xfb, 0x27, 0x87, 0xd6, 0x5b, 0xf3, 0xd5, 0xa0, 0x8e, 0xd6,
+	0xa1, 0x23, 0x15, 0x4c, 0xc1, 0x68, 0xa8, 0x48, 0xfc, 0x2f, 0x0d, 0xda, 0x79, 0x45, 0xa2, 0x21,
+	0xb4, 0xf3, 0xee, 0x25, 0x10, 0xb7, 0xb3, 0x37, 0x50, 0x4f, 0xe8, 0xdc, 0x0b, 0x88, 0x59, 0xa8,
+	0xa0, 0x7b, 0xb0, 0x16, 0x5f, 0x7a, 0x96, 0xe7, 0x08, 0x20, 0xee, 0x9a, 0x8d, 0xf8, 0xd2, 0x3b,
+	0x72, 0xd0, 0x23, 0xe8, 0xa4, 0x38, 0x6d, 0x1d, 0xef, 0x8f, 0xf4, 0xba, 0x90, 0x41, 0xca, 0x3a,
+	0xde, 0x1f, 0xf1, 0x1b, 0x1a, 0x27, 0x51, 0x4c, 0x12, 0xe6, 0x11, 0xaa, 0x37, 0xca, 0x58, 0x71,
+	0x9a, 0x4b, 0x4c, 0x45, 0xcb, 0xf8, 0x5e, 0x03, 0x28, 0x44, 0xe8, 0x27, 0xd0, 0x13, 0x47, 0x9f,
+	0x58, 0x33, 0xe2, 0xb9, 0x33, 0x96, 0x36, 0x8e, 0xae, 0x64, 0x4e, 0x04, 0x0f, 0xfd, 0x3f, 0x74,
+	0x7d, 0x32, 0x65, 0x96, 0xda, 0x44, 0x5a, 0x66, 0x87, 0xf3, 0x46, 0x92, 0x85, 0x7e, 0x0e, 0x3c,
+	0x30, 0x2f, 0xb4, 0x23, 0x87, 0x50, 0xbd, 0xb6, 0x53, 0x53, 0xc1, 0x62, 0x94, 0x49, 0x4c, 0x45,
+	0xc9, 0xd8, 0x87, 0x8d, 0x0a, 0x1a, 0xa0, 0x67, 0xd0, 0x22, 0xbe, 0x28, 0x44, 0xaa, 0x6b, 0x3b,
+	0x35, 0x35, 0x73, 0x79, 0x4f, 0xce, 0x35, 0x8c, 0x5f, 0xc1, 0xd6, 0x32, 0x1c, 0x58, 0xcc, 0x9c,
+	0xb6, 0x98, 0x39, 0x63, 0x0a, 0xbd, 0x12, 0xe8, 0x29, 0x47, 0xa0, 0xa9, 0x47, 0xb0, 0x0d, 0xad,
+	0xfc, 0xaa, 0xc9, 0xd6, 0x99, 0xd3, 0xc8, 0x80, 0x1e, 0xf3, 0xa9, 0x65, 0x93, 0x84, 0x59, 0x33,
+	0x4c, 0x67, 0xe9, 0xe1, 0x75, 0x98, 0x4f, 0x47, 0x24, 0x61, 0x13, 0x4c, 0x67, 0xc6, 0x3b, 0xe8,
+	0xaa, 0x57, 0xf2, 0xb6, 0x65, 0x10, 0xd4, 0xb9, 0x9b, 0x74, 0x09, 0xf1, 0xcd, 0x97, 0x0e, 0x08,
+	0xc3, 0xa2, 0xf6, 0xa5, 0xe7, 0x9c, 0x36, 0x02, 0xe8, 0x28, 0x37, 0xef, 0xf6, 0xae, 0xef, 0x88,
+	0x8e, 0x44, 0xf5, 0xd5, 0x9d, 0x1a, 0xef, 0xfa, 0x29, 0x89, 0x86, 0xd0, 0x0a, 0xa8, 0x6b, 0xb1,
+	0x9b, 0x74, 0xfc, 0xe9, 0x17, 0x6d, 0x89, 0x67, 0xf1, 0x98, 0xba, 0xe7, 0x37, 0x31, 0x31, 0x9b,
+	0x81, 0xfc, 0x30, 0x22, 0xe8, 0x28, 0xfd, 0xf0, 0x96, 0xe5, 0xd4, 0x78, 0x57, 0xcb, 0xf1, 0x7e,
+	0xf4, 0x82, 0xd7, 0x00, 0x45, 0xab, 0xbb, 0x65, 0xbd, 0x9f, 0x42, 0x3d, 0x5d, 0x6b, 0x79, 0x95,
+	0xd4, 0x7f, 0xd4, 0xca, 0x3e, 0x40, 0xd1, 0xca, 0xff, 0xe7, 0x89, 0x7d, 0x01, 0x1d, 0x05, 0xc0,
+	0xd0, 0xcf, 0xca, 0xa3, 0x64, 0x67, 0x6f, 0x3d, 0xb7, 0x96, 0xec, 0x7c, 0xb6, 0x34, 0xbe, 0x05,
+	0x54, 0x45, 0x40, 0xf4, 0x7c, 0xd1, 0xc1, 0xfd, 0x05, 0xb8, 0xac, 0xf8, 0x79, 0x0f, 0xcd, 0x94,
+	0x87, 0x1e, 0x40, 0x93, 0x92, 0x2b, 0x2b, 0x9c, 0x07, 0xe9, 0x76, 0xd7, 0x28, 0xb9, 0x3a, 0x99,
+	0x07, 0xbc, 0x3a, 0x95, 0x53, 0x15, 0xdf, 0x1c, 0x12, 0x4a, 0xe8, 0x5c, 0x13, 0x89, 0x28, 0xe1,
+	0xef, 0xbf, 0x57, 0xa1, 0x5f, 0x5e, 0x16, 0x7d, 0x01, 0xeb, 0xc5, 0xe0, 0x6f, 0x85, 0x38, 0x90,
+	0x99, 0x6d, 0x9b, 0xfd, 0x82, 0x7d, 0x82, 0x03, 0xc2, 0x47, 0x67, 0x2e, 0xa5, 0x31, 0xb6, 0xe5,
+	0xe8, 0xdc, 0x36, 0x0b, 0x06, 0xda, 0x84, 0x06, 0xbb, 0xce, 0xe0, 0xb2, 0x6d, 0xd6, 0xd9, 0xf5,
+	0x91, 0xc3, 0x91, 0x2c, 0x8b, 0x28, 0xf9, 0x8e, 0x12, 0x96, 0xe2, 0x65, 0x16, 0xa6, 0xc9, 0x79,
+	0xe8, 0x19, 0xa0, 0x4c, 0x89, 0x7a, 0x41, 0x86, 0x79, 0x0d, 0xb1, 0xdd, 0x41, 0x2a, 0x39, 0xf3,
+	0x82, 0x14, 0xf7, 0x4e, 0x00, 0x29, 0xe1, 0xda, 0x51, 0x38, 0xf5, 0x5c, 0x9a, 0x8e, 0xb1, 0x8f,
+	0x86, 0xf2, 0x25, 0x33, 0x1c, 0xe5, 0x1a, 0x23, 0xa1, 0x70, 0x8a, 0xed, 0x4b, 0xec, 0x12, 0x73,
+	0xc3, 0x5e, 0x10, 0x50, 0xf4, 0x25, 0x34, 0xe8, 0x0c, 0x27, 0x8e, 0xde, 0x2c, 0x77, 0xed, 0x71,
+	0x82, 0xe9, 0x3c, 0x21, 0x67, 0x5c, 0x66, 0x4a, 0x15, 0xe3, 0xef, 0x1a, 0x74, 0xd5, 0xa1, 0x1a,
+	0x0d, 0x01, 0x82, 0x7c, 0xf6, 0x4d, 0x8f, 0xb7, 0x5f, 0x9e, 0x8a, 0x4d, 0x45, 0xe3, 0xa3, 0x9b,
+	0x90, 0x0a, 0x75, 0xf5, 0x32, 0xd4, 0x19, 0xff, 0xd1, 0x60, 0xa3, 0x32, 0x9d, 0xdc, 0x06, 0x66,
+	0x1f, 0xbb, 0xf0, 0x63, 0xe8, 0x7b, 0xd4, 0x72, 0x88, 0xed, 0xe3, 0x04, 0xf3, 0x74, 0x89, 0x63,
+	0x6d, 0x99, 0x3d, 0x8f, 0x1e, 0x16, 0x4c, 0x1e, 0x5f, 0x9c, 0x78, 0x51, 0x92, 0xc5, 0xd7, 0x33,
+	0x73, 0xba, 0xda, 0xc5, 0x1a, 0xd5, 0x2e, 0x66, 0xfc, 0x1a, 0x5a, 0xd9, 0xf2, 0xbc, 0xd6, 0xbd,
+	0xd0, 0x56, 0x6b, 0xdd, 0x0b, 0x6d, 0x5e, 0xeb, 0xca, 0x25, 0x58, 0x55, 0x2f, 0x81, 0x31, 0x85,
+	0x8d, 0xca, 0x83, 0x05, 0x7d, 0x03, 0x03, 0x4a, 0xfc, 0xa9, 0x98, 0x54, 0x93, 0x40, 0x06, 0xaf,
+	0xed, 0x68, 0x4b, 0xf1, 0x68, 0x9d, 0x6b, 0x1e, 0x15, 0x8a, 0x1c, 0x5c, 0xf8, 0xe4, 0x15, 0xa6,
+	0x20, 0x22, 0x09, 0xe3, 0x02, 0x50, 0xf5, 0x89, 0x83, 0x3e, 0x87, 0x86, 0x78, 0x51, 0xdd, 0xda,
+	0x13, 0xa5, 0x58, 0x80, 0x22, 0xc1, 0xce, 0x1d, 0xa0, 0x48, 0xb0, 0x63, 0xfc, 0x1e, 0xd6, 0xe4,
+	0x1a, 0x3c, 0xa9, 0xa4, 0xf4, 0xe4, 0x34, 0x73, 0xfa, 0x4e, 0x40, 0x5f, 0x3e, 0xb1, 0x18, 0x4d,
+	0x68, 0x88, 0x17, 0x87, 0xf1, 0x07, 0x40, 0xd5, 0xb9, 0x9a, 0x77, 0x4c, 0xca, 0x70, 0xc2, 0xac,
+	0x32, 0xce, 0x74, 0x04, 0xf3, 0x4c, 0x82, 0xcd, 0x67, 0xd0, 0x21, 0xa1, 0x63, 0x95, 0x0f, 0xa1,
+	0x4d, 0x42, 0x47, 0xca, 0x8d, 0x03, 0xd8, 0x5c, 0x32, 0x6d, 0xa3, 0xa7, 0xd0, 0x4a, 0x21, 0x2d,
+	0x9b, 0x1b, 0x2a, 0xd8, 0x99, 0x2b, 0x18, 0xaf, 0x60, 0x6b, 0xd9, 0x04, 0x8b, 0x76, 0x0b, 0x60,
+	0x97, 0x3e, 0xf2, 0x17, 0x52, 0xaa, 0x28, 0xdb, 0x42, 0x8e, 0xf7, 0xc6, 0x3f, 0x35, 0xe8, 0x95,
+	0x44, 0x05, 0x34, 0x69, 0x0a, 0x34, 0xdd, 0x8d, 0x66, 0x9f, 0x01, 0x14, 0x50, 0x91, 0x42, 0x9a,
+	0xc2, 0x41, 0x0f, 0xa1, 0x7d, 0xe1, 0x47, 0xf6, 0x25, 0xcf, 0x89, 0xa8, 0xfc, 0xba, 0xd9, 0x12,
+	0x8c, 0x33, 0x72, 0x85, 0x76, 0xa0, 0xcb, 0x53, 0xe5, 0x85, 0x96, 0x60, 0xa5, 0x85, 0x0f, 0x94,
+	0x5c, 0x1d, 0x85, 0x07, 0x9c, 0x63, 0xbc, 0x86, 0x7b, 0x4b, 0xc7, 0x6d, 0xb4, 0x57, 0x19, 0xb5,
+	0xee, 0x2f, 0x6c, 0x77, 0x2c, 0xc5, 0xca, 0xc0, 0xf5, 0x37, 0x0d, 0xfa, 0x65, 0x21, 0xfa, 0x0a,
+	0xd6, 0x64, 0x3a, 0xd2, 0xca, 0xbf, 0x25, 0x67, 0xa9, 0x92, 0xfa, 0xbb, 0x24, 0x6d, 0x9e, 0x29,
+	0x59, 0xa0, 0x63, 0xed, 0x87, 0xd1, 0xf1, 0x77, 0x79, 0x18, 0x59, 0x6b, 0x79, 0x0c, 0xeb, 0xec,
+	0xda, 0x2a, 0xe5, 0x22, 0x1d, 0x65, 0xd9, 0xf5, 0x59, 0x9e, 0x8d, 0xf2, 0xf2, 0xea, 0xdf, 0x1a,
+	0xe3, 0x0b, 0x58, 0x5f, 0x78, 0x0a, 0xf1, 0x1b, 0x4a, 0x92, 0x24, 0x4a, 0xd2, 0xc3, 0x94, 0x84,
+	0xf1, 0x0e, 0xda, 0xf9, 0x40, 0xcb, 0x7b, 0xa3, 0xd2, 0xc6, 0xc4, 0x37, 0x5f, 0xe3, 0x03, 0x49,
+	0x28, 0x3f, 0x4d, 0x79, 0xd8, 0x19, 0x79, 0xe7, 0x4c, 0xf7, 0x8f, 0x55, 0x40, 0xa2, 0xa6, 0xcf,
+	0x58, 0x42, 0x70, 0x90, 0xd5, 0xa4, 0xf2, 0xef, 0x46, 0x2b, 0xff, 0xbb, 0xa9, 0xdc, 0xa6, 0xd5,
+	0x1f, 0xbc, 0x4d, 0xb5, 0x85, 0xdb, 0x74, 0x17, 0xe8, 0x57, 0xe7, 0xdb, 0x46, 0x65, 0xbe, 0xe5,
+	0xf6, 0x76, 0x14, 0xc4, 0x09, 0xa1, 0xb2, 0x2f, 0xb6, 0xcc, 0x9c, 0x2e, 0x86, 0xa7, 0xa6, 0x3a,
+	0x3c, 0xbd, 0x50, 0xbb, 0x43, 0x2b, 0x7f, 0xd3, 0xf1, 0x7f, 0x7f, 0xc3, 0xec, 0xdf, 0xdf, 0xf0,
+	0x3c, 0xd3, 0x50, 0xfa, 0x84, 0xf1, 0x1a, 0x36, 0x4b, 0xf9, 0x49, 0xcb, 0x78, 0x0b, 0x1a, 0x17,
+	0x98, 0xd9, 0xb3, 0xac, 0x09, 0x09, 0x42, 0x5e, 0x2a, 0x19, 0x08, 0x71, 0xd2, 0x07, 0x8b, 0xc2,
+	0x31, 0xfe, 0x02, 0x5d, 0xb5, 0xae, 0xb8, 0x17, 0x2f, 0x74, 0xc8, 0xb5, 0xf0, 0xd2, 0x33, 0x25,
+	0xc1, 0xdf, 0x11, 0xe2, 0x6f, 0x8c, 0x28, 0x3a, 0x2a, 0xdc, 0xf4, 0xe4, 0x7f, 0x17, 0x61, 0x25,
+	0x9e, 0x4f, 0x31, 0xe6, 0x2d, 0x28, 0x53, 0xa9, 0x09, 0x95, 0xae, 0x64, 0xa6, 0x4a, 0x08, 0xea,
+	0xd4, 0xfb, 0x33, 0x49, 0xef, 0xae, 0xf8, 0xfe, 0xf2, 0x37, 0xd0, 0x51, 0x26, 0xc2, 0xc5, 0x47,
+	0x6a, 0x0f, 0xda, 0x07, 0x6f, 0xde, 0x8e, 0x5e, 0x5b, 0xc7, 0x67, 0xaf, 0x06, 0x1a, 0x7f, 0x8b,
+	0x1e, 0x1d, 0x8e, 0x4f, 0xce, 0x8f, 0xce, 0xdf, 0x0b, 0xce, 0xea, 0xde, 0x9f, 0x60, 0x4d, 0x4e,
+	0xe4, 0xe8, 0x05, 0x74, 0xe5, 0x97, 0x4c, 0x0b, 0xaa, 0x60, 0xfe, 0x76, 0x85, 0x63, 0xac, 0x3c,
+	0xd1, 0x9e, 0x6b, 0xe8, 0x73, 0xa8, 0x9f, 0x7a, 0xa1, 0x8b, 0xca, 0x3f, 0x8b, 0xb6, 0xcb, 0xa4,
+	0xb1, 0xb2, 0x77, 0x0a, 0x3d, 0x91, 0xf7, 0xf3, 0x04, 0x87, 0x74, 0x4a, 0x12, 0xfe, 0x32, 0x97,
+	0x8b, 0x89, 0x2b, 0x45, 0x97, 0x2c, 0xf9, 0xb0, 0xf4, 0x97, 0xa6, 0x7c, 0x60, 0xcf, 0xb5, 0x83,
+	0xaf, 0xfe, 0xf8, 0xd4, 0xf5, 0xd8, 0x6c, 0x7e, 0xc1, 0x67, 0xa8, 0xdd, 0xd9, 0x4d, 0x4c, 0x12,
+	0xd9, 0xa9, 0x77, 0xa7, 0xf8, 0x22, 0xf1, 0x6c, 0xf9, 0x13, 0x98, 0xee, 0x4a, 0x27, 0x17, 0x6b,
+	0x82, 0xfc, 0xfa, 0xbf, 0x03, 0x00, 0x46, 0x58, 0x18, 0xbc, 0x5a, 0x16, 0x00, 0x00,
 }
@@ -48,7 +48,7 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 0}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{5, 0}
 }
 
 // SeekErrorTolerance indicates to the server how block provider errors should be tolerated.  By default,
@@ -79,7 +79,35 @@ func (x SeekInfo_SeekErrorResponse) String() string {
 	return proto.EnumName(SeekInfo_SeekErrorResponse_name, int32(x))
 }
 func (SeekInfo_SeekErrorResponse) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5, 1}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{5, 1}
+}
+
+// SeekContentType indicates what type of content to deliver in response to a request. If BLOCK is specified,
+// the orderer will stream blocks back to the peer. This is the default behavior. If HEADER_WITH_SIG is
+// specified, the orderer will stream only the header and the signature metadata of the blocks, and the
+// data of config blocks. This allows clients that only follow the chain, such as auditors, to verify it
+// at a fraction of the bandwidth.
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK           SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_SIG SeekInfo_SeekContentType = 1
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_SIG",
+}
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":           0,
+	"HEADER_WITH_SIG": 1,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{5, 2}
 }
 
 type BroadcastResponse struct {
@@ -96,7 +124,7 @@ func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{0}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -140,7 +168,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{1}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -170,7 +198,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{2}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -201,7 +229,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{3}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -243,7 +271,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{4}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -411,20 +439,27 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // Otherwise, blocks are returned until a missing block is encountered, then behavior is dictated
 // by the SeekBehavior specified.
 type SeekInfo struct {
-	Start                *SeekPosition              `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	Stop                 *SeekPosition              `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
-	Behavior             SeekInfo_SeekBehavior      `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
-	ErrorResponse        SeekInfo_SeekErrorResponse `protobuf:"varint,4,opt,name=error_response,json=errorResponse,proto3,enum=orderer.SeekInfo_SeekErrorResponse" json:"error_response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Start         *SeekPosition              `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop          *SeekPosition              `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Behavior      SeekInfo_SeekBehavior      `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	ErrorResponse SeekInfo_SeekErrorResponse `protobuf:"varint,4,opt,name=error_response,json=errorResponse,proto3,enum=orderer.SeekInfo_SeekErrorResponse" json:"error_response,omitempty"`
+	ContentType   SeekInfo_SeekContentType   `protobuf:"varint,5,opt,name=content_type,json=contentType,proto3,enum=orderer.SeekInfo_SeekContentType" json:"content_type,omitempty"`
+	// Restricts the chaincode events delivered by the deliver service of a peer to the events matching one
+	// of the filters. Filtered blocks are always delivered, but only carry the transactions with a matching
+	// event, stripped of their other events. Full blocks are only delivered when they contain a matching
+	// event. Deliver services which do not support event filtering, such as the one of the orderer, reject
+	// the request.
+	ChaincodeEventFilters []*ChaincodeEventFilter `protobuf:"bytes,6,rep,name=chaincode_event_filters,json=chaincodeEventFilters,proto3" json:"chaincode_event_filters,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                `json:"-"`
+	XXX_unrecognized      []byte                  `json:"-"`
+	XXX_sizecache         int32                   `json:"-"`
 }
 
 func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{5}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -472,6 +507,68 @@ func (m *SeekInfo) GetErrorResponse() SeekInfo_SeekErrorResponse {
 	return SeekInfo_STRICT
 }
 
+func (m *SeekInfo) GetContentType() SeekInfo_SeekContentType {
+	if m != nil {
+		return m.ContentType
+	}
+	return SeekInfo_BLOCK
+}
+
+func (m *SeekInfo) GetChaincodeEventFilters() []*ChaincodeEventFilter {
+	if m != nil {
+		return m.ChaincodeEventFilters
+	}
+	return nil
+}
+
+// ChaincodeEventFilter selects the events of a chaincode. The event name pattern is a regular expression
+// which must match the whole name of an event; an empty pattern selects all the events of the chaincode.
+type ChaincodeEventFilter struct {
+	ChaincodeName        string   `protobuf:"bytes,1,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	EventNamePattern     string   `protobuf:"bytes,2,opt,name=event_name_pattern,json=eventNamePattern,proto3" json:"event_name_pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventFilter) Reset()         { *m = ChaincodeEventFilter{} }
+func (m *ChaincodeEventFilter) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventFilter) ProtoMessage()    {}
+func (*ChaincodeEventFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{6}
+}
+func (m *ChaincodeEventFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventFilter.Unmarshal(m, b)
+}
+func (m *ChaincodeEventFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventFilter.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventFilter.Merge(dst, src)
+}
+func (m *ChaincodeEventFilter) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventFilter.Size(m)
+}
+func (m *ChaincodeEventFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventFilter proto.InternalMessageInfo
+
+func (m *ChaincodeEventFilter) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *ChaincodeEventFilter) GetEventNamePattern() string {
+	if m != nil {
+		return m.EventNamePattern
+	}
+	return ""
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
@@ -486,7 +583,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_237e854d47750248, []int{6}
+	return fileDescriptor_ab_28d8c8081f4fa3cc, []int{7}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -619,9 +716,11 @@ func init() {
 	proto.RegisterType((*SeekSpecified)(nil), "orderer.SeekSpecified")
 	proto.RegisterType((*SeekPosition)(nil), "orderer.SeekPosition")
 	proto.RegisterType((*SeekInfo)(nil), "orderer.SeekInfo")
+	proto.RegisterType((*ChaincodeEventFilter)(nil), "orderer.ChaincodeEventFilter")
 	proto.RegisterType((*DeliverResponse)(nil), "orderer.DeliverResponse")
 	proto.RegisterEnum("orderer.SeekInfo_SeekBehavior", SeekInfo_SeekBehavior_name, SeekInfo_SeekBehavior_value)
 	proto.RegisterEnum("orderer.SeekInfo_SeekErrorResponse", SeekInfo_SeekErrorResponse_name, SeekInfo_SeekErrorResponse_value)
+	proto.RegisterEnum("orderer.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -797,43 +896,52 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_237e854d47750248) }
-
-var fileDescriptor_ab_237e854d47750248 = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0xc7, 0x93, 0xd1, 0x65, 0xdb, 0xd9, 0xfa, 0xe5, 0x69, 0x53, 0xd5, 0x0b, 0x34, 0x05, 0x0d,
-	0x8a, 0x80, 0x64, 0x2a, 0x12, 0x17, 0x80, 0x84, 0x9a, 0xad, 0x55, 0x0b, 0x55, 0x8b, 0xdc, 0xec,
-	0x02, 0x6e, 0xa2, 0x24, 0x75, 0xdb, 0xb0, 0x36, 0x8e, 0x9c, 0xb4, 0x68, 0x4f, 0xc1, 0x8b, 0xf0,
-	0x44, 0x3c, 0x0d, 0xf2, 0x47, 0xda, 0x75, 0x1b, 0x5c, 0x25, 0xe7, 0xf8, 0x77, 0xce, 0xff, 0x6f,
-	0x1f, 0x1b, 0x2a, 0x94, 0x8d, 0x09, 0x23, 0xcc, 0xf6, 0x03, 0x2b, 0x61, 0x34, 0xa3, 0x68, 0x4f,
-	0x65, 0xea, 0xc7, 0x21, 0x5d, 0x2c, 0x68, 0x6c, 0xcb, 0x8f, 0x5c, 0x35, 0x87, 0x50, 0x75, 0x18,
-	0xf5, 0xc7, 0xa1, 0x9f, 0x66, 0x98, 0xa4, 0x09, 0x8d, 0x53, 0x82, 0x9e, 0x83, 0x91, 0x66, 0x7e,
-	0xb6, 0x4c, 0x6b, 0xfa, 0x99, 0xde, 0x28, 0x35, 0x4b, 0x96, 0xaa, 0x19, 0x89, 0x2c, 0x56, 0xab,
-	0x08, 0x41, 0x21, 0x8a, 0x27, 0xb4, 0xb6, 0x73, 0xa6, 0x37, 0x0e, 0xb0, 0xf8, 0x37, 0x8f, 0x00,
-	0x46, 0x84, 0xdc, 0x0c, 0xc8, 0x4f, 0x92, 0x66, 0x79, 0x34, 0x9c, 0x8f, 0x79, 0xf4, 0x02, 0x8a,
-	0x3c, 0x1a, 0x25, 0x24, 0x8c, 0x26, 0x11, 0x19, 0xa3, 0x53, 0x30, 0xe2, 0xe5, 0x22, 0x20, 0x4c,
-	0x08, 0x15, 0xb0, 0x8a, 0xcc, 0xdf, 0x3a, 0x1c, 0x71, 0xf2, 0x2b, 0x4d, 0xa3, 0x2c, 0xa2, 0x31,
-	0x7a, 0x03, 0x46, 0x2c, 0x3a, 0x0a, 0xf0, 0xb0, 0x79, 0x6c, 0xa9, 0x5d, 0x59, 0x1b, 0xb1, 0xae,
-	0x86, 0x15, 0xc4, 0x71, 0x2a, 0x24, 0x85, 0xb5, 0xfb, 0xb8, 0x74, 0xc3, 0x71, 0x09, 0xa1, 0x77,
-	0x70, 0x90, 0xe6, 0x9e, 0x6a, 0x4f, 0x44, 0xc5, 0xe9, 0x56, 0xc5, 0xda, 0x71, 0x57, 0xc3, 0x1b,
-	0xd4, 0x31, 0xa0, 0xe0, 0xde, 0x26, 0xc4, 0xfc, 0xb3, 0x03, 0xfb, 0x1c, 0xeb, 0xc5, 0x13, 0x8a,
-	0x5e, 0xc1, 0x6e, 0x9a, 0xf9, 0x2c, 0x77, 0x7a, 0xb2, 0xd5, 0x28, 0xdf, 0x10, 0x96, 0x0c, 0x7a,
-	0x09, 0x85, 0x34, 0xa3, 0x89, 0xb2, 0xf9, 0x0f, 0x56, 0x20, 0xe8, 0x3d, 0xec, 0x07, 0x64, 0xe6,
-	0xaf, 0x22, 0xca, 0x84, 0xc7, 0x52, 0xf3, 0xe9, 0x16, 0xce, 0xc5, 0xc5, 0x8f, 0xa3, 0x28, 0xbc,
-	0xe6, 0xd1, 0x67, 0x28, 0x11, 0xc6, 0x28, 0xf3, 0x98, 0x1a, 0x71, 0xad, 0x20, 0x3a, 0x3c, 0x7b,
-	0xbc, 0x43, 0x9b, 0xb3, 0xf9, 0x6d, 0xc0, 0x45, 0x72, 0x37, 0x34, 0x3f, 0xca, 0xd1, 0xe4, 0x2a,
-	0xe8, 0x04, 0xaa, 0x4e, 0x7f, 0x78, 0xf9, 0xc5, 0xbb, 0x1e, 0xb8, 0xbd, 0xbe, 0x87, 0xdb, 0xad,
-	0xab, 0x6f, 0x15, 0x8d, 0xa7, 0x3b, 0xad, 0x5e, 0xdf, 0xeb, 0x75, 0xbc, 0xc1, 0xd0, 0x55, 0x69,
-	0xdd, 0xbc, 0x80, 0xea, 0x03, 0x05, 0x04, 0x60, 0x8c, 0x5c, 0xdc, 0xbb, 0x74, 0x2b, 0x1a, 0x2a,
-	0xc3, 0xa1, 0xd3, 0x1e, 0xb9, 0x5e, 0xbb, 0xd3, 0x19, 0x62, 0xb7, 0xa2, 0x9b, 0x3f, 0xa0, 0x7c,
-	0x45, 0xe6, 0xd1, 0x8a, 0x6c, 0xf8, 0xc6, 0xff, 0xef, 0x27, 0x9f, 0xac, 0xba, 0xa1, 0xe7, 0xb0,
-	0x1b, 0xcc, 0x69, 0x78, 0xa3, 0x0e, 0xb8, 0x98, 0x83, 0x0e, 0x4f, 0x76, 0x35, 0x2c, 0x57, 0xf3,
-	0x41, 0x36, 0x7f, 0xe9, 0x50, 0x6e, 0x65, 0x74, 0x11, 0x85, 0xeb, 0x47, 0x81, 0x3e, 0xc1, 0xc1,
-	0x26, 0xa8, 0xe4, 0x0d, 0xda, 0xf1, 0x8a, 0xcc, 0x69, 0x42, 0xea, 0xf5, 0xf5, 0x11, 0x3e, 0x78,
-	0x47, 0xa6, 0xd6, 0xd0, 0x2f, 0x74, 0xf4, 0x01, 0xf6, 0xd4, 0x06, 0x1e, 0x29, 0xaf, 0xad, 0xcb,
-	0xef, 0x6d, 0x52, 0x16, 0x3b, 0xd7, 0x70, 0x4e, 0xd9, 0xd4, 0x9a, 0xdd, 0x26, 0x84, 0xcd, 0xc9,
-	0x78, 0x4a, 0x98, 0x35, 0xf1, 0x03, 0x16, 0x85, 0xf2, 0xfd, 0xa6, 0x79, 0xf9, 0xf7, 0xd7, 0xd3,
-	0x28, 0x9b, 0x2d, 0x03, 0x2e, 0x60, 0xdf, 0xa1, 0x6d, 0x49, 0xdb, 0x92, 0xb6, 0x15, 0x1d, 0x18,
-	0x22, 0x7e, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0x48, 0x6c, 0xc9, 0x45, 0x2f, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_28d8c8081f4fa3cc) }
+
+var fileDescriptor_ab_28d8c8081f4fa3cc = []byte{
+	// 704 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x94, 0x6d, 0x6f, 0xe2, 0x46,
+	0x10, 0xc7, 0x71, 0x02, 0x24, 0x0c, 0x4f, 0xce, 0xa6, 0x49, 0xad, 0x48, 0xad, 0x52, 0x57, 0x69,
+	0xa9, 0x9a, 0x42, 0x4a, 0xa5, 0xbe, 0x68, 0x4f, 0x3a, 0xf1, 0x60, 0x0e, 0xee, 0x22, 0x88, 0x16,
+	0x47, 0xa7, 0xbb, 0x37, 0x96, 0x31, 0x43, 0xf0, 0x05, 0xbc, 0xd6, 0x7a, 0xc3, 0x29, 0x9f, 0xe2,
+	0xbe, 0xc7, 0xe9, 0x3e, 0xe4, 0x69, 0xd7, 0x36, 0xe4, 0x01, 0xe5, 0x95, 0x3d, 0xb3, 0xbf, 0xf9,
+	0xcf, 0xcc, 0xee, 0xce, 0x82, 0xce, 0xf8, 0x14, 0x39, 0xf2, 0x86, 0x3b, 0xa9, 0x87, 0x9c, 0x09,
+	0x46, 0xf6, 0x12, 0xcf, 0xc9, 0xa1, 0xc7, 0x96, 0x4b, 0x16, 0x34, 0xe2, 0x4f, 0xbc, 0x6a, 0x8e,
+	0xe0, 0xa0, 0xcd, 0x99, 0x3b, 0xf5, 0xdc, 0x48, 0x50, 0x8c, 0x42, 0x16, 0x44, 0x48, 0x7e, 0x83,
+	0x7c, 0x24, 0x5c, 0x71, 0x17, 0x19, 0xda, 0xa9, 0x56, 0xab, 0x34, 0x2b, 0xf5, 0x24, 0x66, 0xac,
+	0xbc, 0x34, 0x59, 0x25, 0x04, 0xb2, 0x7e, 0x30, 0x63, 0xc6, 0xce, 0xa9, 0x56, 0x2b, 0x50, 0xf5,
+	0x6f, 0x96, 0x00, 0xc6, 0x88, 0xb7, 0x43, 0xfc, 0x8c, 0x91, 0x48, 0xad, 0xd1, 0x62, 0x2a, 0xad,
+	0xdf, 0xa1, 0x2c, 0xad, 0x71, 0x88, 0x9e, 0x3f, 0xf3, 0x71, 0x4a, 0x8e, 0x21, 0x1f, 0xdc, 0x2d,
+	0x27, 0xc8, 0x55, 0xa2, 0x2c, 0x4d, 0x2c, 0xf3, 0x9b, 0x06, 0x25, 0x49, 0x5e, 0xb1, 0xc8, 0x17,
+	0x3e, 0x0b, 0xc8, 0x5f, 0x90, 0x0f, 0x94, 0xa2, 0x02, 0x8b, 0xcd, 0xc3, 0x7a, 0xd2, 0x55, 0x7d,
+	0x93, 0xac, 0x9f, 0xa1, 0x09, 0x24, 0x71, 0xa6, 0x52, 0x1a, 0x3b, 0x5b, 0xf0, 0xb8, 0x1a, 0x89,
+	0xc7, 0x10, 0xf9, 0x17, 0x0a, 0x51, 0x5a, 0x93, 0xb1, 0xab, 0x22, 0x8e, 0x1f, 0x45, 0xac, 0x2b,
+	0xee, 0x67, 0xe8, 0x06, 0x6d, 0xe7, 0x21, 0x6b, 0xdf, 0x87, 0x68, 0x7e, 0xcd, 0xc2, 0xbe, 0xc4,
+	0x06, 0xc1, 0x8c, 0x91, 0x3f, 0x21, 0x17, 0x09, 0x97, 0xa7, 0x95, 0x1e, 0x3d, 0x12, 0x4a, 0x1b,
+	0xa2, 0x31, 0x43, 0xfe, 0x80, 0x6c, 0x24, 0x58, 0x68, 0xec, 0xbc, 0xc4, 0x2a, 0x84, 0xfc, 0x07,
+	0xfb, 0x13, 0x9c, 0xbb, 0x2b, 0x9f, 0x71, 0x55, 0x63, 0xa5, 0xf9, 0xf3, 0x23, 0x5c, 0x26, 0x57,
+	0x3f, 0xed, 0x84, 0xa2, 0x6b, 0x9e, 0xbc, 0x85, 0x0a, 0x72, 0xce, 0xb8, 0xc3, 0x93, 0x23, 0x36,
+	0xb2, 0x4a, 0xe1, 0xd7, 0xed, 0x0a, 0x96, 0x64, 0xd3, 0xdb, 0x40, 0xcb, 0xf8, 0xd0, 0x24, 0x5d,
+	0x28, 0x79, 0x2c, 0x10, 0x18, 0x08, 0x47, 0xdc, 0x87, 0x68, 0xe4, 0x94, 0xd2, 0x2f, 0xdb, 0x95,
+	0x3a, 0x31, 0x29, 0x77, 0x89, 0x16, 0xbd, 0x8d, 0x41, 0xae, 0xe1, 0x47, 0x6f, 0xee, 0xfa, 0x81,
+	0xc7, 0xa6, 0xe8, 0xe0, 0x4a, 0xaa, 0xcd, 0xfc, 0x85, 0x40, 0x1e, 0x19, 0xf9, 0xd3, 0xdd, 0x5a,
+	0xb1, 0xf9, 0xd3, 0x5a, 0xb0, 0x93, 0x72, 0x96, 0xc4, 0x7a, 0x8a, 0xa2, 0x47, 0xde, 0x16, 0x6f,
+	0x64, 0xbe, 0x82, 0xd2, 0xc3, 0x2d, 0x20, 0x47, 0x70, 0xd0, 0xbe, 0x1c, 0x75, 0xde, 0x39, 0xd7,
+	0x43, 0x7b, 0x70, 0xe9, 0x50, 0xab, 0xd5, 0xfd, 0xa0, 0x67, 0xa4, 0xbb, 0xd7, 0x1a, 0x5c, 0x3a,
+	0x83, 0x9e, 0x33, 0x1c, 0xd9, 0x89, 0x5b, 0x33, 0x2f, 0xe0, 0xe0, 0x59, 0xfb, 0x04, 0x20, 0x3f,
+	0xb6, 0xe9, 0xa0, 0x63, 0xeb, 0x19, 0x52, 0x85, 0x62, 0xdb, 0x1a, 0xdb, 0x8e, 0xd5, 0xeb, 0x8d,
+	0xa8, 0xad, 0x6b, 0xe6, 0xdf, 0x50, 0x7d, 0xd2, 0x26, 0x29, 0x40, 0x4e, 0xa5, 0xd4, 0x33, 0xe4,
+	0x10, 0xaa, 0x7d, 0xab, 0xd5, 0xb5, 0xa8, 0xf3, 0x7e, 0x60, 0xf7, 0x9d, 0xf1, 0xe0, 0x8d, 0xae,
+	0x99, 0xb7, 0xf0, 0xc3, 0xb6, 0x8e, 0xc8, 0x19, 0x54, 0x36, 0x3b, 0x12, 0xb8, 0x4b, 0x54, 0x17,
+	0xa8, 0x40, 0xcb, 0x6b, 0xef, 0xd0, 0x5d, 0x22, 0x39, 0x07, 0x12, 0x6f, 0x97, 0x44, 0x9c, 0xd0,
+	0x15, 0x02, 0x79, 0x90, 0x4c, 0xa0, 0xae, 0x56, 0x24, 0x76, 0x15, 0xfb, 0xcd, 0x4f, 0x50, 0xed,
+	0xe2, 0xc2, 0x5f, 0xe1, 0xa6, 0x9f, 0xda, 0xcb, 0xc3, 0x2d, 0xc7, 0x22, 0x19, 0xef, 0x33, 0xc8,
+	0x4d, 0x16, 0xcc, 0xbb, 0x4d, 0x6e, 0x67, 0x39, 0x05, 0xdb, 0xd2, 0xd9, 0xcf, 0xd0, 0x78, 0x35,
+	0x9d, 0x82, 0xe6, 0x17, 0x0d, 0xaa, 0x2d, 0xc1, 0x96, 0xbe, 0xb7, 0x7e, 0x51, 0xc8, 0x6b, 0x28,
+	0x6c, 0x0c, 0x3d, 0x15, 0xb0, 0x82, 0x15, 0x2e, 0x58, 0x88, 0x27, 0x27, 0xeb, 0x43, 0x7e, 0xf6,
+	0x08, 0x99, 0x99, 0x9a, 0x76, 0xa1, 0x91, 0xff, 0x61, 0x2f, 0x69, 0x60, 0x4b, 0xb8, 0xb1, 0x0e,
+	0x7f, 0xd2, 0x64, 0x1c, 0xdc, 0xbe, 0x86, 0x33, 0xc6, 0x6f, 0xea, 0xf3, 0xfb, 0x10, 0xf9, 0x02,
+	0xa7, 0x37, 0xc8, 0xeb, 0x33, 0x77, 0xc2, 0x7d, 0x2f, 0x7e, 0xfc, 0xa2, 0x34, 0xfc, 0xe3, 0xf9,
+	0x8d, 0x2f, 0xe6, 0x77, 0x13, 0x99, 0xa0, 0xf1, 0x80, 0x6e, 0xc4, 0x74, 0x23, 0xa6, 0x1b, 0x09,
+	0x3d, 0xc9, 0x2b, 0xfb, 0x9f, 0xef, 0x03, 0x00, 0x78, 0x48, 0x9b, 0x91, 0x6c, 0x05, 0x00, 0x00,
 }
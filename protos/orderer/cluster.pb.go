@@ -39,7 +39,7 @@ func (m *StepRequest) Reset()         { *m = StepRequest{} }
 func (m *StepRequest) String() string { return proto.CompactTextString(m) }
 func (*StepRequest) ProtoMessage()    {}
 func (*StepRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cluster_d9833ceca5c79414, []int{0}
+	return fileDescriptor_cluster_f9016120deff9cf7, []int{0}
 }
 func (m *StepRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StepRequest.Unmarshal(m, b)
@@ -184,7 +184,7 @@ func (m *StepResponse) Reset()         { *m = StepResponse{} }
 func (m *StepResponse) String() string { return proto.CompactTextString(m) }
 func (*StepResponse) ProtoMessage()    {}
 func (*StepResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cluster_d9833ceca5c79414, []int{1}
+	return fileDescriptor_cluster_f9016120deff9cf7, []int{1}
 }
 func (m *StepResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StepResponse.Unmarshal(m, b)
@@ -285,8 +285,11 @@ func _StepResponse_OneofSizer(msg proto.Message) (n int) {
 
 // ConsensusRequest is a consensus specific message sent to a cluster member.
 type ConsensusRequest struct {
-	Channel              string   `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
-	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	// metadata is consensus specific data which is exchanged
+	// by cluster members besides the payload.
+	Metadata             []byte   `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -296,7 +299,7 @@ func (m *ConsensusRequest) Reset()         { *m = ConsensusRequest{} }
 func (m *ConsensusRequest) String() string { return proto.CompactTextString(m) }
 func (*ConsensusRequest) ProtoMessage()    {}
 func (*ConsensusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cluster_d9833ceca5c79414, []int{2}
+	return fileDescriptor_cluster_f9016120deff9cf7, []int{2}
 }
 func (m *ConsensusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusRequest.Unmarshal(m, b)
@@ -330,6 +333,13 @@ func (m *ConsensusRequest) GetPayload() []byte {
 	return nil
 }
 
+func (m *ConsensusRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // SubmitRequest wraps a transaction to be sent for ordering.
 type SubmitRequest struct {
 	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
@@ -349,7 +359,7 @@ func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
 func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitRequest) ProtoMessage()    {}
 func (*SubmitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cluster_d9833ceca5c79414, []int{3}
+	return fileDescriptor_cluster_f9016120deff9cf7, []int{3}
 }
 func (m *SubmitRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitRequest.Unmarshal(m, b)
@@ -407,7 +417,7 @@ func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
 func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitResponse) ProtoMessage()    {}
 func (*SubmitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cluster_d9833ceca5c79414, []int{4}
+	return fileDescriptor_cluster_f9016120deff9cf7, []int{4}
 }
 func (m *SubmitResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitResponse.Unmarshal(m, b)
@@ -562,33 +572,34 @@ var _Cluster_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/cluster.proto",
 }
 
-func init() { proto.RegisterFile("orderer/cluster.proto", fileDescriptor_cluster_d9833ceca5c79414) }
-
-var fileDescriptor_cluster_d9833ceca5c79414 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xd1, 0xee, 0xd2, 0x30,
-	0x14, 0xc6, 0xff, 0x53, 0xc2, 0xb2, 0x03, 0x2c, 0x50, 0x44, 0x91, 0x2b, 0x43, 0xa2, 0x21, 0xc6,
-	0x6c, 0x06, 0x2f, 0xf4, 0xce, 0x04, 0xa2, 0xe1, 0xba, 0x8b, 0x5e, 0x78, 0x43, 0xba, 0xad, 0xc0,
-	0x92, 0xd1, 0x8e, 0xb6, 0x23, 0xe1, 0x01, 0x7c, 0x12, 0x5f, 0xd4, 0xac, 0xed, 0x36, 0xe0, 0x9f,
-	0x70, 0x05, 0x3d, 0xdf, 0xd7, 0xdf, 0xf9, 0xce, 0x7a, 0x60, 0xc2, 0x45, 0x4a, 0x05, 0x15, 0x61,
-	0x92, 0x97, 0x52, 0x51, 0x11, 0x14, 0x82, 0x2b, 0x8e, 0x5c, 0x5b, 0x9e, 0x8d, 0x13, 0x7e, 0x3c,
-	0x72, 0x16, 0x9a, 0x1f, 0xa3, 0xce, 0xff, 0x39, 0xd0, 0x8b, 0x14, 0x2d, 0x30, 0x3d, 0x95, 0x54,
-	0x2a, 0xb4, 0x81, 0x51, 0xc2, 0x99, 0xa4, 0x4c, 0x96, 0x72, 0x2b, 0x4c, 0x71, 0xea, 0xbc, 0x73,
-	0x16, 0xbd, 0xe5, 0xdb, 0xc0, 0x92, 0x82, 0x75, 0xed, 0xb0, 0xb7, 0x36, 0x4f, 0x78, 0x98, 0xdc,
-	0xd5, 0xd0, 0x77, 0xf0, 0x65, 0x19, 0x1f, 0x33, 0xd5, 0x60, 0x5e, 0x68, 0xcc, 0xeb, 0x06, 0x13,
-	0x69, 0xb9, 0x65, 0x0c, 0xe4, 0x75, 0x61, 0xe5, 0x81, 0x5b, 0x90, 0x4b, 0xce, 0x49, 0x3a, 0x8f,
-	0xa0, 0x6f, 0x42, 0xca, 0xa2, 0x6a, 0x83, 0xbe, 0x01, 0x34, 0x6c, 0x69, 0xe3, 0xbd, 0x79, 0xc6,
-	0x35, 0xe6, 0xcd, 0x13, 0xf6, 0x6a, 0xb0, 0xbc, 0x86, 0xfe, 0x84, 0xe1, 0xfd, 0x20, 0x68, 0x0a,
-	0x6e, 0x72, 0x20, 0x8c, 0xd1, 0x5c, 0x53, 0x3d, 0x5c, 0x1f, 0x2b, 0xc5, 0x5e, 0xd4, 0x73, 0xf4,
-	0x71, 0xc3, 0xf9, 0xeb, 0xc0, 0xe0, 0x66, 0x94, 0x07, 0x94, 0x00, 0xc6, 0x39, 0x91, 0x6a, 0x7b,
-	0x26, 0x79, 0x96, 0x12, 0x95, 0x71, 0xb6, 0x95, 0xf4, 0xa4, 0x89, 0x1d, 0x3c, 0xaa, 0xa4, 0xdf,
-	0x8d, 0x12, 0xd1, 0x13, 0xfa, 0xd8, 0x76, 0x7d, 0xa9, 0xa7, 0x1c, 0x06, 0xf6, 0xf9, 0x7e, 0xb0,
-	0x33, 0xcd, 0x79, 0x41, 0xdb, 0x1c, 0x3b, 0xf0, 0x6f, 0x27, 0x7f, 0x90, 0xe3, 0x03, 0x74, 0xa5,
-	0x22, 0xaa, 0x94, 0xba, 0xb5, 0xbf, 0xf4, 0x6b, 0x6c, 0xa4, 0xab, 0xd8, 0xaa, 0x08, 0x41, 0x27,
-	0x63, 0x3b, 0xae, 0x9b, 0x7b, 0x58, 0xff, 0x5f, 0xae, 0xc0, 0x5d, 0x9b, 0x0d, 0x43, 0x5f, 0xa1,
-	0x53, 0xbd, 0x0b, 0x7a, 0xd5, 0x7e, 0xfb, 0x76, 0x97, 0x66, 0x93, 0xbb, 0xaa, 0x49, 0xb5, 0x70,
-	0x3e, 0x3b, 0xab, 0x5f, 0xf0, 0x9e, 0x8b, 0x7d, 0x70, 0xb8, 0x14, 0x54, 0xe4, 0x34, 0xdd, 0x53,
-	0x11, 0xec, 0x48, 0x2c, 0xb2, 0xc4, 0xac, 0xa5, 0xac, 0x6f, 0xfe, 0xf9, 0xb4, 0xcf, 0xd4, 0xa1,
-	0x8c, 0xab, 0x78, 0xe1, 0x95, 0x3b, 0x34, 0xee, 0xd0, 0xb8, 0x43, 0xeb, 0x8e, 0xbb, 0xfa, 0xfc,
-	0xe5, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcc, 0xe7, 0xa4, 0xe2, 0x0b, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/cluster.proto", fileDescriptor_cluster_f9016120deff9cf7) }
+
+var fileDescriptor_cluster_f9016120deff9cf7 = []byte{
+	// 413 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x4d, 0x8f, 0xd3, 0x30,
+	0x10, 0xdd, 0xb0, 0xd5, 0x86, 0xcc, 0xee, 0x46, 0x5d, 0x2f, 0x0b, 0xa1, 0x27, 0x14, 0x09, 0xb4,
+	0x42, 0x28, 0x41, 0xe5, 0x00, 0x37, 0xa4, 0xae, 0x90, 0x7a, 0x76, 0x04, 0x07, 0x2e, 0x95, 0x93,
+	0x4c, 0xdb, 0x48, 0x89, 0x9d, 0xda, 0xce, 0x4a, 0xfd, 0x01, 0xfc, 0x12, 0xfe, 0x28, 0x8a, 0x9d,
+	0x8f, 0xb6, 0x48, 0x3d, 0x25, 0xf3, 0xde, 0xf3, 0x9b, 0x67, 0xcf, 0xc0, 0x83, 0x90, 0x39, 0x4a,
+	0x94, 0x71, 0x56, 0x36, 0x4a, 0xa3, 0x8c, 0x6a, 0x29, 0xb4, 0x20, 0x6e, 0x07, 0xcf, 0xee, 0x33,
+	0x51, 0x55, 0x82, 0xc7, 0xf6, 0x63, 0xd9, 0xf0, 0xaf, 0x03, 0xd7, 0x89, 0xc6, 0x9a, 0xe2, 0xae,
+	0x41, 0xa5, 0xc9, 0x12, 0xee, 0x32, 0xc1, 0x15, 0x72, 0xd5, 0xa8, 0x95, 0xb4, 0x60, 0xe0, 0xbc,
+	0x73, 0x1e, 0xaf, 0xe7, 0x6f, 0xa3, 0xce, 0x29, 0x7a, 0xea, 0x15, 0xdd, 0xa9, 0xe5, 0x05, 0x9d,
+	0x66, 0x27, 0x18, 0xf9, 0x0e, 0xbe, 0x6a, 0xd2, 0xaa, 0xd0, 0x83, 0xcd, 0x0b, 0x63, 0xf3, 0x7a,
+	0xb0, 0x49, 0x0c, 0x3d, 0x7a, 0xdc, 0xaa, 0x43, 0x60, 0xe1, 0x81, 0x5b, 0xb3, 0x7d, 0x29, 0x58,
+	0x1e, 0x26, 0x70, 0x63, 0x43, 0xaa, 0xba, 0x6d, 0x43, 0xbe, 0x01, 0x0c, 0xde, 0xaa, 0x8b, 0xf7,
+	0xe6, 0x3f, 0x5f, 0x2b, 0x5e, 0x5e, 0x50, 0xaf, 0x37, 0x56, 0x87, 0xa6, 0x29, 0x4c, 0x4f, 0x2f,
+	0x42, 0x02, 0x70, 0xb3, 0x2d, 0xe3, 0x1c, 0x4b, 0xe3, 0xea, 0xd1, 0xbe, 0x24, 0xc1, 0x70, 0xd0,
+	0xdc, 0xe3, 0x86, 0xf6, 0x25, 0x99, 0xc1, 0xcb, 0x0a, 0x35, 0xcb, 0x99, 0x66, 0xc1, 0xa5, 0xa1,
+	0x86, 0x3a, 0xfc, 0xe3, 0xc0, 0xed, 0xd1, 0x35, 0xcf, 0x74, 0x88, 0xe0, 0xbe, 0x64, 0x4a, 0xaf,
+	0x9e, 0x59, 0x59, 0xe4, 0x4c, 0x17, 0x82, 0xaf, 0x14, 0xee, 0x4c, 0xb7, 0x09, 0xbd, 0x6b, 0xa9,
+	0x5f, 0x03, 0x93, 0xe0, 0x8e, 0x7c, 0x1c, 0x13, 0x5d, 0x9a, 0x17, 0x98, 0x46, 0xdd, 0x68, 0x7f,
+	0xf0, 0x67, 0x2c, 0x45, 0x8d, 0x43, 0xc6, 0x70, 0x0d, 0xfe, 0xf1, 0xab, 0x9c, 0xc9, 0xf1, 0x01,
+	0xae, 0x94, 0x66, 0xba, 0x51, 0xa6, 0xb5, 0x3f, 0xf7, 0x7b, 0xdb, 0xc4, 0xa0, 0xb4, 0x63, 0x09,
+	0x81, 0x49, 0xc1, 0xd7, 0xc2, 0x34, 0xf7, 0xa8, 0xf9, 0x9f, 0x2f, 0xc0, 0x7d, 0xb2, 0xdb, 0x47,
+	0xbe, 0xc2, 0xa4, 0x9d, 0x19, 0x79, 0x35, 0xce, 0x65, 0xdc, 0xb3, 0xd9, 0xc3, 0x09, 0x6a, 0x53,
+	0x3d, 0x3a, 0x9f, 0x9d, 0xc5, 0x4f, 0x78, 0x2f, 0xe4, 0x26, 0xda, 0xee, 0x6b, 0x94, 0x25, 0xe6,
+	0x1b, 0x94, 0xd1, 0x9a, 0xa5, 0xb2, 0xc8, 0xec, 0xca, 0xaa, 0xfe, 0xe4, 0xef, 0x4f, 0x9b, 0x42,
+	0x6f, 0x9b, 0xb4, 0x8d, 0x17, 0x1f, 0xa8, 0x63, 0xab, 0x8e, 0xad, 0x3a, 0xee, 0xd4, 0xe9, 0x95,
+	0xa9, 0xbf, 0xfc, 0x1b, 0x00, 0x3d, 0x90, 0xa5, 0x9d, 0x27, 0x03, 0x00, 0x00,
 }
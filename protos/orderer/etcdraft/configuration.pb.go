@@ -32,7 +32,7 @@ func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
 func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
 func (*ConfigMetadata) ProtoMessage()    {}
 func (*ConfigMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{0}
+	return fileDescriptor_configuration_46622936d1bbbeb1, []int{0}
 }
 func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
@@ -81,7 +81,7 @@ func (m *Consenter) Reset()         { *m = Consenter{} }
 func (m *Consenter) String() string { return proto.CompactTextString(m) }
 func (*Consenter) ProtoMessage()    {}
 func (*Consenter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{1}
+	return fileDescriptor_configuration_46622936d1bbbeb1, []int{1}
 }
 func (m *Consenter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Consenter.Unmarshal(m, b)
@@ -147,7 +147,7 @@ func (m *Options) Reset()         { *m = Options{} }
 func (m *Options) String() string { return proto.CompactTextString(m) }
 func (*Options) ProtoMessage()    {}
 func (*Options) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{2}
+	return fileDescriptor_configuration_46622936d1bbbeb1, []int{2}
 }
 func (m *Options) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Options.Unmarshal(m, b)
@@ -223,7 +223,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_780531726dd41db7, []int{3}
+	return fileDescriptor_configuration_46622936d1bbbeb1, []int{3}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -264,45 +264,120 @@ func (m *BlockMetadata) GetRaftIndex() uint64 {
 	return 0
 }
 
+// BlockSignature is the signature of a consenter over a block, sent to the
+// other consenters in the metadata of a ConsensusRequest when blocks must
+// carry the signatures of several consenters.
+type BlockSignature struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The signature header and the signature of the
+	// MetadataSignature of the consenter over the block.
+	SignatureHeader []byte `protobuf:"bytes,2,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	Signature       []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Set when the consenter already committed the block,
+	// in which case the signature doesn't need to be answered.
+	Committed            bool     `protobuf:"varint,4,opt,name=committed,proto3" json:"committed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockSignature) Reset()         { *m = BlockSignature{} }
+func (m *BlockSignature) String() string { return proto.CompactTextString(m) }
+func (*BlockSignature) ProtoMessage()    {}
+func (*BlockSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_46622936d1bbbeb1, []int{4}
+}
+func (m *BlockSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSignature.Unmarshal(m, b)
+}
+func (m *BlockSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSignature.Marshal(b, m, deterministic)
+}
+func (dst *BlockSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSignature.Merge(dst, src)
+}
+func (m *BlockSignature) XXX_Size() int {
+	return xxx_messageInfo_BlockSignature.Size(m)
+}
+func (m *BlockSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSignature proto.InternalMessageInfo
+
+func (m *BlockSignature) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockSignature) GetSignatureHeader() []byte {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *BlockSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *BlockSignature) GetCommitted() bool {
+	if m != nil {
+		return m.Committed
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ConfigMetadata)(nil), "etcdraft.ConfigMetadata")
 	proto.RegisterType((*Consenter)(nil), "etcdraft.Consenter")
 	proto.RegisterType((*Options)(nil), "etcdraft.Options")
 	proto.RegisterType((*BlockMetadata)(nil), "etcdraft.BlockMetadata")
+	proto.RegisterType((*BlockSignature)(nil), "etcdraft.BlockSignature")
 }
 
 func init() {
-	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_780531726dd41db7)
-}
-
-var fileDescriptor_configuration_780531726dd41db7 = []byte{
-	// 448 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xc1, 0x6f, 0xd3, 0x30,
-	0x14, 0xc6, 0x15, 0x56, 0x18, 0x7d, 0x6b, 0x36, 0xd5, 0x43, 0x28, 0x17, 0xa4, 0xaa, 0x03, 0x54,
-	0x81, 0x94, 0x48, 0x1b, 0xfc, 0x03, 0xeb, 0xa9, 0x07, 0x84, 0x14, 0x76, 0xe2, 0x62, 0x39, 0xce,
-	0x6b, 0x62, 0x35, 0x8d, 0x23, 0xfb, 0x6d, 0x2a, 0xbb, 0x70, 0xe0, 0x1f, 0xe5, 0x4f, 0x41, 0xb6,
-	0x93, 0xb4, 0xe2, 0x66, 0x7d, 0xdf, 0xef, 0xb3, 0x3f, 0xeb, 0x3d, 0x78, 0xaf, 0x4d, 0x89, 0x06,
-	0x4d, 0x86, 0x24, 0x4b, 0x23, 0xb6, 0x94, 0x49, 0xdd, 0x6e, 0x55, 0xf5, 0x68, 0x04, 0x29, 0xdd,
-	0xa6, 0x9d, 0xd1, 0xa4, 0xd9, 0xeb, 0xc1, 0x5d, 0x1a, 0xb8, 0x5c, 0x7b, 0xe0, 0x1b, 0x92, 0x28,
-	0x05, 0x09, 0x76, 0x07, 0x20, 0x75, 0x6b, 0xb1, 0x25, 0x34, 0x36, 0x89, 0x16, 0x67, 0xab, 0x8b,
-	0xdb, 0xeb, 0x74, 0x08, 0xa4, 0xeb, 0xc1, 0xcb, 0x4f, 0x30, 0xf6, 0x19, 0xce, 0x75, 0xe7, 0x1e,
-	0xb0, 0xc9, 0x8b, 0x45, 0xb4, 0xba, 0xb8, 0x9d, 0x1f, 0x13, 0xdf, 0x83, 0x91, 0x0f, 0xc4, 0xf2,
-	0x4f, 0x04, 0xd3, 0xf1, 0x1a, 0xc6, 0x60, 0x52, 0x6b, 0x4b, 0x49, 0xb4, 0x88, 0x56, 0xd3, 0xdc,
-	0x9f, 0x9d, 0xd6, 0x69, 0x43, 0xfe, 0xae, 0x38, 0xf7, 0x67, 0xf6, 0x11, 0xae, 0x64, 0xa3, 0xb0,
-	0x25, 0x4e, 0x8d, 0xe5, 0x12, 0x0d, 0x25, 0x67, 0x8b, 0x68, 0x35, 0xcb, 0xe3, 0x20, 0x3f, 0x34,
-	0x76, 0x8d, 0x81, 0xb3, 0x68, 0x9e, 0xd0, 0x1c, 0xb9, 0x49, 0xe0, 0x82, 0xdc, 0x73, 0xcb, 0xbf,
-	0x11, 0x9c, 0xf7, 0xd5, 0xd8, 0x0d, 0xc4, 0xa4, 0xe4, 0x8e, 0x2b, 0xd7, 0xe8, 0x49, 0x34, 0x7d,
-	0x99, 0x99, 0x13, 0x37, 0xbd, 0xe6, 0x20, 0x6c, 0x50, 0xba, 0x04, 0x77, 0x46, 0xdf, 0x6e, 0x36,
-	0x88, 0x0f, 0x4a, 0xee, 0xd8, 0x07, 0xb8, 0xac, 0x51, 0x18, 0x2a, 0x50, 0x50, 0xa0, 0xce, 0x3c,
-	0x15, 0x8f, 0xaa, 0xc7, 0x52, 0xb8, 0xde, 0x8b, 0x03, 0x57, 0xed, 0xb6, 0x51, 0x55, 0x4d, 0xbc,
-	0x68, 0xb4, 0xdc, 0x59, 0x5f, 0x34, 0xce, 0xe7, 0x7b, 0x71, 0xd8, 0xf4, 0xce, 0xbd, 0x37, 0xd8,
-	0x17, 0x78, 0x6b, 0x5b, 0xd1, 0xd9, 0x5a, 0xd3, 0x58, 0x92, 0x5b, 0xf5, 0x8c, 0xc9, 0x4b, 0x1f,
-	0x79, 0x33, 0xb8, 0x43, 0xdb, 0x1f, 0xea, 0x19, 0x97, 0xbf, 0x21, 0xf6, 0xf9, 0x71, 0xb6, 0x37,
-	0x10, 0x8f, 0x43, 0xe3, 0xaa, 0x0c, 0xe3, 0x9d, 0xe4, 0xb3, 0x51, 0xdc, 0x94, 0x96, 0x7d, 0x82,
-	0x79, 0x8b, 0x07, 0xe2, 0xa7, 0xa4, 0xff, 0xeb, 0x24, 0xbf, 0x72, 0xc6, 0xfa, 0x08, 0xb3, 0x77,
-	0x00, 0x6e, 0xc6, 0x5c, 0xb5, 0x25, 0x1e, 0xfc, 0x57, 0x27, 0xf9, 0xd4, 0x29, 0x1b, 0x27, 0xdc,
-	0x57, 0x90, 0x6a, 0x53, 0xa5, 0xf5, 0xaf, 0x0e, 0x4d, 0x83, 0x65, 0x85, 0x26, 0xdd, 0x8a, 0xc2,
-	0x28, 0x19, 0xf6, 0xd0, 0xa6, 0xfd, 0xb6, 0x8e, 0xcb, 0xf2, 0xf3, 0x6b, 0xa5, 0xa8, 0x7e, 0x2c,
-	0x52, 0xa9, 0xf7, 0xd9, 0x49, 0x2c, 0x0b, 0xb1, 0x2c, 0xc4, 0xb2, 0xff, 0x97, 0xbc, 0x78, 0xe5,
-	0x8d, 0xbb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x1e, 0x2b, 0x0a, 0xff, 0x02, 0x00, 0x00,
+	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_46622936d1bbbeb1)
+}
+
+var fileDescriptor_configuration_46622936d1bbbeb1 = []byte{
+	// 520 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x93, 0xcf, 0x8e, 0xd3, 0x3c,
+	0x14, 0xc5, 0x95, 0x6f, 0xf2, 0x31, 0xd3, 0x3b, 0xc9, 0x94, 0x7a, 0x10, 0xea, 0x02, 0xa4, 0xd2,
+	0x01, 0x54, 0x40, 0x4a, 0xa4, 0x19, 0x78, 0x81, 0xe9, 0x86, 0x2e, 0x00, 0xc9, 0x33, 0x2b, 0x36,
+	0x96, 0xe3, 0xdc, 0x26, 0x56, 0x93, 0xb8, 0xb2, 0xdd, 0x51, 0x99, 0x0d, 0x0b, 0xde, 0x82, 0xa7,
+	0xe3, 0x51, 0x90, 0x9d, 0x3f, 0xad, 0xd8, 0x45, 0xbf, 0x73, 0x4e, 0x7c, 0xec, 0x6b, 0xc3, 0x6b,
+	0xa5, 0x73, 0xd4, 0xa8, 0x53, 0xb4, 0x22, 0xd7, 0x7c, 0x6d, 0x53, 0xa1, 0x9a, 0xb5, 0x2c, 0x76,
+	0x9a, 0x5b, 0xa9, 0x9a, 0x64, 0xab, 0x95, 0x55, 0xe4, 0xac, 0x57, 0xe7, 0x1a, 0x2e, 0x96, 0xde,
+	0xf0, 0x05, 0x2d, 0xcf, 0xb9, 0xe5, 0xe4, 0x06, 0x40, 0xa8, 0xc6, 0x60, 0x63, 0x51, 0x9b, 0x69,
+	0x30, 0x3b, 0x59, 0x9c, 0x5f, 0x5f, 0x26, 0x7d, 0x20, 0x59, 0xf6, 0x1a, 0x3d, 0xb2, 0x91, 0x0f,
+	0x70, 0xaa, 0xb6, 0x6e, 0x01, 0x33, 0xfd, 0x6f, 0x16, 0x2c, 0xce, 0xaf, 0x27, 0x87, 0xc4, 0xb7,
+	0x56, 0xa0, 0xbd, 0x63, 0xfe, 0x2b, 0x80, 0xd1, 0xf0, 0x1b, 0x42, 0x20, 0x2c, 0x95, 0xb1, 0xd3,
+	0x60, 0x16, 0x2c, 0x46, 0xd4, 0x7f, 0x3b, 0xb6, 0x55, 0xda, 0xfa, 0x7f, 0xc5, 0xd4, 0x7f, 0x93,
+	0xb7, 0x30, 0x16, 0x95, 0xc4, 0xc6, 0x32, 0x5b, 0x19, 0x26, 0x50, 0xdb, 0xe9, 0xc9, 0x2c, 0x58,
+	0x44, 0x34, 0x6e, 0xf1, 0x7d, 0x65, 0x96, 0xd8, 0xfa, 0x0c, 0xea, 0x07, 0xd4, 0x07, 0x5f, 0xd8,
+	0xfa, 0x5a, 0xdc, 0xf9, 0xe6, 0x7f, 0x02, 0x38, 0xed, 0xaa, 0x91, 0x2b, 0x88, 0xad, 0x14, 0x1b,
+	0x26, 0x5d, 0xa3, 0x07, 0x5e, 0x75, 0x65, 0x22, 0x07, 0x57, 0x1d, 0x73, 0x26, 0xac, 0x50, 0xb8,
+	0x04, 0x73, 0x42, 0xd7, 0x2e, 0xea, 0xe1, 0xbd, 0x14, 0x1b, 0xf2, 0x06, 0x2e, 0x4a, 0xe4, 0xda,
+	0x66, 0xc8, 0x6d, 0xeb, 0x3a, 0xf1, 0xae, 0x78, 0xa0, 0xde, 0x96, 0xc0, 0x65, 0xcd, 0xf7, 0x4c,
+	0x36, 0xeb, 0x4a, 0x16, 0xa5, 0x65, 0x59, 0xa5, 0xc4, 0xc6, 0xf8, 0xa2, 0x31, 0x9d, 0xd4, 0x7c,
+	0xbf, 0xea, 0x94, 0x5b, 0x2f, 0x90, 0x8f, 0xf0, 0xdc, 0x34, 0x7c, 0x6b, 0x4a, 0x65, 0x87, 0x92,
+	0xcc, 0xc8, 0x47, 0x9c, 0xfe, 0xef, 0x23, 0xcf, 0x7a, 0xb5, 0x6f, 0x7b, 0x27, 0x1f, 0x71, 0xfe,
+	0x13, 0x62, 0x9f, 0x1f, 0x66, 0x7b, 0x05, 0xf1, 0x30, 0x34, 0x26, 0xf3, 0x76, 0xbc, 0x21, 0x8d,
+	0x06, 0xb8, 0xca, 0x0d, 0x79, 0x0f, 0x93, 0x06, 0xf7, 0x96, 0x1d, 0x3b, 0xfd, 0x5e, 0x43, 0x3a,
+	0x76, 0xc2, 0xf2, 0x60, 0x26, 0x2f, 0x01, 0xdc, 0x8c, 0x99, 0x6c, 0x72, 0xdc, 0xfb, 0xad, 0x86,
+	0x74, 0xe4, 0xc8, 0xca, 0x81, 0xf9, 0xef, 0x00, 0x2e, 0x7c, 0x83, 0x3b, 0x59, 0x34, 0xdc, 0xee,
+	0x34, 0x92, 0x57, 0x10, 0xf9, 0xcd, 0xb2, 0x66, 0x57, 0x67, 0xa8, 0xfd, 0x49, 0x87, 0xf4, 0xdc,
+	0xb3, 0xaf, 0x1e, 0x91, 0x77, 0xf0, 0xd4, 0xf4, 0x7e, 0x56, 0x22, 0xcf, 0x51, 0xfb, 0xf5, 0x23,
+	0x3a, 0x1e, 0xf8, 0x67, 0x8f, 0xc9, 0x0b, 0x18, 0x0d, 0xa8, 0xbb, 0x0e, 0x07, 0xe0, 0x54, 0xa1,
+	0xea, 0x5a, 0x5a, 0x8b, 0xb9, 0x3f, 0xdb, 0x33, 0x7a, 0x00, 0xb7, 0x05, 0x24, 0x4a, 0x17, 0x49,
+	0xf9, 0x63, 0x8b, 0xba, 0xc2, 0xbc, 0x40, 0x9d, 0xac, 0x79, 0xa6, 0xa5, 0x68, 0x1f, 0x89, 0x49,
+	0xba, 0xa7, 0x34, 0xdc, 0xe4, 0xef, 0x9f, 0x0a, 0x69, 0xcb, 0x5d, 0x96, 0x08, 0x55, 0xa7, 0x47,
+	0xb1, 0xb4, 0x8d, 0xa5, 0x6d, 0x2c, 0xfd, 0xf7, 0x05, 0x66, 0x4f, 0xbc, 0x70, 0xf3, 0x77, 0x00,
+	0x47, 0xbd, 0x2a, 0x07, 0x9c, 0x03, 0x00, 0x00,
 }
@@ -49,6 +49,8 @@ const (
 	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
 	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
 	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_STATE_MULTIPLE    ChaincodeMessage_Type = 23
+	ChaincodeMessage_PUT_STATE_MULTIPLE    ChaincodeMessage_Type = 24
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -74,6 +76,8 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "GET_STATE_MULTIPLE",
+	24: "PUT_STATE_MULTIPLE",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":             0,
@@ -98,13 +102,15 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"GET_STATE_METADATA":    20,
 	"PUT_STATE_METADATA":    21,
 	"GET_PRIVATE_DATA_HASH": 22,
+	"GET_STATE_MULTIPLE":    23,
+	"PUT_STATE_MULTIPLE":    24,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -118,7 +124,13 @@ type ChaincodeMessage struct {
 	// with Block.NonHashData.TransactionResult
 	ChaincodeEvent *ChaincodeEvent `protobuf:"bytes,6,opt,name=chaincode_event,json=chaincodeEvent,proto3" json:"chaincode_event,omitempty"`
 	// channel id
-	ChannelId            string   `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ChannelId string `protobuf:"bytes,7,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// events emitted by chaincode, in the order they were set, when it set
+	// more than one. chaincode_event holds the last of them.
+	ChaincodeEvents []*ChaincodeEvent `protobuf:"bytes,8,rep,name=chaincode_events,json=chaincodeEvents,proto3" json:"chaincode_events,omitempty"`
+	// makes a chaincode invoked on another channel read-only, so that its
+	// writes fail instead of being discarded. Used only with INVOKE_CHAINCODE.
+	ReadOnly             bool     `protobuf:"varint,9,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -128,7 +140,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -197,6 +209,20 @@ func (m *ChaincodeMessage) GetChannelId() string {
 	return ""
 }
 
+func (m *ChaincodeMessage) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
+func (m *ChaincodeMessage) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
 // GetState is the payload of a ChaincodeMessage. It contains a key which
 // is to be fetched from the ledger. If the collection is specified, the key
 // would be fetched from the collection (i.e., private state)
@@ -212,7 +238,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +284,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +335,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +389,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +446,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -472,7 +498,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +563,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -593,7 +619,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -640,7 +666,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -678,7 +704,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{10}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +742,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{11}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +781,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{12}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -801,7 +827,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{13}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +889,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{14}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +935,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{15}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +980,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{16}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -981,6 +1007,268 @@ func (m *StateMetadataResult) GetEntries() []*StateMetadata {
 	return nil
 }
 
+// GetStateMultiple is the payload of a ChaincodeMessage. It contains the keys
+// which are to be fetched from the ledger in a single round trip. If the
+// collection is specified, the keys would be fetched from the collection
+// (i.e., private state)
+type GetStateMultiple struct {
+	Keys                 []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Collection           string   `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultiple) Reset()         { *m = GetStateMultiple{} }
+func (m *GetStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultiple) ProtoMessage()    {}
+func (*GetStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{17}
+}
+func (m *GetStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultiple.Unmarshal(m, b)
+}
+func (m *GetStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultiple.Marshal(b, m, deterministic)
+}
+func (dst *GetStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultiple.Merge(dst, src)
+}
+func (m *GetStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultiple.Size(m)
+}
+func (m *GetStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultiple proto.InternalMessageInfo
+
+func (m *GetStateMultiple) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *GetStateMultiple) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+// GetStateMultipleResult is returned by the peer as a result of a
+// GetStateMultiple. It holds the values of the requested keys, in the order
+// of the keys, with an empty value for the keys which don't exist.
+type GetStateMultipleResult struct {
+	Values               [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateMultipleResult) Reset()         { *m = GetStateMultipleResult{} }
+func (m *GetStateMultipleResult) String() string { return proto.CompactTextString(m) }
+func (*GetStateMultipleResult) ProtoMessage()    {}
+func (*GetStateMultipleResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{18}
+}
+func (m *GetStateMultipleResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateMultipleResult.Unmarshal(m, b)
+}
+func (m *GetStateMultipleResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateMultipleResult.Marshal(b, m, deterministic)
+}
+func (dst *GetStateMultipleResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateMultipleResult.Merge(dst, src)
+}
+func (m *GetStateMultipleResult) XXX_Size() int {
+	return xxx_messageInfo_GetStateMultipleResult.Size(m)
+}
+func (m *GetStateMultipleResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateMultipleResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateMultipleResult proto.InternalMessageInfo
+
+func (m *GetStateMultipleResult) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// PutStateMultiple is the payload of a ChaincodeMessage. It contains the
+// writes buffered by the chaincode, which are applied in order to the
+// transaction's write set.
+type PutStateMultiple struct {
+	Records              []*WriteRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PutStateMultiple) Reset()         { *m = PutStateMultiple{} }
+func (m *PutStateMultiple) String() string { return proto.CompactTextString(m) }
+func (*PutStateMultiple) ProtoMessage()    {}
+func (*PutStateMultiple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{19}
+}
+func (m *PutStateMultiple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutStateMultiple.Unmarshal(m, b)
+}
+func (m *PutStateMultiple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutStateMultiple.Marshal(b, m, deterministic)
+}
+func (dst *PutStateMultiple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutStateMultiple.Merge(dst, src)
+}
+func (m *PutStateMultiple) XXX_Size() int {
+	return xxx_messageInfo_PutStateMultiple.Size(m)
+}
+func (m *PutStateMultiple) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutStateMultiple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutStateMultiple proto.InternalMessageInfo
+
+func (m *PutStateMultiple) GetRecords() []*WriteRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+// WriteRecord is a write of a PutStateMultiple. It is a put of the key and
+// value or, if is_delete is set, a delete of the key. If the collection is
+// specified, the write is recorded in the transaction's private write set.
+type WriteRecord struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Collection           string   `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	IsDelete             bool     `protobuf:"varint,4,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteRecord) Reset()         { *m = WriteRecord{} }
+func (m *WriteRecord) String() string { return proto.CompactTextString(m) }
+func (*WriteRecord) ProtoMessage()    {}
+func (*WriteRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{20}
+}
+func (m *WriteRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteRecord.Unmarshal(m, b)
+}
+func (m *WriteRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteRecord.Marshal(b, m, deterministic)
+}
+func (dst *WriteRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteRecord.Merge(dst, src)
+}
+func (m *WriteRecord) XXX_Size() int {
+	return xxx_messageInfo_WriteRecord.Size(m)
+}
+func (m *WriteRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteRecord proto.InternalMessageInfo
+
+func (m *WriteRecord) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WriteRecord) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *WriteRecord) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
+// ChaincodeAdditionalParams is the payload of the REGISTERED message. It
+// advertises the optional capabilities of the peer side of the protocol,
+// which the chaincode may use.
+type ChaincodeAdditionalParams struct {
+	UseWriteBatch          bool     `protobuf:"varint,1,opt,name=use_write_batch,json=useWriteBatch,proto3" json:"use_write_batch,omitempty"`
+	MaxSizeWriteBatch      uint32   `protobuf:"varint,2,opt,name=max_size_write_batch,json=maxSizeWriteBatch,proto3" json:"max_size_write_batch,omitempty"`
+	UseGetMultipleKeys     bool     `protobuf:"varint,3,opt,name=use_get_multiple_keys,json=useGetMultipleKeys,proto3" json:"use_get_multiple_keys,omitempty"`
+	MaxSizeGetMultipleKeys uint32   `protobuf:"varint,4,opt,name=max_size_get_multiple_keys,json=maxSizeGetMultipleKeys,proto3" json:"max_size_get_multiple_keys,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *ChaincodeAdditionalParams) Reset()         { *m = ChaincodeAdditionalParams{} }
+func (m *ChaincodeAdditionalParams) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeAdditionalParams) ProtoMessage()    {}
+func (*ChaincodeAdditionalParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_e277408774d41cbc, []int{21}
+}
+func (m *ChaincodeAdditionalParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Unmarshal(m, b)
+}
+func (m *ChaincodeAdditionalParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeAdditionalParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeAdditionalParams.Merge(dst, src)
+}
+func (m *ChaincodeAdditionalParams) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeAdditionalParams.Size(m)
+}
+func (m *ChaincodeAdditionalParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeAdditionalParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeAdditionalParams proto.InternalMessageInfo
+
+func (m *ChaincodeAdditionalParams) GetUseWriteBatch() bool {
+	if m != nil {
+		return m.UseWriteBatch
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeWriteBatch() uint32 {
+	if m != nil {
+		return m.MaxSizeWriteBatch
+	}
+	return 0
+}
+
+func (m *ChaincodeAdditionalParams) GetUseGetMultipleKeys() bool {
+	if m != nil {
+		return m.UseGetMultipleKeys
+	}
+	return false
+}
+
+func (m *ChaincodeAdditionalParams) GetMaxSizeGetMultipleKeys() uint32 {
+	if m != nil {
+		return m.MaxSizeGetMultipleKeys
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeMessage)(nil), "protos.ChaincodeMessage")
 	proto.RegisterType((*GetState)(nil), "protos.GetState")
@@ -999,6 +1287,11 @@ func init() {
 	proto.RegisterType((*QueryResponseMetadata)(nil), "protos.QueryResponseMetadata")
 	proto.RegisterType((*StateMetadata)(nil), "protos.StateMetadata")
 	proto.RegisterType((*StateMetadataResult)(nil), "protos.StateMetadataResult")
+	proto.RegisterType((*GetStateMultiple)(nil), "protos.GetStateMultiple")
+	proto.RegisterType((*GetStateMultipleResult)(nil), "protos.GetStateMultipleResult")
+	proto.RegisterType((*PutStateMultiple)(nil), "protos.PutStateMultiple")
+	proto.RegisterType((*WriteRecord)(nil), "protos.WriteRecord")
+	proto.RegisterType((*ChaincodeAdditionalParams)(nil), "protos.ChaincodeAdditionalParams")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
 }
 
@@ -1106,75 +1399,187 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// ChaincodeClient is the client API for Chaincode service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chaincode_serviceDesc.Streams[0], "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeServer is the server API for Chaincode service.
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
-}
-
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_e277408774d41cbc)
+}
+
+var fileDescriptor_chaincode_shim_e277408774d41cbc = []byte{
+	// 1292 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdf, 0x73, 0xda, 0xc6,
+	0x16, 0x0e, 0xc6, 0x36, 0xe2, 0xf8, 0x07, 0x9b, 0xb5, 0x21, 0x32, 0x77, 0x72, 0x2f, 0x57, 0x73,
+	0xe7, 0x8e, 0xfb, 0x50, 0x48, 0x68, 0x1f, 0x3a, 0x9d, 0xce, 0x64, 0x64, 0x58, 0x63, 0xc6, 0x18,
+	0xc8, 0x22, 0xa7, 0x71, 0x5f, 0x34, 0x02, 0x6d, 0x40, 0x63, 0x21, 0xa9, 0xda, 0x25, 0x31, 0x79,
+	0xeb, 0x6b, 0xff, 0xac, 0xfe, 0x2b, 0x9d, 0xe9, 0xdf, 0xd1, 0x59, 0xfd, 0x32, 0xe0, 0x3a, 0x99,
+	0x66, 0xfa, 0x84, 0xbe, 0x73, 0xbe, 0xfd, 0xce, 0xb7, 0x67, 0x75, 0xc4, 0xc2, 0x49, 0xc0, 0x58,
+	0xd8, 0x98, 0xcc, 0x2c, 0xc7, 0x9b, 0xf8, 0x36, 0x33, 0xf9, 0xcc, 0x99, 0xd7, 0x83, 0xd0, 0x17,
+	0x3e, 0xde, 0x8d, 0x7e, 0x78, 0xb5, 0xba, 0x41, 0x61, 0xef, 0x99, 0x27, 0x62, 0x4e, 0xf5, 0x28,
+	0xca, 0x05, 0xa1, 0x1f, 0xf8, 0xdc, 0x72, 0x93, 0xe0, 0x7f, 0xa6, 0xbe, 0x3f, 0x75, 0x59, 0x23,
+	0x42, 0xe3, 0xc5, 0xbb, 0x86, 0x70, 0xe6, 0x8c, 0x0b, 0x6b, 0x1e, 0xc4, 0x04, 0xed, 0x8f, 0x5d,
+	0x40, 0xad, 0x54, 0xef, 0x8a, 0x71, 0x6e, 0x4d, 0x19, 0x7e, 0x09, 0xdb, 0x62, 0x19, 0x30, 0x35,
+	0x57, 0xcb, 0x9d, 0x1e, 0x36, 0x9f, 0xc7, 0x54, 0x5e, 0xdf, 0xe4, 0xd5, 0x8d, 0x65, 0xc0, 0x68,
+	0x44, 0xc5, 0xdf, 0x41, 0x31, 0x93, 0x56, 0xb7, 0x6a, 0xb9, 0xd3, 0xbd, 0x66, 0xb5, 0x1e, 0x17,
+	0xaf, 0xa7, 0xc5, 0xeb, 0x46, 0xca, 0xa0, 0xf7, 0x64, 0xac, 0x42, 0x21, 0xb0, 0x96, 0xae, 0x6f,
+	0xd9, 0x6a, 0xbe, 0x96, 0x3b, 0xdd, 0xa7, 0x29, 0xc4, 0x18, 0xb6, 0xc5, 0x9d, 0x63, 0xab, 0xdb,
+	0xb5, 0xdc, 0x69, 0x91, 0x46, 0xcf, 0xb8, 0x09, 0x4a, 0xba, 0x45, 0x75, 0x27, 0x2a, 0x53, 0x49,
+	0xed, 0x8d, 0x9c, 0xa9, 0xc7, 0xec, 0x61, 0x92, 0xa5, 0x19, 0x0f, 0xbf, 0x82, 0xd2, 0x46, 0xcb,
+	0xd4, 0xdd, 0xf5, 0xa5, 0xd9, 0xce, 0x88, 0xcc, 0xd2, 0xc3, 0xc9, 0x1a, 0xc6, 0xcf, 0x01, 0x26,
+	0x33, 0xcb, 0xf3, 0x98, 0x6b, 0x3a, 0xb6, 0x5a, 0x88, 0xec, 0x14, 0x93, 0x48, 0xd7, 0xc6, 0x3a,
+	0xa0, 0x0d, 0x7d, 0xae, 0x2a, 0xb5, 0xfc, 0x27, 0x0a, 0x94, 0xd6, 0x0b, 0x70, 0xfc, 0x2f, 0x28,
+	0x86, 0xcc, 0xb2, 0x4d, 0xdf, 0x73, 0x97, 0x6a, 0xb1, 0x96, 0x3b, 0x55, 0xa8, 0x22, 0x03, 0x03,
+	0xcf, 0x5d, 0x6a, 0xbf, 0xe5, 0x61, 0x5b, 0xb6, 0x1a, 0x1f, 0x40, 0xf1, 0xba, 0xdf, 0x26, 0xe7,
+	0xdd, 0x3e, 0x69, 0xa3, 0x27, 0x78, 0x1f, 0x14, 0x4a, 0x3a, 0xdd, 0x91, 0x41, 0x28, 0xca, 0xe1,
+	0x43, 0x80, 0x14, 0x91, 0x36, 0xda, 0xc2, 0x0a, 0x6c, 0x77, 0xfb, 0x5d, 0x03, 0xe5, 0x71, 0x11,
+	0x76, 0x28, 0xd1, 0xdb, 0x37, 0x68, 0x1b, 0x97, 0x60, 0xcf, 0xa0, 0x7a, 0x7f, 0xa4, 0xb7, 0x8c,
+	0xee, 0xa0, 0x8f, 0x76, 0xa4, 0x64, 0x6b, 0x70, 0x35, 0xec, 0x11, 0x83, 0xb4, 0xd1, 0xae, 0xa4,
+	0x12, 0x4a, 0x07, 0x14, 0x15, 0x64, 0xa6, 0x43, 0x0c, 0x73, 0x64, 0xe8, 0x06, 0x41, 0x8a, 0x84,
+	0xc3, 0xeb, 0x14, 0x16, 0x25, 0x6c, 0x93, 0x5e, 0x02, 0x01, 0x1f, 0x03, 0xea, 0xf6, 0xdf, 0x0c,
+	0x2e, 0x89, 0xd9, 0xba, 0xd0, 0xbb, 0xfd, 0xd6, 0xa0, 0x4d, 0xd0, 0x5e, 0x6c, 0x70, 0x34, 0x1c,
+	0xf4, 0x47, 0x04, 0x1d, 0xe0, 0x0a, 0xe0, 0x4c, 0xd0, 0x3c, 0xbb, 0x31, 0xa9, 0xde, 0xef, 0x10,
+	0x74, 0x28, 0xd7, 0xca, 0xf8, 0xeb, 0x6b, 0x42, 0x6f, 0x4c, 0x4a, 0x46, 0xd7, 0x3d, 0x03, 0x95,
+	0x64, 0x34, 0x8e, 0xc4, 0xfc, 0x3e, 0x79, 0x6b, 0x20, 0x84, 0xcb, 0xf0, 0x74, 0x35, 0xda, 0xea,
+	0x0d, 0x46, 0x04, 0x3d, 0x95, 0x6e, 0x2e, 0x09, 0x19, 0xea, 0xbd, 0xee, 0x1b, 0x82, 0x30, 0x7e,
+	0x06, 0x47, 0x52, 0xf1, 0xa2, 0x3b, 0x32, 0x06, 0xf4, 0xc6, 0x3c, 0x1f, 0x50, 0xf3, 0x92, 0xdc,
+	0xa0, 0xa3, 0x75, 0x0b, 0x57, 0xc4, 0xd0, 0xdb, 0xba, 0xa1, 0xa3, 0x63, 0x19, 0x1f, 0x5e, 0x3f,
+	0x88, 0x97, 0xf1, 0x09, 0x94, 0x25, 0x7f, 0x48, 0xbb, 0x6f, 0x64, 0x46, 0x46, 0xcd, 0x0b, 0x7d,
+	0x74, 0x81, 0x2a, 0x1b, 0x52, 0xd7, 0x3d, 0xa3, 0x3b, 0xec, 0x11, 0xf4, 0x6c, 0x43, 0x2a, 0x8d,
+	0xab, 0xda, 0x0f, 0xa0, 0x74, 0x98, 0x18, 0x09, 0x4b, 0x30, 0x8c, 0x20, 0x7f, 0xcb, 0x96, 0xd1,
+	0x78, 0x15, 0xa9, 0x7c, 0xc4, 0xff, 0x06, 0x98, 0xf8, 0xae, 0xcb, 0x26, 0xc2, 0xf1, 0xbd, 0x68,
+	0x7e, 0x8a, 0x74, 0x25, 0xa2, 0xb5, 0x01, 0xa5, 0xab, 0xaf, 0x98, 0xb0, 0x6c, 0x4b, 0x58, 0x5f,
+	0xa0, 0x42, 0x41, 0x19, 0x2e, 0x1e, 0xf5, 0x70, 0x0c, 0x3b, 0xef, 0x2d, 0x77, 0xc1, 0xa2, 0x85,
+	0xfb, 0x34, 0x06, 0x1b, 0x9a, 0xf9, 0x07, 0x9a, 0x1f, 0x00, 0x0d, 0x17, 0x7f, 0xd3, 0xd9, 0x03,
+	0x15, 0xfc, 0x12, 0x94, 0x79, 0xb2, 0x3a, 0x1a, 0xf7, 0xbd, 0x66, 0x39, 0x1b, 0xeb, 0x55, 0x69,
+	0x9a, 0xd1, 0x64, 0x43, 0xdb, 0xcc, 0xfd, 0xd2, 0x86, 0xfe, 0x92, 0x83, 0x52, 0xda, 0xd1, 0xb3,
+	0x25, 0xb5, 0xbc, 0x29, 0xc3, 0x55, 0x50, 0xb8, 0xb0, 0x42, 0x71, 0x99, 0x49, 0x65, 0x18, 0x57,
+	0x60, 0x97, 0x79, 0xb6, 0xcc, 0xc4, 0x5a, 0x09, 0xfa, 0xec, 0xc6, 0xaa, 0x1b, 0x1b, 0xdb, 0x5f,
+	0xd9, 0xc1, 0x18, 0x0e, 0x3b, 0x4c, 0xbc, 0x5e, 0xb0, 0x70, 0x49, 0x19, 0x5f, 0xb8, 0x42, 0x1e,
+	0xc1, 0xcf, 0x12, 0x26, 0xe5, 0x63, 0xf0, 0xb9, 0xbd, 0xac, 0xd5, 0xc8, 0x6f, 0xd4, 0xe8, 0xc0,
+	0x41, 0x54, 0x20, 0x3b, 0x9b, 0x2a, 0x28, 0x81, 0x35, 0x65, 0x23, 0xe7, 0x63, 0xfc, 0x7d, 0xdf,
+	0xa1, 0x19, 0x96, 0xb9, 0xb1, 0xef, 0xdf, 0xce, 0xad, 0xf0, 0x36, 0x29, 0x93, 0x61, 0xed, 0x7f,
+	0xd1, 0x1b, 0x78, 0xe1, 0x70, 0xe1, 0x87, 0xcb, 0x73, 0x3f, 0x94, 0x9b, 0x7f, 0xd0, 0x76, 0xad,
+	0x06, 0x87, 0x51, 0xb9, 0xa8, 0xaf, 0x7d, 0x76, 0x27, 0xf0, 0x21, 0x6c, 0x39, 0x76, 0x42, 0xd9,
+	0x72, 0x6c, 0xed, 0xbf, 0x50, 0xba, 0x67, 0xb4, 0x5c, 0x9f, 0xb3, 0x07, 0x94, 0x6f, 0x01, 0xad,
+	0x34, 0xe5, 0x6c, 0x29, 0x18, 0xc7, 0x35, 0xd8, 0x0b, 0xef, 0x61, 0x44, 0xde, 0xa7, 0xab, 0x21,
+	0xed, 0xd7, 0x5c, 0xb2, 0x55, 0xca, 0x78, 0xe0, 0x7b, 0x9c, 0xe1, 0x26, 0x14, 0x62, 0x82, 0xe4,
+	0xcb, 0xcf, 0xb1, 0x9a, 0xbe, 0x53, 0x9b, 0xf2, 0x34, 0x25, 0xe2, 0x13, 0x50, 0x66, 0x16, 0x37,
+	0xe7, 0x7e, 0x18, 0xcf, 0x81, 0x42, 0x0b, 0x33, 0x8b, 0x5f, 0xf9, 0x61, 0x6a, 0x33, 0x9f, 0xda,
+	0xfc, 0xe4, 0xd1, 0x4e, 0xa1, 0xbc, 0xe6, 0x25, 0x6b, 0x7f, 0x13, 0xca, 0xef, 0x98, 0x98, 0xcc,
+	0x98, 0x6d, 0x86, 0x6c, 0xe2, 0x87, 0x36, 0x37, 0x27, 0xfe, 0xc2, 0x13, 0xc9, 0x59, 0x1c, 0x25,
+	0x49, 0x1a, 0xe7, 0x5a, 0x32, 0xf5, 0xc9, 0x63, 0x79, 0x05, 0x07, 0xeb, 0xb3, 0xa7, 0x42, 0x41,
+	0xba, 0xb8, 0x3f, 0x97, 0x14, 0xfe, 0xf5, 0x7c, 0x6b, 0xe7, 0x70, 0xb4, 0x3e, 0x61, 0xf1, 0x9b,
+	0xd8, 0x80, 0x02, 0xf3, 0x44, 0xe8, 0xb0, 0xb4, 0x77, 0x8f, 0xcc, 0x63, 0xca, 0xd2, 0xce, 0x57,
+	0xbe, 0x50, 0x0b, 0x57, 0x38, 0x81, 0xcb, 0xe4, 0x1f, 0xf8, 0x2d, 0x5b, 0xc6, 0x0a, 0x45, 0x1a,
+	0x3d, 0x7f, 0x76, 0x30, 0x5f, 0x40, 0x65, 0x53, 0x27, 0xb1, 0x54, 0x81, 0xdd, 0xc8, 0x72, 0xac,
+	0xb7, 0x4f, 0x13, 0xa4, 0xe9, 0x2b, 0x5f, 0xa0, 0xb4, 0xf2, 0xd7, 0xf2, 0xe8, 0xa3, 0x16, 0x26,
+	0xf6, 0x8f, 0x52, 0xfb, 0x3f, 0x86, 0x8e, 0x60, 0x71, 0x7b, 0x69, 0xca, 0xd1, 0x42, 0xd8, 0x5b,
+	0x89, 0xff, 0x53, 0xdf, 0x46, 0xf9, 0xaf, 0xee, 0x70, 0xd3, 0x66, 0x2e, 0x13, 0x2c, 0x7a, 0x45,
+	0x14, 0xaa, 0x38, 0xbc, 0x1d, 0x61, 0xed, 0xf7, 0x1c, 0x9c, 0x64, 0xd7, 0x02, 0xdd, 0xb6, 0x1d,
+	0xb9, 0xc4, 0x72, 0x87, 0x56, 0x68, 0xcd, 0x39, 0xfe, 0x3f, 0x94, 0x16, 0x9c, 0x99, 0x1f, 0xa4,
+	0x2b, 0x73, 0x6c, 0x89, 0xc9, 0x2c, 0xb2, 0xa3, 0xd0, 0x83, 0x05, 0x67, 0x91, 0xd7, 0x33, 0x19,
+	0xc4, 0x0d, 0x38, 0x9e, 0x5b, 0x77, 0x26, 0x77, 0x3e, 0xae, 0x93, 0xa5, 0xcf, 0x03, 0xfa, 0x74,
+	0x6e, 0xdd, 0xc9, 0xc9, 0x5e, 0x59, 0xf0, 0x12, 0xca, 0x52, 0x78, 0xca, 0x84, 0x39, 0x4f, 0xba,
+	0x65, 0x46, 0x87, 0x94, 0x8f, 0xe4, 0xf1, 0x82, 0xb3, 0x0e, 0x13, 0x69, 0x23, 0x2f, 0xe5, 0x91,
+	0x7d, 0x0f, 0xd5, 0xac, 0xc6, 0xc3, 0x75, 0xdb, 0x51, 0xa5, 0x4a, 0x52, 0x69, 0x63, 0x6d, 0xf3,
+	0xed, 0xca, 0xf5, 0x72, 0xb4, 0x08, 0x02, 0x3f, 0x14, 0xb8, 0x0d, 0x0a, 0x65, 0x53, 0x87, 0x0b,
+	0x16, 0x62, 0xf5, 0xb1, 0xcb, 0x65, 0xf5, 0xd1, 0x8c, 0xf6, 0xe4, 0x34, 0xf7, 0x22, 0xd7, 0x1c,
+	0x42, 0x31, 0xcb, 0xe0, 0x16, 0x14, 0x5a, 0xbe, 0xe7, 0xb1, 0x89, 0xf8, 0x72, 0xc5, 0xb3, 0x01,
+	0x68, 0x7e, 0x38, 0xad, 0xcf, 0x96, 0x01, 0x0b, 0x5d, 0x66, 0x4f, 0x59, 0x58, 0x7f, 0x67, 0x8d,
+	0x43, 0x67, 0x92, 0xae, 0x93, 0x37, 0xec, 0x9f, 0xbe, 0x9a, 0x3a, 0x62, 0xb6, 0x18, 0xd7, 0x27,
+	0xfe, 0xbc, 0xb1, 0x42, 0x6d, 0xc4, 0xd4, 0xf8, 0xa6, 0xcd, 0x1b, 0x92, 0x3a, 0x8e, 0xaf, 0xed,
+	0xdf, 0xfc, 0x39, 0x00, 0x6e, 0x73, 0x6b, 0x11, 0xda, 0x0b, 0x00, 0x00,
 }
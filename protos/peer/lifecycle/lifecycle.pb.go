@@ -6,6 +6,7 @@ package lifecycle // import "github.com/hyperledger/fabric/protos/peer/lifecycle
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -33,7 +34,7 @@ func (m *InstallChaincodeArgs) Reset()         { *m = InstallChaincodeArgs{} }
 func (m *InstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeArgs) ProtoMessage()    {}
 func (*InstallChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{0}
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{0}
 }
 func (m *InstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeArgs.Unmarshal(m, b)
@@ -87,7 +88,7 @@ func (m *InstallChaincodeResult) Reset()         { *m = InstallChaincodeResult{}
 func (m *InstallChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeResult) ProtoMessage()    {}
 func (*InstallChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{1}
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{1}
 }
 func (m *InstallChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeResult.Unmarshal(m, b)
@@ -128,7 +129,7 @@ func (m *QueryInstalledChaincodeArgs) Reset()         { *m = QueryInstalledChain
 func (m *QueryInstalledChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeArgs) ProtoMessage()    {}
 func (*QueryInstalledChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{2}
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{2}
 }
 func (m *QueryInstalledChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeArgs.Unmarshal(m, b)
@@ -175,7 +176,7 @@ func (m *QueryInstalledChaincodeResult) Reset()         { *m = QueryInstalledCha
 func (m *QueryInstalledChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeResult) ProtoMessage()    {}
 func (*QueryInstalledChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{3}
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{3}
 }
 func (m *QueryInstalledChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeResult.Unmarshal(m, b)
@@ -202,33 +203,474 @@ func (m *QueryInstalledChaincodeResult) GetHash() []byte {
 	return nil
 }
 
+// ChaincodeDefinition is the definition of a chaincode which the
+// organizations of a channel approve and commit to the channel
+type ChaincodeDefinition struct {
+	Sequence             int64                           `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                          `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                          `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                          `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                          `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *common.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *ChaincodeDefinition) Reset()         { *m = ChaincodeDefinition{} }
+func (m *ChaincodeDefinition) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeDefinition) ProtoMessage()    {}
+func (*ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{4}
+}
+func (m *ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeDefinition.Merge(dst, src)
+}
+func (m *ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeDefinition.Size(m)
+}
+func (m *ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *ChaincodeDefinition) GetCollections() *common.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+// ApproveChaincodeDefinitionForMyOrgArgs is the message used as the argument to
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgArgs{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgArgs) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{5}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgArgs proto.InternalMessageInfo
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// ApproveChaincodeDefinitionForMyOrgResult is the message returned by
+// '+lifecycle.ApproveChaincodeDefinitionForMyOrg'
+type ApproveChaincodeDefinitionForMyOrgResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveChaincodeDefinitionForMyOrgResult) Reset() {
+	*m = ApproveChaincodeDefinitionForMyOrgResult{}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) String() string { return proto.CompactTextString(m) }
+func (*ApproveChaincodeDefinitionForMyOrgResult) ProtoMessage()    {}
+func (*ApproveChaincodeDefinitionForMyOrgResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{6}
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Unmarshal(m, b)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Marshal(b, m, deterministic)
+}
+func (dst *ApproveChaincodeDefinitionForMyOrgResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Merge(dst, src)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_Size() int {
+	return xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.Size(m)
+}
+func (m *ApproveChaincodeDefinitionForMyOrgResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveChaincodeDefinitionForMyOrgResult proto.InternalMessageInfo
+
+// CheckCommitReadinessArgs is the message used as the argument to
+// '+lifecycle.CheckCommitReadiness'
+type CheckCommitReadinessArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CheckCommitReadinessArgs) Reset()         { *m = CheckCommitReadinessArgs{} }
+func (m *CheckCommitReadinessArgs) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessArgs) ProtoMessage()    {}
+func (*CheckCommitReadinessArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{7}
+}
+func (m *CheckCommitReadinessArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessArgs.Merge(dst, src)
+}
+func (m *CheckCommitReadinessArgs) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessArgs.Size(m)
+}
+func (m *CheckCommitReadinessArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessArgs proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CheckCommitReadinessArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// CheckCommitReadinessResult is the message returned by
+// '+lifecycle.CheckCommitReadiness'. It maps each organization
+// of the channel to whether it approved the definition.
+type CheckCommitReadinessResult struct {
+	Approvals            map[string]bool `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CheckCommitReadinessResult) Reset()         { *m = CheckCommitReadinessResult{} }
+func (m *CheckCommitReadinessResult) String() string { return proto.CompactTextString(m) }
+func (*CheckCommitReadinessResult) ProtoMessage()    {}
+func (*CheckCommitReadinessResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{8}
+}
+func (m *CheckCommitReadinessResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCommitReadinessResult.Unmarshal(m, b)
+}
+func (m *CheckCommitReadinessResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCommitReadinessResult.Marshal(b, m, deterministic)
+}
+func (dst *CheckCommitReadinessResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCommitReadinessResult.Merge(dst, src)
+}
+func (m *CheckCommitReadinessResult) XXX_Size() int {
+	return xxx_messageInfo_CheckCommitReadinessResult.Size(m)
+}
+func (m *CheckCommitReadinessResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCommitReadinessResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCommitReadinessResult proto.InternalMessageInfo
+
+func (m *CheckCommitReadinessResult) GetApprovals() map[string]bool {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+// CommitChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionArgs struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Definition           *ChaincodeDefinition `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionArgs) Reset()         { *m = CommitChaincodeDefinitionArgs{} }
+func (m *CommitChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{9}
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionArgs.Size(m)
+}
+func (m *CommitChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *CommitChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CommitChaincodeDefinitionArgs) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
+// CommitChaincodeDefinitionResult is the message returned by
+// '+lifecycle.CommitChaincodeDefinition'
+type CommitChaincodeDefinitionResult struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitChaincodeDefinitionResult) Reset()         { *m = CommitChaincodeDefinitionResult{} }
+func (m *CommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*CommitChaincodeDefinitionResult) ProtoMessage()    {}
+func (*CommitChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{10}
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *CommitChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_CommitChaincodeDefinitionResult.Size(m)
+}
+func (m *CommitChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChaincodeDefinitionResult proto.InternalMessageInfo
+
+// QueryChaincodeDefinitionArgs is the message used as the argument to
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionArgs) Reset()         { *m = QueryChaincodeDefinitionArgs{} }
+func (m *QueryChaincodeDefinitionArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{11}
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionResult is the message returned by
+// '+lifecycle.QueryChaincodeDefinition'
+type QueryChaincodeDefinitionResult struct {
+	Definition           *ChaincodeDefinition `protobuf:"bytes,1,opt,name=definition,proto3" json:"definition,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionResult) Reset()         { *m = QueryChaincodeDefinitionResult{} }
+func (m *QueryChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_61135e4bd6cc2e02, []int{12}
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryChaincodeDefinitionResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.Merge(dst, src)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionResult) GetDefinition() *ChaincodeDefinition {
+	if m != nil {
+		return m.Definition
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*InstallChaincodeArgs)(nil), "lifecycle.InstallChaincodeArgs")
 	proto.RegisterType((*InstallChaincodeResult)(nil), "lifecycle.InstallChaincodeResult")
 	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "lifecycle.QueryInstalledChaincodeArgs")
 	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*ChaincodeDefinition)(nil), "lifecycle.ChaincodeDefinition")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgArgs)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgArgs")
+	proto.RegisterType((*ApproveChaincodeDefinitionForMyOrgResult)(nil), "lifecycle.ApproveChaincodeDefinitionForMyOrgResult")
+	proto.RegisterType((*CheckCommitReadinessArgs)(nil), "lifecycle.CheckCommitReadinessArgs")
+	proto.RegisterType((*CheckCommitReadinessResult)(nil), "lifecycle.CheckCommitReadinessResult")
+	proto.RegisterMapType((map[string]bool)(nil), "lifecycle.CheckCommitReadinessResult.ApprovalsEntry")
+	proto.RegisterType((*CommitChaincodeDefinitionArgs)(nil), "lifecycle.CommitChaincodeDefinitionArgs")
+	proto.RegisterType((*CommitChaincodeDefinitionResult)(nil), "lifecycle.CommitChaincodeDefinitionResult")
+	proto.RegisterType((*QueryChaincodeDefinitionArgs)(nil), "lifecycle.QueryChaincodeDefinitionArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionResult)(nil), "lifecycle.QueryChaincodeDefinitionResult")
 }
 
-func init() {
-	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_f98901bea638af10)
-}
-
-var fileDescriptor_lifecycle_f98901bea638af10 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0xc5, 0x15, 0x40, 0xa0, 0x9e, 0x3a, 0x59, 0x08, 0x82, 0x10, 0xa8, 0xca, 0xd4, 0xa1, 0xb2,
-	0x87, 0x6e, 0x6c, 0xc0, 0x84, 0x58, 0x20, 0x23, 0x4b, 0xe5, 0x38, 0x57, 0xdb, 0xc2, 0x8d, 0xa3,
-	0x73, 0x82, 0x94, 0x8d, 0x8f, 0x8e, 0x6a, 0xb7, 0xe1, 0x8f, 0xd4, 0x89, 0xed, 0xf9, 0xd9, 0xef,
-	0xfc, 0xd3, 0x3b, 0xb8, 0x6d, 0x11, 0x49, 0x38, 0xbb, 0x46, 0x35, 0x28, 0x87, 0xdf, 0x8a, 0xb7,
-	0xe4, 0x3b, 0xcf, 0x26, 0xa3, 0x51, 0x7c, 0x66, 0x70, 0xfe, 0xd4, 0x84, 0x4e, 0x3a, 0xf7, 0x68,
-	0xa4, 0x6d, 0x94, 0xaf, 0xf1, 0x9e, 0x74, 0x60, 0x0c, 0x4e, 0x1a, 0xb9, 0xc1, 0x3c, 0x9b, 0x65,
-	0xf3, 0x49, 0x19, 0x35, 0xcb, 0xe1, 0xec, 0x03, 0x29, 0x58, 0xdf, 0xe4, 0x47, 0xd1, 0xde, 0x1f,
-	0xd9, 0x1d, 0x5c, 0xa9, 0x7d, 0x7c, 0x65, 0xd3, 0xbc, 0x55, 0x2b, 0xd5, 0xbb, 0xd4, 0x98, 0x1f,
-	0xcf, 0xb2, 0xf9, 0xb4, 0xbc, 0x1c, 0x1f, 0xec, 0xfe, 0x7b, 0x49, 0xd7, 0xc5, 0x02, 0x2e, 0xfe,
-	0x12, 0x94, 0x18, 0x7a, 0xd7, 0x6d, 0x19, 0x8c, 0x0c, 0x26, 0x32, 0x4c, 0xcb, 0xa8, 0x8b, 0x67,
-	0xb8, 0x7e, 0xed, 0x91, 0x86, 0x5d, 0x04, 0xeb, 0x7f, 0x60, 0x17, 0x4b, 0xb8, 0x39, 0x30, 0xec,
-	0x30, 0xc1, 0x83, 0x82, 0x85, 0x27, 0xcd, 0xcd, 0xd0, 0x22, 0x39, 0xac, 0x35, 0x12, 0x5f, 0xcb,
-	0x8a, 0xac, 0x4a, 0xed, 0x06, 0xbe, 0x6d, 0x9f, 0x8f, 0x15, 0xbf, 0x2d, 0xb5, 0xed, 0x4c, 0x5f,
-	0x71, 0xe5, 0x37, 0xe2, 0x47, 0x48, 0xa4, 0x90, 0x48, 0x21, 0xf1, 0x7b, 0x65, 0xd5, 0x69, 0xb4,
-	0x97, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x6e, 0xec, 0xb6, 0xcb, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_61135e4bd6cc2e02) }
+
+var fileDescriptor_lifecycle_61135e4bd6cc2e02 = []byte{
+	// 571 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x5f, 0x6f, 0xd3, 0x30,
+	0x10, 0x57, 0xd6, 0x6d, 0xac, 0xd7, 0x09, 0x6d, 0xd9, 0xc4, 0x42, 0x61, 0x5b, 0xc9, 0x03, 0xaa,
+	0x60, 0xa4, 0xa2, 0xe3, 0x01, 0x4d, 0x08, 0xa9, 0x14, 0x90, 0x10, 0x42, 0x0c, 0x3f, 0xf2, 0x32,
+	0x3c, 0xe7, 0x9a, 0x5a, 0x73, 0xec, 0x60, 0xa7, 0x95, 0x2a, 0xf1, 0xc0, 0x17, 0xe1, 0x2b, 0xf0,
+	0x19, 0x51, 0x9c, 0x34, 0x49, 0x51, 0x3b, 0x34, 0xa1, 0xbd, 0x9d, 0x7d, 0xbf, 0xdf, 0xdd, 0xef,
+	0xfe, 0xd8, 0x70, 0x94, 0x20, 0xea, 0x9e, 0xe0, 0x23, 0x64, 0x33, 0x26, 0xb0, 0xb2, 0x82, 0x44,
+	0xab, 0x54, 0xb9, 0xcd, 0xf2, 0xa2, 0x7d, 0xc0, 0x54, 0x1c, 0x2b, 0xd9, 0x63, 0x4a, 0x08, 0x64,
+	0x29, 0x57, 0x32, 0xc7, 0xf8, 0x3f, 0x1d, 0xd8, 0xff, 0x20, 0x4d, 0x4a, 0x85, 0x18, 0x8e, 0x29,
+	0x97, 0x4c, 0x85, 0x38, 0xd0, 0x91, 0x71, 0x5d, 0x58, 0x97, 0x34, 0x46, 0xcf, 0xe9, 0x38, 0xdd,
+	0x26, 0xb1, 0xb6, 0xeb, 0xc1, 0x9d, 0x29, 0x6a, 0xc3, 0x95, 0xf4, 0xd6, 0xec, 0xf5, 0xfc, 0xe8,
+	0x9e, 0xc1, 0x7d, 0x36, 0xa7, 0x5f, 0xf0, 0x3c, 0xde, 0x45, 0x42, 0xd9, 0x15, 0x8d, 0xd0, 0x6b,
+	0x74, 0x9c, 0xee, 0x36, 0x39, 0x28, 0x01, 0x45, 0xbe, 0xf3, 0xdc, 0xed, 0x9f, 0xc0, 0xbd, 0xbf,
+	0x15, 0x10, 0x34, 0x13, 0x91, 0x66, 0x1a, 0xc6, 0xd4, 0x8c, 0xad, 0x86, 0x6d, 0x62, 0x6d, 0xff,
+	0x23, 0x3c, 0xf8, 0x32, 0x41, 0x3d, 0x2b, 0x28, 0x18, 0xfe, 0x87, 0x6c, 0xff, 0x14, 0x0e, 0x57,
+	0x04, 0xbb, 0x46, 0xc1, 0xaf, 0x35, 0xd8, 0x2b, 0x71, 0x6f, 0x71, 0xc4, 0x25, 0xcf, 0x1a, 0xea,
+	0xb6, 0x61, 0xcb, 0xe0, 0xf7, 0x09, 0x4a, 0x96, 0xa7, 0x6f, 0x90, 0xf2, 0x7c, 0x4d, 0xe7, 0x9e,
+	0x81, 0x8b, 0x32, 0x54, 0xda, 0x60, 0x8c, 0x32, 0xbd, 0x48, 0xc4, 0x24, 0xe2, 0xd2, 0xb6, 0xac,
+	0x49, 0x76, 0x6b, 0x9e, 0x73, 0xeb, 0x70, 0x9f, 0xc2, 0xee, 0x94, 0x0a, 0x1e, 0xd2, 0x2c, 0xe5,
+	0x1c, 0xbd, 0x6e, 0xd1, 0x3b, 0x95, 0xa3, 0x00, 0x3f, 0x87, 0xfd, 0x3a, 0x98, 0x6a, 0x1a, 0x63,
+	0x8a, 0xda, 0xdb, 0xb0, 0xd5, 0xec, 0xd5, 0xf0, 0x73, 0x97, 0x3b, 0x80, 0x56, 0xb5, 0x23, 0xc6,
+	0xdb, 0xec, 0x38, 0xdd, 0x56, 0xff, 0x38, 0xc8, 0xd7, 0x27, 0x18, 0x96, 0xae, 0xa1, 0x92, 0x23,
+	0x1e, 0x15, 0x23, 0x24, 0x75, 0x8e, 0xff, 0x03, 0x1e, 0x0f, 0x92, 0x44, 0xab, 0x29, 0x2e, 0xe9,
+	0xd2, 0x7b, 0xa5, 0x3f, 0xcd, 0x3e, 0xeb, 0x68, 0xe5, 0xb0, 0x5e, 0x03, 0x84, 0x25, 0xda, 0x36,
+	0xab, 0xd5, 0x3f, 0x0a, 0xaa, 0xd5, 0x5e, 0x12, 0x93, 0xd4, 0x18, 0xfe, 0x13, 0xe8, 0xfe, 0x3b,
+	0x7b, 0x3e, 0x5d, 0x5f, 0x82, 0x37, 0x1c, 0x23, 0xbb, 0x1a, 0xaa, 0x38, 0xe6, 0x29, 0x41, 0x1a,
+	0x72, 0x89, 0xc6, 0xdc, 0x9a, 0xb6, 0xdf, 0x0e, 0xb4, 0x97, 0x25, 0x2c, 0x96, 0x8d, 0x40, 0x93,
+	0x5a, 0xe9, 0x54, 0x18, 0xcf, 0xe9, 0x34, 0xba, 0xad, 0xfe, 0x8b, 0x85, 0xe8, 0xab, 0x98, 0xc1,
+	0x60, 0x4e, 0x7b, 0x27, 0x53, 0x3d, 0x23, 0x55, 0x98, 0xf6, 0x2b, 0xb8, 0xbb, 0xe8, 0x74, 0x77,
+	0xa0, 0x71, 0x85, 0xb3, 0xa2, 0xae, 0xcc, 0x74, 0xf7, 0x61, 0x63, 0x4a, 0xc5, 0x04, 0x6d, 0x45,
+	0x5b, 0x24, 0x3f, 0x9c, 0xad, 0xbd, 0x74, 0x7c, 0x03, 0x87, 0x79, 0xc2, 0x25, 0x95, 0xdd, 0x5a,
+	0x97, 0x1e, 0xc1, 0xf1, 0xca, 0xa4, 0xc5, 0xe0, 0xfa, 0xf0, 0xd0, 0xbe, 0xdb, 0x1b, 0xc8, 0xf2,
+	0xbf, 0xc1, 0xd1, 0x2a, 0x4e, 0xd1, 0xff, 0x45, 0xe1, 0xce, 0x4d, 0x85, 0xbf, 0x61, 0x70, 0xa2,
+	0x74, 0x14, 0x8c, 0x67, 0x09, 0x6a, 0x81, 0x61, 0x84, 0x3a, 0x18, 0xd1, 0x4b, 0xcd, 0x59, 0xfe,
+	0xd7, 0x9a, 0x20, 0xfb, 0xaf, 0xab, 0x78, 0x5f, 0x4f, 0x23, 0x9e, 0x8e, 0x27, 0x97, 0xd9, 0xe3,
+	0xea, 0xd5, 0x48, 0xbd, 0x9c, 0xd4, 0xcb, 0x49, 0xbd, 0xc5, 0x4f, 0xfe, 0x72, 0xd3, 0x5e, 0x9f,
+	0xfe, 0x19, 0x00, 0xd2, 0x38, 0xe3, 0xc6, 0xfd, 0x05, 0x00, 0x00,
 }
@@ -59,7 +59,7 @@ func (m *SignedProposal) Reset()         { *m = SignedProposal{} }
 func (m *SignedProposal) String() string { return proto.CompactTextString(m) }
 func (*SignedProposal) ProtoMessage()    {}
 func (*SignedProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{0}
+	return fileDescriptor_proposal_b5358a345035162a, []int{0}
 }
 func (m *SignedProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedProposal.Unmarshal(m, b)
@@ -131,7 +131,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{1}
+	return fileDescriptor_proposal_b5358a345035162a, []int{1}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proposal.Unmarshal(m, b)
@@ -199,7 +199,7 @@ func (m *ChaincodeHeaderExtension) Reset()         { *m = ChaincodeHeaderExtensi
 func (m *ChaincodeHeaderExtension) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeHeaderExtension) ProtoMessage()    {}
 func (*ChaincodeHeaderExtension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{2}
+	return fileDescriptor_proposal_b5358a345035162a, []int{2}
 }
 func (m *ChaincodeHeaderExtension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeHeaderExtension.Unmarshal(m, b)
@@ -255,7 +255,7 @@ func (m *ChaincodeProposalPayload) Reset()         { *m = ChaincodeProposalPaylo
 func (m *ChaincodeProposalPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()    {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{3}
+	return fileDescriptor_proposal_b5358a345035162a, []int{3}
 }
 func (m *ChaincodeProposalPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeProposalPayload.Unmarshal(m, b)
@@ -308,17 +308,27 @@ type ChaincodeAction struct {
 	ChaincodeId *ChaincodeID `protobuf:"bytes,4,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
 	// This field contains the token expectation generated by the chaincode
 	// executing this invocation
-	TokenExpectation     *token.TokenExpectation `protobuf:"bytes,5,opt,name=token_expectation,json=tokenExpectation,proto3" json:"token_expectation,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	TokenExpectation *token.TokenExpectation `protobuf:"bytes,5,opt,name=token_expectation,json=tokenExpectation,proto3" json:"token_expectation,omitempty"`
+	// This field contains the reads performed on other channels by the
+	// chaincodes invoked read-only across channels during this invocation.
+	// It is only set by endorsers configured to record cross-channel read
+	// proofs.
+	CrossChannelReads []*CrossChannelRead `protobuf:"bytes,6,rep,name=cross_channel_reads,json=crossChannelReads,proto3" json:"cross_channel_reads,omitempty"`
+	// This field contains the events generated by the chaincode executing this
+	// invocation, in the order they were set, when it set more than one. The
+	// events field then holds the last of them, as it did before transactions
+	// could carry several events.
+	ChaincodeEvents      []*ChaincodeEvent `protobuf:"bytes,7,rep,name=chaincode_events,json=chaincodeEvents,proto3" json:"chaincode_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ChaincodeAction) Reset()         { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()    {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{4}
+	return fileDescriptor_proposal_b5358a345035162a, []int{4}
 }
 func (m *ChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeAction.Unmarshal(m, b)
@@ -373,6 +383,84 @@ func (m *ChaincodeAction) GetTokenExpectation() *token.TokenExpectation {
 	return nil
 }
 
+func (m *ChaincodeAction) GetCrossChannelReads() []*CrossChannelRead {
+	if m != nil {
+		return m.CrossChannelReads
+	}
+	return nil
+}
+
+func (m *ChaincodeAction) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
+// CrossChannelRead records what a chaincode invoked read-only on another
+// channel read while a proposal was simulated, so that the read set of the
+// other channel is the only effect the invocation has on the transaction.
+// The height of the ledger of the other channel is not part of it, as the
+// endorsers may be at different heights; each endorser reports its height
+// in the cross_channel_read_heights of its ProposalResponse instead.
+type CrossChannelRead struct {
+	// This field contains the ID of the channel the chaincode was invoked on.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// This field contains the name of the chaincode invoked on the channel.
+	ChaincodeName string `protobuf:"bytes,2,opt,name=chaincode_name,json=chaincodeName,proto3" json:"chaincode_name,omitempty"`
+	// This field contains the read set of the invocation, a marshaled
+	// TxReadWriteSet.
+	Results              []byte   `protobuf:"bytes,4,opt,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossChannelRead) Reset()         { *m = CrossChannelRead{} }
+func (m *CrossChannelRead) String() string { return proto.CompactTextString(m) }
+func (*CrossChannelRead) ProtoMessage()    {}
+func (*CrossChannelRead) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_b5358a345035162a, []int{5}
+}
+func (m *CrossChannelRead) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossChannelRead.Unmarshal(m, b)
+}
+func (m *CrossChannelRead) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossChannelRead.Marshal(b, m, deterministic)
+}
+func (dst *CrossChannelRead) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossChannelRead.Merge(dst, src)
+}
+func (m *CrossChannelRead) XXX_Size() int {
+	return xxx_messageInfo_CrossChannelRead.Size(m)
+}
+func (m *CrossChannelRead) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossChannelRead.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossChannelRead proto.InternalMessageInfo
+
+func (m *CrossChannelRead) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *CrossChannelRead) GetChaincodeName() string {
+	if m != nil {
+		return m.ChaincodeName
+	}
+	return ""
+}
+
+func (m *CrossChannelRead) GetResults() []byte {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignedProposal)(nil), "protos.SignedProposal")
 	proto.RegisterType((*Proposal)(nil), "protos.Proposal")
@@ -380,41 +468,49 @@ func init() {
 	proto.RegisterType((*ChaincodeProposalPayload)(nil), "protos.ChaincodeProposalPayload")
 	proto.RegisterMapType((map[string][]byte)(nil), "protos.ChaincodeProposalPayload.TransientMapEntry")
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
-}
-
-func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_2be65988745cf952) }
-
-var fileDescriptor_proposal_2be65988745cf952 = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x57, 0x5a, 0x36, 0x36, 0xb7, 0x6c, 0xad, 0x37, 0xa1, 0xa8, 0xda, 0x61, 0x8a, 0x84, 0x34,
-	0x24, 0x48, 0xa4, 0x22, 0x21, 0xc4, 0x05, 0x51, 0xa8, 0xc4, 0x0e, 0x48, 0x53, 0x18, 0x3b, 0xec,
-	0x52, 0x9c, 0xe4, 0x91, 0x5a, 0x0d, 0xb6, 0x65, 0x3b, 0xd5, 0x72, 0xe4, 0xe3, 0xf1, 0x6d, 0xf8,
-	0x08, 0xc8, 0xb1, 0x9d, 0x76, 0xed, 0x85, 0x53, 0xf2, 0xde, 0xef, 0xfd, 0x7e, 0xef, 0xaf, 0xd1,
-	0x99, 0x00, 0x90, 0x89, 0x90, 0x5c, 0x70, 0x45, 0xaa, 0x58, 0x48, 0xae, 0x39, 0x3e, 0x6c, 0x3f,
-	0x6a, 0x72, 0xde, 0x82, 0xf9, 0x92, 0x50, 0x96, 0xf3, 0x02, 0x2c, 0x3a, 0xb9, 0x78, 0x44, 0x59,
-	0x48, 0x50, 0x82, 0x33, 0xe5, 0xd1, 0x50, 0xf3, 0x15, 0xb0, 0x04, 0x1e, 0x04, 0xe4, 0x9a, 0x68,
-	0xca, 0x99, 0xb2, 0x48, 0xf4, 0x1d, 0x9d, 0x7c, 0xa3, 0x25, 0x83, 0xe2, 0xc6, 0x51, 0xf1, 0x0b,
-	0x74, 0xd2, 0xc9, 0x64, 0x8d, 0x06, 0x15, 0x06, 0x97, 0xc1, 0xd5, 0x30, 0x7d, 0xe6, 0xbd, 0x33,
-	0xe3, 0xc4, 0x17, 0xe8, 0x58, 0xd1, 0x92, 0x11, 0x5d, 0x4b, 0x08, 0x7b, 0x6d, 0xc4, 0xc6, 0x11,
-	0xdd, 0xa3, 0xa3, 0x4e, 0xf0, 0x39, 0x3a, 0x5c, 0x02, 0x29, 0x40, 0x3a, 0x21, 0x67, 0xe1, 0x10,
-	0x3d, 0x15, 0xa4, 0xa9, 0x38, 0x29, 0x1c, 0xdf, 0x9b, 0x46, 0x1b, 0x1e, 0x34, 0x30, 0x45, 0x39,
-	0x0b, 0xfb, 0x56, 0xbb, 0x73, 0x44, 0xbf, 0x03, 0x14, 0x7e, 0xf2, 0xed, 0x7f, 0x69, 0xb5, 0xe6,
-	0x1e, 0xc4, 0xaf, 0x11, 0x76, 0x2a, 0x8b, 0x35, 0x55, 0x34, 0xa3, 0x15, 0xd5, 0x8d, 0x4b, 0x3c,
-	0x76, 0xc8, 0x5d, 0x07, 0xe0, 0xb7, 0x68, 0xd8, 0x4d, 0x72, 0x41, 0x6d, 0x21, 0x83, 0xe9, 0x99,
-	0x1d, 0x8e, 0x8a, 0xbb, 0x34, 0xd7, 0x9f, 0xd3, 0x41, 0x17, 0x78, 0x5d, 0x44, 0x7f, 0xb6, 0x6b,
-	0xf0, 0x9d, 0xde, 0xb8, 0xf2, 0xcf, 0xd1, 0x01, 0x65, 0xa2, 0xd6, 0x2e, 0xad, 0x35, 0xf0, 0x1d,
-	0x1a, 0xde, 0x4a, 0xc2, 0x14, 0x05, 0xa6, 0xbf, 0x12, 0x11, 0xf6, 0x2e, 0xfb, 0x57, 0x83, 0xe9,
-	0x74, 0x2f, 0xd5, 0x8e, 0x5a, 0xbc, 0x4d, 0x9a, 0x33, 0x2d, 0x9b, 0xf4, 0x91, 0xce, 0xe4, 0x03,
-	0x1a, 0xef, 0x85, 0xe0, 0x11, 0xea, 0xaf, 0xc0, 0xf6, 0x7d, 0x9c, 0x9a, 0x5f, 0x53, 0xd4, 0x9a,
-	0x54, 0xb5, 0xdf, 0x95, 0x35, 0xde, 0xf7, 0xde, 0x05, 0xd1, 0xdf, 0x00, 0x9d, 0x76, 0xd9, 0x3f,
-	0xe6, 0xe6, 0x3a, 0xcc, 0x6e, 0x24, 0xa8, 0xba, 0xd2, 0x7e, 0xfb, 0xde, 0x34, 0xdb, 0x84, 0x35,
-	0x30, 0xad, 0x9c, 0x90, 0xb3, 0xf0, 0x2b, 0x74, 0xe4, 0x8f, 0xae, 0x5d, 0xd9, 0x60, 0x3a, 0xf2,
-	0xad, 0xa5, 0xce, 0x9f, 0x76, 0x11, 0x7b, 0x73, 0x7f, 0xf2, 0x7f, 0x73, 0xc7, 0x73, 0x34, 0x6e,
-	0x4f, 0x79, 0xb1, 0x75, 0xca, 0xe1, 0x41, 0x4b, 0x0e, 0x3d, 0xf9, 0xd6, 0x04, 0xcc, 0x37, 0x78,
-	0x3a, 0xd2, 0x3b, 0x9e, 0xd9, 0x0f, 0x14, 0x71, 0x59, 0xc6, 0xcb, 0x46, 0x80, 0xac, 0xa0, 0x28,
-	0x41, 0xc6, 0x3f, 0x49, 0x26, 0x69, 0xee, 0x35, 0xcc, 0x6b, 0x9a, 0x9d, 0x6e, 0x56, 0x91, 0xaf,
-	0x48, 0x09, 0xf7, 0x2f, 0x4b, 0xaa, 0x97, 0x75, 0x16, 0xe7, 0xfc, 0x57, 0xb2, 0xc5, 0x4d, 0x2c,
-	0x37, 0xb1, 0xdc, 0xc4, 0x70, 0x33, 0xfb, 0x5a, 0xdf, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xaa,
-	0xf2, 0x0a, 0xf6, 0xcb, 0x03, 0x00, 0x00,
+	proto.RegisterType((*CrossChannelRead)(nil), "protos.CrossChannelRead")
+}
+
+func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_b5358a345035162a) }
+
+var fileDescriptor_proposal_b5358a345035162a = []byte{
+	// 594 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x6b, 0xdb, 0x3e,
+	0x14, 0x25, 0x1f, 0xfd, 0x88, 0xd2, 0x0f, 0x47, 0x2d, 0xc5, 0x84, 0xfe, 0xa0, 0x18, 0x7e, 0xd0,
+	0xc1, 0x66, 0x43, 0x06, 0x63, 0xec, 0x65, 0xb4, 0x5d, 0xa0, 0x1d, 0x6c, 0x14, 0xaf, 0xeb, 0x43,
+	0x5f, 0x32, 0xc5, 0xbe, 0x73, 0x44, 0x1c, 0xc9, 0x48, 0x4a, 0x48, 0x1e, 0xf7, 0xe7, 0xed, 0x8f,
+	0x1a, 0x0c, 0x59, 0x92, 0xe3, 0x24, 0x2f, 0x7b, 0x4a, 0xee, 0xb9, 0xf7, 0x9c, 0x7b, 0xa5, 0x73,
+	0x65, 0x74, 0x56, 0x00, 0x88, 0xa8, 0x10, 0xbc, 0xe0, 0x92, 0xe4, 0x61, 0x21, 0xb8, 0xe2, 0x78,
+	0xbf, 0xfc, 0x91, 0xfd, 0xf3, 0x32, 0x99, 0x4c, 0x08, 0x65, 0x09, 0x4f, 0xc1, 0x64, 0xfb, 0xfd,
+	0x4d, 0x74, 0x04, 0x0b, 0x60, 0xca, 0xe6, 0x2e, 0x37, 0xe4, 0x46, 0x02, 0x64, 0xc1, 0x99, 0x74,
+	0x4c, 0x5f, 0xf1, 0x29, 0xb0, 0x08, 0x96, 0x05, 0x24, 0x8a, 0x28, 0xca, 0x99, 0x34, 0x99, 0xe0,
+	0x3b, 0x3a, 0xf9, 0x46, 0x33, 0x06, 0xe9, 0xa3, 0xa5, 0xe2, 0xff, 0xd1, 0x49, 0x25, 0x33, 0x5e,
+	0x29, 0x90, 0x7e, 0xe3, 0xaa, 0x71, 0x7d, 0x14, 0x1f, 0x3b, 0xf4, 0x56, 0x83, 0xf8, 0x12, 0x75,
+	0x24, 0xcd, 0x18, 0x51, 0x73, 0x01, 0x7e, 0xb3, 0xac, 0x58, 0x03, 0xc1, 0x0b, 0x3a, 0xac, 0x04,
+	0x2f, 0xd0, 0xfe, 0x04, 0x48, 0x0a, 0xc2, 0x0a, 0xd9, 0x08, 0xfb, 0xe8, 0xa0, 0x20, 0xab, 0x9c,
+	0x93, 0xd4, 0xf2, 0x5d, 0xa8, 0xb5, 0x61, 0xa9, 0x80, 0x49, 0xca, 0x99, 0xdf, 0x32, 0xda, 0x15,
+	0x10, 0xfc, 0x6a, 0x20, 0xff, 0xce, 0x5d, 0xc2, 0x7d, 0xa9, 0x35, 0x74, 0x49, 0xfc, 0x06, 0x61,
+	0xab, 0x32, 0x5a, 0x50, 0x49, 0xc7, 0x34, 0xa7, 0x6a, 0x65, 0x1b, 0xf7, 0x6c, 0xe6, 0xb9, 0x4a,
+	0xe0, 0x77, 0xe8, 0x68, 0x7d, 0x9f, 0xd4, 0x0c, 0xd2, 0x1d, 0x9c, 0x99, 0xcb, 0x91, 0x61, 0xd5,
+	0xe6, 0xe1, 0x53, 0xdc, 0xad, 0x0a, 0x1f, 0xd2, 0xe0, 0x77, 0x7d, 0x06, 0x77, 0xd2, 0x47, 0x3b,
+	0xfe, 0x39, 0xda, 0xa3, 0xac, 0x98, 0x2b, 0xdb, 0xd6, 0x04, 0xf8, 0x19, 0x1d, 0x3d, 0x09, 0xc2,
+	0x24, 0x05, 0xa6, 0xbe, 0x90, 0xc2, 0x6f, 0x5e, 0xb5, 0xae, 0xbb, 0x83, 0xc1, 0x4e, 0xab, 0x2d,
+	0xb5, 0xb0, 0x4e, 0x1a, 0x32, 0x25, 0x56, 0xf1, 0x86, 0x4e, 0xff, 0x23, 0xea, 0xed, 0x94, 0x60,
+	0x0f, 0xb5, 0xa6, 0x60, 0xce, 0xdd, 0x89, 0xf5, 0x5f, 0x3d, 0xd4, 0x82, 0xe4, 0x73, 0xe7, 0x95,
+	0x09, 0x3e, 0x34, 0xdf, 0x37, 0x82, 0x3f, 0x4d, 0x74, 0x5a, 0x75, 0xbf, 0x49, 0xf4, 0x76, 0x68,
+	0x6f, 0x04, 0xc8, 0x79, 0xae, 0x9c, 0xfb, 0x2e, 0xd4, 0x6e, 0x96, 0x7b, 0x27, 0xad, 0x90, 0x8d,
+	0xf0, 0x6b, 0x74, 0xe8, 0x96, 0xae, 0xb4, 0xac, 0x3b, 0xf0, 0xdc, 0xd1, 0x62, 0x8b, 0xc7, 0x55,
+	0xc5, 0xce, 0xbd, 0xb7, 0xff, 0xed, 0xde, 0xf1, 0x10, 0xf5, 0xca, 0x55, 0x1e, 0xd5, 0x56, 0xd9,
+	0xdf, 0x2b, 0xc9, 0xbe, 0x23, 0x3f, 0xe9, 0x82, 0xe1, 0x3a, 0x1f, 0x7b, 0x6a, 0x0b, 0xc1, 0xf7,
+	0xe8, 0x2c, 0x11, 0x5c, 0xca, 0x51, 0x32, 0x21, 0x8c, 0x81, 0x7e, 0x2f, 0x24, 0x95, 0xfe, 0xfe,
+	0x55, 0xab, 0x2e, 0x74, 0xa7, 0x4b, 0xee, 0x4c, 0x45, 0x0c, 0x24, 0x8d, 0x7b, 0xc9, 0x16, 0x22,
+	0xf1, 0x0d, 0xf2, 0xb6, 0x1e, 0xa4, 0xf4, 0x0f, 0x4a, 0x99, 0x8b, 0x9d, 0xc3, 0x0c, 0x75, 0x3a,
+	0x3e, 0x4d, 0x36, 0x62, 0x19, 0x2c, 0x91, 0xb7, 0xdd, 0x09, 0xff, 0x87, 0x90, 0x1b, 0x8d, 0xa6,
+	0xd6, 0xc6, 0x8e, 0x45, 0x1e, 0x52, 0xfd, 0x46, 0xd7, 0x5d, 0x19, 0x99, 0x19, 0x57, 0x3b, 0xf1,
+	0x71, 0x85, 0x7e, 0x25, 0x33, 0xa8, 0xbb, 0xd8, 0xde, 0x70, 0xf1, 0x73, 0xfb, 0xb0, 0xe5, 0xb5,
+	0x6f, 0x7f, 0xa0, 0x80, 0x8b, 0x2c, 0x9c, 0xac, 0x0a, 0x10, 0x39, 0xa4, 0x19, 0x88, 0xf0, 0x27,
+	0x19, 0x0b, 0x9a, 0xb8, 0xd1, 0xf5, 0x47, 0xe5, 0xf6, 0x74, 0xbd, 0x91, 0xc9, 0x94, 0x64, 0xf0,
+	0xf2, 0x2a, 0xa3, 0x6a, 0x32, 0x1f, 0x87, 0x09, 0x9f, 0x45, 0x35, 0x6e, 0x64, 0xb8, 0x91, 0xe1,
+	0x46, 0x9a, 0x3b, 0x36, 0x1f, 0xb4, 0xb7, 0x7f, 0x07, 0x00, 0xd9, 0xf7, 0xa9, 0xff, 0xee, 0x04,
+	0x00, 0x00,
 }
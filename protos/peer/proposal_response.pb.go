@@ -40,17 +40,22 @@ type ProposalResponse struct {
 	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
 	// The endorsement of the proposal, basically
 	// the endorser's signature over the payload
-	Endorsement          *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Endorsement *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
+	// The heights of the ledgers of the other channels when the cross-channel
+	// reads of the chaincode action in the payload were performed, in the
+	// order of the reads. They are not covered by the endorsement, as the
+	// endorsers of a proposal may be at different heights.
+	CrossChannelReadHeights []uint64 `protobuf:"varint,7,rep,packed,name=cross_channel_read_heights,json=crossChannelReadHeights,proto3" json:"cross_channel_read_heights,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *ProposalResponse) Reset()         { *m = ProposalResponse{} }
 func (m *ProposalResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponse) ProtoMessage()    {}
 func (*ProposalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{0}
+	return fileDescriptor_proposal_response_c6b3e1489cc82067, []int{0}
 }
 func (m *ProposalResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponse.Unmarshal(m, b)
@@ -105,6 +110,13 @@ func (m *ProposalResponse) GetEndorsement() *Endorsement {
 	return nil
 }
 
+func (m *ProposalResponse) GetCrossChannelReadHeights() []uint64 {
+	if m != nil {
+		return m.CrossChannelReadHeights
+	}
+	return nil
+}
+
 // A response with a representation similar to an HTTP response that can
 // be used within another message.
 type Response struct {
@@ -123,7 +135,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{1}
+	return fileDescriptor_proposal_response_c6b3e1489cc82067, []int{1}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -201,7 +213,7 @@ func (m *ProposalResponsePayload) Reset()         { *m = ProposalResponsePayload
 func (m *ProposalResponsePayload) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponsePayload) ProtoMessage()    {}
 func (*ProposalResponsePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{2}
+	return fileDescriptor_proposal_response_c6b3e1489cc82067, []int{2}
 }
 func (m *ProposalResponsePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponsePayload.Unmarshal(m, b)
@@ -259,7 +271,7 @@ func (m *Endorsement) Reset()         { *m = Endorsement{} }
 func (m *Endorsement) String() string { return proto.CompactTextString(m) }
 func (*Endorsement) ProtoMessage()    {}
 func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{3}
+	return fileDescriptor_proposal_response_c6b3e1489cc82067, []int{3}
 }
 func (m *Endorsement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
@@ -301,32 +313,35 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_22a755721b685f40)
-}
-
-var fileDescriptor_proposal_response_22a755721b685f40 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0xfe, 0xff, 0xcd, 0x2d, 0x9b, 0x30, 0x2a, 0x68, 0x19, 0x03, 0x47, 0x7d, 0x99,
-	0x20, 0x29, 0x28, 0x82, 0xcf, 0x03, 0xd1, 0xc7, 0x11, 0xc4, 0x07, 0x11, 0x24, 0xdd, 0xee, 0xd2,
-	0x62, 0xdb, 0x84, 0xdc, 0x54, 0xdc, 0x07, 0xf6, 0x7b, 0x48, 0xd3, 0xa6, 0xab, 0xe2, 0xd3, 0x38,
-	0x77, 0x27, 0xbf, 0x7b, 0xcf, 0xed, 0x25, 0x73, 0x05, 0xa0, 0x23, 0xa5, 0xa5, 0x92, 0xc8, 0xb3,
-	0x37, 0x0d, 0xa8, 0x64, 0x81, 0x40, 0x95, 0x96, 0x46, 0xfa, 0x03, 0xfb, 0x83, 0xb3, 0x73, 0x21,
-	0xa5, 0xc8, 0x20, 0xb2, 0x32, 0x2e, 0x77, 0x91, 0x49, 0x73, 0x40, 0xc3, 0x73, 0x55, 0x1b, 0xc3,
-	0x2f, 0x8f, 0x4c, 0xd7, 0x0d, 0x84, 0x35, 0x0c, 0x3f, 0x20, 0x47, 0x1f, 0xa0, 0x31, 0x95, 0x45,
-	0xe0, 0x2d, 0xbc, 0x65, 0x9f, 0x39, 0xe9, 0xdf, 0x91, 0x51, 0x4b, 0x08, 0x7a, 0x0b, 0x6f, 0x39,
-	0xbe, 0x9e, 0xd1, 0xba, 0x07, 0x75, 0x3d, 0xe8, 0x93, 0x73, 0xb0, 0x83, 0xd9, 0xbf, 0x22, 0x43,
-	0x37, 0x63, 0xf0, 0xdf, 0x3e, 0x9c, 0xd6, 0x2f, 0x90, 0xba, 0xbe, 0xac, 0x75, 0x54, 0x13, 0x28,
-	0xbe, 0xcf, 0x24, 0xdf, 0x06, 0xfd, 0x85, 0xb7, 0x9c, 0x30, 0x27, 0xfd, 0x5b, 0x32, 0x86, 0x62,
-	0x2b, 0x35, 0x42, 0x0e, 0x85, 0x09, 0x06, 0x16, 0x75, 0xe2, 0x50, 0xf7, 0x87, 0xbf, 0x58, 0xd7,
-	0x17, 0x3e, 0x93, 0x61, 0x1b, 0xef, 0x94, 0x0c, 0xd0, 0x70, 0x53, 0x62, 0x93, 0xae, 0x51, 0x55,
-	0xd3, 0x1c, 0x10, 0xb9, 0x00, 0x1b, 0x6d, 0xc4, 0x9c, 0xec, 0x8e, 0xf3, 0xef, 0xc7, 0x38, 0xe1,
-	0x2b, 0x39, 0xfb, 0xbd, 0xbe, 0x75, 0x33, 0xe9, 0x05, 0x39, 0x6e, 0x3f, 0x4f, 0xc2, 0x31, 0xb1,
-	0xdd, 0x26, 0x6c, 0xe2, 0x8a, 0x8f, 0x1c, 0x13, 0x7f, 0x4e, 0x46, 0xf0, 0x69, 0xa0, 0xb0, 0xcb,
-	0xee, 0x59, 0xc3, 0xa1, 0x10, 0x3e, 0x90, 0x71, 0x27, 0x91, 0x3f, 0x23, 0xc3, 0x26, 0x93, 0x6e,
-	0x60, 0xad, 0xae, 0x40, 0x98, 0x8a, 0x82, 0x9b, 0x52, 0x83, 0x03, 0xb5, 0x85, 0x55, 0x42, 0x42,
-	0xa9, 0x05, 0x4d, 0xf6, 0x0a, 0x74, 0x06, 0x5b, 0x01, 0x9a, 0xee, 0x78, 0xac, 0xd3, 0x8d, 0x5b,
-	0x5c, 0x75, 0x4d, 0xab, 0x3f, 0xa2, 0x6c, 0xde, 0xb9, 0x80, 0x97, 0x4b, 0x91, 0x9a, 0xa4, 0x8c,
-	0xe9, 0x46, 0xe6, 0x51, 0x87, 0x11, 0xd5, 0x8c, 0xfa, 0xba, 0x30, 0xaa, 0x18, 0x71, 0x7d, 0x79,
-	0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x52, 0x0b, 0x35, 0xa0, 0x02, 0x00, 0x00,
+	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_c6b3e1489cc82067)
+}
+
+var fileDescriptor_proposal_response_c6b3e1489cc82067 = []byte{
+	// 405 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xd1, 0x8b, 0xd4, 0x30,
+	0x10, 0xc6, 0xd9, 0xbd, 0xbb, 0xbd, 0xdd, 0xec, 0x0a, 0x47, 0x04, 0xaf, 0x2c, 0x07, 0x2e, 0xf5,
+	0x65, 0x05, 0x49, 0x41, 0x11, 0x04, 0xdf, 0x4e, 0xc4, 0x7b, 0x3c, 0x82, 0xf8, 0x20, 0xc2, 0x92,
+	0x6d, 0xe7, 0x92, 0x62, 0x9b, 0x84, 0x4c, 0x2a, 0xde, 0xbf, 0xe3, 0x5f, 0x2a, 0x4d, 0x9a, 0x6e,
+	0x95, 0x7b, 0x2a, 0xdf, 0xf4, 0xcb, 0x6f, 0x66, 0xbe, 0x84, 0xdc, 0x58, 0x00, 0x57, 0x58, 0x67,
+	0xac, 0x41, 0xd1, 0x1c, 0x1c, 0xa0, 0x35, 0x1a, 0x81, 0x59, 0x67, 0xbc, 0xa1, 0x8b, 0xf0, 0xc1,
+	0xed, 0x4b, 0x69, 0x8c, 0x6c, 0xa0, 0x08, 0xf2, 0xd8, 0x3d, 0x14, 0xbe, 0x6e, 0x01, 0xbd, 0x68,
+	0x6d, 0x34, 0xe6, 0x7f, 0xe6, 0xe4, 0xea, 0x7e, 0x80, 0xf0, 0x81, 0x41, 0x33, 0x72, 0xf9, 0x0b,
+	0x1c, 0xd6, 0x46, 0x67, 0xb3, 0xdd, 0x6c, 0x7f, 0xc1, 0x93, 0xa4, 0x1f, 0xc8, 0x6a, 0x24, 0x64,
+	0xf3, 0xdd, 0x6c, 0xbf, 0x7e, 0xbb, 0x65, 0xb1, 0x07, 0x4b, 0x3d, 0xd8, 0xd7, 0xe4, 0xe0, 0x27,
+	0x33, 0x7d, 0x43, 0x96, 0x69, 0xc6, 0xec, 0x3c, 0x1c, 0xbc, 0x8a, 0x27, 0x90, 0xa5, 0xbe, 0x7c,
+	0xe9, 0x26, 0x13, 0x58, 0xf1, 0xd8, 0x18, 0x51, 0x65, 0x17, 0xbb, 0xd9, 0x7e, 0xc3, 0x93, 0xa4,
+	0xef, 0xc9, 0x1a, 0x74, 0x65, 0x1c, 0x42, 0x0b, 0xda, 0x67, 0x8b, 0x80, 0x7a, 0x9e, 0x50, 0x9f,
+	0x4f, 0xbf, 0xf8, 0xd4, 0x47, 0x3f, 0x92, 0x6d, 0xe9, 0x0c, 0xe2, 0xa1, 0x54, 0x42, 0x6b, 0xe8,
+	0x03, 0x13, 0xd5, 0x41, 0x41, 0x2d, 0x95, 0xc7, 0xec, 0x72, 0x77, 0xb6, 0x3f, 0xe7, 0xd7, 0xc1,
+	0xf1, 0x29, 0x1a, 0x38, 0x88, 0xea, 0x2e, 0xfe, 0xce, 0xbf, 0x91, 0xe5, 0x98, 0xcd, 0x0b, 0xb2,
+	0x40, 0x2f, 0x7c, 0x87, 0x43, 0x34, 0x83, 0xea, 0x27, 0x6e, 0x01, 0x51, 0x48, 0x08, 0xb9, 0xac,
+	0x78, 0x92, 0xd3, 0x5d, 0xce, 0xfe, 0xd9, 0x25, 0xff, 0x41, 0xae, 0xff, 0xcf, 0xfe, 0x7e, 0x58,
+	0xf3, 0x15, 0x79, 0x36, 0xde, 0xad, 0x12, 0xa8, 0x42, 0xb7, 0x0d, 0xdf, 0xa4, 0xe2, 0x9d, 0x40,
+	0x45, 0x6f, 0xc8, 0x0a, 0x7e, 0x7b, 0xd0, 0xe1, 0xa6, 0xe6, 0xc1, 0x70, 0x2a, 0xe4, 0x5f, 0xc8,
+	0x7a, 0x12, 0x07, 0xdd, 0x92, 0xe5, 0x10, 0x88, 0x1b, 0x60, 0xa3, 0xee, 0x41, 0x58, 0x4b, 0x2d,
+	0x7c, 0xe7, 0x20, 0x81, 0xc6, 0xc2, 0xad, 0x22, 0xb9, 0x71, 0x92, 0xa9, 0x47, 0x0b, 0xae, 0x81,
+	0x4a, 0x82, 0x63, 0x0f, 0xe2, 0xe8, 0xea, 0x32, 0xa5, 0x6e, 0x01, 0xdc, 0xed, 0x13, 0xab, 0x94,
+	0x3f, 0x85, 0x84, 0xef, 0xaf, 0x65, 0xed, 0x55, 0x77, 0x64, 0xa5, 0x69, 0x8b, 0x09, 0xa3, 0x88,
+	0x8c, 0xf8, 0x34, 0xb1, 0xe8, 0x19, 0xc7, 0xf8, 0x6c, 0xdf, 0xfd, 0x1d, 0x00, 0x7d, 0x51, 0xdf,
+	0x83, 0xdd, 0x02, 0x00, 0x00,
 }
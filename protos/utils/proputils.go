@@ -182,6 +182,23 @@ func GetChaincodeEvents(eBytes []byte) (*peer.ChaincodeEvent, error) {
 	return chaincodeEvent, errors.Wrap(err, "error unmarshaling ChaicnodeEvent")
 }
 
+// GetChaincodeActionEvents gets all the events of a chaincode action, in the
+// order they were set. Actions carrying a single event only set the events
+// field, which is then returned alone.
+func GetChaincodeActionEvents(action *peer.ChaincodeAction) ([]*peer.ChaincodeEvent, error) {
+	if len(action.ChaincodeEvents) != 0 {
+		return action.ChaincodeEvents, nil
+	}
+	if len(action.Events) == 0 {
+		return nil, nil
+	}
+	event, err := GetChaincodeEvents(action.Events)
+	if err != nil {
+		return nil, err
+	}
+	return []*peer.ChaincodeEvent{event}, nil
+}
+
 // GetProposalResponsePayload gets the proposal response payload
 func GetProposalResponsePayload(prpBytes []byte) (*peer.ProposalResponsePayload, error) {
 	prp := &peer.ProposalResponsePayload{}
@@ -339,6 +356,12 @@ func GetBytesProposalResponsePayload(hash []byte, response *peer.Response, resul
 		Response:    response,
 		ChaincodeId: ccid,
 	}
+	return GetBytesProposalResponsePayloadForAction(hash, cAct)
+}
+
+// GetBytesProposalResponsePayloadForAction gets the proposal response payload
+// carrying the given chaincode action
+func GetBytesProposalResponsePayloadForAction(hash []byte, cAct *peer.ChaincodeAction) ([]byte, error) {
 	cActBytes, err := proto.Marshal(cAct)
 	if err != nil {
 		return nil, errors.Wrap(err, "error marshaling ChaincodeAction")
@@ -489,6 +489,26 @@ func TestProposalResponse(t *testing.T) {
 	}
 }
 
+func TestGetChaincodeActionEvents(t *testing.T) {
+	events, err := utils.GetChaincodeActionEvents(&pb.ChaincodeAction{})
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+
+	event := &pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"}
+	events, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{Events: utils.MarshalOrPanic(event)})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.True(t, proto.Equal(event, events[0]))
+
+	multiple := []*pb.ChaincodeEvent{{EventName: "first"}, event}
+	events, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{Events: utils.MarshalOrPanic(event), ChaincodeEvents: multiple})
+	assert.NoError(t, err)
+	assert.Equal(t, multiple, events)
+
+	_, err = utils.GetChaincodeActionEvents(&pb.ChaincodeAction{Events: []byte("garbage")})
+	assert.Error(t, err)
+}
+
 func TestEnvelope(t *testing.T) {
 	// create a proposal from a ChaincodeInvocationSpec
 	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), signerSerialized)
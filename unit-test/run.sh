@@ -25,6 +25,7 @@ plugin_packages=(
 # packages which need to be tested with build tag pkcs11
 pkcs11_packages=(
     "github.com/hyperledger/fabric/bccsp/..."
+    "github.com/hyperledger/fabric/orderer/common/multichannel"
 )
 
 # obtain packages changed since some git refspec